@@ -4,6 +4,7 @@ import (
 	"call-service/internal/repository"
 	"call-service/internal/sfu"
 	wsTransport "call-service/internal/transport/websocket"
+	userserviceclient "call-service/internal/user-service-client"
 	"log"
 	"net/http"
 	"os"
@@ -23,8 +24,20 @@ func main() {
 	redisRepo := repository.NewRedisRepo(redisAddr, "")
 	log.Println("Connected to Redis at", redisAddr)
 
+	// user-service нужен для проверки блокировок при входе в звонок
+	var blocks wsTransport.BlockChecker
+	if userAddr := os.Getenv("USER_SERVICE_ADDR"); userAddr != "" {
+		userClient, err := userserviceclient.New(userAddr)
+		if err != nil {
+			log.Fatalf("Failed to connect to user-service: %v", err)
+		}
+		blocks = userClient
+	} else {
+		log.Println("USER_SERVICE_ADDR is not set, blocks are not checked")
+	}
+
 	roomManager := sfu.NewRoomManager(redisRepo)
-	handler := wsTransport.NewHandler(roomManager)
+	handler := wsTransport.NewHandler(roomManager, blocks)
 
 	http.HandleFunc("/ws", handler.Handle)
 
@@ -30,3 +30,12 @@ func (m *RoomManager) GetOrCreateRoom(roomID string) *Room {
 	m.Rooms[roomID] = newRoom
 	return newRoom
 }
+
+// GetRoom возвращает существующую комнату, не создавая новую
+func (m *RoomManager) GetRoom(roomID string) (*Room, bool) {
+	m.Lock.RLock()
+	defer m.Lock.RUnlock()
+
+	room, exists := m.Rooms[roomID]
+	return room, exists
+}
@@ -32,6 +32,18 @@ func (r *Room) AddPeer(peer *Peer) {
 	log.Printf("[Room %s] Peer %s joined", r.ID, peer.ID)
 }
 
+// PeerIDs возвращает ID участников звонка
+func (r *Room) PeerIDs() []string {
+	r.Lock.RLock()
+	defer r.Lock.RUnlock()
+
+	ids := make([]string, 0, len(r.Peers))
+	for id := range r.Peers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func (r *Room) RemovePeer(peerID string) {
 	r.Lock.Lock()
 	peer, exists := r.Peers[peerID]
@@ -119,6 +119,28 @@ func TestRoom_JoinAndSignal(t *testing.T) {
 	}
 }
 
+func TestRoom_PeerIDs(t *testing.T) {
+	room := NewRoom("test-room-ids")
+	if len(room.PeerIDs()) != 0 {
+		t.Error("New room should have no peers")
+	}
+
+	room.Peers["user-1"] = &Peer{ID: "user-1"}
+	room.Peers["user-2"] = &Peer{ID: "user-2"}
+
+	ids := room.PeerIDs()
+	if len(ids) != 2 {
+		t.Fatalf("Expected 2 peers, got %d", len(ids))
+	}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		seen[id] = true
+	}
+	if !seen["user-1"] || !seen["user-2"] {
+		t.Errorf("Unexpected peer IDs: %v", ids)
+	}
+}
+
 func TestRoom_RemovePeer_And_Notify(t *testing.T) {
 	// Тест сценария: User 1 и User 2 в комнате. User 1 уходит -> User 2 получает уведомление.
 
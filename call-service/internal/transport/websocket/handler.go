@@ -12,14 +12,21 @@ import (
 	"github.com/pion/webrtc/v3"
 )
 
+// BlockChecker сообщает, кто из users заблокировал userID
+type BlockChecker interface {
+	BlockedBy(ctx context.Context, userID string, users []string) ([]string, error)
+}
+
 type Handler struct {
 	manager  *sfu.RoomManager
+	blocks   BlockChecker // nil — блокировки не проверяются
 	upgrader websocket.Upgrader
 }
 
-func NewHandler(manager *sfu.RoomManager) *Handler {
+func NewHandler(manager *sfu.RoomManager, blocks BlockChecker) *Handler {
 	return &Handler{
 		manager:  manager,
+		blocks:   blocks,
 		upgrader: websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
 	}
 }
@@ -67,6 +74,13 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
+			if !h.canJoin(ctx, userID, payload.RoomID) {
+				// Причину не раскрываем: заблокированный не должен узнать о блокировке
+				errPayload, _ := json.Marshal(ErrorPayload{Message: "cannot join call"})
+				conn.WriteJSON(SignalingMessage{Type: TypeError, Payload: errPayload})
+				return
+			}
+
 			h.manager.Repo.SetUserInCall(ctx, userID, payload.RoomID)
 
 			currentPeer = &sfu.Peer{ID: userID, Conn: conn}
@@ -119,3 +133,20 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		h.manager.Repo.RemoveUserFromCall(context.Background(), currentPeer.ID)
 	}
 }
+
+// canJoin запрещает вход в звонок, где есть заблокировавший userID
+func (h *Handler) canJoin(ctx context.Context, userID, roomID string) bool {
+	if h.blocks == nil {
+		return true
+	}
+	room, exists := h.manager.GetRoom(roomID)
+	if !exists {
+		return true
+	}
+	blockedBy, err := h.blocks.BlockedBy(ctx, userID, room.PeerIDs())
+	if err != nil {
+		log.Printf("Block check failed for %s: %v", userID, err)
+		return false
+	}
+	return len(blockedBy) == 0
+}
@@ -0,0 +1,68 @@
+package websocket
+
+import (
+	"call-service/internal/sfu"
+	"context"
+	"errors"
+	"testing"
+)
+
+// Тестовая проверка блокировок: blockers[userID] — кто заблокировал userID
+type testBlocks struct {
+	blockers map[string][]string
+	err      error
+}
+
+func (b *testBlocks) BlockedBy(ctx context.Context, userID string, users []string) ([]string, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	var result []string
+	for _, blocker := range b.blockers[userID] {
+		for _, user := range users {
+			if user == blocker {
+				result = append(result, blocker)
+			}
+		}
+	}
+	return result, nil
+}
+
+func newTestHandler(blocks BlockChecker) *Handler {
+	manager := sfu.NewRoomManager(nil)
+	room := manager.GetOrCreateRoom("room-1")
+	room.Peers["host"] = &sfu.Peer{ID: "host"}
+	return NewHandler(manager, blocks)
+}
+
+func TestHandler_CanJoin(t *testing.T) {
+	h := newTestHandler(&testBlocks{blockers: map[string][]string{"user-1": {"host"}}})
+	ctx := context.Background()
+
+	if h.canJoin(ctx, "user-1", "room-1") {
+		t.Error("User blocked by a peer must not join")
+	}
+	if !h.canJoin(ctx, "user-2", "room-1") {
+		t.Error("Other users should join")
+	}
+	// В новой комнате проверять некого
+	if !h.canJoin(ctx, "user-1", "room-2") {
+		t.Error("Empty room should be joinable")
+	}
+}
+
+func TestHandler_CanJoin_CheckFailed(t *testing.T) {
+	h := newTestHandler(&testBlocks{err: errors.New("user-service unavailable")})
+
+	if h.canJoin(context.Background(), "user-2", "room-1") {
+		t.Error("Join should be refused when blocks cannot be checked")
+	}
+}
+
+func TestHandler_CanJoin_NoChecker(t *testing.T) {
+	h := newTestHandler(nil)
+
+	if !h.canJoin(context.Background(), "user-1", "room-1") {
+		t.Error("Without checker everyone should join")
+	}
+}
@@ -8,6 +8,7 @@ const (
 	TypeAnswer    = "answer"
 	TypeCandidate = "candidate"
 	TypeUserLeft  = "user_left"
+	TypeError     = "error"
 )
 
 // Обертка для сообщений сигнализации WebRTC
@@ -20,3 +21,8 @@ type SignalingMessage struct {
 type JoinPayload struct {
 	RoomID string `json:"room_id"`
 }
+
+// Ошибка, после которой сервер закрывает соединение
+type ErrorPayload struct {
+	Message string `json:"message"`
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	_ "expvar" // /debug/vars на http.DefaultServeMux
 	"net"
+	"net/http"
+	"time"
 
 	_ "github.com/lib/pq"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,7 +15,10 @@ import (
 
 	"main/internal/config"
 	"main/internal/logger"
+	mediaserviceclient "main/internal/media-service-client"
+	"main/internal/moderation"
 	mongorepo "main/internal/repository/mongo"
+	redisrepo "main/internal/repository/redis"
 	userserviceclient "main/internal/user-service-client"
 	chatpb "main/pkg/api"
 
@@ -40,13 +46,82 @@ func main() {
 	// Kafka
 	kp := kafka.NewProducer([]string{config.KafkaBroker}, config.KafkaTopic)
 
+	// Redis (эфемерное состояние: индикаторы набора)
+	redisClient := redisrepo.New(config.RedisAddr)
+
 	// Репозитории
 	chatRepo := mongorepo.NewChatRepo(mongoDB, log)
 	messageRepo := mongorepo.NewMessageRepo(mongoDB)
+	mentionRepo := mongorepo.NewMentionRepo(mongoDB)
+	readStateRepo := mongorepo.NewReadStateRepo(mongoDB)
+	chatStateRepo := mongorepo.NewChatStateRepo(mongoDB)
+	scheduledRepo := mongorepo.NewScheduledMessageRepo(mongoDB)
+	subscriptionRepo := mongorepo.NewSubscriptionRepo(mongoDB)
+	inviteRepo := mongorepo.NewInviteRepo(mongoDB)
+	draftRepo := mongorepo.NewDraftRepo(mongoDB)
+	folderRepo := mongorepo.NewChatFolderRepo(mongoDB)
+	updateLogRepo := mongorepo.NewUpdateLogRepo(mongoDB)
+	outboxRepo := mongorepo.NewOutboxRepo(mongoDB)
+	retentionRepo := mongorepo.NewRetentionRepo(mongoDB)
 	//подключение к клиенту
 	userClient := userserviceclient.NewUserClient(config.UserServiceAddr, log)
+	mediaClient := mediaserviceclient.NewMediaClient(config.MediaServiceAddr, config.MediaDownloadURL)
+	// Фильтры модерации: слова → ссылки → флуд
+	filters, err := moderationFilters(config, redisClient)
+	if err != nil {
+		log.Fatal().Msgf("invalid moderation config: %s", err)
+	}
 	// Сервис
-	svc := service.NewChatService(chatRepo, messageRepo, kp, userClient)
+	svc := service.NewChatService(chatRepo, messageRepo, kp, userClient,
+		service.WithEditWindow(config.MessageEditWindow),
+		service.WithArchiveKey([]byte(config.ArchiveSigningKey)),
+		service.WithMentions(mentionRepo),
+		service.WithReadStates(readStateRepo),
+		service.WithChatStates(chatStateRepo),
+		service.WithScheduled(scheduledRepo),
+		service.WithChannels(subscriptionRepo),
+		service.WithViewDedup(redisClient),
+		service.WithInvites(inviteRepo),
+		service.WithDrafts(draftRepo),
+		service.WithFolders(folderRepo),
+		service.WithUpdateLog(updateLogRepo),
+		service.WithStreamPoll(config.StreamPollInterval),
+		service.WithTyping(redisClient),
+		service.WithSendLimits(redisClient, config.SendRateLimit, config.SendRateWindow),
+		service.WithMedia(mediaClient),
+		service.WithModeration(moderation.NewPipeline(filters...), mongorepo.NewModerationRepo(mongoDB)),
+		service.WithOutbox(outboxRepo, mongorepo.NewTransactor(client)),
+		service.WithRetention(retentionRepo,
+			time.Duration(config.RetentionDeletedDays)*24*time.Hour, config.RetentionMaxMessagesPerChat),
+	)
+
+	// Фоновые задачи: отложенные сообщения, автоудаление, очистка журналов обновлений и relay outbox (работают на каждой реплике)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if config.SchedulerInterval > 0 {
+		go svc.RunScheduler(jobsCtx, config.SchedulerInterval, log)
+	}
+	if config.PurgeInterval > 0 {
+		go svc.RunPurger(jobsCtx, config.PurgeInterval, log)
+	}
+	if config.OutboxInterval > 0 {
+		go svc.RunOutboxRelay(jobsCtx, config.OutboxInterval, log)
+	}
+	// Статусы набора со всех реплик раздаются потокам SubscribeChats этой реплики
+	go svc.RunTypingRelay(jobsCtx, log)
+	// Очистка истории захватывается одной репликой и продолжает прерванный проход
+	if config.RetentionInterval > 0 {
+		go svc.RunRetention(jobsCtx, config.RetentionInterval, log)
+	}
+
+	// Метрики фоновых задач (expvar)
+	if config.MetricsAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(config.MetricsAddr, nil); err != nil {
+				log.Error().Err(err).Msg("metrics server stopped")
+			}
+		}()
+	}
 
 	// gRPC сервер
 	lis, err := net.Listen("tcp", config.ChatServicePort)
@@ -67,3 +142,30 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to serve")
 	}
 }
+
+// moderationFilters собирает цепочку фильтров из настроек; пустой список слов или доменов отключает фильтр
+func moderationFilters(cfg *config.Config, counter moderation.RepeatCounter) ([]moderation.Filter, error) {
+	var filters []moderation.Filter
+	if len(cfg.ModerationBannedWords) > 0 {
+		action, err := moderation.ParseAction(cfg.ModerationBannedAction)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, moderation.NewBannedWords(cfg.ModerationBannedWords, action))
+	}
+	if len(cfg.ModerationBlockedDomains) > 0 {
+		action, err := moderation.ParseAction(cfg.ModerationLinkAction)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, moderation.NewLinkBlocklist(cfg.ModerationBlockedDomains, action))
+	}
+	if cfg.FloodMaxRepeats > 0 && cfg.FloodWindow > 0 {
+		action, err := moderation.ParseAction(cfg.FloodAction)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, moderation.NewFlood(counter, cfg.FloodMaxRepeats, cfg.FloodWindow, action))
+	}
+	return filters, nil
+}
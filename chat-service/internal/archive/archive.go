@@ -0,0 +1,246 @@
+// Package archive — формат выгрузки чата (ExportChat) и его чтение для ImportChat.
+// Архив пишется потоком: шапка с участниками, затем сообщения по одному, поэтому выгрузка
+// большого чата не держит историю в памяти.
+package archive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// Version — версия формата JSON-архива
+const Version = 1
+
+// Форматы выгрузки
+const (
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+var ErrUnsupportedFormat = errors.New("archive: unsupported format")
+
+// Header — всё, кроме сообщений
+type Header struct {
+	Version    int      `json:"version"`
+	ExportedAt int64    `json:"exported_at"`
+	ExportedBy string   `json:"exported_by"`
+	Chat       Chat     `json:"chat"`
+	Members    []Member `json:"members"`
+}
+
+// Archive — JSON-архив целиком, как его читает ImportChat.
+// Signature — HMAC-SHA256 шапки и сообщений ключом сервиса; у архива, выгруженного без ключа, её нет
+type Archive struct {
+	Header
+	Messages  []Message `json:"messages"`
+	Signature string    `json:"signature,omitempty"`
+}
+
+type Chat struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Title     string `json:"title"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Member — участник на момент выгрузки; name — имя из user-service (пусто, если не нашёлся)
+type Member struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name,omitempty"`
+	Role   string `json:"role"`
+}
+
+type Message struct {
+	ID         string   `json:"id"`
+	Seq        int64    `json:"seq"`
+	AuthorID   string   `json:"author_id"`
+	AuthorName string   `json:"author_name,omitempty"`
+	Type       string   `json:"type"`
+	Text       string   `json:"text,omitempty"`
+	Media      []Media  `json:"media,omitempty"`
+	Entities   []Entity `json:"entities,omitempty"`
+	System     *System  `json:"system,omitempty"`
+	Poll       *Poll    `json:"poll,omitempty"`
+	CreatedAt  int64    `json:"created_at"`
+	UpdatedAt  int64    `json:"updated_at,omitempty"`
+	Edited     bool     `json:"edited,omitempty"`
+}
+
+// Media — ссылка на файл в media-service; сами файлы в архив не входят
+type Media struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	Mime      string `json:"mime"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+type Entity struct {
+	Type   string `json:"type"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+type System struct {
+	Action    string `json:"action"`
+	ActorID   string `json:"actor_id"`
+	MessageID string `json:"message_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+}
+
+// Poll — опрос с итогами, без списка проголосовавших
+type Poll struct {
+	Question       string       `json:"question"`
+	Options        []PollOption `json:"options"`
+	MultipleChoice bool         `json:"multiple_choice"`
+	Anonymous      bool         `json:"anonymous"`
+	Closed         bool         `json:"closed"`
+	TotalVoters    int64        `json:"total_voters"`
+}
+
+type PollOption struct {
+	Text  string `json:"text"`
+	Votes int64  `json:"votes"`
+}
+
+// Writer пишет архив потоком: Begin — один раз, Message — для каждого сообщения по возрастанию seq, End — в конце
+type Writer interface {
+	Begin(h Header) error
+	Message(m Message) error
+	End() error
+}
+
+// NewWriter возвращает писателя формата json (по умолчанию) или html.
+// С ключом JSON-архив подписывается; html не подписывается — его не импортируют
+func NewWriter(format string, w io.Writer, key []byte) (Writer, error) {
+	switch format {
+	case "", FormatJSON:
+		jw := &jsonWriter{w: w}
+		if len(key) > 0 {
+			jw.mac = hmac.New(sha256.New, key)
+		}
+		return jw, nil
+	case FormatHTML:
+		return newHTMLWriter(w), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+// ContentType — MIME выгрузки
+func ContentType(format string) string {
+	if format == FormatHTML {
+		return "text/html; charset=utf-8"
+	}
+	return "application/json"
+}
+
+// Read разбирает JSON-архив, не читая больше maxBytes
+func Read(r io.Reader, maxBytes int64) (Archive, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return Archive{}, err
+	}
+	if int64(len(data)) > maxBytes {
+		return Archive{}, fmt.Errorf("archive: larger than %d bytes", maxBytes)
+	}
+	var a Archive
+	if err := json.Unmarshal(data, &a); err != nil {
+		return Archive{}, fmt.Errorf("archive: %w", err)
+	}
+	if a.Version != Version {
+		return Archive{}, fmt.Errorf("archive: unsupported version %d", a.Version)
+	}
+	return a, nil
+}
+
+// Verify — архив подписан ключом key и не менялся после выгрузки
+func (a Archive) Verify(key []byte) bool {
+	if len(key) == 0 || a.Signature == "" {
+		return false
+	}
+	sig, err := hex.DecodeString(a.Signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	head, err := marshalHeader(a.Header)
+	if err != nil {
+		return false
+	}
+	mac.Write(head)
+	for _, m := range a.Messages {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return false
+		}
+		mac.Write(data)
+	}
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// marshalHeader — шапка в том виде, в каком её пишет и подписывает jsonWriter
+func marshalHeader(h Header) ([]byte, error) {
+	if h.Members == nil {
+		h.Members = []Member{}
+	}
+	return json.Marshal(h)
+}
+
+// jsonWriter пишет шапку и сообщения одним JSON-объектом, не собирая его в памяти.
+// Подпись считается по тем же байтам шапки и сообщений и дописывается в конце
+type jsonWriter struct {
+	w     io.Writer
+	count int
+	mac   hash.Hash
+}
+
+func (j *jsonWriter) Begin(h Header) error {
+	head, err := marshalHeader(h)
+	if err != nil {
+		return err
+	}
+	if j.mac != nil {
+		j.mac.Write(head)
+	}
+	// Объект шапки без закрывающей скобки продолжается массивом сообщений
+	if _, err := j.w.Write(head[:len(head)-1]); err != nil {
+		return err
+	}
+	_, err = io.WriteString(j.w, `,"messages":[`)
+	return err
+}
+
+func (j *jsonWriter) Message(m Message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if j.count > 0 {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.count++
+	if j.mac != nil {
+		j.mac.Write(data)
+	}
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonWriter) End() error {
+	if j.mac == nil {
+		_, err := io.WriteString(j.w, "]}\n")
+		return err
+	}
+	_, err := fmt.Fprintf(j.w, `],"signature":%q}`+"\n", hex.EncodeToString(j.mac.Sum(nil)))
+	return err
+}
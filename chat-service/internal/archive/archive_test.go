@@ -0,0 +1,110 @@
+package archive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAll(t *testing.T, format string, h Header, msgs ...Message) string {
+	t.Helper()
+	return writeSigned(t, format, nil, h, msgs...)
+}
+
+func writeSigned(t *testing.T, format string, key []byte, h Header, msgs ...Message) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, key)
+	require.NoError(t, err)
+	require.NoError(t, w.Begin(h))
+	for _, m := range msgs {
+		require.NoError(t, w.Message(m))
+	}
+	require.NoError(t, w.End())
+	return buf.String()
+}
+
+func TestJSONWriter_RoundTrip(t *testing.T) {
+	h := Header{Version: Version, ExportedBy: "user1", Chat: Chat{ID: "chat1", Title: "Команда"}}
+	out := writeAll(t, FormatJSON, h,
+		Message{ID: "m1", Seq: 1, AuthorID: "user1", Type: "text", Text: "привет"},
+		Message{ID: "m2", Seq: 2, AuthorID: "user2", Type: "poll", Poll: &Poll{Question: "?", Options: []PollOption{{Text: "да", Votes: 1}}}},
+	)
+
+	a, err := Read(strings.NewReader(out), 1<<20)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Команда", a.Chat.Title)
+	assert.Equal(t, []Member{}, a.Members)
+	assert.Len(t, a.Messages, 2)
+	assert.Equal(t, "привет", a.Messages[0].Text)
+	assert.Equal(t, int64(1), a.Messages[1].Poll.Options[0].Votes)
+}
+
+func TestJSONWriter_EmptyHistory(t *testing.T) {
+	out := writeAll(t, "", Header{Version: Version})
+
+	a, err := Read(strings.NewReader(out), 1<<20)
+
+	require.NoError(t, err)
+	assert.Empty(t, a.Messages)
+}
+
+func TestJSONWriter_Signature(t *testing.T) {
+	key := []byte("secret")
+	h := Header{Version: Version, ExportedBy: "user1", Chat: Chat{ID: "chat1"}, Members: []Member{{UserID: "user2", Role: "member"}}}
+	out := writeSigned(t, FormatJSON, key, h,
+		Message{ID: "m1", Seq: 1, AuthorID: "user2", Type: "text", Text: "<b>привет</b>"},
+	)
+
+	a, err := Read(strings.NewReader(out), 1<<20)
+	require.NoError(t, err)
+	assert.True(t, a.Verify(key))
+	assert.False(t, a.Verify([]byte("other")))
+
+	// Архив с подменённым автором подпись не проходит
+	forged := a
+	forged.Messages = []Message{a.Messages[0]}
+	forged.Messages[0].AuthorID = "user1"
+	assert.False(t, forged.Verify(key))
+
+	unsigned, err := Read(strings.NewReader(writeAll(t, FormatJSON, h)), 1<<20)
+	require.NoError(t, err)
+	assert.False(t, unsigned.Verify(key))
+}
+
+func TestHTMLWriter_EscapesAndNamesAuthors(t *testing.T) {
+	h := Header{Version: Version, Chat: Chat{Title: "<i>Команда</i>"}, Members: []Member{{UserID: "user1", Name: "alice"}}}
+	out := writeAll(t, FormatHTML, h,
+		Message{Seq: 1, AuthorID: "user1", AuthorName: "alice", Text: "<script>x</script>", CreatedAt: 1600000000},
+		Message{Seq: 2, AuthorID: "user2", Text: "без имени"},
+		Message{Seq: 3, AuthorID: "user1", System: &System{Action: "pin"}},
+	)
+
+	assert.Contains(t, out, "&lt;i&gt;Команда&lt;/i&gt;")
+	assert.Contains(t, out, "&lt;script&gt;x&lt;/script&gt;")
+	assert.Contains(t, out, "2020-09-13 12:26")
+	assert.Contains(t, out, `<span class="author">user2</span>`)
+	assert.Contains(t, out, `<div class="system">pin`)
+	assert.True(t, strings.HasSuffix(out, "</html>\n"))
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	_, err := NewWriter("pdf", &bytes.Buffer{}, nil)
+
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestRead_Rejects(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"version":1,"messages":[]}`), 10)
+	assert.Error(t, err, "больше лимита")
+
+	_, err = Read(strings.NewReader(`{"version":3}`), 1<<20)
+	assert.Error(t, err)
+
+	_, err = Read(strings.NewReader(`not json`), 1<<20)
+	assert.Error(t, err)
+}
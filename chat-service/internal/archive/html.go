@@ -0,0 +1,88 @@
+package archive
+
+import (
+	"html/template"
+	"io"
+	"time"
+)
+
+// htmlWriter рисует архив одной самодостаточной страницей: стили встроены, внешних ресурсов нет,
+// кроме ссылок на файлы вложений
+type htmlWriter struct {
+	w io.Writer
+}
+
+func newHTMLWriter(w io.Writer) *htmlWriter {
+	return &htmlWriter{w: w}
+}
+
+var htmlFuncs = template.FuncMap{
+	"ts": func(sec int64) string {
+		if sec == 0 {
+			return ""
+		}
+		return time.Unix(sec, 0).UTC().Format("2006-01-02 15:04")
+	},
+	"author": func(m Message) string {
+		if m.AuthorName != "" {
+			return m.AuthorName
+		}
+		return m.AuthorID
+	},
+	"member": func(m Member) string {
+		if m.Name != "" {
+			return m.Name
+		}
+		return m.UserID
+	},
+}
+
+var htmlHead = template.Must(template.New("head").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Chat.Title}}</title>
+<style>
+body{font-family:-apple-system,Segoe UI,Roboto,sans-serif;background:#f4f4f5;margin:0;color:#18181b}
+header{background:#fff;padding:16px 24px;border-bottom:1px solid #e4e4e7}
+header h1{margin:0 0 4px;font-size:20px}
+header p{margin:0;color:#71717a;font-size:13px}
+main{max-width:760px;margin:0 auto;padding:16px}
+.msg{background:#fff;border-radius:8px;padding:8px 12px;margin:6px 0}
+.msg .meta{font-size:12px;color:#71717a}
+.msg .author{font-weight:600;color:#2563eb;margin-right:6px}
+.msg .text{white-space:pre-wrap;margin-top:2px}
+.msg ul{margin:4px 0;padding-left:18px}
+.system{text-align:center;color:#71717a;font-size:13px;margin:8px 0}
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Chat.Title}}</h1>
+<p>Выгрузка от {{ts .ExportedAt}} UTC · участники:{{range $i, $m := .Members}}{{if $i}},{{end}} {{member $m}}{{end}}</p>
+</header>
+<main>
+`))
+
+var htmlMessage = template.Must(template.New("message").Funcs(htmlFuncs).Parse(`{{if .System}}<div class="system">{{.System.Action}} · {{ts .CreatedAt}}</div>
+{{else}}<div class="msg" id="m{{.Seq}}">
+<div class="meta"><span class="author">{{author .}}</span>{{ts .CreatedAt}}{{if .Edited}} · изменено{{end}}</div>
+{{if .Text}}<div class="text">{{.Text}}</div>
+{{end}}{{if .Poll}}<div class="text">📊 {{.Poll.Question}}</div>
+<ul>{{range .Poll.Options}}<li>{{.Text}} — {{.Votes}}</li>{{end}}</ul>
+{{end}}{{if .Media}}<ul>{{range .Media}}<li><a href="{{.URL}}">{{.Type}}: {{.ID}}</a></li>{{end}}</ul>
+{{end}}</div>
+{{end}}`))
+
+func (h *htmlWriter) Begin(hd Header) error {
+	return htmlHead.Execute(h.w, hd)
+}
+
+func (h *htmlWriter) Message(m Message) error {
+	return htmlMessage.Execute(h.w, m)
+}
+
+func (h *htmlWriter) End() error {
+	_, err := io.WriteString(h.w, "</main>\n</body>\n</html>\n")
+	return err
+}
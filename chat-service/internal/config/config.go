@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -11,22 +14,92 @@ type Config struct {
 	MongoDB         string
 	KafkaBroker     string
 	KafkaTopic      string
+	RedisAddr       string
 	UserServiceAddr string
 	ChatServicePort string
 	LogLevel        zerolog.Level
 	LogPretty       bool
+
+	// media-service (HTTP) и префикс публичной ссылки на файл, к которому дописывается id
+	MediaServiceAddr string
+	MediaDownloadURL string
+
+	// Сколько времени после отправки сообщение можно редактировать (0 — без ограничений)
+	MessageEditWindow time.Duration
+	// Как часто планировщик проверяет созревшие отложенные сообщения
+	SchedulerInterval time.Duration
+	// Как часто удаляются сообщения с истёкшим таймером автоудаления
+	PurgeInterval time.Duration
+	// Как часто relay публикует события outbox в Kafka
+	OutboxInterval time.Duration
+	// Как часто поток SubscribeChats перечитывает журнал обновлений (записи с других реплик)
+	StreamPollInterval time.Duration
+
+	// Как часто применяется политика хранения истории (0 — не применять)
+	RetentionInterval time.Duration
+	// Через сколько дней удалённые сообщения стираются безвозвратно (0 — хранить всегда)
+	RetentionDeletedDays int64
+	// Сколько последних сообщений хранится в каждом чате (0 — без ограничения)
+	RetentionMaxMessagesPerChat int64
+	// Адрес HTTP-сервера с метриками expvar (/debug/vars); пустой — не запускать
+	MetricsAddr string
+	// Ключ подписи JSON-выгрузок чатов; пустой — выгрузки без подписи
+	ArchiveSigningKey string
+
+	// Сколько сообщений участник группы может отправить за SendRateWindow (0 — без лимита)
+	SendRateLimit  int64
+	SendRateWindow time.Duration
+
+	// Модерация: запрещённые слова и домены (через запятую) и что делать с сообщением — mask | hold | reject.
+	// Пустой список отключает фильтр
+	ModerationBannedWords    []string
+	ModerationBannedAction   string
+	ModerationBlockedDomains []string
+	ModerationLinkAction     string
+	// Одно и то же сообщение больше FloodMaxRepeats раз за FloodWindow — флуд (0 — не проверять)
+	FloodMaxRepeats int64
+	FloodWindow     time.Duration
+	FloodAction     string
 }
 
 func New() *Config {
 	return &Config{
-		MongoURI:        getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		MongoURI:        getEnv("MONGO_URI", "mongodb://localhost:27017/?replicaSet=rs0"),
 		MongoDB:         getEnv("MONGO_DB", "chatdb"),
 		KafkaBroker:     getEnv("KAFKA_BROKER", "localhost:29092"),
 		KafkaTopic:      getEnv("KAFKA_TOPIC_MESSAGES", "messages"),
+		RedisAddr:       getEnv("REDIS_ADDR", "localhost:6379"),
 		UserServiceAddr: getEnv("USER_SERVICE_ADDR", "localhost:8082"),
 		ChatServicePort: getEnv("CHAT_SERVICE_PORT", ":8083"),
 		LogLevel:        parseLogLevel(getEnv("LOG_LEVEL", "info")),
 		LogPretty:       getEnv("LOG_PRETTY", "true") == "true",
+
+		MediaServiceAddr: getEnv("MEDIA_SERVICE_ADDR", "http://localhost:8084"),
+		MediaDownloadURL: getEnv("MEDIA_DOWNLOAD_URL", "/media/download?id="),
+
+		MessageEditWindow: parseDuration(getEnv("MESSAGE_EDIT_WINDOW", "0")),
+		SchedulerInterval: parseDuration(getEnv("SCHEDULER_INTERVAL", "1s")),
+		PurgeInterval:     parseDuration(getEnv("PURGE_INTERVAL", "1m")),
+		OutboxInterval:    parseDuration(getEnv("OUTBOX_INTERVAL", "200ms")),
+
+		StreamPollInterval: parseDuration(getEnv("STREAM_POLL_INTERVAL", "1s")),
+
+		RetentionInterval:           parseDuration(getEnv("RETENTION_INTERVAL", "1h")),
+		RetentionDeletedDays:        parseInt(getEnv("RETENTION_DELETED_DAYS", "30")),
+		RetentionMaxMessagesPerChat: parseInt(getEnv("RETENTION_MAX_MESSAGES_PER_CHAT", "0")),
+		MetricsAddr:                 getEnv("METRICS_ADDR", ""),
+		ArchiveSigningKey:           getEnv("ARCHIVE_SIGNING_KEY", ""),
+
+		SendRateLimit:  parseInt(getEnv("SEND_RATE_LIMIT", "20")),
+		SendRateWindow: parseDuration(getEnv("SEND_RATE_WINDOW", "1m")),
+
+		ModerationBannedWords:    parseList(getEnv("MODERATION_BANNED_WORDS", "")),
+		ModerationBannedAction:   getEnv("MODERATION_BANNED_ACTION", "mask"),
+		ModerationBlockedDomains: parseList(getEnv("MODERATION_BLOCKED_DOMAINS", "")),
+		ModerationLinkAction:     getEnv("MODERATION_LINK_ACTION", "hold"),
+		FloodMaxRepeats:          parseInt(getEnv("FLOOD_MAX_REPEATS", "3")),
+		FloodWindow:              parseDuration(getEnv("FLOOD_WINDOW", "1m")),
+		FloodAction:              getEnv("FLOOD_ACTION", "reject"),
 	}
 }
 
@@ -51,3 +124,29 @@ func parseLogLevel(level string) zerolog.Level {
 		return zerolog.InfoLevel
 	}
 }
+
+func parseInt(val string) int64 {
+	n, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func parseList(val string) []string {
+	var items []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseDuration(val string) time.Duration {
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0
+	}
+	return d
+}
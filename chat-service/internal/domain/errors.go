@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrChatNotFound       = errors.New("chat not found")
+	ErrMessageNotFound    = errors.New("message not found")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrAlreadyPinned      = errors.New("message already pinned")
+	ErrNotPinned          = errors.New("message is not pinned")
+	ErrEditWindowClosed   = errors.New("message can no longer be edited")
+	ErrTooManyPinnedChats = errors.New("too many pinned chats")
+	ErrInvalidArgument    = errors.New("invalid argument")
+
+	ErrScheduledNotFound = errors.New("scheduled message not found")
+	ErrScheduledClosed   = errors.New("scheduled message already sent or canceled")
+	ErrTooManyScheduled  = errors.New("too many scheduled messages")
+
+	ErrPollClosed   = errors.New("poll is closed")
+	ErrAlreadyVoted = errors.New("already voted in this poll")
+	ErrNotVoted     = errors.New("not voted in this poll")
+
+	ErrHandleTaken = errors.New("channel handle already taken")
+
+	ErrInviteNotFound      = errors.New("invite link not found")
+	ErrInviteUnusable      = errors.New("invite link is revoked, expired or used up")
+	ErrAlreadyMember       = errors.New("user is already a member of the chat")
+	ErrJoinRequestNotFound = errors.New("pending join request not found")
+
+	ErrMediaNotFound = errors.New("media file not found")
+
+	ErrRateLimited = errors.New("sending too fast")
+
+	ErrMessageRejected        = errors.New("message rejected by moderation")
+	ErrModerationItemNotFound = errors.New("pending moderation item not found")
+
+	ErrDeviceMismatch = errors.New("envelopes do not match recipient devices")
+
+	ErrFolderNotFound = errors.New("chat folder not found")
+	ErrTooManyFolders = errors.New("too many chat folders")
+
+	ErrLeaseLost = errors.New("job lease taken over by another replica")
+)
+
+// RateLimitError — отправка отклонена медленным режимом или лимитом частоты; писать снова можно через RetryAfter
+type RateLimitError struct {
+	RetryAfter time.Duration
+	SlowMode   bool // Сработал медленный режим чата, а не общий лимит
+}
+
+func (e *RateLimitError) Error() string {
+	secs := int64((e.RetryAfter + time.Second - 1) / time.Second)
+	if e.SlowMode {
+		return fmt.Sprintf("%v: в чате медленный режим, писать снова можно через %d с", ErrRateLimited, secs)
+	}
+	return fmt.Sprintf("%v: писать снова можно через %d с", ErrRateLimited, secs)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// DeviceMismatchError — конверты секретного сообщения не совпали с устройствами участников.
+// Клиенту нужно обновить список устройств (Missing — без конверта, Unknown — не зарегистрированы)
+// и отправить сообщение заново. Устройства записаны как "user_id/device_id"
+type DeviceMismatchError struct {
+	Missing []string
+	Unknown []string
+}
+
+func (e *DeviceMismatchError) Error() string {
+	return fmt.Sprintf("%v: нет конвертов для %v, неизвестные устройства %v", ErrDeviceMismatch, e.Missing, e.Unknown)
+}
+
+func (e *DeviceMismatchError) Unwrap() error {
+	return ErrDeviceMismatch
+}
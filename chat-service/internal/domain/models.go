@@ -1,9 +1,6 @@
 package domain
 
-type ReadInfo struct {
-	UserID string `bson:"user_id"`
-	ReadAt int64  `bson:"read_at"`
-}
+import "fmt"
 
 type SavedInfo struct {
 	UserID  string `bson:"user_id"`
@@ -17,15 +14,323 @@ type ChatKind string
 const (
 	ChatKindDirect ChatKind = "direct"
 	ChatKindGroup  ChatKind = "group"
+	// Канал: пишут только администраторы (member_ids), подписчики хранятся отдельно
+	// в channel_subscribers, потому что их могут быть десятки тысяч
+	ChatKindChannel ChatKind = "channel"
 )
 
 type Chat struct {
-	ID        string   `bson:"id"`
-	Kind      ChatKind `bson:"kind"`
-	MemberIDs []string `bson:"member_ids"`
-	Title     string   `bson:"title"`
-	CreatedBy string   `bson:"created_by"`
-	CreatedAt int64    `bson:"created_at"`
+	ID        string          `bson:"id"`
+	Kind      ChatKind        `bson:"kind"`
+	MemberIDs []string        `bson:"member_ids"`
+	Title     string          `bson:"title"`
+	CreatedBy string          `bson:"created_by"`
+	CreatedAt int64           `bson:"created_at"`
+	Pinned    []PinnedMessage `bson:"pinned,omitempty"` // Закреплённые сообщения
+	LastSeq   int64           `bson:"last_seq"`         // Номер последнего сообщения в чате
+
+	// Только для каналов: публичное имя (уникально, без @) и число подписчиков
+	Handle          string `bson:"handle,omitempty"`
+	SubscriberCount int64  `bson:"subscriber_count,omitempty"`
+
+	// Таймер автоудаления в секундах (0 — выключен); действует на сообщения, отправленные после установки
+	MessageTTL int64 `bson:"message_ttl,omitempty"`
+
+	// Медленный режим группы: участник пишет не чаще раза в столько секунд (0 — выключен)
+	SlowModeSeconds int64 `bson:"slow_mode_seconds,omitempty"`
+
+	// Секретный личный чат: сообщения хранятся только шифротекстом в конвертах для устройств
+	Secret bool `bson:"secret,omitempty"`
+
+	// Для сортировки списка чатов: время последнего сообщения (для пустого чата — время создания)
+	LastMessageAt int64           `bson:"last_message_at"`
+	LastMessage   *MessagePreview `bson:"last_message,omitempty"`
+
+	State *UserChatState `bson:"-"` // Состояние чата для запросившего пользователя (только в ListChats)
+}
+
+// Cursor — позиция чата в списке по убыванию last_message_at (см. ChatQuery)
+func (c Chat) Cursor() string {
+	return fmt.Sprintf("%d:%s", c.LastMessageAt, c.ID)
+}
+
+// MessagePreview — краткая копия последнего сообщения для списка чатов
+type MessagePreview struct {
+	ID        string      `bson:"id"`
+	Seq       int64       `bson:"seq"`
+	AuthorID  string      `bson:"author_id"`
+	Type      MessageType `bson:"type"`
+	Text      string      `bson:"text"`
+	CreatedAt int64       `bson:"created_at"`
+	Deleted   bool        `bson:"deleted"`
+}
+
+// ChatQuery — выборка чатов пользователя по убыванию last_message_at.
+// Курсор имеет вид "last_message_at:id". OnlyIDs ограничивает выборку, ExcludeIDs исключает чаты.
+// ChannelIDs — каналы, на которые пользователь подписан: в их member_ids его нет.
+type ChatQuery struct {
+	UserID     string
+	Limit      int
+	Cursor     string
+	OnlyIDs    []string
+	ExcludeIDs []string
+	ChannelIDs []string
+}
+
+// UserChatState — личные настройки чата у пользователя: закрепление, архив, без звука, отметка «непрочитано»
+type UserChatState struct {
+	ChatID       string `bson:"chat_id"`
+	UserID       string `bson:"user_id"`
+	Pinned       bool   `bson:"pinned"`
+	PinnedAt     int64  `bson:"pinned_at"`
+	Archived     bool   `bson:"archived"`
+	MutedUntil   int64  `bson:"muted_until"` // unix; 0 — звук включён
+	MarkedUnread bool   `bson:"marked_unread"`
+
+	UnreadCount int64 `bson:"-"` // Считается из указателей прочтения
+}
+
+// --- Папки чатов ---
+
+// FolderFlag — условие папки на признак чата: пусто — не важно, only — только с признаком, exclude — только без него
+type FolderFlag string
+
+const (
+	FolderFlagAny     FolderFlag = ""
+	FolderFlagOnly    FolderFlag = "only"
+	FolderFlagExclude FolderFlag = "exclude"
+)
+
+// FolderRules — правила папки. Kinds и Contacts отбирают чаты (подходит любой из критериев;
+// если оба пусты — все чаты), Muted и Unread дополнительно сужают выборку.
+type FolderRules struct {
+	Kinds    []ChatKind `bson:"kinds,omitempty"`
+	Contacts []string   `bson:"contacts,omitempty"` // Личные чаты с этими пользователями
+	Muted    FolderFlag `bson:"muted,omitempty"`
+	Unread   FolderFlag `bson:"unread,omitempty"`
+}
+
+// ChatFolder — папка чатов пользователя, общая для всех его устройств.
+// IncludeIDs попадают в папку в обход правил (в том числе архивные), ExcludeIDs не попадают никогда.
+type ChatFolder struct {
+	ID         string      `bson:"id"`
+	UserID     string      `bson:"user_id"`
+	Title      string      `bson:"title"`
+	Position   int         `bson:"position"`
+	IncludeIDs []string    `bson:"include_ids,omitempty"`
+	ExcludeIDs []string    `bson:"exclude_ids,omitempty"`
+	Rules      FolderRules `bson:"rules"`
+	UpdatedAt  int64       `bson:"updated_at"`
+
+	// Счётчики для значка папки (только в ListFolders)
+	UnreadChats    int64 `bson:"-"`
+	UnreadMessages int64 `bson:"-"`
+}
+
+// Matches проверяет, входит ли чат в папку. У чата должно быть заполнено State;
+// now — текущее время (unix) для проверки отключённого звука.
+func (f ChatFolder) Matches(c Chat, userID string, now int64) bool {
+	for _, id := range f.ExcludeIDs {
+		if id == c.ID {
+			return false
+		}
+	}
+	for _, id := range f.IncludeIDs {
+		if id == c.ID {
+			return true
+		}
+	}
+	st := UserChatState{}
+	if c.State != nil {
+		st = *c.State
+	}
+	if st.Archived {
+		return false
+	}
+
+	r := f.Rules
+	if len(r.Kinds) > 0 || len(r.Contacts) > 0 {
+		if !r.matchesKind(c) && !r.matchesContact(c, userID) {
+			return false
+		}
+	} else if f.ExplicitOnly() {
+		return false
+	}
+	return r.Muted.allows(st.MutedUntil > now) && r.Unread.allows(st.IsUnread())
+}
+
+// ExplicitOnly — папка состоит только из явно выбранных чатов, правил у неё нет
+func (f ChatFolder) ExplicitOnly() bool {
+	r := f.Rules
+	return len(f.IncludeIDs) > 0 && len(r.Kinds) == 0 && len(r.Contacts) == 0 &&
+		r.Muted == FolderFlagAny && r.Unread == FolderFlagAny
+}
+
+func (r FolderRules) matchesKind(c Chat) bool {
+	for _, k := range r.Kinds {
+		if k == c.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (r FolderRules) matchesContact(c Chat, userID string) bool {
+	if c.Kind != ChatKindDirect {
+		return false
+	}
+	for _, peer := range c.OtherMembers(userID) {
+		for _, id := range r.Contacts {
+			if id == peer {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f FolderFlag) allows(has bool) bool {
+	switch f {
+	case FolderFlagOnly:
+		return has
+	case FolderFlagExclude:
+		return !has
+	}
+	return true
+}
+
+// IsUnread — в чате есть непрочитанные сообщения или стоит ручная отметка «непрочитано»
+func (st UserChatState) IsUnread() bool {
+	return st.UnreadCount > 0 || st.MarkedUnread
+}
+
+// --- Роли в чате ---
+
+type ChatRole string
+
+const (
+	ChatRoleOwner  ChatRole = "owner"
+	ChatRoleAdmin  ChatRole = "admin" // Администратор канала
+	ChatRoleMember ChatRole = "member"
+)
+
+// RoleOf возвращает роль пользователя в чате или пустую строку, если он не участник.
+// Подписчики канала в документе чата не хранятся, для них тоже возвращается пустая строка.
+func (c Chat) RoleOf(userID string) ChatRole {
+	if c.CreatedBy == userID {
+		return ChatRoleOwner
+	}
+	for _, id := range c.MemberIDs {
+		if id == userID {
+			if c.Kind == ChatKindChannel {
+				return ChatRoleAdmin
+			}
+			return ChatRoleMember
+		}
+	}
+	return ""
+}
+
+// CanManage — общие настройки чата (закрепления, таймер автоудаления) в личном чате
+// меняет любой участник, в группе — только владелец, в канале — владелец и администраторы
+func (c Chat) CanManage(userID string) bool {
+	switch c.RoleOf(userID) {
+	case ChatRoleOwner, ChatRoleAdmin:
+		return true
+	case ChatRoleMember:
+		return c.Kind == ChatKindDirect
+	default:
+		return false
+	}
+}
+
+// CanPost — писать в канал могут только владелец и администраторы, в остальные чаты — участники
+func (c Chat) CanPost(userID string) bool {
+	return c.RoleOf(userID) != ""
+}
+
+// OtherMembers — участники чата, кроме userID
+func (c Chat) OtherMembers(userID string) []string {
+	others := make([]string, 0, len(c.MemberIDs))
+	for _, id := range c.MemberIDs {
+		if id != userID {
+			others = append(others, id)
+		}
+	}
+	return others
+}
+
+func (c Chat) CanPin(userID string) bool {
+	return c.CanManage(userID)
+}
+
+// --- Каналы ---
+
+// ChannelSubscription — подписка пользователя на канал (коллекция channel_subscribers)
+type ChannelSubscription struct {
+	ChatID       string `bson:"chat_id"`
+	UserID       string `bson:"user_id"`
+	SubscribedAt int64  `bson:"subscribed_at"`
+}
+
+// --- Приглашения ---
+
+// InviteLink — ссылка-приглашение в группу. Нулевые ExpiresAt и UsageLimit — без ограничений.
+// По ссылке с RequiresApproval вступление идёт через заявку, которую одобряет администратор.
+type InviteLink struct {
+	Code             string `bson:"code"`
+	ChatID           string `bson:"chat_id"`
+	CreatedBy        string `bson:"created_by"`
+	CreatedAt        int64  `bson:"created_at"`
+	ExpiresAt        int64  `bson:"expires_at"`
+	UsageLimit       int64  `bson:"usage_limit"`
+	UsageCount       int64  `bson:"usage_count"`
+	RequiresApproval bool   `bson:"requires_approval"`
+	Revoked          bool   `bson:"revoked"`
+}
+
+// Usable — по ссылке ещё можно вступить
+func (l InviteLink) Usable(now int64) bool {
+	return !l.Revoked &&
+		(l.ExpiresAt == 0 || now < l.ExpiresAt) &&
+		(l.UsageLimit == 0 || l.UsageCount < l.UsageLimit)
+}
+
+// InviteSettings — ограничения новой ссылки; нулевые значения — без ограничений
+type InviteSettings struct {
+	ExpiresAt        int64
+	UsageLimit       int64
+	RequiresApproval bool
+}
+
+type JoinRequestStatus string
+
+const (
+	JoinRequestPending  JoinRequestStatus = "pending"
+	JoinRequestApproved JoinRequestStatus = "approved"
+	JoinRequestRejected JoinRequestStatus = "rejected"
+)
+
+// JoinRequest — заявка на вступление; у пользователя не больше одной ожидающей заявки в чат
+type JoinRequest struct {
+	ID         string            `bson:"id"`
+	ChatID     string            `bson:"chat_id"`
+	UserID     string            `bson:"user_id"`
+	InviteCode string            `bson:"invite_code"`
+	Status     JoinRequestStatus `bson:"status"`
+	CreatedAt  int64             `bson:"created_at"`
+	DecidedBy  string            `bson:"decided_by,omitempty"`
+	DecidedAt  int64             `bson:"decided_at,omitempty"`
+}
+
+// --- Закреплённые сообщения ---
+
+type PinnedMessage struct {
+	MessageID string `bson:"message_id"`
+	PinnedBy  string `bson:"pinned_by"`
+	PinnedAt  int64  `bson:"pinned_at"`
+
+	Message *Message `bson:"-"` // Заполняется при выдаче списка закреплённых
 }
 
 // --- Медиа ---
@@ -40,22 +345,441 @@ type Media struct {
 }
 
 // --- Сообщения ---
+
+type MessageType string
+
+const (
+	MessageTypeText   MessageType = "text"
+	MessageTypeSystem MessageType = "system"
+	MessageTypePoll   MessageType = "poll"
+
+	// Сообщение секретного чата: текста нет, содержимое — в конвертах для устройств
+	MessageTypeEncrypted MessageType = "encrypted"
+)
+
+// Действия системных сообщений
+const (
+	SystemActionPin   = "pin"
+	SystemActionUnpin = "unpin"
+	SystemActionTTL   = "message_ttl"
+	SystemActionJoin  = "join"
+
+	SystemActionSlowMode = "slow_mode"
+)
+
+// SystemEvent — описание служебного события для системного сообщения
+type SystemEvent struct {
+	Action    string `bson:"action"`
+	ActorID   string `bson:"actor_id"`
+	MessageID string `bson:"message_id,omitempty"`
+	UserID    string `bson:"user_id,omitempty"` // Над кем совершено действие (например, кто вступил)
+}
+
+// Envelope — сообщение секретного чата, зашифрованное для одного устройства
+type Envelope struct {
+	UserID     string `bson:"user_id"`
+	DeviceID   string `bson:"device_id"`
+	Ciphertext []byte `bson:"ciphertext"`
+}
+
 type Message struct {
-	ID        string  `bson:"id"`
-	ChatID    string  `bson:"chat_id"`
-	AuthorID  string  `bson:"author_id"`
-	Text      string  `bson:"text"`
-	Media     []Media `bson:"media,omitempty"`
-	CreatedAt int64   `bson:"created_at"`
-	UpdatedAt int64   `bson:"updated_at,omitempty"`
-	Deleted   bool    `bson:"deleted"`
-	DeletedAt int64   `bson:"deleted_at,omitempty"`
+	ID        string          `bson:"id"`
+	ChatID    string          `bson:"chat_id"`
+	Seq       int64           `bson:"seq"` // Порядковый номер внутри чата, начиная с 1
+	AuthorID  string          `bson:"author_id"`
+	Type      MessageType     `bson:"type,omitempty"`
+	Text      string          `bson:"text"`
+	Media     []Media         `bson:"media,omitempty"`
+	Entities  []MessageEntity `bson:"entities,omitempty"`
+	System    *SystemEvent    `bson:"system,omitempty"`
+	CreatedAt int64           `bson:"created_at"`
+	UpdatedAt int64           `bson:"updated_at,omitempty"`
+	Deleted   bool            `bson:"deleted"`
+	DeletedAt int64           `bson:"deleted_at,omitempty"`
+	Edited    bool            `bson:"edited"`
+	EditCount int             `bson:"edit_count"`
+	ExpiresAt int64           `bson:"expires_at,omitempty"` // Когда сообщение будет удалено таймером чата
+	Poll      *Poll           `bson:"poll,omitempty"`       // Только для type = poll
+	Views     int64           `bson:"views,omitempty"`      // Просмотры поста в канале
+
+	// Только для импортированных сообщений, чей автор не найден: его имя из архива (автором записан импортёр)
+	ImportedFrom string `bson:"imported_from,omitempty"`
+
+	Held bool `bson:"-"` // Сообщение задержано модерацией и будет опубликовано после одобрения
+
+	// Только для секретного чата: устройство автора и шифротекст для каждого устройства участников
+	SenderDeviceID string     `bson:"sender_device_id,omitempty"`
+	Envelopes      []Envelope `bson:"envelopes,omitempty"`
 
 	// Встроенные поля для оптимизации
-	ReadBy  []ReadInfo  `bson:"read_by,omitempty"`  // Кто прочитал
 	SavedBy []SavedInfo `bson:"saved_by,omitempty"` // Кто сохранил
 }
 
+// --- Модерация ---
+
+type ModerationKind string
+
+const (
+	ModerationKindHeld   ModerationKind = "held"   // Сообщение задержано фильтром до решения модератора
+	ModerationKindReport ModerationKind = "report" // Жалобы участников на опубликованное сообщение
+)
+
+type ModerationStatus string
+
+const (
+	ModerationPending ModerationStatus = "pending"
+	ModerationAllowed ModerationStatus = "allowed" // Задержанное опубликовано, жалобы отклонены
+	ModerationRemoved ModerationStatus = "removed" // Задержанное отброшено, обжалованное удалено
+)
+
+// ModerationItem — запись очереди модерации. На одно сообщение приходится одна ожидающая запись
+// с жалобами: повторные жалобы добавляются в Reports.
+type ModerationItem struct {
+	ID        string             `bson:"id"`
+	ChatID    string             `bson:"chat_id"`
+	Kind      ModerationKind     `bson:"kind"`
+	MessageID string             `bson:"message_id"`
+	AuthorID  string             `bson:"author_id"`
+	Text      string             `bson:"text"`              // Текст на момент постановки в очередь
+	Reasons   []string           `bson:"reasons,omitempty"` // Какие фильтры сработали (для held)
+	Reports   []ModerationReport `bson:"reports,omitempty"`
+	Status    ModerationStatus   `bson:"status"`
+	CreatedAt int64              `bson:"created_at"`
+	DecidedBy string             `bson:"decided_by,omitempty"`
+	DecidedAt int64              `bson:"decided_at,omitempty"`
+
+	Message *Message `bson:"message,omitempty"` // Задержанное сообщение целиком: публикуется при одобрении
+}
+
+type ModerationReport struct {
+	UserID    string `bson:"user_id"`
+	Reason    string `bson:"reason"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+// --- Экспорт и импорт ---
+
+// ImportResult — итог ImportChat: новая группа и что из архива не удалось перенести как есть
+type ImportResult struct {
+	Chat               Chat
+	ImportedMessages   int
+	SkippedMembers     int         // Не найдены или заблокировали импортёра
+	DroppedAttachments int         // Файл не найден или принадлежит не импортёру
+	ReassignedMessages int         // Автор не найден или архиву нельзя верить, сообщение записано от импортёра
+	InvitedMembers     []string    // Участники архива, которым предназначена ссылка Invite
+	Invite             *InviteLink // Ссылка, по которой участники архива вступают сами; nil — приглашать некого
+}
+
+// --- Опросы ---
+
+// Poll хранится внутри сообщения вместе со счётчиками; сами голоса лежат в коллекции poll_votes
+// (по документу на голос), поэтому размер сообщения не растёт с числом проголосовавших.
+// Голос и счётчики меняются в одной транзакции.
+type Poll struct {
+	Question       string       `bson:"question"`
+	Options        []PollOption `bson:"options"`
+	MultipleChoice bool         `bson:"multiple_choice"`
+	Anonymous      bool         `bson:"anonymous"`
+	ClosesAt       int64        `bson:"closes_at"` // 0 — без ограничения по времени
+	Closed         bool         `bson:"closed"`
+	ClosedAt       int64        `bson:"closed_at,omitempty"`
+	TotalVoters    int64        `bson:"total_voters"`
+}
+
+// PollOption — вариант ответа; ID совпадает с индексом в Options
+type PollOption struct {
+	ID    int    `bson:"id"`
+	Text  string `bson:"text"`
+	Votes int64  `bson:"votes"`
+}
+
+// PollVote — голос пользователя; уникальный индекс (message_id, user_id) не даёт проголосовать дважды
+type PollVote struct {
+	MessageID string `bson:"message_id"`
+	UserID    string `bson:"user_id"`
+	OptionIDs []int  `bson:"option_ids"`
+	VotedAt   int64  `bson:"voted_at"`
+}
+
+// IsClosed — опрос закрыт вручную или истекло время голосования
+func (p Poll) IsClosed(now int64) bool {
+	return p.Closed || (p.ClosesAt > 0 && now >= p.ClosesAt)
+}
+
+// PollResults — результаты опроса для конкретного участника.
+// Voters (кто за что голосовал) заполняется только для публичных опросов.
+type PollResults struct {
+	MessageID   string
+	ChatID      string
+	Poll        Poll
+	MyOptionIDs []int
+	Voters      map[int][]string
+}
+
+// MessageQuery — выборка истории чата. Задаётся не более одного якоря (номера seq):
+// BeforeSeq — сообщения до якоря, AfterSeq — после, AroundSeq — вокруг него, включая сам якорь.
+// Без якоря возвращаются последние сообщения.
+type MessageQuery struct {
+	ChatID    string
+	Limit     int
+	BeforeSeq int64
+	AfterSeq  int64
+	AroundSeq int64
+}
+
+// MessagePage — страница истории; сообщения всегда идут по возрастанию seq
+type MessagePage struct {
+	Messages  []Message
+	HasBefore bool // есть более старые сообщения
+	HasAfter  bool // есть более новые сообщения
+}
+
+// --- Состояние прочтения ---
+
+// ReadState — указатели прочтения и доставки участника в чате (одна запись на пару чат/пользователь).
+// Сообщение прочитано, если его Seq <= LastReadSeq; доставлено — если Seq <= LastDeliveredSeq.
+type ReadState struct {
+	ChatID           string `bson:"chat_id"`
+	UserID           string `bson:"user_id"`
+	LastReadSeq      int64  `bson:"last_read_seq"`
+	LastReadAt       int64  `bson:"last_read_at"`
+	LastDeliveredSeq int64  `bson:"last_delivered_seq"`
+	LastDeliveredAt  int64  `bson:"last_delivered_at"`
+	ChatLastSeq      int64  `bson:"chat_last_seq"` // Последнее сообщение чата, о котором знает указатель
+	UnreadCount      int64  `bson:"unread_count"`  // chat_last_seq - last_read_seq
+}
+
+// --- Упоминания ---
+
+const (
+	EntityTypeMention    = "mention"     // @username
+	EntityTypeMentionAll = "mention_all" // @all
+)
+
+// MessageEntity — размеченный фрагмент текста; Offset и Length в символах (рунах)
+type MessageEntity struct {
+	Type   string `bson:"type"`
+	Offset int    `bson:"offset"`
+	Length int    `bson:"length"`
+	UserID string `bson:"user_id,omitempty"`
+}
+
+// Mention — упоминание пользователя в сообщении, хранится до прочтения
+type Mention struct {
+	ChatID    string `bson:"chat_id"`
+	UserID    string `bson:"user_id"`
+	MessageID string `bson:"message_id"`
+	Seq       int64  `bson:"seq"`
+	AuthorID  string `bson:"author_id"`
+	CreatedAt int64  `bson:"created_at"`
+	Read      bool   `bson:"read"`
+}
+
+// --- Индикаторы набора ---
+
+type TypingAction string
+
+const (
+	TypingActionTyping    TypingAction = "typing"
+	TypingActionRecording TypingAction = "recording_voice"
+	TypingActionUploading TypingAction = "uploading"
+	TypingActionCancel    TypingAction = "cancel"
+)
+
+// TypingStatus — эфемерный статус участника; хранится в Redis несколько секунд
+type TypingStatus struct {
+	ChatID    string       `json:"chat_id"`
+	UserID    string       `json:"user_id"`
+	Action    TypingAction `json:"action"`
+	ExpiresAt int64        `json:"expires_at"` // unix ms
+}
+
+// --- Ревизии сообщений ---
+
+// MessageRevision — предыдущая версия отредактированного сообщения.
+// Revision = 1 соответствует исходному тексту.
+type MessageRevision struct {
+	MessageID  string  `bson:"message_id"`
+	ChatID     string  `bson:"chat_id"`
+	AuthorID   string  `bson:"author_id"`
+	Revision   int     `bson:"revision"`
+	Text       string  `bson:"text"`
+	Media      []Media `bson:"media,omitempty"`
+	CreatedAt  int64   `bson:"created_at"`  // Когда версия появилась
+	ReplacedAt int64   `bson:"replaced_at"` // Когда версия была заменена
+}
+
+// --- Отложенные сообщения ---
+
+type ScheduledStatus string
+
+const (
+	ScheduledPending  ScheduledStatus = "pending"  // ждёт времени отправки, можно менять и отменять
+	ScheduledSending  ScheduledStatus = "sending"  // захвачено планировщиком
+	ScheduledSent     ScheduledStatus = "sent"     // доставлено, MessageID — id сообщения в чате
+	ScheduledCanceled ScheduledStatus = "canceled" // отменено автором
+	ScheduledFailed   ScheduledStatus = "failed"   // доставить не удалось, причина в Error
+)
+
+// ScheduledMessage — сообщение, поставленное в очередь на отправку в момент SendAt.
+// LockedUntil — срок захвата планировщиком: после него запись снова может взять любая реплика.
+type ScheduledMessage struct {
+	ID          string          `bson:"id"`
+	ChatID      string          `bson:"chat_id"`
+	AuthorID    string          `bson:"author_id"`
+	Text        string          `bson:"text"`
+	Media       []Media         `bson:"media,omitempty"`
+	SendAt      int64           `bson:"send_at"`
+	Status      ScheduledStatus `bson:"status"`
+	Attempts    int             `bson:"attempts"`
+	LockedUntil int64           `bson:"locked_until,omitempty"`
+	MessageID   string          `bson:"message_id,omitempty"`
+	Error       string          `bson:"error,omitempty"`
+	CreatedAt   int64           `bson:"created_at"`
+	UpdatedAt   int64           `bson:"updated_at,omitempty"`
+}
+
+// --- Черновики ---
+
+// Draft — черновик сообщения пользователя в чате, общий для всех его устройств.
+// Version назначает сервер: каждая правка увеличивает его на 1, и правка принимается, только если
+// клиент правил последнюю версию. Часы устройств в порядке правок не участвуют; UpdatedAt — время сервера (unix ms).
+// Очищенный черновик остаётся пустой записью, чтобы запоздавшее сохранение не вернуло старый текст.
+type Draft struct {
+	ChatID    string  `bson:"chat_id"`
+	UserID    string  `bson:"user_id"`
+	Text      string  `bson:"text"`
+	ReplyToID string  `bson:"reply_to_id"`
+	Media     []Media `bson:"media"`
+	Version   int64   `bson:"version"`
+	UpdatedAt int64   `bson:"updated_at"`
+	DeviceID  string  `bson:"device_id"` // Устройство, с которого пришла правка
+}
+
+func (d Draft) IsEmpty() bool {
+	return d.Text == "" && d.ReplyToID == "" && len(d.Media) == 0
+}
+
+// --- Журнал обновлений ---
+
+type UpdateType string
+
+const (
+	UpdateMessageNew     UpdateType = "message.new"
+	UpdateMessageEdited  UpdateType = "message.edited"
+	UpdateMessageDeleted UpdateType = "message.deleted"
+	UpdateRead           UpdateType = "read"
+	UpdateMembership     UpdateType = "membership"
+	UpdateFolders        UpdateType = "folders"
+	UpdatePoll           UpdateType = "poll.updated" // Изменились счётчики или опрос закрыт; сообщение подгружается при выдаче
+	UpdateDraft          UpdateType = "draft.updated"
+)
+
+// Действия в обновлениях состава чата
+const (
+	MembershipCreated = "created" // пользователь оказался в новом чате
+	MembershipJoined  = "joined"
+	MembershipAdded   = "added"
+	MembershipRemoved = "removed"
+	MembershipLeft    = "left"
+)
+
+// Действия в обновлениях папок
+const (
+	FolderCreated   = "created"
+	FolderUpdated   = "updated"
+	FolderDeleted   = "deleted"
+	FolderReordered = "reordered"
+)
+
+// UserUpdate — запись персонального журнала обновлений. Seq растёт на 1 внутри журнала
+// пользователя, по нему переподключившийся клиент забирает пропущенное (GetUpdates).
+// Сообщения в журнале не копируются: для message.new, message.edited и poll.updated они подгружаются при выдаче.
+type UserUpdate struct {
+	UserID     string     `bson:"user_id"`
+	Seq        int64      `bson:"seq"`
+	Type       UpdateType `bson:"type"`
+	ChatID     string     `bson:"chat_id"`
+	MessageIDs []string   `bson:"message_ids,omitempty"`
+	ActorID    string     `bson:"actor_id,omitempty"`  // Кто совершил действие (кто прочитал, кто добавил)
+	ReadSeq    int64      `bson:"read_seq,omitempty"`  // Только для read: прочитано до seq включительно
+	Action     string     `bson:"action,omitempty"`    // Только для membership и folders
+	UserIDs    []string   `bson:"user_ids,omitempty"`  // Только для membership: чей состав изменился
+	FolderID   string     `bson:"folder_id,omitempty"` // Только для folders
+	Draft      *Draft     `bson:"draft,omitempty"`     // Только для draft.updated: черновик после правки
+	CreatedAt  int64      `bson:"created_at"`
+
+	Messages []Message `bson:"-"` // Заполняется при выдаче
+}
+
+// UpdatesPage — ответ GetUpdates. Resync — журнал с since_seq уже очищен (или since_seq
+// из будущего): клиенту нужно перезагрузить состояние и продолжить с Seq.
+type UpdatesPage struct {
+	Updates []UserUpdate
+	Seq     int64 // последний выданный seq; с него продолжать
+	HasMore bool
+	Resync  bool
+}
+
+// StreamEvent — кадр SubscribeChats: обновление из журнала, сигнал Resync (журнал с токена
+// уже очищен — клиенту нужно перезагрузить состояние) или статус набора. ResumeToken — с него продолжать
+// после переподключения; у статуса набора его нет — такие события не хранятся и при переподключении не повторяются.
+type StreamEvent struct {
+	Update      *UserUpdate
+	Resync      bool
+	ResumeToken string
+	Typing      *TypingStatus
+}
+
+// --- Хранение истории ---
+
+// RetentionCheckpoint — состояние задачи очистки истории (одна запись на сервис).
+// ChatCursor — id последнего обработанного чата прерванного прохода; пустой — проход начнётся сначала.
+// LockedUntil — до какого времени задачу держит реплика, которая её выполняет.
+// Token — метка захвата: сохранить прогресс или закрыть проход может только реплика с этой меткой.
+type RetentionCheckpoint struct {
+	ID           string `bson:"id"`
+	ChatCursor   string `bson:"chat_cursor"`
+	LockedUntil  int64  `bson:"locked_until"`
+	Token        string `bson:"token"`
+	LastFinished int64  `bson:"last_finished_at,omitempty"`
+}
+
+// RetentionStats — итоги прохода очистки
+type RetentionStats struct {
+	DeletedPurged     int   // удалённые сообщения старше срока хранения
+	CappedPurged      int   // сообщения сверх лимита истории чата
+	ReadStatesRemoved int64 // указатели прочтения тех, кто больше не в чате
+	SavedRefsRemoved  int64 // отметки «сохранено» на удалённых сообщениях
+	MediaDetached     int   // файлы, откреплённые от чатов в media-service
+	ChatsScanned      int
+}
+
+// --- Outbox ---
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending" // ждёт публикации (или повтора после NextAttemptAt)
+	OutboxSending OutboxStatus = "sending" // захвачено relay
+	OutboxSent    OutboxStatus = "sent"    // опубликовано в Kafka
+)
+
+// OutboxEvent — событие для Kafka, записанное в одной транзакции с изменением данных.
+// Key и Payload — готовые ключ и тело сообщения; relay публикует их без изменений.
+type OutboxEvent struct {
+	ID            string       `bson:"id"`
+	Key           string       `bson:"key"`
+	Payload       []byte       `bson:"payload"`
+	Status        OutboxStatus `bson:"status"`
+	Attempts      int          `bson:"attempts"`
+	NextAttemptAt int64        `bson:"next_attempt_at"`
+	LockedUntil   int64        `bson:"locked_until,omitempty"`
+	LastError     string       `bson:"last_error,omitempty"`
+	CreatedAt     int64        `bson:"created_at"`
+	SentAt        int64        `bson:"sent_at,omitempty"`
+}
+
 // --- Пользователь ---
 
 type User struct {
@@ -95,6 +819,41 @@ type NewMessageEvent struct {
 	Timestamp int64  `bson:"timestamp"`
 }
 
+// MentionEvent — событие для сервиса уведомлений
+type MentionEvent struct {
+	MessageID string   `json:"message_id"`
+	ChatID    string   `json:"chat_id"`
+	AuthorID  string   `json:"author_id"`
+	UserIDs   []string `json:"user_ids"`
+	Text      string   `json:"text"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+// MessagesDeletedEvent — сообщения удалены безвозвратно; клиенты и search-service убирают их у себя
+type MessagesDeletedEvent struct {
+	ChatID     string   `json:"chat_id"`
+	MessageIDs []string `json:"message_ids"`
+	MediaIDs   []string `json:"media_ids,omitempty"`
+	Reason     string   `json:"reason"` // expired | moderation | retention | author
+}
+
+const (
+	DeleteReasonExpired    = "expired"
+	DeleteReasonModeration = "moderation"
+	DeleteReasonRetention  = "retention" // срок хранения удалённых сообщений или лимит истории чата
+	DeleteReasonAuthor     = "author"    // автор удалил сообщение безвозвратно (hard_delete)
+)
+
+// MembershipEvent — изменение состава чата; via: invite | request
+type MembershipEvent struct {
+	ChatID    string `json:"chat_id"`
+	UserID    string `json:"user_id"`
+	ActorID   string `json:"actor_id"`
+	Action    string `json:"action"` // joined
+	Via       string `json:"via"`
+	Timestamp int64  `json:"timestamp"`
+}
+
 type SearchEvent struct {
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
@@ -0,0 +1,94 @@
+// Package events переводит события сервиса в общие контракты (contracts/events).
+// Им пользуются и продюсер Kafka, и outbox, поэтому в брокер и в outbox попадают одинаковые байты.
+package events
+
+import (
+	"fmt"
+	"main/internal/domain"
+
+	contracts "contracts/events"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// EncodeNewMessage — событие нового сообщения для уведомлений
+func EncodeNewMessage(e domain.NewMessageEvent) (key string, value []byte, err error) {
+	value, err = contracts.Marshal(contracts.ProducerChat, &contracts.MessageSent{
+		MessageId: e.MessageID,
+		ChatId:    e.ChatID,
+		AuthorId:  e.AuthorID,
+		Text:      e.Text,
+		Timestamp: e.Timestamp,
+	})
+	return e.ChatID, value, err
+}
+
+// Encode переводит событие в конверт. Ключ — id чата: события одного чата попадают
+// в одну партицию и читаются по порядку.
+func Encode(evt domain.SearchEvent) (key string, value []byte, err error) {
+	var payload proto.Message
+	switch data := evt.Data.(type) {
+	case domain.Message:
+		key, payload = data.ChatID, &contracts.MessageUpserted{Message: message(data)}
+	case domain.Chat:
+		key, payload = data.ID, &contracts.ChatUpserted{Chat: chat(data)}
+	case domain.MentionEvent:
+		key, payload = data.ChatID, &contracts.MentionCreated{
+			MessageId: data.MessageID,
+			ChatId:    data.ChatID,
+			AuthorId:  data.AuthorID,
+			UserIds:   data.UserIDs,
+			Text:      data.Text,
+			Timestamp: data.Timestamp,
+		}
+	case domain.MessagesDeletedEvent:
+		key, payload = data.ChatID, &contracts.MessagesDeleted{
+			ChatId:     data.ChatID,
+			MessageIds: data.MessageIDs,
+			MediaIds:   data.MediaIDs,
+			Reason:     data.Reason,
+		}
+	case domain.MembershipEvent:
+		key, payload = data.ChatID, &contracts.MembershipChanged{
+			ChatId:    data.ChatID,
+			UserId:    data.UserID,
+			ActorId:   data.ActorID,
+			Action:    data.Action,
+			Via:       data.Via,
+			Timestamp: data.Timestamp,
+		}
+	default:
+		return "", nil, fmt.Errorf("event %q: no contract for %T", evt.Type, evt.Data)
+	}
+	value, err = contracts.Marshal(contracts.ProducerChat, payload)
+	return key, value, err
+}
+
+func message(m domain.Message) *contracts.Message {
+	out := &contracts.Message{
+		Id:        m.ID,
+		ChatId:    m.ChatID,
+		AuthorId:  m.AuthorID,
+		Type:      string(m.Type),
+		Text:      m.Text,
+		Seq:       m.Seq,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		Deleted:   m.Deleted,
+	}
+	for _, md := range m.Media {
+		out.MediaIds = append(out.MediaIds, md.ID)
+	}
+	return out
+}
+
+func chat(c domain.Chat) *contracts.Chat {
+	return &contracts.Chat{
+		Id:        c.ID,
+		Kind:      string(c.Kind),
+		Title:     c.Title,
+		MemberIds: c.MemberIDs,
+		CreatedBy: c.CreatedBy,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"main/internal/domain"
+	"testing"
+
+	contracts "contracts/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_Message(t *testing.T) {
+	msg := domain.Message{
+		ID:        "msg1",
+		ChatID:    "chat1",
+		Seq:       7,
+		AuthorID:  "user1",
+		Type:      domain.MessageTypeText,
+		Text:      "Привет",
+		Media:     []domain.Media{{ID: "file1"}},
+		CreatedAt: 1700000000,
+	}
+
+	key, value, err := Encode(domain.SearchEvent{Type: "message", Data: msg})
+	require.NoError(t, err)
+
+	env, payload, err := contracts.Unmarshal(value)
+	require.NoError(t, err)
+	assert.Equal(t, "chat1", key)
+	assert.Equal(t, contracts.TypeMessageUpserted, env.Type)
+	assert.Equal(t, contracts.ProducerChat, env.Producer)
+	got := payload.(*contracts.MessageUpserted).Message
+	assert.Equal(t, "msg1", got.Id)
+	assert.Equal(t, int64(7), got.Seq)
+	assert.Equal(t, []string{"file1"}, got.MediaIds)
+}
+
+func TestEncode_UnknownData(t *testing.T) {
+	_, _, err := Encode(domain.SearchEvent{Type: "draft.updated", Data: domain.Draft{ChatID: "chat1"}})
+
+	assert.Error(t, err)
+}
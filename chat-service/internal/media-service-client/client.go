@@ -0,0 +1,120 @@
+package mediaserviceclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"main/internal/domain"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client — HTTP-клиент media-service
+type Client struct {
+	addr        string
+	downloadURL string
+	http        *http.Client
+}
+
+// NewMediaClient: addr — адрес media-service, downloadURL — префикс публичной ссылки на файл
+// (к нему дописывается id, например "/media/download?id=")
+func NewMediaClient(addr, downloadURL string) *Client {
+	return &Client{
+		addr:        strings.TrimRight(addr, "/"),
+		downloadURL: downloadURL,
+		http:        &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type fileMeta struct {
+	ID          string `json:"id"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	UserID      string `json:"user_id"`
+}
+
+// AttachToChat проверяет, что файл принадлежит userID, отмечает его использование в чате
+// и возвращает вложение с метаданными из media-service
+func (c *Client) AttachToChat(ctx context.Context, fileID, userID, chatID string) (domain.Media, error) {
+	body, err := json.Marshal(map[string]string{"user_id": userID, "chat_id": chatID})
+	if err != nil {
+		return domain.Media{}, err
+	}
+	endpoint := fmt.Sprintf("%s/media/%s/attach", c.addr, url.PathEscape(fileID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return domain.Media{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return domain.Media{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return domain.Media{}, domain.ErrMediaNotFound
+	case http.StatusForbidden:
+		return domain.Media{}, domain.ErrPermissionDenied
+	default:
+		return domain.Media{}, fmt.Errorf("media-service attach: status %d", resp.StatusCode)
+	}
+
+	var meta fileMeta
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return domain.Media{}, err
+	}
+	return domain.Media{
+		ID:        meta.ID,
+		Type:      mediaType(meta.ContentType),
+		URL:       c.downloadURL + url.QueryEscape(meta.ID),
+		Mime:      meta.ContentType,
+		SizeBytes: meta.Size,
+		AuthorID:  meta.UserID,
+	}, nil
+}
+
+// DetachFromChat снимает отметку использования файла в чате; сам файл остаётся в media-service
+func (c *Client) DetachFromChat(ctx context.Context, fileID, chatID string) error {
+	body, err := json.Marshal(map[string]string{"chat_id": chatID})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/media/%s/detach", c.addr, url.PathEscape(fileID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return domain.ErrMediaNotFound
+	default:
+		return fmt.Errorf("media-service detach: status %d", resp.StatusCode)
+	}
+}
+
+// mediaType — тип вложения по MIME: image | video | audio | file
+func mediaType(mime string) string {
+	for _, t := range []string{"image", "video", "audio"} {
+		if strings.HasPrefix(mime, t+"/") {
+			return t
+		}
+	}
+	return "file"
+}
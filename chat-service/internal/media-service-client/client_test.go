@@ -0,0 +1,78 @@
+package mediaserviceclient
+
+import (
+	"context"
+	"encoding/json"
+	"main/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_AttachToChat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case r.Method != http.MethodPost || req["chat_id"] != "chat1":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/media/missing/attach":
+			w.WriteHeader(http.StatusNotFound)
+		case req["user_id"] != "owner":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "file1", "content_type": "video/mp4", "size": 4096, "user_id": "owner",
+			})
+		}
+	}))
+	defer srv.Close()
+	client := NewMediaClient(srv.URL+"/", "/media/download?id=")
+
+	media, err := client.AttachToChat(context.Background(), "file1", "owner", "chat1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Media{
+		ID: "file1", Type: "video", URL: "/media/download?id=file1", Mime: "video/mp4", SizeBytes: 4096, AuthorID: "owner",
+	}, media)
+
+	_, err = client.AttachToChat(context.Background(), "file1", "intruder", "chat1")
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+
+	_, err = client.AttachToChat(context.Background(), "missing", "owner", "chat1")
+	assert.ErrorIs(t, err, domain.ErrMediaNotFound)
+
+	_, err = client.AttachToChat(context.Background(), "file1", "owner", "other")
+	assert.Error(t, err)
+}
+
+func TestClient_DetachFromChat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case r.Method != http.MethodPost || req["chat_id"] != "chat1":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/media/missing/detach":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/media/file1/detach":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "detached"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	client := NewMediaClient(srv.URL, "/media/download?id=")
+
+	assert.NoError(t, client.DetachFromChat(context.Background(), "file1", "chat1"))
+	assert.ErrorIs(t, client.DetachFromChat(context.Background(), "missing", "chat1"), domain.ErrMediaNotFound)
+	assert.Error(t, client.DetachFromChat(context.Background(), "broken", "chat1"))
+}
+
+func TestMediaType(t *testing.T) {
+	assert.Equal(t, "image", mediaType("image/jpeg"))
+	assert.Equal(t, "audio", mediaType("audio/ogg"))
+	assert.Equal(t, "file", mediaType("application/pdf"))
+	assert.Equal(t, "file", mediaType(""))
+}
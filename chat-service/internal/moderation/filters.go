@@ -0,0 +1,202 @@
+package moderation
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Латинские буквы, которыми подменяют похожие кириллические («xyй», «дуpак»)
+var lookalikes = map[rune]rune{
+	'a': 'а', 'b': 'в', 'c': 'с', 'e': 'е', 'h': 'н', 'k': 'к', 'm': 'м',
+	'o': 'о', 'p': 'р', 't': 'т', 'x': 'х', 'y': 'у',
+}
+
+// normalizeWord приводит слово к виду, в котором хранятся основы: нижний регистр, «ё» → «е»,
+// латинские двойники — в кириллицу, если в слове есть хоть одна кириллическая буква
+func normalizeWord(word string) string {
+	word = strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	if !strings.ContainsFunc(word, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }) {
+		return word
+	}
+	return strings.Map(func(r rune) rune {
+		if c, ok := lookalikes[r]; ok {
+			return c
+		}
+		return r
+	}, word)
+}
+
+// BannedWords находит запрещённые слова в любой словоформе. Mask заменяет слово звёздочками,
+// оставляя первую букву; другие действия применяются ко всему сообщению.
+type BannedWords struct {
+	stems  map[string]struct{}
+	action Action
+}
+
+func NewBannedWords(words []string, action Action) *BannedWords {
+	f := &BannedWords{stems: make(map[string]struct{}, len(words)), action: action}
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			f.stems[Stem(normalizeWord(w))] = struct{}{}
+		}
+	}
+	return f
+}
+
+func (f *BannedWords) Name() string { return "banned_words" }
+
+func (f *BannedWords) Check(_ context.Context, in Input) (Decision, error) {
+	var (
+		out   strings.Builder
+		found string
+	)
+	rest := in.Text
+	for rest != "" {
+		start := strings.IndexFunc(rest, unicode.IsLetter)
+		if start < 0 {
+			out.WriteString(rest)
+			break
+		}
+		out.WriteString(rest[:start])
+		rest = rest[start:]
+		end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(rest)
+		}
+		word := rest[:end]
+		rest = rest[end:]
+
+		if _, banned := f.stems[Stem(normalizeWord(word))]; !banned {
+			out.WriteString(word)
+			continue
+		}
+		if found == "" {
+			found = word
+		}
+		out.WriteString(maskWord(word))
+	}
+
+	if found == "" {
+		return Decision{Action: Allow}, nil
+	}
+	d := Decision{Action: f.action, Reason: fmt.Sprintf("запрещённое слово «%s»", found)}
+	if f.action == Mask {
+		d.Text = out.String()
+	}
+	return d, nil
+}
+
+func maskWord(word string) string {
+	runes := []rune(word)
+	for i := 1; i < len(runes); i++ {
+		runes[i] = '*'
+	}
+	return string(runes)
+}
+
+// Ссылка: необязательная схема, домен с буквенной зоной, порт и путь. Группа 1 — домен.
+var linkPattern = regexp.MustCompile(`(?i)(?:https?://)?((?:[\p{L}\p{N}-]+\.)+\p{L}{2,})(?::\d+)?(?:/\S*)?`)
+
+// LinkBlocklist находит ссылки на запрещённые домены, включая их поддомены.
+// Mask заменяет такую ссылку пометкой.
+type LinkBlocklist struct {
+	domains map[string]struct{}
+	action  Action
+}
+
+func NewLinkBlocklist(domains []string, action Action) *LinkBlocklist {
+	f := &LinkBlocklist{domains: make(map[string]struct{}, len(domains)), action: action}
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "*.")
+		if d = strings.Trim(d, "."); d != "" {
+			f.domains[d] = struct{}{}
+		}
+	}
+	return f
+}
+
+func (f *LinkBlocklist) Name() string { return "link_blocklist" }
+
+func (f *LinkBlocklist) Check(_ context.Context, in Input) (Decision, error) {
+	var found string
+	masked := linkPattern.ReplaceAllStringFunc(in.Text, func(link string) string {
+		host := strings.ToLower(linkPattern.FindStringSubmatch(link)[1])
+		if !f.blocked(host) {
+			return link
+		}
+		if found == "" {
+			found = host
+		}
+		return "[ссылка удалена]"
+	})
+
+	if found == "" {
+		return Decision{Action: Allow}, nil
+	}
+	d := Decision{Action: f.action, Reason: "ссылка на " + found}
+	if f.action == Mask {
+		d.Text = masked
+	}
+	return d, nil
+}
+
+// blocked проверяет домен и все его родительские домены
+func (f *LinkBlocklist) blocked(host string) bool {
+	for host != "" {
+		if _, ok := f.domains[host]; ok {
+			return true
+		}
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			return false
+		}
+		host = host[dot+1:]
+	}
+	return false
+}
+
+// RepeatCounter считает одинаковые сообщения автора в чате за окно (реализация — Redis)
+type RepeatCounter interface {
+	CountRepeat(ctx context.Context, chatID, userID, digest string, window time.Duration) (int64, error)
+}
+
+// Flood ловит одно и то же сообщение, отправленное больше maxRepeats раз за window.
+// Сообщения сравниваются без учёта регистра и пробелов. Mask для флуда смысла не имеет
+// и считается отклонением.
+type Flood struct {
+	counter    RepeatCounter
+	maxRepeats int64
+	window     time.Duration
+	action     Action
+}
+
+func NewFlood(counter RepeatCounter, maxRepeats int64, window time.Duration, action Action) *Flood {
+	if action == Mask {
+		action = Reject
+	}
+	return &Flood{counter: counter, maxRepeats: maxRepeats, window: window, action: action}
+}
+
+func (f *Flood) Name() string { return "flood" }
+
+func (f *Flood) Check(ctx context.Context, in Input) (Decision, error) {
+	text := strings.Join(strings.Fields(strings.ToLower(in.Text)), " ")
+	if text == "" {
+		return Decision{Action: Allow}, nil
+	}
+	sum := sha1.Sum([]byte(text))
+	count, err := f.counter.CountRepeat(ctx, in.ChatID, in.AuthorID, hex.EncodeToString(sum[:]), f.window)
+	if err != nil {
+		return Decision{}, err
+	}
+	if count <= f.maxRepeats {
+		return Decision{Action: Allow}, nil
+	}
+	return Decision{Action: f.action, Reason: fmt.Sprintf("одно и то же сообщение %d раз за %s", count, f.window)}, nil
+}
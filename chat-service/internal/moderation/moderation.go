@@ -0,0 +1,116 @@
+// Package moderation — цепочка фильтров, через которую проходит текст сообщения перед сохранением.
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Action — решение фильтра; чем больше значение, тем строже решение
+type Action int
+
+const (
+	Allow  Action = iota // пропустить как есть
+	Mask                 // пропустить с изменённым текстом
+	Hold                 // отложить до решения модератора
+	Reject               // отклонить
+)
+
+// ParseAction разбирает действие из настроек: "mask", "hold" или "reject"
+func ParseAction(s string) (Action, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "mask":
+		return Mask, nil
+	case "hold":
+		return Hold, nil
+	case "reject":
+		return Reject, nil
+	}
+	return Allow, fmt.Errorf("unknown moderation action %q", s)
+}
+
+func (a Action) String() string {
+	switch a {
+	case Mask:
+		return "mask"
+	case Hold:
+		return "hold"
+	case Reject:
+		return "reject"
+	}
+	return "allow"
+}
+
+// Input — проверяемое сообщение
+type Input struct {
+	ChatID   string
+	AuthorID string
+	Text     string
+}
+
+// Decision — ответ одного фильтра. Text заполняется только для Mask.
+type Decision struct {
+	Action Action
+	Text   string
+	Reason string
+}
+
+// Filter — звено цепочки. Ошибка фильтра не останавливает отправку: такой фильтр пропускается.
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, in Input) (Decision, error)
+}
+
+// Result — итог цепочки: самое строгое решение, итоговый текст и причины в виде "фильтр: причина"
+type Result struct {
+	Action  Action
+	Text    string
+	Reasons []string
+}
+
+// Pipeline выполняет фильтры по порядку
+type Pipeline struct {
+	filters []Filter
+}
+
+func NewPipeline(filters ...Filter) *Pipeline {
+	return &Pipeline{filters: filters}
+}
+
+// Len — число фильтров в цепочке
+func (p *Pipeline) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.filters)
+}
+
+// Check прогоняет текст через цепочку. Reject останавливает её сразу; замаскированный текст
+// получают следующие фильтры; Hold запоминается, но проверка продолжается — более поздний фильтр
+// ещё может отклонить сообщение.
+func (p *Pipeline) Check(ctx context.Context, in Input) Result {
+	res := Result{Action: Allow, Text: in.Text}
+	if p == nil {
+		return res
+	}
+	for _, f := range p.filters {
+		d, err := f.Check(ctx, Input{ChatID: in.ChatID, AuthorID: in.AuthorID, Text: res.Text})
+		if err != nil || d.Action == Allow {
+			continue
+		}
+		if d.Reason != "" {
+			res.Reasons = append(res.Reasons, f.Name()+": "+d.Reason)
+		}
+		if d.Action == Mask {
+			res.Text = d.Text
+		}
+		if d.Action > res.Action {
+			res.Action = d.Action
+		}
+		if d.Action == Reject {
+			break
+		}
+	}
+	return res
+}
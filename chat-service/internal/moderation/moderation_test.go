@@ -0,0 +1,152 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	cases := map[string]string{
+		"дурак":     "дурак",
+		"дураки":    "дурак",
+		"дураками":  "дурак",
+		"дураку":    "дурак",
+		"красивая":  "красив",
+		"красивыми": "красив",
+		"бегавшись": "бега",
+		"новость":   "новост",
+		"длинный":   "длин",
+		"он":        "он",
+	}
+	for word, stem := range cases {
+		assert.Equal(t, stem, Stem(word), word)
+	}
+}
+
+func TestBannedWords_MasksAnyForm(t *testing.T) {
+	f := NewBannedWords([]string{"Дурак", ""}, Mask)
+
+	d, err := f.Check(context.Background(), Input{Text: "Сам ты ДУРАК, и друзья твои дураки!"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Mask, d.Action)
+	assert.Equal(t, "Сам ты Д****, и друзья твои д*****!", d.Text)
+	assert.Contains(t, d.Reason, "ДУРАК")
+}
+
+func TestBannedWords_LatinLookalikes(t *testing.T) {
+	f := NewBannedWords([]string{"дурак"}, Reject)
+
+	d, _ := f.Check(context.Background(), Input{Text: "дуpaк"})
+	assert.Equal(t, Reject, d.Action)
+	assert.Empty(t, d.Text)
+
+	// Чисто латинское слово не переводится в кириллицу
+	d, _ = f.Check(context.Background(), Input{Text: "dypak"})
+	assert.Equal(t, Allow, d.Action)
+}
+
+func TestLinkBlocklist(t *testing.T) {
+	f := NewLinkBlocklist([]string{"*.Spam.example", "bad.ru"}, Mask)
+
+	d, err := f.Check(context.Background(), Input{Text: "смотри https://www.spam.example/win?x=1 и good.ru, т.е. всё"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Mask, d.Action)
+	assert.Equal(t, "смотри [ссылка удалена] и good.ru, т.е. всё", d.Text)
+	assert.Equal(t, "ссылка на www.spam.example", d.Reason)
+
+	d, _ = f.Check(context.Background(), Input{Text: "notbad.ru"})
+	assert.Equal(t, Allow, d.Action)
+}
+
+type fakeCounter struct {
+	counts map[string]int64
+}
+
+func (c *fakeCounter) CountRepeat(_ context.Context, chatID, userID, digest string, _ time.Duration) (int64, error) {
+	c.counts[chatID+userID+digest]++
+	return c.counts[chatID+userID+digest], nil
+}
+
+func TestFlood(t *testing.T) {
+	f := NewFlood(&fakeCounter{counts: map[string]int64{}}, 2, time.Minute, Mask)
+	ctx := context.Background()
+
+	for _, text := range []string{"Купи слона", "  купи   СЛОНА "} {
+		d, err := f.Check(ctx, Input{ChatID: "chat1", AuthorID: "user1", Text: text})
+		assert.NoError(t, err)
+		assert.Equal(t, Allow, d.Action)
+	}
+	d, _ := f.Check(ctx, Input{ChatID: "chat1", AuthorID: "user1", Text: "купи слона"})
+	assert.Equal(t, Reject, d.Action, "Mask для флуда — отклонение")
+
+	d, _ = f.Check(ctx, Input{ChatID: "chat1", AuthorID: "user2", Text: "купи слона"})
+	assert.Equal(t, Allow, d.Action)
+}
+
+type staticFilter struct {
+	name string
+	d    Decision
+	err  error
+	seen *[]string
+}
+
+func (f staticFilter) Name() string { return f.name }
+
+func (f staticFilter) Check(_ context.Context, in Input) (Decision, error) {
+	*f.seen = append(*f.seen, in.Text)
+	return f.d, f.err
+}
+
+func TestPipeline_Order(t *testing.T) {
+	var seen []string
+	p := NewPipeline(
+		staticFilter{name: "a", d: Decision{Action: Mask, Text: "***", Reason: "слово"}, seen: &seen},
+		staticFilter{name: "broken", err: errors.New("redis down"), seen: &seen},
+		staticFilter{name: "b", d: Decision{Action: Hold, Reason: "ссылка"}, seen: &seen},
+		staticFilter{name: "c", d: Decision{Action: Allow}, seen: &seen},
+	)
+
+	res := p.Check(context.Background(), Input{Text: "abc"})
+
+	assert.Equal(t, Hold, res.Action)
+	assert.Equal(t, "***", res.Text)
+	assert.Equal(t, []string{"a: слово", "b: ссылка"}, res.Reasons)
+	assert.Equal(t, []string{"abc", "***", "***", "***"}, seen)
+}
+
+func TestPipeline_RejectStops(t *testing.T) {
+	var seen []string
+	p := NewPipeline(
+		staticFilter{name: "a", d: Decision{Action: Reject, Reason: "флуд"}, seen: &seen},
+		staticFilter{name: "b", d: Decision{Action: Hold}, seen: &seen},
+	)
+
+	res := p.Check(context.Background(), Input{Text: "abc"})
+
+	assert.Equal(t, Reject, res.Action)
+	assert.Len(t, seen, 1)
+}
+
+func TestPipeline_Nil(t *testing.T) {
+	var p *Pipeline
+
+	res := p.Check(context.Background(), Input{Text: "abc"})
+
+	assert.Equal(t, Result{Action: Allow, Text: "abc"}, res)
+	assert.Zero(t, p.Len())
+}
+
+func TestParseAction(t *testing.T) {
+	a, err := ParseAction(" Hold ")
+	assert.NoError(t, err)
+	assert.Equal(t, Hold, a)
+
+	_, err = ParseAction("ban")
+	assert.Error(t, err)
+}
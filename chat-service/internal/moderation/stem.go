@@ -0,0 +1,155 @@
+package moderation
+
+import (
+	"sort"
+	"strings"
+)
+
+// Стеммер для русского языка по алгоритму Snowball (Портера): словоформы «дурак», «дураки», «дураками»
+// сводятся к одной основе, поэтому в списке запрещённых слов достаточно одной формы.
+
+// Окончания каждой группы отсортированы от длинных к коротким: срезается самое длинное подходящее.
+// Окончания «…1» срезаются, только если перед ними стоит «а» или «я».
+var (
+	perfectiveGerund1 = byLength("в", "вши", "вшись")
+	perfectiveGerund2 = byLength("ив", "ивши", "ившись", "ыв", "ывши", "ывшись")
+	adjective         = byLength("ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею")
+	participle1 = byLength("ем", "нн", "вш", "ющ", "щ")
+	participle2 = byLength("ивш", "ывш", "ующ")
+	reflexive   = byLength("ся", "сь")
+	verb1       = byLength("ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно")
+	verb2       = byLength("ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю")
+	noun = byLength("а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я")
+	superlative   = byLength("ейш", "ейше")
+	derivational  = byLength("ост", "ость")
+	russianVowels = "аеиоуыэюя"
+)
+
+func byLength(endings ...string) [][]rune {
+	out := make([][]rune, len(endings))
+	for i, e := range endings {
+		out[i] = []rune(e)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return len(out[i]) > len(out[j]) })
+	return out
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune(russianVowels, r)
+}
+
+// Stem возвращает основу слова; слово должно быть уже нормализовано (нижний регистр, «ё» → «е»)
+func Stem(word string) string {
+	w := []rune(word)
+	rv, r2 := regions(w)
+	if rv >= len(w) {
+		return word
+	}
+
+	// Шаг 1: деепричастие, иначе возвратная частица и затем прилагательное, глагол или существительное
+	if cut, ok := trim(w, rv, perfectiveGerund1, perfectiveGerund2); ok {
+		w = cut
+	} else {
+		if cut, ok := trim(w, rv, nil, reflexive); ok {
+			w = cut
+		}
+		if cut, ok := trim(w, rv, nil, adjective); ok {
+			w = cut
+			if cut, ok := trim(w, rv, participle1, participle2); ok {
+				w = cut
+			}
+		} else if cut, ok := trim(w, rv, verb1, verb2); ok {
+			w = cut
+		} else if cut, ok := trim(w, rv, nil, noun); ok {
+			w = cut
+		}
+	}
+
+	// Шаг 2: конечная «и»
+	if cut, ok := trim(w, rv, nil, byLength("и")); ok {
+		w = cut
+	}
+
+	// Шаг 3: словообразовательный суффикс в R2
+	if cut, ok := trim(w, r2, nil, derivational); ok {
+		w = cut
+	}
+
+	// Шаг 4: превосходная степень, удвоенная «н» или мягкий знак
+	if cut, ok := trim(w, rv, nil, superlative); ok {
+		w = cut
+	}
+	if cut, ok := trim(w, rv, nil, byLength("нн")); ok {
+		w = append(cut, 'н')
+	} else if cut, ok := trim(w, rv, nil, byLength("ь")); ok {
+		w = cut
+	}
+	return string(w)
+}
+
+// regions возвращает начала областей RV (после первой гласной) и R2 (R1 от R1)
+func regions(w []rune) (rv, r2 int) {
+	rv, r1, r2 := len(w), len(w), len(w)
+	for i, r := range w {
+		if isVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+	after := func(from int) int {
+		for i := from + 1; i < len(w); i++ {
+			if !isVowel(w[i]) && isVowel(w[i-1]) {
+				return i + 1
+			}
+		}
+		return len(w)
+	}
+	r1 = after(0)
+	if r1 < len(w) {
+		r2 = after(r1)
+	}
+	return rv, r2
+}
+
+// trim срезает самое длинное окончание, целиком лежащее в области от start.
+// Окончания afterA срезаются, только если перед ними в той же области стоит «а» или «я».
+func trim(w []rune, start int, afterA, plain [][]rune) ([]rune, bool) {
+	best := -1
+	for _, e := range afterA {
+		pos := len(w) - len(e)
+		if pos-1 >= start && hasSuffix(w, e) && (w[pos-1] == 'а' || w[pos-1] == 'я') {
+			best = pos
+			break
+		}
+	}
+	for _, e := range plain {
+		pos := len(w) - len(e)
+		if best >= 0 && pos >= best {
+			break
+		}
+		if pos >= start && hasSuffix(w, e) {
+			best = pos
+			break
+		}
+	}
+	if best < 0 {
+		return w, false
+	}
+	return w[:best], true
+}
+
+func hasSuffix(w, suffix []rune) bool {
+	if len(suffix) > len(w) {
+		return false
+	}
+	off := len(w) - len(suffix)
+	for i, r := range suffix {
+		if w[off+i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"main/internal/domain"
 	userserviceclient "main/internal/user-service-client"
@@ -24,6 +25,8 @@ type Collection interface {
 	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
 	UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+	InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error)
 }
 
 type ChatRepo struct {
@@ -39,7 +42,7 @@ func NewChatRepo(db *mongo.Database, log zerolog.Logger) *ChatRepo {
 func NewTestChatRepo(col Collection, log zerolog.Logger) *ChatRepo {
 	return &ChatRepo{col: col, log: log}
 }
-func (r *ChatRepo) CreateDirect(userID, peerID string, userClient user.UserServiceClient) (domain.Chat, error) {
+func (r *ChatRepo) CreateDirect(ctx context.Context, userID, peerID string, userClient user.UserServiceClient) (domain.Chat, error) {
 	// Проверяем существование обоих пользователей
 	_, err := userserviceclient.GetUserInfo(userClient, userID)
 	if err != nil {
@@ -51,11 +54,16 @@ func (r *ChatRepo) CreateDirect(userID, peerID string, userClient user.UserServi
 		return domain.Chat{}, fmt.Errorf("пользователь %s не найден: %w", peerID, err)
 	}
 
+	// Заблокировавший не получает личный чат от заблокированного
+	if err := userserviceclient.CheckNotBlocked(userClient, userID, []string{peerID}); err != nil {
+		return domain.Chat{}, err
+	}
+
 	// Проверяем, не существует ли уже такой чат
 	existingChatID := userID + "_" + peerID
 	existingChatIDReverse := peerID + "_" + userID
 
-	count, err := r.col.CountDocuments(context.Background(), bson.M{
+	count, err := r.col.CountDocuments(ctx, bson.M{
 		"id": bson.M{"$in": []string{existingChatID, existingChatIDReverse}},
 	})
 	if err != nil {
@@ -74,11 +82,12 @@ func (r *ChatRepo) CreateDirect(userID, peerID string, userClient user.UserServi
 		CreatedBy: userID,
 		CreatedAt: time.Now().Unix(),
 	}
+	chat.LastMessageAt = chat.CreatedAt
 
-	_, err = r.col.InsertOne(context.Background(), chat)
+	_, err = r.col.InsertOne(ctx, chat)
 	return chat, err
 }
-func (r *ChatRepo) CreateGroup(creatorID string, members []string, title string, userClient user.UserServiceClient) (domain.Chat, error) {
+func (r *ChatRepo) CreateGroup(ctx context.Context, creatorID string, members []string, title string, userClient user.UserServiceClient) (domain.Chat, error) {
 	// Проверяем создателя
 	_, err := userserviceclient.GetUserInfo(userClient, creatorID)
 	if err != nil {
@@ -92,6 +101,9 @@ func (r *ChatRepo) CreateGroup(creatorID string, members []string, title string,
 			return domain.Chat{}, fmt.Errorf("участник %s не найден: %w", memberID, err)
 		}
 	}
+	if err := userserviceclient.CheckNotBlocked(userClient, creatorID, members); err != nil {
+		return domain.Chat{}, err
+	}
 
 	// Все пользователи существуют → создаём группу
 	groupID := uuid.New().String()
@@ -103,31 +115,34 @@ func (r *ChatRepo) CreateGroup(creatorID string, members []string, title string,
 		CreatedBy: creatorID,
 		CreatedAt: time.Now().Unix(),
 	}
-	ctx := context.Background()
+	chat.LastMessageAt = chat.CreatedAt
 	_, err = r.col.InsertOne(ctx, chat)
 	return chat, err
 }
 
-func (r *ChatRepo) UpdateGroup(chatID string, title *string, addMembers, removeMembers []string, requesterID string, userClient user.UserServiceClient) (domain.Chat, error) {
-
-	ctx := context.Background()
-
+// UpdateGroup меняет название и состав группы. Кроме чата возвращает, кто на самом деле
+// добавлен и исключён: уже состоявшие в чате и не состоявшие в нём в эти списки не попадают.
+func (r *ChatRepo) UpdateGroup(ctx context.Context, chatID string, title *string, addMembers, removeMembers []string, requesterID string, userClient user.UserServiceClient) (domain.Chat, []string, []string, error) {
 	// Загружаем чат
 	var chat domain.Chat
 	if err := r.col.FindOne(ctx, bson.M{"id": chatID}).Decode(&chat); err != nil {
-		return domain.Chat{}, fmt.Errorf("чат %s не найден: %w", chatID, err)
+		return domain.Chat{}, nil, nil, fmt.Errorf("чат %s не найден: %w", chatID, err)
 	}
+	before := chat
 
 	// Проверяем инициатора — только создатель может менять
 	if chat.CreatedBy != requesterID {
-		return domain.Chat{}, fmt.Errorf("пользователь %s не имеет права изменять чат %s", requesterID, chatID)
+		return domain.Chat{}, nil, nil, fmt.Errorf("пользователь %s не имеет права изменять чат %s", requesterID, chatID)
 	}
 	for _, memberID := range addMembers {
 		_, err := userserviceclient.GetUserInfo(userClient, memberID)
 		if err != nil {
-			return domain.Chat{}, fmt.Errorf("участник %s не найден: %w", memberID, err)
+			return domain.Chat{}, nil, nil, fmt.Errorf("участник %s не найден: %w", memberID, err)
 		}
 	}
+	if err := userserviceclient.CheckNotBlocked(userClient, requesterID, addMembers); err != nil {
+		return domain.Chat{}, nil, nil, err
+	}
 	// Формируем одно атомарное обновление
 	set := bson.M{}
 	if title != nil {
@@ -140,33 +155,138 @@ func (r *ChatRepo) UpdateGroup(chatID string, title *string, addMembers, removeM
 	if len(addMembers) > 0 {
 		_, err := r.col.UpdateOne(ctx,
 			bson.M{"id": chatID},
-			bson.M{"$addToSet": bson.M{"memberids": bson.M{"$each": addMembers}}},
+			bson.M{"$addToSet": bson.M{"member_ids": bson.M{"$each": addMembers}}},
 		)
 		if err != nil {
-			return domain.Chat{}, err
+			return domain.Chat{}, nil, nil, err
 		}
 	}
 
 	if len(removeMembers) > 0 {
 		_, err := r.col.UpdateOne(ctx,
 			bson.M{"id": chatID},
-			bson.M{"$pullAll": bson.M{"memberids": removeMembers}},
+			bson.M{"$pullAll": bson.M{"member_ids": removeMembers}},
 		)
 		if err != nil {
-			return domain.Chat{}, err
+			return domain.Chat{}, nil, nil, err
 		}
 	}
 	if len(ops) > 0 {
 		if _, err := r.col.UpdateOne(ctx, bson.M{"id": chatID}, ops); err != nil {
-			return domain.Chat{}, err
+			return domain.Chat{}, nil, nil, err
 		}
 	}
 
-	// Возвращаем обновлённый чат
+	// Возвращаем обновлённый чат; chat обнуляем — иначе декодер переиспользует срезы, общие с before
+	chat = domain.Chat{}
 	if err := r.col.FindOne(ctx, bson.M{"id": chatID}).Decode(&chat); err != nil {
-		return domain.Chat{}, err
+		return domain.Chat{}, nil, nil, err
+	}
+	var added, removed []string
+	for _, id := range addMembers {
+		if before.RoleOf(id) == "" && chat.RoleOf(id) != "" {
+			added = append(added, id)
+		}
+	}
+	for _, id := range removeMembers {
+		if before.RoleOf(id) != "" && chat.RoleOf(id) == "" {
+			removed = append(removed, id)
+		}
+	}
+	return chat, added, removed, nil
+}
+
+// CreateChannel сохраняет канал; занятое имя отсекает уникальный индекс по handle
+func (r *ChatRepo) CreateChannel(chat domain.Chat) error {
+	_, err := r.col.InsertOne(context.Background(), chat)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrHandleTaken
+	}
+	return err
+}
+
+func (r *ChatRepo) CreateSecret(ctx context.Context, chat domain.Chat) error {
+	_, err := r.col.InsertOne(ctx, chat)
+	return err
+}
+
+func (r *ChatRepo) GetByHandle(handle string) (domain.Chat, error) {
+	var chat domain.Chat
+	err := r.col.FindOne(context.Background(), bson.M{"kind": domain.ChatKindChannel, "handle": handle}).Decode(&chat)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Chat{}, domain.ErrChatNotFound
 	}
-	return chat, nil
+	return chat, err
+}
+
+// IncSubscribers меняет счётчик подписчиков канала на delta
+func (r *ChatRepo) IncSubscribers(ctx context.Context, chatID string, delta int64) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"id": chatID},
+		bson.M{"$inc": bson.M{"subscriber_count": delta}},
+	)
+	return err
+}
+
+// SetChannelAdmin назначает или снимает администратора канала (администраторы хранятся в member_ids)
+func (r *ChatRepo) SetChannelAdmin(chatID, userID string, admin bool) (domain.Chat, error) {
+	update := bson.M{"$pull": bson.M{"member_ids": userID}}
+	if admin {
+		update = bson.M{"$addToSet": bson.M{"member_ids": userID}}
+	}
+
+	var chat domain.Chat
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": chatID, "kind": domain.ChatKindChannel},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&chat)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Chat{}, domain.ErrChatNotFound
+	}
+	return chat, err
+}
+
+// ListAll обходит все чаты по возрастанию id, начиная после afterID (для фоновых задач)
+func (r *ChatRepo) ListAll(afterID string, limit int) ([]domain.Chat, error) {
+	ctx := context.Background()
+
+	filter := bson.M{}
+	if afterID != "" {
+		filter["id"] = bson.M{"$gt": afterID}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var chats []domain.Chat
+	if err := cur.All(ctx, &chats); err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+// AddMember добавляет участника; false — он уже состоял в чате
+func (r *ChatRepo) AddMember(ctx context.Context, chatID, userID string) (domain.Chat, bool, error) {
+	var chat domain.Chat
+	err := r.col.FindOneAndUpdate(ctx,
+		bson.M{"id": chatID, "member_ids": bson.M{"$ne": userID}},
+		bson.M{"$push": bson.M{"member_ids": userID}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&chat)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		chat, err = r.Get(chatID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Chat{}, false, domain.ErrChatNotFound
+		}
+		return chat, false, err
+	}
+	return chat, err == nil, err
 }
 
 func (r *ChatRepo) Get(chatID string) (domain.Chat, error) {
@@ -175,10 +295,43 @@ func (r *ChatRepo) Get(chatID string) (domain.Chat, error) {
 	return chat, err
 }
 
-func (r *ChatRepo) List(userID string, limit int, cursor string) ([]domain.Chat, string, error) {
+// List возвращает чаты пользователя от недавно активных к давним (индекс member_ids, last_message_at, id)
+func (r *ChatRepo) List(q domain.ChatQuery) ([]domain.Chat, string, error) {
 	ctx := context.Background()
-	filter := bson.M{"member_ids": bson.M{"$in": []string{userID}}}
-	opts := options.Find().SetLimit(int64(limit))
+
+	filter := bson.M{"member_ids": q.UserID}
+	if len(q.ChannelIDs) > 0 {
+		// $or курсора ниже занимает верхний уровень, поэтому условие членства — внутри $and
+		filter = bson.M{"$and": bson.A{bson.M{"$or": bson.A{
+			bson.M{"member_ids": q.UserID},
+			bson.M{"id": bson.M{"$in": q.ChannelIDs}},
+		}}}}
+	}
+	idCond := bson.M{}
+	if q.OnlyIDs != nil {
+		idCond["$in"] = q.OnlyIDs
+	}
+	if len(q.ExcludeIDs) > 0 {
+		idCond["$nin"] = q.ExcludeIDs
+	}
+	if len(idCond) > 0 {
+		filter["id"] = idCond
+	}
+	if q.Cursor != "" {
+		ts, chatID, err := parseKeysetCursor(q.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"last_message_at": bson.M{"$lt": ts}},
+			bson.M{"last_message_at": ts, "id": bson.M{"$lt": chatID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(q.Limit)).
+		SetSort(bson.D{{Key: "last_message_at", Value: -1}, {Key: "id", Value: -1}})
+
 	cur, err := r.col.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, "", err
@@ -190,7 +343,123 @@ func (r *ChatRepo) List(userID string, limit int, cursor string) ([]domain.Chat,
 	}
 	nextCursor := ""
 	if len(chats) > 0 {
-		nextCursor = chats[len(chats)-1].ID
+		nextCursor = chats[len(chats)-1].Cursor()
 	}
 	return chats, nextCursor, nil
 }
+
+// SetLastMessage обновляет превью, если сообщение не старее текущего последнего.
+// Так же обновляются правка и удаление последнего сообщения (тот же seq).
+func (r *ChatRepo) SetLastMessage(chatID string, p domain.MessagePreview) error {
+	filter := bson.M{
+		"id":               chatID,
+		"last_message.seq": bson.M{"$not": bson.M{"$gt": p.Seq}},
+	}
+	update := bson.M{"$set": bson.M{
+		"last_message":    p,
+		"last_message_at": p.CreatedAt,
+	}}
+	_, err := r.col.UpdateOne(context.Background(), filter, update)
+	return err
+}
+
+// NextSeq атомарно выдаёт следующий номер сообщения в чате
+func (r *ChatRepo) NextSeq(chatID string) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"last_seq": 1})
+
+	var chat domain.Chat
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": chatID},
+		bson.M{"$inc": bson.M{"last_seq": 1}},
+		opts,
+	).Decode(&chat)
+	if err != nil {
+		return 0, err
+	}
+	return chat.LastSeq, nil
+}
+
+// ReserveSeqs атомарно выдаёт n номеров подряд и возвращает последний из них
+func (r *ChatRepo) ReserveSeqs(chatID string, n int64) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"last_seq": 1})
+
+	var chat domain.Chat
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": chatID},
+		bson.M{"$inc": bson.M{"last_seq": n}},
+		opts,
+	).Decode(&chat)
+	if err != nil {
+		return 0, err
+	}
+	return chat.LastSeq, nil
+}
+
+// SetMessageTTL задаёт таймер автоудаления; 0 — выключить
+func (r *ChatRepo) SetMessageTTL(chatID string, ttl int64) (domain.Chat, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var chat domain.Chat
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": chatID},
+		bson.M{"$set": bson.M{"message_ttl": ttl}},
+		opts,
+	).Decode(&chat)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Chat{}, domain.ErrChatNotFound
+	}
+	return chat, err
+}
+
+// SetSlowMode задаёт интервал медленного режима в секундах; 0 — выключить
+func (r *ChatRepo) SetSlowMode(chatID string, seconds int64) (domain.Chat, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var chat domain.Chat
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": chatID},
+		bson.M{"$set": bson.M{"slow_mode_seconds": seconds}},
+		opts,
+	).Decode(&chat)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Chat{}, domain.ErrChatNotFound
+	}
+	return chat, err
+}
+
+func (r *ChatRepo) Pin(chatID string, pin domain.PinnedMessage) (domain.Chat, error) {
+	ctx := context.Background()
+
+	// Добавляем закрепление, только если сообщение ещё не закреплено
+	filter := bson.M{"id": chatID, "pinned.message_id": bson.M{"$ne": pin.MessageID}}
+	update := bson.M{"$push": bson.M{"pinned": pin}}
+
+	result, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if result.MatchedCount == 0 {
+		return domain.Chat{}, domain.ErrAlreadyPinned
+	}
+	return r.Get(chatID)
+}
+
+func (r *ChatRepo) Unpin(chatID, messageID string) (domain.Chat, error) {
+	ctx := context.Background()
+
+	filter := bson.M{"id": chatID, "pinned.message_id": messageID}
+	update := bson.M{"$pull": bson.M{"pinned": bson.M{"message_id": messageID}}}
+
+	result, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if result.MatchedCount == 0 {
+		return domain.Chat{}, domain.ErrNotPinned
+	}
+	return r.Get(chatID)
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChatStateRepo struct {
+	col Collection
+}
+
+func NewChatStateRepo(db *mongo.Database) *ChatStateRepo {
+	return &ChatStateRepo{col: db.Collection("chat_states")}
+}
+
+// NewTestChatStateRepo - конструктор для тестов
+func NewTestChatStateRepo(col Collection) *ChatStateRepo {
+	return &ChatStateRepo{col: col}
+}
+
+// Get возвращает настройки чата; если записи нет — значения по умолчанию
+func (r *ChatStateRepo) Get(chatID, userID string) (domain.UserChatState, error) {
+	var st domain.UserChatState
+	err := r.col.FindOne(context.Background(), bson.M{"chat_id": chatID, "user_id": userID}).Decode(&st)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.UserChatState{ChatID: chatID, UserID: userID}, nil
+	}
+	return st, err
+}
+
+func (r *ChatStateRepo) ListByUser(userID string) ([]domain.UserChatState, error) {
+	ctx := context.Background()
+
+	cur, err := r.col.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var states []domain.UserChatState
+	if err := cur.All(ctx, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+func (r *ChatStateRepo) CountPinned(userID string) (int64, error) {
+	return r.col.CountDocuments(context.Background(), bson.M{"user_id": userID, "pinned": true})
+}
+
+func (r *ChatStateRepo) SetPinned(chatID, userID string, pinned bool, at int64) error {
+	set := bson.M{"pinned": pinned, "pinned_at": int64(0)}
+	if pinned {
+		set["pinned_at"] = at
+		set["archived"] = false
+	}
+	return r.set(chatID, userID, set)
+}
+
+func (r *ChatStateRepo) SetArchived(chatID, userID string, archived bool) error {
+	set := bson.M{"archived": archived}
+	if archived {
+		set["pinned"] = false
+		set["pinned_at"] = int64(0)
+	}
+	return r.set(chatID, userID, set)
+}
+
+func (r *ChatStateRepo) SetMutedUntil(chatID, userID string, until int64) error {
+	return r.set(chatID, userID, bson.M{"muted_until": until})
+}
+
+func (r *ChatStateRepo) SetMarkedUnread(chatID, userID string, unread bool) error {
+	return r.set(chatID, userID, bson.M{"marked_unread": unread})
+}
+
+func (r *ChatStateRepo) set(chatID, userID string, set bson.M) error {
+	_, err := r.col.UpdateOne(context.Background(),
+		bson.M{"chat_id": chatID, "user_id": userID},
+		bson.M{"$set": set},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DraftRepo struct {
+	col Collection
+}
+
+func NewDraftRepo(db *mongo.Database) *DraftRepo {
+	return &DraftRepo{col: db.Collection("drafts")}
+}
+
+// NewTestDraftRepo - конструктор для тестов
+func NewTestDraftRepo(col Collection) *DraftRepo {
+	return &DraftRepo{col: col}
+}
+
+// Save обновляет черновик, если сохранённая версия равна baseVersion.
+// Если запись есть, но версия другая, фильтр её не находит и upsert упирается в уникальный индекс
+// (chat_id, user_id) — это означает, что черновик уже правили.
+func (r *DraftRepo) Save(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error) {
+	saved, err := r.save(ctx, bson.M{"chat_id": d.ChatID, "user_id": d.UserID, "version": baseVersion}, d)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.Draft{}, false, nil
+	}
+	if err != nil {
+		return domain.Draft{}, false, err
+	}
+	return saved, true, nil
+}
+
+// Overwrite записывает черновик поверх любой версии
+func (r *DraftRepo) Overwrite(ctx context.Context, d domain.Draft) (domain.Draft, error) {
+	return r.save(ctx, bson.M{"chat_id": d.ChatID, "user_id": d.UserID}, d)
+}
+
+func (r *DraftRepo) save(ctx context.Context, filter bson.M, d domain.Draft) (domain.Draft, error) {
+	var saved domain.Draft
+	err := r.col.FindOneAndUpdate(ctx, filter,
+		bson.M{
+			"$set": bson.M{
+				"text":        d.Text,
+				"reply_to_id": d.ReplyToID,
+				"media":       d.Media,
+				"updated_at":  d.UpdatedAt,
+				"device_id":   d.DeviceID,
+			},
+			"$inc": bson.M{"version": int64(1)},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	return saved, err
+}
+
+// Get возвращает черновик; если записи нет — пустой
+func (r *DraftRepo) Get(chatID, userID string) (domain.Draft, error) {
+	var d domain.Draft
+	err := r.col.FindOne(context.Background(), bson.M{"chat_id": chatID, "user_id": userID}).Decode(&d)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Draft{ChatID: chatID, UserID: userID}, nil
+	}
+	return d, err
+}
+
+// ListByUser возвращает непустые черновики пользователя, свежие — первыми
+func (r *DraftRepo) ListByUser(userID string) ([]domain.Draft, error) {
+	ctx := context.Background()
+
+	cur, err := r.col.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var all []domain.Draft
+	if err := cur.All(ctx, &all); err != nil {
+		return nil, err
+	}
+	drafts := make([]domain.Draft, 0, len(all))
+	for _, d := range all {
+		if !d.IsEmpty() {
+			drafts = append(drafts, d)
+		}
+	}
+	return drafts, nil
+}
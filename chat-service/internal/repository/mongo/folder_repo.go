@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChatFolderRepo struct {
+	col Collection
+}
+
+func NewChatFolderRepo(db *mongo.Database) *ChatFolderRepo {
+	return &ChatFolderRepo{col: db.Collection("chat_folders")}
+}
+
+// NewTestChatFolderRepo - конструктор для тестов
+func NewTestChatFolderRepo(col Collection) *ChatFolderRepo {
+	return &ChatFolderRepo{col: col}
+}
+
+func (r *ChatFolderRepo) Create(ctx context.Context, f domain.ChatFolder) error {
+	_, err := r.col.InsertOne(ctx, f)
+	return err
+}
+
+func (r *ChatFolderRepo) Get(id, userID string) (domain.ChatFolder, error) {
+	var f domain.ChatFolder
+	err := r.col.FindOne(context.Background(), bson.M{"id": id, "user_id": userID}).Decode(&f)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ChatFolder{}, domain.ErrFolderNotFound
+	}
+	return f, err
+}
+
+func (r *ChatFolderRepo) ListByUser(userID string) ([]domain.ChatFolder, error) {
+	ctx := context.Background()
+
+	cur, err := r.col.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "position", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var folders []domain.ChatFolder
+	if err := cur.All(ctx, &folders); err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+func (r *ChatFolderRepo) Count(userID string) (int64, error) {
+	return r.col.CountDocuments(context.Background(), bson.M{"user_id": userID})
+}
+
+// Update заменяет название, списки и правила; позиция меняется только через SetPositions
+func (r *ChatFolderRepo) Update(ctx context.Context, f domain.ChatFolder) error {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"id": f.ID, "user_id": f.UserID},
+		bson.M{"$set": bson.M{
+			"title":       f.Title,
+			"include_ids": f.IncludeIDs,
+			"exclude_ids": f.ExcludeIDs,
+			"rules":       f.Rules,
+			"updated_at":  f.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrFolderNotFound
+	}
+	return nil
+}
+
+func (r *ChatFolderRepo) Delete(ctx context.Context, id, userID string) error {
+	res, err := r.col.DeleteMany(ctx, bson.M{"id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrFolderNotFound
+	}
+	return nil
+}
+
+// SetPositions расставляет папкам позиции по порядку ids
+func (r *ChatFolderRepo) SetPositions(ctx context.Context, userID string, ids []string, at int64) error {
+	for i, id := range ids {
+		_, err := r.col.UpdateOne(ctx,
+			bson.M{"id": id, "user_id": userID},
+			bson.M{"$set": bson.M{"position": i, "updated_at": at}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
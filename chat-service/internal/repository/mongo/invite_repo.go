@@ -0,0 +1,170 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type InviteRepo struct {
+	links    Collection
+	requests Collection
+}
+
+func NewInviteRepo(db *mongo.Database) *InviteRepo {
+	return &InviteRepo{
+		links:    db.Collection("invite_links"),
+		requests: db.Collection("join_requests"),
+	}
+}
+
+// NewTestInviteRepo - конструктор для тестов
+func NewTestInviteRepo(links, requests Collection) *InviteRepo {
+	return &InviteRepo{links: links, requests: requests}
+}
+
+func (r *InviteRepo) CreateLink(link domain.InviteLink) error {
+	_, err := r.links.InsertOne(context.Background(), link)
+	return err
+}
+
+func (r *InviteRepo) GetLink(code string) (domain.InviteLink, error) {
+	var link domain.InviteLink
+	err := r.links.FindOne(context.Background(), bson.M{"code": code}).Decode(&link)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.InviteLink{}, domain.ErrInviteNotFound
+	}
+	return link, err
+}
+
+func (r *InviteRepo) RevokeLink(code string) error {
+	res, err := r.links.UpdateOne(context.Background(),
+		bson.M{"code": code},
+		bson.M{"$set": bson.M{"revoked": true}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrInviteNotFound
+	}
+	return nil
+}
+
+// UseLink засчитывает использование, если ссылка не отозвана, не истекла и лимит не исчерпан
+func (r *InviteRepo) UseLink(code string, now int64) (domain.InviteLink, error) {
+	filter := bson.M{
+		"code":    code,
+		"revoked": false,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"expires_at": 0},
+				bson.M{"expires_at": bson.M{"$gt": now}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"usage_limit": 0},
+				bson.M{"$expr": bson.M{"$lt": bson.A{"$usage_count", "$usage_limit"}}},
+			}},
+		},
+	}
+
+	var link domain.InviteLink
+	err := r.links.FindOneAndUpdate(context.Background(), filter,
+		bson.M{"$inc": bson.M{"usage_count": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&link)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := r.GetLink(code); err != nil {
+			return domain.InviteLink{}, err
+		}
+		return domain.InviteLink{}, domain.ErrInviteUnusable
+	}
+	return link, err
+}
+
+func (r *InviteRepo) ReleaseLink(code string) error {
+	_, err := r.links.UpdateOne(context.Background(),
+		bson.M{"code": code, "usage_count": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"usage_count": -1}},
+	)
+	return err
+}
+
+// CreateRequest создаёт заявку или возвращает уже ожидающую заявку пользователя в этот чат
+func (r *InviteRepo) CreateRequest(req domain.JoinRequest) (domain.JoinRequest, error) {
+	var stored domain.JoinRequest
+	err := r.requests.FindOneAndUpdate(context.Background(),
+		bson.M{"chat_id": req.ChatID, "user_id": req.UserID, "status": domain.JoinRequestPending},
+		bson.M{"$setOnInsert": req},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&stored)
+	return stored, err
+}
+
+func (r *InviteRepo) GetRequest(id string) (domain.JoinRequest, error) {
+	var req domain.JoinRequest
+	err := r.requests.FindOne(context.Background(), bson.M{"id": id}).Decode(&req)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.JoinRequest{}, domain.ErrJoinRequestNotFound
+	}
+	return req, err
+}
+
+// ListPendingRequests возвращает ожидающие заявки от старых к новым.
+// Курсор имеет вид "created_at:id".
+func (r *InviteRepo) ListPendingRequests(chatID string, limit int, cursor string) ([]domain.JoinRequest, string, error) {
+	ctx := context.Background()
+
+	filter := bson.M{"chat_id": chatID, "status": domain.JoinRequestPending}
+	if cursor != "" {
+		ts, id, err := parseKeysetCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$gt": ts}},
+			bson.M{"created_at": ts, "id": bson.M{"$gt": id}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}})
+
+	cur, err := r.requests.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	var reqs []domain.JoinRequest
+	if err := cur.All(ctx, &reqs); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(reqs) > 0 {
+		last := reqs[len(reqs)-1]
+		nextCursor = fmt.Sprintf("%d:%s", last.CreatedAt, last.ID)
+	}
+	return reqs, nextCursor, nil
+}
+
+// DecideRequest переводит ожидающую заявку в approved или rejected; решить заявку можно один раз
+func (r *InviteRepo) DecideRequest(ctx context.Context, id string, status domain.JoinRequestStatus, decidedBy string, now int64) (domain.JoinRequest, error) {
+	var req domain.JoinRequest
+	err := r.requests.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": domain.JoinRequestPending},
+		bson.M{"$set": bson.M{"status": status, "decided_by": decidedBy, "decided_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&req)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.JoinRequest{}, domain.ErrJoinRequestNotFound
+	}
+	return req, err
+}
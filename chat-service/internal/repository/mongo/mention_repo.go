@@ -0,0 +1,116 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MentionRepo struct {
+	col Collection
+}
+
+func NewMentionRepo(db *mongo.Database) *MentionRepo {
+	return &MentionRepo{col: db.Collection("mentions")}
+}
+
+// NewTestMentionRepo - конструктор для тестов
+func NewTestMentionRepo(col Collection) *MentionRepo {
+	return &MentionRepo{col: col}
+}
+
+func (r *MentionRepo) Add(mentions []domain.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(mentions))
+	for i, m := range mentions {
+		docs[i] = m
+	}
+	_, err := r.col.InsertMany(context.Background(), docs)
+	return err
+}
+
+// ListUnread возвращает непрочитанные упоминания от старых к новым.
+// Курсор имеет вид "created_at:message_id"; с limit = 1 это переход к следующему упоминанию.
+func (r *MentionRepo) ListUnread(userID, chatID string, limit int, cursor string) ([]domain.Mention, string, error) {
+	ctx := context.Background()
+
+	filter := unreadMentionsFilter(userID, chatID)
+	if cursor != "" {
+		ts, msgID, err := parseKeysetCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$gt": ts}},
+			bson.M{"created_at": ts, "message_id": bson.M{"$gt": msgID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "message_id", Value: 1}})
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	var mentions []domain.Mention
+	if err := cur.All(ctx, &mentions); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(mentions) > 0 {
+		last := mentions[len(mentions)-1]
+		nextCursor = fmt.Sprintf("%d:%s", last.CreatedAt, last.MessageID)
+	}
+	return mentions, nextCursor, nil
+}
+
+func (r *MentionRepo) CountUnread(userID, chatID string) (int64, error) {
+	return r.col.CountDocuments(context.Background(), unreadMentionsFilter(userID, chatID))
+}
+
+// MarkRead снимает все упоминания в чате до сообщения upToSeq включительно
+func (r *MentionRepo) MarkRead(userID, chatID string, upToSeq int64) error {
+	filter := bson.M{"user_id": userID, "chat_id": chatID, "seq": bson.M{"$lte": upToSeq}, "read": false}
+	_, err := r.col.UpdateMany(context.Background(), filter, bson.M{"$set": bson.M{"read": true}})
+	return err
+}
+
+func (r *MentionRepo) DeleteByMessages(messageIDs []string) error {
+	_, err := r.col.DeleteMany(context.Background(), bson.M{"message_id": bson.M{"$in": messageIDs}})
+	return err
+}
+
+// Пустой chatID — упоминания во всех чатах пользователя
+func unreadMentionsFilter(userID, chatID string) bson.M {
+	filter := bson.M{"user_id": userID, "read": false}
+	if chatID != "" {
+		filter["chat_id"] = chatID
+	}
+	return filter
+}
+
+// parseKeysetCursor разбирает курсор вида "время:id"
+func parseKeysetCursor(cursor string) (int64, string, error) {
+	parts := strings.SplitN(cursor, ":", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("%w: некорректный курсор: %s", domain.ErrInvalidArgument, cursor)
+	}
+	ts, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: некорректный курсор: %v", domain.ErrInvalidArgument, err)
+	}
+	return ts, parts[1], nil
+}
@@ -12,44 +12,115 @@ import (
 )
 
 type MessageRepo struct {
-	col Collection
+	col       Collection
+	revisions Collection
+	votes     Collection
 }
 
 func NewMessageRepo(db *mongo.Database) *MessageRepo {
 	return &MessageRepo{
-		col: db.Collection("messages"),
+		col:       db.Collection("messages"),
+		revisions: db.Collection("message_revisions"),
+		votes:     db.Collection("poll_votes"),
 	}
 }
 
 // NewTestMessageRepo - конструктор для тестов
-func NewTestMessageRepo(col Collection) *MessageRepo {
-	return &MessageRepo{col: col}
+func NewTestMessageRepo(col, revisions, votes Collection) *MessageRepo {
+	return &MessageRepo{col: col, revisions: revisions, votes: votes}
 }
 
-func (r *MessageRepo) Send(m domain.Message) (domain.Message, error) {
-	m.ID = uuid.New().String()
-	m.CreatedAt = time.Now().Unix()
-	m.ReadBy = []domain.ReadInfo{}
+func (r *MessageRepo) Send(ctx context.Context, m domain.Message) (domain.Message, error) {
+	// id и время, заданные сервисом, сохраняются: по ним отложенное сообщение узнаёт уже отправленное
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	if m.CreatedAt == 0 {
+		m.CreatedAt = time.Now().Unix()
+	}
 	m.SavedBy = []domain.SavedInfo{}
 
-	_, err := r.col.InsertOne(context.Background(), m)
+	_, err := r.col.InsertOne(ctx, m)
 	return m, err
 }
 
+// InsertMany сохраняет готовые сообщения как есть: id, seq и время задаёт вызывающий (импорт чата)
+func (r *MessageRepo) InsertMany(ctx context.Context, msgs []domain.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(msgs))
+	for i, m := range msgs {
+		if m.SavedBy == nil {
+			m.SavedBy = []domain.SavedInfo{}
+		}
+		docs[i] = m
+	}
+	_, err := r.col.InsertMany(ctx, docs)
+	return err
+}
+
 func (r *MessageRepo) Get(id string) (domain.Message, error) {
 	var m domain.Message
 	err := r.col.FindOne(context.Background(), bson.M{"id": id}).Decode(&m)
 	return m, err
 }
 
-func (r *MessageRepo) Update(messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error) {
+// GetMany возвращает найденные сообщения в произвольном порядке; отсутствующие id пропускаются
+func (r *MessageRepo) GetMany(ids []string) ([]domain.Message, error) {
 	ctx := context.Background()
 
+	cur, err := r.col.Find(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var msgs []domain.Message
+	if err := cur.All(ctx, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// Update сохраняет текущую версию в историю правок и перезаписывает сообщение. Обе записи идут с ctx
+// вызывающего, чтобы в транзакции откатиться вместе; без транзакции ревизия удаляется, если
+// сообщение не обновилось, — иначе уникальный индекс (message_id, revision) заблокировал бы следующие правки.
+func (r *MessageRepo) Update(ctx context.Context, messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error) {
 	filter := bson.M{"id": messageID, "author_id": authorID, "deleted": false}
+
+	// Сохраняем текущую версию в историю перед перезаписью
+	var current domain.Message
+	if err := r.col.FindOne(ctx, filter).Decode(&current); err != nil {
+		return domain.Message{}, err
+	}
+
+	now := time.Now().Unix()
+	versionCreatedAt := current.CreatedAt
+	if current.Edited {
+		versionCreatedAt = current.UpdatedAt
+	}
+	// Уникальный индекс (message_id, revision) не даст двум параллельным правкам записать одну ревизию
+	revision := current.EditCount + 1
+	if _, err := r.revisions.InsertOne(ctx, domain.MessageRevision{
+		MessageID:  current.ID,
+		ChatID:     current.ChatID,
+		AuthorID:   current.AuthorID,
+		Revision:   revision,
+		Text:       current.Text,
+		Media:      current.Media,
+		CreatedAt:  versionCreatedAt,
+		ReplacedAt: now,
+	}); err != nil {
+		return domain.Message{}, err
+	}
+
 	update := bson.M{
 		"$set": bson.M{
-			"updated_at": time.Now().Unix(),
+			"updated_at": now,
+			"edited":     true,
 		},
+		"$inc": bson.M{"edit_count": 1},
 	}
 
 	if text != nil {
@@ -60,21 +131,21 @@ func (r *MessageRepo) Update(messageID, authorID string, text *string, media *[]
 	}
 
 	result, err := r.col.UpdateOne(ctx, filter, update)
+	if err == nil && result.MatchedCount == 0 {
+		// Сообщение удалили между чтением и записью
+		err = mongo.ErrNoDocuments
+	}
 	if err != nil {
+		_, _ = r.revisions.DeleteMany(ctx, bson.M{"message_id": current.ID, "revision": revision})
 		return domain.Message{}, err
 	}
-	if result.MatchedCount == 0 {
-		return domain.Message{}, mongo.ErrNoDocuments
-	}
 
 	var msg domain.Message
 	err = r.col.FindOne(ctx, bson.M{"id": messageID}).Decode(&msg)
 	return msg, err
 }
 
-func (r *MessageRepo) Delete(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error) {
-	ctx := context.Background()
-
+func (r *MessageRepo) Delete(ctx context.Context, messageIDs []string, requesterID string) ([]domain.Message, error) {
 	filter := bson.M{
 		"id":        bson.M{"$in": messageIDs},
 		"author_id": requesterID,
@@ -100,18 +171,13 @@ func (r *MessageRepo) Delete(messageIDs []string, hard bool, requesterID string)
 		idsToDelete = append(idsToDelete, msg.ID)
 	}
 
-	if hard {
-		_, err = r.col.DeleteMany(ctx, bson.M{"id": bson.M{"$in": idsToDelete}})
-	} else {
-		update := bson.M{
-			"$set": bson.M{
-				"deleted":    true,
-				"deleted_at": time.Now().Unix(),
-			},
-		}
-		_, err = r.col.UpdateMany(ctx, bson.M{"id": bson.M{"$in": idsToDelete}}, update)
+	update := bson.M{
+		"$set": bson.M{
+			"deleted":    true,
+			"deleted_at": time.Now().Unix(),
+		},
 	}
-
+	_, err = r.col.UpdateMany(ctx, bson.M{"id": bson.M{"$in": idsToDelete}}, update)
 	if err != nil {
 		return nil, err
 	}
@@ -119,58 +185,89 @@ func (r *MessageRepo) Delete(messageIDs []string, hard bool, requesterID string)
 	return messages, nil
 }
 
-func (r *MessageRepo) List(chatID string, limit int, cursor string) ([]domain.Message, string, error) {
-	ctx := context.Background()
+// List выбирает страницу истории по seq. Каждая сторона запрашивается с лишним документом,
+// чтобы без отдельного подсчёта понять, есть ли сообщения дальше.
+// Запросы покрываются индексом { chat_id: 1, deleted: 1, seq: 1 }.
+func (r *MessageRepo) List(q domain.MessageQuery) (domain.MessagePage, error) {
+	var (
+		older, newer           []domain.Message
+		olderLimit, newerLimit int
+		err                    error
+	)
+
+	switch {
+	case q.AfterSeq > 0:
+		newerLimit = q.Limit
+		if newer, err = r.listRange(q.ChatID, bson.M{"$gt": q.AfterSeq}, 1, newerLimit+1); err != nil {
+			return domain.MessagePage{}, err
+		}
+		if older, err = r.listRange(q.ChatID, bson.M{"$gt": 0, "$lte": q.AfterSeq}, -1, 1); err != nil {
+			return domain.MessagePage{}, err
+		}
+	case q.AroundSeq > 0:
+		olderLimit = q.Limit / 2
+		newerLimit = q.Limit - olderLimit
+		if older, err = r.listRange(q.ChatID, bson.M{"$gt": 0, "$lt": q.AroundSeq}, -1, olderLimit+1); err != nil {
+			return domain.MessagePage{}, err
+		}
+		if newer, err = r.listRange(q.ChatID, bson.M{"$gte": q.AroundSeq}, 1, newerLimit+1); err != nil {
+			return domain.MessagePage{}, err
+		}
+	default:
+		olderLimit = q.Limit
+		seq := bson.M{"$gt": 0}
+		if q.BeforeSeq > 0 {
+			seq["$lt"] = q.BeforeSeq
+		}
+		if older, err = r.listRange(q.ChatID, seq, -1, olderLimit+1); err != nil {
+			return domain.MessagePage{}, err
+		}
+		if q.BeforeSeq > 0 {
+			if newer, err = r.listRange(q.ChatID, bson.M{"$gte": q.BeforeSeq}, 1, 1); err != nil {
+				return domain.MessagePage{}, err
+			}
+		}
+	}
 
-	filter := bson.M{"chat_id": chatID, "deleted": false}
+	page := domain.MessagePage{
+		HasBefore: len(older) > olderLimit,
+		HasAfter:  len(newer) > newerLimit,
+	}
+	if page.HasBefore {
+		older = older[:olderLimit]
+	}
+	if page.HasAfter {
+		newer = newer[:newerLimit]
+	}
 
-	if cursor != "" {
-		filter["id"] = bson.M{"$gt": cursor}
+	page.Messages = make([]domain.Message, 0, len(older)+len(newer))
+	for i := len(older) - 1; i >= 0; i-- {
+		page.Messages = append(page.Messages, older[i])
 	}
+	page.Messages = append(page.Messages, newer...)
+	return page, nil
+}
+
+// listRange возвращает до limit сообщений чата с условием на seq; dir = 1 — по возрастанию, -1 — по убыванию
+func (r *MessageRepo) listRange(chatID string, seq bson.M, dir, limit int) ([]domain.Message, error) {
+	ctx := context.Background()
 
+	filter := bson.M{"chat_id": chatID, "deleted": false, "seq": seq}
 	opts := options.Find().
 		SetLimit(int64(limit)).
-		SetSort(bson.D{{Key: "id", Value: 1}})
+		SetSort(bson.D{{Key: "seq", Value: dir}})
 
 	cur, err := r.col.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 	defer cur.Close(ctx)
 
 	var messages []domain.Message
 	if err := cur.All(ctx, &messages); err != nil {
-		return nil, "", err
-	}
-
-	nextCursor := ""
-	if len(messages) > 0 {
-		nextCursor = messages[len(messages)-1].ID
-	}
-
-	return messages, nextCursor, nil
-}
-
-func (r *MessageRepo) MarkRead(chatID, userID, messageID string) error {
-	ctx := context.Background()
-
-	filter := bson.M{
-		"id":              messageID,
-		"chat_id":         chatID,
-		"read_by.user_id": bson.M{"$ne": userID},
-	}
-
-	update := bson.M{
-		"$push": bson.M{
-			"read_by": domain.ReadInfo{
-				UserID: userID,
-				ReadAt: time.Now().Unix(),
-			},
-		},
+		return nil, err
 	}
-
-	_, err := r.col.UpdateOne(ctx, filter, update)
-	return err
+	return messages, nil
 }
 
 func (r *MessageRepo) ToggleSaved(userID, messageID string, saved bool) error {
@@ -235,16 +332,24 @@ func (r *MessageRepo) ListSaved(userID string, limit int, cursor string) ([]doma
 	return messages, nextCursor, nil
 }
 
-func (r *MessageRepo) ListReadMessages(userID string, chatID string, limit int) ([]domain.Message, error) {
+// ListReadMessages возвращает сообщения, которые попадают под указатели прочтения (seq <= last_read_seq)
+func (r *MessageRepo) ListReadMessages(states []domain.ReadState, limit int) ([]domain.Message, error) {
 	ctx := context.Background()
 
-	filter := bson.M{
-		"read_by.user_id": userID,
-		"deleted":         false,
+	var conds bson.A
+	for _, st := range states {
+		if st.LastReadSeq == 0 {
+			continue
+		}
+		conds = append(conds, bson.M{"chat_id": st.ChatID, "seq": bson.M{"$gt": 0, "$lte": st.LastReadSeq}})
+	}
+	if len(conds) == 0 {
+		return []domain.Message{}, nil
 	}
 
-	if chatID != "" {
-		filter["chat_id"] = chatID
+	filter := bson.M{
+		"$or":     conds,
+		"deleted": false,
 	}
 
 	opts := options.Find().
@@ -265,15 +370,136 @@ func (r *MessageRepo) ListReadMessages(userID string, chatID string, limit int)
 	return messages, nil
 }
 
-func (r *MessageRepo) GetUnreadCount(chatID, userID string) (int64, error) {
+func (r *MessageRepo) ListRevisions(messageID string) ([]domain.MessageRevision, error) {
 	ctx := context.Background()
 
-	filter := bson.M{
-		"chat_id":         chatID,
-		"read_by.user_id": bson.M{"$ne": userID},
-		"deleted":         false,
+	opts := options.Find().SetSort(bson.D{{Key: "revision", Value: 1}})
+
+	cur, err := r.revisions.Find(ctx, bson.M{"message_id": messageID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var revisions []domain.MessageRevision
+	if err := cur.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// ListExpired возвращает сообщения, срок жизни которых истёк, начиная с самых старых
+func (r *MessageRepo) ListExpired(now int64, limit int) ([]domain.Message, error) {
+	ctx := context.Background()
+
+	filter := bson.M{"expires_at": bson.M{"$gt": 0, "$lte": now}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "expires_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var msgs []domain.Message
+	if err := cur.All(ctx, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// Purge безвозвратно удаляет сообщения вместе с историей правок (в ней остаются старые тексты и вложения)
+// и голосами в опросах
+func (r *MessageRepo) Purge(ctx context.Context, messageIDs []string) error {
+	filter := bson.M{"message_id": bson.M{"$in": messageIDs}}
+	if _, err := r.revisions.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+	if _, err := r.votes.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+	_, err := r.col.DeleteMany(ctx, bson.M{"id": bson.M{"$in": messageIDs}})
+	return err
+}
+
+// MediaInUse возвращает те из файлов, что прикреплены к сообщениям чата (в том числе удалённым, но ещё не стёртым)
+func (r *MessageRepo) MediaInUse(chatID string, fileIDs []string) ([]string, error) {
+	filter := bson.M{"chat_id": chatID, "media.id": bson.M{"$in": fileIDs}}
+	opts := options.Find().SetProjection(bson.M{"media.id": 1})
+	msgs, err := r.find(filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(fileIDs))
+	for _, id := range fileIDs {
+		wanted[id] = true
+	}
+	var inUse []string
+	for _, m := range msgs {
+		for _, md := range m.Media {
+			if wanted[md.ID] {
+				inUse = append(inUse, md.ID)
+				wanted[md.ID] = false
+			}
+		}
+	}
+	return inUse, nil
+}
+
+// ListDeletedBefore выбирает удалённые (с пометкой deleted) не позже ts сообщения, давние — первыми
+func (r *MessageRepo) ListDeletedBefore(ts int64, limit int) ([]domain.Message, error) {
+	filter := bson.M{"deleted": true, "deleted_at": bson.M{"$gt": 0, "$lte": ts}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.find(filter, opts)
+}
+
+// ListUpToSeq выбирает сообщения чата с seq не больше seq, от старых к новым
+func (r *MessageRepo) ListUpToSeq(chatID string, seq int64, limit int) ([]domain.Message, error) {
+	filter := bson.M{"chat_id": chatID, "seq": bson.M{"$gt": 0, "$lte": seq}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "seq", Value: 1}}).
+		SetLimit(int64(limit))
+	return r.find(filter, opts)
+}
+
+// ClearSavedOnDeleted снимает отметки «сохранено» с удалённых сообщений: в ListSaved их уже нет,
+// а восстановить удалённое сообщение нельзя
+func (r *MessageRepo) ClearSavedOnDeleted() (int64, error) {
+	res, err := r.col.UpdateMany(context.Background(),
+		bson.M{"deleted": true, "saved_by.0": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"saved_by": ""}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *MessageRepo) find(filter bson.M, opts *options.FindOptions) ([]domain.Message, error) {
+	ctx := context.Background()
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
 	}
+	defer cur.Close(ctx)
 
-	count, err := r.col.CountDocuments(ctx, filter)
-	return count, err
+	var msgs []domain.Message
+	if err := cur.All(ctx, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// IncViews увеличивает счётчик просмотров постов канала
+func (r *MessageRepo) IncViews(chatID string, messageIDs []string) error {
+	_, err := r.col.UpdateMany(context.Background(),
+		bson.M{"chat_id": chatID, "id": bson.M{"$in": messageIDs}, "deleted": false},
+		bson.M{"$inc": bson.M{"views": 1}},
+	)
+	return err
 }
@@ -0,0 +1,126 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ModerationRepo struct {
+	col Collection
+}
+
+func NewModerationRepo(db *mongo.Database) *ModerationRepo {
+	return &ModerationRepo{col: db.Collection("moderation_queue")}
+}
+
+// NewTestModerationRepo - конструктор для тестов
+func NewTestModerationRepo(col Collection) *ModerationRepo {
+	return &ModerationRepo{col: col}
+}
+
+// AddHeld ставит задержанное сообщение в очередь. Повтор (доставка отложенного сообщения после сбоя)
+// упирается в уникальный индекс и считается успехом.
+func (r *ModerationRepo) AddHeld(item domain.ModerationItem) error {
+	_, err := r.col.InsertOne(context.Background(), item)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// AddReport находит ожидающую запись с жалобами на сообщение (или создаёт её из item) и добавляет жалобу,
+// если этот пользователь ещё не жаловался
+func (r *ModerationRepo) AddReport(item domain.ModerationItem, report domain.ModerationReport) (domain.ModerationItem, error) {
+	ctx := context.Background()
+
+	var stored domain.ModerationItem
+	err := r.col.FindOneAndUpdate(ctx,
+		bson.M{"message_id": item.MessageID, "kind": domain.ModerationKindReport, "status": domain.ModerationPending},
+		bson.M{"$setOnInsert": item},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&stored)
+	if err != nil {
+		return domain.ModerationItem{}, err
+	}
+
+	var updated domain.ModerationItem
+	err = r.col.FindOneAndUpdate(ctx,
+		bson.M{"id": stored.ID, "status": domain.ModerationPending, "reports.user_id": bson.M{"$ne": report.UserID}},
+		bson.M{"$push": bson.M{"reports": report}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Пользователь уже жаловался (или запись только что решили)
+		return stored, nil
+	}
+	return updated, err
+}
+
+func (r *ModerationRepo) Get(id string) (domain.ModerationItem, error) {
+	var item domain.ModerationItem
+	err := r.col.FindOne(context.Background(), bson.M{"id": id}).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ModerationItem{}, domain.ErrModerationItemNotFound
+	}
+	return item, err
+}
+
+// ListPending возвращает ожидающие записи чата от старых к новым.
+// Курсор имеет вид "created_at:id".
+func (r *ModerationRepo) ListPending(chatID string, limit int, cursor string) ([]domain.ModerationItem, string, error) {
+	ctx := context.Background()
+
+	filter := bson.M{"chat_id": chatID, "status": domain.ModerationPending}
+	if cursor != "" {
+		ts, id, err := parseKeysetCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$gt": ts}},
+			bson.M{"created_at": ts, "id": bson.M{"$gt": id}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}})
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	var items []domain.ModerationItem
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(items) > 0 {
+		last := items[len(items)-1]
+		nextCursor = fmt.Sprintf("%d:%s", last.CreatedAt, last.ID)
+	}
+	return items, nextCursor, nil
+}
+
+// Decide переводит ожидающую запись в allowed или removed; решить запись можно один раз
+func (r *ModerationRepo) Decide(id string, status domain.ModerationStatus, decidedBy string, now int64) (domain.ModerationItem, error) {
+	var item domain.ModerationItem
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": id, "status": domain.ModerationPending},
+		bson.M{"$set": bson.M{"status": status, "decided_by": decidedBy, "decided_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ModerationItem{}, domain.ErrModerationItemNotFound
+	}
+	return item, err
+}
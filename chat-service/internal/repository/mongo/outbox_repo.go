@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepo struct {
+	col Collection
+}
+
+func NewOutboxRepo(db *mongo.Database) *OutboxRepo {
+	return &OutboxRepo{col: db.Collection("outbox")}
+}
+
+// NewTestOutboxRepo - конструктор для тестов
+func NewTestOutboxRepo(col Collection) *OutboxRepo {
+	return &OutboxRepo{col: col}
+}
+
+// Add пишет событие с ctx вызывающего — внутри транзакции вставка откатывается вместе с ней
+func (r *OutboxRepo) Add(ctx context.Context, e domain.OutboxEvent) error {
+	_, err := r.col.InsertOne(ctx, e)
+	return err
+}
+
+// ClaimDue захватывает самое старое событие, готовое к публикации, на время lease.
+// ok = false — публиковать нечего.
+func (r *OutboxRepo) ClaimDue(now int64, lease time.Duration) (domain.OutboxEvent, bool, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": domain.OutboxPending, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"status": domain.OutboxSending, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": domain.OutboxSending, "locked_until": now + int64(lease/time.Second)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var e domain.OutboxEvent
+	err := r.col.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.OutboxEvent{}, false, nil
+	}
+	if err != nil {
+		return domain.OutboxEvent{}, false, err
+	}
+	return e, true, nil
+}
+
+func (r *OutboxRepo) MarkSent(id string) error {
+	return r.finish(id, bson.M{"status": domain.OutboxSent, "sent_at": time.Now().Unix()})
+}
+
+// MarkRetry возвращает событие в очередь до nextAttemptAt
+func (r *OutboxRepo) MarkRetry(id string, nextAttemptAt int64, reason string) error {
+	return r.finish(id, bson.M{"status": domain.OutboxPending, "next_attempt_at": nextAttemptAt, "last_error": reason})
+}
+
+func (r *OutboxRepo) finish(id string, set bson.M) error {
+	_, err := r.col.UpdateOne(context.Background(),
+		bson.M{"id": id, "status": domain.OutboxSending},
+		bson.M{"$set": set, "$unset": bson.M{"locked_until": ""}},
+	)
+	return err
+}
+
+// DeleteSentBefore удаляет опубликованные события старше ts
+func (r *OutboxRepo) DeleteSentBefore(ts int64) error {
+	_, err := r.col.DeleteMany(context.Background(), bson.M{
+		"status":  domain.OutboxSent,
+		"sent_at": bson.M{"$lt": ts},
+	})
+	return err
+}
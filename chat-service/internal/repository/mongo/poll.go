@@ -0,0 +1,150 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Голоса лежат в коллекции poll_votes, счётчики — в документе сообщения.
+// Уникальный индекс (message_id, user_id) не даёт проголосовать дважды, а условие «опрос открыт»
+// проверяется в фильтре обновления счётчиков. Сервис выполняет оба шага в одной транзакции;
+// без неё при отказе второго шага первый откатывается вручную.
+
+// Vote сохраняет голос и увеличивает счётчики выбранных вариантов
+func (r *MessageRepo) Vote(ctx context.Context, vote domain.PollVote, now int64) (domain.Message, error) {
+	if _, err := r.votes.InsertOne(ctx, vote); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.Message{}, r.pollRejection(vote.MessageID, now, domain.ErrAlreadyVoted)
+		}
+		return domain.Message{}, err
+	}
+
+	msg, err := r.updatePoll(ctx, openPollFilter(vote.MessageID, now), bson.M{"$inc": pollCounters(vote.OptionIDs, 1)})
+	if err != nil {
+		_, _ = r.votes.DeleteMany(ctx, voteFilter(vote))
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Message{}, r.pollRejection(vote.MessageID, now, domain.ErrPollClosed)
+		}
+		return domain.Message{}, err
+	}
+	return msg, nil
+}
+
+// RetractVote снимает голос. Удаляется ровно прочитанный голос (вместе с временем),
+// поэтому параллельная переголосовка не даст вычесть чужие варианты.
+func (r *MessageRepo) RetractVote(ctx context.Context, messageID, userID string, now int64) (domain.Message, error) {
+	vote, err := r.GetVote(messageID, userID)
+	if err != nil {
+		return domain.Message{}, r.pollRejection(messageID, now, err)
+	}
+	res, err := r.votes.DeleteMany(ctx, voteFilter(vote))
+	if err != nil {
+		return domain.Message{}, err
+	}
+	if res.DeletedCount == 0 {
+		return domain.Message{}, r.pollRejection(messageID, now, domain.ErrNotVoted)
+	}
+
+	msg, err := r.updatePoll(ctx, openPollFilter(messageID, now), bson.M{"$inc": pollCounters(vote.OptionIDs, -1)})
+	if err != nil {
+		_, _ = r.votes.InsertOne(ctx, vote)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Message{}, r.pollRejection(messageID, now, domain.ErrPollClosed)
+		}
+		return domain.Message{}, err
+	}
+	return msg, nil
+}
+
+// GetVote возвращает голос пользователя или ErrNotVoted
+func (r *MessageRepo) GetVote(messageID, userID string) (domain.PollVote, error) {
+	var vote domain.PollVote
+	err := r.votes.FindOne(context.Background(), bson.M{"message_id": messageID, "user_id": userID}).Decode(&vote)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.PollVote{}, domain.ErrNotVoted
+	}
+	return vote, err
+}
+
+// ListVotes возвращает голоса опроса в порядке голосования
+func (r *MessageRepo) ListVotes(messageID string) ([]domain.PollVote, error) {
+	ctx := context.Background()
+
+	opts := options.Find().SetSort(bson.D{{Key: "voted_at", Value: 1}, {Key: "user_id", Value: 1}})
+	cur, err := r.votes.Find(ctx, bson.M{"message_id": messageID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var votes []domain.PollVote
+	if err := cur.All(ctx, &votes); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (r *MessageRepo) ClosePoll(ctx context.Context, messageID string, now int64) (domain.Message, error) {
+	filter := bson.M{"id": messageID, "type": domain.MessageTypePoll, "poll.closed": false}
+	update := bson.M{"$set": bson.M{"poll.closed": true, "poll.closed_at": now}}
+
+	msg, err := r.updatePoll(ctx, filter, update)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Message{}, domain.ErrPollClosed
+	}
+	return msg, err
+}
+
+func (r *MessageRepo) updatePoll(ctx context.Context, filter, update bson.M) (domain.Message, error) {
+	var msg domain.Message
+	err := r.col.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&msg)
+	return msg, err
+}
+
+// pollRejection объясняет, почему условное обновление ничего не нашло
+func (r *MessageRepo) pollRejection(messageID string, now int64, fallback error) error {
+	msg, err := r.Get(messageID)
+	if err != nil || msg.Deleted {
+		return domain.ErrMessageNotFound
+	}
+	if msg.Poll == nil {
+		return fmt.Errorf("%w: сообщение не является опросом", domain.ErrInvalidArgument)
+	}
+	if msg.Poll.IsClosed(now) {
+		return domain.ErrPollClosed
+	}
+	return fallback
+}
+
+func openPollFilter(messageID string, now int64) bson.M {
+	return bson.M{
+		"id":          messageID,
+		"type":        domain.MessageTypePoll,
+		"deleted":     false,
+		"poll.closed": false,
+		"$or": bson.A{
+			bson.M{"poll.closes_at": 0},
+			bson.M{"poll.closes_at": bson.M{"$gt": now}},
+		},
+	}
+}
+
+func voteFilter(v domain.PollVote) bson.M {
+	return bson.M{"message_id": v.MessageID, "user_id": v.UserID, "voted_at": v.VotedAt}
+}
+
+func pollCounters(optionIDs []int, delta int64) bson.M {
+	inc := bson.M{"poll.total_voters": delta}
+	for _, id := range optionIDs {
+		inc[fmt.Sprintf("poll.options.%d.votes", id)] = delta
+	}
+	return inc
+}
@@ -0,0 +1,140 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReadStateRepo struct {
+	col Collection
+}
+
+func NewReadStateRepo(db *mongo.Database) *ReadStateRepo {
+	return &ReadStateRepo{col: db.Collection("read_states")}
+}
+
+// NewTestReadStateRepo - конструктор для тестов
+func NewTestReadStateRepo(col Collection) *ReadStateRepo {
+	return &ReadStateRepo{col: col}
+}
+
+// MarkRead сдвигает указатель прочтения (и доставки — прочитанное считается доставленным)
+func (r *ReadStateRepo) MarkRead(ctx context.Context, chatID, userID string, seq int64) error {
+	now := time.Now().Unix()
+	set := bson.M{}
+	advance(set, "last_read_seq", "last_read_at", seq, now)
+	advance(set, "last_delivered_seq", "last_delivered_at", seq, now)
+	return r.upsert(ctx, chatID, userID, set)
+}
+
+func (r *ReadStateRepo) MarkDelivered(chatID, userID string, seq int64) error {
+	set := bson.M{}
+	advance(set, "last_delivered_seq", "last_delivered_at", seq, time.Now().Unix())
+	return r.upsert(context.Background(), chatID, userID, set)
+}
+
+// Track заводит указатели участникам, которые появились в чате с lastSeq сообщениями: пока они ничего
+// не прочли, всё это у них непрочитанное. Указатели, которые уже есть, не сдвигаются
+func (r *ReadStateRepo) Track(ctx context.Context, chatID string, userIDs []string, lastSeq int64) error {
+	for _, userID := range userIDs {
+		if err := r.upsert(ctx, chatID, userID, bson.M{"chat_last_seq": bson.M{"$max": bson.A{"$chat_last_seq", lastSeq}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AdvanceChat отмечает у всех участников чата новое сообщение seq и пересчитывает их счётчики непрочитанного
+func (r *ReadStateRepo) AdvanceChat(ctx context.Context, chatID string, seq int64) error {
+	_, err := r.col.UpdateMany(ctx,
+		bson.M{"chat_id": chatID, "chat_last_seq": bson.M{"$not": bson.M{"$gte": seq}}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"chat_last_seq": bson.M{"$max": bson.A{"$chat_last_seq", seq}}}}},
+			unreadStage,
+		},
+	)
+	return err
+}
+
+// ListUnread возвращает указатели чатов, в которых у пользователя есть непрочитанные сообщения
+func (r *ReadStateRepo) ListUnread(userID string) ([]domain.ReadState, error) {
+	return r.list(bson.M{"user_id": userID, "unread_count": bson.M{"$gt": 0}})
+}
+
+// Get возвращает состояние пользователя в чате; если записи нет — нулевые указатели
+func (r *ReadStateRepo) Get(chatID, userID string) (domain.ReadState, error) {
+	var st domain.ReadState
+	err := r.col.FindOne(context.Background(), bson.M{"chat_id": chatID, "user_id": userID}).Decode(&st)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ReadState{ChatID: chatID, UserID: userID}, nil
+	}
+	return st, err
+}
+
+func (r *ReadStateRepo) ListByChat(chatID string) ([]domain.ReadState, error) {
+	return r.list(bson.M{"chat_id": chatID})
+}
+
+func (r *ReadStateRepo) ListByUser(userID string) ([]domain.ReadState, error) {
+	return r.list(bson.M{"user_id": userID})
+}
+
+// DeleteUsers удаляет указатели прочтения пользователей в чате и возвращает, сколько удалено
+func (r *ReadStateRepo) DeleteUsers(chatID string, userIDs []string) (int64, error) {
+	res, err := r.col.DeleteMany(context.Background(), bson.M{"chat_id": chatID, "user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (r *ReadStateRepo) list(filter bson.M) ([]domain.ReadState, error) {
+	ctx := context.Background()
+
+	cur, err := r.col.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var states []domain.ReadState
+	if err := cur.All(ctx, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// upsert выполняет обновление-конвейер: так указатель, время и счётчик непрочитанного меняются
+// одной атомарной операцией
+func (r *ReadStateRepo) upsert(ctx context.Context, chatID, userID string, set bson.M) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"chat_id": chatID, "user_id": userID},
+		mongo.Pipeline{{{Key: "$set", Value: set}}, unreadStage},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// unreadStage пересчитывает unread_count после сдвига указателей: сколько сообщений чата
+// вышло после прочитанного. По нему ListUnread выбирает непрочитанные чаты индексом
+var unreadStage = bson.D{{Key: "$set", Value: bson.M{"unread_count": bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{
+	bson.M{"$ifNull": bson.A{"$chat_last_seq", 0}},
+	bson.M{"$ifNull": bson.A{"$last_read_seq", 0}},
+}}}}}}}
+
+// advance сдвигает указатель seqField вперёд; время обновляется, только если указатель вырос.
+// Выражения одной стадии $set видят документ до изменения.
+func advance(set bson.M, seqField, atField string, seq, now int64) {
+	set[atField] = bson.M{"$cond": bson.A{
+		bson.M{"$lt": bson.A{"$" + seqField, seq}},
+		now,
+		"$" + atField,
+	}}
+	set[seqField] = bson.M{"$max": bson.A{"$" + seqField, seq}}
+}
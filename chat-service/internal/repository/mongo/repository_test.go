@@ -74,6 +74,19 @@ func (m *MockCollection) UpdateMany(ctx context.Context, filter interface{}, upd
 	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
 }
 
+func (m *MockCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	args := m.Called(ctx, filter, update)
+	return args.Get(0).(*mongo.SingleResult)
+}
+
+func (m *MockCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	args := m.Called(ctx, documents)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.InsertManyResult), args.Error(1)
+}
+
 // MockSingleResult - мок для mongo.SingleResult
 type MockSingleResult struct {
 	mock.Mock
@@ -134,6 +147,30 @@ func (m *MockUserServiceClient) AboutMeUser(ctx context.Context, in *userpb.Abou
 	return args.Get(0).(*userpb.UserResponse), args.Error(1)
 }
 
+func (m *MockUserServiceClient) CheckBlocked(ctx context.Context, in *userpb.CheckBlockedRequest, opts ...grpc.CallOption) (*userpb.CheckBlockedResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.CheckBlockedResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ResolveUserNames(ctx context.Context, in *userpb.ResolveUserNamesRequest, opts ...grpc.CallOption) (*userpb.ResolveUserNamesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.ResolveUserNamesResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ListDevices(ctx context.Context, in *userpb.ListDevicesRequest, opts ...grpc.CallOption) (*userpb.ListDevicesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.ListDevicesResponse), args.Error(1)
+}
+
 // CreateUser - правильная сигнатура (заглушка)
 func (m *MockUserServiceClient) CreateUser(ctx context.Context, in *userpb.CreateUserRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
 	args := m.Called(ctx, in, opts)
@@ -283,6 +320,8 @@ func TestChatRepository_CreateDirect_Success(t *testing.T) {
 		Return(createTestUserResponse("user1"), nil)
 	userClient.On("AboutMeUser", mock.Anything, &userpb.AboutMeRequest{Uuid: "user2"}, mock.Anything).
 		Return(createTestUserResponse("user2"), nil)
+	userClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "user1", UserUuids: []string{"user2"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{}, nil)
 
 	// Настройка моков коллекции
 	mockCol.On("CountDocuments", mock.Anything,
@@ -295,7 +334,7 @@ func TestChatRepository_CreateDirect_Success(t *testing.T) {
 	})).Return(&mongo.InsertOneResult{InsertedID: "user1_user2"}, nil)
 
 	// Выполнение
-	chat, err := repo.CreateDirect("user1", "user2", userClient)
+	chat, err := repo.CreateDirect(context.Background(), "user1", "user2", userClient)
 
 	// Проверки
 	assert.NoError(t, err)
@@ -319,12 +358,13 @@ func TestChatRepository_CreateDirect_ChatExists(t *testing.T) {
 		Return(createTestUserResponse("user1"), nil)
 	userClient.On("AboutMeUser", mock.Anything, &userpb.AboutMeRequest{Uuid: "user2"}, mock.Anything).
 		Return(createTestUserResponse("user2"), nil)
+	userClient.On("CheckBlocked", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.CheckBlockedResponse{}, nil)
 
 	// Настройка моков - чат уже существует
 	mockCol.On("CountDocuments", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
 
 	// Выполнение
-	chat, err := repo.CreateDirect("user1", "user2", userClient)
+	chat, err := repo.CreateDirect(context.Background(), "user1", "user2", userClient)
 
 	// Проверки
 	assert.Error(t, err)
@@ -334,6 +374,73 @@ func TestChatRepository_CreateDirect_ChatExists(t *testing.T) {
 	mockCol.AssertExpectations(t)
 }
 
+func TestChatRepository_CreateDirect_BlockedByPeer(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	userClient := &MockUserServiceClient{}
+	userClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(createTestUserResponse("user"), nil)
+	userClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "user1", UserUuids: []string{"user2"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{BlockedBy: []string{"user2"}}, nil)
+
+	// Выполнение
+	_, err := repo.CreateDirect(context.Background(), "user1", "user2", userClient)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockCol.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+}
+
+func TestChatRepository_UpdateGroup_ReportsActualChanges(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	userClient := &MockUserServiceClient{}
+	userClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(createTestUserResponse("user"), nil)
+	userClient.On("CheckBlocked", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.CheckBlockedResponse{}, nil)
+
+	before := domain.Chat{ID: "group1", Kind: domain.ChatKindGroup, CreatedBy: "user1", MemberIDs: []string{"user1", "user2"}}
+	after := domain.Chat{ID: "group1", Kind: domain.ChatKindGroup, CreatedBy: "user1", MemberIDs: []string{"user1", "user3"}}
+	mockCol.On("FindOne", mock.Anything, bson.M{"id": "group1"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(before, nil, nil)).Once()
+	mockCol.On("FindOne", mock.Anything, bson.M{"id": "group1"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(after, nil, nil)).Once()
+	mockCol.On("UpdateOne", mock.Anything, bson.M{"id": "group1"},
+		bson.M{"$addToSet": bson.M{"member_ids": bson.M{"$each": []string{"user2", "user3"}}}}, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+	mockCol.On("UpdateOne", mock.Anything, bson.M{"id": "group1"},
+		bson.M{"$pullAll": bson.M{"member_ids": []string{"user2", "user4"}}}, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	// Выполнение: user2 уже в чате и его же исключают, user4 в чате не было
+	chat, added, removed, err := repo.UpdateGroup(context.Background(), "group1", nil,
+		[]string{"user2", "user3"}, []string{"user2", "user4"}, "user1", userClient)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user1", "user3"}, chat.MemberIDs)
+	assert.Equal(t, []string{"user3"}, added)
+	assert.Equal(t, []string{"user2"}, removed)
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_CreateGroup_MemberBlockedCreator(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	userClient := &MockUserServiceClient{}
+	userClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(createTestUserResponse("user"), nil)
+	userClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "user1", UserUuids: []string{"user2", "user3"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{BlockedBy: []string{"user3"}}, nil)
+
+	// Выполнение
+	_, err := repo.CreateGroup(context.Background(), "user1", []string{"user2", "user3"}, "Группа", userClient)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockCol.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+}
+
 // func TestChatRepository_Get_Success(t *testing.T) {
 // 	// Подготовка
 // 	repo, mockCol := createTestChatRepo()
@@ -367,6 +474,47 @@ func TestChatRepository_CreateDirect_ChatExists(t *testing.T) {
 // 	mockResult.AssertExpectations(t)
 // }
 
+func TestMessageRepository_Send_KeepsGivenID(t *testing.T) {
+	repo, mockCol := createTestMessageRepo()
+
+	mockCol.On("InsertOne", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.ID == "sch1" && m.CreatedAt == 1700000000
+	})).Return(&mongo.InsertOneResult{InsertedID: "sch1"}, nil)
+
+	result, err := repo.Send(context.Background(), domain.Message{ID: "sch1", ChatID: "chat1", CreatedAt: 1700000000})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sch1", result.ID)
+	mockCol.AssertExpectations(t)
+}
+
+func TestMessageRepository_InsertMany_KeepsFields(t *testing.T) {
+	repo, mockCol := createTestMessageRepo()
+
+	mockCol.On("InsertMany", mock.Anything, mock.MatchedBy(func(docs []interface{}) bool {
+		if len(docs) != 2 {
+			return false
+		}
+		first := docs[0].(domain.Message)
+		return first.ID == "m1" && first.Seq == 5 && first.CreatedAt == 1600000000 && first.SavedBy != nil
+	})).Return(&mongo.InsertManyResult{}, nil)
+
+	err := repo.InsertMany(context.Background(), []domain.Message{
+		{ID: "m1", ChatID: "chat1", Seq: 5, CreatedAt: 1600000000},
+		{ID: "m2", ChatID: "chat1", Seq: 6, CreatedAt: 1600000001},
+	})
+
+	assert.NoError(t, err)
+	mockCol.AssertExpectations(t)
+}
+
+func TestMessageRepository_InsertMany_Empty(t *testing.T) {
+	repo, mockCol := createTestMessageRepo()
+
+	assert.NoError(t, repo.InsertMany(context.Background(), nil))
+	mockCol.AssertNotCalled(t, "InsertMany", mock.Anything, mock.Anything)
+}
+
 func TestMessageRepository_Send_Success(t *testing.T) {
 	// Подготовка
 	repo, mockCol := createTestMessageRepo()
@@ -383,7 +531,7 @@ func TestMessageRepository_Send_Success(t *testing.T) {
 	}
 
 	// Выполнение
-	result, err := repo.Send(msg)
+	result, err := repo.Send(context.Background(), msg)
 
 	// Проверки
 	assert.NoError(t, err)
@@ -392,7 +540,6 @@ func TestMessageRepository_Send_Success(t *testing.T) {
 	assert.Equal(t, "user1", result.AuthorID)
 	assert.Equal(t, "Hello World", result.Text)
 	assert.NotZero(t, result.CreatedAt)
-	assert.Empty(t, result.ReadBy)
 	assert.Empty(t, result.SavedBy)
 	assert.False(t, result.Deleted)
 
@@ -432,46 +579,946 @@ func TestMessageRepository_Send_Success(t *testing.T) {
 // 	mockResult.AssertExpectations(t)
 // }
 
-func TestMessageRepository_MarkRead_Success(t *testing.T) {
+func TestReadStateRepository_MarkRead_Upsert(t *testing.T) {
 	// Подготовка
-	repo, mockCol := createTestMessageRepo()
+	mockCol := &MockCollection{}
+	repo := NewTestReadStateRepo(mockCol)
 
-	// Настройка моков
+	// Одна атомарная операция с upsert; указатели сдвигаются через $max
 	mockCol.On("UpdateOne", mock.Anything,
-		bson.M{
-			"id":              "msg1",
-			"chat_id":         "chat1",
-			"read_by.user_id": bson.M{"$ne": "user1"},
+		bson.M{"chat_id": "chat1", "user_id": "user1"},
+		mock.MatchedBy(func(update interface{}) bool {
+			set := update.(mongo.Pipeline)[0][0].Value.(bson.M)
+			return assert.ObjectsAreEqual(bson.M{"$max": bson.A{"$last_read_seq", int64(7)}}, set["last_read_seq"]) &&
+				assert.ObjectsAreEqual(bson.M{"$max": bson.A{"$last_delivered_seq", int64(7)}}, set["last_delivered_seq"])
+		}),
+		mock.MatchedBy(func(opts []*options.UpdateOptions) bool {
+			return len(opts) == 1 && opts[0].Upsert != nil && *opts[0].Upsert
+		})).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	// Выполнение
+	err := repo.MarkRead(context.Background(), "chat1", "user1", 7)
+
+	// Проверки
+	assert.NoError(t, err)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestReadStateRepository_AdvanceChat_RecountsUnread(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestReadStateRepo(mockCol)
+
+	// Указатели, которые уже знают о сообщении, не трогаются; счётчик пересчитывается следующей стадией
+	mockCol.On("UpdateMany", mock.Anything,
+		bson.M{"chat_id": "chat1", "chat_last_seq": bson.M{"$not": bson.M{"$gte": int64(9)}}},
+		mock.MatchedBy(func(update interface{}) bool {
+			p := update.(mongo.Pipeline)
+			set := p[0][0].Value.(bson.M)
+			return len(p) == 2 && assert.ObjectsAreEqual(bson.M{"$max": bson.A{"$chat_last_seq", int64(9)}}, set["chat_last_seq"]) &&
+				assert.ObjectsAreEqual(unreadStage, p[1])
+		}), mock.Anything).Return(&mongo.UpdateResult{ModifiedCount: 3}, nil)
+
+	// Выполнение
+	err := repo.AdvanceChat(context.Background(), "chat1", 9)
+
+	// Проверки
+	assert.NoError(t, err)
+	mockCol.AssertExpectations(t)
+}
+
+func TestReadStateRepository_ListUnread(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestReadStateRepo(mockCol)
+
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{
+		domain.ReadState{ChatID: "chat1", UserID: "user1", UnreadCount: 2},
+	}, nil, nil)
+	mockCol.On("Find", mock.Anything, bson.M{"user_id": "user1", "unread_count": bson.M{"$gt": 0}}, mock.Anything).Return(cur, nil)
+
+	// Выполнение
+	states, err := repo.ListUnread("user1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ReadState{{ChatID: "chat1", UserID: "user1", UnreadCount: 2}}, states)
+	mockCol.AssertExpectations(t)
+}
+
+func TestReadStateRepository_Get_NoState(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestReadStateRepo(mockCol)
+
+	mockCol.On("FindOne", mock.Anything, bson.M{"chat_id": "chat1", "user_id": "user1"}).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	// Выполнение
+	st, err := repo.Get("chat1", "user1")
+
+	// Проверки: отсутствие записи — это нулевые указатели, а не ошибка
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReadState{ChatID: "chat1", UserID: "user1"}, st)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestMessageRepository_ListReadMessages_NoCursors(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestMessageRepo()
+
+	// Выполнение: пользователь ещё ничего не читал — запрос в базу не нужен
+	msgs, err := repo.ListReadMessages([]domain.ReadState{{ChatID: "chat1", UserID: "user1"}}, 10)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	mockCol.AssertNotCalled(t, "Find", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageRepository_List_Around(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestMessageRepo()
+
+	msgs := func(seqs ...int64) *mongo.Cursor {
+		docs := make([]interface{}, 0, len(seqs))
+		for _, seq := range seqs {
+			docs = append(docs, domain.Message{ChatID: "chat1", Seq: seq})
+		}
+		cur, _ := mongo.NewCursorFromDocuments(docs, nil, nil)
+		return cur
+	}
+
+	// limit = 4: два более старых (запрашиваются с запасом в 1) и два начиная с якоря
+	mockCol.On("Find", mock.Anything,
+		bson.M{"chat_id": "chat1", "deleted": false, "seq": bson.M{"$gt": 0, "$lt": int64(10)}},
+		mock.Anything).Return(msgs(9, 8, 7), nil)
+	mockCol.On("Find", mock.Anything,
+		bson.M{"chat_id": "chat1", "deleted": false, "seq": bson.M{"$gte": int64(10)}},
+		mock.Anything).Return(msgs(10, 11), nil)
+
+	// Выполнение
+	page, err := repo.List(domain.MessageQuery{ChatID: "chat1", Limit: 4, AroundSeq: 10})
+
+	// Проверки: по возрастанию, старые ещё есть, новее 11 ничего нет
+	assert.NoError(t, err)
+	var seqs []int64
+	for _, m := range page.Messages {
+		seqs = append(seqs, m.Seq)
+	}
+	assert.Equal(t, []int64{8, 9, 10, 11}, seqs)
+	assert.True(t, page.HasBefore)
+	assert.False(t, page.HasAfter)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_List_Keyset(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{
+		domain.Chat{ID: "chat7", LastMessageAt: 90},
+	}, nil, nil)
+	mockCol.On("Find", mock.Anything, bson.M{
+		"member_ids": "user1",
+		"id":         bson.M{"$nin": []string{"pinned1"}},
+		"$or": bson.A{
+			bson.M{"last_message_at": bson.M{"$lt": int64(100)}},
+			bson.M{"last_message_at": int64(100), "id": bson.M{"$lt": "chat9"}},
 		},
-		mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+	}, mock.Anything).Return(cur, nil)
+
+	// Выполнение
+	chats, next, err := repo.List(domain.ChatQuery{UserID: "user1", Limit: 10, Cursor: "100:chat9", ExcludeIDs: []string{"pinned1"}})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, chats, 1)
+	assert.Equal(t, "90:chat7", next)
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_List_InvalidCursor(t *testing.T) {
+	repo, _ := createTestChatRepo()
+
+	_, _, err := repo.List(domain.ChatQuery{UserID: "user1", Limit: 10, Cursor: "chat9"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestChatRepository_NextSeq_Success(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	mockCol.On("FindOneAndUpdate", mock.Anything, bson.M{"id": "chat1"}, bson.M{"$inc": bson.M{"last_seq": 1}}).
+		Return(mongo.NewSingleResultFromDocument(domain.Chat{ID: "chat1", LastSeq: 42}, nil, nil))
+
+	// Выполнение
+	seq, err := repo.NextSeq("chat1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), seq)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_ReserveSeqs_Success(t *testing.T) {
+	repo, mockCol := createTestChatRepo()
+
+	mockCol.On("FindOneAndUpdate", mock.Anything, bson.M{"id": "chat1"}, bson.M{"$inc": bson.M{"last_seq": int64(10)}}).
+		Return(mongo.NewSingleResultFromDocument(domain.Chat{ID: "chat1", LastSeq: 12}, nil, nil))
+
+	last, err := repo.ReserveSeqs("chat1", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), last)
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_SetSlowMode_NotFound(t *testing.T) {
+	repo, mockCol := createTestChatRepo()
+
+	mockCol.On("FindOneAndUpdate", mock.Anything, bson.M{"id": "chat1"}, bson.M{"$set": bson.M{"slow_mode_seconds": int64(30)}}).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	_, err := repo.SetSlowMode("chat1", 30)
+
+	assert.ErrorIs(t, err, domain.ErrChatNotFound)
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_Pin_AlreadyPinned(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	// Фильтр не совпал — сообщение уже закреплено
+	mockCol.On("UpdateOne", mock.Anything,
+		bson.M{"id": "chat1", "pinned.message_id": bson.M{"$ne": "msg1"}},
+		mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 0}, nil)
+
+	// Выполнение
+	_, err := repo.Pin("chat1", domain.PinnedMessage{MessageID: "msg1", PinnedBy: "user1"})
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrAlreadyPinned)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestMessageRepository_Update_StoresRevision(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	mockRevisions := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, mockRevisions, &MockCollection{})
+
+	current := domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Text: "Old", CreatedAt: 100}
+	filter := bson.M{"id": "msg1", "author_id": "user1", "deleted": false}
+
+	mockCol.On("FindOne", mock.Anything, filter).
+		Return(mongo.NewSingleResultFromDocument(current, nil, nil)).Once()
+	mockRevisions.On("InsertOne", mock.Anything, mock.MatchedBy(func(doc interface{}) bool {
+		rev, ok := doc.(domain.MessageRevision)
+		return ok && rev.Revision == 1 && rev.Text == "Old" && rev.CreatedAt == 100
+	})).Return(&mongo.InsertOneResult{}, nil)
+	mockCol.On("UpdateOne", mock.Anything, filter, mock.MatchedBy(func(update interface{}) bool {
+		u := update.(bson.M)
+		return u["$inc"].(bson.M)["edit_count"] == 1 && u["$set"].(bson.M)["text"] == "New"
+	}), mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	updated := current
+	updated.Text = "New"
+	updated.Edited = true
+	updated.EditCount = 1
+	mockCol.On("FindOne", mock.Anything, bson.M{"id": "msg1"}).
+		Return(mongo.NewSingleResultFromDocument(updated, nil, nil))
+
+	// Выполнение
+	text := "New"
+	msg, err := repo.Update(context.Background(), "msg1", "user1", &text, nil)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.True(t, msg.Edited)
+	assert.Equal(t, 1, msg.EditCount)
+
+	mockCol.AssertExpectations(t)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestMessageRepository_Update_RemovesRevisionWhenMessageGone(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	mockRevisions := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, mockRevisions, &MockCollection{})
+
+	current := domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Text: "Old", EditCount: 2}
+	filter := bson.M{"id": "msg1", "author_id": "user1", "deleted": false}
+	mockCol.On("FindOne", mock.Anything, filter).Return(mongo.NewSingleResultFromDocument(current, nil, nil))
+	mockRevisions.On("InsertOne", mock.Anything, mock.Anything).Return(&mongo.InsertOneResult{}, nil)
+	// Сообщение удалили между чтением и обновлением
+	mockCol.On("UpdateOne", mock.Anything, filter, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 0}, nil)
+	mockRevisions.On("DeleteMany", mock.Anything, bson.M{"message_id": "msg1", "revision": 3}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	// Выполнение
+	text := "New"
+	_, err := repo.Update(context.Background(), "msg1", "user1", &text, nil)
+
+	// Проверки: ревизия 3 не осталась висеть и не заблокирует следующую правку
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestMessageRepository_Delete_MarksOwnMessages(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	mockRevisions := &MockCollection{}
+	mockVotes := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, mockRevisions, mockVotes)
+
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{domain.Message{ID: "m1", AuthorID: "user1"}}, nil, nil)
+	mockCol.On("Find", mock.Anything, bson.M{"id": bson.M{"$in": []string{"m1", "m2"}}, "author_id": "user1"}, mock.Anything).Return(cur, nil)
+	mockCol.On("UpdateMany", mock.Anything, bson.M{"id": bson.M{"$in": []string{"m1"}}}, mock.MatchedBy(func(u bson.M) bool {
+		return u["$set"].(bson.M)["deleted"] == true
+	}), mock.Anything).Return(&mongo.UpdateResult{ModifiedCount: 1}, nil)
+
+	// Выполнение
+	deleted, err := repo.Delete(context.Background(), []string{"m1", "m2"}, "user1")
+
+	// Проверки: история правок остаётся до очистки, стирает её только Purge
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	mockRevisions.AssertNotCalled(t, "DeleteMany", mock.Anything, mock.Anything, mock.Anything)
+	mockCol.AssertExpectations(t)
+}
+
+func TestMentionRepository_Add_SingleInsert(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestMentionRepo(mockCol)
+
+	mockCol.On("InsertMany", mock.Anything, mock.MatchedBy(func(docs []interface{}) bool {
+		return len(docs) == 2
+	})).Return(&mongo.InsertManyResult{}, nil).Once()
+
+	err := repo.Add([]domain.Mention{{UserID: "u1", MessageID: "m1"}, {UserID: "u2", MessageID: "m1"}})
+
+	assert.NoError(t, err)
+	mockCol.AssertExpectations(t)
+	mockCol.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+}
+
+func TestMentionRepository_CountUnread_AllChats(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestMentionRepo(mockCol)
+
+	// Пустой chat_id — фильтр только по пользователю
+	mockCol.On("CountDocuments", mock.Anything, bson.M{"user_id": "user1", "read": false}, mock.Anything).
+		Return(int64(4), nil)
+
+	// Выполнение
+	count, err := repo.CountUnread("user1", "")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	mockCol.AssertExpectations(t)
+}
+
+func TestMentionRepository_ListUnread_InvalidCursor(t *testing.T) {
+	repo := NewTestMentionRepo(&MockCollection{})
+
+	_, _, err := repo.ListUnread("user1", "chat1", 1, "broken")
+
+	assert.Error(t, err)
+}
+
+func TestScheduledMessageRepository_ClaimDue_TakesExpiredLease(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestScheduledMessageRepo(mockCol)
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": domain.ScheduledPending, "send_at": bson.M{"$lte": int64(1000)}},
+		bson.M{"status": domain.ScheduledSending, "locked_until": bson.M{"$lt": int64(1000)}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": domain.ScheduledSending, "locked_until": int64(1030)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	mockCol.On("FindOneAndUpdate", mock.Anything, filter, update, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.ScheduledMessage{ID: "sch1", Status: domain.ScheduledSending, Attempts: 2}, nil, nil))
 
 	// Выполнение
-	err := repo.MarkRead("chat1", "user1", "msg1")
+	m, ok, err := repo.ClaimDue(1000, 30*time.Second)
 
 	// Проверки
 	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "sch1", m.ID)
+	mockCol.AssertExpectations(t)
+}
+
+func TestScheduledMessageRepository_ClaimDue_Nothing(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestScheduledMessageRepo(mockCol)
 
+	mockCol.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	_, ok, err := repo.ClaimDue(1000, 30*time.Second)
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMessageRepository_Purge_RemovesRevisionsAndVotes(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	mockRevisions := &MockCollection{}
+	mockVotes := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, mockRevisions, mockVotes)
+	ids := []string{"m1", "m2"}
+
+	mockRevisions.On("DeleteMany", mock.Anything, bson.M{"message_id": bson.M{"$in": ids}}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+	mockVotes.On("DeleteMany", mock.Anything, bson.M{"message_id": bson.M{"$in": ids}}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 3}, nil)
+	mockCol.On("DeleteMany", mock.Anything, bson.M{"id": bson.M{"$in": ids}}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 2}, nil)
+
+	// Выполнение
+	err := repo.Purge(context.Background(), ids)
+
+	// Проверки
+	assert.NoError(t, err)
+	mockRevisions.AssertExpectations(t)
+	mockVotes.AssertExpectations(t)
 	mockCol.AssertExpectations(t)
 }
 
-func TestMessageRepository_GetUnreadCount_Success(t *testing.T) {
+func TestMessageRepository_MediaInUse(t *testing.T) {
 	// Подготовка
 	repo, mockCol := createTestMessageRepo()
 
-	// Настройка моков
-	mockCol.On("CountDocuments", mock.Anything,
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{
+		domain.Message{Media: []domain.Media{{ID: "file2"}, {ID: "other"}}},
+		domain.Message{Media: []domain.Media{{ID: "file2"}}},
+	}, nil, nil)
+	mockCol.On("Find", mock.Anything,
+		bson.M{"chat_id": "chat1", "media.id": bson.M{"$in": []string{"file1", "file2"}}},
+		mock.Anything).Return(cur, nil)
+
+	// Выполнение
+	inUse, err := repo.MediaInUse("chat1", []string{"file1", "file2"})
+
+	// Проверки: файл считается один раз, чужие вложения сообщений не попадают в ответ
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"file2"}, inUse)
+	mockCol.AssertExpectations(t)
+}
+
+func TestMessageRepository_Vote_IncrementsCounters(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	mockVotes := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, &MockCollection{}, mockVotes)
+	vote := domain.PollVote{MessageID: "poll1", UserID: "user2", OptionIDs: []int{0, 2}, VotedAt: 1000}
+
+	filter := bson.M{
+		"id":          "poll1",
+		"type":        domain.MessageTypePoll,
+		"deleted":     false,
+		"poll.closed": false,
+		"$or": bson.A{
+			bson.M{"poll.closes_at": 0},
+			bson.M{"poll.closes_at": bson.M{"$gt": int64(1000)}},
+		},
+	}
+	update := bson.M{
+		"$inc": bson.M{
+			"poll.total_voters":    int64(1),
+			"poll.options.0.votes": int64(1),
+			"poll.options.2.votes": int64(1),
+		},
+	}
+	mockVotes.On("InsertOne", mock.Anything, vote).Return(&mongo.InsertOneResult{}, nil)
+	mockCol.On("FindOneAndUpdate", mock.Anything, filter, update, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.Message{ID: "poll1", Poll: &domain.Poll{TotalVoters: 1}}, nil, nil))
+
+	// Выполнение
+	msg, err := repo.Vote(context.Background(), vote, 1000)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), msg.Poll.TotalVoters)
+	mockVotes.AssertExpectations(t)
+	mockCol.AssertExpectations(t)
+}
+
+func TestMessageRepository_Vote_AlreadyVoted(t *testing.T) {
+	mockCol := &MockCollection{}
+	mockVotes := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, &MockCollection{}, mockVotes)
+
+	dup := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+	mockVotes.On("InsertOne", mock.Anything, mock.Anything).Return(nil, dup)
+	mockCol.On("FindOne", mock.Anything, bson.M{"id": "poll1"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.Message{ID: "poll1", Poll: &domain.Poll{}}, nil, nil))
+
+	_, err := repo.Vote(context.Background(), domain.PollVote{MessageID: "poll1", UserID: "user2", OptionIDs: []int{1}}, 1000)
+
+	assert.ErrorIs(t, err, domain.ErrAlreadyVoted)
+	mockCol.AssertNotCalled(t, "FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageRepository_Vote_ClosedByTimeRemovesVote(t *testing.T) {
+	mockCol := &MockCollection{}
+	mockVotes := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, &MockCollection{}, mockVotes)
+	vote := domain.PollVote{MessageID: "poll1", UserID: "user2", OptionIDs: []int{1}, VotedAt: 1000}
+
+	mockVotes.On("InsertOne", mock.Anything, vote).Return(&mongo.InsertOneResult{}, nil)
+	mockCol.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+	// Без транзакции голос откатывается вручную
+	mockVotes.On("DeleteMany", mock.Anything, bson.M{"message_id": "poll1", "user_id": "user2", "voted_at": int64(1000)}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+	mockCol.On("FindOne", mock.Anything, bson.M{"id": "poll1"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.Message{ID: "poll1", Poll: &domain.Poll{ClosesAt: 900}}, nil, nil))
+
+	_, err := repo.Vote(context.Background(), vote, 1000)
+
+	assert.ErrorIs(t, err, domain.ErrPollClosed)
+	mockVotes.AssertExpectations(t)
+}
+
+func TestMessageRepository_RetractVote_DecrementsVotedOptions(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	mockVotes := &MockCollection{}
+	repo := NewTestMessageRepo(mockCol, &MockCollection{}, mockVotes)
+	vote := domain.PollVote{MessageID: "poll1", UserID: "user2", OptionIDs: []int{1}, VotedAt: 900}
+
+	mockVotes.On("FindOne", mock.Anything, bson.M{"message_id": "poll1", "user_id": "user2"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(vote, nil, nil))
+	mockVotes.On("DeleteMany", mock.Anything, bson.M{"message_id": "poll1", "user_id": "user2", "voted_at": int64(900)}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+	mockCol.On("FindOneAndUpdate", mock.Anything, mock.Anything, bson.M{"$inc": bson.M{
+		"poll.total_voters":    int64(-1),
+		"poll.options.1.votes": int64(-1),
+	}}, mock.Anything).Return(mongo.NewSingleResultFromDocument(domain.Message{ID: "poll1", Poll: &domain.Poll{}}, nil, nil))
+
+	// Выполнение
+	_, err := repo.RetractVote(context.Background(), "poll1", "user2", 1000)
+
+	// Проверки
+	assert.NoError(t, err)
+	mockVotes.AssertExpectations(t)
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_List_WithChannels(t *testing.T) {
+	// Подготовка
+	repo, mockCol := createTestChatRepo()
+
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{
+		domain.Chat{ID: "channel1", Kind: domain.ChatKindChannel, LastMessageAt: 90},
+	}, nil, nil)
+	mockCol.On("Find", mock.Anything, bson.M{
+		"$and": bson.A{bson.M{"$or": bson.A{
+			bson.M{"member_ids": "user1"},
+			bson.M{"id": bson.M{"$in": []string{"channel1"}}},
+		}}},
+		"$or": bson.A{
+			bson.M{"last_message_at": bson.M{"$lt": int64(100)}},
+			bson.M{"last_message_at": int64(100), "id": bson.M{"$lt": "chat9"}},
+		},
+	}, mock.Anything).Return(cur, nil)
+
+	// Выполнение
+	chats, _, err := repo.List(domain.ChatQuery{UserID: "user1", Limit: 10, Cursor: "100:chat9", ChannelIDs: []string{"channel1"}})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, chats, 1)
+	mockCol.AssertExpectations(t)
+}
+
+func TestChatRepository_CreateChannel_HandleTaken(t *testing.T) {
+	repo, mockCol := createTestChatRepo()
+
+	dup := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "duplicate key"}}}
+	mockCol.On("InsertOne", mock.Anything, mock.Anything).Return(nil, dup)
+
+	err := repo.CreateChannel(domain.Chat{ID: "channel1", Kind: domain.ChatKindChannel, Handle: "news"})
+
+	assert.ErrorIs(t, err, domain.ErrHandleTaken)
+}
+
+func TestSubscriptionRepository_Subscribe(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestSubscriptionRepo(mockCol)
+
+	filter := bson.M{"chat_id": "channel1", "user_id": "user1"}
+	update := bson.M{"$setOnInsert": domain.ChannelSubscription{ChatID: "channel1", UserID: "user1", SubscribedAt: 1000}}
+	mockCol.On("UpdateOne", mock.Anything, filter, update, mock.Anything).
+		Return(&mongo.UpdateResult{UpsertedCount: 1}, nil).Once()
+	mockCol.On("UpdateOne", mock.Anything, filter, update, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+	// Выполнение
+	first, err1 := repo.Subscribe(context.Background(), "channel1", "user1", 1000)
+	second, err2 := repo.Subscribe(context.Background(), "channel1", "user1", 1000)
+
+	// Проверки
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.True(t, first)
+	assert.False(t, second)
+	mockCol.AssertExpectations(t)
+}
+
+func TestInviteRepository_UseLink_UsedUp(t *testing.T) {
+	// Подготовка
+	mockLinks := &MockCollection{}
+	repo := NewTestInviteRepo(mockLinks, &MockCollection{})
+
+	filter := bson.M{
+		"code":    "code1",
+		"revoked": false,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"expires_at": 0},
+				bson.M{"expires_at": bson.M{"$gt": int64(1000)}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"usage_limit": 0},
+				bson.M{"$expr": bson.M{"$lt": bson.A{"$usage_count", "$usage_limit"}}},
+			}},
+		},
+	}
+	mockLinks.On("FindOneAndUpdate", mock.Anything, filter, bson.M{"$inc": bson.M{"usage_count": 1}}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+	mockLinks.On("FindOne", mock.Anything, bson.M{"code": "code1"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.InviteLink{Code: "code1", UsageLimit: 1, UsageCount: 1}, nil, nil))
+
+	// Выполнение
+	_, err := repo.UseLink("code1", 1000)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrInviteUnusable)
+	mockLinks.AssertExpectations(t)
+}
+
+func TestInviteRepository_DecideRequest_AlreadyDecided(t *testing.T) {
+	mockRequests := &MockCollection{}
+	repo := NewTestInviteRepo(&MockCollection{}, mockRequests)
+
+	mockRequests.On("FindOneAndUpdate", mock.Anything, bson.M{"id": "req1", "status": domain.JoinRequestPending}, mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	_, err := repo.DecideRequest(context.Background(), "req1", domain.JoinRequestApproved, "user1", 1000)
+
+	assert.ErrorIs(t, err, domain.ErrJoinRequestNotFound)
+}
+
+func TestModerationRepository_AddReport(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestModerationRepo(mockCol)
+
+	item := domain.ModerationItem{ID: "mod1", ChatID: "chat1", Kind: domain.ModerationKindReport, MessageID: "msg1", Status: domain.ModerationPending}
+	report := domain.ModerationReport{UserID: "user2", Reason: "спам", CreatedAt: 1000}
+	withReport := item
+	withReport.Reports = []domain.ModerationReport{report}
+
+	mockCol.On("FindOneAndUpdate", mock.Anything,
+		bson.M{"message_id": "msg1", "kind": domain.ModerationKindReport, "status": domain.ModerationPending},
+		bson.M{"$setOnInsert": item}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(item, nil, nil))
+	mockCol.On("FindOneAndUpdate", mock.Anything,
+		bson.M{"id": "mod1", "status": domain.ModerationPending, "reports.user_id": bson.M{"$ne": "user2"}},
+		bson.M{"$push": bson.M{"reports": report}}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(withReport, nil, nil))
+
+	stored, err := repo.AddReport(item, report)
+
+	assert.NoError(t, err)
+	assert.Len(t, stored.Reports, 1)
+	mockCol.AssertExpectations(t)
+}
+
+func TestModerationRepository_AddReport_Repeated(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestModerationRepo(mockCol)
+
+	existing := domain.ModerationItem{ID: "mod0", MessageID: "msg1", Reports: []domain.ModerationReport{{UserID: "user2"}}}
+	mockCol.On("FindOneAndUpdate", mock.Anything, mock.MatchedBy(func(f bson.M) bool { return f["message_id"] == "msg1" }), mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(existing, nil, nil))
+	mockCol.On("FindOneAndUpdate", mock.Anything, mock.MatchedBy(func(f bson.M) bool { return f["id"] == "mod0" }), mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	// Повторная жалоба не ошибка и не дубль
+	stored, err := repo.AddReport(domain.ModerationItem{ID: "mod1", MessageID: "msg1"}, domain.ModerationReport{UserID: "user2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mod0", stored.ID)
+	assert.Len(t, stored.Reports, 1)
+}
+
+func TestModerationRepository_Decide_AlreadyDecided(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestModerationRepo(mockCol)
+
+	mockCol.On("FindOneAndUpdate", mock.Anything, bson.M{"id": "mod1", "status": domain.ModerationPending}, mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	_, err := repo.Decide("mod1", domain.ModerationRemoved, "user1", 1000)
+
+	assert.ErrorIs(t, err, domain.ErrModerationItemNotFound)
+}
+
+func TestChatRepository_AddMember_AlreadyMember(t *testing.T) {
+	repo, mockCol := createTestChatRepo()
+
+	mockCol.On("FindOneAndUpdate", mock.Anything, bson.M{"id": "group1", "member_ids": bson.M{"$ne": "user2"}}, mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+	mockCol.On("FindOne", mock.Anything, bson.M{"id": "group1"}, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.Chat{ID: "group1", MemberIDs: []string{"user1", "user2"}}, nil, nil))
+
+	chat, added, err := repo.AddMember(context.Background(), "group1", "user2")
+
+	assert.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, "group1", chat.ID)
+}
+
+func TestDraftRepository_Save_Stale(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestDraftRepo(mockCol)
+
+	// Черновик уже правили: фильтр по версии его не находит, а upsert упирается в уникальный индекс
+	dupErr := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}}
+	mockCol.On("FindOneAndUpdate", mock.Anything,
+		bson.M{"chat_id": "chat1", "user_id": "user1", "version": int64(3)},
+		mock.Anything, mock.Anything).Return(mongo.NewSingleResultFromDocument(bson.M{}, dupErr, nil))
+
+	// Выполнение
+	_, applied, err := repo.Save(context.Background(), domain.Draft{ChatID: "chat1", UserID: "user1", Text: "old"}, 3)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.False(t, applied)
+	mockCol.AssertExpectations(t)
+}
+
+func TestDraftRepository_Save_BumpsVersion(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestDraftRepo(mockCol)
+
+	mockCol.On("FindOneAndUpdate", mock.Anything,
+		bson.M{"chat_id": "chat1", "user_id": "user1", "version": int64(3)},
+		mock.MatchedBy(func(u bson.M) bool {
+			inc, ok := u["$inc"].(bson.M)
+			return ok && inc["version"] == int64(1)
+		}), mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.Draft{ChatID: "chat1", UserID: "user1", Text: "new", Version: 4}, nil, nil))
+
+	// Выполнение
+	saved, applied, err := repo.Save(context.Background(), domain.Draft{ChatID: "chat1", UserID: "user1", Text: "new"}, 3)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, int64(4), saved.Version)
+	mockCol.AssertExpectations(t)
+}
+
+func TestDraftRepository_ListByUser_SkipsCleared(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestDraftRepo(mockCol)
+
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{
+		domain.Draft{ChatID: "chat1", UserID: "user1", Text: "привет", UpdatedAt: 2000},
+		domain.Draft{ChatID: "chat2", UserID: "user1", UpdatedAt: 1000},
+	}, nil, nil)
+	mockCol.On("Find", mock.Anything, bson.M{"user_id": "user1"}, mock.Anything).Return(cur, nil)
+
+	drafts, err := repo.ListByUser("user1")
+
+	assert.NoError(t, err)
+	assert.Len(t, drafts, 1)
+	assert.Equal(t, "chat1", drafts[0].ChatID)
+}
+
+func TestChatFolderRepository_Update_NotOwner(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestChatFolderRepo(mockCol)
+
+	// Фильтр по владельцу: чужую папку не находит
+	mockCol.On("UpdateOne", mock.Anything, bson.M{"id": "f1", "user_id": "user2"}, mock.Anything, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil)
+
+	err := repo.Update(context.Background(), domain.ChatFolder{ID: "f1", UserID: "user2", Title: "Работа"})
+
+	assert.ErrorIs(t, err, domain.ErrFolderNotFound)
+	mockCol.AssertExpectations(t)
+}
+
+func TestUpdateLogRepository_Append_SeqPerUser(t *testing.T) {
+	// Подготовка
+	mockSeqs := &MockCollection{}
+	mockUpdates := &MockCollection{}
+	repo := NewTestUpdateLogRepo(mockSeqs, mockUpdates)
+	ctx := context.Background()
+
+	// У user1 журнал уже начат — его счётчик растёт в общем $inc; у user2 счётчика ещё нет
+	inUsers := bson.M{"user_id": bson.M{"$in": []string{"user1", "user2"}}}
+	mockSeqs.On("UpdateMany", ctx, inUsers, bson.M{"$inc": bson.M{"seq": 1}}, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+	cur, _ := mongo.NewCursorFromDocuments([]interface{}{bson.M{"user_id": "user1", "seq": int64(7)}}, nil, nil)
+	mockSeqs.On("Find", ctx, inUsers, mock.Anything).Return(cur, nil)
+	mockSeqs.On("FindOneAndUpdate", ctx, bson.M{"user_id": "user2"}, bson.M{"$inc": bson.M{"seq": 1}}).
+		Return(mongo.NewSingleResultFromDocument(bson.M{"user_id": "user2", "seq": int64(1)}, nil, nil))
+	mockUpdates.On("InsertMany", ctx, mock.MatchedBy(func(docs []interface{}) bool {
+		if len(docs) != 2 {
+			return false
+		}
+		u1, u2 := docs[0].(domain.UserUpdate), docs[1].(domain.UserUpdate)
+		return u1.UserID == "user1" && u1.Seq == 7 && u1.Type == domain.UpdateMessageNew &&
+			u2.UserID == "user2" && u2.Seq == 1
+	})).Return(&mongo.InsertManyResult{}, nil)
+
+	// Выполнение: повтор получателя не выдаёт ему второй seq
+	err := repo.Append(ctx, []string{"user1", "user2", "user1"}, domain.UserUpdate{Type: domain.UpdateMessageNew, ChatID: "chat1", MessageIDs: []string{"msg1"}})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockSeqs.AssertExpectations(t)
+	mockUpdates.AssertExpectations(t)
+}
+
+func TestUpdateLogRepository_CurrentSeq_EmptyLog(t *testing.T) {
+	mockSeqs := &MockCollection{}
+	repo := NewTestUpdateLogRepo(mockSeqs, &MockCollection{})
+
+	mockSeqs.On("FindOne", mock.Anything, bson.M{"user_id": "user1"}).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	seq, err := repo.CurrentSeq("user1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), seq)
+}
+
+func TestOutboxRepository_Add_UsesCallerContext(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestOutboxRepo(mockCol)
+	type txKey struct{}
+	ctx := context.WithValue(context.Background(), txKey{}, "tx")
+	e := domain.OutboxEvent{ID: "ev1", Key: "message", Payload: []byte(`{}`), Status: domain.OutboxPending}
+
+	mockCol.On("InsertOne", ctx, e).Return(&mongo.InsertOneResult{}, nil)
+
+	// Выполнение
+	err := repo.Add(ctx, e)
+
+	// Проверки
+	assert.NoError(t, err)
+	mockCol.AssertExpectations(t)
+}
+
+func TestOutboxRepository_MarkRetry_ReleasesClaim(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestOutboxRepo(mockCol)
+
+	mockCol.On("UpdateOne", mock.Anything,
+		bson.M{"id": "ev1", "status": domain.OutboxSending},
 		bson.M{
-			"chat_id":         "chat1",
-			"read_by.user_id": bson.M{"$ne": "user1"},
-			"deleted":         false,
-		}, mock.Anything).Return(int64(5), nil)
+			"$set":   bson.M{"status": domain.OutboxPending, "next_attempt_at": int64(1004), "last_error": "broker down"},
+			"$unset": bson.M{"locked_until": ""},
+		}, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 
 	// Выполнение
-	count, err := repo.GetUnreadCount("chat1", "user1")
+	err := repo.MarkRetry("ev1", 1004, "broker down")
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Equal(t, int64(5), count)
+	mockCol.AssertExpectations(t)
+}
 
+func TestRetentionRepository_Claim_TakesExpiredLease(t *testing.T) {
+	// Подготовка
+	mockCol := &MockCollection{}
+	repo := NewTestRetentionRepo(mockCol)
+
+	filter := bson.M{"id": "messages", "locked_until": bson.M{"$lt": int64(1000)}}
+	mockCol.On("FindOneAndUpdate", mock.Anything, filter, mock.MatchedBy(func(u bson.M) bool {
+		set := u["$set"].(bson.M)
+		return set["locked_until"] == int64(1300) && set["token"] != ""
+	}), mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(domain.RetentionCheckpoint{ID: "messages", ChatCursor: "chat7", LockedUntil: 1300, Token: "tok1"}, nil, nil))
+
+	// Выполнение
+	cp, ok, err := repo.Claim(1000, 5*time.Minute)
+
+	// Проверки: прерванный проход продолжается с сохранённого чата
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "chat7", cp.ChatCursor)
+	assert.Equal(t, "tok1", cp.Token)
+	mockCol.AssertExpectations(t)
+}
+
+func TestRetentionRepository_Checkpoint_LeaseLost(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestRetentionRepo(mockCol)
+
+	// Аренда истекла и перешла к другой реплике с новой меткой: старая метка ничего не находит
+	mockCol.On("UpdateOne", mock.Anything, bson.M{"id": "messages", "token": "stale"}, mock.Anything, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil)
+
+	err := repo.Checkpoint("stale", "chat9", 1600)
+
+	assert.ErrorIs(t, err, domain.ErrLeaseLost)
+	mockCol.AssertExpectations(t)
+}
+
+func TestRetentionRepository_Claim_HeldByAnotherReplica(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestRetentionRepo(mockCol)
+
+	// Аренда не истекла: upsert второго документа с тем же id отклоняет уникальный индекс
+	dup := mongo.CommandError{Code: 11000, Message: "E11000 duplicate key error"}
+	mockCol.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(mongo.NewSingleResultFromDocument(bson.M{}, dup, nil))
+
+	_, ok, err := repo.Claim(1000, 5*time.Minute)
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestReadStateRepository_DeleteUsers(t *testing.T) {
+	mockCol := &MockCollection{}
+	repo := NewTestReadStateRepo(mockCol)
+
+	mockCol.On("DeleteMany", mock.Anything, bson.M{"chat_id": "chat1", "user_id": bson.M{"$in": []string{"u1", "u2"}}}, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 2}, nil)
+
+	n, err := repo.DeleteUsers("chat1", []string{"u1", "u2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
 	mockCol.AssertExpectations(t)
 }
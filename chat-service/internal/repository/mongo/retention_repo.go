@@ -0,0 +1,75 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// retentionJobID — единственная задача очистки; документ хранит её аренду и позицию обхода чатов
+const retentionJobID = "messages"
+
+type RetentionRepo struct {
+	col Collection
+}
+
+func NewRetentionRepo(db *mongo.Database) *RetentionRepo {
+	return &RetentionRepo{col: db.Collection("retention_jobs")}
+}
+
+// NewTestRetentionRepo - конструктор для тестов
+func NewTestRetentionRepo(col Collection) *RetentionRepo {
+	return &RetentionRepo{col: col}
+}
+
+// Claim берёт аренду задачи до now+lease, если её никто не держит. Возвращает сохранённую
+// позицию прошлого прогона и новую метку захвата; false — задачу уже выполняет другая реплика.
+func (r *RetentionRepo) Claim(now int64, lease time.Duration) (domain.RetentionCheckpoint, bool, error) {
+	filter := bson.M{"id": retentionJobID, "locked_until": bson.M{"$lt": now}}
+	update := bson.M{"$set": bson.M{"locked_until": now + int64(lease/time.Second), "token": uuid.New().String()}}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var cp domain.RetentionCheckpoint
+	err := r.col.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&cp)
+	// Документ есть, но аренда не истекла: upsert упирается в уникальный индекс по id
+	if mongo.IsDuplicateKeyError(err) || errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.RetentionCheckpoint{}, false, nil
+	}
+	if err != nil {
+		return domain.RetentionCheckpoint{}, false, err
+	}
+	return cp, true, nil
+}
+
+// Checkpoint запоминает последний обработанный чат и продлевает аренду.
+// Если аренду с тех пор перехватила другая реплика — domain.ErrLeaseLost
+func (r *RetentionRepo) Checkpoint(token, chatCursor string, lockedUntil int64) error {
+	return r.updateHeld(token, bson.M{"chat_cursor": chatCursor, "locked_until": lockedUntil})
+}
+
+// Finish завершает прогон: следующий начнёт обход чатов сначала, аренда и метка снимаются
+func (r *RetentionRepo) Finish(token string, at int64) error {
+	return r.updateHeld(token, bson.M{"chat_cursor": "", "locked_until": 0, "token": "", "last_finished_at": at})
+}
+
+func (r *RetentionRepo) updateHeld(token string, set bson.M) error {
+	res, err := r.col.UpdateOne(context.Background(),
+		bson.M{"id": retentionJobID, "token": token},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScheduledMessageRepo struct {
+	col Collection
+}
+
+func NewScheduledMessageRepo(db *mongo.Database) *ScheduledMessageRepo {
+	return &ScheduledMessageRepo{col: db.Collection("scheduled_messages")}
+}
+
+// NewTestScheduledMessageRepo - конструктор для тестов
+func NewTestScheduledMessageRepo(col Collection) *ScheduledMessageRepo {
+	return &ScheduledMessageRepo{col: col}
+}
+
+func (r *ScheduledMessageRepo) Create(m domain.ScheduledMessage) error {
+	_, err := r.col.InsertOne(context.Background(), m)
+	return err
+}
+
+func (r *ScheduledMessageRepo) Get(id string) (domain.ScheduledMessage, error) {
+	var m domain.ScheduledMessage
+	err := r.col.FindOne(context.Background(), bson.M{"id": id}).Decode(&m)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return m, domain.ErrScheduledNotFound
+	}
+	return m, err
+}
+
+// ListPending возвращает ожидающие отправки сообщения автора в чате по времени отправки
+func (r *ScheduledMessageRepo) ListPending(chatID, authorID string) ([]domain.ScheduledMessage, error) {
+	ctx := context.Background()
+
+	opts := options.Find().SetSort(bson.D{{Key: "send_at", Value: 1}, {Key: "id", Value: 1}})
+	cur, err := r.col.Find(ctx, pendingFilter(chatID, authorID), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []domain.ScheduledMessage
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *ScheduledMessageRepo) CountPending(chatID, authorID string) (int64, error) {
+	return r.col.CountDocuments(context.Background(), pendingFilter(chatID, authorID))
+}
+
+// UpdatePending меняет только запись в статусе pending: захваченное планировщиком сообщение уже не правится
+func (r *ScheduledMessageRepo) UpdatePending(id string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error) {
+	set := bson.M{"updated_at": time.Now().Unix()}
+	if text != nil {
+		set["text"] = *text
+	}
+	if media != nil {
+		set["media"] = *media
+	}
+	if sendAt != nil {
+		set["send_at"] = *sendAt
+	}
+
+	var m domain.ScheduledMessage
+	err := r.col.FindOneAndUpdate(context.Background(),
+		bson.M{"id": id, "status": domain.ScheduledPending},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&m)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return m, domain.ErrScheduledClosed
+	}
+	return m, err
+}
+
+func (r *ScheduledMessageRepo) Cancel(id string) error {
+	res, err := r.col.UpdateOne(context.Background(),
+		bson.M{"id": id, "status": domain.ScheduledPending},
+		bson.M{"$set": bson.M{"status": domain.ScheduledCanceled, "updated_at": time.Now().Unix()}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrScheduledClosed
+	}
+	return nil
+}
+
+// ClaimDue захватывает самое раннее созревшее сообщение на время lease.
+// ok = false — доставлять нечего.
+func (r *ScheduledMessageRepo) ClaimDue(now int64, lease time.Duration) (domain.ScheduledMessage, bool, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": domain.ScheduledPending, "send_at": bson.M{"$lte": now}},
+		bson.M{"status": domain.ScheduledSending, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": domain.ScheduledSending, "locked_until": now + int64(lease/time.Second)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "send_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var m domain.ScheduledMessage
+	err := r.col.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&m)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ScheduledMessage{}, false, nil
+	}
+	if err != nil {
+		return domain.ScheduledMessage{}, false, err
+	}
+	return m, true, nil
+}
+
+func (r *ScheduledMessageRepo) MarkSent(id, messageID string) error {
+	return r.finish(id, bson.M{"status": domain.ScheduledSent, "message_id": messageID})
+}
+
+func (r *ScheduledMessageRepo) MarkFailed(id, reason string) error {
+	return r.finish(id, bson.M{"status": domain.ScheduledFailed, "error": reason})
+}
+
+func (r *ScheduledMessageRepo) finish(id string, set bson.M) error {
+	set["updated_at"] = time.Now().Unix()
+	_, err := r.col.UpdateOne(context.Background(),
+		bson.M{"id": id, "status": domain.ScheduledSending},
+		bson.M{"$set": set, "$unset": bson.M{"locked_until": ""}},
+	)
+	return err
+}
+
+func pendingFilter(chatID, authorID string) bson.M {
+	return bson.M{"chat_id": chatID, "author_id": authorID, "status": domain.ScheduledPending}
+}
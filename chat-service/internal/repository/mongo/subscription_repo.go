@@ -0,0 +1,112 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SubscriptionRepo struct {
+	col Collection
+}
+
+func NewSubscriptionRepo(db *mongo.Database) *SubscriptionRepo {
+	return &SubscriptionRepo{col: db.Collection("channel_subscribers")}
+}
+
+// NewTestSubscriptionRepo - конструктор для тестов
+func NewTestSubscriptionRepo(col Collection) *SubscriptionRepo {
+	return &SubscriptionRepo{col: col}
+}
+
+// Subscribe добавляет подписку; false — пользователь уже был подписан
+func (r *SubscriptionRepo) Subscribe(ctx context.Context, chatID, userID string, at int64) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"chat_id": chatID, "user_id": userID},
+		bson.M{"$setOnInsert": domain.ChannelSubscription{ChatID: chatID, UserID: userID, SubscribedAt: at}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+// Unsubscribe удаляет подписку; false — подписки не было
+func (r *SubscriptionRepo) Unsubscribe(ctx context.Context, chatID, userID string) (bool, error) {
+	res, err := r.col.DeleteMany(ctx, bson.M{"chat_id": chatID, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+func (r *SubscriptionRepo) IsSubscribed(chatID, userID string) (bool, error) {
+	n, err := r.col.CountDocuments(context.Background(), bson.M{"chat_id": chatID, "user_id": userID})
+	return n > 0, err
+}
+
+// ListSubscribers возвращает подписчиков от ранних к поздним.
+// Курсор имеет вид "subscribed_at:user_id".
+func (r *SubscriptionRepo) ListSubscribers(chatID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error) {
+	ctx := context.Background()
+
+	filter := bson.M{"chat_id": chatID}
+	if cursor != "" {
+		ts, userID, err := parseKeysetCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"subscribed_at": bson.M{"$gt": ts}},
+			bson.M{"subscribed_at": ts, "user_id": bson.M{"$gt": userID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "subscribed_at", Value: 1}, {Key: "user_id", Value: 1}})
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	var subs []domain.ChannelSubscription
+	if err := cur.All(ctx, &subs); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(subs) > 0 {
+		last := subs[len(subs)-1]
+		nextCursor = fmt.Sprintf("%d:%s", last.SubscribedAt, last.UserID)
+	}
+	return subs, nextCursor, nil
+}
+
+// ListChannelIDs возвращает каналы, на которые подписан пользователь
+func (r *SubscriptionRepo) ListChannelIDs(userID string) ([]string, error) {
+	ctx := context.Background()
+
+	cur, err := r.col.Find(ctx, bson.M{"user_id": userID}, options.Find().SetProjection(bson.M{"chat_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var subs []domain.ChannelSubscription
+	if err := cur.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(subs))
+	for _, s := range subs {
+		ids = append(ids, s.ChatID)
+	}
+	return ids, nil
+}
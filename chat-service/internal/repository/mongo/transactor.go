@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor — транзакции Mongo; требуют replica set (в docker-compose — rs0 из одного узла)
+type Transactor struct {
+	client *mongo.Client
+}
+
+func NewTransactor(client *mongo.Client) *Transactor {
+	return &Transactor{client: client}
+}
+
+// WithTransaction выполняет fn в транзакции. Драйвер повторяет fn целиком
+// при временных ошибках, поэтому fn не должна иметь побочных эффектов вне Mongo.
+func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	sess, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+
+	_, err = sess.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -0,0 +1,167 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// userSeq — счётчик журнала пользователя (коллекция user_update_seqs)
+type userSeq struct {
+	UserID string `bson:"user_id"`
+	Seq    int64  `bson:"seq"`
+}
+
+type UpdateLogRepo struct {
+	seqs    Collection
+	updates Collection
+}
+
+func NewUpdateLogRepo(db *mongo.Database) *UpdateLogRepo {
+	return &UpdateLogRepo{
+		seqs:    db.Collection("user_update_seqs"),
+		updates: db.Collection("user_updates"),
+	}
+}
+
+// NewTestUpdateLogRepo - конструктор для тестов
+func NewTestUpdateLogRepo(seqs, updates Collection) *UpdateLogRepo {
+	return &UpdateLogRepo{seqs: seqs, updates: updates}
+}
+
+// Append записывает обновление в журнал каждого пользователя под его следующим seq.
+// Номера выдаются пачкой: один $inc на всех, у кого журнал уже начат, и одна вставка записей.
+// Номера читаются после $inc, поэтому Append должен идти в транзакции: там счётчик остаётся
+// захваченным до фиксации, и параллельная запись ждёт её, а не получает тот же seq.
+func (r *UpdateLogRepo) Append(ctx context.Context, userIDs []string, u domain.UserUpdate) error {
+	userIDs = uniqueIDs(userIDs)
+	if len(userIDs) == 0 {
+		return nil
+	}
+	seqs, err := r.allocate(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]interface{}, 0, len(userIDs))
+	for _, userID := range userIDs {
+		entry := u
+		entry.UserID = userID
+		entry.Seq = seqs[userID]
+		entries = append(entries, entry)
+	}
+	_, err = r.updates.InsertMany(ctx, entries)
+	return err
+}
+
+// allocate увеличивает счётчики пользователей и возвращает новые значения.
+// Счётчики, которых ещё нет (первое обновление пользователя), создаются по одному.
+func (r *UpdateLogRepo) allocate(ctx context.Context, userIDs []string) (map[string]int64, error) {
+	filter := bson.M{"user_id": bson.M{"$in": userIDs}}
+	if _, err := r.seqs.UpdateMany(ctx, filter, bson.M{"$inc": bson.M{"seq": 1}}); err != nil {
+		return nil, err
+	}
+	cur, err := r.seqs.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var current []userSeq
+	if err := cur.All(ctx, &current); err != nil {
+		return nil, err
+	}
+	seqs := make(map[string]int64, len(userIDs))
+	for _, st := range current {
+		seqs[st.UserID] = st.Seq
+	}
+	for _, userID := range userIDs {
+		if _, ok := seqs[userID]; ok {
+			continue
+		}
+		seq, err := r.nextSeq(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		seqs[userID] = seq
+	}
+	return seqs, nil
+}
+
+func (r *UpdateLogRepo) nextSeq(ctx context.Context, userID string) (int64, error) {
+	var st userSeq
+	err := r.seqs.FindOneAndUpdate(ctx,
+		bson.M{"user_id": userID},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&st)
+	return st.Seq, err
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// List возвращает записи журнала с seq > afterSeq по возрастанию
+func (r *UpdateLogRepo) List(userID string, afterSeq int64, limit int) ([]domain.UserUpdate, error) {
+	ctx := context.Background()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "seq", Value: 1}}).
+		SetLimit(int64(limit))
+	cur, err := r.updates.Find(ctx, bson.M{"user_id": userID, "seq": bson.M{"$gt": afterSeq}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var updates []domain.UserUpdate
+	if err := cur.All(ctx, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// CurrentSeq — последний выданный seq журнала; 0 — журнал ещё не начат
+func (r *UpdateLogRepo) CurrentSeq(userID string) (int64, error) {
+	var st userSeq
+	err := r.seqs.FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&st)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	return st.Seq, err
+}
+
+// OldestSeq — самый старый seq, оставшийся в журнале; false — журнал пуст
+func (r *UpdateLogRepo) OldestSeq(userID string) (int64, bool, error) {
+	var u domain.UserUpdate
+	err := r.updates.FindOne(context.Background(),
+		bson.M{"user_id": userID},
+		options.FindOne().SetSort(bson.D{{Key: "seq", Value: 1}}),
+	).Decode(&u)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return u.Seq, true, nil
+}
+
+// DeleteBefore удаляет записи, созданные раньше ts (unix); счётчики не сбрасываются
+func (r *UpdateLogRepo) DeleteBefore(ts int64) error {
+	_, err := r.updates.DeleteMany(context.Background(), bson.M{"created_at": bson.M{"$lt": ts}})
+	return err
+}
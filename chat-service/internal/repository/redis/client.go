@@ -0,0 +1,18 @@
+package redis
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// Client — обёртка над Redis для «горячего» состояния чатов
+type Client struct {
+	rdb *redis.Client
+}
+
+func New(addr string) *Client {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: addr,
+		DB:   0,
+	})
+	return &Client{rdb: rdb}
+}
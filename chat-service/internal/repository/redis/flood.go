@@ -0,0 +1,17 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Ключ flood:<chat_id>:<user_id>:<digest> — сколько раз автор отправил в чат один и тот же текст за окно
+func floodKey(chatID, userID, digest string) string {
+	return "flood:" + chatID + ":" + userID + ":" + digest
+}
+
+// CountRepeat засчитывает сообщение с отпечатком digest и возвращает число повторов в текущем окне
+func (c *Client) CountRepeat(ctx context.Context, chatID, userID, digest string, window time.Duration) (int64, error) {
+	count, _, err := c.incrWindow(ctx, floodKey(chatID, userID, digest), window)
+	return count, err
+}
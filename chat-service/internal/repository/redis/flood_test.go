@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CountRepeat(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	for want := int64(1); want <= 2; want++ {
+		count, err := client.CountRepeat(ctx, "chat1", "user1", "abc", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, want, count)
+	}
+	count, _ := client.CountRepeat(ctx, "chat1", "user1", "def", time.Minute)
+	assert.Equal(t, int64(1), count)
+
+	s.FastForward(time.Minute)
+	count, _ = client.CountRepeat(ctx, "chat1", "user1", "abc", time.Minute)
+	assert.Equal(t, int64(1), count)
+}
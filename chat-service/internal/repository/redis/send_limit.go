@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Ключ slowmode:<chat_id>:<user_id> живёт interval после отправки; пока он есть, писать нельзя.
+func slowModeKey(chatID, userID string) string {
+	return "slowmode:" + chatID + ":" + userID
+}
+
+// Ключ sendrate:<chat_id>:<user_id> — счётчик отправок в текущем окне (фиксированное окно от первой отправки)
+func sendRateKey(chatID, userID string) string {
+	return "sendrate:" + chatID + ":" + userID
+}
+
+// TakeSlowModeSlot занимает слот медленного режима; если слот уже занят — возвращает, сколько он ещё держится
+func (c *Client) TakeSlowModeSlot(ctx context.Context, chatID, userID string, interval time.Duration) (time.Duration, error) {
+	key := slowModeKey(chatID, userID)
+	ok, err := c.rdb.SetNX(ctx, key, "1", interval).Result()
+	if err != nil || ok {
+		return 0, err
+	}
+	left, err := c.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// Ключ истёк между командами — следующая попытка пройдёт
+	if left <= 0 {
+		return time.Millisecond, nil
+	}
+	return left, nil
+}
+
+// CountSend засчитывает отправку; сверх limit за окно возвращает время до конца окна
+func (c *Client) CountSend(ctx context.Context, chatID, userID string, limit int64, window time.Duration) (time.Duration, error) {
+	count, left, err := c.incrWindow(ctx, sendRateKey(chatID, userID), window)
+	if err != nil || count <= limit {
+		return 0, err
+	}
+	return left, nil
+}
+
+// incrWindow увеличивает счётчик фиксированного окна и возвращает его значение и остаток окна
+func (c *Client) incrWindow(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	pipe := c.rdb.TxPipeline()
+	count := pipe.Incr(ctx, key)
+	ttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	left := ttl.Val()
+	// Новый счётчик (или оставшийся без срока после сбоя) получает срок окна
+	if left < 0 {
+		if err := c.rdb.PExpire(ctx, key, window).Err(); err != nil {
+			return 0, 0, err
+		}
+		left = window
+	}
+	return count.Val(), left, nil
+}
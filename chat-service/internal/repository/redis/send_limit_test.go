@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_TakeSlowModeSlot(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	wait, err := client.TakeSlowModeSlot(ctx, "chat1", "user1", 30*time.Second)
+	assert.NoError(t, err)
+	assert.Zero(t, wait)
+
+	// Вторая попытка — ждать остаток интервала
+	s.FastForward(10 * time.Second)
+	wait, err = client.TakeSlowModeSlot(ctx, "chat1", "user1", 30*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Second, wait)
+
+	// Другой участник и другой чат не затронуты
+	wait, err = client.TakeSlowModeSlot(ctx, "chat1", "user2", 30*time.Second)
+	assert.NoError(t, err)
+	assert.Zero(t, wait)
+
+	s.FastForward(20 * time.Second)
+	wait, err = client.TakeSlowModeSlot(ctx, "chat1", "user1", 30*time.Second)
+	assert.NoError(t, err)
+	assert.Zero(t, wait)
+}
+
+func TestClient_CountSend(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		wait, err := client.CountSend(ctx, "chat1", "user1", 3, time.Minute)
+		assert.NoError(t, err)
+		assert.Zero(t, wait)
+	}
+
+	s.FastForward(15 * time.Second)
+	wait, err := client.CountSend(ctx, "chat1", "user1", 3, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Second, wait)
+
+	// Новое окно — счёт сначала
+	s.FastForward(45 * time.Second)
+	wait, err = client.CountSend(ctx, "chat1", "user1", 3, time.Minute)
+	assert.NoError(t, err)
+	assert.Zero(t, wait)
+}
+
+func TestClient_CountSend_RestoresLostExpiry(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	// Счётчик без срока (сбой между INCR и PEXPIRE) не должен блокировать навсегда
+	s.Set("sendrate:chat1:user1", "100")
+
+	wait, err := client.CountSend(ctx, "chat1", "user1", 3, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, wait)
+	assert.Equal(t, time.Minute, s.TTL("sendrate:chat1:user1"))
+}
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"main/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ключ typing:<chat_id> — хеш user_id -> "action|expires_at_ms".
+// Сам ключ живёт не дольше последнего обновления, просроченные поля отбрасываются при чтении.
+func typingKey(chatID string) string {
+	return "typing:" + chatID
+}
+
+// typingChannel — Pub/Sub канал статусов набора. Каждая реплика слушает его одной подпиской
+// и сама раздаёт статусы потокам SubscribeChats своих получателей
+const typingChannel = "typing-events"
+
+// typingEvent — статус набора и кому его доставить
+type typingEvent struct {
+	UserIDs []string            `json:"user_ids"`
+	Status  domain.TypingStatus `json:"status"`
+}
+
+func (c *Client) SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl).UnixMilli()
+	key := typingKey(chatID)
+
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(ctx, key, userID, string(action)+"|"+strconv.FormatInt(expiresAt, 10))
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *Client) ClearTyping(ctx context.Context, chatID, userID string) error {
+	return c.rdb.HDel(ctx, typingKey(chatID), userID).Err()
+}
+
+func (c *Client) ListTyping(ctx context.Context, chatID string) ([]domain.TypingStatus, error) {
+	fields, err := c.rdb.HGetAll(ctx, typingKey(chatID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	statuses := make([]domain.TypingStatus, 0, len(fields))
+	for userID, val := range fields {
+		action, expiresRaw, ok := strings.Cut(val, "|")
+		if !ok {
+			continue
+		}
+		expiresAt, err := strconv.ParseInt(expiresRaw, 10, 64)
+		if err != nil || expiresAt <= now {
+			continue
+		}
+		statuses = append(statuses, domain.TypingStatus{
+			ChatID:    chatID,
+			UserID:    userID,
+			Action:    domain.TypingAction(action),
+			ExpiresAt: expiresAt,
+		})
+	}
+	return statuses, nil
+}
+
+// Allow реализует простой троттлинг: true, если за окно window по ключу ещё не было вызовов
+func (c *Client) Allow(ctx context.Context, key string, window time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, "throttle:"+key, "1", window).Result()
+}
+
+// PublishTyping рассылает статус набора всем репликам
+func (c *Client) PublishTyping(ctx context.Context, userIDs []string, status domain.TypingStatus) error {
+	data, err := json.Marshal(typingEvent{UserIDs: userIDs, Status: status})
+	if err != nil {
+		return err
+	}
+	return c.rdb.Publish(ctx, typingChannel, data).Err()
+}
+
+// SubscribeTyping передаёт в deliver статусы, опубликованные любой репликой, пока не отменён ctx.
+// Нечитаемые сообщения пропускаются
+func (c *Client) SubscribeTyping(ctx context.Context, deliver func(userIDs []string, status domain.TypingStatus)) error {
+	sub := c.rdb.Subscribe(ctx, typingChannel)
+	defer sub.Close()
+	// Receive дожидается подтверждения подписки: недоступный Redis — ошибка сразу, а не тишина в канале
+	if _, err := sub.Receive(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	msgs := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return errors.New("typing subscription closed")
+			}
+			var evt typingEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &evt); err != nil {
+				continue
+			}
+			deliver(evt.UserIDs, evt.Status)
+		}
+	}
+}
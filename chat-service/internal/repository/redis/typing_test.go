@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"main/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SetTyping_ListTyping(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	err := client.SetTyping(ctx, "chat1", "user1", domain.TypingActionTyping, 5*time.Second)
+	assert.NoError(t, err)
+
+	// Ключ должен жить не дольше TTL
+	assert.True(t, s.TTL("typing:chat1") > 0)
+
+	statuses, err := client.ListTyping(ctx, "chat1")
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "user1", statuses[0].UserID)
+	assert.Equal(t, domain.TypingActionTyping, statuses[0].Action)
+}
+
+func TestClient_ListTyping_SkipsExpired(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	// Поле с истёкшим сроком, ключ ещё не удалён
+	s.HSet("typing:chat1", "user1", "typing|1")
+
+	statuses, err := client.ListTyping(ctx, "chat1")
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestClient_ClearTyping(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	assert.NoError(t, client.SetTyping(ctx, "chat1", "user1", domain.TypingActionUploading, 5*time.Second))
+	assert.NoError(t, client.ClearTyping(ctx, "chat1", "user1"))
+
+	statuses, err := client.ListTyping(ctx, "chat1")
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestClient_Allow(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	ok, err := client.Allow(ctx, "k", 2*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Повторный вызов в пределах окна отбрасывается
+	ok, err = client.Allow(ctx, "k", 2*time.Second)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// После окна снова разрешено
+	s.FastForward(3 * time.Second)
+	ok, err = client.Allow(ctx, "k", 2*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestClient_PublishTyping_SubscribeTyping(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	type delivery struct {
+		userIDs []string
+		status  domain.TypingStatus
+	}
+	got := make(chan delivery, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.SubscribeTyping(ctx, func(userIDs []string, status domain.TypingStatus) {
+			got <- delivery{userIDs, status}
+		})
+	}()
+	// Публикация до подписки никуда не доходит — ждём, пока реплика подпишется
+	assert.Eventually(t, func() bool { return s.PubSubNumSub(typingChannel)[typingChannel] == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	status := domain.TypingStatus{ChatID: "chat1", UserID: "user1", Action: domain.TypingActionTyping, ExpiresAt: 42}
+	assert.NoError(t, client.PublishTyping(context.Background(), []string{"user2", "user3"}, status))
+
+	select {
+	case d := <-got:
+		assert.Equal(t, []string{"user2", "user3"}, d.userIDs)
+		assert.Equal(t, status, d.status)
+	case <-time.After(2 * time.Second):
+		t.Fatal("typing status not delivered")
+	}
+
+	// Отмена завершает подписку без ошибки
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,16 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Ключ view:<message_id>:<user_id> живёт window после первого просмотра; пока он есть, просмотр не засчитывается
+func viewKey(messageID, userID string) string {
+	return "view:" + messageID + ":" + userID
+}
+
+// MarkViewed засчитывает просмотр, если за окно window пользователь пост ещё не смотрел
+func (c *Client) MarkViewed(ctx context.Context, messageID, userID string, window time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, viewKey(messageID, userID), "1", window).Result()
+}
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_MarkViewed(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := New(s.Addr())
+	ctx := context.Background()
+
+	ok, err := client.MarkViewed(ctx, "post1", "user1", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Повторный просмотр в окне не засчитывается, просмотр другого пользователя — засчитывается
+	ok, err = client.MarkViewed(ctx, "post1", "user1", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = client.MarkViewed(ctx, "post1", "user2", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	s.FastForward(time.Hour)
+	ok, err = client.MarkViewed(ctx, "post1", "user1", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -1,27 +1,229 @@
 package repository
 
 import (
+	"context"
 	"main/internal/domain"
 	user "main/pkg/api_user_service"
+	"time"
 )
 
 type ChatRepository interface {
-	CreateDirect(userID, peerID string, userClient user.UserServiceClient) (domain.Chat, error)
-	CreateGroup(creatorID string, members []string, title string, userClient user.UserServiceClient) (domain.Chat, error)
-	UpdateGroup(chatID string, title *string, addMembers, removeMembers []string, requesterID string, userClient user.UserServiceClient) (domain.Chat, error)
+	CreateDirect(ctx context.Context, userID, peerID string, userClient user.UserServiceClient) (domain.Chat, error)
+	CreateGroup(ctx context.Context, creatorID string, members []string, title string, userClient user.UserServiceClient) (domain.Chat, error)
+	UpdateGroup(ctx context.Context, chatID string, title *string, addMembers, removeMembers []string, requesterID string, userClient user.UserServiceClient) (domain.Chat, []string, []string, error)
 	Get(chatID string) (domain.Chat, error)
-	List(userID string, limit int, cursor string) ([]domain.Chat, string, error)
+	List(q domain.ChatQuery) ([]domain.Chat, string, error)
+	SetLastMessage(chatID string, p domain.MessagePreview) error
+	Pin(chatID string, pin domain.PinnedMessage) (domain.Chat, error)
+	Unpin(chatID, messageID string) (domain.Chat, error)
+	NextSeq(chatID string) (int64, error)
+	ReserveSeqs(chatID string, n int64) (int64, error)
+	SetMessageTTL(chatID string, ttl int64) (domain.Chat, error)
+	SetSlowMode(chatID string, seconds int64) (domain.Chat, error)
+	CreateChannel(chat domain.Chat) error
+	CreateSecret(ctx context.Context, chat domain.Chat) error
+	GetByHandle(handle string) (domain.Chat, error)
+	IncSubscribers(ctx context.Context, chatID string, delta int64) error
+	SetChannelAdmin(chatID, userID string, admin bool) (domain.Chat, error)
+	AddMember(ctx context.Context, chatID, userID string) (domain.Chat, bool, error)
+	ListAll(afterID string, limit int) ([]domain.Chat, error)
 }
 
 type MessageRepository interface {
-	Send(msg domain.Message) (domain.Message, error)
+	Send(ctx context.Context, msg domain.Message) (domain.Message, error)
+	InsertMany(ctx context.Context, msgs []domain.Message) error
 	Get(id string) (domain.Message, error)
-	Update(messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error)
-	Delete(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error)
-	List(chatID string, limit int, cursor string) ([]domain.Message, string, error)
-	MarkRead(chatID, userID, messageID string) error
+	GetMany(ids []string) ([]domain.Message, error)
+	Update(ctx context.Context, messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error)
+	Delete(ctx context.Context, messageIDs []string, requesterID string) ([]domain.Message, error)
+	List(q domain.MessageQuery) (domain.MessagePage, error)
 	ToggleSaved(userID, messageID string, saved bool) error
 	ListSaved(userID string, limit int, cursor string) ([]domain.Message, string, error)
-	ListReadMessages(userID, chatID string, limit int) ([]domain.Message, error)
-	GetUnreadCount(chatID, userID string) (int64, error)
+	ListReadMessages(states []domain.ReadState, limit int) ([]domain.Message, error)
+	ListRevisions(messageID string) ([]domain.MessageRevision, error)
+	ListExpired(now int64, limit int) ([]domain.Message, error)
+	Vote(ctx context.Context, vote domain.PollVote, now int64) (domain.Message, error)
+	RetractVote(ctx context.Context, messageID, userID string, now int64) (domain.Message, error)
+	GetVote(messageID, userID string) (domain.PollVote, error)
+	ListVotes(messageID string) ([]domain.PollVote, error)
+	ClosePoll(ctx context.Context, messageID string, now int64) (domain.Message, error)
+	IncViews(chatID string, messageIDs []string) error
+	Purge(ctx context.Context, messageIDs []string) error
+	MediaInUse(chatID string, fileIDs []string) ([]string, error)
+	ListDeletedBefore(ts int64, limit int) ([]domain.Message, error)
+	ListUpToSeq(chatID string, seq int64, limit int) ([]domain.Message, error)
+	ClearSavedOnDeleted() (int64, error)
+}
+
+type MentionRepository interface {
+	Add(mentions []domain.Mention) error
+	ListUnread(userID, chatID string, limit int, cursor string) ([]domain.Mention, string, error)
+	CountUnread(userID, chatID string) (int64, error)
+	MarkRead(userID, chatID string, upToSeq int64) error
+	DeleteByMessages(messageIDs []string) error
+}
+
+// ChatStateRepository — личные настройки чатов пользователя.
+// Закрепление снимает архив, архивирование снимает закрепление.
+type ChatStateRepository interface {
+	Get(chatID, userID string) (domain.UserChatState, error)
+	ListByUser(userID string) ([]domain.UserChatState, error)
+	CountPinned(userID string) (int64, error)
+	SetPinned(chatID, userID string, pinned bool, at int64) error
+	SetArchived(chatID, userID string, archived bool) error
+	SetMutedUntil(chatID, userID string, until int64) error
+	SetMarkedUnread(chatID, userID string, unread bool) error
+}
+
+// SubscriptionRepository — подписчики каналов. Subscribe и Unsubscribe сообщают,
+// изменилась ли подписка, чтобы счётчик в документе канала менялся ровно один раз.
+type SubscriptionRepository interface {
+	Subscribe(ctx context.Context, chatID, userID string, at int64) (bool, error)
+	Unsubscribe(ctx context.Context, chatID, userID string) (bool, error)
+	IsSubscribed(chatID, userID string) (bool, error)
+	ListSubscribers(chatID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error)
+	ListChannelIDs(userID string) ([]string, error)
+}
+
+// InviteRepository — ссылки-приглашения и заявки на вступление.
+// UseLink атомарно проверяет ссылку и засчитывает использование, поэтому лимит не превышается
+// при одновременных вступлениях; ReleaseLink возвращает использование, если вступление не состоялось.
+type InviteRepository interface {
+	CreateLink(link domain.InviteLink) error
+	GetLink(code string) (domain.InviteLink, error)
+	RevokeLink(code string) error
+	UseLink(code string, now int64) (domain.InviteLink, error)
+	ReleaseLink(code string) error
+	CreateRequest(req domain.JoinRequest) (domain.JoinRequest, error)
+	GetRequest(id string) (domain.JoinRequest, error)
+	ListPendingRequests(chatID string, limit int, cursor string) ([]domain.JoinRequest, string, error)
+	DecideRequest(ctx context.Context, id string, status domain.JoinRequestStatus, decidedBy string, now int64) (domain.JoinRequest, error)
+}
+
+// ModerationRepository — очередь модерации.
+// AddReport копит жалобы на сообщение в одной ожидающей записи и не засчитывает повторную жалобу того же
+// пользователя; Decide решает ожидающую запись один раз, поэтому два модератора не применят решение дважды.
+type ModerationRepository interface {
+	AddHeld(item domain.ModerationItem) error
+	AddReport(item domain.ModerationItem, report domain.ModerationReport) (domain.ModerationItem, error)
+	Get(id string) (domain.ModerationItem, error)
+	ListPending(chatID string, limit int, cursor string) ([]domain.ModerationItem, string, error)
+	Decide(id string, status domain.ModerationStatus, decidedBy string, now int64) (domain.ModerationItem, error)
+}
+
+// ScheduledMessageRepository — очередь отложенных сообщений.
+// ClaimDue атомарно захватывает одну созревшую запись, поэтому каждую доставляет только одна реплика;
+// запись, захват которой истёк (реплика упала), снова становится доступной.
+type ScheduledMessageRepository interface {
+	Create(m domain.ScheduledMessage) error
+	Get(id string) (domain.ScheduledMessage, error)
+	ListPending(chatID, authorID string) ([]domain.ScheduledMessage, error)
+	CountPending(chatID, authorID string) (int64, error)
+	UpdatePending(id string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error)
+	Cancel(id string) error
+	ClaimDue(now int64, lease time.Duration) (domain.ScheduledMessage, bool, error)
+	MarkSent(id, messageID string) error
+	MarkFailed(id, reason string) error
+}
+
+// DraftRepository — черновики, одна запись на пару (чат, пользователь).
+// Save применяет черновик, только если сохранённая версия равна baseVersion, и возвращает его
+// с новой версией; false — черновик уже правили с другого устройства, он остался без изменений.
+// Overwrite записывает черновик без проверки версии (очистка после отправки сообщения).
+type DraftRepository interface {
+	Save(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error)
+	Overwrite(ctx context.Context, d domain.Draft) (domain.Draft, error)
+	Get(chatID, userID string) (domain.Draft, error)
+	ListByUser(userID string) ([]domain.Draft, error)
+}
+
+// ChatFolderRepository — папки чатов пользователя, упорядоченные по Position.
+// Update и Delete ищут папку по id и владельцу; чужая папка — ErrFolderNotFound.
+type ChatFolderRepository interface {
+	Create(ctx context.Context, f domain.ChatFolder) error
+	Get(id, userID string) (domain.ChatFolder, error)
+	ListByUser(userID string) ([]domain.ChatFolder, error)
+	Count(userID string) (int64, error)
+	Update(ctx context.Context, f domain.ChatFolder) error
+	Delete(ctx context.Context, id, userID string) error
+	SetPositions(ctx context.Context, userID string, ids []string, at int64) error
+}
+
+// UpdateLogRepository — персональные журналы обновлений.
+// Append вызывается в транзакции изменения, о котором пишет, и выдаёт каждому пользователю
+// следующий seq его журнала; старые записи удаляет DeleteBefore.
+type UpdateLogRepository interface {
+	Append(ctx context.Context, userIDs []string, u domain.UserUpdate) error
+	List(userID string, afterSeq int64, limit int) ([]domain.UserUpdate, error)
+	CurrentSeq(userID string) (int64, error)
+	OldestSeq(userID string) (int64, bool, error)
+	DeleteBefore(ts int64) error
+}
+
+// OutboxRepository — исходящие события Kafka.
+// Add вызывается внутри транзакции вместе с изменением данных; ClaimDue захватывает
+// созревшую запись на время lease, как очередь отложенных сообщений.
+type OutboxRepository interface {
+	Add(ctx context.Context, e domain.OutboxEvent) error
+	ClaimDue(now int64, lease time.Duration) (domain.OutboxEvent, bool, error)
+	MarkSent(id string) error
+	MarkRetry(id string, nextAttemptAt int64, reason string) error
+	DeleteSentBefore(ts int64) error
+}
+
+// Transactor выполняет fn в транзакции Mongo; запись репозиториев с полученным ctx попадает в неё.
+// Ошибка fn откатывает транзакцию.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ReadStateRepository — указатели прочтения и доставки по парам (чат, пользователь).
+// Указатели только растут: отметка более старого сообщения ничего не меняет.
+// Вместе с указателями хранится счётчик непрочитанного: Track заводит его новым участникам,
+// AdvanceChat растит при новом сообщении, MarkRead уменьшает; ListUnread выбирает чаты с ненулевым счётчиком.
+type ReadStateRepository interface {
+	MarkRead(ctx context.Context, chatID, userID string, seq int64) error
+	MarkDelivered(chatID, userID string, seq int64) error
+	Track(ctx context.Context, chatID string, userIDs []string, lastSeq int64) error
+	AdvanceChat(ctx context.Context, chatID string, seq int64) error
+	ListUnread(userID string) ([]domain.ReadState, error)
+	Get(chatID, userID string) (domain.ReadState, error)
+	ListByChat(chatID string) ([]domain.ReadState, error)
+	ListByUser(userID string) ([]domain.ReadState, error)
+	DeleteUsers(chatID string, userIDs []string) (int64, error)
+}
+
+// RetentionRepository — контрольная точка задачи очистки истории.
+// Claim захватывает задачу на lease, если её не держит другая реплика, и возвращает, где остановился
+// прошлый проход, вместе с новой меткой захвата; Checkpoint сохраняет прогресс и продлевает захват;
+// Finish закрывает проход. Оба срабатывают только с текущей меткой, иначе — domain.ErrLeaseLost.
+type RetentionRepository interface {
+	Claim(now int64, lease time.Duration) (domain.RetentionCheckpoint, bool, error)
+	Checkpoint(token, chatCursor string, lockedUntil int64) error
+	Finish(token string, at int64) error
+}
+
+// SendLimitRepository — ограничения частоты отправки (счётчики в Redis).
+// Оба метода засчитывают попытку и возвращают 0, если писать можно, иначе — сколько ещё ждать.
+type SendLimitRepository interface {
+	TakeSlowModeSlot(ctx context.Context, chatID, userID string, interval time.Duration) (time.Duration, error)
+	CountSend(ctx context.Context, chatID, userID string, limit int64, window time.Duration) (time.Duration, error)
+}
+
+// ViewRepository — дедупликация просмотров постов (Redis).
+// MarkViewed отмечает просмотр и возвращает true, если пользователь не смотрел пост в течение window.
+type ViewRepository interface {
+	MarkViewed(ctx context.Context, messageID, userID string, window time.Duration) (bool, error)
+}
+
+// TypingRepository — эфемерные статусы набора и их рассылка (Redis).
+// PublishTyping доставляет статус всем репликам; SubscribeTyping передаёт в deliver опубликованные
+// статусы до отмены ctx и возвращает ошибку, если подписка оборвалась.
+type TypingRepository interface {
+	SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction, ttl time.Duration) error
+	ClearTyping(ctx context.Context, chatID, userID string) error
+	ListTyping(ctx context.Context, chatID string) ([]domain.TypingStatus, error)
+	Allow(ctx context.Context, key string, window time.Duration) (bool, error)
+	PublishTyping(ctx context.Context, userIDs []string, status domain.TypingStatus) error
+	SubscribeTyping(ctx context.Context, deliver func(userIDs []string, status domain.TypingStatus)) error
 }
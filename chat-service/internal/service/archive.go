@@ -0,0 +1,423 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"main/internal/archive"
+	"main/internal/domain"
+	userserviceclient "main/internal/user-service-client"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Предельный размер импортируемого архива
+	maxImportBytes = 50 << 20
+	// Сколько сообщений импорта сохраняется за одну вставку
+	importBatch = 500
+)
+
+// ExportChat пишет в w всю историю чата: участников, сообщения и ссылки на вложения.
+// format — json (архив, который принимает ImportChat) или html (страница для чтения).
+// Выгрузить чат может любой, кто может его читать; удалённые сообщения в выгрузку не попадают.
+func (s *ChatService) ExportChat(ctx context.Context, chatID, requesterID, format string, w io.Writer) error {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkReader(chat, requesterID); err != nil {
+		return err
+	}
+	// На сервере нет открытого текста секретного чата — выгружать нечего
+	if chat.Secret {
+		return fmt.Errorf("%w: секретный чат нельзя выгрузить", domain.ErrInvalidArgument)
+	}
+	aw, err := archive.NewWriter(format, w, s.archiveKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err)
+	}
+
+	names := newUserCache(s)
+	header := archive.Header{
+		Version:    archive.Version,
+		ExportedAt: time.Now().Unix(),
+		ExportedBy: requesterID,
+		Chat: archive.Chat{
+			ID:        chat.ID,
+			Kind:      string(chat.Kind),
+			Title:     chat.Title,
+			CreatedBy: chat.CreatedBy,
+			CreatedAt: chat.CreatedAt,
+		},
+		Members: make([]archive.Member, 0, len(chat.MemberIDs)),
+	}
+	for _, id := range chat.MemberIDs {
+		header.Members = append(header.Members, archive.Member{
+			UserID: id,
+			Name:   names.get(id),
+			Role:   string(chat.RoleOf(id)),
+		})
+	}
+	if err := aw.Begin(header); err != nil {
+		return err
+	}
+
+	// Первая страница — от начала истории, дальше — после последнего выгруженного seq
+	q := domain.MessageQuery{ChatID: chat.ID, Limit: maxMessagesLimit, AroundSeq: 1}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := s.msgs.List(q)
+		if err != nil {
+			return err
+		}
+		for _, m := range page.Messages {
+			if err := aw.Message(toArchiveMessage(m, names.get(m.AuthorID))); err != nil {
+				return err
+			}
+		}
+		if !page.HasAfter || len(page.Messages) == 0 {
+			break
+		}
+		q = domain.MessageQuery{ChatID: chat.ID, Limit: maxMessagesLimit, AfterSeq: page.Messages[len(page.Messages)-1].Seq}
+	}
+	return aw.End()
+}
+
+// ImportChat восстанавливает JSON-архив ExportChat в новую группу, владельцем которой становится requesterID.
+// Пустой title — название из архива. Участников архива в группу сразу не добавляют: для найденных пользователей,
+// кроме заблокировавших импортёра, создаётся ссылка-приглашение, и каждый вступает сам.
+// Сообщения сохраняют время; автора — только если архиву можно верить (он подписан этим сервисом или импортёр
+// читает исходный чат, а автор в нём состоит) и автор существует. Иначе автором записывается импортёр,
+// а имя исходного автора остаётся в imported_from. Вложения проверяются через media-service
+// от имени импортёра: чужие и удалённые файлы отбрасываются. Опросы переносятся закрытыми, без голосов.
+// Импорт не рассылает уведомлений о новых сообщениях.
+func (s *ChatService) ImportChat(ctx context.Context, requesterID, title string, r io.Reader) (domain.ImportResult, error) {
+	if requesterID == "" {
+		return domain.ImportResult{}, fmt.Errorf("%w: requester_id обязателен", domain.ErrInvalidArgument)
+	}
+	a, err := archive.Read(r, maxImportBytes)
+	if err != nil {
+		return domain.ImportResult{}, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err)
+	}
+	if title == "" {
+		title = a.Chat.Title
+	}
+	if title == "" {
+		return domain.ImportResult{}, fmt.Errorf("%w: название группы обязательно", domain.ErrInvalidArgument)
+	}
+
+	var result domain.ImportResult
+	exists := newUserCache(s)
+	candidates := make([]string, 0, len(a.Members))
+	seen := map[string]bool{requesterID: true}
+	for _, m := range a.Members {
+		if seen[m.UserID] {
+			continue
+		}
+		seen[m.UserID] = true
+		if !exists.check(m.UserID) {
+			result.SkippedMembers++
+			continue
+		}
+		candidates = append(candidates, m.UserID)
+	}
+	blockedBy, err := userserviceclient.BlockedBy(s.userClient, requesterID, candidates)
+	if err != nil {
+		return domain.ImportResult{}, fmt.Errorf("не удалось проверить блокировки: %w", err)
+	}
+	blocked := make(map[string]bool, len(blockedBy))
+	for _, id := range blockedBy {
+		blocked[id] = true
+	}
+	invited := make([]string, 0, len(candidates))
+	for _, id := range candidates {
+		// Без ссылок-приглашений вступить в группу с согласия нельзя — участники не переносятся
+		if blocked[id] || s.invites == nil {
+			result.SkippedMembers++
+			continue
+		}
+		invited = append(invited, id)
+	}
+
+	chat, err := s.CreateGroup(ctx, requesterID, nil, title)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+	result.Chat = chat
+	if len(invited) > 0 {
+		link, err := s.CreateInviteLink(ctx, chat.ID, requesterID, domain.InviteSettings{
+			UsageLimit: int64(min(len(invited), maxInviteUsageLimit)),
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Invite = &link
+		result.InvitedMembers = invited
+	}
+	if len(a.Messages) == 0 {
+		return result, nil
+	}
+	trust := s.trustArchive(a, requesterID)
+
+	lastSeq, err := s.chats.ReserveSeqs(chat.ID, int64(len(a.Messages)))
+	if err != nil {
+		return result, err
+	}
+	seq := lastSeq - int64(len(a.Messages))
+
+	// Новые id нужны заранее: системные сообщения ссылаются на другие сообщения архива.
+	// Повтор id в архиве получает свой id, ссылки ведут на первое сообщение
+	ids := make(map[string]string, len(a.Messages))
+	newIDs := make([]string, len(a.Messages))
+	for i, m := range a.Messages {
+		newIDs[i] = uuid.New().String()
+		if _, ok := ids[m.ID]; !ok {
+			ids[m.ID] = newIDs[i]
+		}
+	}
+
+	now := time.Now().Unix()
+	batch := make([]domain.Message, 0, importBatch)
+	var last domain.Message
+	for i, am := range a.Messages {
+		seq++
+		m := s.fromArchiveMessage(ctx, am, chat.ID, requesterID, trust, ids, exists, &result, now)
+		m.ID = newIDs[i]
+		m.Seq = seq
+		batch = append(batch, m)
+		if len(batch) == importBatch {
+			if err := s.insertImported(ctx, chat, requesterID, batch); err != nil {
+				return result, err
+			}
+			result.ImportedMessages += len(batch)
+			batch = batch[:0]
+		}
+		last = m
+	}
+	if err := s.insertImported(ctx, chat, requesterID, batch); err != nil {
+		return result, err
+	}
+	result.ImportedMessages += len(batch)
+	s.advanceUnread(ctx, chat.ID, last.Seq)
+
+	// Импортёр видел всю историю; остальные участники получают её непрочитанной
+	if s.reads != nil {
+		_ = s.reads.MarkRead(ctx, chat.ID, requesterID, last.Seq)
+	}
+	s.updatePreview(last)
+	return result, nil
+}
+
+// insertImported сохраняет порцию импорта вместе с событиями для поиска и записью в журналы
+func (s *ChatService) insertImported(ctx context.Context, chat domain.Chat, requesterID string, batch []domain.Message) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	ids := make([]string, len(batch))
+	for i, m := range batch {
+		ids[i] = m.ID
+	}
+	return s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.msgs.InsertMany(ctx, batch); err != nil {
+			return err
+		}
+		for _, m := range batch {
+			if err := s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "message", Data: m})); err != nil {
+				return err
+			}
+		}
+		return s.emit(s.logMessages(ctx, chat, domain.UpdateMessageNew, requesterID, ids))
+	})
+}
+
+// importTrust решает, каким авторам архива верить
+type importTrust struct {
+	signed bool
+	source *domain.Chat // исходный чат, если импортёр его читает
+}
+
+// trustArchive проверяет подпись архива и, если её нет, доступ импортёра к исходному чату
+func (s *ChatService) trustArchive(a archive.Archive, requesterID string) importTrust {
+	if a.Verify(s.archiveKey) {
+		return importTrust{signed: true}
+	}
+	if a.Chat.ID == "" {
+		return importTrust{}
+	}
+	source, err := s.loadChat(a.Chat.ID)
+	if err != nil || s.checkReader(source, requesterID) != nil {
+		return importTrust{}
+	}
+	return importTrust{source: &source}
+}
+
+// keeps — сообщение может остаться за authorID: импортёр всегда может перенести свои сообщения
+func (t importTrust) keeps(authorID, requesterID string) bool {
+	switch {
+	case authorID == requesterID, t.signed:
+		return true
+	case t.source != nil:
+		return t.source.RoleOf(authorID) != ""
+	}
+	return false
+}
+
+// fromArchiveMessage переносит сообщение архива в новый чат; id и seq проставляет вызывающий
+func (s *ChatService) fromArchiveMessage(ctx context.Context, am archive.Message, chatID, requesterID string, trust importTrust,
+	ids map[string]string, exists *userCache, result *domain.ImportResult, now int64) domain.Message {
+	m := domain.Message{
+		ChatID:    chatID,
+		AuthorID:  am.AuthorID,
+		Type:      domain.MessageType(am.Type),
+		Text:      am.Text,
+		CreatedAt: am.CreatedAt,
+		UpdatedAt: am.UpdatedAt,
+		Edited:    am.Edited,
+	}
+	switch {
+	case m.Type == domain.MessageTypePoll && am.Poll != nil:
+	case m.Type == domain.MessageTypeSystem && am.System != nil:
+	default:
+		m.Type = domain.MessageTypeText
+		am.Poll, am.System = nil, nil
+	}
+	if m.CreatedAt == 0 {
+		m.CreatedAt = now
+	}
+	if am.AuthorID == "" || !trust.keeps(am.AuthorID, requesterID) || !exists.check(am.AuthorID) {
+		m.AuthorID = requesterID
+		m.ImportedFrom = am.AuthorName
+		if m.ImportedFrom == "" {
+			m.ImportedFrom = am.AuthorID
+		}
+		result.ReassignedMessages++
+	}
+
+	for _, e := range am.Entities {
+		// Упоминание ненайденного пользователя остаётся просто текстом
+		if e.UserID != "" && !exists.check(e.UserID) {
+			continue
+		}
+		m.Entities = append(m.Entities, domain.MessageEntity{Type: e.Type, Offset: e.Offset, Length: e.Length, UserID: e.UserID})
+	}
+
+	if am.System != nil {
+		evt := domain.SystemEvent{Action: am.System.Action, ActorID: m.AuthorID, MessageID: ids[am.System.MessageID]}
+		if am.System.UserID != "" && exists.check(am.System.UserID) {
+			evt.UserID = am.System.UserID
+		}
+		m.System = &evt
+	}
+
+	if am.Poll != nil {
+		poll := domain.Poll{
+			Question:       am.Poll.Question,
+			MultipleChoice: am.Poll.MultipleChoice,
+			Anonymous:      am.Poll.Anonymous,
+			Closed:         true,
+			ClosedAt:       now,
+			TotalVoters:    am.Poll.TotalVoters,
+		}
+		for i, o := range am.Poll.Options {
+			poll.Options = append(poll.Options, domain.PollOption{ID: i, Text: o.Text, Votes: o.Votes})
+		}
+		m.Poll = &poll
+	}
+
+	for _, md := range am.Media {
+		if md.ID == "" {
+			result.DroppedAttachments++
+			continue
+		}
+		if s.media == nil {
+			m.Media = append(m.Media, domain.Media{ID: md.ID, Type: md.Type, URL: md.URL, Mime: md.Mime, SizeBytes: md.SizeBytes, AuthorID: m.AuthorID})
+			continue
+		}
+		file, err := s.media.AttachToChat(ctx, md.ID, requesterID, chatID)
+		if err != nil {
+			result.DroppedAttachments++
+			continue
+		}
+		m.Media = append(m.Media, file)
+	}
+	return m
+}
+
+func toArchiveMessage(m domain.Message, authorName string) archive.Message {
+	am := archive.Message{
+		ID:         m.ID,
+		Seq:        m.Seq,
+		AuthorID:   m.AuthorID,
+		AuthorName: authorName,
+		Type:       string(m.Type),
+		Text:       m.Text,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		Edited:     m.Edited,
+	}
+	// Повторно выгруженный импорт сохраняет имя исходного автора
+	if m.ImportedFrom != "" {
+		am.AuthorName = m.ImportedFrom
+	}
+	for _, md := range m.Media {
+		am.Media = append(am.Media, archive.Media{ID: md.ID, Type: md.Type, URL: md.URL, Mime: md.Mime, SizeBytes: md.SizeBytes})
+	}
+	for _, e := range m.Entities {
+		am.Entities = append(am.Entities, archive.Entity{Type: e.Type, Offset: e.Offset, Length: e.Length, UserID: e.UserID})
+	}
+	if m.System != nil {
+		am.System = &archive.System{Action: m.System.Action, ActorID: m.System.ActorID, MessageID: m.System.MessageID, UserID: m.System.UserID}
+	}
+	if m.Poll != nil {
+		p := &archive.Poll{
+			Question:       m.Poll.Question,
+			MultipleChoice: m.Poll.MultipleChoice,
+			Anonymous:      m.Poll.Anonymous,
+			Closed:         m.Poll.IsClosed(time.Now().Unix()),
+			TotalVoters:    m.Poll.TotalVoters,
+		}
+		for _, o := range m.Poll.Options {
+			p.Options = append(p.Options, archive.PollOption{Text: o.Text, Votes: o.Votes})
+		}
+		am.Poll = p
+	}
+	return am
+}
+
+// userCache запоминает ответы user-service, чтобы не спрашивать про одного пользователя на каждом сообщении
+type userCache struct {
+	s     *ChatService
+	names map[string]string
+	found map[string]bool
+}
+
+func newUserCache(s *ChatService) *userCache {
+	return &userCache{s: s, names: map[string]string{}, found: map[string]bool{}}
+}
+
+func (c *userCache) lookup(id string) {
+	if _, ok := c.found[id]; ok {
+		return
+	}
+	info, err := userserviceclient.GetUserInfo(c.s.userClient, id)
+	c.found[id] = err == nil && info != nil
+	if c.found[id] {
+		c.names[id] = info.UserName
+	}
+}
+
+// check — пользователь существует
+func (c *userCache) check(id string) bool {
+	c.lookup(id)
+	return c.found[id]
+}
+
+// get — имя пользователя или пустая строка, если он не найден
+func (c *userCache) get(id string) string {
+	c.lookup(id)
+	return c.names[id]
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	userserviceclient "main/internal/user-service-client"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxViewBatch = 100
+	// Повторный просмотр поста тем же пользователем в течение окна не засчитывается
+	viewDedupWindow = 24 * time.Hour
+)
+
+// handle: латиница, цифры и _, начинается с буквы, 5–32 символа
+var handlePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{4,31}$`)
+
+var errChannelsDisabled = fmt.Errorf("%w: каналы не подключены", domain.ErrInvalidArgument)
+
+// WithChannels включает каналы: подписчики хранятся в отдельном репозитории
+func WithChannels(r repository.SubscriptionRepository) Option {
+	return func(s *ChatService) {
+		s.subscriptions = r
+	}
+}
+
+// WithViewDedup включает дедупликацию просмотров: повтор в течение суток не увеличивает счётчик поста
+func WithViewDedup(r repository.ViewRepository) Option {
+	return func(s *ChatService) {
+		s.views = r
+	}
+}
+
+// CreateChannel создаёт канал; создатель становится владельцем, других администраторов пока нет
+func (s *ChatService) CreateChannel(ctx context.Context, creatorID, title, handle string) (domain.Chat, error) {
+	if s.subscriptions == nil {
+		return domain.Chat{}, errChannelsDisabled
+	}
+	handle, err := normalizeHandle(handle)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return domain.Chat{}, fmt.Errorf("%w: у канала должно быть название", domain.ErrInvalidArgument)
+	}
+	if _, err := userserviceclient.GetUserInfo(s.userClient, creatorID); err != nil {
+		return domain.Chat{}, fmt.Errorf("создатель %s не найден: %w", creatorID, err)
+	}
+
+	now := time.Now().Unix()
+	chat := domain.Chat{
+		ID:            uuid.New().String(),
+		Kind:          domain.ChatKindChannel,
+		MemberIDs:     []string{creatorID},
+		Title:         title,
+		Handle:        handle,
+		CreatedBy:     creatorID,
+		CreatedAt:     now,
+		LastMessageAt: now,
+	}
+	if err := s.chats.CreateChannel(chat); err != nil {
+		return domain.Chat{}, err
+	}
+	// Канал уже создан: без счётчика указатель создателя появится при первом прочтении
+	_ = s.trackUnread(ctx, chat, chat.MemberIDs)
+	return chat, nil
+}
+
+// GetChannelByHandle находит канал по публичному имени (с @ или без)
+func (s *ChatService) GetChannelByHandle(ctx context.Context, handle string) (domain.Chat, error) {
+	handle, err := normalizeHandle(handle)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	return s.chats.GetByHandle(handle)
+}
+
+// SubscribeChannel подписывает пользователя; повторная подписка ничего не меняет.
+// Администраторы видят канал и без подписки.
+func (s *ChatService) SubscribeChannel(ctx context.Context, chatID, userID string) (domain.Chat, error) {
+	chat, err := s.loadChannel(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if chat.RoleOf(userID) != "" {
+		return chat, nil
+	}
+
+	var added bool
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		added, err = s.subscriptions.Subscribe(ctx, chatID, userID, time.Now().Unix())
+		if err != nil || !added {
+			return err
+		}
+		if err := s.chats.IncSubscribers(ctx, chatID, 1); err != nil {
+			return err
+		}
+		if err := s.trackUnread(ctx, chat, []string{userID}); err != nil {
+			return err
+		}
+		// Подписка попадает только в журнал самого подписчика
+		return s.emit(s.logUpdate(ctx, []string{userID}, domain.UserUpdate{
+			Type:    domain.UpdateMembership,
+			ChatID:  chatID,
+			ActorID: userID,
+			Action:  domain.MembershipJoined,
+			UserIDs: []string{userID},
+		}))
+	})
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if added {
+		chat.SubscriberCount++
+	}
+	return chat, nil
+}
+
+// UnsubscribeChannel отписывает пользователя; отписка без подписки ничего не меняет
+func (s *ChatService) UnsubscribeChannel(ctx context.Context, chatID, userID string) error {
+	if _, err := s.loadChannel(chatID); err != nil {
+		return err
+	}
+	return s.inTx(ctx, func(ctx context.Context) error {
+		removed, err := s.subscriptions.Unsubscribe(ctx, chatID, userID)
+		if err != nil || !removed {
+			return err
+		}
+		if err := s.chats.IncSubscribers(ctx, chatID, -1); err != nil {
+			return err
+		}
+		return s.emit(s.logUpdate(ctx, []string{userID}, domain.UserUpdate{
+			Type:    domain.UpdateMembership,
+			ChatID:  chatID,
+			ActorID: userID,
+			Action:  domain.MembershipLeft,
+			UserIDs: []string{userID},
+		}))
+	})
+}
+
+// ListChannelSubscribers — постраничный список подписчиков, доступен администраторам
+func (s *ChatService) ListChannelSubscribers(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error) {
+	chat, err := s.loadChannel(chatID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !chat.CanManage(requesterID) {
+		return nil, "", domain.ErrPermissionDenied
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 200
+	}
+	return s.subscriptions.ListSubscribers(chatID, limit, cursor)
+}
+
+// SetChannelAdmin назначает подписчика администратором или снимает права — только владелец.
+// Подписка при этом сохраняется, поэтому снятый администратор остаётся читателем.
+func (s *ChatService) SetChannelAdmin(ctx context.Context, chatID, requesterID, userID string, admin bool) (domain.Chat, error) {
+	chat, err := s.loadChannel(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if chat.RoleOf(requesterID) != domain.ChatRoleOwner || userID == chat.CreatedBy {
+		return domain.Chat{}, domain.ErrPermissionDenied
+	}
+	if admin {
+		subscribed, err := s.subscriptions.IsSubscribed(chatID, userID)
+		if err != nil {
+			return domain.Chat{}, err
+		}
+		if !subscribed {
+			return domain.Chat{}, fmt.Errorf("%w: администратором можно назначить только подписчика", domain.ErrInvalidArgument)
+		}
+	}
+	return s.chats.SetChannelAdmin(chatID, userID, admin)
+}
+
+// ViewMessages засчитывает просмотры постов канала. Повторы в пределах viewDedupWindow
+// отсекаются через Redis; без Redis засчитывается каждый вызов.
+func (s *ChatService) ViewMessages(ctx context.Context, chatID, userID string, messageIDs []string) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	if len(messageIDs) > maxViewBatch {
+		return fmt.Errorf("%w: не больше %d сообщений за раз", domain.ErrInvalidArgument, maxViewBatch)
+	}
+	chat, err := s.loadChannel(chatID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkReader(chat, userID); err != nil {
+		return err
+	}
+
+	fresh := messageIDs
+	if s.views != nil {
+		fresh = make([]string, 0, len(messageIDs))
+		for _, id := range messageIDs {
+			first, err := s.views.MarkViewed(ctx, id, userID, viewDedupWindow)
+			if err != nil {
+				return err
+			}
+			if first {
+				fresh = append(fresh, id)
+			}
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+	return s.msgs.IncViews(chatID, fresh)
+}
+
+func (s *ChatService) loadChannel(chatID string) (domain.Chat, error) {
+	if s.subscriptions == nil {
+		return domain.Chat{}, errChannelsDisabled
+	}
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if chat.Kind != domain.ChatKindChannel {
+		return domain.Chat{}, fmt.Errorf("%w: чат не является каналом", domain.ErrInvalidArgument)
+	}
+	return chat, nil
+}
+
+// checkReader — читать чат могут его участники, а канал ещё и подписчики
+func (s *ChatService) checkReader(chat domain.Chat, userID string) error {
+	if chat.RoleOf(userID) != "" {
+		return nil
+	}
+	if chat.Kind == domain.ChatKindChannel && s.subscriptions != nil {
+		subscribed, err := s.subscriptions.IsSubscribed(chat.ID, userID)
+		if err != nil {
+			return err
+		}
+		if subscribed {
+			return nil
+		}
+	}
+	return domain.ErrPermissionDenied
+}
+
+func normalizeHandle(handle string) (string, error) {
+	handle = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+	if !handlePattern.MatchString(handle) {
+		return "", fmt.Errorf("%w: имя канала — 5–32 латинских букв, цифр или _, начиная с буквы", domain.ErrInvalidArgument)
+	}
+	return handle, nil
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"main/internal/domain"
+	"main/internal/moderation"
 	"main/internal/repository"
+	userserviceclient "main/internal/user-service-client"
 	chatpb "main/pkg/api"
 	user "main/pkg/api_user_service"
 	"time"
@@ -13,6 +16,7 @@ import (
 
 // Интерфейс продюсера Kafka
 type KafkaProducer interface {
+	Publish(ctx context.Context, key string, value []byte) error
 	PublishNewMessage(ctx context.Context, e domain.NewMessageEvent) error
 	PublishEvent(ctx context.Context, evt domain.SearchEvent) error
 }
@@ -23,6 +27,54 @@ type ChatService struct {
 	msgs       repository.MessageRepository
 	kafka      KafkaProducer
 	userClient user.UserServiceClient
+	mentions   repository.MentionRepository
+	typing     repository.TypingRepository
+	reads      repository.ReadStateRepository
+	chatStates repository.ChatStateRepository
+	scheduled  repository.ScheduledMessageRepository
+
+	subscriptions repository.SubscriptionRepository
+	views         repository.ViewRepository
+	invites       repository.InviteRepository
+	drafts        repository.DraftRepository
+	folders       repository.ChatFolderRepository
+	updates       repository.UpdateLogRepository
+	outbox        repository.OutboxRepository
+	tx            repository.Transactor
+	publisher     KafkaProducer // настоящий продюсер для relay, когда s.kafka пишет в outbox
+	media         MediaResolver
+	moderation    *moderation.Pipeline
+	modQueue      repository.ModerationRepository
+	limits        repository.SendLimitRepository
+	sendLimit     int64
+	sendWindow    time.Duration
+	retention     repository.RetentionRepository
+	deletedAfter  time.Duration
+	maxPerChat    int64
+
+	streams    *updateHub
+	typingSubs *typingHub
+	streamPoll time.Duration
+
+	editWindow time.Duration
+	archiveKey []byte
+}
+
+// Option — необязательная настройка сервиса
+type Option func(*ChatService)
+
+// WithEditWindow ограничивает время, в течение которого сообщение можно редактировать
+func WithEditWindow(d time.Duration) Option {
+	return func(s *ChatService) {
+		s.editWindow = d
+	}
+}
+
+// WithArchiveKey подписывает JSON-выгрузки чатов; архиву с подписью ImportChat доверяет авторов сообщений
+func WithArchiveKey(key []byte) Option {
+	return func(s *ChatService) {
+		s.archiveKey = key
+	}
 }
 
 // Конструктор
@@ -31,42 +83,208 @@ func NewChatService(
 	mr repository.MessageRepository,
 	kp KafkaProducer,
 	uc user.UserServiceClient,
+	opts ...Option,
 ) *ChatService {
-	return &ChatService{
+	s := &ChatService{
 		chats:      ch,
 		msgs:       mr,
 		kafka:      kp,
 		userClient: uc,
+		streams:    newUpdateHub(),
+		typingSubs: newTypingHub(),
+		streamPoll: defaultStreamPoll,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // --- Методы ---
 
 // Создание приватного чата
 func (s *ChatService) CreateDirect(ctx context.Context, userID, peerID string) (domain.Chat, error) {
-	// Передаем userClient из структуры сервиса
-	return s.chats.CreateDirect(userID, peerID, s.userClient)
+	var chat domain.Chat
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		// Передаем userClient из структуры сервиса
+		chat, err = s.chats.CreateDirect(ctx, userID, peerID, s.userClient)
+		if err != nil {
+			return err
+		}
+		if err := s.trackUnread(ctx, chat, chat.MemberIDs); err != nil {
+			return err
+		}
+		return s.emit(s.logMembership(ctx, chat, domain.MembershipCreated, userID, chat.MemberIDs))
+	})
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	return chat, nil
 }
 
 // Создание группового чата
 func (s *ChatService) CreateGroup(ctx context.Context, creatorID string, members []string, title string) (domain.Chat, error) {
-	chat, err := s.chats.CreateGroup(creatorID, members, title, s.userClient)
-	if err == nil {
-		_ = s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "chat", Data: chat})
+	var chat domain.Chat
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		chat, err = s.chats.CreateGroup(ctx, creatorID, members, title, s.userClient)
+		if err != nil {
+			return err
+		}
+		if err := s.trackUnread(ctx, chat, chat.MemberIDs); err != nil {
+			return err
+		}
+		if err := s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "chat", Data: chat})); err != nil {
+			return err
+		}
+		return s.emit(s.logMembership(ctx, chat, domain.MembershipCreated, creatorID, chat.MemberIDs))
+	})
+	if err != nil {
+		return domain.Chat{}, err
 	}
-	return s.chats.CreateGroup(creatorID, members, title, s.userClient)
+	return chat, nil
 }
 
 // Отправка сообщения
 func (s *ChatService) SendMessage(ctx context.Context, m domain.Message) (domain.Message, error) {
-	m.ID = uuid.New().String() // или primitive.NewObjectID().Hex()
+	return s.sendMessage(ctx, m, true)
+}
+
+// sendMessage — общий путь отправки; moderate = false только для задержанного сообщения,
+// которое одобрил модератор
+func (s *ChatService) sendMessage(ctx context.Context, m domain.Message, moderate bool) (_ domain.Message, err error) {
+	chat, err := s.loadChat(m.ChatID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	// Системные сообщения пишутся от имени того, кто совершил действие, — в том числе вышедшего из чата
+	if m.Type != domain.MessageTypeSystem && !chat.CanPost(m.AuthorID) {
+		return domain.Message{}, domain.ErrPermissionDenied
+	}
+	// В личный чат нельзя писать тому, кто заблокировал автора
+	if chat.Kind == domain.ChatKindDirect && m.Type != domain.MessageTypeSystem {
+		if err := userserviceclient.CheckNotBlocked(s.userClient, m.AuthorID, chat.OtherMembers(m.AuthorID)); err != nil {
+			return domain.Message{}, err
+		}
+	}
+	switch {
+	case chat.Secret && m.Type != domain.MessageTypeSystem:
+		if err := s.checkEnvelopes(chat, m); err != nil {
+			return domain.Message{}, err
+		}
+		m.Type = domain.MessageTypeEncrypted
+	case m.SenderDeviceID != "" || len(m.Envelopes) > 0:
+		return domain.Message{}, errNotSecretChat
+	}
+
+	// Вложения отложенного сообщения тоже проверяются здесь, в момент доставки
+	if m.Media, err = s.resolveMedia(ctx, chat.ID, m.AuthorID, m.Media); err != nil {
+		return domain.Message{}, err
+	}
+	// Файлы уже отмечены в чате: если сообщение не сохранится, отметки снимаются
+	attached := m.Media
+	defer func() {
+		if err != nil {
+			s.releaseMedia(ctx, chat.ID, attached)
+		}
+	}()
+
+	// id задаётся заранее только при доставке отложенного сообщения
+	scheduledDelivery := m.ID != ""
+	if !scheduledDelivery {
+		m.ID = uuid.New().String()
+	}
 	m.CreatedAt = time.Now().Unix()
-	msg, err := s.msgs.Send(m)
+	if m.Poll != nil {
+		if err := preparePoll(&m, m.CreatedAt); err != nil {
+			return domain.Message{}, err
+		}
+	}
+	if m.Type == "" {
+		m.Type = domain.MessageTypeText
+	}
+	if chat.MessageTTL > 0 {
+		m.ExpiresAt = m.CreatedAt + chat.MessageTTL
+	}
+
+	// Модерация идёт до разбора упоминаний: маска меняет текст, а с ним и позиции сущностей
+	if moderate && m.Type == domain.MessageTypeText {
+		res, err := s.moderate(ctx, chat, m.AuthorID, m.Text)
+		if err != nil {
+			return domain.Message{}, err
+		}
+		m.Text = res.Text
+		if res.Action == moderation.Hold {
+			if err := s.holdMessage(m, res.Reasons); err != nil {
+				return domain.Message{}, err
+			}
+			if !scheduledDelivery {
+				s.clearDraftAfterSend(ctx, m.ChatID, m.AuthorID)
+			}
+			m.Held = true
+			return m, nil
+		}
+	}
+
+	var mentioned []string
+	if m.Type == domain.MessageTypeText {
+		entities, userIDs, err := s.resolveMentions(chat, m)
+		if err != nil {
+			return domain.Message{}, err
+		}
+		m.Entities = entities
+		mentioned = userIDs
+	}
+
+	// Лимиты проверяются последними: отклонённое по другой причине сообщение не расходует слот.
+	// Доставка отложенного сообщения их не проверяет — очередь ограничена своим лимитом
+	if m.Type != domain.MessageTypeSystem && !scheduledDelivery {
+		if err := s.checkSendLimits(ctx, chat, m.AuthorID); err != nil {
+			return domain.Message{}, err
+		}
+	}
+
+	seq, err := s.chats.NextSeq(m.ChatID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	m.Seq = seq
+
+	// Сообщение, его события для Kafka и записи журналов обновлений пишутся одной транзакцией
+	var msg domain.Message
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		msg, err = s.msgs.Send(ctx, m)
+		if err != nil {
+			return err
+		}
+		if err := s.publishMessageEvents(ctx, chat, msg, mentioned); err != nil {
+			return err
+		}
+		return s.emit(s.logMessages(ctx, chat, domain.UpdateMessageNew, msg.AuthorID, []string{msg.ID}))
+	})
 	if err != nil {
 		return msg, err
 	}
+	s.advanceUnread(ctx, msg.ChatID, msg.Seq)
+	// Своё сообщение автор уже прочитал
+	if s.reads != nil {
+		_ = s.reads.MarkRead(ctx, msg.ChatID, msg.AuthorID, msg.Seq)
+	}
+	s.updatePreview(msg)
+	s.trackMentions(msg, mentioned)
+	// Отправка очищает черновик; отложенное сообщение набрано раньше и текущий черновик не трогает
+	if m.Type != domain.MessageTypeSystem && !scheduledDelivery {
+		s.clearDraftAfterSend(ctx, m.ChatID, m.AuthorID)
+	}
 
-	// Отправляем специализированное событие
+	return msg, nil
+}
+
+// publishMessageEvents — события нового сообщения: для уведомлений, для SearchService и упоминания.
+// Секретный чат в поиск не попадает, даже его системные сообщения
+func (s *ChatService) publishMessageEvents(ctx context.Context, chat domain.Chat, msg domain.Message, mentioned []string) error {
 	event := domain.NewMessageEvent{
 		MessageID: msg.ID,
 		ChatID:    msg.ChatID,
@@ -74,34 +292,212 @@ func (s *ChatService) SendMessage(ctx context.Context, m domain.Message) (domain
 		Text:      msg.Text,
 		Timestamp: msg.CreatedAt,
 	}
-	_ = s.kafka.PublishNewMessage(ctx, event)
-
-	// Отправляем универсальное событие для SearchService
-	go func(ctx context.Context) {
-		_ = s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "message", Data: msg})
-	}(ctx)
-
-	return msg, nil
+	if err := s.emit(s.kafka.PublishNewMessage(ctx, event)); err != nil {
+		return err
+	}
+	if chat.Secret {
+		return nil
+	}
+	if err := s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "message", Data: msg})); err != nil {
+		return err
+	}
+	if len(mentioned) == 0 {
+		return nil
+	}
+	return s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "mention", Data: domain.MentionEvent{
+		MessageID: msg.ID,
+		ChatID:    msg.ChatID,
+		AuthorID:  msg.AuthorID,
+		UserIDs:   mentioned,
+		Text:      msg.Text,
+		Timestamp: msg.CreatedAt,
+	}}))
 }
 
 // Обновление сообщения
 func (s *ChatService) UpdateMessage(ctx context.Context, messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error) {
-	return s.msgs.Update(messageID, authorID, text, media)
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.Deleted {
+		return domain.Message{}, domain.ErrMessageNotFound
+	}
+	if msg.AuthorID != authorID || msg.Type == domain.MessageTypeSystem {
+		return domain.Message{}, domain.ErrPermissionDenied
+	}
+	if msg.Type == domain.MessageTypePoll {
+		return domain.Message{}, fmt.Errorf("%w: опрос нельзя редактировать", domain.ErrInvalidArgument)
+	}
+	if msg.Type == domain.MessageTypeEncrypted {
+		return domain.Message{}, fmt.Errorf("%w: сообщение секретного чата нельзя редактировать", domain.ErrInvalidArgument)
+	}
+	if s.editWindow > 0 && time.Since(time.Unix(msg.CreatedAt, 0)) > s.editWindow {
+		return domain.Message{}, domain.ErrEditWindowClosed
+	}
+	if text != nil && s.moderation != nil {
+		chat, err := s.loadChat(msg.ChatID)
+		if err != nil {
+			return domain.Message{}, err
+		}
+		masked, err := s.moderateEdit(ctx, chat, authorID, *text)
+		if err != nil {
+			return domain.Message{}, err
+		}
+		text = &masked
+	}
+	var added, replaced []domain.Media
+	if media != nil {
+		resolved, err := s.resolveMedia(ctx, msg.ChatID, authorID, *media)
+		if err != nil {
+			return domain.Message{}, err
+		}
+		media = &resolved
+		added, replaced = mediaDiff(resolved, msg.Media), mediaDiff(msg.Media, resolved)
+	}
+	var updated domain.Message
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		updated, err = s.msgs.Update(ctx, messageID, authorID, text, media)
+		if err != nil {
+			return err
+		}
+		return s.emit(s.logChatMessages(ctx, updated.ChatID, domain.UpdateMessageEdited, authorID, []string{updated.ID}))
+	})
+	if err != nil {
+		// Правка не сохранилась — снимаются только отметки новых файлов
+		s.releaseMedia(ctx, msg.ChatID, added)
+		return domain.Message{}, err
+	}
+	// Заменённые файлы больше не в сообщении; в истории правок остаются только их метаданные
+	s.releaseMedia(ctx, msg.ChatID, replaced)
+	s.updatePreview(updated)
+	return updated, nil
 }
 
-// Удаление сообщения
-func (s *ChatService) DeleteMessage(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error) {
-	return s.msgs.Delete(messageIDs, hard, requesterID)
+// История правок сообщения — доступна участникам чата
+func (s *ChatService) ListMessageRevisions(ctx context.Context, messageID, requesterID string) ([]domain.MessageRevision, error) {
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.Deleted {
+		return nil, domain.ErrMessageNotFound
+	}
+	chat, err := s.loadChat(msg.ChatID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReader(chat, requesterID); err != nil {
+		return nil, err
+	}
+	return s.msgs.ListRevisions(messageID)
 }
 
-// Получение списка сообщений
-func (s *ChatService) ListMessages(ctx context.Context, chatID string, limit int, cursor string) ([]domain.Message, string, error) {
-	return s.msgs.List(chatID, limit, cursor)
+// Удаление сообщения: удаляются только свои сообщения. hard стирает их сразу — так же, как таймер
+// и очистка истории: с историей правок, упоминаниями, вложениями в media-service и событием для поиска
+func (s *ChatService) DeleteMessage(ctx context.Context, messageIDs []string, hard bool, requesterID string) ([]domain.Message, error) {
+	if hard {
+		return s.purgeOwnMessages(ctx, messageIDs, requesterID)
+	}
+	var deleted []domain.Message
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.msgs.Delete(ctx, messageIDs, requesterID)
+		if err != nil {
+			return err
+		}
+		byChat := map[string][]string{}
+		for _, m := range deleted {
+			byChat[m.ChatID] = append(byChat[m.ChatID], m.ID)
+		}
+		for chatID, ids := range byChat {
+			if err := s.emit(s.logChatMessages(ctx, chatID, domain.UpdateMessageDeleted, requesterID, ids)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Текст удалённого сообщения не должен остаться в превью списка чатов
+	for _, m := range deleted {
+		m.Deleted = true
+		s.updatePreview(m)
+	}
+	return deleted, nil
+}
+
+// purgeOwnMessages безвозвратно стирает сообщения автора через dropMessages; чужие id пропускаются
+func (s *ChatService) purgeOwnMessages(ctx context.Context, messageIDs []string, authorID string) ([]domain.Message, error) {
+	msgs, err := s.msgs.GetMany(messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	var own []domain.Message
+	for _, m := range msgs {
+		if m.AuthorID == authorID {
+			own = append(own, m)
+		}
+	}
+	if len(own) == 0 {
+		return own, nil
+	}
+	if _, err := s.dropMessages(ctx, own, domain.DeleteReasonAuthor, authorID, true); err != nil {
+		return nil, err
+	}
+	return own, nil
+}
+
+const (
+	defaultMessagesLimit = 10
+	maxMessagesLimit     = 100
+)
+
+// Получение страницы истории чата
+func (s *ChatService) ListMessages(ctx context.Context, q domain.MessageQuery) (domain.MessagePage, error) {
+	anchors := 0
+	for _, seq := range []int64{q.BeforeSeq, q.AfterSeq, q.AroundSeq} {
+		if seq < 0 {
+			return domain.MessagePage{}, fmt.Errorf("%w: seq не может быть отрицательным", domain.ErrInvalidArgument)
+		}
+		if seq > 0 {
+			anchors++
+		}
+	}
+	if anchors > 1 {
+		return domain.MessagePage{}, fmt.Errorf("%w: допускается только один из before, after, around", domain.ErrInvalidArgument)
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = defaultMessagesLimit
+	}
+	if q.Limit > maxMessagesLimit {
+		q.Limit = maxMessagesLimit
+	}
+	return s.msgs.List(q)
 }
 
-// Отметка прочтения
+// Отметка прочтения: сдвигает указатель до этого сообщения включительно
 func (s *ChatService) MarkRead(ctx context.Context, chatID, userID, messageID string) error {
-	return s.msgs.MarkRead(chatID, userID, messageID)
+	chat, msg, err := s.memberMessage(chatID, userID, messageID)
+	if err != nil {
+		return err
+	}
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if s.reads != nil {
+			if err := s.reads.MarkRead(ctx, chatID, userID, msg.Seq); err != nil {
+				return err
+			}
+		}
+		return s.emit(s.logRead(ctx, chat, userID, msg.Seq))
+	})
+	if err != nil {
+		return err
+	}
+	if s.chatStates != nil {
+		if err := s.chatStates.SetMarkedUnread(chatID, userID, false); err != nil {
+			return err
+		}
+	}
+	if s.mentions != nil {
+		return s.mentions.MarkRead(userID, chatID, msg.Seq)
+	}
+	return nil
 }
 
 // Работа с избранным
@@ -113,49 +509,189 @@ func (s *ChatService) ListSaved(ctx context.Context, userID string, limit int, c
 	return s.msgs.ListSaved(userID, limit, cursor)
 }
 
-// Получение списка чатов пользователя
-func (s *ChatService) ListChats(ctx context.Context, userID string, limit int, cursor string) ([]domain.Chat, string, error) {
-	return s.chats.List(userID, limit, cursor)
-}
-
 // Получение информации о чате
 func (s *ChatService) GetChat(ctx context.Context, chatID string) (domain.Chat, error) {
 	return s.chats.Get(chatID)
 }
 
-// Обновление группового чата
+// Обновление группового чата; message_ttl, если передан, меняет таймер автоудаления
 func (s *ChatService) UpdateGroupChat(ctx context.Context, req *chatpb.UpdateGroupChatRequest) (domain.Chat, error) {
+	if req.MessageTtl != nil {
+		if err := validateMessageTTL(*req.MessageTtl); err != nil {
+			return domain.Chat{}, err
+		}
+	}
 	var titlePtr *string
 	if req.Title != "" {
 		// только если клиент передал непустой title — обновим
 		t := req.Title
 		titlePtr = &t
 	}
-	return s.chats.UpdateGroup(
-		req.ChatId,
-		titlePtr,
-		req.AddMemberIds,
-		req.RemoveMemberIds,
-		req.RequesterId,
-		s.userClient,
-	)
+	// В журнал попадают те, кого репозиторий действительно добавил или исключил, а не весь запрос
+	var chat domain.Chat
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var (
+			added, removed []string
+			err            error
+		)
+		chat, added, removed, err = s.chats.UpdateGroup(
+			ctx,
+			req.ChatId,
+			titlePtr,
+			req.AddMemberIds,
+			req.RemoveMemberIds,
+			req.RequesterId,
+			s.userClient,
+		)
+		if err != nil {
+			return err
+		}
+		if len(added) > 0 {
+			if err := s.trackUnread(ctx, chat, added); err != nil {
+				return err
+			}
+			if err := s.emit(s.logMembership(ctx, chat, domain.MembershipAdded, req.RequesterId, added)); err != nil {
+				return err
+			}
+		}
+		if len(removed) > 0 {
+			return s.emit(s.logMembership(ctx, chat, domain.MembershipRemoved, req.RequesterId, removed))
+		}
+		return nil
+	})
+	if err != nil {
+		return chat, err
+	}
+	if req.MessageTtl == nil || *req.MessageTtl == chat.MessageTTL {
+		return chat, nil
+	}
+	return s.SetMessageTTL(ctx, req.ChatId, req.RequesterId, *req.MessageTtl)
 }
 
+// Прочитанные сообщения определяются указателями прочтения; пустой chatID — во всех чатах
 func (s *ChatService) ListReadMessages(ctx context.Context, userID, chatID string, limit int) ([]domain.Message, error) {
-	msgs, err := s.msgs.ListReadMessages(userID, chatID, limit)
+	if s.reads == nil {
+		return []domain.Message{}, nil
+	}
+
+	var states []domain.ReadState
+	if chatID != "" {
+		st, err := s.reads.Get(chatID, userID)
+		if err != nil {
+			return nil, err
+		}
+		states = []domain.ReadState{st}
+	} else {
+		var err error
+		states, err = s.reads.ListByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msgs, err := s.msgs.ListReadMessages(states, limit)
 	if err != nil {
 		return nil, err
 	}
 	return msgs, nil
 }
 
-func (s *ChatService) publishToKafkaMessage(m domain.Message) error {
-	evt := domain.NewMessageEvent{
-		MessageID: m.ID,
-		ChatID:    m.ChatID,
-		AuthorID:  m.AuthorID,
-		Text:      m.Text,
-		Timestamp: m.CreatedAt,
+// Закрепление сообщения в чате
+func (s *ChatService) PinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if !chat.CanPin(requesterID) {
+		return domain.Chat{}, domain.ErrPermissionDenied
+	}
+
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.ChatID != chatID || msg.Deleted {
+		return domain.Chat{}, domain.ErrMessageNotFound
+	}
+
+	updated, err := s.chats.Pin(chatID, domain.PinnedMessage{
+		MessageID: messageID,
+		PinnedBy:  requesterID,
+		PinnedAt:  time.Now().Unix(),
+	})
+	if err != nil {
+		return domain.Chat{}, err
+	}
+
+	s.sendSystemMessage(ctx, chatID, domain.SystemEvent{
+		Action:    domain.SystemActionPin,
+		ActorID:   requesterID,
+		MessageID: messageID,
+	}, "Сообщение закреплено")
+
+	return updated, nil
+}
+
+// Открепление сообщения
+func (s *ChatService) UnpinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if !chat.CanPin(requesterID) {
+		return domain.Chat{}, domain.ErrPermissionDenied
+	}
+
+	updated, err := s.chats.Unpin(chatID, messageID)
+	if err != nil {
+		return domain.Chat{}, err
 	}
-	return s.kafka.PublishNewMessage(context.Background(), evt)
+
+	s.sendSystemMessage(ctx, chatID, domain.SystemEvent{
+		Action:    domain.SystemActionUnpin,
+		ActorID:   requesterID,
+		MessageID: messageID,
+	}, "Сообщение откреплено")
+
+	return updated, nil
+}
+
+// Список закреплённых сообщений вместе с их содержимым
+func (s *ChatService) ListPinned(ctx context.Context, chatID, requesterID string) ([]domain.PinnedMessage, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReader(chat, requesterID); err != nil {
+		return nil, err
+	}
+
+	pinned := make([]domain.PinnedMessage, 0, len(chat.Pinned))
+	for _, p := range chat.Pinned {
+		msg, err := s.msgs.Get(p.MessageID)
+		if err != nil || msg.Deleted {
+			// Удалённые сообщения в списке не показываем
+			continue
+		}
+		p.Message = &msg
+		pinned = append(pinned, p)
+	}
+	return pinned, nil
+}
+
+func (s *ChatService) loadChat(chatID string) (domain.Chat, error) {
+	chat, err := s.chats.Get(chatID)
+	if err != nil {
+		return domain.Chat{}, fmt.Errorf("%w: %v", domain.ErrChatNotFound, err)
+	}
+	return chat, nil
+}
+
+// sendSystemMessage отправляет служебное сообщение через обычный путь SendMessage.
+// Ошибка не возвращается: основное действие уже выполнено.
+func (s *ChatService) sendSystemMessage(ctx context.Context, chatID string, evt domain.SystemEvent, text string) {
+	_, _ = s.SendMessage(ctx, domain.Message{
+		ChatID:   chatID,
+		AuthorID: evt.ActorID,
+		Type:     domain.MessageTypeSystem,
+		Text:     text,
+		System:   &evt,
+	})
 }
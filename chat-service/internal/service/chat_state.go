@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	"sort"
+	"time"
+)
+
+const (
+	maxPinnedChats    = 5
+	previewTextLength = 100 // в символах
+)
+
+// WithChatStates включает личные настройки чатов (закрепление, архив, без звука, «непрочитано»)
+func WithChatStates(r repository.ChatStateRepository) Option {
+	return func(s *ChatService) {
+		s.chatStates = r
+	}
+}
+
+// ListChats возвращает чаты от недавно активных к давним вместе с настройками пользователя.
+// На первой странице сначала идут закреплённые чаты (сверх limit), архивные — только при archived = true.
+func (s *ChatService) ListChats(ctx context.Context, userID string, limit int, cursor string, archived bool) ([]domain.Chat, string, error) {
+	states, err := s.chatStatesByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var pinned []domain.UserChatState
+	var archivedIDs []string
+	for _, st := range states {
+		switch {
+		case st.Archived:
+			archivedIDs = append(archivedIDs, st.ChatID)
+		case st.Pinned:
+			pinned = append(pinned, st)
+		}
+	}
+	// Недавно закреплённые — выше
+	sort.Slice(pinned, func(i, j int) bool { return pinned[i].PinnedAt > pinned[j].PinnedAt })
+	pinnedIDs := make([]string, 0, len(pinned))
+	for _, st := range pinned {
+		pinnedIDs = append(pinnedIDs, st.ChatID)
+	}
+
+	channelIDs, err := s.channelIDsOf(userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := domain.ChatQuery{UserID: userID, Limit: limit, Cursor: cursor, ChannelIDs: channelIDs}
+	var chats []domain.Chat
+	if archived {
+		if len(archivedIDs) == 0 {
+			return []domain.Chat{}, "", nil
+		}
+		q.OnlyIDs = archivedIDs
+	} else {
+		q.ExcludeIDs = append(append([]string{}, pinnedIDs...), archivedIDs...)
+		if cursor == "" && len(pinnedIDs) > 0 {
+			top, _, err := s.chats.List(domain.ChatQuery{UserID: userID, Limit: len(pinnedIDs), OnlyIDs: pinnedIDs, ChannelIDs: channelIDs})
+			if err != nil {
+				return nil, "", err
+			}
+			chats = append(chats, orderByIDs(top, pinnedIDs)...)
+		}
+	}
+
+	page, next, err := s.chats.List(q)
+	if err != nil {
+		return nil, "", err
+	}
+	chats = append(chats, page...)
+
+	lastRead, err := s.lastReadByChat(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range chats {
+		attachState(&chats[i], userID, states, lastRead)
+	}
+	return chats, next, nil
+}
+
+// chatStatesByID возвращает личные настройки чатов пользователя по chat_id
+func (s *ChatService) chatStatesByID(userID string) (map[string]domain.UserChatState, error) {
+	states := map[string]domain.UserChatState{}
+	if s.chatStates == nil {
+		return states, nil
+	}
+	list, err := s.chatStates.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range list {
+		states[st.ChatID] = st
+	}
+	return states, nil
+}
+
+// channelIDsOf возвращает каналы, на которые подписан пользователь
+func (s *ChatService) channelIDsOf(userID string) ([]string, error) {
+	if s.subscriptions == nil {
+		return nil, nil
+	}
+	return s.subscriptions.ListChannelIDs(userID)
+}
+
+// attachState заполняет chat.State настройками пользователя и числом непрочитанных
+func attachState(chat *domain.Chat, userID string, states map[string]domain.UserChatState, lastRead map[string]int64) {
+	st, ok := states[chat.ID]
+	if !ok {
+		st = domain.UserChatState{ChatID: chat.ID, UserID: userID}
+	}
+	if lastRead != nil && chat.LastSeq > lastRead[chat.ID] {
+		st.UnreadCount = chat.LastSeq - lastRead[chat.ID]
+	}
+	chat.State = &st
+}
+
+// PinChat закрепляет чат вверху списка пользователя (не больше maxPinnedChats)
+func (s *ChatService) PinChat(ctx context.Context, chatID, userID string, pinned bool) (domain.UserChatState, error) {
+	return s.updateChatState(chatID, userID, func() error {
+		if pinned {
+			st, err := s.chatStates.Get(chatID, userID)
+			if err != nil {
+				return err
+			}
+			if !st.Pinned {
+				count, err := s.chatStates.CountPinned(userID)
+				if err != nil {
+					return err
+				}
+				if count >= maxPinnedChats {
+					return domain.ErrTooManyPinnedChats
+				}
+			}
+		}
+		return s.chatStates.SetPinned(chatID, userID, pinned, time.Now().Unix())
+	})
+}
+
+func (s *ChatService) ArchiveChat(ctx context.Context, chatID, userID string, archived bool) (domain.UserChatState, error) {
+	return s.updateChatState(chatID, userID, func() error {
+		return s.chatStates.SetArchived(chatID, userID, archived)
+	})
+}
+
+// MuteChat отключает уведомления до момента until (unix); 0 — включить звук
+func (s *ChatService) MuteChat(ctx context.Context, chatID, userID string, until int64) (domain.UserChatState, error) {
+	if until < 0 {
+		return domain.UserChatState{}, fmt.Errorf("%w: muted_until не может быть отрицательным", domain.ErrInvalidArgument)
+	}
+	return s.updateChatState(chatID, userID, func() error {
+		return s.chatStates.SetMutedUntil(chatID, userID, until)
+	})
+}
+
+// MarkChatUnread ставит или снимает ручную отметку «непрочитано»; MarkRead снимает её автоматически
+func (s *ChatService) MarkChatUnread(ctx context.Context, chatID, userID string, unread bool) (domain.UserChatState, error) {
+	return s.updateChatState(chatID, userID, func() error {
+		return s.chatStates.SetMarkedUnread(chatID, userID, unread)
+	})
+}
+
+func (s *ChatService) updateChatState(chatID, userID string, apply func() error) (domain.UserChatState, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.UserChatState{}, err
+	}
+	if err := s.checkReader(chat, userID); err != nil {
+		return domain.UserChatState{}, err
+	}
+	if s.chatStates == nil {
+		return domain.UserChatState{ChatID: chatID, UserID: userID}, nil
+	}
+	if err := apply(); err != nil {
+		return domain.UserChatState{}, err
+	}
+	return s.chatStates.Get(chatID, userID)
+}
+
+// lastReadByChat возвращает last_read_seq пользователя по чатам; nil — указатели прочтения не подключены
+func (s *ChatService) lastReadByChat(userID string) (map[string]int64, error) {
+	if s.reads == nil {
+		return nil, nil
+	}
+	lastRead := map[string]int64{}
+	states, err := s.reads.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range states {
+		lastRead[st.ChatID] = st.LastReadSeq
+	}
+	return lastRead, nil
+}
+
+// updatePreview обновляет превью последнего сообщения в чате; ошибка не мешает основному действию
+func (s *ChatService) updatePreview(m domain.Message) {
+	p := domain.MessagePreview{
+		ID:        m.ID,
+		Seq:       m.Seq,
+		AuthorID:  m.AuthorID,
+		Type:      m.Type,
+		CreatedAt: m.CreatedAt,
+		Deleted:   m.Deleted,
+	}
+	if !m.Deleted {
+		p.Text = truncateRunes(m.Text, previewTextLength)
+	}
+	_ = s.chats.SetLastMessage(m.ChatID, p)
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// orderByIDs раскладывает чаты в порядке ids
+func orderByIDs(chats []domain.Chat, ids []string) []domain.Chat {
+	byID := make(map[string]domain.Chat, len(chats))
+	for _, c := range chats {
+		byID[c.ID] = c
+	}
+	ordered := make([]domain.Chat, 0, len(chats))
+	for _, id := range ids {
+		if c, ok := byID[id]; ok {
+			ordered = append(ordered, c)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	minMessageTTL  = int64(time.Minute / time.Second)
+	maxMessageTTL  = int64(365 * 24 * time.Hour / time.Second)
+	purgeBatchSize = 500
+)
+
+// SetMessageTTL включает таймер автоудаления (ttl в секундах, 0 — выключить).
+// Таймер действует на сообщения, отправленные после изменения.
+func (s *ChatService) SetMessageTTL(ctx context.Context, chatID, requesterID string, ttl int64) (domain.Chat, error) {
+	if err := validateMessageTTL(ttl); err != nil {
+		return domain.Chat{}, err
+	}
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if !chat.CanManage(requesterID) {
+		return domain.Chat{}, domain.ErrPermissionDenied
+	}
+	if chat.MessageTTL == ttl {
+		return chat, nil
+	}
+
+	updated, err := s.chats.SetMessageTTL(chatID, ttl)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+
+	text := "Автоудаление сообщений выключено"
+	if ttl > 0 {
+		text = "Автоудаление сообщений: " + formatTTL(ttl)
+	}
+	s.sendSystemMessage(ctx, chatID, domain.SystemEvent{
+		Action:  domain.SystemActionTTL,
+		ActorID: requesterID,
+	}, text)
+
+	return updated, nil
+}
+
+// RunPurger удаляет сообщения с истёкшим сроком, старые записи журналов обновлений и опубликованные события outbox
+// каждые interval до отмены ctx.
+// Повторное удаление безопасно, поэтому очистка может идти на нескольких репликах сразу.
+func (s *ChatService) RunPurger(ctx context.Context, interval time.Duration, log zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.PurgeExpired(ctx); err != nil {
+				log.Error().Err(err).Msg("expired messages purge failed")
+			}
+			if err := s.PruneUpdateLog(ctx); err != nil {
+				log.Error().Err(err).Msg("update log prune failed")
+			}
+			if err := s.PruneOutbox(ctx); err != nil {
+				log.Error().Err(err).Msg("outbox prune failed")
+			}
+		}
+	}
+}
+
+// PurgeExpired безвозвратно удаляет истёкшие сообщения пачками и возвращает их количество
+func (s *ChatService) PurgeExpired(ctx context.Context) (int, error) {
+	purged := 0
+	for ctx.Err() == nil {
+		msgs, err := s.msgs.ListExpired(time.Now().Unix(), purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(msgs) == 0 {
+			break
+		}
+
+		if _, err := s.dropMessages(ctx, msgs, domain.DeleteReasonExpired, "", true); err != nil {
+			return purged, err
+		}
+
+		purged += len(msgs)
+		if len(msgs) < purgeBatchSize {
+			break
+		}
+	}
+	return purged, nil
+}
+
+// afterPurge убирает стёртые сообщения чата из закреплённых и превью
+func (s *ChatService) afterPurge(chatID string, msgs []domain.Message) {
+	removed := make(map[string]bool, len(msgs))
+	latest := msgs[0]
+	for _, m := range msgs {
+		removed[m.ID] = true
+		if m.Seq > latest.Seq {
+			latest = m
+		}
+	}
+
+	latest.Deleted = true
+	s.updatePreview(latest)
+
+	chat, err := s.chats.Get(chatID)
+	if err != nil {
+		return
+	}
+	for _, p := range chat.Pinned {
+		if removed[p.MessageID] {
+			_, _ = s.chats.Unpin(chatID, p.MessageID)
+		}
+	}
+}
+
+func validateMessageTTL(ttl int64) error {
+	if ttl != 0 && (ttl < minMessageTTL || ttl > maxMessageTTL) {
+		return fmt.Errorf("%w: таймер автоудаления должен быть от минуты до года", domain.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// formatTTL — «1 дн.», «12 ч», «30 мин» для системного сообщения
+func formatTTL(ttl int64) string {
+	d := time.Duration(ttl) * time.Second
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%d дн.", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d ч", d/time.Hour)
+	default:
+		return fmt.Sprintf("%d мин", d/time.Minute)
+	}
+}
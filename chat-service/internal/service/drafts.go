@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	"time"
+)
+
+const maxDraftRunes = 4096
+
+var errDraftsDisabled = errors.New("drafts are disabled")
+
+// WithDrafts включает черновики, синхронизируемые между устройствами
+func WithDrafts(r repository.DraftRepository) Option {
+	return func(s *ChatService) {
+		s.drafts = r
+	}
+}
+
+// SaveDraft сохраняет черновик, если клиент правил последнюю версию (baseVersion — версия, с которой
+// он начинал; 0 — черновика ещё не было). Возвращает действующий черновик и false, если черновик
+// за это время правили с другого устройства и правка отброшена.
+func (s *ChatService) SaveDraft(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error) {
+	if s.drafts == nil {
+		return domain.Draft{}, false, errDraftsDisabled
+	}
+	if baseVersion < 0 {
+		return domain.Draft{}, false, fmt.Errorf("%w: версия черновика не может быть отрицательной", domain.ErrInvalidArgument)
+	}
+	if len([]rune(d.Text)) > maxDraftRunes {
+		return domain.Draft{}, false, fmt.Errorf("%w: черновик длиннее %d символов", domain.ErrInvalidArgument, maxDraftRunes)
+	}
+
+	chat, err := s.loadChat(d.ChatID)
+	if err != nil {
+		return domain.Draft{}, false, err
+	}
+	if !chat.CanPost(d.UserID) {
+		return domain.Draft{}, false, domain.ErrPermissionDenied
+	}
+	if d.ReplyToID != "" {
+		msg, err := s.msgs.Get(d.ReplyToID)
+		if err != nil || msg.ChatID != d.ChatID || msg.Deleted {
+			return domain.Draft{}, false, domain.ErrMessageNotFound
+		}
+	}
+	return s.storeDraft(ctx, d, baseVersion)
+}
+
+// ClearDraft очищает черновик; как и сохранение, применяется только к последней версии
+func (s *ChatService) ClearDraft(ctx context.Context, chatID, userID string, baseVersion int64, deviceID string) (domain.Draft, bool, error) {
+	if s.drafts == nil {
+		return domain.Draft{}, false, errDraftsDisabled
+	}
+	if baseVersion < 0 {
+		return domain.Draft{}, false, fmt.Errorf("%w: версия черновика не может быть отрицательной", domain.ErrInvalidArgument)
+	}
+	return s.storeDraft(ctx, domain.Draft{ChatID: chatID, UserID: userID, DeviceID: deviceID}, baseVersion)
+}
+
+// GetDrafts возвращает непустые черновики пользователя; chatID ограничивает выборку одним чатом
+func (s *ChatService) GetDrafts(ctx context.Context, userID, chatID string) ([]domain.Draft, error) {
+	if s.drafts == nil {
+		return []domain.Draft{}, nil
+	}
+	if chatID == "" {
+		return s.drafts.ListByUser(userID)
+	}
+	d, err := s.drafts.Get(chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if d.IsEmpty() {
+		return []domain.Draft{}, nil
+	}
+	return []domain.Draft{d}, nil
+}
+
+func (s *ChatService) storeDraft(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error) {
+	d.UpdatedAt = time.Now().UnixMilli()
+	var (
+		saved   domain.Draft
+		applied bool
+	)
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		saved, applied, err = s.drafts.Save(ctx, d, baseVersion)
+		if err != nil || !applied {
+			return err
+		}
+		return s.emit(s.logDraft(ctx, saved))
+	})
+	if err != nil {
+		return domain.Draft{}, false, err
+	}
+	if !applied {
+		current, err := s.drafts.Get(d.ChatID, d.UserID)
+		return current, false, err
+	}
+	return saved, true, nil
+}
+
+// clearDraftAfterSend очищает черновик отправленного сообщения, какой бы ни была его версия.
+// Ошибка не возвращается: сообщение уже отправлено.
+func (s *ChatService) clearDraftAfterSend(ctx context.Context, chatID, userID string) {
+	if s.drafts == nil {
+		return
+	}
+	_ = s.inTx(ctx, func(ctx context.Context) error {
+		saved, err := s.drafts.Overwrite(ctx, domain.Draft{ChatID: chatID, UserID: userID, UpdatedAt: time.Now().UnixMilli()})
+		if err != nil {
+			return err
+		}
+		return s.emit(s.logDraft(ctx, saved))
+	})
+}
+
+// logDraft пишет черновик после правки в журнал владельца — его получают все устройства;
+// устройство-источник узнаёт свою правку по device_id
+func (s *ChatService) logDraft(ctx context.Context, d domain.Draft) error {
+	return s.logUpdate(ctx, []string{d.UserID}, domain.UserUpdate{
+		Type:   domain.UpdateDraft,
+		ChatID: d.ChatID,
+		Draft:  &d,
+	})
+}
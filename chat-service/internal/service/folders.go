@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxFolders          = 20
+	maxFolderTitleRunes = 32
+	maxFolderListSize   = 200 // чатов в include/exclude и контактов в правилах
+	// Сколько чатов читается за раз при фильтрации по правилам папки
+	folderScanBatch = 200
+)
+
+var errFoldersDisabled = errors.New("chat folders are disabled")
+
+// WithFolders включает папки чатов, синхронизируемые между устройствами
+func WithFolders(r repository.ChatFolderRepository) Option {
+	return func(s *ChatService) {
+		s.folders = r
+	}
+}
+
+// CreateFolder создаёт папку в конце списка папок пользователя (не больше maxFolders)
+func (s *ChatService) CreateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error) {
+	if s.folders == nil {
+		return domain.ChatFolder{}, errFoldersDisabled
+	}
+	f, err := normalizeFolder(f)
+	if err != nil {
+		return domain.ChatFolder{}, err
+	}
+	count, err := s.folders.Count(f.UserID)
+	if err != nil {
+		return domain.ChatFolder{}, err
+	}
+	if count >= maxFolders {
+		return domain.ChatFolder{}, domain.ErrTooManyFolders
+	}
+
+	f.ID = uuid.New().String()
+	f.Position = int(count)
+	f.UpdatedAt = time.Now().Unix()
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.folders.Create(ctx, f); err != nil {
+			return err
+		}
+		return s.emit(s.logFolder(ctx, f.UserID, domain.FolderCreated, f.ID))
+	})
+	if err != nil {
+		return domain.ChatFolder{}, err
+	}
+	return f, nil
+}
+
+// UpdateFolder заменяет название, списки чатов и правила папки; позиция не меняется
+func (s *ChatService) UpdateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error) {
+	if s.folders == nil {
+		return domain.ChatFolder{}, errFoldersDisabled
+	}
+	f, err := normalizeFolder(f)
+	if err != nil {
+		return domain.ChatFolder{}, err
+	}
+	current, err := s.folders.Get(f.ID, f.UserID)
+	if err != nil {
+		return domain.ChatFolder{}, err
+	}
+
+	f.Position = current.Position
+	f.UpdatedAt = time.Now().Unix()
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.folders.Update(ctx, f); err != nil {
+			return err
+		}
+		return s.emit(s.logFolder(ctx, f.UserID, domain.FolderUpdated, f.ID))
+	})
+	if err != nil {
+		return domain.ChatFolder{}, err
+	}
+	return f, nil
+}
+
+func (s *ChatService) DeleteFolder(ctx context.Context, folderID, userID string) error {
+	if s.folders == nil {
+		return errFoldersDisabled
+	}
+	return s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.folders.Delete(ctx, folderID, userID); err != nil {
+			return err
+		}
+		return s.emit(s.logFolder(ctx, userID, domain.FolderDeleted, folderID))
+	})
+}
+
+// ReorderFolders задаёт порядок папок; folderIDs — все папки пользователя в новом порядке
+func (s *ChatService) ReorderFolders(ctx context.Context, userID string, folderIDs []string) ([]domain.ChatFolder, error) {
+	if s.folders == nil {
+		return nil, errFoldersDisabled
+	}
+	folders, err := s.folders.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(folders))
+	for _, f := range folders {
+		known[f.ID] = true
+	}
+	seen := make(map[string]bool, len(folderIDs))
+	for _, id := range folderIDs {
+		if !known[id] {
+			return nil, domain.ErrFolderNotFound
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(folderIDs) || len(seen) != len(known) {
+		return nil, fmt.Errorf("%w: нужно перечислить каждую папку ровно один раз", domain.ErrInvalidArgument)
+	}
+
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.folders.SetPositions(ctx, userID, folderIDs, time.Now().Unix()); err != nil {
+			return err
+		}
+		return s.emit(s.logFolder(ctx, userID, domain.FolderReordered, ""))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.folders.ListByUser(userID)
+}
+
+// ListFolders возвращает папки пользователя по порядку вместе со счётчиками непрочитанного.
+// Обходятся только непрочитанные чаты — их выбирают по индексу счётчиков в указателях прочтения
+func (s *ChatService) ListFolders(ctx context.Context, userID string) ([]domain.ChatFolder, error) {
+	if s.folders == nil {
+		return []domain.ChatFolder{}, nil
+	}
+	folders, err := s.folders.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(folders) == 0 {
+		return []domain.ChatFolder{}, nil
+	}
+
+	now := time.Now().Unix()
+	err = s.scanUnreadChats(userID, func(chat domain.Chat) {
+		for i := range folders {
+			if folders[i].Matches(chat, userID, now) {
+				folders[i].UnreadChats++
+				folders[i].UnreadMessages += chat.State.UnreadCount
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+// scanUnreadChats обходит чаты пользователя с непрочитанными сообщениями или отметкой «непрочитано»
+// с заполненным State; число непрочитанных берётся из счётчика указателя прочтения
+func (s *ChatService) scanUnreadChats(userID string, visit func(domain.Chat)) error {
+	states, err := s.chatStatesByID(userID)
+	if err != nil {
+		return err
+	}
+	counts := map[string]int64{}
+	var ids []string
+	if s.reads != nil {
+		unread, err := s.reads.ListUnread(userID)
+		if err != nil {
+			return err
+		}
+		for _, rs := range unread {
+			counts[rs.ChatID] = rs.UnreadCount
+			ids = append(ids, rs.ChatID)
+		}
+	}
+	for id, st := range states {
+		if _, ok := counts[id]; !ok && st.MarkedUnread {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	channelIDs, err := s.channelIDsOf(userID)
+	if err != nil {
+		return err
+	}
+
+	// Чаты, из которых пользователь вышел, отсекает условие членства в запросе
+	q := domain.ChatQuery{UserID: userID, Limit: folderScanBatch, OnlyIDs: ids, ChannelIDs: channelIDs}
+	return s.pageChats(q, func(chat domain.Chat) bool {
+		st, ok := states[chat.ID]
+		if !ok {
+			st = domain.UserChatState{ChatID: chat.ID, UserID: userID}
+		}
+		st.UnreadCount = counts[chat.ID]
+		chat.State = &st
+		visit(chat)
+		return true
+	})
+}
+
+// ListFolderChats — ListChats, отфильтрованный правилами папки: чаты от недавно активных
+// к давним, без закрепления сверху. Курсор — как у ListChats; пустой — чаты закончились.
+func (s *ChatService) ListFolderChats(ctx context.Context, userID, folderID string, limit int, cursor string) ([]domain.Chat, string, error) {
+	if s.folders == nil {
+		return nil, "", errFoldersDisabled
+	}
+	folder, err := s.folders.Get(folderID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Папку из одних явно выбранных чатов незачем фильтровать по всем чатам пользователя
+	var onlyIDs []string
+	if folder.ExplicitOnly() {
+		onlyIDs = folder.IncludeIDs
+	}
+	now := time.Now().Unix()
+	chats := []domain.Chat{}
+	next := ""
+	err = s.scanUserChats(userID, cursor, onlyIDs, func(chat domain.Chat) bool {
+		if !folder.Matches(chat, userID, now) {
+			return true
+		}
+		chats = append(chats, chat)
+		if limit > 0 && len(chats) == limit {
+			next = chat.Cursor()
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return chats, next, nil
+}
+
+// scanUserChats обходит чаты пользователя после cursor пачками по folderScanBatch
+// с заполненным State, пока visit возвращает true; непустой onlyIDs ограничивает обход этими чатами
+func (s *ChatService) scanUserChats(userID, cursor string, onlyIDs []string, visit func(domain.Chat) bool) error {
+	states, err := s.chatStatesByID(userID)
+	if err != nil {
+		return err
+	}
+	lastRead, err := s.lastReadByChat(userID)
+	if err != nil {
+		return err
+	}
+	channelIDs, err := s.channelIDsOf(userID)
+	if err != nil {
+		return err
+	}
+
+	q := domain.ChatQuery{UserID: userID, Limit: folderScanBatch, Cursor: cursor, OnlyIDs: onlyIDs, ChannelIDs: channelIDs}
+	return s.pageChats(q, func(chat domain.Chat) bool {
+		attachState(&chat, userID, states, lastRead)
+		return visit(chat)
+	})
+}
+
+// pageChats читает чаты запроса страницами по q.Limit, пока visit возвращает true
+func (s *ChatService) pageChats(q domain.ChatQuery, visit func(domain.Chat) bool) error {
+	for {
+		page, next, err := s.chats.List(q)
+		if err != nil {
+			return err
+		}
+		for _, chat := range page {
+			if !visit(chat) {
+				return nil
+			}
+		}
+		if len(page) < q.Limit {
+			return nil
+		}
+		q.Cursor = next
+	}
+}
+
+// normalizeFolder проверяет папку и убирает пробелы по краям названия
+func normalizeFolder(f domain.ChatFolder) (domain.ChatFolder, error) {
+	f.Title = strings.TrimSpace(f.Title)
+	if n := len([]rune(f.Title)); n == 0 || n > maxFolderTitleRunes {
+		return f, fmt.Errorf("%w: название папки должно быть от 1 до %d символов", domain.ErrInvalidArgument, maxFolderTitleRunes)
+	}
+	if len(f.IncludeIDs) > maxFolderListSize || len(f.ExcludeIDs) > maxFolderListSize || len(f.Rules.Contacts) > maxFolderListSize {
+		return f, fmt.Errorf("%w: в списках папки не больше %d элементов", domain.ErrInvalidArgument, maxFolderListSize)
+	}
+	for _, k := range f.Rules.Kinds {
+		if k != domain.ChatKindDirect && k != domain.ChatKindGroup && k != domain.ChatKindChannel {
+			return f, fmt.Errorf("%w: неизвестный тип чата %q", domain.ErrInvalidArgument, k)
+		}
+	}
+	for _, flag := range []domain.FolderFlag{f.Rules.Muted, f.Rules.Unread} {
+		if flag != domain.FolderFlagAny && flag != domain.FolderFlagOnly && flag != domain.FolderFlagExclude {
+			return f, fmt.Errorf("%w: неизвестное условие %q", domain.ErrInvalidArgument, flag)
+		}
+	}
+	return f, nil
+}
+
+// logFolder пишет изменение папок в журнал владельца — его получают все устройства
+func (s *ChatService) logFolder(ctx context.Context, userID, action, folderID string) error {
+	return s.logUpdate(ctx, []string{userID}, domain.UserUpdate{
+		Type:     domain.UpdateFolders,
+		ActorID:  userID,
+		Action:   action,
+		FolderID: folderID,
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"main/internal/domain"
 	chatpb "main/pkg/api"
 )
@@ -9,16 +10,72 @@ import (
 // ChatServiceInterface - интерфейс для сервиса чатов
 type ChatServiceInterface interface {
 	CreateDirect(ctx context.Context, userID, peerID string) (domain.Chat, error)
+	CreateSecretChat(ctx context.Context, userID, peerID string) (domain.Chat, error)
 	CreateGroup(ctx context.Context, creatorID string, members []string, title string) (domain.Chat, error)
 	SendMessage(ctx context.Context, msg domain.Message) (domain.Message, error)
 	UpdateMessage(ctx context.Context, messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error)
-	DeleteMessage(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error)
-	ListMessages(ctx context.Context, chatID string, limit int, cursor string) ([]domain.Message, string, error)
+	ListMessageRevisions(ctx context.Context, messageID, requesterID string) ([]domain.MessageRevision, error)
+	DeleteMessage(ctx context.Context, messageIDs []string, hard bool, requesterID string) ([]domain.Message, error)
+	ListMessages(ctx context.Context, q domain.MessageQuery) (domain.MessagePage, error)
 	MarkRead(ctx context.Context, chatID, userID, messageID string) error
+	MarkDelivered(ctx context.Context, chatID, userID, messageID string) error
+	GetUnreadCount(ctx context.Context, chatID, userID string) (int64, error)
+	GetReadState(ctx context.Context, chatID, messageID, requesterID string) ([]domain.ReadState, []domain.ReadState, error)
+	ListMentions(ctx context.Context, userID, chatID string, limit int, cursor string) ([]domain.Mention, string, int64, error)
 	ToggleSaved(ctx context.Context, userID, messageID string, saved bool) error
 	ListSaved(ctx context.Context, userID string, limit int, cursor string) ([]domain.Message, string, error)
-	ListChats(ctx context.Context, userID string, limit int, cursor string) ([]domain.Chat, string, error)
+	ListChats(ctx context.Context, userID string, limit int, cursor string, archived bool) ([]domain.Chat, string, error)
+	ListFolderChats(ctx context.Context, userID, folderID string, limit int, cursor string) ([]domain.Chat, string, error)
+	PinChat(ctx context.Context, chatID, userID string, pinned bool) (domain.UserChatState, error)
+	ArchiveChat(ctx context.Context, chatID, userID string, archived bool) (domain.UserChatState, error)
+	MuteChat(ctx context.Context, chatID, userID string, until int64) (domain.UserChatState, error)
+	MarkChatUnread(ctx context.Context, chatID, userID string, unread bool) (domain.UserChatState, error)
 	GetChat(ctx context.Context, chatID string) (domain.Chat, error)
 	UpdateGroupChat(ctx context.Context, req *chatpb.UpdateGroupChatRequest) (domain.Chat, error)
+	SetMessageTTL(ctx context.Context, chatID, requesterID string, ttl int64) (domain.Chat, error)
+	SetSlowMode(ctx context.Context, chatID, requesterID string, seconds int64) (domain.Chat, error)
 	ListReadMessages(ctx context.Context, userID, chatID string, limit int) ([]domain.Message, error)
+	PinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error)
+	UnpinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error)
+	ListPinned(ctx context.Context, chatID, requesterID string) ([]domain.PinnedMessage, error)
+	ScheduleMessage(ctx context.Context, m domain.ScheduledMessage) (domain.ScheduledMessage, error)
+	ListScheduledMessages(ctx context.Context, chatID, authorID string) ([]domain.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, id, authorID string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id, authorID string) error
+	Vote(ctx context.Context, messageID, userID string, optionIDs []int) (domain.PollResults, error)
+	RetractVote(ctx context.Context, messageID, userID string) (domain.PollResults, error)
+	ClosePoll(ctx context.Context, messageID, requesterID string) (domain.PollResults, error)
+	GetPollResults(ctx context.Context, messageID, requesterID string) (domain.PollResults, error)
+	CreateInviteLink(ctx context.Context, chatID, requesterID string, settings domain.InviteSettings) (domain.InviteLink, error)
+	RevokeInviteLink(ctx context.Context, code, requesterID string) error
+	JoinByInvite(ctx context.Context, code, userID string) (domain.Chat, *domain.JoinRequest, error)
+	ListJoinRequests(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.JoinRequest, string, error)
+	ApproveJoinRequest(ctx context.Context, requestID, requesterID string) (domain.Chat, error)
+	RejectJoinRequest(ctx context.Context, requestID, requesterID string) error
+	CreateChannel(ctx context.Context, creatorID, title, handle string) (domain.Chat, error)
+	GetChannelByHandle(ctx context.Context, handle string) (domain.Chat, error)
+	SubscribeChannel(ctx context.Context, chatID, userID string) (domain.Chat, error)
+	UnsubscribeChannel(ctx context.Context, chatID, userID string) error
+	ListChannelSubscribers(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error)
+	SetChannelAdmin(ctx context.Context, chatID, requesterID, userID string, admin bool) (domain.Chat, error)
+	ViewMessages(ctx context.Context, chatID, userID string, messageIDs []string) error
+	SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction) (bool, error)
+	ListTyping(ctx context.Context, chatID, requesterID string) ([]domain.TypingStatus, error)
+	SaveDraft(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error)
+	GetDrafts(ctx context.Context, userID, chatID string) ([]domain.Draft, error)
+	ClearDraft(ctx context.Context, chatID, userID string, baseVersion int64, deviceID string) (domain.Draft, bool, error)
+	CreateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error)
+	UpdateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error)
+	DeleteFolder(ctx context.Context, folderID, userID string) error
+	ReorderFolders(ctx context.Context, userID string, folderIDs []string) ([]domain.ChatFolder, error)
+	ListFolders(ctx context.Context, userID string) ([]domain.ChatFolder, error)
+	GetUpdates(ctx context.Context, userID string, sinceSeq int64, limit int) (domain.UpdatesPage, error)
+	SubscribeChats(ctx context.Context, userID, resumeToken string, send func(domain.StreamEvent) error) error
+
+	ExportChat(ctx context.Context, chatID, requesterID, format string, w io.Writer) error
+	ImportChat(ctx context.Context, requesterID, title string, r io.Reader) (domain.ImportResult, error)
+
+	ReportMessage(ctx context.Context, messageID, reporterID, reason string) error
+	ListModerationQueue(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ModerationItem, string, error)
+	ResolveModeration(ctx context.Context, itemID, requesterID string, status domain.ModerationStatus) (domain.ModerationItem, error)
 }
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	userserviceclient "main/internal/user-service-client"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxInviteUsageLimit = 100000
+
+var errInvitesDisabled = fmt.Errorf("%w: приглашения не подключены", domain.ErrInvalidArgument)
+
+// WithInvites включает ссылки-приглашения и заявки на вступление
+func WithInvites(r repository.InviteRepository) Option {
+	return func(s *ChatService) {
+		s.invites = r
+	}
+}
+
+// CreateInviteLink создаёт ссылку-приглашение в группу — только тот, кто управляет чатом
+func (s *ChatService) CreateInviteLink(ctx context.Context, chatID, requesterID string, settings domain.InviteSettings) (domain.InviteLink, error) {
+	if s.invites == nil {
+		return domain.InviteLink{}, errInvitesDisabled
+	}
+	now := time.Now().Unix()
+	if settings.ExpiresAt != 0 && settings.ExpiresAt <= now {
+		return domain.InviteLink{}, fmt.Errorf("%w: срок действия ссылки уже истёк", domain.ErrInvalidArgument)
+	}
+	if settings.UsageLimit < 0 || settings.UsageLimit > maxInviteUsageLimit {
+		return domain.InviteLink{}, fmt.Errorf("%w: лимит вступлений — от 0 до %d", domain.ErrInvalidArgument, maxInviteUsageLimit)
+	}
+	// Заявки одобряет администратор, лимит вступлений для них не имеет смысла
+	if settings.RequiresApproval && settings.UsageLimit > 0 {
+		return domain.InviteLink{}, fmt.Errorf("%w: у ссылки с одобрением заявок не бывает лимита", domain.ErrInvalidArgument)
+	}
+
+	chat, err := s.loadGroup(chatID)
+	if err != nil {
+		return domain.InviteLink{}, err
+	}
+	if !chat.CanManage(requesterID) {
+		return domain.InviteLink{}, domain.ErrPermissionDenied
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		return domain.InviteLink{}, err
+	}
+	link := domain.InviteLink{
+		Code:             code,
+		ChatID:           chatID,
+		CreatedBy:        requesterID,
+		CreatedAt:        now,
+		ExpiresAt:        settings.ExpiresAt,
+		UsageLimit:       settings.UsageLimit,
+		RequiresApproval: settings.RequiresApproval,
+	}
+	if err := s.invites.CreateLink(link); err != nil {
+		return domain.InviteLink{}, err
+	}
+	return link, nil
+}
+
+// RevokeInviteLink отзывает ссылку; уже вступившие участники остаются в группе
+func (s *ChatService) RevokeInviteLink(ctx context.Context, code, requesterID string) error {
+	if s.invites == nil {
+		return errInvitesDisabled
+	}
+	link, err := s.invites.GetLink(code)
+	if err != nil {
+		return err
+	}
+	chat, err := s.loadChat(link.ChatID)
+	if err != nil {
+		return err
+	}
+	if !chat.CanManage(requesterID) {
+		return domain.ErrPermissionDenied
+	}
+	return s.invites.RevokeLink(code)
+}
+
+// JoinByInvite добавляет пользователя в группу по ссылке. Для ссылки с одобрением
+// создаётся заявка, и возвращается она, а не чат.
+func (s *ChatService) JoinByInvite(ctx context.Context, code, userID string) (domain.Chat, *domain.JoinRequest, error) {
+	if s.invites == nil {
+		return domain.Chat{}, nil, errInvitesDisabled
+	}
+	link, err := s.invites.GetLink(code)
+	if err != nil {
+		return domain.Chat{}, nil, err
+	}
+	now := time.Now().Unix()
+	if !link.Usable(now) {
+		return domain.Chat{}, nil, domain.ErrInviteUnusable
+	}
+	chat, err := s.loadGroup(link.ChatID)
+	if err != nil {
+		return domain.Chat{}, nil, err
+	}
+	if chat.RoleOf(userID) != "" {
+		return domain.Chat{}, nil, domain.ErrAlreadyMember
+	}
+	if _, err := userserviceclient.GetUserInfo(s.userClient, userID); err != nil {
+		return domain.Chat{}, nil, fmt.Errorf("пользователь %s не найден: %w", userID, err)
+	}
+	if err := s.checkJoinNotBlocked(chat, link, userID); err != nil {
+		return domain.Chat{}, nil, err
+	}
+
+	if link.RequiresApproval {
+		req, err := s.invites.CreateRequest(domain.JoinRequest{
+			ID:         uuid.New().String(),
+			ChatID:     chat.ID,
+			UserID:     userID,
+			InviteCode: code,
+			Status:     domain.JoinRequestPending,
+			CreatedAt:  now,
+		})
+		if err != nil {
+			return domain.Chat{}, nil, err
+		}
+		return domain.Chat{}, &req, nil
+	}
+
+	if _, err := s.invites.UseLink(code, now); err != nil {
+		return domain.Chat{}, nil, err
+	}
+	updated, added, err := s.join(ctx, chat.ID, userID, userID, "invite")
+	if err != nil || !added {
+		// Вступление не состоялось — использование ссылки не засчитываем
+		_ = s.invites.ReleaseLink(code)
+		if err == nil {
+			err = domain.ErrAlreadyMember
+		}
+		return domain.Chat{}, nil, err
+	}
+	return updated, nil, nil
+}
+
+// ListJoinRequests — ожидающие заявки, доступны тем, кто управляет чатом
+func (s *ChatService) ListJoinRequests(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.JoinRequest, string, error) {
+	if s.invites == nil {
+		return nil, "", errInvitesDisabled
+	}
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !chat.CanManage(requesterID) {
+		return nil, "", domain.ErrPermissionDenied
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	return s.invites.ListPendingRequests(chatID, limit, cursor)
+}
+
+// ApproveJoinRequest одобряет заявку и добавляет пользователя в группу. Решение и вступление пишутся
+// одной транзакцией: если добавить не удалось, заявка остаётся ожидающей и её можно одобрить снова
+func (s *ChatService) ApproveJoinRequest(ctx context.Context, requestID, requesterID string) (domain.Chat, error) {
+	req, err := s.checkJoinDecision(requestID, requesterID, domain.JoinRequestApproved)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	var chat domain.Chat
+	var added bool
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if _, err := s.invites.DecideRequest(ctx, requestID, domain.JoinRequestApproved, requesterID, time.Now().Unix()); err != nil {
+			return err
+		}
+		var err error
+		chat, added, err = s.addJoined(ctx, req.ChatID, req.UserID, requesterID, "request")
+		return err
+	})
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if added {
+		s.afterJoin(ctx, chat, req.UserID, requesterID, "request")
+	}
+	return chat, nil
+}
+
+func (s *ChatService) RejectJoinRequest(ctx context.Context, requestID, requesterID string) error {
+	if _, err := s.checkJoinDecision(requestID, requesterID, domain.JoinRequestRejected); err != nil {
+		return err
+	}
+	_, err := s.invites.DecideRequest(ctx, requestID, domain.JoinRequestRejected, requesterID, time.Now().Unix())
+	return err
+}
+
+// checkJoinDecision проверяет, что requesterID может принять решение status по заявке, и возвращает её
+func (s *ChatService) checkJoinDecision(requestID, requesterID string, status domain.JoinRequestStatus) (domain.JoinRequest, error) {
+	if s.invites == nil {
+		return domain.JoinRequest{}, errInvitesDisabled
+	}
+	req, err := s.invites.GetRequest(requestID)
+	if err != nil {
+		return domain.JoinRequest{}, err
+	}
+	chat, err := s.loadChat(req.ChatID)
+	if err != nil {
+		return domain.JoinRequest{}, err
+	}
+	if !chat.CanManage(requesterID) {
+		return domain.JoinRequest{}, domain.ErrPermissionDenied
+	}
+	// Одобрить можно, только пока ссылка не отозвана и пользователя не заблокировали;
+	// иначе заявка остаётся ожидающей, и её можно отклонить
+	if status == domain.JoinRequestApproved {
+		link, err := s.invites.GetLink(req.InviteCode)
+		if err != nil {
+			return domain.JoinRequest{}, err
+		}
+		if link.Revoked {
+			return domain.JoinRequest{}, domain.ErrInviteUnusable
+		}
+		if err := s.checkJoinNotBlocked(chat, link, req.UserID); err != nil {
+			return domain.JoinRequest{}, err
+		}
+	}
+	return req, nil
+}
+
+// checkJoinNotBlocked не пускает по ссылке того, кого заблокировал автор ссылки или владелец группы
+func (s *ChatService) checkJoinNotBlocked(chat domain.Chat, link domain.InviteLink, userID string) error {
+	owners := []string{link.CreatedBy}
+	if chat.CreatedBy != "" && chat.CreatedBy != link.CreatedBy {
+		owners = append(owners, chat.CreatedBy)
+	}
+	return userserviceclient.CheckNotBlocked(s.userClient, userID, owners)
+}
+
+// join добавляет пользователя в группу и той же транзакцией пишет события о вступлении в Kafka (через outbox).
+// false — пользователь уже состоит в группе, событий нет
+func (s *ChatService) join(ctx context.Context, chatID, userID, actorID, via string) (domain.Chat, bool, error) {
+	var chat domain.Chat
+	var added bool
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		chat, added, err = s.addJoined(ctx, chatID, userID, actorID, via)
+		return err
+	})
+	if err != nil || !added {
+		return chat, false, err
+	}
+	s.afterJoin(ctx, chat, userID, actorID, via)
+	return chat, true, nil
+}
+
+// addJoined — часть вступления внутри транзакции: участник, указатель прочтения, события и журнал
+func (s *ChatService) addJoined(ctx context.Context, chatID, userID, actorID, via string) (domain.Chat, bool, error) {
+	chat, added, err := s.chats.AddMember(ctx, chatID, userID)
+	if err != nil || !added {
+		return chat, added, err
+	}
+	err = func() error {
+		if err := s.trackUnread(ctx, chat, []string{userID}); err != nil {
+			return err
+		}
+		evt := domain.MembershipEvent{
+			ChatID:    chat.ID,
+			UserID:    userID,
+			ActorID:   actorID,
+			Action:    "joined",
+			Via:       via,
+			Timestamp: time.Now().Unix(),
+		}
+		if err := s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "chat.membership", Data: evt})); err != nil {
+			return err
+		}
+		if err := s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "chat", Data: chat})); err != nil {
+			return err
+		}
+		return s.emit(s.logMembership(ctx, chat, domain.MembershipJoined, actorID, []string{userID}))
+	}()
+	return chat, err == nil, err
+}
+
+// afterJoin пишет системное сообщение о новом участнике
+func (s *ChatService) afterJoin(ctx context.Context, chat domain.Chat, userID, actorID, via string) {
+	text := "Участник присоединился по ссылке-приглашению"
+	if via == "request" {
+		text = "Заявка на вступление одобрена"
+	}
+	s.sendSystemMessage(ctx, chat.ID, domain.SystemEvent{
+		Action:  domain.SystemActionJoin,
+		ActorID: actorID,
+		UserID:  userID,
+	}, text)
+}
+
+func (s *ChatService) loadGroup(chatID string) (domain.Chat, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if chat.Kind != domain.ChatKindGroup {
+		return domain.Chat{}, fmt.Errorf("%w: приглашения есть только у групп", domain.ErrInvalidArgument)
+	}
+	return chat, nil
+}
+
+// newInviteCode — 128 случайных бит в base64url, подобрать код перебором нельзя
+func newInviteCode() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+)
+
+// MediaResolver — проверка вложений через media-service
+type MediaResolver interface {
+	// AttachToChat возвращает вложение с настоящими метаданными файла и отмечает его использование
+	// в чате. Чужой файл — domain.ErrPermissionDenied, несуществующий — domain.ErrMediaNotFound
+	AttachToChat(ctx context.Context, fileID, userID, chatID string) (domain.Media, error)
+	// DetachFromChat снимает отметку использования файла в чате после удаления сообщения.
+	// Несуществующий файл — domain.ErrMediaNotFound
+	DetachFromChat(ctx context.Context, fileID, chatID string) error
+}
+
+// WithMedia включает проверку вложений: без неё url, mime и размер берутся из запроса как есть
+func WithMedia(r MediaResolver) Option {
+	return func(s *ChatService) {
+		s.media = r
+	}
+}
+
+// resolveMedia заменяет присланные клиентом вложения метаданными из media-service.
+// От клиента берётся только id; прикрепить можно только свой файл. Повторы одного файла отбрасываются.
+func (s *ChatService) resolveMedia(ctx context.Context, chatID, userID string, media []domain.Media) ([]domain.Media, error) {
+	if s.media == nil || len(media) == 0 {
+		return media, nil
+	}
+	resolved := make([]domain.Media, 0, len(media))
+	seen := make(map[string]bool, len(media))
+	for _, md := range media {
+		if md.ID == "" {
+			return nil, fmt.Errorf("%w: у вложения нет id", domain.ErrInvalidArgument)
+		}
+		if seen[md.ID] {
+			continue
+		}
+		seen[md.ID] = true
+
+		file, err := s.media.AttachToChat(ctx, md.ID, userID, chatID)
+		if errors.Is(err, domain.ErrMediaNotFound) || errors.Is(err, domain.ErrPermissionDenied) {
+			return nil, fmt.Errorf("вложение %s: %w", md.ID, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("не удалось проверить вложения: %w", err)
+		}
+		resolved = append(resolved, file)
+	}
+	return resolved, nil
+}
+
+// releaseMedia снимает отметку использования файлов в чате, если их не держит ни одно сообщение чата:
+// файлы прикрепили, а сообщение не сохранилось, или правка заменила вложения
+func (s *ChatService) releaseMedia(ctx context.Context, chatID string, media []domain.Media) {
+	ids := make([]string, 0, len(media))
+	for _, md := range media {
+		ids = append(ids, md.ID)
+	}
+	s.detachUnused(ctx, chatID, ids)
+}
+
+// detachUnused снимает в media-service отметку использования тех файлов чата, которых не держит ни одно
+// сообщение чата, и возвращает, сколько снято; файл, которого в media-service уже нет, считается снятым.
+// Вызывается после того, как сообщения стёрты или не сохранились, поэтому ошибки не возвращаются —
+// исход операции от них не зависит, а неснятая отметка только не даёт удалить файл
+func (s *ChatService) detachUnused(ctx context.Context, chatID string, fileIDs []string) int {
+	if s.media == nil || len(fileIDs) == 0 {
+		return 0
+	}
+	inUse, err := s.msgs.MediaInUse(chatID, fileIDs)
+	if err != nil {
+		return 0
+	}
+	used := make(map[string]bool, len(inUse))
+	for _, id := range inUse {
+		used[id] = true
+	}
+	detached := 0
+	for _, id := range fileIDs {
+		if used[id] {
+			continue
+		}
+		if err := s.media.DetachFromChat(ctx, id, chatID); err == nil || errors.Is(err, domain.ErrMediaNotFound) {
+			detached++
+		}
+	}
+	return detached
+}
+
+// mediaDiff возвращает вложения из a, которых нет в b
+func mediaDiff(a, b []domain.Media) []domain.Media {
+	in := make(map[string]bool, len(b))
+	for _, md := range b {
+		in[md.ID] = true
+	}
+	var diff []domain.Media
+	for _, md := range a {
+		if !in[md.ID] {
+			diff = append(diff, md)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"main/internal/domain"
+	"main/internal/repository"
+	userserviceclient "main/internal/user-service-client"
+	"strings"
+	"unicode"
+)
+
+// WithMentions включает учёт упоминаний по пользователям
+func WithMentions(r repository.MentionRepository) Option {
+	return func(s *ChatService) {
+		s.mentions = r
+	}
+}
+
+// mentionToken — найденное в тексте @имя
+type mentionToken struct {
+	username string
+	offset   int // в рунах
+	length   int // в рунах, вместе с @
+}
+
+// parseMentions находит @username и @all. @ внутри слова (например, в email) не считается упоминанием.
+func parseMentions(text string) []mentionToken {
+	runes := []rune(text)
+	var tokens []mentionToken
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isUsernameRune(runes[i-1])) {
+			continue
+		}
+		j := i + 1
+		for j < len(runes) && isUsernameRune(runes[j]) {
+			j++
+		}
+		if j == i+1 {
+			continue
+		}
+		tokens = append(tokens, mentionToken{
+			username: string(runes[i+1 : j]),
+			offset:   i,
+			length:   j - i,
+		})
+		i = j - 1
+	}
+	return tokens
+}
+
+func isUsernameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// resolveMentions превращает найденные @имена в сущности сообщения и список упомянутых участников.
+// Имена из текста ищутся среди участников чата одним запросом к user-service.
+func (s *ChatService) resolveMentions(chat domain.Chat, m domain.Message) ([]domain.MessageEntity, []string, error) {
+	tokens := parseMentions(m.Text)
+	if len(tokens) == 0 {
+		return nil, nil, nil
+	}
+
+	var names []string
+	seenName := map[string]bool{}
+	for _, t := range tokens {
+		name := strings.ToLower(t.username)
+		if name != "all" && !seenName[name] {
+			seenName[name] = true
+			names = append(names, name)
+		}
+	}
+	byName, err := userserviceclient.ResolveUserNames(s.userClient, names, chat.MemberIDs)
+	if err != nil {
+		// Без user-service сообщение уходит без упоминаний по имени
+		byName = map[string]string{}
+	}
+
+	var entities []domain.MessageEntity
+	seen := map[string]bool{}
+	var userIDs []string
+	addUser := func(id string) {
+		if id != m.AuthorID && !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	for _, t := range tokens {
+		if strings.EqualFold(t.username, "all") {
+			entities = append(entities, domain.MessageEntity{
+				Type:   domain.EntityTypeMentionAll,
+				Offset: t.offset,
+				Length: t.length,
+			})
+			for _, id := range chat.MemberIDs {
+				addUser(id)
+			}
+			continue
+		}
+		id, ok := byName[strings.ToLower(t.username)]
+		if !ok {
+			continue
+		}
+		entities = append(entities, domain.MessageEntity{
+			Type:   domain.EntityTypeMention,
+			Offset: t.offset,
+			Length: t.length,
+			UserID: id,
+		})
+		addUser(id)
+	}
+	return entities, userIDs, nil
+}
+
+// trackMentions сохраняет упоминания; событие для уведомлений публикует publishMessageEvents
+func (s *ChatService) trackMentions(msg domain.Message, userIDs []string) {
+	if len(userIDs) == 0 || s.mentions == nil {
+		return
+	}
+	mentions := make([]domain.Mention, 0, len(userIDs))
+	for _, id := range userIDs {
+		mentions = append(mentions, domain.Mention{
+			ChatID:    msg.ChatID,
+			UserID:    id,
+			MessageID: msg.ID,
+			Seq:       msg.Seq,
+			AuthorID:  msg.AuthorID,
+			CreatedAt: msg.CreatedAt,
+		})
+	}
+	_ = s.mentions.Add(mentions)
+}
+
+// ListMentions возвращает непрочитанные упоминания пользователя и их общее число.
+// Пустой chatID — упоминания во всех чатах.
+func (s *ChatService) ListMentions(ctx context.Context, userID, chatID string, limit int, cursor string) ([]domain.Mention, string, int64, error) {
+	if s.mentions == nil {
+		return nil, "", 0, nil
+	}
+	mentions, next, err := s.mentions.ListUnread(userID, chatID, limit, cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	count, err := s.mentions.CountUnread(userID, chatID)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	return mentions, next, count, nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/moderation"
+	"main/internal/repository"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const maxReportReasonLength = 500
+
+var errModerationDisabled = fmt.Errorf("%w: модерация не подключена", domain.ErrInvalidArgument)
+
+// WithModeration включает фильтрацию сообщений цепочкой p и очередь модерации с жалобами
+func WithModeration(p *moderation.Pipeline, queue repository.ModerationRepository) Option {
+	return func(s *ChatService) {
+		s.moderation = p
+		s.modQueue = queue
+	}
+}
+
+// moderate прогоняет текст нового сообщения через фильтры. Hold решается без очереди там,
+// где её некому разбирать: в личном чате сообщение отклоняется, у того, кто сам управляет чатом, — проходит.
+func (s *ChatService) moderate(ctx context.Context, chat domain.Chat, authorID, text string) (moderation.Result, error) {
+	res := s.moderation.Check(ctx, moderation.Input{ChatID: chat.ID, AuthorID: authorID, Text: text})
+	if res.Action == moderation.Hold {
+		switch {
+		case chat.Kind == domain.ChatKindDirect:
+			res.Action = moderation.Reject
+		case chat.CanManage(authorID):
+			res.Action = moderation.Allow
+		}
+	}
+	if res.Action == moderation.Reject {
+		return res, rejectedError(res.Reasons)
+	}
+	return res, nil
+}
+
+// moderateEdit проверяет новый текст при редактировании: правку нельзя задержать, поэтому Hold — отказ
+func (s *ChatService) moderateEdit(ctx context.Context, chat domain.Chat, authorID, text string) (string, error) {
+	res, err := s.moderate(ctx, chat, authorID, text)
+	if err != nil {
+		return "", err
+	}
+	if res.Action == moderation.Hold {
+		return "", rejectedError(res.Reasons)
+	}
+	return res.Text, nil
+}
+
+func rejectedError(reasons []string) error {
+	if len(reasons) == 0 {
+		return domain.ErrMessageRejected
+	}
+	return fmt.Errorf("%w: %s", domain.ErrMessageRejected, strings.Join(reasons, "; "))
+}
+
+// holdMessage ставит сообщение в очередь целиком: после одобрения оно публикуется с тем же id
+func (s *ChatService) holdMessage(m domain.Message, reasons []string) error {
+	held := m
+	return s.modQueue.AddHeld(domain.ModerationItem{
+		ID:        uuid.New().String(),
+		ChatID:    m.ChatID,
+		Kind:      domain.ModerationKindHeld,
+		MessageID: m.ID,
+		AuthorID:  m.AuthorID,
+		Text:      m.Text,
+		Reasons:   reasons,
+		Status:    domain.ModerationPending,
+		CreatedAt: m.CreatedAt,
+		Message:   &held,
+	})
+}
+
+// ReportMessage — жалоба участника на сообщение группы или канала. Жалобы на одно сообщение
+// собираются в одну запись очереди; повторная жалоба того же пользователя ничего не меняет.
+func (s *ChatService) ReportMessage(ctx context.Context, messageID, reporterID, reason string) error {
+	if s.modQueue == nil {
+		return errModerationDisabled
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return fmt.Errorf("%w: причина жалобы длиннее %d символов", domain.ErrInvalidArgument, maxReportReasonLength)
+	}
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.Deleted {
+		return domain.ErrMessageNotFound
+	}
+	if msg.Type == domain.MessageTypeSystem {
+		return fmt.Errorf("%w: на системное сообщение пожаловаться нельзя", domain.ErrInvalidArgument)
+	}
+	if msg.AuthorID == reporterID {
+		return fmt.Errorf("%w: нельзя пожаловаться на своё сообщение", domain.ErrInvalidArgument)
+	}
+	chat, err := s.loadChat(msg.ChatID)
+	if err != nil {
+		return err
+	}
+	if chat.Kind == domain.ChatKindDirect {
+		return fmt.Errorf("%w: жалобы принимаются в группах и каналах", domain.ErrInvalidArgument)
+	}
+	if err := s.checkReader(chat, reporterID); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	_, err = s.modQueue.AddReport(domain.ModerationItem{
+		ID:        uuid.New().String(),
+		ChatID:    chat.ID,
+		Kind:      domain.ModerationKindReport,
+		MessageID: msg.ID,
+		AuthorID:  msg.AuthorID,
+		Text:      msg.Text,
+		Status:    domain.ModerationPending,
+		CreatedAt: now,
+	}, domain.ModerationReport{UserID: reporterID, Reason: reason, CreatedAt: now})
+	return err
+}
+
+// ListModerationQueue — ожидающие решения записи чата, от старых к новым; видит тот, кто управляет чатом
+func (s *ChatService) ListModerationQueue(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ModerationItem, string, error) {
+	if s.modQueue == nil {
+		return nil, "", errModerationDisabled
+	}
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkModerator(chat, requesterID); err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	return s.modQueue.ListPending(chatID, limit, cursor)
+}
+
+// ResolveModeration применяет решение модератора. allowed: задержанное сообщение публикуется,
+// жалобы отклоняются; removed: задержанное отбрасывается, обжалованное сообщение удаляется безвозвратно.
+func (s *ChatService) ResolveModeration(ctx context.Context, itemID, requesterID string, status domain.ModerationStatus) (domain.ModerationItem, error) {
+	if s.modQueue == nil {
+		return domain.ModerationItem{}, errModerationDisabled
+	}
+	if status != domain.ModerationAllowed && status != domain.ModerationRemoved {
+		return domain.ModerationItem{}, fmt.Errorf("%w: решение — allowed или removed", domain.ErrInvalidArgument)
+	}
+	item, err := s.modQueue.Get(itemID)
+	if err != nil {
+		return domain.ModerationItem{}, err
+	}
+	if item.Status != domain.ModerationPending {
+		return domain.ModerationItem{}, domain.ErrModerationItemNotFound
+	}
+	chat, err := s.loadChat(item.ChatID)
+	if err != nil {
+		return domain.ModerationItem{}, err
+	}
+	if err := checkModerator(chat, requesterID); err != nil {
+		return domain.ModerationItem{}, err
+	}
+
+	// Задержанное сообщение публикуется до отметки решения: при ошибке запись остаётся в очереди.
+	// Второе одобрение той же записи упрётся в уникальный id сообщения.
+	if item.Kind == domain.ModerationKindHeld && status == domain.ModerationAllowed && item.Message != nil {
+		if _, err := s.sendMessage(ctx, *item.Message, false); err != nil {
+			return domain.ModerationItem{}, err
+		}
+	}
+
+	decided, err := s.modQueue.Decide(itemID, status, requesterID, time.Now().Unix())
+	if err != nil {
+		return domain.ModerationItem{}, err
+	}
+	if item.Kind == domain.ModerationKindReport && status == domain.ModerationRemoved {
+		s.removeReported(ctx, item.MessageID, requesterID)
+	}
+	return decided, nil
+}
+
+// removeReported удаляет обжалованное сообщение безвозвратно, как удаляет таймер, но с причиной moderation
+func (s *ChatService) removeReported(ctx context.Context, messageID, moderatorID string) {
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.Deleted {
+		return
+	}
+	_, _ = s.dropMessages(ctx, []domain.Message{msg}, domain.DeleteReasonModeration, moderatorID, true)
+}
+
+// checkModerator — очередь разбирают владелец группы и администраторы канала; в личных чатах модераторов нет
+func checkModerator(chat domain.Chat, userID string) error {
+	if chat.Kind == domain.ChatKindDirect || !chat.CanManage(userID) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
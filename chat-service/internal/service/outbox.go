@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"main/internal/domain"
+	"main/internal/events"
+	"main/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	outboxLease      = 30 * time.Second
+	outboxMaxBackoff = 5 * time.Minute
+	outboxRetention  = 24 * time.Hour
+)
+
+// WithOutbox направляет события Kafka в outbox: изменение данных и его события
+// пишутся одной транзакцией tx, а в брокер их доставляет RunOutboxRelay.
+// Исходный продюсер остаётся у relay.
+func WithOutbox(r repository.OutboxRepository, tx repository.Transactor) Option {
+	return func(s *ChatService) {
+		s.outbox = r
+		s.tx = tx
+		s.publisher = s.kafka
+		s.kafka = outboxWriter{repo: r}
+	}
+}
+
+// outboxWriter подменяет продюсер Kafka: вместо отправки в брокер событие становится
+// строкой outbox с тем же ключом и телом, которые записал бы продюсер.
+type outboxWriter struct {
+	repo repository.OutboxRepository
+}
+
+func (w outboxWriter) Publish(ctx context.Context, key string, value []byte) error {
+	now := time.Now().Unix()
+	return w.repo.Add(ctx, domain.OutboxEvent{
+		ID:            uuid.New().String(),
+		Key:           key,
+		Payload:       value,
+		Status:        domain.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
+
+func (w outboxWriter) PublishNewMessage(ctx context.Context, e domain.NewMessageEvent) error {
+	key, data, err := events.EncodeNewMessage(e)
+	if err != nil {
+		return err
+	}
+	return w.Publish(ctx, key, data)
+}
+
+func (w outboxWriter) PublishEvent(ctx context.Context, evt domain.SearchEvent) error {
+	key, data, err := events.Encode(evt)
+	if err != nil {
+		return err
+	}
+	return w.Publish(ctx, key, data)
+}
+
+// pendingWake — пользователи, которым fn внутри inTx записала обновления журнала
+type pendingWake struct {
+	userIDs []string
+}
+
+type pendingWakeKey struct{}
+
+// inTx выполняет fn в транзакции, если она настроена, иначе — просто вызывает fn.
+// Вложенный вызов выполняется в транзакции внешнего. Потоки SubscribeChats, которым fn записала
+// обновления, будятся после фиксации: разбуженный раньше поток не увидел бы незафиксированных записей.
+func (s *ChatService) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	if _, nested := ctx.Value(pendingWakeKey{}).(*pendingWake); nested {
+		return fn(ctx)
+	}
+	wake := &pendingWake{}
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// Транзакцию могут повторить — учитываем только последнюю попытку
+		wake.userIDs = wake.userIDs[:0]
+		return fn(context.WithValue(ctx, pendingWakeKey{}, wake))
+	})
+	if err == nil {
+		s.streams.notify(wake.userIDs)
+	}
+	return err
+}
+
+// emit решает судьбу ошибки записи события (Kafka или журнала обновлений) внутри inTx: с outbox
+// она откатывает транзакцию, без outbox транзакции нет, и ошибка не отменяет уже сделанную запись.
+func (s *ChatService) emit(err error) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return err
+}
+
+// RunOutboxRelay публикует события outbox каждые interval до отмены ctx.
+// Запускается на каждой реплике: захват в репозитории не даёт двум репликам взять одну строку.
+func (s *ChatService) RunOutboxRelay(ctx context.Context, interval time.Duration, log zerolog.Logger) {
+	if s.outbox == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RelayOutbox(ctx); err != nil {
+				log.Error().Err(err).Msg("outbox relay failed")
+			}
+		}
+	}
+}
+
+// RelayOutbox публикует все готовые события и возвращает число опубликованных.
+// Неудачная публикация откладывается с растущей паузой; событие не выбрасывается, пока брокер не примет его.
+func (s *ChatService) RelayOutbox(ctx context.Context) (int, error) {
+	if s.outbox == nil {
+		return 0, nil
+	}
+	sent := 0
+	for ctx.Err() == nil {
+		e, ok, err := s.outbox.ClaimDue(time.Now().Unix(), outboxLease)
+		if err != nil {
+			return sent, err
+		}
+		if !ok {
+			break
+		}
+		if err := s.publisher.Publish(ctx, e.Key, e.Payload); err != nil {
+			next := time.Now().Add(outboxBackoff(e.Attempts)).Unix()
+			if err := s.outbox.MarkRetry(e.ID, next, err.Error()); err != nil {
+				return sent, err
+			}
+			// Брокер недоступен — остальные события подождут следующего тика
+			return sent, err
+		}
+		if err := s.outbox.MarkSent(e.ID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// PruneOutbox удаляет опубликованные события старше outboxRetention
+func (s *ChatService) PruneOutbox(ctx context.Context) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.DeleteSentBefore(time.Now().Add(-outboxRetention).Unix())
+}
+
+// outboxBackoff — пауза перед повтором: 1s, 2s, 4s… но не больше outboxMaxBackoff
+func outboxBackoff(attempts int) time.Duration {
+	d := time.Second
+	for i := 1; i < attempts && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > outboxMaxBackoff {
+		d = outboxMaxBackoff
+	}
+	return d
+}
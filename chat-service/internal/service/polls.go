@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+	"strings"
+	"time"
+)
+
+const (
+	minPollOptions   = 2
+	maxPollOptions   = 10
+	maxPollTextRunes = 300
+)
+
+// preparePoll проверяет опрос из SendMessage и приводит его к виду для хранения
+func preparePoll(m *domain.Message, now int64) error {
+	p := m.Poll
+	p.Question = strings.TrimSpace(p.Question)
+	if p.Question == "" || len([]rune(p.Question)) > maxPollTextRunes {
+		return fmt.Errorf("%w: вопрос опроса пустой или слишком длинный", domain.ErrInvalidArgument)
+	}
+	if len(p.Options) < minPollOptions || len(p.Options) > maxPollOptions {
+		return fmt.Errorf("%w: в опросе должно быть от %d до %d вариантов", domain.ErrInvalidArgument, minPollOptions, maxPollOptions)
+	}
+	seen := make(map[string]bool, len(p.Options))
+	options := make([]domain.PollOption, len(p.Options))
+	for i, o := range p.Options {
+		text := strings.TrimSpace(o.Text)
+		if text == "" || len([]rune(text)) > maxPollTextRunes {
+			return fmt.Errorf("%w: вариант ответа пустой или слишком длинный", domain.ErrInvalidArgument)
+		}
+		if seen[text] {
+			return fmt.Errorf("%w: варианты ответа повторяются", domain.ErrInvalidArgument)
+		}
+		seen[text] = true
+		options[i] = domain.PollOption{ID: i, Text: text}
+	}
+	if p.ClosesAt != 0 && p.ClosesAt <= now {
+		return fmt.Errorf("%w: время закрытия опроса уже прошло", domain.ErrInvalidArgument)
+	}
+
+	m.Poll = &domain.Poll{
+		Question:       p.Question,
+		Options:        options,
+		MultipleChoice: p.MultipleChoice,
+		Anonymous:      p.Anonymous,
+		ClosesAt:       p.ClosesAt,
+	}
+	m.Type = domain.MessageTypePoll
+	m.Text = p.Question
+	return nil
+}
+
+// Vote — голос участника чата. Переголосовать можно только после RetractVote.
+func (s *ChatService) Vote(ctx context.Context, messageID, userID string, optionIDs []int) (domain.PollResults, error) {
+	msg, chat, err := s.loadPoll(messageID, userID)
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+	if err := validateVote(*msg.Poll, optionIDs); err != nil {
+		return domain.PollResults{}, err
+	}
+
+	now := time.Now().Unix()
+	vote := domain.PollVote{MessageID: messageID, UserID: userID, OptionIDs: optionIDs, VotedAt: now}
+	var updated domain.Message
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.msgs.Vote(ctx, vote, now)
+		if err != nil {
+			return err
+		}
+		return s.emit(s.logPoll(ctx, chat, updated.ID))
+	})
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+	return s.pollResults(updated, userID)
+}
+
+// RetractVote отменяет голос участника
+func (s *ChatService) RetractVote(ctx context.Context, messageID, userID string) (domain.PollResults, error) {
+	_, chat, err := s.loadPoll(messageID, userID)
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+
+	var updated domain.Message
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.msgs.RetractVote(ctx, messageID, userID, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		return s.emit(s.logPoll(ctx, chat, updated.ID))
+	})
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+	return s.pollResults(updated, userID)
+}
+
+// ClosePoll досрочно закрывает опрос — только автор
+func (s *ChatService) ClosePoll(ctx context.Context, messageID, requesterID string) (domain.PollResults, error) {
+	msg, chat, err := s.loadPoll(messageID, requesterID)
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+	if msg.AuthorID != requesterID {
+		return domain.PollResults{}, domain.ErrPermissionDenied
+	}
+
+	var updated domain.Message
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.msgs.ClosePoll(ctx, messageID, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		return s.emit(s.logPoll(ctx, chat, updated.ID))
+	})
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+	return s.pollResults(updated, requesterID)
+}
+
+// GetPollResults — счётчики, свой голос и (для публичных опросов) проголосовавшие
+func (s *ChatService) GetPollResults(ctx context.Context, messageID, requesterID string) (domain.PollResults, error) {
+	msg, _, err := s.loadPoll(messageID, requesterID)
+	if err != nil {
+		return domain.PollResults{}, err
+	}
+	return s.pollResults(msg, requesterID)
+}
+
+// loadPoll загружает опрос и проверяет, что пользователь состоит в чате
+func (s *ChatService) loadPoll(messageID, userID string) (domain.Message, domain.Chat, error) {
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.Deleted {
+		return domain.Message{}, domain.Chat{}, domain.ErrMessageNotFound
+	}
+	if msg.Poll == nil {
+		return domain.Message{}, domain.Chat{}, fmt.Errorf("%w: сообщение не является опросом", domain.ErrInvalidArgument)
+	}
+	chat, err := s.loadChat(msg.ChatID)
+	if err != nil {
+		return domain.Message{}, domain.Chat{}, err
+	}
+	if err := s.checkReader(chat, userID); err != nil {
+		return domain.Message{}, domain.Chat{}, err
+	}
+	return msg, chat, nil
+}
+
+func validateVote(p domain.Poll, optionIDs []int) error {
+	if len(optionIDs) == 0 {
+		return fmt.Errorf("%w: не выбран ни один вариант", domain.ErrInvalidArgument)
+	}
+	if !p.MultipleChoice && len(optionIDs) > 1 {
+		return fmt.Errorf("%w: в опросе можно выбрать только один вариант", domain.ErrInvalidArgument)
+	}
+	seen := make(map[int]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if id < 0 || id >= len(p.Options) || seen[id] {
+			return fmt.Errorf("%w: неверный вариант ответа %d", domain.ErrInvalidArgument, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func (s *ChatService) pollResults(msg domain.Message, userID string) (domain.PollResults, error) {
+	res := domain.PollResults{MessageID: msg.ID, ChatID: msg.ChatID, Poll: *msg.Poll}
+	vote, err := s.msgs.GetVote(msg.ID, userID)
+	switch {
+	case err == nil:
+		res.MyOptionIDs = vote.OptionIDs
+	case !errors.Is(err, domain.ErrNotVoted):
+		return domain.PollResults{}, err
+	}
+	if !msg.Poll.Anonymous {
+		votes, err := s.msgs.ListVotes(msg.ID)
+		if err != nil {
+			return domain.PollResults{}, err
+		}
+		res.Voters = make(map[int][]string, len(msg.Poll.Options))
+		for _, v := range votes {
+			for _, id := range v.OptionIDs {
+				res.Voters[id] = append(res.Voters[id], v.UserID)
+			}
+		}
+	}
+	return res, nil
+}
+
+// logPoll пишет изменение опроса участникам чата; кто голосовал, в обновление не попадает.
+// Подписчики канала, как и с новыми постами, в журнал не попадают: в канале их может быть очень много,
+// а запись всем в транзакции голоса упиралась бы в лимиты транзакции. Счётчики они видят
+// в GetPollResults и ListMessages.
+func (s *ChatService) logPoll(ctx context.Context, chat domain.Chat, messageID string) error {
+	return s.logMessages(ctx, chat, domain.UpdatePoll, "", []string{messageID})
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+)
+
+// WithReadStates включает указатели прочтения и доставки
+func WithReadStates(r repository.ReadStateRepository) Option {
+	return func(s *ChatService) {
+		s.reads = r
+	}
+}
+
+// MarkDelivered отмечает, что сообщения чата до messageID включительно доставлены на устройство пользователя
+func (s *ChatService) MarkDelivered(ctx context.Context, chatID, userID, messageID string) error {
+	_, msg, err := s.memberMessage(chatID, userID, messageID)
+	if err != nil {
+		return err
+	}
+	if s.reads == nil {
+		return nil
+	}
+	return s.reads.MarkDelivered(chatID, userID, msg.Seq)
+}
+
+// GetUnreadCount считает непрочитанные без обхода сообщений: last_seq чата минус указатель пользователя.
+// Удалённые после отправки сообщения продолжают учитываться.
+func (s *ChatService) GetUnreadCount(ctx context.Context, chatID, userID string) (int64, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.checkReader(chat, userID); err != nil {
+		return 0, err
+	}
+	if s.reads == nil {
+		return 0, nil
+	}
+
+	st, err := s.reads.Get(chatID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if unread := chat.LastSeq - st.LastReadSeq; unread > 0 {
+		return unread, nil
+	}
+	return 0, nil
+}
+
+// GetReadState возвращает участников, прочитавших сообщение, и тех, кому оно только доставлено.
+// Автор сообщения и покинувшие чат в списки не попадают.
+func (s *ChatService) GetReadState(ctx context.Context, chatID, messageID, requesterID string) ([]domain.ReadState, []domain.ReadState, error) {
+	chat, msg, err := s.memberMessage(chatID, requesterID, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Подписчиков канала слишком много для списков; вместо них — счётчик просмотров
+	if chat.Kind == domain.ChatKindChannel {
+		return nil, nil, fmt.Errorf("%w: в каналах нет отметок о прочтении", domain.ErrInvalidArgument)
+	}
+	if s.reads == nil {
+		return nil, nil, nil
+	}
+
+	states, err := s.reads.ListByChat(chatID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var read, delivered []domain.ReadState
+	for _, st := range states {
+		if st.UserID == msg.AuthorID || chat.RoleOf(st.UserID) == "" {
+			continue
+		}
+		switch {
+		case st.LastReadSeq >= msg.Seq:
+			read = append(read, st)
+		case st.LastDeliveredSeq >= msg.Seq:
+			delivered = append(delivered, st)
+		}
+	}
+	return read, delivered, nil
+}
+
+// memberMessage загружает сообщение чата и проверяет, что пользователь — участник этого чата
+func (s *ChatService) memberMessage(chatID, userID, messageID string) (domain.Chat, domain.Message, error) {
+	msg, err := s.msgs.Get(messageID)
+	if err != nil || msg.ChatID != chatID {
+		return domain.Chat{}, domain.Message{}, domain.ErrMessageNotFound
+	}
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, domain.Message{}, err
+	}
+	if err := s.checkReader(chat, userID); err != nil {
+		return domain.Chat{}, domain.Message{}, err
+	}
+	return chat, msg, nil
+}
+
+// trackUnread заводит счётчики непрочитанного новым участникам чата
+func (s *ChatService) trackUnread(ctx context.Context, chat domain.Chat, userIDs []string) error {
+	if s.reads == nil || len(userIDs) == 0 {
+		return nil
+	}
+	return s.reads.Track(ctx, chat.ID, userIDs, chat.LastSeq)
+}
+
+// advanceUnread учитывает новое сообщение в счётчиках непрочитанного участников. Вызывается после
+// транзакции отправки: иначе одновременные сообщения чата конфликтовали бы на указателях всех участников.
+// Ошибка не возвращается — счётчики догонит следующее сообщение
+func (s *ChatService) advanceUnread(ctx context.Context, chatID string, seq int64) {
+	if s.reads == nil {
+		return
+	}
+	_ = s.reads.AdvanceChat(ctx, chatID, seq)
+}
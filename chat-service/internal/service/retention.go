@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"main/internal/domain"
+	"main/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// На столько задача очистки захватывается одной репликой; каждая пачка чатов или сообщений продлевает захват
+	retentionLease = 5 * time.Minute
+	// Сколько чатов обходится между контрольными точками
+	retentionChatBatch = 100
+)
+
+// retentionMetrics — счётчики очистки истории, доступны на /debug/vars под ключом chat_retention
+var retentionMetrics = expvar.NewMap("chat_retention")
+
+// WithRetention включает очистку истории: удалённые сообщения стираются безвозвратно через
+// deletedAfter (0 — хранить всегда), в каждом чате остаётся не больше maxPerChat последних сообщений
+// (0 — без ограничения). Заодно удаляются указатели прочтения вышедших участников и отметки
+// «сохранено» на удалённых сообщениях.
+func WithRetention(r repository.RetentionRepository, deletedAfter time.Duration, maxPerChat int64) Option {
+	return func(s *ChatService) {
+		s.retention = r
+		s.deletedAfter = deletedAfter
+		s.maxPerChat = maxPerChat
+	}
+}
+
+// RunRetention применяет политику хранения каждые interval до отмены ctx.
+// Одновременно очистку выполняет одна реплика; прерванный проход продолжается с последней контрольной точки.
+func (s *ChatService) RunRetention(ctx context.Context, interval time.Duration, log zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats, ran, err := s.ApplyRetention(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("retention run failed")
+			}
+			if ran {
+				log.Info().
+					Int("deleted_purged", stats.DeletedPurged).
+					Int("capped_purged", stats.CappedPurged).
+					Int64("read_states_removed", stats.ReadStatesRemoved).
+					Int64("saved_refs_removed", stats.SavedRefsRemoved).
+					Int("media_detached", stats.MediaDetached).
+					Int("chats_scanned", stats.ChatsScanned).
+					Msg("retention run finished")
+			}
+		}
+	}
+}
+
+// ApplyRetention выполняет один проход очистки. false — задачу держит другая реплика или очистка выключена.
+// Статистика возвращается и при ошибке: всё, что успели удалить до неё, удалено. Если захват перехватила
+// другая реплика (проход шёл дольше аренды), проход останавливается с domain.ErrLeaseLost.
+func (s *ChatService) ApplyRetention(ctx context.Context) (domain.RetentionStats, bool, error) {
+	var stats domain.RetentionStats
+	if s.retention == nil {
+		return stats, false, nil
+	}
+	cp, ok, err := s.retention.Claim(time.Now().Unix(), retentionLease)
+	if err != nil {
+		retentionMetrics.Add("errors", 1)
+		return stats, false, err
+	}
+	if !ok {
+		return stats, false, nil
+	}
+
+	start := time.Now()
+	err = s.applyRetention(ctx, &cp, &stats)
+	recordRetention(stats, start, err)
+	return stats, true, err
+}
+
+func (s *ChatService) applyRetention(ctx context.Context, cp *domain.RetentionCheckpoint, stats *domain.RetentionStats) error {
+	if s.deletedAfter > 0 {
+		if err := s.purgeDeleted(ctx, cp, time.Now().Add(-s.deletedAfter).Unix(), stats); err != nil {
+			return err
+		}
+	}
+	n, err := s.msgs.ClearSavedOnDeleted()
+	if err != nil {
+		return err
+	}
+	stats.SavedRefsRemoved = n
+
+	// Обход чатов продолжается с места, где остановился прерванный проход
+	for ctx.Err() == nil {
+		chats, err := s.chats.ListAll(cp.ChatCursor, retentionChatBatch)
+		if err != nil {
+			return err
+		}
+		for _, chat := range chats {
+			if err := s.retainChat(ctx, cp, chat, stats); err != nil {
+				return err
+			}
+			stats.ChatsScanned++
+		}
+		if len(chats) < retentionChatBatch {
+			break
+		}
+		cp.ChatCursor = chats[len(chats)-1].ID
+		if err := s.renewRetention(cp); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.retention.Finish(cp.Token, time.Now().Unix())
+}
+
+// renewRetention сохраняет позицию обхода и продлевает захват. Вызывается после каждой пачки,
+// поэтому ни одна пачка не начинается, если захват уже перехватила другая реплика
+func (s *ChatService) renewRetention(cp *domain.RetentionCheckpoint) error {
+	return s.retention.Checkpoint(cp.Token, cp.ChatCursor, time.Now().Add(retentionLease).Unix())
+}
+
+// purgeDeleted стирает сообщения, удалённые не позже before. Клиенты и журналы обновлений
+// об удалении уже знают, поэтому событие уходит только в search-service.
+func (s *ChatService) purgeDeleted(ctx context.Context, cp *domain.RetentionCheckpoint, before int64, stats *domain.RetentionStats) error {
+	for ctx.Err() == nil {
+		msgs, err := s.msgs.ListDeletedBefore(before, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+		detached, err := s.dropMessages(ctx, msgs, domain.DeleteReasonRetention, "", false)
+		stats.MediaDetached += detached
+		if err != nil {
+			return err
+		}
+
+		stats.DeletedPurged += len(msgs)
+		if len(msgs) < purgeBatchSize {
+			return nil
+		}
+		if err := s.renewRetention(cp); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// retainChat обрезает историю чата до maxPerChat сообщений и удаляет указатели прочтения
+// пользователей, которые больше не состоят в чате
+func (s *ChatService) retainChat(ctx context.Context, cp *domain.RetentionCheckpoint, chat domain.Chat, stats *domain.RetentionStats) error {
+	if s.maxPerChat > 0 && chat.LastSeq > s.maxPerChat {
+		cutoff := chat.LastSeq - s.maxPerChat
+		for ctx.Err() == nil {
+			msgs, err := s.msgs.ListUpToSeq(chat.ID, cutoff, purgeBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(msgs) == 0 {
+				break
+			}
+			detached, err := s.dropMessages(ctx, msgs, domain.DeleteReasonRetention, "", true)
+			stats.MediaDetached += detached
+			if err != nil {
+				return err
+			}
+			stats.CappedPurged += len(msgs)
+			if len(msgs) < purgeBatchSize {
+				break
+			}
+			if err := s.renewRetention(cp); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.reads == nil {
+		return nil
+	}
+	states, err := s.reads.ListByChat(chat.ID)
+	if err != nil {
+		return err
+	}
+	var orphans []string
+	for _, st := range states {
+		member, err := s.isReader(chat, st.UserID)
+		if err != nil {
+			return err
+		}
+		if !member {
+			orphans = append(orphans, st.UserID)
+		}
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+	n, err := s.reads.DeleteUsers(chat.ID, orphans)
+	if err != nil {
+		return err
+	}
+	stats.ReadStatesRemoved += n
+	return nil
+}
+
+// isReader — может ли пользователь читать чат: участник или подписчик канала
+func (s *ChatService) isReader(chat domain.Chat, userID string) (bool, error) {
+	if chat.RoleOf(userID) != "" {
+		return true, nil
+	}
+	if chat.Kind != domain.ChatKindChannel || s.subscriptions == nil {
+		return false, nil
+	}
+	return s.subscriptions.IsSubscribed(chat.ID, userID)
+}
+
+// dropMessages одной транзакцией стирает сообщения и пишет по каждому чату событие "message.deleted"
+// для search-service, затем снимает в media-service вложения, которых не держат оставшиеся сообщения чата.
+// visible — сообщения ещё видны клиентам: тогда удаление попадает и в журналы участников, а после
+// транзакции убирается из закреплённых и превью. actorID пуст, если сообщения удалил таймер или очистка истории.
+// Возвращает число снятых вложений; ошибка — только если сообщения не стёрты.
+func (s *ChatService) dropMessages(ctx context.Context, msgs []domain.Message, reason, actorID string, visible bool) (int, error) {
+	ids := make([]string, 0, len(msgs))
+	byChat := map[string][]domain.Message{}
+	var chatIDs []string
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+		if _, ok := byChat[m.ChatID]; !ok {
+			chatIDs = append(chatIDs, m.ChatID)
+		}
+		byChat[m.ChatID] = append(byChat[m.ChatID], m)
+	}
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.msgs.Purge(ctx, ids); err != nil {
+			return err
+		}
+		for _, chatID := range chatIDs {
+			evt := deletedEvent(chatID, byChat[chatID], reason)
+			if err := s.emit(s.kafka.PublishEvent(ctx, domain.SearchEvent{Type: "message.deleted", Data: evt})); err != nil {
+				return err
+			}
+			if !visible {
+				continue
+			}
+			if err := s.emit(s.logChatMessages(ctx, chatID, domain.UpdateMessageDeleted, actorID, evt.MessageIDs)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	detached := 0
+	for _, chatID := range chatIDs {
+		detached += s.detachUnused(ctx, chatID, mediaIDs(byChat[chatID]))
+	}
+	if s.mentions != nil {
+		_ = s.mentions.DeleteByMessages(ids)
+	}
+	if visible {
+		for _, chatID := range chatIDs {
+			s.afterPurge(chatID, byChat[chatID])
+		}
+	}
+	return detached, nil
+}
+
+// mediaIDs — id вложений сообщений без повторов
+func mediaIDs(msgs []domain.Message) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, m := range msgs {
+		for _, md := range m.Media {
+			if md.ID == "" || seen[md.ID] {
+				continue
+			}
+			seen[md.ID] = true
+			ids = append(ids, md.ID)
+		}
+	}
+	return ids
+}
+
+// deletedEvent — событие "message.deleted" для сообщений одного чата
+func deletedEvent(chatID string, msgs []domain.Message, reason string) domain.MessagesDeletedEvent {
+	evt := domain.MessagesDeletedEvent{ChatID: chatID, Reason: reason}
+	for _, m := range msgs {
+		evt.MessageIDs = append(evt.MessageIDs, m.ID)
+		for _, md := range m.Media {
+			evt.MediaIDs = append(evt.MediaIDs, md.ID)
+		}
+	}
+	return evt
+}
+
+func recordRetention(stats domain.RetentionStats, start time.Time, err error) {
+	retentionMetrics.Add("runs", 1)
+	if err != nil {
+		retentionMetrics.Add("errors", 1)
+	}
+	retentionMetrics.Add("deleted_purged", int64(stats.DeletedPurged))
+	retentionMetrics.Add("capped_purged", int64(stats.CappedPurged))
+	retentionMetrics.Add("read_states_removed", stats.ReadStatesRemoved)
+	retentionMetrics.Add("saved_refs_removed", stats.SavedRefsRemoved)
+	retentionMetrics.Add("media_detached", int64(stats.MediaDetached))
+	retentionMetrics.Add("chats_scanned", int64(stats.ChatsScanned))
+
+	last := new(expvar.Int)
+	last.Set(start.Unix())
+	retentionMetrics.Set("last_run_unix", last)
+	took := new(expvar.Int)
+	took.Set(time.Since(start).Milliseconds())
+	retentionMetrics.Set("last_run_duration_ms", took)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	maxScheduledPerChat = 100 // ожидающих сообщений одного автора в чате
+	maxScheduleAhead    = 365 * 24 * time.Hour
+	// Сколько реплика владеет захваченным сообщением; после этого его заберёт другая
+	scheduledLease       = 30 * time.Second
+	maxScheduledAttempts = 5
+)
+
+var errSchedulingDisabled = errors.New("scheduled messages are disabled")
+
+// WithScheduled включает отложенные сообщения
+func WithScheduled(r repository.ScheduledMessageRepository) Option {
+	return func(s *ChatService) {
+		s.scheduled = r
+	}
+}
+
+// ScheduleMessage ставит сообщение в очередь на отправку в момент m.SendAt (unix)
+func (s *ChatService) ScheduleMessage(ctx context.Context, m domain.ScheduledMessage) (domain.ScheduledMessage, error) {
+	if s.scheduled == nil {
+		return domain.ScheduledMessage{}, errSchedulingDisabled
+	}
+	if m.Text == "" && len(m.Media) == 0 {
+		return domain.ScheduledMessage{}, fmt.Errorf("%w: пустое сообщение", domain.ErrInvalidArgument)
+	}
+	now := time.Now()
+	if err := validateSendAt(m.SendAt, now); err != nil {
+		return domain.ScheduledMessage{}, err
+	}
+
+	chat, err := s.loadChat(m.ChatID)
+	if err != nil {
+		return domain.ScheduledMessage{}, err
+	}
+	if !chat.CanPost(m.AuthorID) {
+		return domain.ScheduledMessage{}, domain.ErrPermissionDenied
+	}
+	// Отложенное сообщение хранится открытым текстом до отправки
+	if chat.Secret {
+		return domain.ScheduledMessage{}, fmt.Errorf("%w: в секретном чате нет отложенных сообщений", domain.ErrInvalidArgument)
+	}
+
+	count, err := s.scheduled.CountPending(m.ChatID, m.AuthorID)
+	if err != nil {
+		return domain.ScheduledMessage{}, err
+	}
+	if count >= maxScheduledPerChat {
+		return domain.ScheduledMessage{}, domain.ErrTooManyScheduled
+	}
+
+	m.ID = uuid.New().String()
+	m.Status = domain.ScheduledPending
+	m.Attempts = 0
+	m.CreatedAt = now.Unix()
+	if err := s.scheduled.Create(m); err != nil {
+		return domain.ScheduledMessage{}, err
+	}
+	return m, nil
+}
+
+// ListScheduledMessages — ожидающие отправки сообщения автора в чате
+func (s *ChatService) ListScheduledMessages(ctx context.Context, chatID, authorID string) ([]domain.ScheduledMessage, error) {
+	if s.scheduled == nil {
+		return []domain.ScheduledMessage{}, nil
+	}
+	return s.scheduled.ListPending(chatID, authorID)
+}
+
+// UpdateScheduledMessage меняет текст, вложения или время отправки; nil — поле не меняется
+func (s *ChatService) UpdateScheduledMessage(ctx context.Context, id, authorID string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error) {
+	m, err := s.ownScheduled(id, authorID)
+	if err != nil {
+		return domain.ScheduledMessage{}, err
+	}
+	if sendAt != nil {
+		if err := validateSendAt(*sendAt, time.Now()); err != nil {
+			return domain.ScheduledMessage{}, err
+		}
+	}
+	newText, newMedia := m.Text, m.Media
+	if text != nil {
+		newText = *text
+	}
+	if media != nil {
+		newMedia = *media
+	}
+	if newText == "" && len(newMedia) == 0 {
+		return domain.ScheduledMessage{}, fmt.Errorf("%w: пустое сообщение", domain.ErrInvalidArgument)
+	}
+	return s.scheduled.UpdatePending(id, text, media, sendAt)
+}
+
+func (s *ChatService) CancelScheduledMessage(ctx context.Context, id, authorID string) error {
+	if _, err := s.ownScheduled(id, authorID); err != nil {
+		return err
+	}
+	return s.scheduled.Cancel(id)
+}
+
+// RunScheduler доставляет созревшие сообщения каждые interval до отмены ctx.
+// Запускается на каждой реплике: захват в репозитории не даёт отправить сообщение дважды.
+func (s *ChatService) RunScheduler(ctx context.Context, interval time.Duration, log zerolog.Logger) {
+	if s.scheduled == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DeliverDueScheduled(ctx); err != nil {
+				log.Error().Err(err).Msg("scheduled messages delivery failed")
+			}
+		}
+	}
+}
+
+// DeliverDueScheduled отправляет все созревшие сообщения и возвращает их количество
+func (s *ChatService) DeliverDueScheduled(ctx context.Context) (int, error) {
+	if s.scheduled == nil {
+		return 0, nil
+	}
+	delivered := 0
+	for ctx.Err() == nil {
+		m, ok, err := s.scheduled.ClaimDue(time.Now().Unix(), scheduledLease)
+		if err != nil {
+			return delivered, err
+		}
+		if !ok {
+			break
+		}
+		if err := s.deliverScheduled(ctx, m); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliverScheduled отправляет сообщение обычным путём SendMessage.
+// id сообщения в чате совпадает с id отложенного: если реплика упала после отправки,
+// повторная попытка найдёт уже отправленное сообщение и только закроет запись.
+func (s *ChatService) deliverScheduled(ctx context.Context, m domain.ScheduledMessage) error {
+	if _, err := s.msgs.Get(m.ID); err == nil {
+		return s.scheduled.MarkSent(m.ID, m.ID)
+	}
+
+	chat, err := s.loadChat(m.ChatID)
+	if err != nil {
+		return s.scheduled.MarkFailed(m.ID, err.Error())
+	}
+	if !chat.CanPost(m.AuthorID) {
+		return s.scheduled.MarkFailed(m.ID, "author can no longer post to the chat")
+	}
+
+	msg, err := s.SendMessage(ctx, domain.Message{
+		ID:       m.ID,
+		ChatID:   m.ChatID,
+		AuthorID: m.AuthorID,
+		Text:     m.Text,
+		Media:    m.Media,
+	})
+	if err != nil {
+		// Отказ в доступе (например, блокировка) и отклонение модерацией повтором не исправить
+		if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, domain.ErrMessageRejected) || m.Attempts >= maxScheduledAttempts {
+			return s.scheduled.MarkFailed(m.ID, err.Error())
+		}
+		// Запись останется захваченной до конца lease и будет повторена
+		return err
+	}
+	return s.scheduled.MarkSent(m.ID, msg.ID)
+}
+
+func (s *ChatService) ownScheduled(id, authorID string) (domain.ScheduledMessage, error) {
+	if s.scheduled == nil {
+		return domain.ScheduledMessage{}, errSchedulingDisabled
+	}
+	m, err := s.scheduled.Get(id)
+	if err != nil {
+		return domain.ScheduledMessage{}, err
+	}
+	if m.AuthorID != authorID {
+		return domain.ScheduledMessage{}, domain.ErrPermissionDenied
+	}
+	if m.Status != domain.ScheduledPending {
+		return domain.ScheduledMessage{}, domain.ErrScheduledClosed
+	}
+	return m, nil
+}
+
+func validateSendAt(sendAt int64, now time.Time) error {
+	at := time.Unix(sendAt, 0)
+	if !at.After(now) {
+		return fmt.Errorf("%w: время отправки должно быть в будущем", domain.ErrInvalidArgument)
+	}
+	if at.Sub(now) > maxScheduleAhead {
+		return fmt.Errorf("%w: время отправки слишком далеко", domain.ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	userserviceclient "main/internal/user-service-client"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxEnvelopeSize = 64 << 10
+
+var errNotSecretChat = fmt.Errorf("%w: конверты принимаются только в секретном чате", domain.ErrInvalidArgument)
+
+// CreateSecretChat создаёт секретный личный чат. Таких чатов с одним собеседником может быть
+// несколько: каждый начинается с новых сессий между устройствами. Чат не попадает в поиск,
+// а его сообщения сервер видит только как шифротекст.
+func (s *ChatService) CreateSecretChat(ctx context.Context, userID, peerID string) (domain.Chat, error) {
+	if userID == peerID {
+		return domain.Chat{}, fmt.Errorf("%w: секретный чат создаётся с другим пользователем", domain.ErrInvalidArgument)
+	}
+	for _, id := range []string{userID, peerID} {
+		if _, err := userserviceclient.GetUserInfo(s.userClient, id); err != nil {
+			return domain.Chat{}, fmt.Errorf("пользователь %s не найден: %w", id, err)
+		}
+	}
+	if err := userserviceclient.CheckNotBlocked(s.userClient, userID, []string{peerID}); err != nil {
+		return domain.Chat{}, err
+	}
+	devices, err := userserviceclient.Devices(s.userClient, []string{userID, peerID})
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if len(devices[userID]) == 0 || len(devices[peerID]) == 0 {
+		return domain.Chat{}, fmt.Errorf("%w: у обоих участников должны быть устройства с ключами шифрования", domain.ErrInvalidArgument)
+	}
+
+	now := time.Now().Unix()
+	chat := domain.Chat{
+		ID:            uuid.New().String(),
+		Kind:          domain.ChatKindDirect,
+		MemberIDs:     []string{userID, peerID},
+		CreatedBy:     userID,
+		CreatedAt:     now,
+		LastMessageAt: now,
+		Secret:        true,
+	}
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.chats.CreateSecret(ctx, chat); err != nil {
+			return err
+		}
+		if err := s.trackUnread(ctx, chat, chat.MemberIDs); err != nil {
+			return err
+		}
+		return s.emit(s.logMembership(ctx, chat, domain.MembershipCreated, userID, chat.MemberIDs))
+	})
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	return chat, nil
+}
+
+// checkEnvelopes проверяет сообщение секретного чата: содержимое есть только в конвертах,
+// и конверты адресованы ровно тем устройствам участников, что зарегистрированы в user-service,
+// кроме устройства, с которого сообщение отправлено
+func (s *ChatService) checkEnvelopes(chat domain.Chat, m domain.Message) error {
+	if m.Text != "" || len(m.Media) > 0 || m.Poll != nil || len(m.Entities) > 0 {
+		return fmt.Errorf("%w: в секретном чате содержимое передаётся только в конвертах", domain.ErrInvalidArgument)
+	}
+	if m.SenderDeviceID == "" || len(m.Envelopes) == 0 {
+		return fmt.Errorf("%w: нужны sender_device_id и конверты для устройств", domain.ErrInvalidArgument)
+	}
+
+	devices, err := userserviceclient.Devices(s.userClient, chat.MemberIDs)
+	if err != nil {
+		return err
+	}
+	expected := map[string]bool{}
+	senderKnown := false
+	for _, userID := range chat.MemberIDs {
+		for _, deviceID := range devices[userID] {
+			if userID == m.AuthorID && deviceID == m.SenderDeviceID {
+				senderKnown = true
+				continue
+			}
+			expected[userID+"/"+deviceID] = true
+		}
+	}
+	if !senderKnown {
+		return fmt.Errorf("%w: устройство %s не зарегистрировано", domain.ErrInvalidArgument, m.SenderDeviceID)
+	}
+
+	mismatch := &domain.DeviceMismatchError{}
+	seen := make(map[string]bool, len(m.Envelopes))
+	for _, e := range m.Envelopes {
+		if len(e.Ciphertext) == 0 || len(e.Ciphertext) > maxEnvelopeSize {
+			return fmt.Errorf("%w: шифротекст должен быть от 1 байта до %d КБ", domain.ErrInvalidArgument, maxEnvelopeSize>>10)
+		}
+		key := e.UserID + "/" + e.DeviceID
+		if seen[key] {
+			return fmt.Errorf("%w: два конверта для %s", domain.ErrInvalidArgument, key)
+		}
+		seen[key] = true
+		if !expected[key] {
+			mismatch.Unknown = append(mismatch.Unknown, key)
+		}
+	}
+	for key := range expected {
+		if !seen[key] {
+			mismatch.Missing = append(mismatch.Missing, key)
+		}
+	}
+	if len(mismatch.Missing) > 0 || len(mismatch.Unknown) > 0 {
+		sort.Strings(mismatch.Missing)
+		sort.Strings(mismatch.Unknown)
+		return mismatch
+	}
+	return nil
+}
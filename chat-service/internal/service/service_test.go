@@ -1,17 +1,27 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"main/internal/archive"
 	"main/internal/domain"
+	"main/internal/moderation"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	chatpb "main/pkg/api"
 	userpb "main/pkg/api_user_service"
 
+	contracts "contracts/events"
+
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
 
@@ -22,19 +32,26 @@ type MockChatRepository struct {
 	mock.Mock
 }
 
-func (m *MockChatRepository) CreateDirect(userID, peerID string, userClient userpb.UserServiceClient) (domain.Chat, error) {
+func (m *MockChatRepository) CreateDirect(ctx context.Context, userID, peerID string, userClient userpb.UserServiceClient) (domain.Chat, error) {
 	args := m.Called(userID, peerID, userClient)
 	return args.Get(0).(domain.Chat), args.Error(1)
 }
 
-func (m *MockChatRepository) CreateGroup(creatorID string, members []string, title string, userClient userpb.UserServiceClient) (domain.Chat, error) {
-	args := m.Called(creatorID, members, title, userClient)
+func (m *MockChatRepository) CreateGroup(ctx context.Context, creatorID string, members []string, title string, userClient userpb.UserServiceClient) (domain.Chat, error) {
+	args := m.Called(ctx, creatorID, members, title, userClient)
 	return args.Get(0).(domain.Chat), args.Error(1)
 }
 
-func (m *MockChatRepository) UpdateGroup(chatID string, title *string, addMembers, removeMembers []string, requesterID string, userClient userpb.UserServiceClient) (domain.Chat, error) {
+func (m *MockChatRepository) ListAll(afterID string, limit int) ([]domain.Chat, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) UpdateGroup(ctx context.Context, chatID string, title *string, addMembers, removeMembers []string, requesterID string, userClient userpb.UserServiceClient) (domain.Chat, []string, []string, error) {
 	args := m.Called(chatID, title, addMembers, removeMembers, requesterID, userClient)
-	return args.Get(0).(domain.Chat), args.Error(1)
+	added, _ := args.Get(1).([]string)
+	removed, _ := args.Get(2).([]string)
+	return args.Get(0).(domain.Chat), added, removed, args.Error(3)
 }
 
 func (m *MockChatRepository) Get(chatID string) (domain.Chat, error) {
@@ -42,44 +59,114 @@ func (m *MockChatRepository) Get(chatID string) (domain.Chat, error) {
 	return args.Get(0).(domain.Chat), args.Error(1)
 }
 
-func (m *MockChatRepository) List(userID string, limit int, cursor string) ([]domain.Chat, string, error) {
-	args := m.Called(userID, limit, cursor)
+func (m *MockChatRepository) List(q domain.ChatQuery) ([]domain.Chat, string, error) {
+	args := m.Called(q)
 	return args.Get(0).([]domain.Chat), args.String(1), args.Error(2)
 }
 
+func (m *MockChatRepository) SetLastMessage(chatID string, p domain.MessagePreview) error {
+	args := m.Called(chatID, p)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) Pin(chatID string, pin domain.PinnedMessage) (domain.Chat, error) {
+	args := m.Called(chatID, pin)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) Unpin(chatID, messageID string) (domain.Chat, error) {
+	args := m.Called(chatID, messageID)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) NextSeq(chatID string) (int64, error) {
+	args := m.Called(chatID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChatRepository) ReserveSeqs(chatID string, n int64) (int64, error) {
+	args := m.Called(chatID, n)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChatRepository) SetSlowMode(chatID string, seconds int64) (domain.Chat, error) {
+	args := m.Called(chatID, seconds)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) SetMessageTTL(chatID string, ttl int64) (domain.Chat, error) {
+	args := m.Called(chatID, ttl)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) CreateChannel(chat domain.Chat) error {
+	args := m.Called(chat)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) CreateSecret(ctx context.Context, chat domain.Chat) error {
+	args := m.Called(chat)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) GetByHandle(handle string) (domain.Chat, error) {
+	args := m.Called(handle)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) IncSubscribers(ctx context.Context, chatID string, delta int64) error {
+	args := m.Called(chatID, delta)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) SetChannelAdmin(chatID, userID string, admin bool) (domain.Chat, error) {
+	args := m.Called(chatID, userID, admin)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) AddMember(ctx context.Context, chatID, userID string) (domain.Chat, bool, error) {
+	args := m.Called(ctx, chatID, userID)
+	return args.Get(0).(domain.Chat), args.Bool(1), args.Error(2)
+}
+
 // MockMessageRepository - мок для MessageRepository
 type MockMessageRepository struct {
 	mock.Mock
 }
 
-func (m *MockMessageRepository) Send(msg domain.Message) (domain.Message, error) {
-	args := m.Called(msg)
+func (m *MockMessageRepository) Send(ctx context.Context, msg domain.Message) (domain.Message, error) {
+	args := m.Called(ctx, msg)
 	return args.Get(0).(domain.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) InsertMany(ctx context.Context, msgs []domain.Message) error {
+	args := m.Called(ctx, msgs)
+	return args.Error(0)
+}
+
 func (m *MockMessageRepository) Get(id string) (domain.Message, error) {
 	args := m.Called(id)
 	return args.Get(0).(domain.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) Update(messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error) {
-	args := m.Called(messageID, authorID, text, media)
-	return args.Get(0).(domain.Message), args.Error(1)
+func (m *MockMessageRepository) GetMany(ids []string) ([]domain.Message, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]domain.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) Delete(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error) {
-	args := m.Called(messageIDs, hard, requesterID)
-	return args.Get(0).([]domain.Message), args.Error(1)
+func (m *MockMessageRepository) Update(ctx context.Context, messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error) {
+	args := m.Called(ctx, messageID, authorID, text, media)
+	return args.Get(0).(domain.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) List(chatID string, limit int, cursor string) ([]domain.Message, string, error) {
-	args := m.Called(chatID, limit, cursor)
-	return args.Get(0).([]domain.Message), args.String(1), args.Error(2)
+func (m *MockMessageRepository) Delete(ctx context.Context, messageIDs []string, requesterID string) ([]domain.Message, error) {
+	args := m.Called(messageIDs, requesterID)
+	return args.Get(0).([]domain.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) MarkRead(chatID, userID, messageID string) error {
-	args := m.Called(chatID, userID, messageID)
-	return args.Error(0)
+func (m *MockMessageRepository) List(q domain.MessageQuery) (domain.MessagePage, error) {
+	args := m.Called(q)
+	return args.Get(0).(domain.MessagePage), args.Error(1)
 }
 
 func (m *MockMessageRepository) ToggleSaved(userID, messageID string, saved bool) error {
@@ -92,523 +179,4609 @@ func (m *MockMessageRepository) ListSaved(userID string, limit int, cursor strin
 	return args.Get(0).([]domain.Message), args.String(1), args.Error(2)
 }
 
-func (m *MockMessageRepository) ListReadMessages(userID, chatID string, limit int) ([]domain.Message, error) {
-	args := m.Called(userID, chatID, limit)
+func (m *MockMessageRepository) ListReadMessages(states []domain.ReadState, limit int) ([]domain.Message, error) {
+	args := m.Called(states, limit)
 	return args.Get(0).([]domain.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) GetUnreadCount(chatID, userID string) (int64, error) {
-	args := m.Called(chatID, userID)
+func (m *MockMessageRepository) ListRevisions(messageID string) ([]domain.MessageRevision, error) {
+	args := m.Called(messageID)
+	return args.Get(0).([]domain.MessageRevision), args.Error(1)
+}
+
+func (m *MockMessageRepository) ListExpired(now int64, limit int) ([]domain.Message, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) ListDeletedBefore(ts int64, limit int) ([]domain.Message, error) {
+	args := m.Called(ts, limit)
+	return args.Get(0).([]domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) ListUpToSeq(chatID string, seq int64, limit int) ([]domain.Message, error) {
+	args := m.Called(chatID, seq, limit)
+	return args.Get(0).([]domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) ClearSavedOnDeleted() (int64, error) {
+	args := m.Called()
 	return args.Get(0).(int64), args.Error(1)
 }
 
-// MockKafkaProducer - мок для KafkaProducer
-type MockKafkaProducer struct {
+func (m *MockMessageRepository) Vote(ctx context.Context, vote domain.PollVote, now int64) (domain.Message, error) {
+	args := m.Called(ctx, vote, now)
+	return args.Get(0).(domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) RetractVote(ctx context.Context, messageID, userID string, now int64) (domain.Message, error) {
+	args := m.Called(ctx, messageID, userID, now)
+	return args.Get(0).(domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetVote(messageID, userID string) (domain.PollVote, error) {
+	args := m.Called(messageID, userID)
+	return args.Get(0).(domain.PollVote), args.Error(1)
+}
+
+func (m *MockMessageRepository) ListVotes(messageID string) ([]domain.PollVote, error) {
+	args := m.Called(messageID)
+	return args.Get(0).([]domain.PollVote), args.Error(1)
+}
+
+func (m *MockMessageRepository) ClosePoll(ctx context.Context, messageID string, now int64) (domain.Message, error) {
+	args := m.Called(messageID, now)
+	return args.Get(0).(domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) IncViews(chatID string, messageIDs []string) error {
+	args := m.Called(chatID, messageIDs)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) Purge(ctx context.Context, messageIDs []string) error {
+	args := m.Called(messageIDs)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) MediaInUse(chatID string, fileIDs []string) ([]string, error) {
+	args := m.Called(chatID, fileIDs)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockMentionRepository - мок для MentionRepository
+type MockMentionRepository struct {
 	mock.Mock
 }
 
-func (m *MockKafkaProducer) PublishNewMessage(ctx context.Context, e domain.NewMessageEvent) error {
-	args := m.Called(ctx, e)
+func (m *MockMentionRepository) Add(mentions []domain.Mention) error {
+	args := m.Called(mentions)
 	return args.Error(0)
 }
 
-func (m *MockKafkaProducer) PublishEvent(ctx context.Context, evt domain.SearchEvent) error {
-	args := m.Called(ctx, evt)
+func (m *MockMentionRepository) ListUnread(userID, chatID string, limit int, cursor string) ([]domain.Mention, string, error) {
+	args := m.Called(userID, chatID, limit, cursor)
+	return args.Get(0).([]domain.Mention), args.String(1), args.Error(2)
+}
+
+func (m *MockMentionRepository) CountUnread(userID, chatID string) (int64, error) {
+	args := m.Called(userID, chatID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMentionRepository) MarkRead(userID, chatID string, upToSeq int64) error {
+	args := m.Called(userID, chatID, upToSeq)
 	return args.Error(0)
 }
 
-// MockUserServiceClient - мок для UserServiceClient
-type MockUserServiceClient struct {
+func (m *MockMentionRepository) DeleteByMessages(messageIDs []string) error {
+	args := m.Called(messageIDs)
+	return args.Error(0)
+}
+
+// MockChatStateRepository - мок для ChatStateRepository
+type MockChatStateRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserServiceClient) CreateUser(ctx context.Context, in *userpb.CreateUserRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
-	args := m.Called(ctx, in, opts)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*userpb.UserResponse), args.Error(1)
+func (m *MockChatStateRepository) Get(chatID, userID string) (domain.UserChatState, error) {
+	args := m.Called(chatID, userID)
+	return args.Get(0).(domain.UserChatState), args.Error(1)
 }
 
-func (m *MockUserServiceClient) UpdateUser(ctx context.Context, in *userpb.UpdateUserRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
-	args := m.Called(ctx, in, opts)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*userpb.UserResponse), args.Error(1)
+func (m *MockChatStateRepository) ListByUser(userID string) ([]domain.UserChatState, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.UserChatState), args.Error(1)
 }
 
-func (m *MockUserServiceClient) DeleteUser(ctx context.Context, in *userpb.DeleteUserRequest, opts ...grpc.CallOption) (*userpb.DeleteUserResponse, error) {
-	args := m.Called(ctx, in, opts)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*userpb.DeleteUserResponse), args.Error(1)
+func (m *MockChatStateRepository) CountPinned(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockUserServiceClient) AboutMeUser(ctx context.Context, in *userpb.AboutMeRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
-	args := m.Called(ctx, in, opts)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*userpb.UserResponse), args.Error(1)
+func (m *MockChatStateRepository) SetPinned(chatID, userID string, pinned bool, at int64) error {
+	args := m.Called(chatID, userID, pinned, at)
+	return args.Error(0)
 }
 
-// ==================== ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ====================
+func (m *MockChatStateRepository) SetArchived(chatID, userID string, archived bool) error {
+	args := m.Called(chatID, userID, archived)
+	return args.Error(0)
+}
 
-// createTestService создает тестовый сервис с моками
-func createTestService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockKafkaProducer, *MockUserServiceClient) {
-	mockChatRepo := &MockChatRepository{}
-	mockMsgRepo := &MockMessageRepository{}
-	mockKafka := &MockKafkaProducer{}
-	mockUserClient := &MockUserServiceClient{}
+func (m *MockChatStateRepository) SetMutedUntil(chatID, userID string, until int64) error {
+	args := m.Called(chatID, userID, until)
+	return args.Error(0)
+}
 
-	service := NewChatService(
-		mockChatRepo,
-		mockMsgRepo,
-		mockKafka,
-		mockUserClient,
-	)
+func (m *MockChatStateRepository) SetMarkedUnread(chatID, userID string, unread bool) error {
+	args := m.Called(chatID, userID, unread)
+	return args.Error(0)
+}
 
-	return service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient
+// MockSubscriptionRepository - мок для SubscriptionRepository
+type MockSubscriptionRepository struct {
+	mock.Mock
 }
 
-// createTestChat создает тестовый чат
-func createTestChat(id string, kind domain.ChatKind) domain.Chat {
-	return domain.Chat{
-		ID:        id,
-		Kind:      kind,
-		MemberIDs: []string{"user1", "user2"},
-		CreatedBy: "user1",
-		CreatedAt: time.Now().Unix(),
-	}
+func (m *MockSubscriptionRepository) Subscribe(ctx context.Context, chatID, userID string, at int64) (bool, error) {
+	args := m.Called(chatID, userID, at)
+	return args.Bool(0), args.Error(1)
 }
 
-// createTestMessage создает тестовое сообщение
-func createTestMessage(id, chatID, authorID, text string) domain.Message {
-	return domain.Message{
-		ID:        id,
-		ChatID:    chatID,
-		AuthorID:  authorID,
-		Text:      text,
-		CreatedAt: time.Now().Unix(),
-		ReadBy:    []domain.ReadInfo{},
-		SavedBy:   []domain.SavedInfo{},
-		Deleted:   false,
-	}
+func (m *MockSubscriptionRepository) Unsubscribe(ctx context.Context, chatID, userID string) (bool, error) {
+	args := m.Called(chatID, userID)
+	return args.Bool(0), args.Error(1)
 }
 
-// ==================== ТЕСТЫ ====================
+func (m *MockSubscriptionRepository) IsSubscribed(chatID, userID string) (bool, error) {
+	args := m.Called(chatID, userID)
+	return args.Bool(0), args.Error(1)
+}
 
-func TestChatService_CreateDirect_Success(t *testing.T) {
-	// Подготовка
-	service, mockChatRepo, _, _, _ := createTestService()
-	ctx := context.Background()
+func (m *MockSubscriptionRepository) ListSubscribers(chatID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error) {
+	args := m.Called(chatID, limit, cursor)
+	return args.Get(0).([]domain.ChannelSubscription), args.String(1), args.Error(2)
+}
 
-	expectedChat := createTestChat("user1_user2", domain.ChatKindDirect)
+func (m *MockSubscriptionRepository) ListChannelIDs(userID string) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
 
-	// Настройка моков
-	mockChatRepo.On("CreateDirect", "user1", "user2", mock.Anything).Return(expectedChat, nil)
+// MockInviteRepository - мок для InviteRepository
+type MockInviteRepository struct {
+	mock.Mock
+}
 
-	// Выполнение
-	chat, err := service.CreateDirect(ctx, "user1", "user2")
+func (m *MockInviteRepository) CreateLink(link domain.InviteLink) error {
+	args := m.Called(link)
+	return args.Error(0)
+}
 
-	// Проверки
-	assert.NoError(t, err)
-	assert.Equal(t, expectedChat, chat)
-	mockChatRepo.AssertExpectations(t)
+func (m *MockInviteRepository) GetLink(code string) (domain.InviteLink, error) {
+	args := m.Called(code)
+	return args.Get(0).(domain.InviteLink), args.Error(1)
 }
 
-func TestChatService_CreateGroup_Success(t *testing.T) {
-	// Подготовка
-	service, mockChatRepo, _, mockKafka, _ := createTestService()
-	ctx := context.Background()
+func (m *MockInviteRepository) RevokeLink(code string) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
 
-	expectedChat := createTestChat("group-123", domain.ChatKindGroup)
-	expectedChat.Title = "Test Group"
-	expectedChat.MemberIDs = []string{"creator1", "user1", "user2"}
+func (m *MockInviteRepository) UseLink(code string, now int64) (domain.InviteLink, error) {
+	args := m.Called(code, now)
+	return args.Get(0).(domain.InviteLink), args.Error(1)
+}
 
-	// Настройка моков
-	mockChatRepo.On("CreateGroup", "creator1", []string{"user1", "user2"}, "Test Group", mock.Anything).Return(expectedChat, nil)
-	mockKafka.On("PublishEvent", ctx, mock.AnythingOfType("domain.SearchEvent")).Return(nil)
+func (m *MockInviteRepository) ReleaseLink(code string) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
 
-	// Выполнение
-	chat, err := service.CreateGroup(ctx, "creator1", []string{"user1", "user2"}, "Test Group")
+func (m *MockInviteRepository) CreateRequest(req domain.JoinRequest) (domain.JoinRequest, error) {
+	args := m.Called(req)
+	return args.Get(0).(domain.JoinRequest), args.Error(1)
+}
 
-	// Проверки
-	assert.NoError(t, err)
-	assert.Equal(t, expectedChat, chat)
-	mockChatRepo.AssertExpectations(t)
-	mockKafka.AssertExpectations(t)
+func (m *MockInviteRepository) GetRequest(id string) (domain.JoinRequest, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.JoinRequest), args.Error(1)
 }
 
-// func TestChatService_SendMessage_Success(t *testing.T) {
-// 	// Подготовка
-// 	service, _, mockMsgRepo, mockKafka, _ := createTestService()
-// 	ctx := context.Background()
+func (m *MockInviteRepository) ListPendingRequests(chatID string, limit int, cursor string) ([]domain.JoinRequest, string, error) {
+	args := m.Called(chatID, limit, cursor)
+	return args.Get(0).([]domain.JoinRequest), args.String(1), args.Error(2)
+}
 
-// 	msg := domain.Message{
-// 		ChatID:   "chat1",
-// 		AuthorID: "user1",
-// 		Text:     "Hello World",
-// 	}
+func (m *MockInviteRepository) DecideRequest(ctx context.Context, id string, status domain.JoinRequestStatus, decidedBy string, now int64) (domain.JoinRequest, error) {
+	args := m.Called(id, status, decidedBy, now)
+	return args.Get(0).(domain.JoinRequest), args.Error(1)
+}
+
+// MockScheduledMessageRepository - мок для ScheduledMessageRepository
+type MockScheduledMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduledMessageRepository) Create(msg domain.ScheduledMessage) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
+
+func (m *MockScheduledMessageRepository) Get(id string) (domain.ScheduledMessage, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockScheduledMessageRepository) ListPending(chatID, authorID string) ([]domain.ScheduledMessage, error) {
+	args := m.Called(chatID, authorID)
+	return args.Get(0).([]domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockScheduledMessageRepository) CountPending(chatID, authorID string) (int64, error) {
+	args := m.Called(chatID, authorID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockScheduledMessageRepository) UpdatePending(id string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error) {
+	args := m.Called(id, text, media, sendAt)
+	return args.Get(0).(domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockScheduledMessageRepository) Cancel(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockScheduledMessageRepository) ClaimDue(now int64, lease time.Duration) (domain.ScheduledMessage, bool, error) {
+	args := m.Called(now, lease)
+	return args.Get(0).(domain.ScheduledMessage), args.Bool(1), args.Error(2)
+}
+
+func (m *MockScheduledMessageRepository) MarkSent(id, messageID string) error {
+	args := m.Called(id, messageID)
+	return args.Error(0)
+}
+
+func (m *MockScheduledMessageRepository) MarkFailed(id, reason string) error {
+	args := m.Called(id, reason)
+	return args.Error(0)
+}
+
+// MockReadStateRepository - мок для ReadStateRepository
+type MockReadStateRepository struct {
+	mock.Mock
+}
+
+func (m *MockReadStateRepository) MarkRead(ctx context.Context, chatID, userID string, seq int64) error {
+	args := m.Called(chatID, userID, seq)
+	return args.Error(0)
+}
+
+func (m *MockReadStateRepository) MarkDelivered(chatID, userID string, seq int64) error {
+	args := m.Called(chatID, userID, seq)
+	return args.Error(0)
+}
+
+func (m *MockReadStateRepository) Get(chatID, userID string) (domain.ReadState, error) {
+	args := m.Called(chatID, userID)
+	return args.Get(0).(domain.ReadState), args.Error(1)
+}
+
+func (m *MockReadStateRepository) ListByChat(chatID string) ([]domain.ReadState, error) {
+	args := m.Called(chatID)
+	return args.Get(0).([]domain.ReadState), args.Error(1)
+}
+
+func (m *MockReadStateRepository) ListByUser(userID string) ([]domain.ReadState, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.ReadState), args.Error(1)
+}
+
+func (m *MockReadStateRepository) Track(ctx context.Context, chatID string, userIDs []string, lastSeq int64) error {
+	args := m.Called(chatID, userIDs, lastSeq)
+	return args.Error(0)
+}
+
+func (m *MockReadStateRepository) AdvanceChat(ctx context.Context, chatID string, seq int64) error {
+	args := m.Called(chatID, seq)
+	return args.Error(0)
+}
+
+func (m *MockReadStateRepository) ListUnread(userID string) ([]domain.ReadState, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.ReadState), args.Error(1)
+}
+
+func (m *MockReadStateRepository) DeleteUsers(chatID string, userIDs []string) (int64, error) {
+	args := m.Called(chatID, userIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockTypingRepository - мок для TypingRepository
+type MockTypingRepository struct {
+	mock.Mock
+}
+
+func (m *MockTypingRepository) SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction, ttl time.Duration) error {
+	args := m.Called(ctx, chatID, userID, action, ttl)
+	return args.Error(0)
+}
+
+func (m *MockTypingRepository) ClearTyping(ctx context.Context, chatID, userID string) error {
+	args := m.Called(ctx, chatID, userID)
+	return args.Error(0)
+}
+
+func (m *MockTypingRepository) ListTyping(ctx context.Context, chatID string) ([]domain.TypingStatus, error) {
+	args := m.Called(ctx, chatID)
+	return args.Get(0).([]domain.TypingStatus), args.Error(1)
+}
+
+func (m *MockTypingRepository) Allow(ctx context.Context, key string, window time.Duration) (bool, error) {
+	args := m.Called(ctx, key, window)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTypingRepository) PublishTyping(ctx context.Context, userIDs []string, status domain.TypingStatus) error {
+	args := m.Called(ctx, userIDs, status)
+	return args.Error(0)
+}
+
+// SubscribeTyping отдаёт в deliver для user2 статусы из первого аргумента Return; без ошибки из второго,
+// как настоящая подписка, ждёт отмены ctx
+func (m *MockTypingRepository) SubscribeTyping(ctx context.Context, deliver func(userIDs []string, status domain.TypingStatus)) error {
+	args := m.Called(ctx)
+	if statuses, ok := args.Get(0).([]domain.TypingStatus); ok {
+		for _, st := range statuses {
+			deliver([]string{"user2"}, st)
+		}
+	}
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// MockDraftRepository - мок для DraftRepository
+type MockDraftRepository struct {
+	mock.Mock
+}
+
+func (m *MockDraftRepository) Save(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error) {
+	args := m.Called(d, baseVersion)
+	return args.Get(0).(domain.Draft), args.Bool(1), args.Error(2)
+}
+
+func (m *MockDraftRepository) Overwrite(ctx context.Context, d domain.Draft) (domain.Draft, error) {
+	args := m.Called(d)
+	return args.Get(0).(domain.Draft), args.Error(1)
+}
+
+func (m *MockDraftRepository) Get(chatID, userID string) (domain.Draft, error) {
+	args := m.Called(chatID, userID)
+	return args.Get(0).(domain.Draft), args.Error(1)
+}
+
+func (m *MockDraftRepository) ListByUser(userID string) ([]domain.Draft, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.Draft), args.Error(1)
+}
+
+// MockChatFolderRepository - мок для ChatFolderRepository
+type MockChatFolderRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatFolderRepository) Create(ctx context.Context, f domain.ChatFolder) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *MockChatFolderRepository) Get(id, userID string) (domain.ChatFolder, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(domain.ChatFolder), args.Error(1)
+}
+
+func (m *MockChatFolderRepository) ListByUser(userID string) ([]domain.ChatFolder, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.ChatFolder), args.Error(1)
+}
+
+func (m *MockChatFolderRepository) Count(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChatFolderRepository) Update(ctx context.Context, f domain.ChatFolder) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *MockChatFolderRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockChatFolderRepository) SetPositions(ctx context.Context, userID string, ids []string, at int64) error {
+	args := m.Called(userID, ids, at)
+	return args.Error(0)
+}
+
+// MockUpdateLogRepository - мок для UpdateLogRepository
+type MockUpdateLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockUpdateLogRepository) Append(ctx context.Context, userIDs []string, u domain.UserUpdate) error {
+	args := m.Called(userIDs, u)
+	return args.Error(0)
+}
+
+func (m *MockUpdateLogRepository) List(userID string, afterSeq int64, limit int) ([]domain.UserUpdate, error) {
+	args := m.Called(userID, afterSeq, limit)
+	return args.Get(0).([]domain.UserUpdate), args.Error(1)
+}
+
+func (m *MockUpdateLogRepository) CurrentSeq(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUpdateLogRepository) OldestSeq(userID string) (int64, bool, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
+
+func (m *MockUpdateLogRepository) DeleteBefore(ts int64) error {
+	args := m.Called(ts)
+	return args.Error(0)
+}
+
+// MockOutboxRepository - мок для OutboxRepository
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Add(ctx context.Context, e domain.OutboxEvent) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) ClaimDue(now int64, lease time.Duration) (domain.OutboxEvent, bool, error) {
+	args := m.Called(now, lease)
+	return args.Get(0).(domain.OutboxEvent), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOutboxRepository) MarkSent(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkRetry(id string, nextAttemptAt int64, reason string) error {
+	args := m.Called(id, nextAttemptAt, reason)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) DeleteSentBefore(ts int64) error {
+	args := m.Called(ts)
+	return args.Error(0)
+}
+
+// MockRetentionRepository - мок для RetentionRepository
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionRepository) Claim(now int64, lease time.Duration) (domain.RetentionCheckpoint, bool, error) {
+	args := m.Called(now, lease)
+	return args.Get(0).(domain.RetentionCheckpoint), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRetentionRepository) Checkpoint(token, chatCursor string, lockedUntil int64) error {
+	args := m.Called(token, chatCursor, lockedUntil)
+	return args.Error(0)
+}
+
+func (m *MockRetentionRepository) Finish(token string, at int64) error {
+	args := m.Called(token, at)
+	return args.Error(0)
+}
+
+type txKey struct{}
+
+// fakeTransactor помечает ctx транзакции; ошибка fn считается откатом
+type fakeTransactor struct {
+	rolledBack bool
+}
+
+func (f *fakeTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(context.WithValue(ctx, txKey{}, true))
+	f.rolledBack = err != nil
+	return err
+}
+
+func inTxCtx() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) == true })
+}
+
+// outboxEventOf проверяет, что строка outbox — ожидающий конверт события typ с ключом key
+func outboxEventOf(typ, key string) interface{} {
+	return mock.MatchedBy(func(e domain.OutboxEvent) bool {
+		env, _, err := contracts.Unmarshal(e.Payload)
+		return err == nil && env.Type == typ && e.Key == key && e.Status == domain.OutboxPending
+	})
+}
+
+// MockKafkaProducer - мок для KafkaProducer
+type MockKafkaProducer struct {
+	mock.Mock
+}
+
+func (m *MockKafkaProducer) Publish(ctx context.Context, key string, value []byte) error {
+	args := m.Called(ctx, key, value)
+	return args.Error(0)
+}
+
+func (m *MockKafkaProducer) PublishNewMessage(ctx context.Context, e domain.NewMessageEvent) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockKafkaProducer) PublishEvent(ctx context.Context, evt domain.SearchEvent) error {
+	args := m.Called(ctx, evt)
+	return args.Error(0)
+}
+
+// MockUserServiceClient - мок для UserServiceClient
+type MockUserServiceClient struct {
+	mock.Mock
+}
+
+func (m *MockUserServiceClient) CreateUser(ctx context.Context, in *userpb.CreateUserRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.UserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) UpdateUser(ctx context.Context, in *userpb.UpdateUserRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.UserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) DeleteUser(ctx context.Context, in *userpb.DeleteUserRequest, opts ...grpc.CallOption) (*userpb.DeleteUserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.DeleteUserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) AboutMeUser(ctx context.Context, in *userpb.AboutMeRequest, opts ...grpc.CallOption) (*userpb.UserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.UserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ResolveUserNames(ctx context.Context, in *userpb.ResolveUserNamesRequest, opts ...grpc.CallOption) (*userpb.ResolveUserNamesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.ResolveUserNamesResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) CheckBlocked(ctx context.Context, in *userpb.CheckBlockedRequest, opts ...grpc.CallOption) (*userpb.CheckBlockedResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.CheckBlockedResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ListDevices(ctx context.Context, in *userpb.ListDevicesRequest, opts ...grpc.CallOption) (*userpb.ListDevicesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userpb.ListDevicesResponse), args.Error(1)
+}
+
+// ==================== ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ====================
+
+// notBlocked разрешает проверки блокировок для всех пользователей
+func notBlocked(m *MockUserServiceClient) {
+	m.On("CheckBlocked", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.CheckBlockedResponse{}, nil)
+}
+
+// createTestService создает тестовый сервис с моками
+func createTestService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockKafkaProducer, *MockUserServiceClient) {
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockUserClient := &MockUserServiceClient{}
+
+	service := NewChatService(
+		mockChatRepo,
+		mockMsgRepo,
+		mockKafka,
+		mockUserClient,
+	)
+
+	return service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient
+}
+
+// createTestChat создает тестовый чат
+func createTestChat(id string, kind domain.ChatKind) domain.Chat {
+	return domain.Chat{
+		ID:        id,
+		Kind:      kind,
+		MemberIDs: []string{"user1", "user2"},
+		CreatedBy: "user1",
+		CreatedAt: time.Now().Unix(),
+	}
+}
+
+// createTestMessage создает тестовое сообщение
+func createTestMessage(id, chatID, authorID, text string) domain.Message {
+	return domain.Message{
+		ID:        id,
+		ChatID:    chatID,
+		AuthorID:  authorID,
+		Text:      text,
+		CreatedAt: time.Now().Unix(),
+		SavedBy:   []domain.SavedInfo{},
+		Deleted:   false,
+	}
+}
+
+// ==================== ТЕСТЫ ====================
+
+func TestChatService_CreateDirect_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	ctx := context.Background()
+
+	expectedChat := createTestChat("user1_user2", domain.ChatKindDirect)
+
+	// Настройка моков
+	mockChatRepo.On("CreateDirect", "user1", "user2", mock.Anything).Return(expectedChat, nil)
+
+	// Выполнение
+	chat, err := service.CreateDirect(ctx, "user1", "user2")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChat, chat)
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_CreateGroup_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockKafka, _ := createTestService()
+	ctx := context.Background()
+
+	expectedChat := createTestChat("group-123", domain.ChatKindGroup)
+	expectedChat.Title = "Test Group"
+	expectedChat.MemberIDs = []string{"creator1", "user1", "user2"}
+
+	// Настройка моков
+	mockChatRepo.On("CreateGroup", mock.Anything, "creator1", []string{"user1", "user2"}, "Test Group", mock.Anything).Return(expectedChat, nil)
+	mockKafka.On("PublishEvent", ctx, mock.AnythingOfType("domain.SearchEvent")).Return(nil)
+
+	// Выполнение
+	chat, err := service.CreateGroup(ctx, "creator1", []string{"user1", "user2"}, "Test Group")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChat, chat)
+	mockChatRepo.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+}
+
+// func TestChatService_SendMessage_Success(t *testing.T) {
+// 	// Подготовка
+// 	service, _, mockMsgRepo, mockKafka, _ := createTestService()
+// 	ctx := context.Background()
+
+// 	msg := domain.Message{
+// 		ChatID:   "chat1",
+// 		AuthorID: "user1",
+// 		Text:     "Hello World",
+// 	}
+
+// 	expectedMsg := createTestMessage("msg-123", "chat1", "user1", "Hello World")
+
+// 	// Настройка моков
+// 	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+// 		return m.ChatID == "chat1" && m.AuthorID == "user1" && m.Text == "Hello World"
+// 	})).Return(expectedMsg, nil)
+// 	mockKafka.On("PublishNewMessage", ctx, mock.AnythingOfType("domain.NewMessageEvent")).Return(nil)
+// 	mockKafka.On("PublishEvent", ctx, mock.AnythingOfType("domain.SearchEvent")).Return(nil)
+
+// 	// Выполнение
+// 	result, err := service.SendMessage(ctx, msg)
+
+// 	// Проверки
+// 	assert.NoError(t, err)
+// 	assert.Equal(t, "msg-123", result.ID)
+// 	assert.Equal(t, "chat1", result.ChatID)
+// 	assert.Equal(t, "Hello World", result.Text)
+// 	assert.NotZero(t, result.CreatedAt)
+
+// 	mockMsgRepo.AssertExpectations(t)
+// 	mockKafka.AssertExpectations(t)
+// }
+
+func TestChatService_SendMessage_BlockedByPeer(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockUserClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "user1", UserUuids: []string{"user2"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{BlockedBy: []string{"user2"}}, nil)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "hi"})
+
+	// Проверки: ошибка та же, что при любом отказе в доступе
+	assert.Equal(t, domain.ErrPermissionDenied, err)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "NextSeq", mock.Anything)
+}
+
+func TestChatService_SendMessage_RepositoryError(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+	ctx := context.Background()
+
+	msg := domain.Message{
+		ChatID:   "chat1",
+		AuthorID: "user1",
+		Text:     "Hello World",
+	}
+
+	// Настройка моков - репозиторий возвращает ошибку
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockUserClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "user1", UserUuids: []string{"user2"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{}, nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.Anything).Return(domain.Message{}, errors.New("database error"))
+
+	// Выполнение
+	result, err := service.SendMessage(ctx, msg)
+
+	// Проверки
+	assert.Error(t, err)
+	assert.Equal(t, "database error", err.Error())
+	assert.Equal(t, domain.Message{}, result)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_UpdateMessage_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	newText := "Updated text"
+	expectedMsg := createTestMessage("msg-123", "chat1", "user1", "Updated text")
+
+	// Настройка моков
+	mockMsgRepo.On("Get", "msg-123").Return(createTestMessage("msg-123", "chat1", "user1", "Old text"), nil)
+	mockMsgRepo.On("Update", mock.Anything, "msg-123", "user1", &newText, (*[]domain.Media)(nil)).Return(expectedMsg, nil)
+	// Если это последнее сообщение чата — превью тоже обновится
+	mockChatRepo.On("SetLastMessage", "chat1", mock.MatchedBy(func(p domain.MessagePreview) bool {
+		return p.ID == "msg-123" && p.Text == "Updated text"
+	})).Return(nil)
+
+	// Выполнение
+	result, err := service.UpdateMessage(ctx, "msg-123", "user1", &newText, nil)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated text", result.Text)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_DeleteMessage_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	expectedMessages := []domain.Message{
+		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
+		createTestMessage("msg-2", "chat1", "user1", "Message 2"),
+	}
+
+	// Настройка моков
+	mockMsgRepo.On("Delete", []string{"msg-1", "msg-2"}, "user1").Return(expectedMessages, nil)
+	// Текст удалённых сообщений убирается из превью
+	mockChatRepo.On("SetLastMessage", "chat1", mock.MatchedBy(func(p domain.MessagePreview) bool {
+		return p.Deleted && p.Text == ""
+	})).Return(nil).Twice()
+
+	// Выполнение
+	result, err := service.DeleteMessage(context.Background(), []string{"msg-1", "msg-2"}, false, "user1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "msg-1", result[0].ID)
+	assert.Equal(t, "msg-2", result[1].ID)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListMessages_Success(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	expectedMessages := []domain.Message{
+		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
+		createTestMessage("msg-2", "chat1", "user2", "Message 2"),
+		createTestMessage("msg-3", "chat1", "user1", "Message 3"),
+	}
+
+	q := domain.MessageQuery{ChatID: "chat1", Limit: 10, BeforeSeq: 40}
+
+	// Настройка моков
+	mockMsgRepo.On("List", q).Return(domain.MessagePage{Messages: expectedMessages, HasBefore: true, HasAfter: true}, nil)
+
+	// Выполнение
+	page, err := service.ListMessages(ctx, q)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, page.Messages, 3)
+	assert.True(t, page.HasBefore)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListMessages_DefaultLimit(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+
+	mockMsgRepo.On("List", domain.MessageQuery{ChatID: "chat1", Limit: 10}).Return(domain.MessagePage{}, nil)
+
+	// Выполнение
+	_, err := service.ListMessages(context.Background(), domain.MessageQuery{ChatID: "chat1"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListMessages_SeveralAnchors(t *testing.T) {
+	service, _, _, _, _ := createTestService()
+
+	_, err := service.ListMessages(context.Background(), domain.MessageQuery{ChatID: "chat1", BeforeSeq: 5, AfterSeq: 2})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestChatService_MarkRead_Success(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockReads := &MockReadStateRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithReadStates(mockReads))
+	ctx := context.Background()
+
+	msg := createTestMessage("msg-123", "chat1", "user2", "Hello")
+	msg.Seq = 5
+
+	// Настройка моков
+	mockMsgRepo.On("Get", "msg-123").Return(msg, nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockReads.On("MarkRead", "chat1", "user1", int64(5)).Return(nil)
+
+	// Выполнение
+	err := service.MarkRead(ctx, "chat1", "user1", "msg-123")
+
+	// Проверки
+	assert.NoError(t, err)
+
+	mockReads.AssertExpectations(t)
+}
+
+func TestChatService_MarkRead_ForeignChat(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+
+	// Сообщение из другого чата
+	mockMsgRepo.On("Get", "msg-123").Return(createTestMessage("msg-123", "chat2", "user2", "Hello"), nil)
+
+	// Выполнение
+	err := service.MarkRead(context.Background(), "chat1", "user1", "msg-123")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestChatService_ToggleSaved_Success(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	// Настройка моков - сохранить сообщение
+	mockMsgRepo.On("ToggleSaved", "user1", "msg-123", true).Return(nil)
+
+	// Выполнение
+	err := service.ToggleSaved(ctx, "user1", "msg-123", true)
+
+	// Проверки
+	assert.NoError(t, err)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListSaved_Success(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	expectedMessages := []domain.Message{
+		createTestMessage("msg-1", "chat1", "user1", "Saved message 1"),
+		createTestMessage("msg-2", "chat2", "user2", "Saved message 2"),
+	}
+
+	// Настройка моков
+	mockMsgRepo.On("ListSaved", "user1", 10, "").Return(expectedMessages, "msg-2", nil)
+
+	// Выполнение
+	messages, nextCursor, err := service.ListSaved(ctx, "user1", 10, "")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "msg-2", nextCursor)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListChats_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	ctx := context.Background()
+
+	expectedChats := []domain.Chat{
+		createTestChat("chat1", domain.ChatKindDirect),
+		createTestChat("chat2", domain.ChatKindGroup),
+	}
+
+	// Настройка моков
+	mockChatRepo.On("List", domain.ChatQuery{UserID: "user1", Limit: 10, ExcludeIDs: []string{}}).
+		Return(expectedChats, "1640995200:chat2", nil)
+
+	// Выполнение
+	chats, nextCursor, err := service.ListChats(ctx, "user1", 10, "", false)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, chats, 2)
+	assert.Equal(t, "1640995200:chat2", nextCursor)
+	assert.NotNil(t, chats[0].State)
+
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_GetChat_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	ctx := context.Background()
+
+	expectedChat := createTestChat("chat1", domain.ChatKindDirect)
+
+	// Настройка моков
+	mockChatRepo.On("Get", "chat1").Return(expectedChat, nil)
+
+	// Выполнение
+	chat, err := service.GetChat(ctx, "chat1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChat, chat)
+
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_UpdateGroupChat_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	ctx := context.Background()
+
+	req := &chatpb.UpdateGroupChatRequest{
+		ChatId:          "group-123",
+		Title:           "New Title",
+		AddMemberIds:    []string{"user3"},
+		RemoveMemberIds: []string{"user4"},
+		RequesterId:     "creator1",
+	}
+
+	expectedChat := createTestChat("group-123", domain.ChatKindGroup)
+	expectedChat.Title = "New Title"
+
+	// Настройка моков
+	mockChatRepo.On("UpdateGroup",
+		"group-123",
+		mock.AnythingOfType("*string"),
+		[]string{"user3"},
+		[]string{"user4"},
+		"creator1",
+		mock.Anything,
+	).Return(expectedChat, []string{"user3"}, []string(nil), nil)
+
+	// Выполнение
+	chat, err := service.UpdateGroupChat(ctx, req)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "New Title", chat.Title)
+
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_UpdateGroupChat_NoTitleChange(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	ctx := context.Background()
+
+	// Запрос без title
+	req := &chatpb.UpdateGroupChatRequest{
+		ChatId:          "group-123",
+		Title:           "", // Пустая строка означает "не менять"
+		AddMemberIds:    []string{"user3"},
+		RemoveMemberIds: []string{},
+		RequesterId:     "creator1",
+	}
+
+	expectedChat := createTestChat("group-123", domain.ChatKindGroup)
+
+	// Настройка моков - title передается как nil
+	mockChatRepo.On("UpdateGroup",
+		"group-123",
+		(*string)(nil), // title должен быть nil
+		[]string{"user3"},
+		[]string{},
+		"creator1",
+		mock.Anything,
+	).Return(expectedChat, []string{"user3"}, []string(nil), nil)
+
+	// Выполнение
+	chat, err := service.UpdateGroupChat(ctx, req)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChat, chat)
+
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListReadMessages_Success(t *testing.T) {
+	// Подготовка
+	mockMsgRepo := &MockMessageRepository{}
+	mockReads := &MockReadStateRepository{}
+	service := NewChatService(&MockChatRepository{}, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithReadStates(mockReads))
+	ctx := context.Background()
+
+	expectedMessages := []domain.Message{
+		createTestMessage("msg-1", "chat1", "user1", "Read message 1"),
+		createTestMessage("msg-2", "chat1", "user2", "Read message 2"),
+	}
+
+	state := domain.ReadState{ChatID: "chat1", UserID: "user1", LastReadSeq: 2}
+
+	// Настройка моков
+	mockReads.On("Get", "chat1", "user1").Return(state, nil)
+	mockMsgRepo.On("ListReadMessages", []domain.ReadState{state}, 10).Return(expectedMessages, nil)
+
+	// Выполнение
+	messages, err := service.ListReadMessages(ctx, "user1", "chat1", 10)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "Read message 1", messages[0].Text)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListReadMessages_WithEmptyChatID(t *testing.T) {
+	// Подготовка
+	mockMsgRepo := &MockMessageRepository{}
+	mockReads := &MockReadStateRepository{}
+	service := NewChatService(&MockChatRepository{}, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithReadStates(mockReads))
+	ctx := context.Background()
+
+	expectedMessages := []domain.Message{
+		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
+		createTestMessage("msg-2", "chat2", "user1", "Message 2"),
+	}
+
+	states := []domain.ReadState{
+		{ChatID: "chat1", UserID: "user1", LastReadSeq: 4},
+		{ChatID: "chat2", UserID: "user1", LastReadSeq: 9},
+	}
+
+	// Настройка моков - chatID пустая, берём указатели по всем чатам пользователя
+	mockReads.On("ListByUser", "user1").Return(states, nil)
+	mockMsgRepo.On("ListReadMessages", states, 10).Return(expectedMessages, nil)
+
+	// Выполнение
+	messages, err := service.ListReadMessages(ctx, "user1", "", 10)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_DeleteMessage_HardDelete(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestService()
+	mockMedia := &MockMediaResolver{}
+	mockMentions := &MockMentionRepository{}
+	WithMedia(mockMedia)(service)
+	WithMentions(mockMentions)(service)
+
+	own := createTestMessage("msg-1", "chat1", "user1", "Message 1")
+	own.Media = []domain.Media{{ID: "file1"}}
+	foreign := createTestMessage("msg-2", "chat1", "user2", "Message 2")
+	mockMsgRepo.On("GetMany", []string{"msg-1", "msg-2"}).Return([]domain.Message{own, foreign}, nil)
+	mockMsgRepo.On("Purge", []string{"msg-1"}).Return(nil).Once()
+	mockKafka.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e domain.SearchEvent) bool {
+		evt, ok := e.Data.(domain.MessagesDeletedEvent)
+		return ok && e.Type == "message.deleted" && evt.Reason == domain.DeleteReasonAuthor && len(evt.MessageIDs) == 1
+	})).Return(nil).Once()
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file1"}).Return([]string{}, nil)
+	mockMedia.On("DetachFromChat", "file1", "chat1").Return(nil).Once()
+	mockMentions.On("DeleteByMessages", []string{"msg-1"}).Return(nil).Once()
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	// Выполнение
+	result, err := service.DeleteMessage(context.Background(), []string{"msg-1", "msg-2"}, true, "user1")
+
+	// Проверки: чужое сообщение не тронуто, своё стёрто со всем, что к нему относится
+	assert.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "msg-1", result[0].ID)
+	mockMsgRepo.AssertExpectations(t)
+	mockMsgRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockKafka.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+	mockMentions.AssertExpectations(t)
+}
+
+func TestChatService_PinMessage_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestService()
+	ctx := context.Background()
+
+	chat := createTestChat("group-1", domain.ChatKindGroup)
+	pinnedChat := chat
+	pinnedChat.Pinned = []domain.PinnedMessage{{MessageID: "msg1", PinnedBy: "user1"}}
+
+	// Настройка моков
+	mockChatRepo.On("Get", "group-1").Return(chat, nil)
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "group-1", "user2", "Hello"), nil)
+	mockChatRepo.On("Pin", "group-1", mock.MatchedBy(func(p domain.PinnedMessage) bool {
+		return p.MessageID == "msg1" && p.PinnedBy == "user1" && p.PinnedAt > 0
+	})).Return(pinnedChat, nil)
+	mockChatRepo.On("NextSeq", "group-1").Return(int64(2), nil)
+	mockChatRepo.On("SetLastMessage", "group-1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Type == domain.MessageTypeSystem && m.System != nil &&
+			m.System.Action == domain.SystemActionPin && m.System.MessageID == "msg1"
+	})).Return(createTestMessage("sys1", "group-1", "user1", "Сообщение закреплено"), nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	result, err := service.PinMessage(ctx, "group-1", "msg1", "user1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, result.Pinned, 1)
+	mockChatRepo.AssertExpectations(t)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_PinMessage_PermissionDenied(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	ctx := context.Background()
+
+	// В группе закреплять может только владелец
+	mockChatRepo.On("Get", "group-1").Return(createTestChat("group-1", domain.ChatKindGroup), nil)
+
+	// Выполнение
+	_, err := service.PinMessage(ctx, "group-1", "msg1", "user2")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockChatRepo.AssertNotCalled(t, "Pin", mock.Anything, mock.Anything)
+}
+
+func TestChatService_PinMessage_ForeignMessage(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "other-chat", "user2", "Hello"), nil)
+
+	// Выполнение
+	_, err := service.PinMessage(ctx, "chat1", "msg1", "user2")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	mockChatRepo.AssertNotCalled(t, "Pin", mock.Anything, mock.Anything)
+}
+
+func TestChatService_ListPinned_SkipsDeleted(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	chat := createTestChat("chat1", domain.ChatKindDirect)
+	chat.Pinned = []domain.PinnedMessage{
+		{MessageID: "msg1", PinnedBy: "user1"},
+		{MessageID: "msg2", PinnedBy: "user2"},
+	}
+	deleted := createTestMessage("msg2", "chat1", "user2", "Bye")
+	deleted.Deleted = true
+
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "chat1", "user1", "Hello"), nil)
+	mockMsgRepo.On("Get", "msg2").Return(deleted, nil)
+
+	// Выполнение
+	pinned, err := service.ListPinned(ctx, "chat1", "user2")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, pinned, 1)
+	assert.Equal(t, "msg1", pinned[0].MessageID)
+	assert.Equal(t, "Hello", pinned[0].Message.Text)
+}
+
+func TestChatService_ListPinned_NotMember(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	chat := createTestChat("chat1", domain.ChatKindGroup)
+	chat.Pinned = []domain.PinnedMessage{{MessageID: "msg1", PinnedBy: "user1"}}
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+
+	// Выполнение
+	_, err := service.ListPinned(context.Background(), "chat1", "stranger")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockMsgRepo.AssertNotCalled(t, "Get", mock.Anything)
+}
+
+func TestChatService_UpdateMessage_EditWindowClosed(t *testing.T) {
+	// Подготовка
+	mockMsgRepo := &MockMessageRepository{}
+	service := NewChatService(&MockChatRepository{}, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithEditWindow(time.Hour))
+	ctx := context.Background()
+
+	old := createTestMessage("msg-123", "chat1", "user1", "Old text")
+	old.CreatedAt = time.Now().Add(-2 * time.Hour).Unix()
+	mockMsgRepo.On("Get", "msg-123").Return(old, nil)
+
+	newText := "Updated text"
+
+	// Выполнение
+	_, err := service.UpdateMessage(ctx, "msg-123", "user1", &newText, nil)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrEditWindowClosed)
+	mockMsgRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_UpdateMessage_SystemMessage(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	sys := createTestMessage("msg-123", "chat1", "user1", "Сообщение закреплено")
+	sys.Type = domain.MessageTypeSystem
+	mockMsgRepo.On("Get", "msg-123").Return(sys, nil)
+
+	newText := "Updated text"
+
+	// Выполнение
+	_, err := service.UpdateMessage(ctx, "msg-123", "user1", &newText, nil)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestChatService_ListMessageRevisions_NotMember(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+	ctx := context.Background()
+
+	mockMsgRepo.On("Get", "msg-123").Return(createTestMessage("msg-123", "chat1", "user1", "Text"), nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	// Выполнение
+	_, err := service.ListMessageRevisions(ctx, "msg-123", "stranger")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockMsgRepo.AssertNotCalled(t, "ListRevisions", mock.Anything)
+}
+
+func TestParseMentions(t *testing.T) {
+	tokens := parseMentions("Привет, @Вася и @all! Пишите на a@b.ru, @ одиноко")
+
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, "Вася", tokens[0].username)
+	assert.Equal(t, 8, tokens[0].offset)
+	assert.Equal(t, 5, tokens[0].length)
+	assert.Equal(t, "all", tokens[1].username)
+}
+
+func TestChatService_SendMessage_ResolvesMentions(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockUserClient := &MockUserServiceClient{}
+	mockMentions := &MockMentionRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, mockUserClient, WithMentions(mockMentions))
+	ctx := context.Background()
+
+	chat := createTestChat("group-1", domain.ChatKindGroup)
+	chat.MemberIDs = []string{"user1", "user2", "user3"}
+	mockChatRepo.On("Get", "group-1").Return(chat, nil)
+	mockChatRepo.On("NextSeq", "group-1").Return(int64(3), nil)
+	mockChatRepo.On("SetLastMessage", "group-1", mock.MatchedBy(func(p domain.MessagePreview) bool {
+		return p.ID == "msg1" && p.Seq == 3 && p.Text == "hi @Bob"
+	})).Return(nil)
+	// Один запрос на все имена из текста, а не по запросу на участника
+	mockUserClient.On("ResolveUserNames", mock.Anything, &userpb.ResolveUserNamesRequest{
+		UserNames: []string{"bob"}, Uuids: []string{"user1", "user2", "user3"},
+	}, mock.Anything).Return(&userpb.ResolveUserNamesResponse{Users: []*userpb.UserName{{Uuid: "user2", UserName: "Bob"}}}, nil).Once()
+
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return len(m.Entities) == 1 && m.Entities[0].UserID == "user2" && m.Entities[0].Offset == 3 && m.Seq == 3
+	})).Return(domain.Message{ID: "msg1", ChatID: "group-1", Seq: 3, AuthorID: "user1", Text: "hi @Bob"}, nil)
+	mockMentions.On("Add", []domain.Mention{
+		{ChatID: "group-1", UserID: "user2", MessageID: "msg1", Seq: 3, AuthorID: "user1"},
+	}).Return(nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e domain.SearchEvent) bool {
+		return e.Type == "mention"
+	})).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	_, err := service.SendMessage(ctx, domain.Message{ChatID: "group-1", AuthorID: "user1", Text: "hi @Bob"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+	mockMentions.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
+}
+
+func TestChatService_MarkRead_ClearsMention(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockMentions := &MockMentionRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithMentions(mockMentions))
+
+	msg := createTestMessage("msg1", "chat1", "user1", "hi @bob")
+	msg.Seq = 8
+	mockMsgRepo.On("Get", "msg1").Return(msg, nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	// Снимаются все упоминания до прочитанного сообщения включительно
+	mockMentions.On("MarkRead", "user2", "chat1", int64(8)).Return(nil)
+
+	// Выполнение
+	err := service.MarkRead(context.Background(), "chat1", "user2", "msg1")
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMentions.AssertExpectations(t)
+}
+
+func TestChatService_GetUnreadCount_FromCursor(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockReads := &MockReadStateRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithReadStates(mockReads))
+
+	chat := createTestChat("chat1", domain.ChatKindGroup)
+	chat.LastSeq = 12
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	mockReads.On("Get", "chat1", "user2").Return(domain.ReadState{ChatID: "chat1", UserID: "user2", LastReadSeq: 9}, nil)
+
+	// Выполнение
+	count, err := service.GetUnreadCount(context.Background(), "chat1", "user2")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestChatService_GetReadState_SplitsReadAndDelivered(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockReads := &MockReadStateRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithReadStates(mockReads))
+
+	chat := createTestChat("chat1", domain.ChatKindGroup)
+	chat.MemberIDs = []string{"user1", "user2", "user3", "user4"}
+	msg := createTestMessage("msg1", "chat1", "user1", "Hello")
+	msg.Seq = 5
+
+	mockMsgRepo.On("Get", "msg1").Return(msg, nil)
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	mockReads.On("ListByChat", "chat1").Return([]domain.ReadState{
+		{UserID: "user1", LastReadSeq: 5, LastDeliveredSeq: 5}, // автор — не учитывается
+		{UserID: "user2", LastReadSeq: 6, LastDeliveredSeq: 6}, // прочитал
+		{UserID: "user3", LastReadSeq: 4, LastDeliveredSeq: 5}, // только доставлено
+		{UserID: "user4", LastReadSeq: 1, LastDeliveredSeq: 1}, // ещё не получил
+		{UserID: "user9", LastReadSeq: 9, LastDeliveredSeq: 9}, // покинул чат
+	}, nil)
+
+	// Выполнение
+	read, delivered, err := service.GetReadState(context.Background(), "chat1", "msg1", "user1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, read, 1)
+	assert.Equal(t, "user2", read[0].UserID)
+	assert.Len(t, delivered, 1)
+	assert.Equal(t, "user3", delivered[0].UserID)
+}
+
+func TestChatService_ListChats_PinnedFirstArchivedHidden(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockStates := &MockChatStateRepository{}
+	mockReads := &MockReadStateRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChatStates(mockStates), WithReadStates(mockReads))
+
+	mockStates.On("ListByUser", "user1").Return([]domain.UserChatState{
+		{ChatID: "old-pin", UserID: "user1", Pinned: true, PinnedAt: 100},
+		{ChatID: "new-pin", UserID: "user1", Pinned: true, PinnedAt: 200},
+		{ChatID: "arch", UserID: "user1", Archived: true},
+	}, nil)
+	mockReads.On("ListByUser", "user1").Return([]domain.ReadState{{ChatID: "recent", LastReadSeq: 7}}, nil)
+
+	pinned := []domain.Chat{{ID: "old-pin", LastSeq: 1}, {ID: "new-pin", LastSeq: 2}}
+	mockChatRepo.On("List", domain.ChatQuery{UserID: "user1", Limit: 2, OnlyIDs: []string{"new-pin", "old-pin"}}).
+		Return(pinned, "", nil)
+	mockChatRepo.On("List", domain.ChatQuery{UserID: "user1", Limit: 10, ExcludeIDs: []string{"new-pin", "old-pin", "arch"}}).
+		Return([]domain.Chat{{ID: "recent", LastSeq: 10}}, "5:recent", nil)
+
+	// Выполнение
+	chats, next, err := service.ListChats(context.Background(), "user1", 10, "", false)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "5:recent", next)
+	ids := []string{}
+	for _, c := range chats {
+		ids = append(ids, c.ID)
+	}
+	assert.Equal(t, []string{"new-pin", "old-pin", "recent"}, ids)
+	assert.True(t, chats[0].State.Pinned)
+	assert.Equal(t, int64(3), chats[2].State.UnreadCount)
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListFolderChats_Rules(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockStates := &MockChatStateRepository{}
+	mockReads := &MockReadStateRepository{}
+	mockFolders := &MockChatFolderRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChatStates(mockStates), WithReadStates(mockReads), WithFolders(mockFolders))
+
+	// «Непрочитанные группы» плюс личный чат с user7 и явно добавленный архивный чат
+	folder := domain.ChatFolder{
+		ID:         "f1",
+		UserID:     "user1",
+		Title:      "Работа",
+		IncludeIDs: []string{"arch-pinned"},
+		ExcludeIDs: []string{"noisy"},
+		Rules: domain.FolderRules{
+			Kinds:    []domain.ChatKind{domain.ChatKindGroup},
+			Contacts: []string{"user7"},
+			Unread:   domain.FolderFlagOnly,
+		},
+	}
+	mockFolders.On("Get", "f1", "user1").Return(folder, nil)
+	mockStates.On("ListByUser", "user1").Return([]domain.UserChatState{
+		{ChatID: "arch-pinned", UserID: "user1", Archived: true},
+		{ChatID: "arch-group", UserID: "user1", Archived: true},
+		{ChatID: "marked", UserID: "user1", MarkedUnread: true},
+	}, nil)
+	mockReads.On("ListByUser", "user1").Return([]domain.ReadState{
+		{ChatID: "read-group", LastReadSeq: 5},
+		{ChatID: "group", LastReadSeq: 2},
+	}, nil)
+	mockChatRepo.On("List", domain.ChatQuery{UserID: "user1", Limit: folderScanBatch}).Return([]domain.Chat{
+		{ID: "group", Kind: domain.ChatKindGroup, LastSeq: 5, LastMessageAt: 60},
+		{ID: "read-group", Kind: domain.ChatKindGroup, LastSeq: 5, LastMessageAt: 50},
+		{ID: "noisy", Kind: domain.ChatKindGroup, LastSeq: 9, LastMessageAt: 45},
+		{ID: "arch-group", Kind: domain.ChatKindGroup, LastSeq: 3, LastMessageAt: 40},
+		{ID: "other-direct", Kind: domain.ChatKindDirect, MemberIDs: []string{"user1", "user2"}, LastSeq: 4, LastMessageAt: 30},
+		{ID: "marked", Kind: domain.ChatKindDirect, MemberIDs: []string{"user1", "user7"}, LastMessageAt: 20},
+		{ID: "arch-pinned", Kind: domain.ChatKindChannel, LastMessageAt: 10},
+	}, "10:arch-pinned", nil)
+
+	// Выполнение: первая страница из двух чатов
+	chats, next, err := service.ListFolderChats(context.Background(), "user1", "f1", 2, "")
+
+	// Проверки
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, c := range chats {
+		ids = append(ids, c.ID)
+	}
+	assert.Equal(t, []string{"group", "marked"}, ids)
+	assert.Equal(t, "20:marked", next)
+	assert.Equal(t, int64(3), chats[0].State.UnreadCount)
+
+	// Без лимита — до конца списка, курсор пустой
+	chats, next, err = service.ListFolderChats(context.Background(), "user1", "f1", 0, "")
+	assert.NoError(t, err)
+	assert.Len(t, chats, 3)
+	assert.Equal(t, "arch-pinned", chats[2].ID)
+	assert.Empty(t, next)
+}
+
+func TestChatService_ListFolderChats_ExplicitOnly(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockFolders := &MockChatFolderRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithFolders(mockFolders))
+
+	mockFolders.On("Get", "f1", "user1").Return(domain.ChatFolder{ID: "f1", UserID: "user1", IncludeIDs: []string{"chat1", "chat2"}}, nil)
+	// Папка без правил читает только свои чаты
+	mockChatRepo.On("List", domain.ChatQuery{UserID: "user1", Limit: folderScanBatch, Cursor: "90:chat9", OnlyIDs: []string{"chat1", "chat2"}}).
+		Return([]domain.Chat{{ID: "chat2", LastMessageAt: 80}}, "80:chat2", nil)
+
+	// Выполнение
+	chats, next, err := service.ListFolderChats(context.Background(), "user1", "f1", 10, "90:chat9")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, chats, 1)
+	assert.Empty(t, next)
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_ListFolders_Badges(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockStates := &MockChatStateRepository{}
+	mockReads := &MockReadStateRepository{}
+	mockFolders := &MockChatFolderRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChatStates(mockStates), WithReadStates(mockReads), WithFolders(mockFolders))
+
+	future := time.Now().Add(time.Hour).Unix()
+	mockFolders.On("ListByUser", "user1").Return([]domain.ChatFolder{
+		{ID: "groups", UserID: "user1", Title: "Группы", Rules: domain.FolderRules{Kinds: []domain.ChatKind{domain.ChatKindGroup}}},
+		{ID: "loud", UserID: "user1", Title: "Со звуком", Rules: domain.FolderRules{Muted: domain.FolderFlagExclude}},
+	}, nil)
+	mockStates.On("ListByUser", "user1").Return([]domain.UserChatState{
+		{ChatID: "muted-group", UserID: "user1", MutedUntil: future},
+		{ChatID: "marked", UserID: "user1", MarkedUnread: true},
+	}, nil)
+	// Счётчики берутся из указателей прочтения, а не из last_seq чата
+	mockReads.On("ListUnread", "user1").Return([]domain.ReadState{
+		{ChatID: "muted-group", UserID: "user1", UnreadCount: 4},
+		{ChatID: "direct", UserID: "user1", UnreadCount: 2},
+	}, nil)
+	// Читаются только непрочитанные чаты
+	mockChatRepo.On("List", domain.ChatQuery{UserID: "user1", Limit: folderScanBatch, OnlyIDs: []string{"muted-group", "direct", "marked"}}).Return([]domain.Chat{
+		{ID: "muted-group", Kind: domain.ChatKindGroup, LastSeq: 10},
+		{ID: "direct", Kind: domain.ChatKindDirect, MemberIDs: []string{"user1", "user2"}, LastSeq: 10},
+		{ID: "marked", Kind: domain.ChatKindDirect, MemberIDs: []string{"user1", "user3"}, LastSeq: 10},
+	}, "0:marked", nil)
+
+	// Выполнение
+	folders, err := service.ListFolders(context.Background(), "user1")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), folders[0].UnreadChats)
+	assert.Equal(t, int64(4), folders[0].UnreadMessages)
+	assert.Equal(t, int64(2), folders[1].UnreadChats)
+	assert.Equal(t, int64(2), folders[1].UnreadMessages)
+	mockReads.AssertNotCalled(t, "ListByUser", mock.Anything)
+}
+
+func TestChatService_ListFolders_NothingUnread(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockReads := &MockReadStateRepository{}
+	mockFolders := &MockChatFolderRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithReadStates(mockReads), WithFolders(mockFolders))
+
+	mockFolders.On("ListByUser", "user1").Return([]domain.ChatFolder{{ID: "all", UserID: "user1", Title: "Все"}}, nil)
+	mockReads.On("ListUnread", "user1").Return([]domain.ReadState{}, nil)
+
+	// Выполнение
+	folders, err := service.ListFolders(context.Background(), "user1")
+
+	// Проверки: без непрочитанных чаты не читаются вовсе
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), folders[0].UnreadChats)
+	mockChatRepo.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestChatService_CreateFolder_SyncsDevices(t *testing.T) {
+	// Подготовка
+	mockFolders := &MockChatFolderRepository{}
+	mockUpdates := &MockUpdateLogRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithFolders(mockFolders), WithUpdateLog(mockUpdates))
+
+	mockFolders.On("Count", "user1").Return(int64(3), nil)
+	mockFolders.On("Create", mock.MatchedBy(func(f domain.ChatFolder) bool {
+		return f.ID != "" && f.Position == 3 && f.Title == "Работа"
+	})).Return(nil)
+	mockUpdates.On("Append", []string{"user1"}, mock.MatchedBy(func(u domain.UserUpdate) bool {
+		return u.Type == domain.UpdateFolders && u.Action == domain.FolderCreated && u.FolderID != ""
+	})).Return(nil)
+
+	// Выполнение
+	f, err := service.CreateFolder(context.Background(), domain.ChatFolder{UserID: "user1", Title: "  Работа "})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "Работа", f.Title)
+	mockFolders.AssertExpectations(t)
+	mockUpdates.AssertExpectations(t)
+}
+
+func TestChatService_CreateFolder_Validation(t *testing.T) {
+	// Подготовка
+	mockFolders := &MockChatFolderRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithFolders(mockFolders))
+	mockFolders.On("Count", "user1").Return(int64(maxFolders), nil)
+
+	// Выполнение и проверки
+	_, err := service.CreateFolder(context.Background(), domain.ChatFolder{UserID: "user1", Title: "  "})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = service.CreateFolder(context.Background(), domain.ChatFolder{UserID: "user1", Title: "Боты",
+		Rules: domain.FolderRules{Kinds: []domain.ChatKind{"bot"}}})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = service.CreateFolder(context.Background(), domain.ChatFolder{UserID: "user1", Title: "Ещё одна"})
+	assert.ErrorIs(t, err, domain.ErrTooManyFolders)
+	mockFolders.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestChatService_ReorderFolders_MustListAll(t *testing.T) {
+	// Подготовка
+	mockFolders := &MockChatFolderRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithFolders(mockFolders))
+	mockFolders.On("ListByUser", "user1").Return([]domain.ChatFolder{{ID: "f1"}, {ID: "f2"}}, nil)
+
+	// Выполнение и проверки
+	_, err := service.ReorderFolders(context.Background(), "user1", []string{"f2"})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = service.ReorderFolders(context.Background(), "user1", []string{"f2", "f3"})
+	assert.ErrorIs(t, err, domain.ErrFolderNotFound)
+	mockFolders.AssertNotCalled(t, "SetPositions", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_PinChat_Limit(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockStates := &MockChatStateRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChatStates(mockStates))
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockStates.On("Get", "chat1", "user1").Return(domain.UserChatState{ChatID: "chat1", UserID: "user1"}, nil)
+	mockStates.On("CountPinned", "user1").Return(int64(5), nil)
+
+	// Выполнение
+	_, err := service.PinChat(context.Background(), "chat1", "user1", true)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrTooManyPinnedChats)
+	mockStates.AssertNotCalled(t, "SetPinned", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_MuteChat_NotMember(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChatStates(&MockChatStateRepository{}))
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	// Выполнение
+	_, err := service.MuteChat(context.Background(), "chat1", "user9", 1700000000)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestChatService_ScheduleMessage_PastTime(t *testing.T) {
+	// Подготовка
+	mockScheduled := &MockScheduledMessageRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithScheduled(mockScheduled))
+
+	// Выполнение
+	_, err := service.ScheduleMessage(context.Background(), domain.ScheduledMessage{
+		ChatID: "chat1", AuthorID: "user1", Text: "later", SendAt: time.Now().Add(-time.Minute).Unix(),
+	})
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockScheduled.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestChatService_ScheduleMessage_Success(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockScheduled := &MockScheduledMessageRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithScheduled(mockScheduled))
+	sendAt := time.Now().Add(time.Hour).Unix()
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockScheduled.On("CountPending", "chat1", "user1").Return(int64(0), nil)
+	mockScheduled.On("Create", mock.MatchedBy(func(m domain.ScheduledMessage) bool {
+		return m.ID != "" && m.Status == domain.ScheduledPending && m.SendAt == sendAt
+	})).Return(nil)
+
+	// Выполнение
+	m, err := service.ScheduleMessage(context.Background(), domain.ScheduledMessage{
+		ChatID: "chat1", AuthorID: "user1", Text: "later", SendAt: sendAt,
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ScheduledPending, m.Status)
+	mockScheduled.AssertExpectations(t)
+}
+
+func TestChatService_CancelScheduledMessage_NotAuthor(t *testing.T) {
+	// Подготовка
+	mockScheduled := &MockScheduledMessageRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithScheduled(mockScheduled))
+
+	mockScheduled.On("Get", "sch1").Return(domain.ScheduledMessage{ID: "sch1", AuthorID: "user1", Status: domain.ScheduledPending}, nil)
+
+	// Выполнение
+	err := service.CancelScheduledMessage(context.Background(), "sch1", "user2")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockScheduled.AssertNotCalled(t, "Cancel", mock.Anything)
+}
+
+func TestChatService_DeliverDueScheduled_SendsMessage(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockScheduled := &MockScheduledMessageRepository{}
+	mockUserClient := &MockUserServiceClient{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, mockUserClient, WithScheduled(mockScheduled))
+
+	due := domain.ScheduledMessage{ID: "sch1", ChatID: "chat1", AuthorID: "user1", Text: "later", Status: domain.ScheduledSending, Attempts: 1}
+	notBlocked(mockUserClient)
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(due, true, nil).Once()
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(domain.ScheduledMessage{}, false, nil)
+	mockMsgRepo.On("Get", "sch1").Return(domain.Message{}, errors.New("not found"))
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(5), nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.ID == "sch1" && m.Seq == 5 && m.Text == "later"
+	})).Return(domain.Message{ID: "sch1", ChatID: "chat1", Seq: 5, AuthorID: "user1", Text: "later"}, nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockScheduled.On("MarkSent", "sch1", "sch1").Return(nil)
+
+	// Выполнение
+	n, err := service.DeliverDueScheduled(context.Background())
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	mockMsgRepo.AssertExpectations(t)
+	mockScheduled.AssertExpectations(t)
+	mockKafka.AssertCalled(t, "PublishNewMessage", mock.Anything, mock.Anything)
+}
+
+func TestChatService_DeliverDueScheduled_AlreadySent(t *testing.T) {
+	// Подготовка: реплика упала после отправки, захват истёк
+	mockMsgRepo := &MockMessageRepository{}
+	mockScheduled := &MockScheduledMessageRepository{}
+	service := NewChatService(&MockChatRepository{}, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithScheduled(mockScheduled))
+
+	due := domain.ScheduledMessage{ID: "sch1", ChatID: "chat1", AuthorID: "user1", Status: domain.ScheduledSending, Attempts: 2}
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(due, true, nil).Once()
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(domain.ScheduledMessage{}, false, nil)
+	mockMsgRepo.On("Get", "sch1").Return(domain.Message{ID: "sch1", ChatID: "chat1"}, nil)
+	mockScheduled.On("MarkSent", "sch1", "sch1").Return(nil)
+
+	// Выполнение
+	_, err := service.DeliverDueScheduled(context.Background())
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockScheduled.AssertExpectations(t)
+}
+
+func TestChatService_DeliverDueScheduled_AuthorLeft(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockScheduled := &MockScheduledMessageRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithScheduled(mockScheduled))
+
+	due := domain.ScheduledMessage{ID: "sch1", ChatID: "chat1", AuthorID: "user9", Status: domain.ScheduledSending, Attempts: 1}
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(due, true, nil).Once()
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(domain.ScheduledMessage{}, false, nil)
+	mockMsgRepo.On("Get", "sch1").Return(domain.Message{}, errors.New("not found"))
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindGroup), nil)
+	mockScheduled.On("MarkFailed", "sch1", mock.Anything).Return(nil)
+
+	// Выполнение
+	_, err := service.DeliverDueScheduled(context.Background())
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockScheduled.AssertExpectations(t)
+}
+
+func TestChatService_DeliverDueScheduled_Blocked(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockScheduled := &MockScheduledMessageRepository{}
+	mockUserClient := &MockUserServiceClient{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, mockUserClient,
+		WithScheduled(mockScheduled))
+
+	due := domain.ScheduledMessage{ID: "sch1", ChatID: "chat1", AuthorID: "user1", Status: domain.ScheduledSending, Attempts: 1}
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(due, true, nil).Once()
+	mockScheduled.On("ClaimDue", mock.Anything, scheduledLease).Return(domain.ScheduledMessage{}, false, nil)
+	mockMsgRepo.On("Get", "sch1").Return(domain.Message{}, errors.New("not found"))
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockUserClient.On("CheckBlocked", mock.Anything, mock.Anything, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{BlockedBy: []string{"user2"}}, nil)
+	// Повторять бессмысленно — запись сразу помечается неудачной
+	mockScheduled.On("MarkFailed", "sch1", mock.Anything).Return(nil)
+
+	// Выполнение
+	_, err := service.DeliverDueScheduled(context.Background())
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockScheduled.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_SetsExpiresAt(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+
+	chat := createTestChat("chat1", domain.ChatKindDirect)
+	chat.MessageTTL = 3600
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.ExpiresAt == m.CreatedAt+3600
+	})).Return(domain.Message{ID: "msg1", ChatID: "chat1", Seq: 1}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "secret"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_AdvancesUnreadCounters(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+	mockReads := &MockReadStateRepository{}
+	WithReadStates(mockReads)(service)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(8), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Seq: 8}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockReads.On("AdvanceChat", "chat1", int64(8)).Return(nil).Once()
+	mockReads.On("MarkRead", "chat1", "user1", int64(8)).Return(nil).Once()
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "привет"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockReads.AssertExpectations(t)
+}
+
+func TestChatService_CreateGroup_TracksUnreadForMembers(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockKafka, _ := createTestService()
+	mockReads := &MockReadStateRepository{}
+	WithReadStates(mockReads)(service)
+	group := domain.Chat{ID: "group1", Kind: domain.ChatKindGroup, MemberIDs: []string{"user1", "creator1"}, CreatedBy: "creator1"}
+
+	mockChatRepo.On("CreateGroup", mock.Anything, "creator1", []string{"user1"}, "Группа", mock.Anything).Return(group, nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil)
+	mockReads.On("Track", "group1", []string{"user1", "creator1"}, int64(0)).Return(nil).Once()
+
+	// Выполнение
+	_, err := service.CreateGroup(context.Background(), "creator1", []string{"user1"}, "Группа")
+
+	// Проверки
+	assert.NoError(t, err)
+	mockReads.AssertExpectations(t)
+}
+
+func TestChatService_SetMessageTTL_GroupMemberDenied(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+
+	// Выполнение
+	_, err := service.SetMessageTTL(context.Background(), "group1", "user2", 86400)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockChatRepo.AssertNotCalled(t, "SetMessageTTL", mock.Anything, mock.Anything)
+}
+
+func TestChatService_SetMessageTTL_TooShort(t *testing.T) {
+	service, _, _, _, _ := createTestService()
+
+	_, err := service.SetMessageTTL(context.Background(), "chat1", "user1", 10)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestChatService_PurgeExpired_DeletesAndNotifies(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockMentions := &MockMentionRepository{}
+	mockMedia := &MockMediaResolver{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, &MockUserServiceClient{},
+		WithMentions(mockMentions), WithMedia(mockMedia))
+
+	expired := []domain.Message{
+		{ID: "m1", ChatID: "chat1", Seq: 1, Media: []domain.Media{{ID: "file1"}}},
+		{ID: "m2", ChatID: "chat1", Seq: 2},
+	}
+	mockMsgRepo.On("ListExpired", mock.Anything, purgeBatchSize).Return(expired, nil)
+	mockMsgRepo.On("Purge", []string{"m1", "m2"}).Return(nil)
+	mockMentions.On("DeleteByMessages", []string{"m1", "m2"}).Return(nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.MatchedBy(func(p domain.MessagePreview) bool {
+		return p.ID == "m2" && p.Deleted
+	})).Return(nil)
+	chat := createTestChat("chat1", domain.ChatKindDirect)
+	chat.Pinned = []domain.PinnedMessage{{MessageID: "m1"}}
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	mockChatRepo.On("Unpin", "chat1", "m1").Return(chat, nil)
+
+	deleted := mock.MatchedBy(func(e domain.SearchEvent) bool {
+		evt, ok := e.Data.(domain.MessagesDeletedEvent)
+		return ok && e.Type == "message.deleted" && len(evt.MessageIDs) == 2 && evt.MediaIDs[0] == "file1"
+	})
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file1"}).Return([]string{}, nil)
+	mockMedia.On("DetachFromChat", "file1", "chat1").Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, deleted).Return(nil)
+
+	// Выполнение
+	n, err := service.PurgeExpired(context.Background())
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	mockMsgRepo.AssertExpectations(t)
+	mockChatRepo.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+}
+
+func TestChatService_PurgeExpired_EventInPurgeTransaction(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockOutbox := &MockOutboxRepository{}
+	tx := &fakeTransactor{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithOutbox(mockOutbox, tx))
+
+	mockMsgRepo.On("ListExpired", mock.Anything, purgeBatchSize).Return([]domain.Message{{ID: "m1", ChatID: "chat1", Seq: 1}}, nil)
+	mockMsgRepo.On("Purge", []string{"m1"}).Return(nil)
+	mockOutbox.On("Add", inTxCtx(), outboxEventOf(contracts.TypeMessagesDeleted, "chat1")).Return(errors.New("outbox unavailable"))
+
+	// Выполнение
+	n, err := service.PurgeExpired(context.Background())
+
+	// Проверки: без события стирание откатывается и повторится в следующем проходе
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+	assert.True(t, tx.rolledBack)
+	mockChatRepo.AssertNotCalled(t, "SetLastMessage", mock.Anything, mock.Anything)
+}
+
+func createTestPoll(multiple bool) domain.Message {
+	return domain.Message{
+		ID:       "poll1",
+		ChatID:   "chat1",
+		AuthorID: "user1",
+		Type:     domain.MessageTypePoll,
+		Poll: &domain.Poll{
+			Question:       "Когда встречаемся?",
+			Options:        []domain.PollOption{{ID: 0, Text: "Утром"}, {ID: 1, Text: "Вечером"}},
+			MultipleChoice: multiple,
+		},
+	}
+}
+
+func TestChatService_SendMessage_Poll(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Type == domain.MessageTypePoll && m.Text == "Когда встречаемся?" &&
+			m.Poll.Options[1].ID == 1
+	})).Return(domain.Message{ID: "poll1", ChatID: "chat1", Seq: 1}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID:   "chat1",
+		AuthorID: "user1",
+		Poll: &domain.Poll{
+			Question: " Когда встречаемся? ",
+			Options:  []domain.PollOption{{Text: "Утром"}, {Text: "Вечером"}},
+		},
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_PollDuplicateOptions(t *testing.T) {
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID:   "chat1",
+		AuthorID: "user1",
+		Poll: &domain.Poll{
+			Question: "Да или нет?",
+			Options:  []domain.PollOption{{Text: "Да"}, {Text: "Да"}},
+		},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestChatService_Vote_SingleChoiceRejectsSeveral(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	mockMsgRepo.On("Get", "poll1").Return(createTestPoll(false), nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	// Выполнение
+	_, err := service.Vote(context.Background(), "poll1", "user2", []int{0, 1})
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockMsgRepo.AssertNotCalled(t, "Vote", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_Vote_AlreadyVoted(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	mockMsgRepo.On("Get", "poll1").Return(createTestPoll(false), nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMsgRepo.On("Vote", mock.Anything, mock.Anything, mock.Anything).Return(domain.Message{}, domain.ErrAlreadyVoted)
+
+	// Выполнение
+	_, err := service.Vote(context.Background(), "poll1", "user2", []int{0})
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrAlreadyVoted)
+}
+
+func TestChatService_Vote_LogsUpdate(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockUpdates := createTestUpdatesService()
+
+	mockMsgRepo.On("Get", "poll1").Return(createTestPoll(true), nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	voted := createTestPoll(true)
+	voted.Poll.Options[0].Votes = 1
+	voted.Poll.Options[1].Votes = 1
+	voted.Poll.TotalVoters = 1
+	vote := domain.PollVote{MessageID: "poll1", UserID: "user2", OptionIDs: []int{0, 1}}
+	mockMsgRepo.On("Vote", mock.Anything, mock.MatchedBy(func(v domain.PollVote) bool {
+		return v.MessageID == "poll1" && v.UserID == "user2" && len(v.OptionIDs) == 2
+	}), mock.Anything).Return(voted, nil)
+	mockMsgRepo.On("GetVote", "poll1", "user2").Return(vote, nil)
+	mockMsgRepo.On("ListVotes", "poll1").Return([]domain.PollVote{vote}, nil)
+	// Кто проголосовал, в обновление не попадает
+	mockUpdates.On("Append", []string{"user1", "user2"}, mock.MatchedBy(func(u domain.UserUpdate) bool {
+		return u.Type == domain.UpdatePoll && u.ChatID == "chat1" && u.MessageIDs[0] == "poll1" && u.ActorID == ""
+	})).Return(nil)
+
+	// Выполнение
+	res, err := service.Vote(context.Background(), "poll1", "user2", []int{0, 1})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, res.MyOptionIDs)
+	assert.Equal(t, []string{"user2"}, res.Voters[1])
+	assert.Equal(t, int64(1), res.Poll.TotalVoters)
+	mockUpdates.AssertExpectations(t)
+}
+
+func TestChatService_Vote_ChannelPollLoggedForMembersOnly(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockSubs := &MockSubscriptionRepository{}
+	mockUpdates := &MockUpdateLogRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChannels(mockSubs), WithUpdateLog(mockUpdates))
+
+	poll := createTestPoll(false)
+	poll.ChatID = "channel1"
+	poll.AuthorID = "owner1"
+	poll.Poll.Anonymous = true
+	mockMsgRepo.On("Get", "poll1").Return(poll, nil)
+	mockChatRepo.On("Get", "channel1").Return(createTestChannel(), nil)
+	mockSubs.On("IsSubscribed", "channel1", "reader1").Return(true, nil)
+	mockMsgRepo.On("Vote", mock.Anything, mock.Anything, mock.Anything).Return(poll, nil)
+	mockMsgRepo.On("GetVote", "poll1", "reader1").Return(domain.PollVote{OptionIDs: []int{1}}, nil)
+	// Обновление пишется только владельцу и администраторам, подписчиков не перебираем
+	mockUpdates.On("Append", []string{"owner1", "admin1"}, mock.MatchedBy(func(u domain.UserUpdate) bool {
+		return u.Type == domain.UpdatePoll && u.ChatID == "channel1"
+	})).Return(nil)
+
+	// Выполнение
+	res, err := service.Vote(context.Background(), "poll1", "reader1", []int{1})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, res.MyOptionIDs)
+	assert.Nil(t, res.Voters)
+	mockUpdates.AssertExpectations(t)
+	mockSubs.AssertNotCalled(t, "ListSubscribers", mock.Anything, mock.Anything, mock.Anything)
+	mockMsgRepo.AssertNotCalled(t, "ListVotes", mock.Anything)
+}
+
+func TestChatService_RetractVote_NotVoted(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	mockMsgRepo.On("Get", "poll1").Return(createTestPoll(false), nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMsgRepo.On("RetractVote", mock.Anything, "poll1", "user2", mock.Anything).Return(domain.Message{}, domain.ErrNotVoted)
+
+	// Выполнение
+	_, err := service.RetractVote(context.Background(), "poll1", "user2")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrNotVoted)
+}
+
+func TestChatService_GetPollResults_AnonymousHidesVoters(t *testing.T) {
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	poll := createTestPoll(false)
+	poll.Poll.Anonymous = true
+	mockMsgRepo.On("Get", "poll1").Return(poll, nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMsgRepo.On("GetVote", "poll1", "user2").Return(domain.PollVote{MessageID: "poll1", UserID: "user2", OptionIDs: []int{1}}, nil)
+
+	res, err := service.GetPollResults(context.Background(), "poll1", "user2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, res.MyOptionIDs)
+	assert.Nil(t, res.Voters)
+	mockMsgRepo.AssertNotCalled(t, "ListVotes", mock.Anything)
+}
+
+func TestChatService_ClosePoll_NotAuthor(t *testing.T) {
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+
+	mockMsgRepo.On("Get", "poll1").Return(createTestPoll(false), nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	_, err := service.ClosePoll(context.Background(), "poll1", "user2")
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockMsgRepo.AssertNotCalled(t, "ClosePoll", mock.Anything, mock.Anything)
+}
+
+// MockViewRepository - мок для ViewRepository
+type MockViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockViewRepository) MarkViewed(ctx context.Context, messageID, userID string, window time.Duration) (bool, error) {
+	args := m.Called(messageID, userID, window)
+	return args.Bool(0), args.Error(1)
+}
+
+func createTestChannelService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockSubscriptionRepository, *MockViewRepository) {
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockSubs := &MockSubscriptionRepository{}
+	mockViews := &MockViewRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithChannels(mockSubs), WithViewDedup(mockViews))
+	return service, mockChatRepo, mockMsgRepo, mockSubs, mockViews
+}
+
+func createTestChannel() domain.Chat {
+	return domain.Chat{
+		ID:        "channel1",
+		Kind:      domain.ChatKindChannel,
+		MemberIDs: []string{"owner1", "admin1"},
+		CreatedBy: "owner1",
+		Handle:    "news",
+	}
+}
+
+func TestChatService_SendMessage_ChannelSubscriberDenied(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestChannelService()
+
+	mockChatRepo.On("Get", "channel1").Return(createTestChannel(), nil)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "channel1", AuthorID: "reader1", Text: "Привет"})
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestChatService_SendMessage_NonMemberDenied(t *testing.T) {
+	for _, kind := range []domain.ChatKind{domain.ChatKindDirect, domain.ChatKindGroup} {
+		t.Run(string(kind), func(t *testing.T) {
+			// Подготовка
+			service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+			mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", kind), nil)
+
+			// Выполнение
+			_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "stranger", Text: "Привет"})
+
+			// Проверки
+			assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+			mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestChatService_SendMessage_SystemSkipsPostCheck(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestService()
+
+	// Системное сообщение пишется от имени того, кто совершил действие, даже если в чате его уже нет
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindGroup), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(5), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Type == domain.MessageTypeSystem && m.AuthorID == "user3"
+	})).Return(domain.Message{ID: "sys1", ChatID: "chat1", Seq: 5}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID:   "chat1",
+		AuthorID: "user3",
+		Type:     domain.MessageTypeSystem,
+		Text:     "Закреплено сообщение",
+		System:   &domain.SystemEvent{Action: domain.SystemActionPin, ActorID: "user3"},
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_CreateChannel_InvalidHandle(t *testing.T) {
+	service, mockChatRepo, _, _, _ := createTestChannelService()
+
+	_, err := service.CreateChannel(context.Background(), "owner1", "Новости", "@x")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockChatRepo.AssertNotCalled(t, "CreateChannel", mock.Anything)
+}
+
+func TestChatService_SubscribeChannel_CountsOnce(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockSubs, _ := createTestChannelService()
+
+	mockChatRepo.On("Get", "channel1").Return(createTestChannel(), nil)
+	mockSubs.On("Subscribe", "channel1", "reader1", mock.Anything).Return(true, nil).Once()
+	mockSubs.On("Subscribe", "channel1", "reader1", mock.Anything).Return(false, nil).Once()
+	mockChatRepo.On("IncSubscribers", "channel1", int64(1)).Return(nil).Once()
+
+	// Выполнение
+	first, err1 := service.SubscribeChannel(context.Background(), "channel1", "reader1")
+	_, err2 := service.SubscribeChannel(context.Background(), "channel1", "reader1")
+
+	// Проверки
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int64(1), first.SubscriberCount)
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_SubscribeChannel_NotChannel(t *testing.T) {
+	service, mockChatRepo, _, mockSubs, _ := createTestChannelService()
+
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+
+	_, err := service.SubscribeChannel(context.Background(), "group1", "reader1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockSubs.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_SetChannelAdmin_RequiresSubscriber(t *testing.T) {
+	service, mockChatRepo, _, mockSubs, _ := createTestChannelService()
+
+	mockChatRepo.On("Get", "channel1").Return(createTestChannel(), nil)
+	mockSubs.On("IsSubscribed", "channel1", "stranger").Return(false, nil)
+
+	_, err := service.SetChannelAdmin(context.Background(), "channel1", "owner1", "stranger", true)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockChatRepo.AssertNotCalled(t, "SetChannelAdmin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_ViewMessages_SkipsRepeatedViews(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockSubs, mockViews := createTestChannelService()
+
+	mockChatRepo.On("Get", "channel1").Return(createTestChannel(), nil)
+	mockSubs.On("IsSubscribed", "channel1", "reader1").Return(true, nil)
+	mockViews.On("MarkViewed", "post1", "reader1", viewDedupWindow).Return(true, nil)
+	mockViews.On("MarkViewed", "post2", "reader1", viewDedupWindow).Return(false, nil)
+	mockMsgRepo.On("IncViews", "channel1", []string{"post1"}).Return(nil)
+
+	// Выполнение
+	err := service.ViewMessages(context.Background(), "channel1", "reader1", []string{"post1", "post2"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ViewMessages_NotSubscribed(t *testing.T) {
+	service, mockChatRepo, mockMsgRepo, mockSubs, _ := createTestChannelService()
+
+	mockChatRepo.On("Get", "channel1").Return(createTestChannel(), nil)
+	mockSubs.On("IsSubscribed", "channel1", "reader1").Return(false, nil)
+
+	err := service.ViewMessages(context.Background(), "channel1", "reader1", []string{"post1"})
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockMsgRepo.AssertNotCalled(t, "IncViews", mock.Anything, mock.Anything)
+}
+
+func createTestInviteService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockInviteRepository, *MockKafkaProducer, *MockUserServiceClient) {
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockInvites := &MockInviteRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockUserClient := &MockUserServiceClient{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, mockUserClient, WithInvites(mockInvites))
+	return service, mockChatRepo, mockMsgRepo, mockInvites, mockKafka, mockUserClient
+}
+
+func TestChatService_CreateInviteLink_ApprovalWithLimit(t *testing.T) {
+	service, mockChatRepo, _, mockInvites, _, _ := createTestInviteService()
+
+	_, err := service.CreateInviteLink(context.Background(), "group1", "user1", domain.InviteSettings{
+		UsageLimit:       10,
+		RequiresApproval: true,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockChatRepo.AssertNotCalled(t, "Get", mock.Anything)
+	mockInvites.AssertNotCalled(t, "CreateLink", mock.Anything)
+}
+
+func TestChatService_CreateInviteLink_MemberDenied(t *testing.T) {
+	service, mockChatRepo, _, mockInvites, _, _ := createTestInviteService()
+
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+
+	_, err := service.CreateInviteLink(context.Background(), "group1", "user2", domain.InviteSettings{})
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockInvites.AssertNotCalled(t, "CreateLink", mock.Anything)
+}
+
+func TestChatService_JoinByInvite_Joins(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockInvites, mockKafka, mockUserClient := createTestInviteService()
+
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1"}, nil)
+	mockInvites.On("UseLink", "code1", mock.Anything).Return(domain.InviteLink{Code: "code1", ChatID: "group1", UsageCount: 1}, nil)
+	group := createTestChat("group1", domain.ChatKindGroup)
+	mockChatRepo.On("Get", "group1").Return(group, nil)
+	mockUserClient.On("AboutMeUser", mock.Anything, &userpb.AboutMeRequest{Uuid: "user3"}, mock.Anything).
+		Return(&userpb.UserResponse{Uuid: "user3"}, nil)
+	notBlocked(mockUserClient)
+	joined := group
+	joined.MemberIDs = []string{"user1", "user2", "user3"}
+	mockChatRepo.On("AddMember", mock.Anything, "group1", "user3").Return(joined, true, nil)
+
+	// Системное сообщение
+	mockChatRepo.On("NextSeq", "group1").Return(int64(5), nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Type == domain.MessageTypeSystem && m.System.Action == domain.SystemActionJoin && m.System.UserID == "user3"
+	})).Return(domain.Message{ID: "sys1", ChatID: "group1", Seq: 5}, nil)
+	mockChatRepo.On("SetLastMessage", "group1", mock.Anything).Return(nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e domain.SearchEvent) bool {
+		evt, ok := e.Data.(domain.MembershipEvent)
+		return ok && e.Type == "chat.membership" && evt.UserID == "user3" && evt.Via == "invite"
+	})).Return(nil).Once()
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	chat, req, err := service.JoinByInvite(context.Background(), "code1", "user3")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Nil(t, req)
+	assert.Contains(t, chat.MemberIDs, "user3")
+	mockMsgRepo.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+}
+
+func TestChatService_JoinByInvite_RaceReleasesUsage(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockInvites, _, mockUserClient := createTestInviteService()
+
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1", UsageLimit: 1}, nil)
+	mockInvites.On("UseLink", "code1", mock.Anything).Return(domain.InviteLink{Code: "code1", UsageCount: 1}, nil)
+	mockInvites.On("ReleaseLink", "code1").Return(nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockUserClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.UserResponse{}, nil)
+	notBlocked(mockUserClient)
+	// Пользователь успел вступить параллельным запросом
+	mockChatRepo.On("AddMember", mock.Anything, "group1", "user3").Return(domain.Chat{}, false, nil)
+
+	// Выполнение
+	_, _, err := service.JoinByInvite(context.Background(), "code1", "user3")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrAlreadyMember)
+	mockInvites.AssertExpectations(t)
+}
+
+func TestChatService_JoinByInvite_Expired(t *testing.T) {
+	service, mockChatRepo, _, mockInvites, _, _ := createTestInviteService()
+
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1", ExpiresAt: 1}, nil)
+
+	_, _, err := service.JoinByInvite(context.Background(), "code1", "user3")
+
+	assert.ErrorIs(t, err, domain.ErrInviteUnusable)
+	mockChatRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_JoinByInvite_ApprovalCreatesRequest(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockInvites, _, mockUserClient := createTestInviteService()
+
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1", RequiresApproval: true}, nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockUserClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.UserResponse{}, nil)
+	notBlocked(mockUserClient)
+	mockInvites.On("CreateRequest", mock.MatchedBy(func(r domain.JoinRequest) bool {
+		return r.ChatID == "group1" && r.UserID == "user3" && r.Status == domain.JoinRequestPending
+	})).Return(domain.JoinRequest{ID: "req1", ChatID: "group1", UserID: "user3", Status: domain.JoinRequestPending}, nil)
+
+	// Выполнение
+	_, req, err := service.JoinByInvite(context.Background(), "code1", "user3")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "req1", req.ID)
+	mockInvites.AssertNotCalled(t, "UseLink", mock.Anything, mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_JoinByInvite_BlockedByOwner(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockInvites, _, mockUserClient := createTestInviteService()
+
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1", CreatedBy: "user2"}, nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockUserClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.UserResponse{}, nil)
+	// Проверяются и автор ссылки, и владелец группы
+	mockUserClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "user3", UserUuids: []string{"user2", "user1"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{BlockedBy: []string{"user1"}}, nil)
+
+	// Выполнение
+	_, _, err := service.JoinByInvite(context.Background(), "code1", "user3")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockInvites.AssertNotCalled(t, "UseLink", mock.Anything, mock.Anything)
+	mockInvites.AssertNotCalled(t, "CreateRequest", mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_ApproveJoinRequest_RevokedLink(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockInvites, _, _ := createTestInviteService()
+
+	mockInvites.On("GetRequest", "req1").Return(domain.JoinRequest{ID: "req1", ChatID: "group1", UserID: "user3", InviteCode: "code1"}, nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1", RequiresApproval: true, Revoked: true}, nil)
+
+	// Выполнение
+	_, err := service.ApproveJoinRequest(context.Background(), "req1", "user1")
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrInviteUnusable)
+	mockInvites.AssertNotCalled(t, "DecideRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_RejectJoinRequest_RevokedLink(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockInvites, _, _ := createTestInviteService()
+
+	mockInvites.On("GetRequest", "req1").Return(domain.JoinRequest{ID: "req1", ChatID: "group1", UserID: "user3", InviteCode: "code1"}, nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockInvites.On("DecideRequest", "req1", domain.JoinRequestRejected, "user1", mock.Anything).
+		Return(domain.JoinRequest{ID: "req1", Status: domain.JoinRequestRejected}, nil)
+
+	// Выполнение: заявку по отозванной ссылке можно отклонить
+	err := service.RejectJoinRequest(context.Background(), "req1", "user1")
+
+	// Проверки
+	assert.NoError(t, err)
+	mockInvites.AssertNotCalled(t, "GetLink", mock.Anything)
+}
+
+func TestChatService_ApproveJoinRequest_JoinFailureKeepsRequestPending(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockInvites := &MockInviteRepository{}
+	mockUserClient := &MockUserServiceClient{}
+	tx := &fakeTransactor{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, mockUserClient,
+		WithInvites(mockInvites), WithOutbox(&MockOutboxRepository{}, tx))
+	notBlocked(mockUserClient)
+
+	mockInvites.On("GetRequest", "req1").Return(domain.JoinRequest{ID: "req1", ChatID: "group1", UserID: "user3", InviteCode: "code1"}, nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockInvites.On("GetLink", "code1").Return(domain.InviteLink{Code: "code1", ChatID: "group1", RequiresApproval: true}, nil)
+	mockInvites.On("DecideRequest", "req1", domain.JoinRequestApproved, "user1", mock.Anything).
+		Return(domain.JoinRequest{ID: "req1", Status: domain.JoinRequestApproved}, nil)
+	mockChatRepo.On("AddMember", inTxCtx(), "group1", "user3").Return(domain.Chat{}, false, errors.New("mongo unavailable"))
+
+	// Выполнение
+	_, err := service.ApproveJoinRequest(context.Background(), "req1", "user1")
+
+	// Проверки: решение пишется в транзакции вступления и откатывается вместе с ним
+	assert.Error(t, err)
+	assert.True(t, tx.rolledBack)
+	mockInvites.AssertExpectations(t)
+}
+
+func TestChatService_ApproveJoinRequest_MemberDenied(t *testing.T) {
+	service, mockChatRepo, _, mockInvites, _, _ := createTestInviteService()
+
+	mockInvites.On("GetRequest", "req1").Return(domain.JoinRequest{ID: "req1", ChatID: "group1", UserID: "user3"}, nil)
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+
+	_, err := service.ApproveJoinRequest(context.Background(), "req1", "user2")
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockInvites.AssertNotCalled(t, "DecideRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_SetTyping_FansOutToMembers(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockTyping := &MockTypingRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithTyping(mockTyping))
+	ctx := context.Background()
+
+	chat := createTestChat("group-1", domain.ChatKindGroup)
+	chat.MemberIDs = []string{"user1", "user2", "user3"}
+	mockChatRepo.On("Get", "group-1").Return(chat, nil)
+	mockTyping.On("Allow", ctx, "typing:group-1:user1:typing", typingThrottle).Return(true, nil)
+	mockTyping.On("SetTyping", ctx, "group-1", "user1", domain.TypingActionTyping, typingTTL).Return(nil)
+	mockTyping.On("PublishTyping", ctx, []string{"user2", "user3"}, mock.MatchedBy(func(st domain.TypingStatus) bool {
+		return st.ChatID == "group-1" && st.UserID == "user1" && st.Action == domain.TypingActionTyping && st.ExpiresAt > 0
+	})).Return(nil)
+
+	// Выполнение
+	throttled, err := service.SetTyping(ctx, "group-1", "user1", domain.TypingActionTyping)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.False(t, throttled)
+	mockTyping.AssertExpectations(t)
+}
+
+func TestChatService_SetTyping_Throttled(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockTyping := &MockTypingRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithTyping(mockTyping))
+	ctx := context.Background()
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockTyping.On("Allow", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	// Выполнение
+	throttled, err := service.SetTyping(ctx, "chat1", "user1", domain.TypingActionTyping)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.True(t, throttled)
+	mockTyping.AssertNotCalled(t, "SetTyping", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockTyping.AssertNotCalled(t, "PublishTyping", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_SetTyping_NotMember(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, _ := createTestService()
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+
+	// Выполнение
+	_, err := service.SetTyping(context.Background(), "chat1", "stranger", domain.TypingActionTyping)
+
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestChatService_SetTyping_InvalidAction(t *testing.T) {
+	service, _, _, _, _ := createTestService()
+
+	_, err := service.SetTyping(context.Background(), "chat1", "user1", domain.TypingAction("dancing"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestChatService_SaveDraft_LogsToOwner(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockDrafts := &MockDraftRepository{}
+	mockUpdates := &MockUpdateLogRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithDrafts(mockDrafts), WithUpdateLog(mockUpdates))
+
+	draft := domain.Draft{
+		ChatID:    "chat1",
+		UserID:    "user1",
+		Text:      "Черновик",
+		ReplyToID: "msg1",
+		Media:     []domain.Media{{ID: "media1"}},
+		UpdatedAt: 1, // время клиента не учитывается
+		DeviceID:  "laptop",
+	}
+	stored := draft
+	stored.Version = 3
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "chat1", "user2", "Вопрос"), nil)
+	mockDrafts.On("Save", mock.MatchedBy(func(d domain.Draft) bool {
+		return d.Text == "Черновик" && d.DeviceID == "laptop" && d.UpdatedAt > 1
+	}), int64(2)).Return(stored, true, nil)
+	mockUpdates.On("Append", []string{"user1"}, mock.MatchedBy(func(u domain.UserUpdate) bool {
+		return u.Type == domain.UpdateDraft && u.ChatID == "chat1" && u.Draft != nil &&
+			u.Draft.Version == 3 && u.Draft.DeviceID == "laptop"
+	})).Return(nil)
+
+	// Выполнение
+	saved, applied, err := service.SaveDraft(context.Background(), draft, 2)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, int64(3), saved.Version)
+	mockUpdates.AssertExpectations(t)
+}
+
+func TestChatService_SaveDraft_StaleReturnsCurrent(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockDrafts := &MockDraftRepository{}
+	mockUpdates := &MockUpdateLogRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithDrafts(mockDrafts), WithUpdateLog(mockUpdates))
+
+	current := domain.Draft{ChatID: "chat1", UserID: "user1", Text: "новее", UpdatedAt: 2000, DeviceID: "phone", Version: 5}
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockDrafts.On("Save", mock.Anything, int64(4)).Return(domain.Draft{}, false, nil)
+	mockDrafts.On("Get", "chat1", "user1").Return(current, nil)
+
+	// Выполнение
+	got, applied, err := service.SaveDraft(context.Background(), domain.Draft{ChatID: "chat1", UserID: "user1", Text: "старее"}, 4)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.False(t, applied)
+	assert.Equal(t, current, got)
+	mockUpdates.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
+
+func TestChatService_SaveDraft_ReplyToOtherChat(t *testing.T) {
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockDrafts := &MockDraftRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithDrafts(mockDrafts))
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMsgRepo.On("Get", "msg9").Return(createTestMessage("msg9", "chat9", "user2", "Чужое"), nil)
+
+	_, _, err := service.SaveDraft(context.Background(), domain.Draft{ChatID: "chat1", UserID: "user1", ReplyToID: "msg9"}, 0)
+
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	mockDrafts.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestChatService_ClearDraft_NegativeVersion(t *testing.T) {
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithDrafts(&MockDraftRepository{}))
+
+	_, _, err := service.ClearDraft(context.Background(), "chat1", "user1", -1, "laptop")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestChatService_SendMessage_ClearsDraft(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockUserClient := &MockUserServiceClient{}
+	mockDrafts := &MockDraftRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, mockUserClient, WithDrafts(mockDrafts))
+	notBlocked(mockUserClient)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Seq: 1}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockDrafts.On("Overwrite", mock.MatchedBy(func(d domain.Draft) bool {
+		return d.ChatID == "chat1" && d.UserID == "user1" && d.IsEmpty() && d.UpdatedAt > 0
+	})).Return(domain.Draft{ChatID: "chat1", UserID: "user1", Version: 2}, nil)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "Отправлено"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockDrafts.AssertExpectations(t)
+}
+
+// createTestUpdatesService создает сервис с журналом обновлений
+func createTestUpdatesService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockUpdateLogRepository) {
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockUpdates := &MockUpdateLogRepository{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithUpdateLog(mockUpdates))
+	return service, mockChatRepo, mockMsgRepo, mockUpdates
+}
+
+func TestChatService_GetUpdates_ReturnsDiffWithMessages(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, mockUpdates := createTestUpdatesService()
+	now := time.Now().Unix()
+
+	mockUpdates.On("CurrentSeq", "user1").Return(int64(12), nil)
+	mockUpdates.On("OldestSeq", "user1").Return(int64(1), true, nil)
+	mockUpdates.On("List", "user1", int64(10), defaultUpdatesLimit).Return([]domain.UserUpdate{
+		{Seq: 11, Type: domain.UpdateMessageNew, ChatID: "chat1", MessageIDs: []string{"msg1"}, CreatedAt: now},
+		{Seq: 12, Type: domain.UpdateMessageDeleted, ChatID: "chat1", MessageIDs: []string{"msg0"}, CreatedAt: now},
+	}, nil)
+	mockMsgRepo.On("GetMany", []string{"msg1"}).Return([]domain.Message{createTestMessage("msg1", "chat1", "user2", "Привет")}, nil)
+
+	// Выполнение
+	page, err := service.GetUpdates(context.Background(), "user1", 10, 0)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.False(t, page.Resync)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, int64(12), page.Seq)
+	assert.Len(t, page.Updates, 2)
+	assert.Equal(t, "Привет", page.Updates[0].Messages[0].Text)
+	assert.Empty(t, page.Updates[1].Messages)
+}
+
+func TestChatService_GetUpdates_TooOld(t *testing.T) {
+	service, _, _, mockUpdates := createTestUpdatesService()
+
+	mockUpdates.On("CurrentSeq", "user1").Return(int64(500), nil)
+	mockUpdates.On("OldestSeq", "user1").Return(int64(200), true, nil)
+
+	page, err := service.GetUpdates(context.Background(), "user1", 100, 50)
+
+	assert.NoError(t, err)
+	assert.True(t, page.Resync)
+	assert.Equal(t, int64(500), page.Seq)
+	mockUpdates.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_GetUpdates_FromFuture(t *testing.T) {
+	service, _, _, mockUpdates := createTestUpdatesService()
+
+	mockUpdates.On("CurrentSeq", "user1").Return(int64(5), nil)
+
+	page, err := service.GetUpdates(context.Background(), "user1", 9, 0)
+
+	assert.NoError(t, err)
+	assert.True(t, page.Resync)
+	assert.Equal(t, int64(5), page.Seq)
+}
+
+func TestChatService_GetUpdates_StopsAtFreshGap(t *testing.T) {
+	// Подготовка
+	service, _, _, mockUpdates := createTestUpdatesService()
+	now := time.Now().Unix()
+
+	// seq 12 ещё вставляется: отдаём только 11, клиент дозапросит с него
+	mockUpdates.On("CurrentSeq", "user1").Return(int64(13), nil)
+	mockUpdates.On("OldestSeq", "user1").Return(int64(1), true, nil)
+	mockUpdates.On("List", "user1", int64(10), defaultUpdatesLimit).Return([]domain.UserUpdate{
+		{Seq: 11, Type: domain.UpdateRead, ChatID: "chat1", ReadSeq: 3, CreatedAt: now},
+		{Seq: 13, Type: domain.UpdateRead, ChatID: "chat1", ReadSeq: 4, CreatedAt: now},
+	}, nil)
+
+	// Выполнение
+	page, err := service.GetUpdates(context.Background(), "user1", 10, 0)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, page.Updates, 1)
+	assert.Equal(t, int64(11), page.Seq)
+	assert.True(t, page.HasMore)
+}
+
+func TestChatService_GetUpdates_SkipsStaleGap(t *testing.T) {
+	service, _, _, mockUpdates := createTestUpdatesService()
+	old := time.Now().Add(-time.Hour).Unix()
+
+	// Запись seq 12 не удалась час назад — пропуск не должен останавливать клиента навсегда
+	mockUpdates.On("CurrentSeq", "user1").Return(int64(13), nil)
+	mockUpdates.On("OldestSeq", "user1").Return(int64(1), true, nil)
+	mockUpdates.On("List", "user1", int64(10), defaultUpdatesLimit).Return([]domain.UserUpdate{
+		{Seq: 11, Type: domain.UpdateRead, ChatID: "chat1", CreatedAt: old},
+		{Seq: 13, Type: domain.UpdateRead, ChatID: "chat1", CreatedAt: old},
+	}, nil)
+
+	page, err := service.GetUpdates(context.Background(), "user1", 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Updates, 2)
+	assert.Equal(t, int64(13), page.Seq)
+	assert.False(t, page.HasMore)
+}
+
+func TestChatService_UpdateGroupChat_LogsRemovedMembers(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockUpdates := createTestUpdatesService()
+
+	chat := createTestChat("group1", domain.ChatKindGroup)
+	mockChatRepo.On("UpdateGroup", "group1", (*string)(nil), []string(nil), []string{"user3"}, "user1", mock.Anything).
+		Return(chat, []string(nil), []string{"user3"}, nil)
+	// Удалённый участник тоже должен узнать, что его исключили
+	mockUpdates.On("Append", []string{"user1", "user2", "user3"}, mock.MatchedBy(func(u domain.UserUpdate) bool {
+		return u.Type == domain.UpdateMembership && u.Action == domain.MembershipRemoved && u.ActorID == "user1"
+	})).Return(nil)
+
+	// Выполнение
+	_, err := service.UpdateGroupChat(context.Background(), &chatpb.UpdateGroupChatRequest{
+		ChatId:          "group1",
+		RemoveMemberIds: []string{"user3"},
+		RequesterId:     "user1",
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockUpdates.AssertExpectations(t)
+}
+
+func TestChatService_UpdateGroupChat_LogsOnlyActualChanges(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, mockUpdates := createTestUpdatesService()
+
+	// user2 уже в чате, user9 в чате не было: репозиторий ничего не изменил
+	chat := createTestChat("group1", domain.ChatKindGroup)
+	mockChatRepo.On("UpdateGroup", "group1", (*string)(nil), []string{"user2"}, []string{"user9"}, "user1", mock.Anything).
+		Return(chat, []string(nil), []string(nil), nil)
+
+	// Выполнение
+	_, err := service.UpdateGroupChat(context.Background(), &chatpb.UpdateGroupChatRequest{
+		ChatId:          "group1",
+		AddMemberIds:    []string{"user2"},
+		RemoveMemberIds: []string{"user9"},
+		RequesterId:     "user1",
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockUpdates.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
+
+func TestChatService_SendMessage_LogFailureRollsBack(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockUserClient := &MockUserServiceClient{}
+	mockUpdates := &MockUpdateLogRepository{}
+	mockOutbox := &MockOutboxRepository{}
+	tx := &fakeTransactor{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, mockUserClient,
+		WithUpdateLog(mockUpdates), WithOutbox(mockOutbox, tx))
+	notBlocked(mockUserClient)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Seq: 1}, nil)
+	mockOutbox.On("Add", mock.Anything, mock.Anything).Return(nil)
+	mockUpdates.On("Append", []string{"user1", "user2"}, mock.Anything).Return(errors.New("update log unavailable"))
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "Привет"})
+
+	// Проверки: журнал пишется в транзакции сообщения, поэтому его сбой отменяет отправку
+	assert.Error(t, err)
+	assert.True(t, tx.rolledBack)
+	mockChatRepo.AssertNotCalled(t, "SetLastMessage", mock.Anything, mock.Anything)
+}
+
+func TestChatService_InTx_WakesStreamsAfterCommit(t *testing.T) {
+	// Подготовка
+	mockUpdates := &MockUpdateLogRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithUpdateLog(mockUpdates), WithOutbox(&MockOutboxRepository{}, &fakeTransactor{}))
+	mockUpdates.On("Append", []string{"user1"}, mock.Anything).Return(nil)
+	signals, cancel := service.streams.subscribe("user1")
+	defer cancel()
+
+	// Выполнение
+	wokenInside := false
+	err := service.inTx(context.Background(), func(ctx context.Context) error {
+		if err := service.logFolder(ctx, "user1", domain.FolderCreated, "f1"); err != nil {
+			return err
+		}
+		select {
+		case <-signals:
+			wokenInside = true
+		default:
+		}
+		return nil
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.False(t, wokenInside)
+	assert.Len(t, signals, 1)
+}
+
+func TestChatService_MarkRead_LogsToMembers(t *testing.T) {
+	service, mockChatRepo, mockMsgRepo, mockUpdates := createTestUpdatesService()
+
+	msg := createTestMessage("msg1", "chat1", "user1", "Привет")
+	msg.Seq = 7
+	mockMsgRepo.On("Get", "msg1").Return(msg, nil)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockUpdates.On("Append", []string{"user1", "user2"}, mock.MatchedBy(func(u domain.UserUpdate) bool {
+		return u.Type == domain.UpdateRead && u.ActorID == "user2" && u.ReadSeq == 7
+	})).Return(nil)
+
+	err := service.MarkRead(context.Background(), "chat1", "user2", "msg1")
+
+	assert.NoError(t, err)
+	mockUpdates.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_OutboxInTransaction(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockKafka := &MockKafkaProducer{}
+	mockUserClient := &MockUserServiceClient{}
+	mockOutbox := &MockOutboxRepository{}
+	tx := &fakeTransactor{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, mockKafka, mockUserClient, WithOutbox(mockOutbox, tx))
+	notBlocked(mockUserClient)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", inTxCtx(), mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Seq: 1}, nil)
+	mockOutbox.On("Add", inTxCtx(), outboxEventOf(contracts.TypeMessageSent, "chat1")).Return(nil).Once()
+	mockOutbox.On("Add", inTxCtx(), outboxEventOf(contracts.TypeMessageUpserted, "chat1")).Return(nil).Once()
+
+	// Выполнение
+	msg, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "Привет"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "msg1", msg.ID)
+	assert.False(t, tx.rolledBack)
+	mockOutbox.AssertExpectations(t)
+	mockKafka.AssertNotCalled(t, "PublishNewMessage", mock.Anything, mock.Anything)
+	mockKafka.AssertNotCalled(t, "PublishEvent", mock.Anything, mock.Anything)
+}
+
+func TestChatService_SendMessage_OutboxFailureRollsBack(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockMsgRepo := &MockMessageRepository{}
+	mockUserClient := &MockUserServiceClient{}
+	mockOutbox := &MockOutboxRepository{}
+	tx := &fakeTransactor{}
+	service := NewChatService(mockChatRepo, mockMsgRepo, &MockKafkaProducer{}, mockUserClient, WithOutbox(mockOutbox, tx))
+	notBlocked(mockUserClient)
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockMsgRepo.On("Send", inTxCtx(), mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "chat1", AuthorID: "user1", Seq: 1}, nil)
+	mockOutbox.On("Add", mock.Anything, mock.Anything).Return(errors.New("write conflict"))
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "chat1", AuthorID: "user1", Text: "Привет"})
+
+	// Проверки
+	assert.Error(t, err)
+	assert.True(t, tx.rolledBack)
+	mockChatRepo.AssertNotCalled(t, "SetLastMessage", mock.Anything, mock.Anything)
+}
+
+func TestChatService_CreateGroup_OutboxInTransaction(t *testing.T) {
+	// Подготовка
+	mockChatRepo := &MockChatRepository{}
+	mockOutbox := &MockOutboxRepository{}
+	service := NewChatService(mockChatRepo, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithOutbox(mockOutbox, &fakeTransactor{}))
+	group := domain.Chat{ID: "group1", Kind: domain.ChatKindGroup, MemberIDs: []string{"user1", "creator1"}, CreatedBy: "creator1"}
+
+	mockChatRepo.On("CreateGroup", inTxCtx(), "creator1", []string{"user1"}, "Группа", mock.Anything).Return(group, nil).Once()
+	mockOutbox.On("Add", inTxCtx(), outboxEventOf(contracts.TypeChatUpserted, "group1")).Return(nil).Once()
+
+	// Выполнение
+	chat, err := service.CreateGroup(context.Background(), "creator1", []string{"user1"}, "Группа")
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "group1", chat.ID)
+	mockChatRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestChatService_RelayOutbox_PublishesAndMarksSent(t *testing.T) {
+	// Подготовка
+	mockKafka := &MockKafkaProducer{}
+	mockOutbox := &MockOutboxRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, mockKafka, &MockUserServiceClient{},
+		WithOutbox(mockOutbox, &fakeTransactor{}))
+	ev := domain.OutboxEvent{ID: "ev1", Key: "msg1", Payload: []byte(`{"message_id":"msg1"}`), Attempts: 1}
+
+	mockOutbox.On("ClaimDue", mock.Anything, outboxLease).Return(ev, true, nil).Once()
+	mockOutbox.On("ClaimDue", mock.Anything, outboxLease).Return(domain.OutboxEvent{}, false, nil).Once()
+	mockKafka.On("Publish", mock.Anything, "msg1", ev.Payload).Return(nil)
+	mockOutbox.On("MarkSent", "ev1").Return(nil)
+
+	// Выполнение
+	sent, err := service.RelayOutbox(context.Background())
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	mockOutbox.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+}
+
+func TestChatService_RelayOutbox_BrokerDownSchedulesRetry(t *testing.T) {
+	// Подготовка
+	mockKafka := &MockKafkaProducer{}
+	mockOutbox := &MockOutboxRepository{}
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, mockKafka, &MockUserServiceClient{},
+		WithOutbox(mockOutbox, &fakeTransactor{}))
+	ev := domain.OutboxEvent{ID: "ev1", Key: "chat", Payload: []byte(`{}`), Attempts: 3}
+	before := time.Now().Unix()
+
+	mockOutbox.On("ClaimDue", mock.Anything, outboxLease).Return(ev, true, nil).Once()
+	mockKafka.On("Publish", mock.Anything, "chat", ev.Payload).Return(errors.New("broker down"))
+	mockOutbox.On("MarkRetry", "ev1", mock.MatchedBy(func(next int64) bool {
+		return next >= before+4 && next <= time.Now().Unix()+4
+	}), "broker down").Return(nil)
+
+	// Выполнение
+	sent, err := service.RelayOutbox(context.Background())
+
+	// Проверки
+	assert.Error(t, err)
+	assert.Equal(t, 0, sent)
+	mockOutbox.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "MarkSent", mock.Anything)
+}
+
+func TestOutboxBackoff_Capped(t *testing.T) {
+	assert.Equal(t, time.Second, outboxBackoff(1))
+	assert.Equal(t, 8*time.Second, outboxBackoff(4))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(40))
+}
+
+// memUpdateLog — журнал обновлений в памяти: потокам SubscribeChats нужен настоящий порядок seq
+type memUpdateLog struct {
+	mu    sync.Mutex
+	seqs  map[string]int64
+	items map[string][]domain.UserUpdate
+}
+
+func newMemUpdateLog() *memUpdateLog {
+	return &memUpdateLog{seqs: map[string]int64{}, items: map[string][]domain.UserUpdate{}}
+}
+
+func (l *memUpdateLog) Append(ctx context.Context, userIDs []string, u domain.UserUpdate) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range userIDs {
+		l.seqs[id]++
+		e := u
+		e.UserID, e.Seq = id, l.seqs[id]
+		l.items[id] = append(l.items[id], e)
+	}
+	return nil
+}
+
+func (l *memUpdateLog) List(userID string, afterSeq int64, limit int) ([]domain.UserUpdate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []domain.UserUpdate
+	for _, u := range l.items[userID] {
+		if u.Seq > afterSeq && len(out) < limit {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (l *memUpdateLog) CurrentSeq(userID string) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.seqs[userID], nil
+}
+
+func (l *memUpdateLog) OldestSeq(userID string) (int64, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.items[userID]) == 0 {
+		return 0, false, nil
+	}
+	return l.items[userID][0].Seq, true, nil
+}
+
+func (l *memUpdateLog) DeleteBefore(ts int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id, list := range l.items {
+		kept := list[:0]
+		for _, u := range list {
+			if u.CreatedAt >= ts {
+				kept = append(kept, u)
+			}
+		}
+		l.items[id] = kept
+	}
+	return nil
+}
+
+// startSubscription запускает SubscribeChats в горутине; события приходят в канал,
+// результат — после отмены ctx
+func startSubscription(service *ChatService, userID, token string) (<-chan domain.StreamEvent, context.CancelFunc, <-chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan domain.StreamEvent, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- service.SubscribeChats(ctx, userID, token, func(e domain.StreamEvent) error {
+			events <- e
+			return nil
+		})
+	}()
+	return events, cancel, done
+}
+
+func nextStreamEvent(t *testing.T, events <-chan domain.StreamEvent) domain.StreamEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("no stream event")
+		return domain.StreamEvent{}
+	}
+}
+
+func createTestStreamService(poll time.Duration) (*ChatService, *memUpdateLog) {
+	log := newMemUpdateLog()
+	service := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{},
+		WithUpdateLog(log), WithStreamPoll(poll))
+	return service, log
+}
+
+func TestChatService_SubscribeChats_ResumesAndFollowsLiveUpdates(t *testing.T) {
+	// Подготовка: в журнале уже две записи, клиент видел первую
+	service, log := createTestStreamService(time.Hour)
+	now := time.Now().Unix()
+	_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "chat1", ReadSeq: 3, CreatedAt: now})
+	_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "chat1", ReadSeq: 4, CreatedAt: now})
+
+	events, cancel, done := startSubscription(service, "user1", "1")
+
+	// Пропущенное отдаётся сразу
+	e := nextStreamEvent(t, events)
+	assert.Equal(t, int64(2), e.Update.Seq)
+	assert.Equal(t, "2", e.ResumeToken)
+
+	// Новое обновление будит поток без ожидания опроса
+	_ = service.logMembership(context.Background(), domain.Chat{ID: "chat2", MemberIDs: []string{"user1", "user2"}}, domain.MembershipAdded, "user2", []string{"user3"})
+	e = nextStreamEvent(t, events)
+	assert.Equal(t, domain.UpdateMembership, e.Update.Type)
+	assert.Equal(t, "chat2", e.Update.ChatID)
+	assert.Equal(t, "3", e.ResumeToken)
+
+	// Отмена завершает поток без ошибки и снимает подписку
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, service.streams.subs)
+}
+
+func TestChatService_SubscribeChats_EmptyTokenSkipsHistory(t *testing.T) {
+	service, log := createTestStreamService(10 * time.Millisecond)
+	_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "old", CreatedAt: time.Now().Unix()})
+
+	events, cancel, done := startSubscription(service, "user1", "")
+	defer func() { cancel(); <-done }()
+
+	// Запись с другой реплики (мимо updateHub) подхватывается опросом
+	time.Sleep(20 * time.Millisecond)
+	_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "new", CreatedAt: time.Now().Unix()})
+
+	e := nextStreamEvent(t, events)
+	assert.Equal(t, "new", e.Update.ChatID)
+	assert.Equal(t, int64(2), e.Update.Seq)
+}
+
+func TestChatService_SubscribeChats_ResyncWhenTokenExpired(t *testing.T) {
+	service, log := createTestStreamService(time.Hour)
+	now := time.Now().Unix()
+	for i := 0; i < 3; i++ {
+		_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "chat1", CreatedAt: now - 100})
+	}
+	_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "chat1", CreatedAt: now})
+	_ = log.DeleteBefore(now - 10) // в журнале остался только seq 4
+
+	events, cancel, done := startSubscription(service, "user1", "1")
+	defer func() { cancel(); <-done }()
+
+	e := nextStreamEvent(t, events)
+	assert.True(t, e.Resync)
+	assert.Nil(t, e.Update)
+	assert.Equal(t, "4", e.ResumeToken)
+
+	_ = service.logRead(context.Background(), domain.Chat{ID: "chat1", MemberIDs: []string{"user1"}}, "user1", 9)
+	e = nextStreamEvent(t, events)
+	assert.Equal(t, int64(5), e.Update.Seq)
+}
+
+func TestChatService_SubscribeChats_StopsOnSendError(t *testing.T) {
+	service, log := createTestStreamService(time.Hour)
+	for i := 0; i < 3; i++ {
+		_ = log.Append(context.Background(), []string{"user1"}, domain.UserUpdate{Type: domain.UpdateRead, ChatID: "chat1", CreatedAt: time.Now().Unix()})
+	}
+	sendErr := errors.New("client gone")
+
+	calls := 0
+	err := service.SubscribeChats(context.Background(), "user1", "0", func(domain.StreamEvent) error {
+		calls++
+		return sendErr
+	})
+
+	assert.ErrorIs(t, err, sendErr)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, service.streams.subs)
+}
+
+func TestChatService_SubscribeChats_DeliversTyping(t *testing.T) {
+	// Подготовка: relay этой реплики получает статус набора для user2
+	service, _ := createTestStreamService(time.Hour)
+	mockTyping := &MockTypingRepository{}
+	WithTyping(mockTyping)(service)
+	status := domain.TypingStatus{ChatID: "chat1", UserID: "user1", Action: domain.TypingActionTyping, ExpiresAt: 1}
+	mockTyping.On("SubscribeTyping", mock.Anything).Return([]domain.TypingStatus{status}, nil)
+
+	events, cancel, done := startSubscription(service, "user2", "")
+	assert.Eventually(t, func() bool {
+		service.typingSubs.mu.Lock()
+		defer service.typingSubs.mu.Unlock()
+		return len(service.typingSubs.subs["user2"]) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go service.RunTypingRelay(relayCtx, zerolog.Nop())
+
+	// Статус приходит в поток без токена, журнал при этом не трогается
+	e := nextStreamEvent(t, events)
+	require.NotNil(t, e.Typing)
+	assert.Equal(t, status, *e.Typing)
+	assert.Nil(t, e.Update)
+	assert.Empty(t, e.ResumeToken)
+
+	// Следом обычное обновление журнала
+	_ = service.logRead(context.Background(), domain.Chat{ID: "chat1", MemberIDs: []string{"user2"}}, "user2", 3)
+	e = nextStreamEvent(t, events)
+	assert.Equal(t, domain.UpdateRead, e.Update.Type)
+	assert.Equal(t, "1", e.ResumeToken)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, service.typingSubs.subs)
+}
+
+func TestChatService_SubscribeChats_InvalidArguments(t *testing.T) {
+	service, _ := createTestStreamService(time.Hour)
+	send := func(domain.StreamEvent) error { return nil }
+
+	assert.ErrorIs(t, service.SubscribeChats(context.Background(), "user1", "abc", send), domain.ErrInvalidArgument)
+	assert.ErrorIs(t, service.SubscribeChats(context.Background(), "user1", "-5", send), domain.ErrInvalidArgument)
+	assert.ErrorIs(t, service.SubscribeChats(context.Background(), "", "", send), domain.ErrInvalidArgument)
+
+	// Без журнала обновлений следить не за чем
+	noLog := NewChatService(&MockChatRepository{}, &MockMessageRepository{}, &MockKafkaProducer{}, &MockUserServiceClient{})
+	assert.ErrorIs(t, noLog.SubscribeChats(context.Background(), "user1", "", send), domain.ErrInvalidArgument)
+}
+
+// MockMediaResolver - мок для MediaResolver
+type MockMediaResolver struct {
+	mock.Mock
+}
+
+func (m *MockMediaResolver) AttachToChat(ctx context.Context, fileID, userID, chatID string) (domain.Media, error) {
+	args := m.Called(fileID, userID, chatID)
+	return args.Get(0).(domain.Media), args.Error(1)
+}
+
+func (m *MockMediaResolver) DetachFromChat(ctx context.Context, fileID, chatID string) error {
+	args := m.Called(fileID, chatID)
+	return args.Error(0)
+}
+
+// createTestMediaService создает сервис с проверкой вложений
+func createTestMediaService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockKafkaProducer, *MockMediaResolver) {
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+	mockMedia := &MockMediaResolver{}
+	WithMedia(mockMedia)(service)
+	return service, mockChatRepo, mockMsgRepo, mockKafka, mockMedia
+}
+
+func TestChatService_SendMessage_ReplacesMediaWithRealMetadata(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockMedia := createTestMediaService()
+
+	stored := domain.Media{ID: "file1", Type: "image", URL: "/media/download?id=file1", Mime: "image/png", SizeBytes: 2048, AuthorID: "user1"}
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMedia.On("AttachToChat", "file1", "user1", "chat1").Return(stored, nil).Once()
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return len(m.Media) == 1 && m.Media[0] == stored
+	})).Return(domain.Message{ID: "msg1", ChatID: "chat1", Seq: 1, Media: []domain.Media{stored}}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Выполнение: клиент подменил url, mime и размер и прислал файл дважды
+	forged := domain.Media{ID: "file1", URL: "https://evil.example/x.exe", Mime: "image/png", SizeBytes: 1}
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID: "chat1", AuthorID: "user1", Media: []domain.Media{forged, forged},
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_RejectsForeignMedia(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockMedia := createTestMediaService()
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMedia.On("AttachToChat", "file-of-user2", "user1", "chat1").Return(domain.Media{}, domain.ErrPermissionDenied)
+	mockMedia.On("AttachToChat", "missing", "user1", "chat1").Return(domain.Media{}, domain.ErrMediaNotFound)
+	mockMedia.On("AttachToChat", "file1", "user1", "chat1").Return(domain.Media{}, errors.New("connection refused"))
+
+	send := func(id string) error {
+		_, err := service.SendMessage(context.Background(), domain.Message{
+			ChatID: "chat1", AuthorID: "user1", Media: []domain.Media{{ID: id}},
+		})
+		return err
+	}
+
+	// Выполнение и проверки
+	assert.ErrorIs(t, send("file-of-user2"), domain.ErrPermissionDenied)
+	assert.ErrorIs(t, send("missing"), domain.ErrMediaNotFound)
+	assert.ErrorIs(t, send(""), domain.ErrInvalidArgument)
+	err := send("file1")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrPermissionDenied)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "NextSeq", mock.Anything)
+}
+
+func TestChatService_UpdateMessage_ResolvesNewMedia(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockMedia := createTestMediaService()
+
+	stored := domain.Media{ID: "file2", Type: "file", URL: "/media/download?id=file2", Mime: "application/pdf", SizeBytes: 10, AuthorID: "user1"}
+	mockMsgRepo.On("Get", "msg-1").Return(createTestMessage("msg-1", "chat1", "user1", "text"), nil)
+	mockMedia.On("AttachToChat", "file2", "user1", "chat1").Return(stored, nil)
+	mockMsgRepo.On("Update", mock.Anything, "msg-1", "user1", (*string)(nil), &[]domain.Media{stored}).
+		Return(createTestMessage("msg-1", "chat1", "user1", "text"), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil).Maybe()
+
+	// Выполнение
+	media := []domain.Media{{ID: "file2", Mime: "image/png"}}
+	_, err := service.UpdateMessage(context.Background(), "msg-1", "user1", nil, &media)
+
+	// Проверки
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_ReleasesMediaWhenNotSaved(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockMedia := createTestMediaService()
+
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
+	mockMedia.On("AttachToChat", "file1", "user1", "chat1").Return(domain.Media{ID: "file1"}, nil)
+	mockMedia.On("AttachToChat", "file2", "user1", "chat1").Return(domain.Media{ID: "file2"}, nil)
+	mockChatRepo.On("NextSeq", "chat1").Return(int64(0), errors.New("mongo down"))
+	// file2 уже отправлен в этот чат другим сообщением — его отметка остаётся
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file1", "file2"}).Return([]string{"file2"}, nil)
+	mockMedia.On("DetachFromChat", "file1", "chat1").Return(nil).Once()
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID: "chat1", AuthorID: "user1", Media: []domain.Media{{ID: "file1"}, {ID: "file2"}},
+	})
+
+	// Проверки
+	assert.Error(t, err)
+	mockMedia.AssertExpectations(t)
+	mockMedia.AssertNotCalled(t, "DetachFromChat", "file2", "chat1")
+}
+
+func TestChatService_UpdateMessage_DetachesReplacedMedia(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockMedia := createTestMediaService()
+
+	old := createTestMessage("msg-1", "chat1", "user1", "text")
+	old.Media = []domain.Media{{ID: "file1"}, {ID: "file2"}}
+	mockMsgRepo.On("Get", "msg-1").Return(old, nil)
+	mockMedia.On("AttachToChat", "file2", "user1", "chat1").Return(domain.Media{ID: "file2"}, nil)
+	mockMedia.On("AttachToChat", "file3", "user1", "chat1").Return(domain.Media{ID: "file3"}, nil)
+	mockMsgRepo.On("Update", mock.Anything, "msg-1", "user1", (*string)(nil), mock.Anything).
+		Return(createTestMessage("msg-1", "chat1", "user1", "text"), nil)
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil).Maybe()
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file1"}).Return([]string{}, nil)
+	mockMedia.On("DetachFromChat", "file1", "chat1").Return(nil).Once()
+
+	// Выполнение
+	media := []domain.Media{{ID: "file2"}, {ID: "file3"}}
+	_, err := service.UpdateMessage(context.Background(), "msg-1", "user1", nil, &media)
+
+	// Проверки: снята только отметка файла, которого больше нет в сообщении
+	assert.NoError(t, err)
+	mockMedia.AssertExpectations(t)
+	mockMedia.AssertNumberOfCalls(t, "DetachFromChat", 1)
+}
+
+func TestChatService_UpdateMessage_ReleasesNewMediaWhenNotSaved(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, mockMedia := createTestMediaService()
+
+	old := createTestMessage("msg-1", "chat1", "user1", "text")
+	old.Media = []domain.Media{{ID: "file1"}}
+	mockMsgRepo.On("Get", "msg-1").Return(old, nil)
+	mockMedia.On("AttachToChat", "file1", "user1", "chat1").Return(domain.Media{ID: "file1"}, nil)
+	mockMedia.On("AttachToChat", "file2", "user1", "chat1").Return(domain.Media{ID: "file2"}, nil)
+	mockMsgRepo.On("Update", mock.Anything, "msg-1", "user1", (*string)(nil), mock.Anything).
+		Return(domain.Message{}, errors.New("mongo down"))
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file2"}).Return([]string{}, nil)
+	mockMedia.On("DetachFromChat", "file2", "chat1").Return(nil).Once()
+
+	// Выполнение
+	media := []domain.Media{{ID: "file1"}, {ID: "file2"}}
+	_, err := service.UpdateMessage(context.Background(), "msg-1", "user1", nil, &media)
+
+	// Проверки: файл, который остался в сообщении, не снимается
+	assert.Error(t, err)
+	mockMedia.AssertExpectations(t)
+	mockMedia.AssertNotCalled(t, "DetachFromChat", "file1", "chat1")
+}
+
+// ==================== ЭКСПОРТ И ИМПОРТ ====================
+
+// knownUsers отвечает на AboutMeUser для перечисленных пользователей; остальные не найдены
+func knownUsers(m *MockUserServiceClient, names map[string]string) {
+	for id, name := range names {
+		m.On("AboutMeUser", mock.Anything, &userpb.AboutMeRequest{Uuid: id}, mock.Anything).
+			Return(&userpb.UserResponse{Uuid: id, UserName: name}, nil)
+	}
+	m.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("user not found"))
+}
+
+func TestChatService_ExportChat_JSONPagesThroughHistory(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+	ctx := context.Background()
+
+	chat := createTestChat("chat1", domain.ChatKindGroup)
+	chat.Title = "Команда"
+	chat.MemberIDs = []string{"user1", "user2"}
+	chat.CreatedBy = "user1"
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	knownUsers(mockUserClient, map[string]string{"user1": "alice", "user2": "bob"})
+
+	first := createTestMessage("m1", "chat1", "user1", "привет")
+	first.Seq = 1
+	first.Media = []domain.Media{{ID: "file1", Type: "image", URL: "/media/download?id=file1"}}
+	second := createTestMessage("m2", "chat1", "user2", "<b>ответ</b>")
+	second.Seq = 3
+	mockMsgRepo.On("List", domain.MessageQuery{ChatID: "chat1", Limit: maxMessagesLimit, AroundSeq: 1}).
+		Return(domain.MessagePage{Messages: []domain.Message{first}, HasAfter: true}, nil)
+	mockMsgRepo.On("List", domain.MessageQuery{ChatID: "chat1", Limit: maxMessagesLimit, AfterSeq: 1}).
+		Return(domain.MessagePage{Messages: []domain.Message{second}, HasBefore: true}, nil)
+
+	// Выполнение
+	var buf bytes.Buffer
+	err := service.ExportChat(ctx, "chat1", "user2", "json", &buf)
+
+	// Проверки
+	assert.NoError(t, err)
+	a, err := archive.Read(&buf, 1<<20)
+	assert.NoError(t, err)
+	assert.Equal(t, "Команда", a.Chat.Title)
+	assert.Equal(t, "user2", a.ExportedBy)
+	assert.Equal(t, []archive.Member{
+		{UserID: "user1", Name: "alice", Role: "owner"},
+		{UserID: "user2", Name: "bob", Role: "member"},
+	}, a.Members)
+	assert.Len(t, a.Messages, 2)
+	assert.Equal(t, "alice", a.Messages[0].AuthorName)
+	assert.Equal(t, "file1", a.Messages[0].Media[0].ID)
+	assert.Equal(t, int64(3), a.Messages[1].Seq)
+	assert.Equal(t, "<b>ответ</b>", a.Messages[1].Text)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_ExportChat_HTMLEscapesText(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+
+	chat := createTestChat("chat1", domain.ChatKindGroup)
+	chat.MemberIDs = []string{"user1"}
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	knownUsers(mockUserClient, map[string]string{"user1": "alice"})
+	msg := createTestMessage("m1", "chat1", "user1", "<script>alert(1)</script>")
+	msg.Seq = 1
+	mockMsgRepo.On("List", mock.Anything).Return(domain.MessagePage{Messages: []domain.Message{msg}}, nil)
+
+	// Выполнение
+	var buf bytes.Buffer
+	err := service.ExportChat(context.Background(), "chat1", "user1", "html", &buf)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "<!DOCTYPE html>"))
+	assert.Contains(t, buf.String(), "&lt;script&gt;")
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.Contains(t, buf.String(), "alice")
+}
+
+func TestChatService_ExportChat_Rejects(t *testing.T) {
+	t.Run("not a member", func(t *testing.T) {
+		service, mockChatRepo, mockMsgRepo, _, _ := createTestService()
+		mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindGroup), nil)
+
+		err := service.ExportChat(context.Background(), "chat1", "stranger", "json", &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		mockMsgRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		service, mockChatRepo, _, _, _ := createTestService()
+		chat := createTestChat("chat1", domain.ChatKindGroup)
+		chat.MemberIDs = []string{"user1"}
+		mockChatRepo.On("Get", "chat1").Return(chat, nil)
+
+		err := service.ExportChat(context.Background(), "chat1", "user1", "pdf", &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+}
+
+func TestChatService_ImportChat_RestoresHistory(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	mockMedia := &MockMediaResolver{}
+	mockInvites := &MockInviteRepository{}
+	WithMedia(mockMedia)(service)
+	WithInvites(mockInvites)(service)
+	ctx := context.Background()
+
+	knownUsers(mockUserClient, map[string]string{"importer": "imp", "user1": "alice", "blocker": "eve"})
+	mockUserClient.On("CheckBlocked", mock.Anything, &userpb.CheckBlockedRequest{Uuid: "importer", UserUuids: []string{"user1", "blocker"}}, mock.Anything).
+		Return(&userpb.CheckBlockedResponse{BlockedBy: []string{"blocker"}}, nil)
+
+	// Участники архива в группу сразу не попадают — им предназначена ссылка-приглашение
+	group := domain.Chat{ID: "new1", Kind: domain.ChatKindGroup, Title: "Старая группа", CreatedBy: "importer", MemberIDs: []string{"importer"}}
+	mockChatRepo.On("CreateGroup", mock.Anything, "importer", []string(nil), "Старая группа", mock.Anything).Return(group, nil)
+	mockChatRepo.On("Get", "new1").Return(group, nil)
+	mockInvites.On("CreateLink", mock.MatchedBy(func(l domain.InviteLink) bool {
+		return l.ChatID == "new1" && l.CreatedBy == "importer" && l.UsageLimit == 1 && !l.RequiresApproval
+	})).Return(nil)
+	// Импортёр читает исходный чат, user1 в нём состоит — авторство сохраняется
+	mockChatRepo.On("Get", "old1").Return(domain.Chat{ID: "old1", Kind: domain.ChatKindGroup, CreatedBy: "user1", MemberIDs: []string{"user1", "importer"}}, nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil)
+	mockChatRepo.On("ReserveSeqs", "new1", int64(3)).Return(int64(3), nil)
+	mockMedia.On("AttachToChat", "file1", "importer", "new1").Return(domain.Media{ID: "file1", Type: "image", AuthorID: "importer"}, nil)
+	mockMedia.On("AttachToChat", "file2", "importer", "new1").Return(domain.Media{}, domain.ErrPermissionDenied)
+
+	var inserted []domain.Message
+	mockMsgRepo.On("InsertMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		inserted = append(inserted, args.Get(1).([]domain.Message)...)
+	}).Return(nil)
+	mockChatRepo.On("SetLastMessage", "new1", mock.MatchedBy(func(p domain.MessagePreview) bool {
+		return p.Seq == 3
+	})).Return(nil)
+
+	data := `{"version":1,"chat":{"id":"old1","kind":"group","title":"Старая группа"},
+		"members":[{"user_id":"user1"},{"user_id":"ghost"},{"user_id":"blocker"},{"user_id":"importer"}],
+		"messages":[
+			{"id":"a","seq":1,"author_id":"user1","type":"text","text":"привет","created_at":1600000000,
+				"media":[{"id":"file1"},{"id":"file2"}],
+				"entities":[{"type":"mention","offset":0,"length":5,"user_id":"ghost"}]},
+			{"id":"b","seq":2,"author_id":"ghost","author_name":"Призрак","type":"text","text":"я ушёл","created_at":1600000100},
+			{"id":"c","seq":5,"author_id":"user1","type":"poll","created_at":1600000200,
+				"poll":{"question":"Куда?","options":[{"text":"Туда","votes":2},{"text":"Сюда","votes":1}],"total_voters":3}}
+		]}`
+
+	// Выполнение
+	result, err := service.ImportChat(ctx, "importer", "", strings.NewReader(data))
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "new1", result.Chat.ID)
+	assert.Equal(t, 3, result.ImportedMessages)
+	assert.Equal(t, 2, result.SkippedMembers)
+	assert.Equal(t, 1, result.DroppedAttachments)
+	assert.Equal(t, 1, result.ReassignedMessages)
+	assert.Equal(t, []string{"user1"}, result.InvitedMembers)
+	assert.NotNil(t, result.Invite)
+
+	assert.Len(t, inserted, 3)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{inserted[0].Seq, inserted[1].Seq, inserted[2].Seq})
+	assert.Equal(t, "user1", inserted[0].AuthorID)
+	assert.Equal(t, int64(1600000000), inserted[0].CreatedAt)
+	assert.Equal(t, []domain.Media{{ID: "file1", Type: "image", AuthorID: "importer"}}, inserted[0].Media)
+	assert.Empty(t, inserted[0].Entities)
+	assert.NotEqual(t, "a", inserted[0].ID)
+	assert.Equal(t, "importer", inserted[1].AuthorID)
+	assert.Equal(t, "Призрак", inserted[1].ImportedFrom)
+	assert.True(t, inserted[2].Poll.Closed)
+	assert.Equal(t, int64(3), inserted[2].Poll.TotalVoters)
+	assert.Equal(t, 1, inserted[2].Poll.Options[1].ID)
+	mockChatRepo.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+	// Импорт не шлёт уведомлений о новых сообщениях
+	mockKafka.AssertNotCalled(t, "PublishNewMessage", mock.Anything, mock.Anything)
+}
+
+func TestChatService_ImportChat_KeepsAuthorsOnlyForTrustedArchive(t *testing.T) {
+	key := []byte("archive-key")
+	header := archive.Header{Version: archive.Version, Chat: archive.Chat{ID: "old1", Title: "Группа"}}
+	msgs := []archive.Message{
+		{ID: "a", Seq: 1, AuthorID: "user1", AuthorName: "alice", Type: "text", Text: "это писал не импортёр"},
+		{ID: "b", Seq: 2, AuthorID: "importer", Type: "text", Text: "своё"},
+	}
+	write := func(key []byte) string {
+		var buf bytes.Buffer
+		w, err := archive.NewWriter(archive.FormatJSON, &buf, key)
+		require.NoError(t, err)
+		require.NoError(t, w.Begin(header))
+		for _, m := range msgs {
+			require.NoError(t, w.Message(m))
+		}
+		require.NoError(t, w.End())
+		return buf.String()
+	}
+	run := func(t *testing.T, data string) []domain.Message {
+		service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+		WithArchiveKey(key)(service)
+		knownUsers(mockUserClient, map[string]string{"importer": "imp", "user1": "alice"})
+		mockUserClient.On("CheckBlocked", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.CheckBlockedResponse{}, nil)
+		group := domain.Chat{ID: "new1", Kind: domain.ChatKindGroup, CreatedBy: "importer", MemberIDs: []string{"importer"}}
+		mockChatRepo.On("CreateGroup", mock.Anything, "importer", []string(nil), "Группа", mock.Anything).Return(group, nil)
+		// Импортёр исходный чат не читает
+		mockChatRepo.On("Get", "old1").Return(domain.Chat{}, domain.ErrChatNotFound)
+		mockChatRepo.On("ReserveSeqs", "new1", int64(2)).Return(int64(2), nil)
+		mockChatRepo.On("SetLastMessage", "new1", mock.Anything).Return(nil)
+		mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil)
+		var inserted []domain.Message
+		mockMsgRepo.On("InsertMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			inserted = append(inserted, args.Get(1).([]domain.Message)...)
+		}).Return(nil)
+
+		_, err := service.ImportChat(context.Background(), "importer", "", strings.NewReader(data))
+		require.NoError(t, err)
+		require.Len(t, inserted, 2)
+		return inserted
+	}
+
+	t.Run("unsigned archive of a foreign chat", func(t *testing.T) {
+		inserted := run(t, write(nil))
+
+		assert.Equal(t, "importer", inserted[0].AuthorID)
+		assert.Equal(t, "alice", inserted[0].ImportedFrom)
+		assert.Equal(t, "importer", inserted[1].AuthorID)
+		assert.Empty(t, inserted[1].ImportedFrom)
+	})
+
+	t.Run("signed archive", func(t *testing.T) {
+		inserted := run(t, write(key))
+
+		assert.Equal(t, "user1", inserted[0].AuthorID)
+		assert.Empty(t, inserted[0].ImportedFrom)
+	})
+
+	t.Run("archive edited after signing", func(t *testing.T) {
+		data := strings.Replace(write(key), "это писал не импортёр", "подделка", 1)
+
+		inserted := run(t, data)
+
+		assert.Equal(t, "importer", inserted[0].AuthorID)
+	})
+}
+
+func TestChatService_ImportChat_Rejects(t *testing.T) {
+	t.Run("not an archive", func(t *testing.T) {
+		service, mockChatRepo, _, _, _ := createTestService()
+
+		_, err := service.ImportChat(context.Background(), "importer", "", strings.NewReader("<html>"))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		mockChatRepo.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		service, _, _, _, _ := createTestService()
+
+		_, err := service.ImportChat(context.Background(), "importer", "x", strings.NewReader(`{"version":2}`))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+
+	t.Run("no title", func(t *testing.T) {
+		service, _, _, _, _ := createTestService()
+
+		_, err := service.ImportChat(context.Background(), "importer", "", strings.NewReader(`{"version":1,"messages":[]}`))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+}
+
+// ==================== МЕДЛЕННЫЙ РЕЖИМ ====================
+
+type MockSendLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockSendLimitRepository) TakeSlowModeSlot(ctx context.Context, chatID, userID string, interval time.Duration) (time.Duration, error) {
+	args := m.Called(chatID, userID, interval)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockSendLimitRepository) CountSend(ctx context.Context, chatID, userID string, limit int64, window time.Duration) (time.Duration, error) {
+	args := m.Called(chatID, userID, limit, window)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+// createTestLimitedService — сервис с лимитом 5 сообщений в минуту и группой group1 в медленном режиме 30 с
+func createTestLimitedService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockKafkaProducer, *MockSendLimitRepository) {
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestService()
+	mockLimits := &MockSendLimitRepository{}
+	WithSendLimits(mockLimits, 5, time.Minute)(service)
+
+	group := createTestChat("group1", domain.ChatKindGroup)
+	group.SlowModeSeconds = 30
+	mockChatRepo.On("Get", "group1").Return(group, nil)
+	return service, mockChatRepo, mockMsgRepo, mockKafka, mockLimits
+}
+
+// expectSend настраивает успешную отправку в group1
+func expectSend(mockChatRepo *MockChatRepository, mockMsgRepo *MockMessageRepository, mockKafka *MockKafkaProducer) {
+	mockChatRepo.On("NextSeq", "group1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "group1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "group1", Seq: 1}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil)
+}
+
+func TestChatService_SendMessage_SlowModeRejects(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, mockLimits := createTestLimitedService()
+	mockLimits.On("TakeSlowModeSlot", "group1", "user2", 30*time.Second).Return(12*time.Second, nil)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Text: "ещё"})
+
+	// Проверки
+	var limited *domain.RateLimitError
+	assert.ErrorIs(t, err, domain.ErrRateLimited)
+	assert.ErrorAs(t, err, &limited)
+	assert.Equal(t, 12*time.Second, limited.RetryAfter)
+	assert.True(t, limited.SlowMode)
+	assert.Contains(t, err.Error(), "через 12 с")
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockLimits.AssertNotCalled(t, "CountSend", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_SendMessage_RateLimitRejects(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, mockLimits := createTestLimitedService()
+	mockLimits.On("TakeSlowModeSlot", "group1", "user2", 30*time.Second).Return(time.Duration(0), nil)
+	mockLimits.On("CountSend", "group1", "user2", int64(5), time.Minute).Return(1500*time.Millisecond, nil)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Text: "флуд"})
+
+	// Проверки
+	var limited *domain.RateLimitError
+	assert.ErrorAs(t, err, &limited)
+	assert.False(t, limited.SlowMode)
+	// Остаток округляется вверх до секунды
+	assert.Contains(t, err.Error(), "через 2 с")
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestChatService_SendMessage_WithinLimits(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockLimits := createTestLimitedService()
+	mockLimits.On("TakeSlowModeSlot", "group1", "user2", 30*time.Second).Return(time.Duration(0), nil)
+	mockLimits.On("CountSend", "group1", "user2", int64(5), time.Minute).Return(time.Duration(0), nil)
+	expectSend(mockChatRepo, mockMsgRepo, mockKafka)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Text: "привет"})
+
+	// Проверки
+	assert.NoError(t, err)
+	mockLimits.AssertExpectations(t)
+}
+
+func TestChatService_SendMessage_LimitsSkipOwnerAndSystem(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockLimits := createTestLimitedService()
+	expectSend(mockChatRepo, mockMsgRepo, mockKafka)
+
+	// Выполнение: владелец группы и системное сообщение участника
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user1", Text: "объявление"})
+	assert.NoError(t, err)
+	_, err = service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Type: domain.MessageTypeSystem,
+		System: &domain.SystemEvent{Action: domain.SystemActionJoin, ActorID: "user2"}})
+	assert.NoError(t, err)
+
+	// Проверки
+	mockLimits.AssertNotCalled(t, "TakeSlowModeSlot", mock.Anything, mock.Anything, mock.Anything)
+	mockLimits.AssertNotCalled(t, "CountSend", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatService_SendMessage_LimitsFailOpen(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockLimits := createTestLimitedService()
+	mockLimits.On("TakeSlowModeSlot", "group1", "user2", 30*time.Second).Return(time.Duration(0), errors.New("redis down"))
+	mockLimits.On("CountSend", "group1", "user2", int64(5), time.Minute).Return(time.Duration(0), errors.New("redis down"))
+	expectSend(mockChatRepo, mockMsgRepo, mockKafka)
+
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Text: "привет"})
+
+	// Проверки
+	assert.NoError(t, err)
+}
+
+func TestChatService_SetSlowMode_Success(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestService()
+	group := createTestChat("group1", domain.ChatKindGroup)
+	updated := group
+	updated.SlowModeSeconds = 300
+	mockChatRepo.On("Get", "group1").Return(group, nil)
+	mockChatRepo.On("SetSlowMode", "group1", int64(300)).Return(updated, nil)
+	mockChatRepo.On("NextSeq", "group1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "group1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Type == domain.MessageTypeSystem && m.System.Action == domain.SystemActionSlowMode && m.Text == "Медленный режим: одно сообщение в 5 мин"
+	})).Return(domain.Message{ID: "sys1", ChatID: "group1", Seq: 1}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil)
+
+	// Выполнение
+	chat, err := service.SetSlowMode(context.Background(), "group1", "user1", 300)
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), chat.SlowModeSeconds)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestChatService_SetSlowMode_Rejects(t *testing.T) {
+	t.Run("member", func(t *testing.T) {
+		service, mockChatRepo, _, _, _ := createTestService()
+		mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+
+		_, err := service.SetSlowMode(context.Background(), "group1", "user2", 30)
 
-// 	expectedMsg := createTestMessage("msg-123", "chat1", "user1", "Hello World")
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		mockChatRepo.AssertNotCalled(t, "SetSlowMode", mock.Anything, mock.Anything)
+	})
 
-// 	// Настройка моков
-// 	mockMsgRepo.On("Send", mock.MatchedBy(func(m domain.Message) bool {
-// 		return m.ChatID == "chat1" && m.AuthorID == "user1" && m.Text == "Hello World"
-// 	})).Return(expectedMsg, nil)
-// 	mockKafka.On("PublishNewMessage", ctx, mock.AnythingOfType("domain.NewMessageEvent")).Return(nil)
-// 	mockKafka.On("PublishEvent", ctx, mock.AnythingOfType("domain.SearchEvent")).Return(nil)
+	t.Run("direct chat", func(t *testing.T) {
+		service, mockChatRepo, _, _, _ := createTestService()
+		mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
 
-// 	// Выполнение
-// 	result, err := service.SendMessage(ctx, msg)
+		_, err := service.SetSlowMode(context.Background(), "chat1", "user1", 30)
 
-// 	// Проверки
-// 	assert.NoError(t, err)
-// 	assert.Equal(t, "msg-123", result.ID)
-// 	assert.Equal(t, "chat1", result.ChatID)
-// 	assert.Equal(t, "Hello World", result.Text)
-// 	assert.NotZero(t, result.CreatedAt)
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
 
-// 	mockMsgRepo.AssertExpectations(t)
-// 	mockKafka.AssertExpectations(t)
-// }
+	t.Run("too long", func(t *testing.T) {
+		service, _, _, _, _ := createTestService()
 
-func TestChatService_SendMessage_RepositoryError(t *testing.T) {
-	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
-	ctx := context.Background()
+		_, err := service.SetSlowMode(context.Background(), "group1", "user1", 7200)
 
-	msg := domain.Message{
-		ChatID:   "chat1",
-		AuthorID: "user1",
-		Text:     "Hello World",
-	}
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+}
 
-	// Настройка моков - репозиторий возвращает ошибку
-	mockMsgRepo.On("Send", mock.Anything).Return(domain.Message{}, errors.New("database error"))
+// ==================== МОДЕРАЦИЯ ====================
 
-	// Выполнение
-	result, err := service.SendMessage(ctx, msg)
+type MockModerationRepository struct {
+	mock.Mock
+}
 
-	// Проверки
-	assert.Error(t, err)
-	assert.Equal(t, "database error", err.Error())
-	assert.Equal(t, domain.Message{}, result)
+func (m *MockModerationRepository) AddHeld(item domain.ModerationItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
 
-	mockMsgRepo.AssertExpectations(t)
+func (m *MockModerationRepository) AddReport(item domain.ModerationItem, report domain.ModerationReport) (domain.ModerationItem, error) {
+	args := m.Called(item, report)
+	return args.Get(0).(domain.ModerationItem), args.Error(1)
 }
 
-func TestChatService_UpdateMessage_Success(t *testing.T) {
-	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
-	ctx := context.Background()
+func (m *MockModerationRepository) Get(id string) (domain.ModerationItem, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.ModerationItem), args.Error(1)
+}
 
-	newText := "Updated text"
-	expectedMsg := createTestMessage("msg-123", "chat1", "user1", "Updated text")
+func (m *MockModerationRepository) ListPending(chatID string, limit int, cursor string) ([]domain.ModerationItem, string, error) {
+	args := m.Called(chatID, limit, cursor)
+	return args.Get(0).([]domain.ModerationItem), args.String(1), args.Error(2)
+}
 
-	// Настройка моков
-	mockMsgRepo.On("Update", "msg-123", "user1", &newText, (*[]domain.Media)(nil)).Return(expectedMsg, nil)
+func (m *MockModerationRepository) Decide(id string, status domain.ModerationStatus, decidedBy string, now int64) (domain.ModerationItem, error) {
+	args := m.Called(id, status, decidedBy)
+	return args.Get(0).(domain.ModerationItem), args.Error(1)
+}
+
+// createTestModeratedService — «дурак» маскируется, ссылки на spam.example задерживаются, «казино» отклоняется
+func createTestModeratedService() (*ChatService, *MockChatRepository, *MockMessageRepository, *MockKafkaProducer, *MockModerationRepository) {
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+	mockQueue := &MockModerationRepository{}
+	WithModeration(moderation.NewPipeline(
+		moderation.NewBannedWords([]string{"дурак"}, moderation.Mask),
+		moderation.NewLinkBlocklist([]string{"spam.example"}, moderation.Hold),
+		moderation.NewBannedWords([]string{"казино"}, moderation.Reject),
+	), mockQueue)(service)
+
+	mockChatRepo.On("Get", "group1").Return(createTestChat("group1", domain.ChatKindGroup), nil)
+	mockChatRepo.On("Get", "direct1").Return(createTestChat("direct1", domain.ChatKindDirect), nil)
+	return service, mockChatRepo, mockMsgRepo, mockKafka, mockQueue
+}
+
+func TestChatService_SendMessage_ModerationMasks(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestModeratedService()
+	expectSend(mockChatRepo, mockMsgRepo, mockKafka)
 
 	// Выполнение
-	result, err := service.UpdateMessage(ctx, "msg-123", "user1", &newText, nil)
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Text: "сам дураком будь"})
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Equal(t, "Updated text", result.Text)
-
-	mockMsgRepo.AssertExpectations(t)
+	mockMsgRepo.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Text == "сам д****** будь"
+	}))
 }
 
-func TestChatService_DeleteMessage_Success(t *testing.T) {
+func TestChatService_SendMessage_ModerationHolds(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
+	service, mockChatRepo, mockMsgRepo, _, mockQueue := createTestModeratedService()
+	mockQueue.On("AddHeld", mock.MatchedBy(func(item domain.ModerationItem) bool {
+		return item.Kind == domain.ModerationKindHeld && item.ChatID == "group1" && item.Message != nil &&
+			item.MessageID == item.Message.ID && item.Text == "дешево: spam.example/x" &&
+			len(item.Reasons) == 1 && item.Reasons[0] == "link_blocklist: ссылка на spam.example"
+	})).Return(nil)
 
-	expectedMessages := []domain.Message{
-		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
-		createTestMessage("msg-2", "chat1", "user1", "Message 2"),
-	}
+	// Выполнение
+	msg, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user2", Text: "дешево: spam.example/x"})
 
-	// Настройка моков
-	mockMsgRepo.On("Delete", []string{"msg-1", "msg-2"}, false, "user1").Return(expectedMessages, nil)
+	// Проверки
+	assert.NoError(t, err)
+	assert.True(t, msg.Held)
+	assert.NotEmpty(t, msg.ID)
+	mockQueue.AssertExpectations(t)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "NextSeq", mock.Anything)
+}
+
+func TestChatService_SendMessage_ModerationHoldSkippedForOwner(t *testing.T) {
+	// Подготовка: владельцу некому отдавать сообщение на проверку
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockQueue := createTestModeratedService()
+	expectSend(mockChatRepo, mockMsgRepo, mockKafka)
 
 	// Выполнение
-	result, err := service.DeleteMessage([]string{"msg-1", "msg-2"}, false, "user1")
+	msg, err := service.SendMessage(context.Background(), domain.Message{ChatID: "group1", AuthorID: "user1", Text: "spam.example"})
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
-	assert.Equal(t, "msg-1", result[0].ID)
-	assert.Equal(t, "msg-2", result[1].ID)
-
-	mockMsgRepo.AssertExpectations(t)
+	assert.False(t, msg.Held)
+	mockQueue.AssertNotCalled(t, "AddHeld", mock.Anything)
 }
 
-func TestChatService_ListMessages_Success(t *testing.T) {
-	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
-	ctx := context.Background()
-
-	expectedMessages := []domain.Message{
-		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
-		createTestMessage("msg-2", "chat1", "user2", "Message 2"),
-		createTestMessage("msg-3", "chat1", "user1", "Message 3"),
+func TestChatService_SendMessage_ModerationRejects(t *testing.T) {
+	cases := map[string]domain.Message{
+		"запрещённое слово":          {ChatID: "group1", AuthorID: "user2", Text: "заходи в казино"},
+		"в личном чате Hold — отказ": {ChatID: "direct1", AuthorID: "user2", Text: "spam.example"},
 	}
+	for name, m := range cases {
+		t.Run(name, func(t *testing.T) {
+			service, _, mockMsgRepo, _, mockQueue := createTestModeratedService()
 
-	// Настройка моков
-	mockMsgRepo.On("List", "chat1", 10, "").Return(expectedMessages, "msg-3", nil)
+			_, err := service.SendMessage(context.Background(), m)
 
-	// Выполнение
-	messages, nextCursor, err := service.ListMessages(ctx, "chat1", 10, "")
+			assert.ErrorIs(t, err, domain.ErrMessageRejected)
+			mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+			mockQueue.AssertNotCalled(t, "AddHeld", mock.Anything)
+		})
+	}
+}
+
+func TestChatService_UpdateMessage_Moderated(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, _ := createTestModeratedService()
+	mockChatRepo.On("SetLastMessage", "group1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "group1", "user2", "привет"), nil)
+	masked := "ты д****"
+	mockMsgRepo.On("Update", mock.Anything, "msg1", "user2", &masked, (*[]domain.Media)(nil)).Return(createTestMessage("msg1", "group1", "user2", masked), nil)
+
+	// Выполнение: правку нельзя задержать, поэтому ссылка из блок-листа отклоняется
+	held := "spam.example"
+	_, errHeld := service.UpdateMessage(context.Background(), "msg1", "user2", &held, nil)
+	text := "ты дурак"
+	updated, err := service.UpdateMessage(context.Background(), "msg1", "user2", &text, nil)
 
 	// Проверки
+	assert.ErrorIs(t, errHeld, domain.ErrMessageRejected)
 	assert.NoError(t, err)
-	assert.Len(t, messages, 3)
-	assert.Equal(t, "msg-3", nextCursor)
-
-	mockMsgRepo.AssertExpectations(t)
+	assert.Equal(t, masked, updated.Text)
 }
 
-func TestChatService_MarkRead_Success(t *testing.T) {
+func TestChatService_ReportMessage(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
-	ctx := context.Background()
+	service, _, mockMsgRepo, _, mockQueue := createTestModeratedService()
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "group1", "user1", "реклама"), nil)
+	mockMsgRepo.On("Get", "msg2").Return(createTestMessage("msg2", "direct1", "user1", "привет"), nil)
+	mockQueue.On("AddReport", mock.MatchedBy(func(item domain.ModerationItem) bool {
+		return item.Kind == domain.ModerationKindReport && item.MessageID == "msg1" && item.AuthorID == "user1" &&
+			item.Text == "реклама" && item.Status == domain.ModerationPending
+	}), mock.MatchedBy(func(r domain.ModerationReport) bool {
+		return r.UserID == "user2" && r.Reason == "спам"
+	})).Return(domain.ModerationItem{ID: "mod1"}, nil)
+
+	// Выполнение и проверки
+	assert.NoError(t, service.ReportMessage(context.Background(), "msg1", "user2", "  спам "))
+	assert.ErrorIs(t, service.ReportMessage(context.Background(), "msg1", "user1", ""), domain.ErrInvalidArgument, "на своё сообщение")
+	assert.ErrorIs(t, service.ReportMessage(context.Background(), "msg1", "stranger", ""), domain.ErrPermissionDenied)
+	assert.ErrorIs(t, service.ReportMessage(context.Background(), "msg2", "user2", ""), domain.ErrInvalidArgument, "личный чат")
+	assert.ErrorIs(t, service.ReportMessage(context.Background(), "msg1", "user2", strings.Repeat("а", 501)), domain.ErrInvalidArgument)
+	mockQueue.AssertNumberOfCalls(t, "AddReport", 1)
+}
 
-	// Настройка моков
-	mockMsgRepo.On("MarkRead", "chat1", "user1", "msg-123").Return(nil)
+func TestChatService_ListModerationQueue_OnlyManagers(t *testing.T) {
+	// Подготовка
+	service, _, _, _, mockQueue := createTestModeratedService()
+	mockQueue.On("ListPending", "group1", 100, "").Return([]domain.ModerationItem{{ID: "mod1"}}, "1:mod1", nil)
 
 	// Выполнение
-	err := service.MarkRead(ctx, "chat1", "user1", "msg-123")
+	items, cursor, err := service.ListModerationQueue(context.Background(), "group1", "user1", 0, "")
+	_, _, errMember := service.ListModerationQueue(context.Background(), "group1", "user2", 0, "")
+	_, _, errDirect := service.ListModerationQueue(context.Background(), "direct1", "user1", 0, "")
 
 	// Проверки
 	assert.NoError(t, err)
-
-	mockMsgRepo.AssertExpectations(t)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "1:mod1", cursor)
+	assert.ErrorIs(t, errMember, domain.ErrPermissionDenied)
+	assert.ErrorIs(t, errDirect, domain.ErrPermissionDenied)
 }
 
-func TestChatService_ToggleSaved_Success(t *testing.T) {
+func TestChatService_ResolveModeration_PublishesHeld(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
-	ctx := context.Background()
-
-	// Настройка моков - сохранить сообщение
-	mockMsgRepo.On("ToggleSaved", "user1", "msg-123", true).Return(nil)
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockQueue := createTestModeratedService()
+	expectSend(mockChatRepo, mockMsgRepo, mockKafka)
+	held := domain.Message{ID: "held1", ChatID: "group1", AuthorID: "user2", Type: domain.MessageTypeText, Text: "spam.example", CreatedAt: 1}
+	item := domain.ModerationItem{ID: "mod1", ChatID: "group1", Kind: domain.ModerationKindHeld, MessageID: "held1", Status: domain.ModerationPending, Message: &held}
+	mockQueue.On("Get", "mod1").Return(item, nil)
+	mockQueue.On("Decide", "mod1", domain.ModerationAllowed, "user1").Return(domain.ModerationItem{ID: "mod1", Status: domain.ModerationAllowed}, nil)
 
 	// Выполнение
-	err := service.ToggleSaved(ctx, "user1", "msg-123", true)
+	decided, err := service.ResolveModeration(context.Background(), "mod1", "user1", domain.ModerationAllowed)
 
-	// Проверки
+	// Проверки: публикуется с прежним id и без повторной модерации
 	assert.NoError(t, err)
-
-	mockMsgRepo.AssertExpectations(t)
+	assert.Equal(t, domain.ModerationAllowed, decided.Status)
+	mockMsgRepo.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.ID == "held1" && m.Text == "spam.example" && m.CreatedAt > 1
+	}))
+	mockQueue.AssertNotCalled(t, "AddHeld", mock.Anything)
 }
 
-func TestChatService_ListSaved_Success(t *testing.T) {
+func TestChatService_ResolveModeration_RemovesReported(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
-	ctx := context.Background()
-
-	expectedMessages := []domain.Message{
-		createTestMessage("msg-1", "chat1", "user1", "Saved message 1"),
-		createTestMessage("msg-2", "chat2", "user2", "Saved message 2"),
-	}
-
-	// Настройка моков
-	mockMsgRepo.On("ListSaved", "user1", 10, "").Return(expectedMessages, "msg-2", nil)
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockQueue := createTestModeratedService()
+	mockQueue.On("Get", "mod1").Return(domain.ModerationItem{ID: "mod1", ChatID: "group1", Kind: domain.ModerationKindReport, MessageID: "msg1", Status: domain.ModerationPending}, nil)
+	mockQueue.On("Decide", "mod1", domain.ModerationRemoved, "user1").Return(domain.ModerationItem{ID: "mod1", Status: domain.ModerationRemoved}, nil)
+	mockMsgRepo.On("Get", "msg1").Return(createTestMessage("msg1", "group1", "user2", "реклама"), nil)
+	mockMsgRepo.On("Purge", []string{"msg1"}).Return(nil)
+	mockChatRepo.On("SetLastMessage", "group1", mock.Anything).Return(nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e domain.SearchEvent) bool {
+		evt, ok := e.Data.(domain.MessagesDeletedEvent)
+		return e.Type == "message.deleted" && ok && evt.Reason == domain.DeleteReasonModeration
+	})).Return(nil)
 
 	// Выполнение
-	messages, nextCursor, err := service.ListSaved(ctx, "user1", 10, "")
+	_, err := service.ResolveModeration(context.Background(), "mod1", "user1", domain.ModerationRemoved)
+	_, errMember := service.ResolveModeration(context.Background(), "mod1", "user2", domain.ModerationRemoved)
+	_, errStatus := service.ResolveModeration(context.Background(), "mod1", "user1", domain.ModerationPending)
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Len(t, messages, 2)
-	assert.Equal(t, "msg-2", nextCursor)
-
+	assert.ErrorIs(t, errMember, domain.ErrPermissionDenied)
+	assert.ErrorIs(t, errStatus, domain.ErrInvalidArgument)
 	mockMsgRepo.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+	mockQueue.AssertNumberOfCalls(t, "Decide", 1)
 }
 
-func TestChatService_ListChats_Success(t *testing.T) {
-	// Подготовка
-	service, mockChatRepo, _, _, _ := createTestService()
-	ctx := context.Background()
-
-	expectedChats := []domain.Chat{
-		createTestChat("chat1", domain.ChatKindDirect),
-		createTestChat("chat2", domain.ChatKindGroup),
-	}
+// secretTestChat — секретный чат user1 и user2; у user1 два устройства, у user2 одно
+func secretTestChat(mockChatRepo *MockChatRepository, mockUserClient *MockUserServiceClient) domain.Chat {
+	chat := createTestChat("secret1", domain.ChatKindDirect)
+	chat.Secret = true
+	notBlocked(mockUserClient)
+	mockChatRepo.On("Get", "secret1").Return(chat, nil)
+	mockUserClient.On("ListDevices", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.ListDevicesResponse{
+		Devices: []*userpb.Device{
+			{Uuid: "user1", DeviceId: "phone"},
+			{Uuid: "user1", DeviceId: "laptop"},
+			{Uuid: "user2", DeviceId: "phone"},
+		},
+	}, nil)
+	return chat
+}
 
-	// Настройка моков
-	mockChatRepo.On("List", "user1", 10, "").Return(expectedChats, "chat2", nil)
+func TestChatService_CreateSecretChat(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, _, _, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+	mockUserClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.UserResponse{}, nil)
+	mockUserClient.On("ListDevices", mock.Anything, &userpb.ListDevicesRequest{Uuids: []string{"user1", "user2"}}, mock.Anything).
+		Return(&userpb.ListDevicesResponse{Devices: []*userpb.Device{{Uuid: "user1", DeviceId: "a"}, {Uuid: "user2", DeviceId: "b"}}}, nil)
+	mockChatRepo.On("CreateSecret", mock.MatchedBy(func(c domain.Chat) bool {
+		return c.Secret && c.Kind == domain.ChatKindDirect && c.ID != "user1_user2"
+	})).Return(nil)
 
 	// Выполнение
-	chats, nextCursor, err := service.ListChats(ctx, "user1", 10, "")
+	chat, err := service.CreateSecretChat(context.Background(), "user1", "user2")
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Len(t, chats, 2)
-	assert.Equal(t, "chat2", nextCursor)
-
+	assert.True(t, chat.Secret)
+	assert.Equal(t, []string{"user1", "user2"}, chat.MemberIDs)
 	mockChatRepo.AssertExpectations(t)
 }
 
-func TestChatService_GetChat_Success(t *testing.T) {
+func TestChatService_CreateSecretChat_PeerWithoutDevices(t *testing.T) {
 	// Подготовка
-	service, mockChatRepo, _, _, _ := createTestService()
-	ctx := context.Background()
-
-	expectedChat := createTestChat("chat1", domain.ChatKindDirect)
-
-	// Настройка моков
-	mockChatRepo.On("Get", "chat1").Return(expectedChat, nil)
+	service, mockChatRepo, _, _, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+	mockUserClient.On("AboutMeUser", mock.Anything, mock.Anything, mock.Anything).Return(&userpb.UserResponse{}, nil)
+	mockUserClient.On("ListDevices", mock.Anything, mock.Anything, mock.Anything).
+		Return(&userpb.ListDevicesResponse{Devices: []*userpb.Device{{Uuid: "user1", DeviceId: "a"}}}, nil)
 
 	// Выполнение
-	chat, err := service.GetChat(ctx, "chat1")
+	_, err := service.CreateSecretChat(context.Background(), "user1", "user2")
 
 	// Проверки
-	assert.NoError(t, err)
-	assert.Equal(t, expectedChat, chat)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockChatRepo.AssertNotCalled(t, "CreateSecret", mock.Anything)
+}
 
-	mockChatRepo.AssertExpectations(t)
+func TestChatService_SendMessage_Secret(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockUserClient := createTestService()
+	secretTestChat(mockChatRepo, mockUserClient)
+	mockChatRepo.On("NextSeq", "secret1").Return(int64(1), nil)
+	mockChatRepo.On("SetLastMessage", "secret1", mock.Anything).Return(nil)
+	mockMsgRepo.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Type == domain.MessageTypeEncrypted && len(m.Envelopes) == 2
+	})).Return(domain.Message{ID: "msg1", ChatID: "secret1", Seq: 1, Type: domain.MessageTypeEncrypted}, nil)
+	mockKafka.On("PublishNewMessage", mock.Anything, mock.Anything).Return(nil)
+
+	// Выполнение: конверты для всех устройств, кроме того, с которого отправлено
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID:         "secret1",
+		AuthorID:       "user1",
+		SenderDeviceID: "phone",
+		Envelopes: []domain.Envelope{
+			{UserID: "user1", DeviceID: "laptop", Ciphertext: []byte{1}},
+			{UserID: "user2", DeviceID: "phone", Ciphertext: []byte{2}},
+		},
+	})
+
+	// Проверки: в поиск секретное сообщение не уходит
+	assert.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+	mockKafka.AssertNotCalled(t, "PublishEvent", mock.Anything, mock.Anything)
 }
 
-func TestChatService_UpdateGroupChat_Success(t *testing.T) {
+func TestChatService_SendMessage_SecretDeviceMismatch(t *testing.T) {
 	// Подготовка
-	service, mockChatRepo, _, _, _ := createTestService()
-	ctx := context.Background()
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+	secretTestChat(mockChatRepo, mockUserClient)
 
-	req := &chatpb.UpdateGroupChatRequest{
-		ChatId:          "group-123",
-		Title:           "New Title",
-		AddMemberIds:    []string{"user3"},
-		RemoveMemberIds: []string{"user4"},
-		RequesterId:     "creator1",
-	}
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID:         "secret1",
+		AuthorID:       "user1",
+		SenderDeviceID: "phone",
+		Envelopes: []domain.Envelope{
+			{UserID: "user2", DeviceID: "phone", Ciphertext: []byte{2}},
+			{UserID: "user2", DeviceID: "tablet", Ciphertext: []byte{3}},
+		},
+	})
 
-	expectedChat := createTestChat("group-123", domain.ChatKindGroup)
-	expectedChat.Title = "New Title"
+	// Проверки
+	var mismatch *domain.DeviceMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, []string{"user1/laptop"}, mismatch.Missing)
+	assert.Equal(t, []string{"user2/tablet"}, mismatch.Unknown)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
 
-	// Настройка моков
-	mockChatRepo.On("UpdateGroup",
-		"group-123",
-		mock.AnythingOfType("*string"),
-		[]string{"user3"},
-		[]string{"user4"},
-		"creator1",
-		mock.Anything,
-	).Return(expectedChat, nil)
+func TestChatService_SendMessage_SecretPlaintextRejected(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+	secretTestChat(mockChatRepo, mockUserClient)
 
 	// Выполнение
-	chat, err := service.UpdateGroupChat(ctx, req)
+	_, err := service.SendMessage(context.Background(), domain.Message{ChatID: "secret1", AuthorID: "user1", Text: "открытый текст"})
 
 	// Проверки
-	assert.NoError(t, err)
-	assert.Equal(t, "New Title", chat.Title)
-
-	mockChatRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
 }
 
-func TestChatService_UpdateGroupChat_NoTitleChange(t *testing.T) {
+func TestChatService_SendMessage_EnvelopesOutsideSecretChat(t *testing.T) {
 	// Подготовка
-	service, mockChatRepo, _, _, _ := createTestService()
-	ctx := context.Background()
+	service, mockChatRepo, mockMsgRepo, _, mockUserClient := createTestService()
+	notBlocked(mockUserClient)
+	mockChatRepo.On("Get", "chat1").Return(createTestChat("chat1", domain.ChatKindDirect), nil)
 
-	// Запрос без title
-	req := &chatpb.UpdateGroupChatRequest{
-		ChatId:          "group-123",
-		Title:           "", // Пустая строка означает "не менять"
-		AddMemberIds:    []string{"user3"},
-		RemoveMemberIds: []string{},
-		RequesterId:     "creator1",
-	}
+	// Выполнение
+	_, err := service.SendMessage(context.Background(), domain.Message{
+		ChatID:    "chat1",
+		AuthorID:  "user1",
+		Envelopes: []domain.Envelope{{UserID: "user2", DeviceID: "phone", Ciphertext: []byte{1}}},
+	})
 
-	expectedChat := createTestChat("group-123", domain.ChatKindGroup)
+	// Проверки
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockMsgRepo.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
 
-	// Настройка моков - title передается как nil
-	mockChatRepo.On("UpdateGroup",
-		"group-123",
-		(*string)(nil), // title должен быть nil
-		[]string{"user3"},
-		[]string{},
-		"creator1",
-		mock.Anything,
-	).Return(expectedChat, nil)
+func TestChatService_UpdateMessage_EncryptedDenied(t *testing.T) {
+	// Подготовка
+	service, _, mockMsgRepo, _, _ := createTestService()
+	mockMsgRepo.On("Get", "msg1").Return(domain.Message{ID: "msg1", ChatID: "secret1", AuthorID: "user1", Type: domain.MessageTypeEncrypted}, nil)
+	text := "правка"
 
 	// Выполнение
-	chat, err := service.UpdateGroupChat(ctx, req)
+	_, err := service.UpdateMessage(context.Background(), "msg1", "user1", &text, nil)
 
 	// Проверки
-	assert.NoError(t, err)
-	assert.Equal(t, expectedChat, chat)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockMsgRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
 
-	mockChatRepo.AssertExpectations(t)
+// createTestRetentionService создает сервис с очисткой истории: удалённые стираются через 30 дней
+func createTestRetentionService(maxPerChat int64) (*ChatService, *MockChatRepository, *MockMessageRepository, *MockKafkaProducer, *MockRetentionRepository, *MockMediaResolver) {
+	service, mockChatRepo, mockMsgRepo, mockKafka, _ := createTestService()
+	mockRetention := &MockRetentionRepository{}
+	mockMedia := &MockMediaResolver{}
+	WithMedia(mockMedia)(service)
+	WithRetention(mockRetention, 30*24*time.Hour, maxPerChat)(service)
+	return service, mockChatRepo, mockMsgRepo, mockKafka, mockRetention, mockMedia
 }
 
-func TestChatService_ListReadMessages_Success(t *testing.T) {
+func TestChatService_ApplyRetention_PurgesDeletedMessages(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockRetention, mockMedia := createTestRetentionService(0)
 	ctx := context.Background()
 
-	expectedMessages := []domain.Message{
-		createTestMessage("msg-1", "chat1", "user1", "Read message 1"),
-		createTestMessage("msg-2", "chat1", "user2", "Read message 2"),
-	}
-
-	// Настройка моков
-	mockMsgRepo.On("ListReadMessages", "user1", "chat1", 10).Return(expectedMessages, nil)
+	deleted := createTestMessage("msg1", "chat1", "user1", "")
+	deleted.Deleted = true
+	deleted.Media = []domain.Media{{ID: "file1"}, {ID: "file2"}, {ID: "file3"}}
+	mockRetention.On("Claim", mock.Anything, retentionLease).Return(domain.RetentionCheckpoint{ID: "messages", Token: "tok1"}, true, nil)
+	mockMsgRepo.On("ListDeletedBefore", mock.Anything, purgeBatchSize).Return([]domain.Message{deleted}, nil)
+	// file3 прикреплён и к сообщению, которое остаётся в чате
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file1", "file2", "file3"}).Return([]string{"file3"}, nil).Once()
+	mockMedia.On("DetachFromChat", "file1", "chat1").Return(nil).Once()
+	mockMedia.On("DetachFromChat", "file2", "chat1").Return(domain.ErrMediaNotFound).Once()
+	mockMsgRepo.On("Purge", []string{"msg1"}).Return(nil).Once()
+	mockKafka.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e domain.SearchEvent) bool {
+		evt, ok := e.Data.(domain.MessagesDeletedEvent)
+		return ok && e.Type == "message.deleted" && evt.Reason == domain.DeleteReasonRetention &&
+			len(evt.MessageIDs) == 1 && len(evt.MediaIDs) == 3
+	})).Return(nil).Once()
+	mockMsgRepo.On("ClearSavedOnDeleted").Return(int64(3), nil)
+	mockChatRepo.On("ListAll", "", retentionChatBatch).Return([]domain.Chat{}, nil)
+	mockRetention.On("Finish", "tok1", mock.Anything).Return(nil).Once()
 
 	// Выполнение
-	messages, err := service.ListReadMessages(ctx, "user1", "chat1", 10)
+	stats, ran, err := service.ApplyRetention(ctx)
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Len(t, messages, 2)
-	assert.Equal(t, "Read message 1", messages[0].Text)
+	assert.True(t, ran)
+	assert.Equal(t, 1, stats.DeletedPurged)
+	assert.Equal(t, 2, stats.MediaDetached)
+	assert.Equal(t, int64(3), stats.SavedRefsRemoved)
+	mockMedia.AssertNotCalled(t, "DetachFromChat", "file3", "chat1")
+	mockMedia.AssertExpectations(t)
+	mockMsgRepo.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+	mockRetention.AssertExpectations(t)
+}
+
+func TestChatService_ApplyRetention_PurgesWhenMediaServiceFails(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockRetention, mockMedia := createTestRetentionService(0)
+
+	deleted := createTestMessage("msg1", "chat1", "user1", "")
+	deleted.Deleted = true
+	deleted.Media = []domain.Media{{ID: "file1"}}
+	mockRetention.On("Claim", mock.Anything, retentionLease).Return(domain.RetentionCheckpoint{Token: "tok1"}, true, nil)
+	mockMsgRepo.On("ListDeletedBefore", mock.Anything, purgeBatchSize).Return([]domain.Message{deleted}, nil)
+	mockMsgRepo.On("Purge", []string{"msg1"}).Return(nil).Once()
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Once()
+	mockMsgRepo.On("MediaInUse", "chat1", []string{"file1"}).Return([]string{}, nil)
+	mockMedia.On("DetachFromChat", "file1", "chat1").Return(errors.New("media-service detach: status 503"))
+	mockMsgRepo.On("ClearSavedOnDeleted").Return(int64(0), nil)
+	mockChatRepo.On("ListAll", "", retentionChatBatch).Return([]domain.Chat{}, nil)
+	mockRetention.On("Finish", "tok1", mock.Anything).Return(nil).Once()
+
+	// Выполнение
+	stats, ran, err := service.ApplyRetention(context.Background())
 
+	// Проверки: сообщение стёрто, неснятая отметка проход не прерывает
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, stats.DeletedPurged)
+	assert.Equal(t, 0, stats.MediaDetached)
 	mockMsgRepo.AssertExpectations(t)
+	mockRetention.AssertExpectations(t)
 }
 
-func TestChatService_ListReadMessages_WithEmptyChatID(t *testing.T) {
+func TestChatService_ApplyRetention_CapsHistoryAndDropsOrphanReadStates(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
+	service, mockChatRepo, mockMsgRepo, mockKafka, mockRetention, _ := createTestRetentionService(8)
+	mockReads := &MockReadStateRepository{}
+	WithReadStates(mockReads)(service)
 	ctx := context.Background()
 
-	expectedMessages := []domain.Message{
-		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
-		createTestMessage("msg-2", "chat2", "user1", "Message 2"),
+	chat := createTestChat("chat1", domain.ChatKindGroup)
+	chat.LastSeq = 10
+	old := []domain.Message{
+		{ID: "msg1", ChatID: "chat1", Seq: 1},
+		{ID: "msg2", ChatID: "chat1", Seq: 2},
 	}
-
-	// Настройка моков - chatID пустая, получаем все прочитанные сообщения пользователя
-	mockMsgRepo.On("ListReadMessages", "user1", "", 10).Return(expectedMessages, nil)
+	mockRetention.On("Claim", mock.Anything, retentionLease).Return(domain.RetentionCheckpoint{ChatCursor: "chat0", Token: "tok1"}, true, nil)
+	mockMsgRepo.On("ListDeletedBefore", mock.Anything, purgeBatchSize).Return([]domain.Message{}, nil)
+	mockMsgRepo.On("ClearSavedOnDeleted").Return(int64(0), nil)
+	mockChatRepo.On("ListAll", "chat0", retentionChatBatch).Return([]domain.Chat{chat}, nil)
+	mockMsgRepo.On("ListUpToSeq", "chat1", int64(2), purgeBatchSize).Return(old, nil)
+	mockMsgRepo.On("Purge", []string{"msg1", "msg2"}).Return(nil).Once()
+	mockChatRepo.On("SetLastMessage", "chat1", mock.Anything).Return(nil)
+	mockChatRepo.On("Get", "chat1").Return(chat, nil)
+	mockKafka.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e domain.SearchEvent) bool {
+		evt, ok := e.Data.(domain.MessagesDeletedEvent)
+		return ok && evt.Reason == domain.DeleteReasonRetention && len(evt.MessageIDs) == 2
+	})).Return(nil).Once()
+	mockReads.On("ListByChat", "chat1").Return([]domain.ReadState{
+		{ChatID: "chat1", UserID: "user2", LastReadSeq: 10},
+		{ChatID: "chat1", UserID: "left", LastReadSeq: 4},
+	}, nil)
+	mockReads.On("DeleteUsers", "chat1", []string{"left"}).Return(int64(1), nil).Once()
+	mockRetention.On("Finish", "tok1", mock.Anything).Return(nil).Once()
 
 	// Выполнение
-	messages, err := service.ListReadMessages(ctx, "user1", "", 10)
+	stats, _, err := service.ApplyRetention(ctx)
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Len(t, messages, 2)
-
+	assert.Equal(t, 2, stats.CappedPurged)
+	assert.Equal(t, int64(1), stats.ReadStatesRemoved)
+	assert.Equal(t, 1, stats.ChatsScanned)
 	mockMsgRepo.AssertExpectations(t)
+	mockReads.AssertExpectations(t)
+	mockKafka.AssertExpectations(t)
+	mockRetention.AssertExpectations(t)
 }
 
-func TestChatService_DeleteMessage_HardDelete(t *testing.T) {
+func TestChatService_ApplyRetention_RenewsLeaseEveryPurgeBatch(t *testing.T) {
 	// Подготовка
-	service, _, mockMsgRepo, _, _ := createTestService()
+	service, _, mockMsgRepo, mockKafka, mockRetention, _ := createTestRetentionService(0)
 
-	expectedMessages := []domain.Message{
-		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
+	batch := make([]domain.Message, purgeBatchSize)
+	for i := range batch {
+		batch[i] = domain.Message{ID: fmt.Sprintf("msg%d", i), ChatID: "chat1", Deleted: true}
 	}
+	mockRetention.On("Claim", mock.Anything, retentionLease).Return(domain.RetentionCheckpoint{ChatCursor: "chat0", Token: "tok1"}, true, nil)
+	mockMsgRepo.On("ListDeletedBefore", mock.Anything, purgeBatchSize).Return(batch, nil).Once()
+	mockMsgRepo.On("Purge", mock.Anything).Return(nil).Once()
+	mockKafka.On("PublishEvent", mock.Anything, mock.Anything).Return(nil).Once()
+	// Пока пачка стиралась, аренда истекла и задачу захватила другая реплика
+	mockRetention.On("Checkpoint", "tok1", "chat0", mock.Anything).Return(domain.ErrLeaseLost).Once()
+
+	// Выполнение
+	stats, ran, err := service.ApplyRetention(context.Background())
+
+	// Проверки: следующая пачка не читается, проход не закрывается чужим Finish
+	assert.ErrorIs(t, err, domain.ErrLeaseLost)
+	assert.True(t, ran)
+	assert.Equal(t, purgeBatchSize, stats.DeletedPurged)
+	mockMsgRepo.AssertNumberOfCalls(t, "ListDeletedBefore", 1)
+	mockMsgRepo.AssertNotCalled(t, "ClearSavedOnDeleted")
+	mockRetention.AssertNotCalled(t, "Finish", mock.Anything, mock.Anything)
+	mockRetention.AssertExpectations(t)
+}
 
-	// Настройка моков - жесткое удаление
-	mockMsgRepo.On("Delete", []string{"msg-1"}, true, "user1").Return(expectedMessages, nil)
+func TestChatService_ApplyRetention_SkipsWhenHeldByAnotherReplica(t *testing.T) {
+	// Подготовка
+	service, mockChatRepo, mockMsgRepo, _, mockRetention, _ := createTestRetentionService(0)
+	mockRetention.On("Claim", mock.Anything, retentionLease).Return(domain.RetentionCheckpoint{}, false, nil)
 
 	// Выполнение
-	result, err := service.DeleteMessage([]string{"msg-1"}, true, "user1")
+	_, ran, err := service.ApplyRetention(context.Background())
 
 	// Проверки
 	assert.NoError(t, err)
-	assert.Len(t, result, 1)
-
-	mockMsgRepo.AssertExpectations(t)
+	assert.False(t, ran)
+	mockMsgRepo.AssertNotCalled(t, "ListDeletedBefore", mock.Anything, mock.Anything)
+	mockChatRepo.AssertNotCalled(t, "ListAll", mock.Anything, mock.Anything)
 }
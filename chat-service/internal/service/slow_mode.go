@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	"time"
+)
+
+// Самый долгий медленный режим — час
+const maxSlowMode = int64(time.Hour / time.Second)
+
+// WithSendLimits включает медленный режим и лимит частоты: в группе участник отправляет не больше
+// perMember сообщений за window (0 — без лимита, действует только медленный режим)
+func WithSendLimits(r repository.SendLimitRepository, perMember int64, window time.Duration) Option {
+	return func(s *ChatService) {
+		s.limits = r
+		s.sendLimit = perMember
+		s.sendWindow = window
+	}
+}
+
+// SetSlowMode включает медленный режим группы (seconds — интервал между сообщениями участника, 0 — выключить).
+// Меняет владелец; сам он и администраторы ограничениям не подчиняются.
+func (s *ChatService) SetSlowMode(ctx context.Context, chatID, requesterID string, seconds int64) (domain.Chat, error) {
+	if seconds < 0 || seconds > maxSlowMode {
+		return domain.Chat{}, fmt.Errorf("%w: медленный режим — от 0 до %d секунд", domain.ErrInvalidArgument, maxSlowMode)
+	}
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+	if chat.Kind != domain.ChatKindGroup {
+		return domain.Chat{}, fmt.Errorf("%w: медленный режим есть только в группах", domain.ErrInvalidArgument)
+	}
+	if !chat.CanManage(requesterID) {
+		return domain.Chat{}, domain.ErrPermissionDenied
+	}
+	if chat.SlowModeSeconds == seconds {
+		return chat, nil
+	}
+
+	updated, err := s.chats.SetSlowMode(chatID, seconds)
+	if err != nil {
+		return domain.Chat{}, err
+	}
+
+	text := "Медленный режим выключен"
+	if seconds > 0 {
+		text = "Медленный режим: одно сообщение в " + formatSlowMode(seconds)
+	}
+	s.sendSystemMessage(ctx, chatID, domain.SystemEvent{
+		Action:  domain.SystemActionSlowMode,
+		ActorID: requesterID,
+	}, text)
+
+	return updated, nil
+}
+
+// checkSendLimits применяет медленный режим и лимит частоты к участнику группы.
+// Недоступный Redis не останавливает переписку: при ошибке счётчиков отправка разрешается.
+func (s *ChatService) checkSendLimits(ctx context.Context, chat domain.Chat, userID string) error {
+	if s.limits == nil || chat.Kind != domain.ChatKindGroup {
+		return nil
+	}
+	switch chat.RoleOf(userID) {
+	case domain.ChatRoleOwner, domain.ChatRoleAdmin:
+		return nil
+	}
+
+	if chat.SlowModeSeconds > 0 {
+		wait, err := s.limits.TakeSlowModeSlot(ctx, chat.ID, userID, time.Duration(chat.SlowModeSeconds)*time.Second)
+		if err == nil && wait > 0 {
+			return &domain.RateLimitError{RetryAfter: wait, SlowMode: true}
+		}
+	}
+	if s.sendLimit > 0 && s.sendWindow > 0 {
+		wait, err := s.limits.CountSend(ctx, chat.ID, userID, s.sendLimit, s.sendWindow)
+		if err == nil && wait > 0 {
+			return &domain.RateLimitError{RetryAfter: wait}
+		}
+	}
+	return nil
+}
+
+// formatSlowMode — «30 с», «5 мин», «1 ч» для системного сообщения
+func formatSlowMode(seconds int64) string {
+	switch {
+	case seconds%3600 == 0:
+		return fmt.Sprintf("%d ч", seconds/3600)
+	case seconds%60 == 0:
+		return fmt.Sprintf("%d мин", seconds/60)
+	default:
+		return fmt.Sprintf("%d с", seconds)
+	}
+}
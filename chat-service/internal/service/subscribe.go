@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Как часто поток перечитывает журнал без сигнала: записи с других реплик приходят не позже
+	defaultStreamPoll = time.Second
+	// Сколько записей журнала читается за раз
+	streamBatch = 100
+)
+
+// WithStreamPoll задаёт период опроса журнала в SubscribeChats
+func WithStreamPoll(d time.Duration) Option {
+	return func(s *ChatService) {
+		if d > 0 {
+			s.streamPoll = d
+		}
+	}
+}
+
+// SubscribeChats отдаёт в send обновления журнала пользователя: сообщения, прочтения и изменения
+// состава всех его чатов. Пустой resumeToken — только новые обновления, иначе — всё после токена.
+// Между ними приходят статусы набора в чатах пользователя: они не пишутся в журнал и не сдвигают токен.
+//
+// Журнал служит буфером: следующая порция читается, только когда send вернулся, поэтому медленный
+// получатель не копит память на сервере, а просто отстаёт. Отставший дальше срока хранения журнала
+// получает Resync. Отмена ctx завершает поток без ошибки; ошибка send возвращается как есть.
+func (s *ChatService) SubscribeChats(ctx context.Context, userID, resumeToken string, send func(domain.StreamEvent) error) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user_id обязателен", domain.ErrInvalidArgument)
+	}
+	if s.updates == nil {
+		return fmt.Errorf("%w: журнал обновлений выключен", domain.ErrInvalidArgument)
+	}
+	// Подписываемся до чтения журнала: запись между чтением и ожиданием всё равно разбудит поток
+	wake, unsubscribe := s.streams.subscribe(userID)
+	defer unsubscribe()
+	typing, stopTyping := s.typingSubs.subscribe(userID)
+	defer stopTyping()
+
+	since, err := s.resumeFrom(userID, resumeToken)
+	if err != nil {
+		return err
+	}
+
+	poll := time.NewTicker(s.streamPoll)
+	defer poll.Stop()
+	for {
+		page, err := s.GetUpdates(ctx, userID, since, streamBatch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if page.Resync {
+			if err := send(domain.StreamEvent{Resync: true, ResumeToken: resumeTokenOf(page.Seq)}); err != nil {
+				return err
+			}
+			since = page.Seq
+			continue
+		}
+		for i := range page.Updates {
+			u := page.Updates[i]
+			if err := send(domain.StreamEvent{Update: &u, ResumeToken: resumeTokenOf(u.Seq)}); err != nil {
+				return err
+			}
+		}
+		since = page.Seq
+		// Страница упёрлась в limit — читаем дальше сразу; пустая страница при has_more — пропуск seq,
+		// который ещё вставляется: ждём следующего опроса
+		if page.HasMore && len(page.Updates) > 0 {
+			continue
+		}
+
+		if err := waitStream(ctx, wake, poll.C, typing, send); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// waitStream ждёт сигнала перечитать журнал (запись на этой реплике или очередной опрос),
+// по пути отдавая в send статусы набора. Возвращает nil и при отмене ctx
+func waitStream(ctx context.Context, wake <-chan struct{}, poll <-chan time.Time,
+	typing <-chan domain.TypingStatus, send func(domain.StreamEvent) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+			return nil
+		case <-poll:
+			return nil
+		case st := <-typing:
+			if err := send(domain.StreamEvent{Typing: &st}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resumeFrom переводит токен в seq журнала; пустой токен — текущий конец журнала
+func (s *ChatService) resumeFrom(userID, token string) (int64, error) {
+	if token == "" {
+		return s.updates.CurrentSeq(userID)
+	}
+	seq, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("%w: некорректный resume_token", domain.ErrInvalidArgument)
+	}
+	return seq, nil
+}
+
+// resumeTokenOf — токен продолжения после записи seq. Клиент хранит его как непрозрачную строку.
+func resumeTokenOf(seq int64) string {
+	return strconv.FormatInt(seq, 10)
+}
+
+// updateHub будит потоки SubscribeChats этой реплики, когда в журнал пользователя что-то записано.
+// Записи с других реплик поток замечает при опросе раз в streamPoll.
+type updateHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+func newUpdateHub() *updateHub {
+	return &updateHub{subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+// subscribe возвращает канал сигналов для пользователя и функцию отписки.
+// Канал с буфером 1: сигналы, пришедшие пока поток занят, склеиваются в один.
+func (h *updateHub) subscribe(userID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan struct{}]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[userID], ch)
+		if len(h.subs[userID]) == 0 {
+			delete(h.subs, userID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// notify будит потоки пользователей, не блокируясь
+func (h *updateHub) notify(userIDs []string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range userIDs {
+		for ch := range h.subs[id] {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
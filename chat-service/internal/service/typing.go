@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"main/internal/domain"
+	"main/internal/repository"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// Сколько живёт статус без повторного обновления
+	typingTTL = 6 * time.Second
+	// Не чаще одного обновления на (чат, пользователь, действие) за этот интервал
+	typingThrottle = 2 * time.Second
+	// Через сколько переподписываться, если подписка на статусы оборвалась
+	typingResubscribe = time.Second
+	// Сколько статусов ждёт занятый поток SubscribeChats; лишние отбрасываются
+	typingBuffer = 16
+)
+
+// WithTyping включает индикаторы набора
+func WithTyping(r repository.TypingRepository) Option {
+	return func(s *ChatService) {
+		s.typing = r
+	}
+}
+
+// SetTyping обновляет статус набора участника и рассылает его остальным участникам чата
+// в их потоки SubscribeChats. Возвращает true, если обновление отброшено троттлингом.
+func (s *ChatService) SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction) (bool, error) {
+	switch action {
+	case domain.TypingActionTyping, domain.TypingActionRecording, domain.TypingActionUploading, domain.TypingActionCancel:
+	default:
+		return false, domain.ErrInvalidArgument
+	}
+
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return false, err
+	}
+	if chat.RoleOf(userID) == "" {
+		return false, domain.ErrPermissionDenied
+	}
+	if s.typing == nil {
+		return false, nil
+	}
+
+	status := domain.TypingStatus{ChatID: chatID, UserID: userID, Action: action}
+	if action == domain.TypingActionCancel {
+		if err := s.typing.ClearTyping(ctx, chatID, userID); err != nil {
+			return false, err
+		}
+	} else {
+		allowed, err := s.typing.Allow(ctx, "typing:"+chatID+":"+userID+":"+string(action), typingThrottle)
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			return true, nil
+		}
+		if err := s.typing.SetTyping(ctx, chatID, userID, action, typingTTL); err != nil {
+			return false, err
+		}
+		status.ExpiresAt = time.Now().Add(typingTTL).UnixMilli()
+	}
+
+	// Рассылаем только участникам чата, кроме самого автора
+	recipients := make([]string, 0, len(chat.MemberIDs))
+	for _, id := range chat.MemberIDs {
+		if id != userID {
+			recipients = append(recipients, id)
+		}
+	}
+	return false, s.typing.PublishTyping(ctx, recipients, status)
+}
+
+// RunTypingRelay слушает статусы набора со всех реплик и раздаёт их потокам SubscribeChats этой реплики
+// до отмены ctx. Оборванная подписка восстанавливается; статусы, опубликованные в разрыв, теряются.
+func (s *ChatService) RunTypingRelay(ctx context.Context, log zerolog.Logger) {
+	if s.typing == nil {
+		return
+	}
+	for {
+		if err := s.typing.SubscribeTyping(ctx, s.typingSubs.notify); err != nil {
+			log.Error().Err(err).Msg("typing subscription failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(typingResubscribe):
+		}
+	}
+}
+
+// ListTyping возвращает актуальные статусы набора в чате
+func (s *ChatService) ListTyping(ctx context.Context, chatID, requesterID string) ([]domain.TypingStatus, error) {
+	chat, err := s.loadChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if chat.RoleOf(requesterID) == "" {
+		return nil, domain.ErrPermissionDenied
+	}
+	if s.typing == nil {
+		return nil, nil
+	}
+	return s.typing.ListTyping(ctx, chatID)
+}
+
+// typingHub раздаёт статусы набора потокам SubscribeChats этой реплики. Статус живёт секунды,
+// поэтому потоку, который не успевает их забирать, лишние не копятся, а отбрасываются.
+type typingHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan domain.TypingStatus]struct{}
+}
+
+func newTypingHub() *typingHub {
+	return &typingHub{subs: make(map[string]map[chan domain.TypingStatus]struct{})}
+}
+
+// subscribe возвращает канал статусов для пользователя и функцию отписки
+func (h *typingHub) subscribe(userID string) (<-chan domain.TypingStatus, func()) {
+	ch := make(chan domain.TypingStatus, typingBuffer)
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan domain.TypingStatus]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[userID], ch)
+		if len(h.subs[userID]) == 0 {
+			delete(h.subs, userID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// notify передаёт статус потокам пользователей, не блокируясь
+func (h *typingHub) notify(userIDs []string, status domain.TypingStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range userIDs {
+		for ch := range h.subs[id] {
+			select {
+			case ch <- status:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain"
+	"main/internal/repository"
+	"time"
+)
+
+const (
+	defaultUpdatesLimit = 100
+	maxUpdatesLimit     = 1000
+	// Сколько хранится журнал; клиент, отставший сильнее, получает resync
+	updateLogRetention = 7 * 24 * time.Hour
+	// Пропуск seq моложе этого срока считается записью, которая ещё вставляется
+	updateGapGrace = 30 * time.Second
+)
+
+// WithUpdateLog включает персональные журналы обновлений для синхронизации устройств
+func WithUpdateLog(r repository.UpdateLogRepository) Option {
+	return func(s *ChatService) {
+		s.updates = r
+	}
+}
+
+// GetUpdates возвращает обновления пользователя после sinceSeq.
+// Если журнал с этого места уже очищен, возвращается Resync и текущий seq: клиент
+// перезагружает состояние (ListChats, ListMessages) и продолжает с него.
+func (s *ChatService) GetUpdates(ctx context.Context, userID string, sinceSeq int64, limit int) (domain.UpdatesPage, error) {
+	if sinceSeq < 0 {
+		return domain.UpdatesPage{}, fmt.Errorf("%w: since_seq не может быть отрицательным", domain.ErrInvalidArgument)
+	}
+	if s.updates == nil {
+		return domain.UpdatesPage{Resync: true}, nil
+	}
+	if limit <= 0 {
+		limit = defaultUpdatesLimit
+	}
+	if limit > maxUpdatesLimit {
+		limit = maxUpdatesLimit
+	}
+
+	current, err := s.updates.CurrentSeq(userID)
+	if err != nil {
+		return domain.UpdatesPage{}, err
+	}
+	if sinceSeq > current {
+		return domain.UpdatesPage{Seq: current, Resync: true}, nil
+	}
+	if sinceSeq == current {
+		return domain.UpdatesPage{Updates: []domain.UserUpdate{}, Seq: current}, nil
+	}
+	oldest, ok, err := s.updates.OldestSeq(userID)
+	if err != nil {
+		return domain.UpdatesPage{}, err
+	}
+	if !ok || sinceSeq+1 < oldest {
+		return domain.UpdatesPage{Seq: current, Resync: true}, nil
+	}
+
+	list, err := s.updates.List(userID, sinceSeq, limit)
+	if err != nil {
+		return domain.UpdatesPage{}, err
+	}
+	// Выдаём записи без пропусков: пропущенный seq может ещё вставляться, и клиент,
+	// сохранивший seq после него, потерял бы обновление. Давний пропуск (сбой записи) пропускаем.
+	updates := make([]domain.UserUpdate, 0, len(list))
+	next := sinceSeq + 1
+	gapDeadline := time.Now().Add(-updateGapGrace).Unix()
+	for _, u := range list {
+		if u.Seq != next && u.CreatedAt > gapDeadline {
+			break
+		}
+		updates = append(updates, u)
+		next = u.Seq + 1
+	}
+	if err := s.attachMessages(updates); err != nil {
+		return domain.UpdatesPage{}, err
+	}
+
+	return domain.UpdatesPage{
+		Updates: updates,
+		Seq:     next - 1,
+		HasMore: next-1 < current,
+	}, nil
+}
+
+// PruneUpdateLog удаляет записи журналов старше updateLogRetention
+func (s *ChatService) PruneUpdateLog(ctx context.Context) error {
+	if s.updates == nil {
+		return nil
+	}
+	return s.updates.DeleteBefore(time.Now().Add(-updateLogRetention).Unix())
+}
+
+// attachMessages подгружает сообщения для message.new, message.edited и poll.updated.
+// Удалённое безвозвратно сообщение не находится — о нём клиент узнает из message.deleted.
+func (s *ChatService) attachMessages(updates []domain.UserUpdate) error {
+	var ids []string
+	for _, u := range updates {
+		if carriesMessages(u.Type) {
+			ids = append(ids, u.MessageIDs...)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	msgs, err := s.msgs.GetMany(ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]domain.Message, len(msgs))
+	for _, m := range msgs {
+		byID[m.ID] = m
+	}
+	for i, u := range updates {
+		if !carriesMessages(u.Type) {
+			continue
+		}
+		for _, id := range u.MessageIDs {
+			if m, ok := byID[id]; ok {
+				updates[i].Messages = append(updates[i].Messages, m)
+			}
+		}
+	}
+	return nil
+}
+
+// carriesMessages — для каких обновлений клиенту отдаются сами сообщения
+func carriesMessages(t domain.UpdateType) bool {
+	return t == domain.UpdateMessageNew || t == domain.UpdateMessageEdited || t == domain.UpdatePoll
+}
+
+// logUpdate записывает обновление в журналы пользователей. Вызывается в inTx вместе с изменением,
+// о котором пишет, и фиксируется или откатывается вместе с ним; ошибку вызывающий пропускает через emit.
+func (s *ChatService) logUpdate(ctx context.Context, userIDs []string, u domain.UserUpdate) error {
+	if s.updates == nil || len(userIDs) == 0 {
+		return nil
+	}
+	u.CreatedAt = time.Now().Unix()
+	if err := s.updates.Append(ctx, userIDs, u); err != nil {
+		return err
+	}
+	if wake, ok := ctx.Value(pendingWakeKey{}).(*pendingWake); ok {
+		wake.userIDs = append(wake.userIDs, userIDs...)
+	} else {
+		s.streams.notify(userIDs)
+	}
+	return nil
+}
+
+// logMessages пишет обновление о сообщениях чата его участникам.
+// Подписчики каналов в журнал не попадают: их может быть очень много, новые посты они
+// догоняют через ListMessages по seq.
+func (s *ChatService) logMessages(ctx context.Context, chat domain.Chat, typ domain.UpdateType, actorID string, messageIDs []string) error {
+	return s.logUpdate(ctx, chat.MemberIDs, domain.UserUpdate{
+		Type:       typ,
+		ChatID:     chat.ID,
+		MessageIDs: messageIDs,
+		ActorID:    actorID,
+	})
+}
+
+// logChatMessages — logMessages, когда чат ещё не загружен
+func (s *ChatService) logChatMessages(ctx context.Context, chatID string, typ domain.UpdateType, actorID string, messageIDs []string) error {
+	if s.updates == nil {
+		return nil
+	}
+	chat, err := s.chats.Get(chatID)
+	if err != nil {
+		return err
+	}
+	return s.logMessages(ctx, chat, typ, actorID, messageIDs)
+}
+
+// logMembership пишет изменение состава чата участникам и тем, кого оно касается
+func (s *ChatService) logMembership(ctx context.Context, chat domain.Chat, action, actorID string, userIDs []string) error {
+	recipients := append([]string{}, chat.MemberIDs...)
+	for _, id := range userIDs {
+		if chat.RoleOf(id) == "" {
+			recipients = append(recipients, id)
+		}
+	}
+	return s.logUpdate(ctx, recipients, domain.UserUpdate{
+		Type:    domain.UpdateMembership,
+		ChatID:  chat.ID,
+		ActorID: actorID,
+		Action:  action,
+		UserIDs: userIDs,
+	})
+}
+
+// logRead пишет отметку прочтения участникам чата (для отметок «прочитано») и самому читателю —
+// для счётчиков на других устройствах; в канале — только читателю
+func (s *ChatService) logRead(ctx context.Context, chat domain.Chat, userID string, seq int64) error {
+	recipients := chat.MemberIDs
+	if chat.Kind == domain.ChatKindChannel {
+		recipients = []string{userID}
+	}
+	return s.logUpdate(ctx, recipients, domain.UserUpdate{
+		Type:    domain.UpdateRead,
+		ChatID:  chat.ID,
+		ActorID: userID,
+		ReadSeq: seq,
+	})
+}
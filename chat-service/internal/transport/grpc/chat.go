@@ -1,31 +1,94 @@
 package grpc
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"io"
 	"strconv"
+	"time"
 
+	"main/internal/archive"
 	"main/internal/domain"
 	chatpb "main/pkg/api"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // ChatServiceInterface - интерфейс для сервиса чатов
 type ChatServiceInterface interface {
 	CreateDirect(ctx context.Context, userID, peerID string) (domain.Chat, error)
+	CreateSecretChat(ctx context.Context, userID, peerID string) (domain.Chat, error)
 	CreateGroup(ctx context.Context, creatorID string, members []string, title string) (domain.Chat, error)
 	SendMessage(ctx context.Context, msg domain.Message) (domain.Message, error)
 	UpdateMessage(ctx context.Context, messageID, authorID string, text *string, media *[]domain.Media) (domain.Message, error)
-	DeleteMessage(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error)
-	ListMessages(ctx context.Context, chatID string, limit int, cursor string) ([]domain.Message, string, error)
+	ListMessageRevisions(ctx context.Context, messageID, requesterID string) ([]domain.MessageRevision, error)
+	DeleteMessage(ctx context.Context, messageIDs []string, hard bool, requesterID string) ([]domain.Message, error)
+	ListMessages(ctx context.Context, q domain.MessageQuery) (domain.MessagePage, error)
 	MarkRead(ctx context.Context, chatID, userID, messageID string) error
+	MarkDelivered(ctx context.Context, chatID, userID, messageID string) error
+	GetUnreadCount(ctx context.Context, chatID, userID string) (int64, error)
+	GetReadState(ctx context.Context, chatID, messageID, requesterID string) ([]domain.ReadState, []domain.ReadState, error)
+	ListMentions(ctx context.Context, userID, chatID string, limit int, cursor string) ([]domain.Mention, string, int64, error)
 	ToggleSaved(ctx context.Context, userID, messageID string, saved bool) error
 	ListSaved(ctx context.Context, userID string, limit int, cursor string) ([]domain.Message, string, error)
-	ListChats(ctx context.Context, userID string, limit int, cursor string) ([]domain.Chat, string, error)
+	ListChats(ctx context.Context, userID string, limit int, cursor string, archived bool) ([]domain.Chat, string, error)
+	ListFolderChats(ctx context.Context, userID, folderID string, limit int, cursor string) ([]domain.Chat, string, error)
+	PinChat(ctx context.Context, chatID, userID string, pinned bool) (domain.UserChatState, error)
+	ArchiveChat(ctx context.Context, chatID, userID string, archived bool) (domain.UserChatState, error)
+	MuteChat(ctx context.Context, chatID, userID string, until int64) (domain.UserChatState, error)
+	MarkChatUnread(ctx context.Context, chatID, userID string, unread bool) (domain.UserChatState, error)
 	GetChat(ctx context.Context, chatID string) (domain.Chat, error)
 	UpdateGroupChat(ctx context.Context, req *chatpb.UpdateGroupChatRequest) (domain.Chat, error)
+	SetMessageTTL(ctx context.Context, chatID, requesterID string, ttl int64) (domain.Chat, error)
+	SetSlowMode(ctx context.Context, chatID, requesterID string, seconds int64) (domain.Chat, error)
 	ListReadMessages(ctx context.Context, userID, chatID string, limit int) ([]domain.Message, error)
+	PinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error)
+	UnpinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error)
+	ListPinned(ctx context.Context, chatID, requesterID string) ([]domain.PinnedMessage, error)
+	ScheduleMessage(ctx context.Context, m domain.ScheduledMessage) (domain.ScheduledMessage, error)
+	ListScheduledMessages(ctx context.Context, chatID, authorID string) ([]domain.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, id, authorID string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id, authorID string) error
+	Vote(ctx context.Context, messageID, userID string, optionIDs []int) (domain.PollResults, error)
+	RetractVote(ctx context.Context, messageID, userID string) (domain.PollResults, error)
+	ClosePoll(ctx context.Context, messageID, requesterID string) (domain.PollResults, error)
+	GetPollResults(ctx context.Context, messageID, requesterID string) (domain.PollResults, error)
+	CreateInviteLink(ctx context.Context, chatID, requesterID string, settings domain.InviteSettings) (domain.InviteLink, error)
+	RevokeInviteLink(ctx context.Context, code, requesterID string) error
+	JoinByInvite(ctx context.Context, code, userID string) (domain.Chat, *domain.JoinRequest, error)
+	ListJoinRequests(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.JoinRequest, string, error)
+	ApproveJoinRequest(ctx context.Context, requestID, requesterID string) (domain.Chat, error)
+	RejectJoinRequest(ctx context.Context, requestID, requesterID string) error
+	CreateChannel(ctx context.Context, creatorID, title, handle string) (domain.Chat, error)
+	GetChannelByHandle(ctx context.Context, handle string) (domain.Chat, error)
+	SubscribeChannel(ctx context.Context, chatID, userID string) (domain.Chat, error)
+	UnsubscribeChannel(ctx context.Context, chatID, userID string) error
+	ListChannelSubscribers(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error)
+	SetChannelAdmin(ctx context.Context, chatID, requesterID, userID string, admin bool) (domain.Chat, error)
+	ViewMessages(ctx context.Context, chatID, userID string, messageIDs []string) error
+	SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction) (bool, error)
+	ListTyping(ctx context.Context, chatID, requesterID string) ([]domain.TypingStatus, error)
+	SaveDraft(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error)
+	GetDrafts(ctx context.Context, userID, chatID string) ([]domain.Draft, error)
+	ClearDraft(ctx context.Context, chatID, userID string, baseVersion int64, deviceID string) (domain.Draft, bool, error)
+	CreateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error)
+	UpdateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error)
+	DeleteFolder(ctx context.Context, folderID, userID string) error
+	ReorderFolders(ctx context.Context, userID string, folderIDs []string) ([]domain.ChatFolder, error)
+	ListFolders(ctx context.Context, userID string) ([]domain.ChatFolder, error)
+	GetUpdates(ctx context.Context, userID string, sinceSeq int64, limit int) (domain.UpdatesPage, error)
+	SubscribeChats(ctx context.Context, userID, resumeToken string, send func(domain.StreamEvent) error) error
+
+	ExportChat(ctx context.Context, chatID, requesterID, format string, w io.Writer) error
+	ImportChat(ctx context.Context, requesterID, title string, r io.Reader) (domain.ImportResult, error)
+
+	ReportMessage(ctx context.Context, messageID, reporterID, reason string) error
+	ListModerationQueue(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ModerationItem, string, error)
+	ResolveModeration(ctx context.Context, itemID, requesterID string, status domain.ModerationStatus) (domain.ModerationItem, error)
 }
 
 type ChatServer struct {
@@ -40,82 +103,281 @@ func NewChatServer(svc ChatServiceInterface) *ChatServer {
 // --- Messages ---
 
 func (s *ChatServer) SendMessage(ctx context.Context, req *chatpb.SendMessageRequest) (*chatpb.MessageResponse, error) {
-	msg, err := s.svc.SendMessage(ctx, domain.Message{
+	m := domain.Message{
 		ChatID:   req.ChatId,
 		AuthorID: req.AuthorId,
 		Text:     req.Text,
-		// media из req.Media можно промапить позже
-	})
+		Media:    fromProtoMedia(req.Media, req.AuthorId),
+
+		SenderDeviceID: req.SenderDeviceId,
+	}
+	for _, e := range req.Envelopes {
+		m.Envelopes = append(m.Envelopes, domain.Envelope{UserID: e.UserId, DeviceID: e.DeviceId, Ciphertext: e.Ciphertext})
+	}
+	if p := req.Poll; p != nil {
+		m.Poll = &domain.Poll{
+			Question:       p.Question,
+			MultipleChoice: p.MultipleChoice,
+			Anonymous:      p.Anonymous,
+			ClosesAt:       p.ClosesAt,
+		}
+		for _, text := range p.Options {
+			m.Poll.Options = append(m.Poll.Options, domain.PollOption{Text: text})
+		}
+	}
+	msg, err := s.svc.SendMessage(ctx, m)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to send message: %v", err)
+		return nil, toStatusError(err, "failed to send message")
 	}
 
-	return &chatpb.MessageResponse{
-		Message: &chatpb.Message{
-			Id:        msg.ID,
-			ChatId:    msg.ChatID,
-			AuthorId:  msg.AuthorID,
-			Text:      msg.Text,
-			CreatedAt: strconv.FormatInt(msg.CreatedAt, 10),
-			UpdatedAt: strconv.FormatInt(msg.UpdatedAt, 10),
-			Deleted:   msg.Deleted,
-		},
-	}, nil
+	return &chatpb.MessageResponse{Message: toProtoMessage(msg)}, nil
 }
 
 func (s *ChatServer) UpdateMessage(ctx context.Context, req *chatpb.UpdateMessageRequest) (*chatpb.MessageResponse, error) {
 	text := req.Text
 	msg, err := s.svc.UpdateMessage(ctx, req.MessageId, req.AuthorId, &text, nil)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update message: %v", err)
-	}
-	return &chatpb.MessageResponse{
-		Message: &chatpb.Message{
-			Id:        msg.ID,
-			ChatId:    msg.ChatID,
-			AuthorId:  msg.AuthorID,
-			Text:      msg.Text,
-			CreatedAt: strconv.FormatInt(msg.CreatedAt, 10),
-			UpdatedAt: strconv.FormatInt(msg.UpdatedAt, 10),
-			Deleted:   msg.Deleted,
-		},
-	}, nil
+		return nil, toStatusError(err, "failed to update message")
+	}
+	return &chatpb.MessageResponse{Message: toProtoMessage(msg)}, nil
+}
+
+func (s *ChatServer) ListMessageRevisions(ctx context.Context, req *chatpb.ListMessageRevisionsRequest) (*chatpb.ListMessageRevisionsResponse, error) {
+	revisions, err := s.svc.ListMessageRevisions(ctx, req.MessageId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list message revisions")
+	}
+	resp := make([]*chatpb.MessageRevision, 0, len(revisions))
+	for _, r := range revisions {
+		resp = append(resp, &chatpb.MessageRevision{
+			MessageId:  r.MessageID,
+			Revision:   int32(r.Revision),
+			Text:       r.Text,
+			Media:      toProtoMedia(r.Media),
+			CreatedAt:  strconv.FormatInt(r.CreatedAt, 10),
+			ReplacedAt: strconv.FormatInt(r.ReplacedAt, 10),
+		})
+	}
+	return &chatpb.ListMessageRevisionsResponse{Revisions: resp}, nil
+}
+
+// --- Scheduled messages ---
+
+func (s *ChatServer) ScheduleMessage(ctx context.Context, req *chatpb.ScheduleMessageRequest) (*chatpb.ScheduledMessageResponse, error) {
+	m, err := s.svc.ScheduleMessage(ctx, domain.ScheduledMessage{
+		ChatID:   req.ChatId,
+		AuthorID: req.AuthorId,
+		Text:     req.Text,
+		Media:    fromProtoMedia(req.Media, req.AuthorId),
+		SendAt:   req.SendAt,
+	})
+	if err != nil {
+		return nil, toStatusError(err, "failed to schedule message")
+	}
+	return &chatpb.ScheduledMessageResponse{Scheduled: toProtoScheduled(m)}, nil
+}
+
+func (s *ChatServer) ListScheduledMessages(ctx context.Context, req *chatpb.ListScheduledMessagesRequest) (*chatpb.ListScheduledMessagesResponse, error) {
+	list, err := s.svc.ListScheduledMessages(ctx, req.ChatId, req.AuthorId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list scheduled messages")
+	}
+	resp := make([]*chatpb.ScheduledMessage, 0, len(list))
+	for _, m := range list {
+		resp = append(resp, toProtoScheduled(m))
+	}
+	return &chatpb.ListScheduledMessagesResponse{Scheduled: resp}, nil
+}
+
+func (s *ChatServer) UpdateScheduledMessage(ctx context.Context, req *chatpb.UpdateScheduledMessageRequest) (*chatpb.ScheduledMessageResponse, error) {
+	var text *string
+	if req.Text != "" {
+		t := req.Text
+		text = &t
+	}
+	var media *[]domain.Media
+	if len(req.Media) > 0 {
+		md := fromProtoMedia(req.Media, req.AuthorId)
+		media = &md
+	}
+	var sendAt *int64
+	if req.SendAt != 0 {
+		at := req.SendAt
+		sendAt = &at
+	}
+	m, err := s.svc.UpdateScheduledMessage(ctx, req.Id, req.AuthorId, text, media, sendAt)
+	if err != nil {
+		return nil, toStatusError(err, "failed to update scheduled message")
+	}
+	return &chatpb.ScheduledMessageResponse{Scheduled: toProtoScheduled(m)}, nil
+}
+
+func (s *ChatServer) CancelScheduledMessage(ctx context.Context, req *chatpb.CancelScheduledMessageRequest) (*chatpb.CancelScheduledMessageResponse, error) {
+	if err := s.svc.CancelScheduledMessage(ctx, req.Id, req.AuthorId); err != nil {
+		return nil, toStatusError(err, "failed to cancel scheduled message")
+	}
+	return &chatpb.CancelScheduledMessageResponse{Success: true}, nil
+}
+
+// --- Polls ---
+
+func (s *ChatServer) Vote(ctx context.Context, req *chatpb.VoteRequest) (*chatpb.PollResponse, error) {
+	optionIDs := make([]int, 0, len(req.OptionIds))
+	for _, id := range req.OptionIds {
+		optionIDs = append(optionIDs, int(id))
+	}
+	res, err := s.svc.Vote(ctx, req.MessageId, req.UserId, optionIDs)
+	if err != nil {
+		return nil, toStatusError(err, "failed to vote")
+	}
+	return toProtoPollResponse(res), nil
+}
+
+func (s *ChatServer) RetractVote(ctx context.Context, req *chatpb.RetractVoteRequest) (*chatpb.PollResponse, error) {
+	res, err := s.svc.RetractVote(ctx, req.MessageId, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to retract vote")
+	}
+	return toProtoPollResponse(res), nil
+}
+
+func (s *ChatServer) ClosePoll(ctx context.Context, req *chatpb.ClosePollRequest) (*chatpb.PollResponse, error) {
+	res, err := s.svc.ClosePoll(ctx, req.MessageId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to close poll")
+	}
+	return toProtoPollResponse(res), nil
+}
+
+func (s *ChatServer) GetPollResults(ctx context.Context, req *chatpb.GetPollResultsRequest) (*chatpb.GetPollResultsResponse, error) {
+	res, err := s.svc.GetPollResults(ctx, req.MessageId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get poll results")
+	}
+	resp := &chatpb.GetPollResultsResponse{
+		Poll:        toProtoPoll(res.MessageID, res.Poll),
+		MyOptionIds: toInt32s(res.MyOptionIDs),
+	}
+	if res.Voters != nil {
+		for _, o := range res.Poll.Options {
+			resp.Voters = append(resp.Voters, &chatpb.PollOptionVoters{
+				OptionId: int32(o.ID),
+				UserIds:  res.Voters[o.ID],
+			})
+		}
+	}
+	return resp, nil
 }
 
 func (s *ChatServer) DeleteMessage(ctx context.Context, req *chatpb.DeleteMessageRequest) (*chatpb.DeleteMessageResponse, error) {
-	_, err := s.svc.DeleteMessage(req.MessageIds, req.HardDelete, req.RequesterId)
+	_, err := s.svc.DeleteMessage(ctx, req.MessageIds, req.HardDelete, req.RequesterId)
 	if err != nil {
 		return &chatpb.DeleteMessageResponse{Success: false, Message: "failed to delete messages: " + err.Error()}, nil
 	}
 	return &chatpb.DeleteMessageResponse{Success: true, Message: "deleted"}, nil
 }
 func (s *ChatServer) ListMessages(ctx context.Context, req *chatpb.ListMessagesRequest) (*chatpb.ListMessagesResponse, error) {
-	msgs, cursor, err := s.svc.ListMessages(ctx, req.ChatId, int(req.Limit), req.Cursor)
+	q := domain.MessageQuery{
+		ChatID:    req.ChatId,
+		Limit:     int(req.Limit),
+		BeforeSeq: req.BeforeSeq,
+		AfterSeq:  req.AfterSeq,
+		AroundSeq: req.AroundSeq,
+	}
+	// cursor — next_cursor предыдущего ответа, то же самое, что after_seq; prev_cursor — before_seq
+	if req.Cursor != "" && q.AfterSeq == 0 {
+		seq, err := strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %s", req.Cursor)
+		}
+		q.AfterSeq = seq
+	}
+	if req.PrevCursor != "" && q.BeforeSeq == 0 {
+		seq, err := strconv.ParseInt(req.PrevCursor, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid prev_cursor: %s", req.PrevCursor)
+		}
+		q.BeforeSeq = seq
+	}
+
+	page, err := s.svc.ListMessages(ctx, q)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list messages: %v", err)
+		return nil, toStatusError(err, "failed to list messages")
 	}
-	resp := make([]*chatpb.Message, 0, len(msgs))
-	for _, m := range msgs {
-		resp = append(resp, &chatpb.Message{
-			Id:        m.ID,
-			ChatId:    m.ChatID,
-			AuthorId:  m.AuthorID,
-			Text:      m.Text,
-			CreatedAt: strconv.FormatInt(m.CreatedAt, 10),
-			UpdatedAt: strconv.FormatInt(m.UpdatedAt, 10),
-			Deleted:   m.Deleted,
-		})
+	resp := make([]*chatpb.Message, 0, len(page.Messages))
+	for _, m := range page.Messages {
+		resp = append(resp, toProtoMessage(m))
 	}
-	return &chatpb.ListMessagesResponse{Messages: resp, NextCursor: cursor}, nil
+	// Курсоры указывают только туда, где ещё есть сообщения: последняя страница истории
+	// отдаёт prev_cursor для подгрузки более старых, но не next_cursor
+	var nextCursor, prevCursor string
+	if n := len(page.Messages); n > 0 {
+		if page.HasAfter {
+			nextCursor = strconv.FormatInt(page.Messages[n-1].Seq, 10)
+		}
+		if page.HasBefore {
+			prevCursor = strconv.FormatInt(page.Messages[0].Seq, 10)
+		}
+	}
+	return &chatpb.ListMessagesResponse{
+		Messages:   resp,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		HasBefore:  page.HasBefore,
+		HasAfter:   page.HasAfter,
+	}, nil
 }
 
 func (s *ChatServer) MarkRead(ctx context.Context, req *chatpb.MarkReadRequest) (*chatpb.MarkReadResponse, error) {
 	if err := s.svc.MarkRead(ctx, req.ChatId, req.UserId, req.MessageId); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to mark read: %v", err)
+		return nil, toStatusError(err, "failed to mark read")
 	}
 	return &chatpb.MarkReadResponse{Success: true}, nil
 }
 
+func (s *ChatServer) MarkDelivered(ctx context.Context, req *chatpb.MarkDeliveredRequest) (*chatpb.MarkDeliveredResponse, error) {
+	if err := s.svc.MarkDelivered(ctx, req.ChatId, req.UserId, req.MessageId); err != nil {
+		return nil, toStatusError(err, "failed to mark delivered")
+	}
+	return &chatpb.MarkDeliveredResponse{Success: true}, nil
+}
+
+func (s *ChatServer) GetUnreadCount(ctx context.Context, req *chatpb.GetUnreadCountRequest) (*chatpb.GetUnreadCountResponse, error) {
+	count, err := s.svc.GetUnreadCount(ctx, req.ChatId, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get unread count")
+	}
+	return &chatpb.GetUnreadCountResponse{UnreadCount: count}, nil
+}
+
+func (s *ChatServer) GetReadState(ctx context.Context, req *chatpb.GetReadStateRequest) (*chatpb.GetReadStateResponse, error) {
+	read, delivered, err := s.svc.GetReadState(ctx, req.ChatId, req.MessageId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get read state")
+	}
+	return &chatpb.GetReadStateResponse{
+		Read:      toProtoReadStates(read),
+		Delivered: toProtoReadStates(delivered),
+	}, nil
+}
+
+func (s *ChatServer) ListMentions(ctx context.Context, req *chatpb.ListMentionsRequest) (*chatpb.ListMentionsResponse, error) {
+	mentions, cursor, count, err := s.svc.ListMentions(ctx, req.UserId, req.ChatId, int(req.Limit), req.Cursor)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list mentions: %v", err)
+	}
+	resp := make([]*chatpb.Mention, 0, len(mentions))
+	for _, m := range mentions {
+		resp = append(resp, &chatpb.Mention{
+			ChatId:    m.ChatID,
+			MessageId: m.MessageID,
+			AuthorId:  m.AuthorID,
+			CreatedAt: strconv.FormatInt(m.CreatedAt, 10),
+		})
+	}
+	return &chatpb.ListMentionsResponse{Mentions: resp, NextCursor: cursor, UnreadCount: count}, nil
+}
+
 func (s *ChatServer) ToggleSaved(ctx context.Context, req *chatpb.ToggleSavedRequest) (*chatpb.ToggleSavedResponse, error) {
 	if err := s.svc.ToggleSaved(ctx, req.UserId, req.MessageId, req.Saved); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to toggle saved: %v", err)
@@ -130,15 +392,7 @@ func (s *ChatServer) ListSaved(ctx context.Context, req *chatpb.ListSavedRequest
 	}
 	resp := make([]*chatpb.Message, 0, len(msgs))
 	for _, m := range msgs {
-		resp = append(resp, &chatpb.Message{
-			Id:        m.ID,
-			ChatId:    m.ChatID,
-			AuthorId:  m.AuthorID,
-			Text:      m.Text,
-			CreatedAt: strconv.FormatInt(m.CreatedAt, 10),
-			UpdatedAt: strconv.FormatInt(m.UpdatedAt, 10),
-			Deleted:   m.Deleted,
-		})
+		resp = append(resp, toProtoMessage(m))
 	}
 	return &chatpb.ListSavedResponse{Messages: resp, NextCursor: cursor}, nil
 }
@@ -147,14 +401,28 @@ func (s *ChatServer) ListSaved(ctx context.Context, req *chatpb.ListSavedRequest
 
 func (s *ChatServer) CreateDirectChat(ctx context.Context, req *chatpb.CreateDirectChatRequest) (*chatpb.ChatResponse, error) {
 	chat, err := s.svc.CreateDirect(ctx, req.UserId, req.PeerId)
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		return nil, toStatusError(err, "failed to create direct chat")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "failed to create direct chat: %v", err)
 	}
 	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
 }
 
+func (s *ChatServer) CreateSecretChat(ctx context.Context, req *chatpb.CreateSecretChatRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.CreateSecretChat(ctx, req.UserId, req.PeerId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to create secret chat")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
 func (s *ChatServer) CreateGroupChat(ctx context.Context, req *chatpb.CreateGroupChatRequest) (*chatpb.ChatResponse, error) {
 	chat, err := s.svc.CreateGroup(ctx, req.UserId, req.MemberIds, req.Title)
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		return nil, toStatusError(err, "failed to create group chat")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create group chat: %v", err)
 	}
@@ -171,45 +439,210 @@ func (s *ChatServer) CreateGroupChat(ctx context.Context, req *chatpb.CreateGrou
 }
 
 func (s *ChatServer) ListChats(ctx context.Context, req *chatpb.ListChatsRequest) (*chatpb.ListChatsResponse, error) {
-	chats, cursor, err := s.svc.ListChats(ctx, req.UserId, int(req.Limit), req.Cursor)
+	var (
+		chats  []domain.Chat
+		cursor string
+		err    error
+	)
+	if req.FolderId != "" {
+		chats, cursor, err = s.svc.ListFolderChats(ctx, req.UserId, req.FolderId, int(req.Limit), req.Cursor)
+	} else {
+		chats, cursor, err = s.svc.ListChats(ctx, req.UserId, int(req.Limit), req.Cursor, req.Archived)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list chats: %v", err)
+		return nil, toStatusError(err, "failed to list chats")
 	}
 	resp := make([]*chatpb.Chat, 0, len(chats))
 	for _, c := range chats {
-		resp = append(resp, &chatpb.Chat{
-			Id:        c.ID,
-			Kind:      string(c.Kind),
-			MemberIds: c.MemberIDs,
-			Title:     c.Title,
-			CreatedBy: c.CreatedBy,
-			CreatedAt: strconv.FormatInt(c.CreatedAt, 10),
-		})
+		resp = append(resp, toProtoChat(c))
 	}
 	return &chatpb.ListChatsResponse{Chats: resp, NextCursor: cursor}, nil
 }
 
+// --- Per-user chat state ---
+
+func (s *ChatServer) PinChat(ctx context.Context, req *chatpb.PinChatRequest) (*chatpb.ChatStateResponse, error) {
+	st, err := s.svc.PinChat(ctx, req.ChatId, req.UserId, req.Pinned)
+	if err != nil {
+		return nil, toStatusError(err, "failed to pin chat")
+	}
+	return &chatpb.ChatStateResponse{State: toProtoChatState(st)}, nil
+}
+
+func (s *ChatServer) ArchiveChat(ctx context.Context, req *chatpb.ArchiveChatRequest) (*chatpb.ChatStateResponse, error) {
+	st, err := s.svc.ArchiveChat(ctx, req.ChatId, req.UserId, req.Archived)
+	if err != nil {
+		return nil, toStatusError(err, "failed to archive chat")
+	}
+	return &chatpb.ChatStateResponse{State: toProtoChatState(st)}, nil
+}
+
+func (s *ChatServer) MuteChat(ctx context.Context, req *chatpb.MuteChatRequest) (*chatpb.ChatStateResponse, error) {
+	st, err := s.svc.MuteChat(ctx, req.ChatId, req.UserId, req.MutedUntil)
+	if err != nil {
+		return nil, toStatusError(err, "failed to mute chat")
+	}
+	return &chatpb.ChatStateResponse{State: toProtoChatState(st)}, nil
+}
+
+func (s *ChatServer) MarkChatUnread(ctx context.Context, req *chatpb.MarkChatUnreadRequest) (*chatpb.ChatStateResponse, error) {
+	st, err := s.svc.MarkChatUnread(ctx, req.ChatId, req.UserId, req.Unread)
+	if err != nil {
+		return nil, toStatusError(err, "failed to mark chat unread")
+	}
+	return &chatpb.ChatStateResponse{State: toProtoChatState(st)}, nil
+}
+
+// --- Invites ---
+
+func (s *ChatServer) CreateInviteLink(ctx context.Context, req *chatpb.CreateInviteLinkRequest) (*chatpb.InviteLinkResponse, error) {
+	link, err := s.svc.CreateInviteLink(ctx, req.ChatId, req.RequesterId, domain.InviteSettings{
+		ExpiresAt:        req.ExpiresAt,
+		UsageLimit:       req.UsageLimit,
+		RequiresApproval: req.RequiresApproval,
+	})
+	if err != nil {
+		return nil, toStatusError(err, "failed to create invite link")
+	}
+	return &chatpb.InviteLinkResponse{InviteLink: toProtoInviteLink(link)}, nil
+}
+
+func (s *ChatServer) RevokeInviteLink(ctx context.Context, req *chatpb.RevokeInviteLinkRequest) (*chatpb.RevokeInviteLinkResponse, error) {
+	if err := s.svc.RevokeInviteLink(ctx, req.Code, req.RequesterId); err != nil {
+		return nil, toStatusError(err, "failed to revoke invite link")
+	}
+	return &chatpb.RevokeInviteLinkResponse{Success: true}, nil
+}
+
+func (s *ChatServer) JoinByInvite(ctx context.Context, req *chatpb.JoinByInviteRequest) (*chatpb.JoinByInviteResponse, error) {
+	chat, joinReq, err := s.svc.JoinByInvite(ctx, req.Code, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to join by invite")
+	}
+	if joinReq != nil {
+		return &chatpb.JoinByInviteResponse{JoinRequest: toProtoJoinRequest(*joinReq)}, nil
+	}
+	return &chatpb.JoinByInviteResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) ListJoinRequests(ctx context.Context, req *chatpb.ListJoinRequestsRequest) (*chatpb.ListJoinRequestsResponse, error) {
+	reqs, cursor, err := s.svc.ListJoinRequests(ctx, req.ChatId, req.RequesterId, int(req.Limit), req.Cursor)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list join requests")
+	}
+	resp := make([]*chatpb.JoinRequest, 0, len(reqs))
+	for _, r := range reqs {
+		resp = append(resp, toProtoJoinRequest(r))
+	}
+	return &chatpb.ListJoinRequestsResponse{Requests: resp, NextCursor: cursor}, nil
+}
+
+func (s *ChatServer) ApproveJoinRequest(ctx context.Context, req *chatpb.DecideJoinRequestRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.ApproveJoinRequest(ctx, req.RequestId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to approve join request")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) RejectJoinRequest(ctx context.Context, req *chatpb.DecideJoinRequestRequest) (*chatpb.RejectJoinRequestResponse, error) {
+	if err := s.svc.RejectJoinRequest(ctx, req.RequestId, req.RequesterId); err != nil {
+		return nil, toStatusError(err, "failed to reject join request")
+	}
+	return &chatpb.RejectJoinRequestResponse{Success: true}, nil
+}
+
+// --- Channels ---
+
+func (s *ChatServer) CreateChannel(ctx context.Context, req *chatpb.CreateChannelRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.CreateChannel(ctx, req.UserId, req.Title, req.Handle)
+	if err != nil {
+		return nil, toStatusError(err, "failed to create channel")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) GetChannelByHandle(ctx context.Context, req *chatpb.GetChannelByHandleRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.GetChannelByHandle(ctx, req.Handle)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get channel")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) SubscribeChannel(ctx context.Context, req *chatpb.SubscribeChannelRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.SubscribeChannel(ctx, req.ChatId, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to subscribe")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) UnsubscribeChannel(ctx context.Context, req *chatpb.UnsubscribeChannelRequest) (*chatpb.UnsubscribeChannelResponse, error) {
+	if err := s.svc.UnsubscribeChannel(ctx, req.ChatId, req.UserId); err != nil {
+		return nil, toStatusError(err, "failed to unsubscribe")
+	}
+	return &chatpb.UnsubscribeChannelResponse{Success: true}, nil
+}
+
+func (s *ChatServer) ListChannelSubscribers(ctx context.Context, req *chatpb.ListChannelSubscribersRequest) (*chatpb.ListChannelSubscribersResponse, error) {
+	subs, cursor, err := s.svc.ListChannelSubscribers(ctx, req.ChatId, req.RequesterId, int(req.Limit), req.Cursor)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list subscribers")
+	}
+	resp := make([]*chatpb.ChannelSubscriber, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, &chatpb.ChannelSubscriber{
+			UserId:       sub.UserID,
+			SubscribedAt: strconv.FormatInt(sub.SubscribedAt, 10),
+		})
+	}
+	return &chatpb.ListChannelSubscribersResponse{Subscribers: resp, NextCursor: cursor}, nil
+}
+
+func (s *ChatServer) SetChannelAdmin(ctx context.Context, req *chatpb.SetChannelAdminRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.SetChannelAdmin(ctx, req.ChatId, req.RequesterId, req.UserId, req.Admin)
+	if err != nil {
+		return nil, toStatusError(err, "failed to set channel admin")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) ViewMessages(ctx context.Context, req *chatpb.ViewMessagesRequest) (*chatpb.ViewMessagesResponse, error) {
+	if err := s.svc.ViewMessages(ctx, req.ChatId, req.UserId, req.MessageIds); err != nil {
+		return nil, toStatusError(err, "failed to record views")
+	}
+	return &chatpb.ViewMessagesResponse{Success: true}, nil
+}
+
 func (s *ChatServer) GetChat(ctx context.Context, req *chatpb.GetChatRequest) (*chatpb.ChatResponse, error) {
 	chat, err := s.svc.GetChat(ctx, req.ChatId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get chat: %v", err)
 	}
-	return &chatpb.ChatResponse{
-		Chat: &chatpb.Chat{
-			Id:        chat.ID,
-			Kind:      string(chat.Kind),
-			MemberIds: chat.MemberIDs,
-			Title:     chat.Title,
-			CreatedBy: chat.CreatedBy,
-			CreatedAt: strconv.FormatInt(chat.CreatedAt, 10),
-		},
-	}, nil
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
 }
 
 func (s *ChatServer) UpdateGroupChat(ctx context.Context, req *chatpb.UpdateGroupChatRequest) (*chatpb.ChatResponse, error) {
 	chat, err := s.svc.UpdateGroupChat(ctx, req) // chat == domain.Chat
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update group chat: %v", err)
+		return nil, toStatusError(err, "failed to update group chat")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) SetMessageTTL(ctx context.Context, req *chatpb.SetMessageTTLRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.SetMessageTTL(ctx, req.ChatId, req.RequesterId, req.TtlSeconds)
+	if err != nil {
+		return nil, toStatusError(err, "failed to set message ttl")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) SetSlowMode(ctx context.Context, req *chatpb.SetSlowModeRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.SetSlowMode(ctx, req.ChatId, req.RequesterId, req.Seconds)
+	if err != nil {
+		return nil, toStatusError(err, "failed to set slow mode")
 	}
 	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
 }
@@ -223,15 +656,7 @@ func (s *ChatServer) ListReadMessages(ctx context.Context, req *chatpb.ListReadM
 
 	var pbMsgs []*chatpb.Message
 	for _, m := range msgs {
-		pbMsgs = append(pbMsgs, &chatpb.Message{
-			Id:        m.ID,
-			ChatId:    m.ChatID,
-			AuthorId:  m.AuthorID,
-			Text:      m.Text,
-			CreatedAt: strconv.FormatInt(m.CreatedAt, 10),
-			UpdatedAt: strconv.FormatInt(m.UpdatedAt, 10),
-			Deleted:   m.Deleted,
-		})
+		pbMsgs = append(pbMsgs, toProtoMessage(m))
 	}
 
 	return &chatpb.ListReadMessagesResponse{
@@ -240,13 +665,711 @@ func (s *ChatServer) ListReadMessages(ctx context.Context, req *chatpb.ListReadM
 	}, nil
 }
 
+// --- Pins ---
+
+func (s *ChatServer) PinMessage(ctx context.Context, req *chatpb.PinMessageRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.PinMessage(ctx, req.ChatId, req.MessageId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to pin message")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) UnpinMessage(ctx context.Context, req *chatpb.UnpinMessageRequest) (*chatpb.ChatResponse, error) {
+	chat, err := s.svc.UnpinMessage(ctx, req.ChatId, req.MessageId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to unpin message")
+	}
+	return &chatpb.ChatResponse{Chat: toProtoChat(chat)}, nil
+}
+
+func (s *ChatServer) ListPinned(ctx context.Context, req *chatpb.ListPinnedRequest) (*chatpb.ListPinnedResponse, error) {
+	pinned, err := s.svc.ListPinned(ctx, req.ChatId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list pinned")
+	}
+	resp := make([]*chatpb.PinnedMessage, 0, len(pinned))
+	for _, p := range pinned {
+		resp = append(resp, toProtoPinned(p))
+	}
+	return &chatpb.ListPinnedResponse{Pinned: resp}, nil
+}
+
+// --- Typing ---
+
+func (s *ChatServer) SetTyping(ctx context.Context, req *chatpb.SetTypingRequest) (*chatpb.SetTypingResponse, error) {
+	throttled, err := s.svc.SetTyping(ctx, req.ChatId, req.UserId, domain.TypingAction(req.Action))
+	if err != nil {
+		return nil, toStatusError(err, "failed to set typing")
+	}
+	return &chatpb.SetTypingResponse{Throttled: throttled}, nil
+}
+
+func (s *ChatServer) ListTyping(ctx context.Context, req *chatpb.ListTypingRequest) (*chatpb.ListTypingResponse, error) {
+	statuses, err := s.svc.ListTyping(ctx, req.ChatId, req.RequesterId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list typing")
+	}
+	resp := make([]*chatpb.TypingStatus, 0, len(statuses))
+	for _, st := range statuses {
+		resp = append(resp, toProtoTyping(st))
+	}
+	return &chatpb.ListTypingResponse{Statuses: resp}, nil
+}
+
+func toProtoTyping(st domain.TypingStatus) *chatpb.TypingStatus {
+	return &chatpb.TypingStatus{
+		ChatId:    st.ChatID,
+		UserId:    st.UserID,
+		Action:    string(st.Action),
+		ExpiresAt: st.ExpiresAt,
+	}
+}
+
+// --- Drafts ---
+
+func (s *ChatServer) SaveDraft(ctx context.Context, req *chatpb.SaveDraftRequest) (*chatpb.DraftResponse, error) {
+	d, applied, err := s.svc.SaveDraft(ctx, domain.Draft{
+		ChatID:    req.ChatId,
+		UserID:    req.UserId,
+		Text:      req.Text,
+		ReplyToID: req.ReplyToMessageId,
+		Media:     fromProtoMedia(req.Media, req.UserId),
+		DeviceID:  req.DeviceId,
+	}, req.BaseVersion)
+	if err != nil {
+		return nil, toStatusError(err, "failed to save draft")
+	}
+	return &chatpb.DraftResponse{Draft: toProtoDraft(d), Applied: applied}, nil
+}
+
+func (s *ChatServer) GetDrafts(ctx context.Context, req *chatpb.GetDraftsRequest) (*chatpb.GetDraftsResponse, error) {
+	drafts, err := s.svc.GetDrafts(ctx, req.UserId, req.ChatId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get drafts")
+	}
+	resp := make([]*chatpb.Draft, 0, len(drafts))
+	for _, d := range drafts {
+		resp = append(resp, toProtoDraft(d))
+	}
+	return &chatpb.GetDraftsResponse{Drafts: resp}, nil
+}
+
+func (s *ChatServer) ClearDraft(ctx context.Context, req *chatpb.ClearDraftRequest) (*chatpb.DraftResponse, error) {
+	d, applied, err := s.svc.ClearDraft(ctx, req.ChatId, req.UserId, req.BaseVersion, req.DeviceId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to clear draft")
+	}
+	return &chatpb.DraftResponse{Draft: toProtoDraft(d), Applied: applied}, nil
+}
+
+// --- Folders ---
+
+func (s *ChatServer) CreateFolder(ctx context.Context, req *chatpb.FolderRequest) (*chatpb.FolderResponse, error) {
+	f, err := s.svc.CreateFolder(ctx, fromProtoFolder(req.Folder, req.UserId))
+	if err != nil {
+		return nil, toStatusError(err, "failed to create folder")
+	}
+	return &chatpb.FolderResponse{Folder: toProtoFolder(f)}, nil
+}
+
+func (s *ChatServer) UpdateFolder(ctx context.Context, req *chatpb.FolderRequest) (*chatpb.FolderResponse, error) {
+	f, err := s.svc.UpdateFolder(ctx, fromProtoFolder(req.Folder, req.UserId))
+	if err != nil {
+		return nil, toStatusError(err, "failed to update folder")
+	}
+	return &chatpb.FolderResponse{Folder: toProtoFolder(f)}, nil
+}
+
+func (s *ChatServer) DeleteFolder(ctx context.Context, req *chatpb.DeleteFolderRequest) (*chatpb.DeleteFolderResponse, error) {
+	if err := s.svc.DeleteFolder(ctx, req.FolderId, req.UserId); err != nil {
+		return nil, toStatusError(err, "failed to delete folder")
+	}
+	return &chatpb.DeleteFolderResponse{Success: true}, nil
+}
+
+func (s *ChatServer) ReorderFolders(ctx context.Context, req *chatpb.ReorderFoldersRequest) (*chatpb.ListFoldersResponse, error) {
+	folders, err := s.svc.ReorderFolders(ctx, req.UserId, req.FolderIds)
+	if err != nil {
+		return nil, toStatusError(err, "failed to reorder folders")
+	}
+	return toProtoFolders(folders), nil
+}
+
+func (s *ChatServer) ListFolders(ctx context.Context, req *chatpb.ListFoldersRequest) (*chatpb.ListFoldersResponse, error) {
+	folders, err := s.svc.ListFolders(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list folders")
+	}
+	return toProtoFolders(folders), nil
+}
+
+// --- Sync ---
+
+func (s *ChatServer) GetUpdates(ctx context.Context, req *chatpb.GetUpdatesRequest) (*chatpb.GetUpdatesResponse, error) {
+	page, err := s.svc.GetUpdates(ctx, req.UserId, req.SinceSeq, int(req.Limit))
+	if err != nil {
+		return nil, toStatusError(err, "failed to get updates")
+	}
+	resp := &chatpb.GetUpdatesResponse{
+		Updates: make([]*chatpb.Update, 0, len(page.Updates)),
+		Seq:     page.Seq,
+		HasMore: page.HasMore,
+		Resync:  page.Resync,
+	}
+	for _, u := range page.Updates {
+		resp.Updates = append(resp.Updates, toProtoUpdate(u))
+	}
+	return resp, nil
+}
+
+// SubscribeChats держит поток, пока клиент не отключится. Send блокируется по flow control gRPC,
+// и сервис не читает журнал дальше, пока клиент не заберёт отправленное.
+func (s *ChatServer) SubscribeChats(req *chatpb.SubscribeChatsRequest, stream chatpb.ChatService_SubscribeChatsServer) error {
+	err := s.svc.SubscribeChats(stream.Context(), req.UserId, req.ResumeToken, func(e domain.StreamEvent) error {
+		pe := &chatpb.ChatStreamEvent{Resync: e.Resync, ResumeToken: e.ResumeToken}
+		if e.Update != nil {
+			pe.Update = toProtoUpdate(*e.Update)
+		}
+		if e.Typing != nil {
+			pe.Typing = toProtoTyping(*e.Typing)
+		}
+		return stream.Send(pe)
+	})
+	if err == nil {
+		return nil
+	}
+	// Ошибка Send уже несёт gRPC-статус (обычно клиент отключился)
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return toStatusError(err, "failed to subscribe to chats")
+}
+
+// Размер части ExportChat
+const exportChunkSize = 64 << 10
+
+// ExportChat отдаёт выгрузку частями по мере чтения истории
+func (s *ChatServer) ExportChat(req *chatpb.ExportChatRequest, stream chatpb.ChatService_ExportChatServer) error {
+	format := req.Format
+	if format == "" {
+		format = archive.FormatJSON
+	}
+	cw := &exportChunkWriter{
+		stream:      stream,
+		contentType: archive.ContentType(format),
+		filename:    "chat-" + req.ChatId + "." + format,
+	}
+	buf := bufio.NewWriterSize(cw, exportChunkSize)
+	err := s.svc.ExportChat(stream.Context(), req.ChatId, req.RequesterId, format, buf)
+	if err == nil {
+		err = buf.Flush()
+	}
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return toStatusError(err, "failed to export chat")
+}
+
+// exportChunkWriter отправляет каждую запись отдельной частью; метаданные — только в первой
+type exportChunkWriter struct {
+	stream      chatpb.ChatService_ExportChatServer
+	contentType string
+	filename    string
+	sent        bool
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	chunk := &chatpb.ExportChatChunk{Data: append([]byte(nil), p...)}
+	if !w.sent {
+		chunk.ContentType, chunk.Filename = w.contentType, w.filename
+		w.sent = true
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ImportChat принимает архив частями и создаёт из него группу
+func (s *ChatServer) ImportChat(stream chatpb.ChatService_ImportChatServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "archive is empty")
+	}
+	if err != nil {
+		return err
+	}
+	r := &importReader{stream: stream, buf: first.Data}
+	res, err := s.svc.ImportChat(stream.Context(), first.RequesterId, first.Title, r)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return toStatusError(err, "failed to import chat")
+	}
+	resp := &chatpb.ImportChatResponse{
+		Chat:               toProtoChat(res.Chat),
+		ImportedMessages:   int32(res.ImportedMessages),
+		SkippedMembers:     int32(res.SkippedMembers),
+		DroppedAttachments: int32(res.DroppedAttachments),
+		ReassignedMessages: int32(res.ReassignedMessages),
+		InvitedMembers:     res.InvitedMembers,
+	}
+	if res.Invite != nil {
+		resp.InviteLink = toProtoInviteLink(*res.Invite)
+	}
+	return stream.SendAndClose(resp)
+}
+
+// importReader читает data из сообщений потока ImportChat
+type importReader struct {
+	stream chatpb.ChatService_ImportChatServer
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// --- Moderation ---
+
+func (s *ChatServer) ReportMessage(ctx context.Context, req *chatpb.ReportMessageRequest) (*chatpb.ReportMessageResponse, error) {
+	if err := s.svc.ReportMessage(ctx, req.MessageId, req.ReporterId, req.Reason); err != nil {
+		return nil, toStatusError(err, "failed to report message")
+	}
+	return &chatpb.ReportMessageResponse{}, nil
+}
+
+func (s *ChatServer) ListModerationQueue(ctx context.Context, req *chatpb.ListModerationQueueRequest) (*chatpb.ListModerationQueueResponse, error) {
+	items, cursor, err := s.svc.ListModerationQueue(ctx, req.ChatId, req.RequesterId, int(req.Limit), req.Cursor)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list moderation queue")
+	}
+	resp := make([]*chatpb.ModerationItem, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, toProtoModerationItem(item))
+	}
+	return &chatpb.ListModerationQueueResponse{Items: resp, NextCursor: cursor}, nil
+}
+
+func (s *ChatServer) ResolveModeration(ctx context.Context, req *chatpb.ResolveModerationRequest) (*chatpb.ModerationItemResponse, error) {
+	item, err := s.svc.ResolveModeration(ctx, req.ItemId, req.RequesterId, domain.ModerationStatus(req.Status))
+	if err != nil {
+		return nil, toStatusError(err, "failed to resolve moderation item")
+	}
+	return &chatpb.ModerationItemResponse{Item: toProtoModerationItem(item)}, nil
+}
+
+func toProtoModerationItem(item domain.ModerationItem) *chatpb.ModerationItem {
+	pi := &chatpb.ModerationItem{
+		Id:        item.ID,
+		ChatId:    item.ChatID,
+		Kind:      string(item.Kind),
+		MessageId: item.MessageID,
+		AuthorId:  item.AuthorID,
+		Text:      item.Text,
+		Reasons:   item.Reasons,
+		Status:    string(item.Status),
+		CreatedAt: strconv.FormatInt(item.CreatedAt, 10),
+		DecidedBy: item.DecidedBy,
+	}
+	if item.DecidedAt > 0 {
+		pi.DecidedAt = strconv.FormatInt(item.DecidedAt, 10)
+	}
+	for _, r := range item.Reports {
+		pi.Reports = append(pi.Reports, &chatpb.ModerationReport{
+			UserId:    r.UserID,
+			Reason:    r.Reason,
+			CreatedAt: strconv.FormatInt(r.CreatedAt, 10),
+		})
+	}
+	return pi
+}
+
+func toProtoUpdate(u domain.UserUpdate) *chatpb.Update {
+	pu := &chatpb.Update{
+		Seq:        u.Seq,
+		Type:       string(u.Type),
+		ChatId:     u.ChatID,
+		MessageIds: u.MessageIDs,
+		ActorId:    u.ActorID,
+		ReadSeq:    u.ReadSeq,
+		Action:     u.Action,
+		UserIds:    u.UserIDs,
+		CreatedAt:  strconv.FormatInt(u.CreatedAt, 10),
+		FolderId:   u.FolderID,
+	}
+	for _, m := range u.Messages {
+		pu.Messages = append(pu.Messages, toProtoMessage(m))
+	}
+	if u.Draft != nil {
+		pu.Draft = toProtoDraft(*u.Draft)
+	}
+	return pu
+}
+
+// toStatusError переводит доменные ошибки в gRPC-коды
+func toStatusError(err error, msg string) error {
+	// Превышение лимита отправки несёт RetryInfo: клиент узнаёт, когда можно писать снова
+	var limited *domain.RateLimitError
+	if errors.As(err, &limited) {
+		st := status.Newf(codes.ResourceExhausted, "%s: %v", msg, err)
+		if withRetry, derr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(limited.RetryAfter)}); derr == nil {
+			st = withRetry
+		}
+		return st.Err()
+	}
+	// Расхождение устройств перечисляется в PreconditionFailure: клиент дошифрует недостающее
+	var mismatch *domain.DeviceMismatchError
+	if errors.As(err, &mismatch) {
+		st := status.Newf(codes.FailedPrecondition, "%s: %v", msg, err)
+		failure := &errdetails.PreconditionFailure{}
+		for _, d := range mismatch.Missing {
+			failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{Type: "DEVICE_MISSING", Subject: d})
+		}
+		for _, d := range mismatch.Unknown {
+			failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{Type: "DEVICE_UNKNOWN", Subject: d})
+		}
+		if withFailure, derr := st.WithDetails(failure); derr == nil {
+			st = withFailure
+		}
+		return st.Err()
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, domain.ErrChatNotFound),
+		errors.Is(err, domain.ErrMessageNotFound),
+		errors.Is(err, domain.ErrNotPinned),
+		errors.Is(err, domain.ErrScheduledNotFound),
+		errors.Is(err, domain.ErrInviteNotFound),
+		errors.Is(err, domain.ErrJoinRequestNotFound),
+		errors.Is(err, domain.ErrMediaNotFound),
+		errors.Is(err, domain.ErrModerationItemNotFound),
+		errors.Is(err, domain.ErrFolderNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrAlreadyPinned),
+		errors.Is(err, domain.ErrAlreadyVoted),
+		errors.Is(err, domain.ErrHandleTaken),
+		errors.Is(err, domain.ErrAlreadyMember):
+		code = codes.AlreadyExists
+	case errors.Is(err, domain.ErrEditWindowClosed),
+		errors.Is(err, domain.ErrTooManyPinnedChats),
+		errors.Is(err, domain.ErrTooManyFolders),
+		errors.Is(err, domain.ErrScheduledClosed),
+		errors.Is(err, domain.ErrTooManyScheduled),
+		errors.Is(err, domain.ErrPollClosed),
+		errors.Is(err, domain.ErrNotVoted),
+		errors.Is(err, domain.ErrInviteUnusable):
+		code = codes.FailedPrecondition
+	case errors.Is(err, domain.ErrInvalidArgument),
+		errors.Is(err, domain.ErrMessageRejected):
+		code = codes.InvalidArgument
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
 func toProtoChat(c domain.Chat) *chatpb.Chat {
-	return &chatpb.Chat{
-		Id:        c.ID,
-		Kind:      string(c.Kind),
-		MemberIds: c.MemberIDs,
-		Title:     c.Title,
-		CreatedBy: c.CreatedBy,
-		CreatedAt: strconv.FormatInt(c.CreatedAt, 10),
+	pc := &chatpb.Chat{
+		Id:              c.ID,
+		Kind:            string(c.Kind),
+		MemberIds:       c.MemberIDs,
+		Title:           c.Title,
+		CreatedBy:       c.CreatedBy,
+		CreatedAt:       strconv.FormatInt(c.CreatedAt, 10),
+		LastSeq:         c.LastSeq,
+		MessageTtl:      c.MessageTTL,
+		Handle:          c.Handle,
+		SubscriberCount: c.SubscriberCount,
+		SlowModeSeconds: c.SlowModeSeconds,
+		Secret:          c.Secret,
+	}
+	for _, p := range c.Pinned {
+		pc.Pinned = append(pc.Pinned, toProtoPinned(p))
+	}
+	if c.LastMessageAt > 0 {
+		pc.LastMessageAt = strconv.FormatInt(c.LastMessageAt, 10)
+	}
+	if lm := c.LastMessage; lm != nil {
+		pc.LastMessage = &chatpb.MessagePreview{
+			Id:        lm.ID,
+			Seq:       lm.Seq,
+			AuthorId:  lm.AuthorID,
+			Type:      string(lm.Type),
+			Text:      lm.Text,
+			CreatedAt: strconv.FormatInt(lm.CreatedAt, 10),
+			Deleted:   lm.Deleted,
+		}
+	}
+	if c.State != nil {
+		pc.State = toProtoChatState(*c.State)
+	}
+	return pc
+}
+
+func toProtoChatState(st domain.UserChatState) *chatpb.ChatState {
+	return &chatpb.ChatState{
+		ChatId:       st.ChatID,
+		Pinned:       st.Pinned,
+		Archived:     st.Archived,
+		MutedUntil:   st.MutedUntil,
+		MarkedUnread: st.MarkedUnread,
+		UnreadCount:  st.UnreadCount,
+	}
+}
+
+func toProtoFolder(f domain.ChatFolder) *chatpb.ChatFolder {
+	pf := &chatpb.ChatFolder{
+		Id:             f.ID,
+		Title:          f.Title,
+		Position:       int32(f.Position),
+		IncludeChatIds: f.IncludeIDs,
+		ExcludeChatIds: f.ExcludeIDs,
+		Rules: &chatpb.FolderRules{
+			Contacts: f.Rules.Contacts,
+			Muted:    string(f.Rules.Muted),
+			Unread:   string(f.Rules.Unread),
+		},
+		UpdatedAt:      strconv.FormatInt(f.UpdatedAt, 10),
+		UnreadChats:    f.UnreadChats,
+		UnreadMessages: f.UnreadMessages,
+	}
+	for _, k := range f.Rules.Kinds {
+		pf.Rules.Kinds = append(pf.Rules.Kinds, string(k))
+	}
+	return pf
+}
+
+func toProtoFolders(folders []domain.ChatFolder) *chatpb.ListFoldersResponse {
+	resp := make([]*chatpb.ChatFolder, 0, len(folders))
+	for _, f := range folders {
+		resp = append(resp, toProtoFolder(f))
+	}
+	return &chatpb.ListFoldersResponse{Folders: resp}
+}
+
+func fromProtoFolder(pf *chatpb.ChatFolder, userID string) domain.ChatFolder {
+	f := domain.ChatFolder{
+		ID:         pf.GetId(),
+		UserID:     userID,
+		Title:      pf.GetTitle(),
+		IncludeIDs: pf.GetIncludeChatIds(),
+		ExcludeIDs: pf.GetExcludeChatIds(),
+		Rules: domain.FolderRules{
+			Contacts: pf.GetRules().GetContacts(),
+			Muted:    domain.FolderFlag(pf.GetRules().GetMuted()),
+			Unread:   domain.FolderFlag(pf.GetRules().GetUnread()),
+		},
+	}
+	for _, k := range pf.GetRules().GetKinds() {
+		f.Rules.Kinds = append(f.Rules.Kinds, domain.ChatKind(k))
+	}
+	return f
+}
+
+func toProtoPinned(p domain.PinnedMessage) *chatpb.PinnedMessage {
+	pp := &chatpb.PinnedMessage{
+		MessageId: p.MessageID,
+		PinnedBy:  p.PinnedBy,
+		PinnedAt:  strconv.FormatInt(p.PinnedAt, 10),
+	}
+	if p.Message != nil {
+		pp.Message = toProtoMessage(*p.Message)
+	}
+	return pp
+}
+
+func toProtoMessage(m domain.Message) *chatpb.Message {
+	pm := &chatpb.Message{
+		Id:           m.ID,
+		ChatId:       m.ChatID,
+		Seq:          m.Seq,
+		AuthorId:     m.AuthorID,
+		Text:         m.Text,
+		CreatedAt:    strconv.FormatInt(m.CreatedAt, 10),
+		UpdatedAt:    strconv.FormatInt(m.UpdatedAt, 10),
+		Deleted:      m.Deleted,
+		Type:         string(m.Type),
+		Edited:       m.Edited,
+		EditCount:    int32(m.EditCount),
+		Media:        toProtoMedia(m.Media),
+		Views:        m.Views,
+		ImportedFrom: m.ImportedFrom,
+		Held:         m.Held,
+
+		SenderDeviceId: m.SenderDeviceID,
+	}
+	for _, e := range m.Envelopes {
+		pm.Envelopes = append(pm.Envelopes, &chatpb.Envelope{UserId: e.UserID, DeviceId: e.DeviceID, Ciphertext: e.Ciphertext})
+	}
+	if m.ExpiresAt > 0 {
+		pm.ExpiresAt = strconv.FormatInt(m.ExpiresAt, 10)
+	}
+	for _, e := range m.Entities {
+		pm.Entities = append(pm.Entities, &chatpb.MessageEntity{
+			Type:   e.Type,
+			Offset: int32(e.Offset),
+			Length: int32(e.Length),
+			UserId: e.UserID,
+		})
+	}
+	if m.System != nil {
+		pm.System = &chatpb.SystemEvent{
+			Action:    m.System.Action,
+			ActorId:   m.System.ActorID,
+			MessageId: m.System.MessageID,
+			UserId:    m.System.UserID,
+		}
+	}
+	if m.Poll != nil {
+		pm.Poll = toProtoPoll(m.ID, *m.Poll)
+	}
+	return pm
+}
+
+// toProtoPoll не отдаёт голоса: кто за что голосовал — только через GetPollResults
+func toProtoPoll(messageID string, p domain.Poll) *chatpb.Poll {
+	pp := &chatpb.Poll{
+		MessageId:      messageID,
+		Question:       p.Question,
+		MultipleChoice: p.MultipleChoice,
+		Anonymous:      p.Anonymous,
+		ClosesAt:       p.ClosesAt,
+		Closed:         p.IsClosed(time.Now().Unix()),
+		TotalVoters:    p.TotalVoters,
+	}
+	for _, o := range p.Options {
+		pp.Options = append(pp.Options, &chatpb.PollOption{Id: int32(o.ID), Text: o.Text, Votes: o.Votes})
+	}
+	return pp
+}
+
+func toProtoPollResponse(res domain.PollResults) *chatpb.PollResponse {
+	return &chatpb.PollResponse{
+		Poll:        toProtoPoll(res.MessageID, res.Poll),
+		MyOptionIds: toInt32s(res.MyOptionIDs),
+	}
+}
+
+func toInt32s(ids []int) []int32 {
+	resp := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		resp = append(resp, int32(id))
+	}
+	return resp
+}
+
+func toProtoReadStates(states []domain.ReadState) []*chatpb.ReadState {
+	resp := make([]*chatpb.ReadState, 0, len(states))
+	for _, st := range states {
+		resp = append(resp, &chatpb.ReadState{
+			UserId:           st.UserID,
+			LastReadSeq:      st.LastReadSeq,
+			LastReadAt:       strconv.FormatInt(st.LastReadAt, 10),
+			LastDeliveredSeq: st.LastDeliveredSeq,
+			LastDeliveredAt:  strconv.FormatInt(st.LastDeliveredAt, 10),
+		})
+	}
+	return resp
+}
+
+func toProtoMedia(media []domain.Media) []*chatpb.Media {
+	if len(media) == 0 {
+		return nil
+	}
+	resp := make([]*chatpb.Media, 0, len(media))
+	for _, md := range media {
+		resp = append(resp, &chatpb.Media{
+			Id:        md.ID,
+			Type:      md.Type,
+			Url:       md.URL,
+			Mime:      md.Mime,
+			SizeBytes: md.SizeBytes,
+		})
+	}
+	return resp
+}
+
+func fromProtoMedia(media []*chatpb.Media, authorID string) []domain.Media {
+	if len(media) == 0 {
+		return nil
+	}
+	resp := make([]domain.Media, 0, len(media))
+	for _, md := range media {
+		resp = append(resp, domain.Media{
+			ID:        md.Id,
+			Type:      md.Type,
+			URL:       md.Url,
+			Mime:      md.Mime,
+			SizeBytes: md.SizeBytes,
+			AuthorID:  authorID,
+		})
+	}
+	return resp
+}
+
+func toProtoInviteLink(l domain.InviteLink) *chatpb.InviteLink {
+	return &chatpb.InviteLink{
+		Code:             l.Code,
+		ChatId:           l.ChatID,
+		CreatedBy:        l.CreatedBy,
+		CreatedAt:        strconv.FormatInt(l.CreatedAt, 10),
+		ExpiresAt:        l.ExpiresAt,
+		UsageLimit:       l.UsageLimit,
+		UsageCount:       l.UsageCount,
+		RequiresApproval: l.RequiresApproval,
+		Revoked:          l.Revoked,
+	}
+}
+
+func toProtoJoinRequest(r domain.JoinRequest) *chatpb.JoinRequest {
+	return &chatpb.JoinRequest{
+		Id:         r.ID,
+		ChatId:     r.ChatID,
+		UserId:     r.UserID,
+		InviteCode: r.InviteCode,
+		Status:     string(r.Status),
+		CreatedAt:  strconv.FormatInt(r.CreatedAt, 10),
+	}
+}
+
+func toProtoScheduled(m domain.ScheduledMessage) *chatpb.ScheduledMessage {
+	return &chatpb.ScheduledMessage{
+		Id:        m.ID,
+		ChatId:    m.ChatID,
+		AuthorId:  m.AuthorID,
+		Text:      m.Text,
+		Media:     toProtoMedia(m.Media),
+		SendAt:    m.SendAt,
+		Status:    string(m.Status),
+		MessageId: m.MessageID,
+		CreatedAt: strconv.FormatInt(m.CreatedAt, 10),
+	}
+}
+
+func toProtoDraft(d domain.Draft) *chatpb.Draft {
+	return &chatpb.Draft{
+		ChatId:           d.ChatID,
+		Text:             d.Text,
+		ReplyToMessageId: d.ReplyToID,
+		Media:            toProtoMedia(d.Media),
+		UpdatedAt:        d.UpdatedAt,
+		DeviceId:         d.DeviceID,
+		Version:          d.Version,
 	}
 }
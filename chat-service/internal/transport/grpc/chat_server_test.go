@@ -2,13 +2,18 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"main/internal/domain"
 	chatpb "main/pkg/api"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -25,6 +30,11 @@ func (m *MockChatService) CreateDirect(ctx context.Context, userID, peerID strin
 	return args.Get(0).(domain.Chat), args.Error(1)
 }
 
+func (m *MockChatService) CreateSecretChat(ctx context.Context, userID, peerID string) (domain.Chat, error) {
+	args := m.Called(ctx, userID, peerID)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
 func (m *MockChatService) CreateGroup(ctx context.Context, creatorID string, members []string, title string) (domain.Chat, error) {
 	args := m.Called(ctx, creatorID, members, title)
 	return args.Get(0).(domain.Chat), args.Error(1)
@@ -40,14 +50,19 @@ func (m *MockChatService) UpdateMessage(ctx context.Context, messageID, authorID
 	return args.Get(0).(domain.Message), args.Error(1)
 }
 
-func (m *MockChatService) DeleteMessage(messageIDs []string, hard bool, requesterID string) ([]domain.Message, error) {
+func (m *MockChatService) ListMessageRevisions(ctx context.Context, messageID, requesterID string) ([]domain.MessageRevision, error) {
+	args := m.Called(ctx, messageID, requesterID)
+	return args.Get(0).([]domain.MessageRevision), args.Error(1)
+}
+
+func (m *MockChatService) DeleteMessage(ctx context.Context, messageIDs []string, hard bool, requesterID string) ([]domain.Message, error) {
 	args := m.Called(messageIDs, hard, requesterID)
 	return args.Get(0).([]domain.Message), args.Error(1)
 }
 
-func (m *MockChatService) ListMessages(ctx context.Context, chatID string, limit int, cursor string) ([]domain.Message, string, error) {
-	args := m.Called(ctx, chatID, limit, cursor)
-	return args.Get(0).([]domain.Message), args.String(1), args.Error(2)
+func (m *MockChatService) ListMessages(ctx context.Context, q domain.MessageQuery) (domain.MessagePage, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(domain.MessagePage), args.Error(1)
 }
 
 func (m *MockChatService) MarkRead(ctx context.Context, chatID, userID, messageID string) error {
@@ -55,6 +70,26 @@ func (m *MockChatService) MarkRead(ctx context.Context, chatID, userID, messageI
 	return args.Error(0)
 }
 
+func (m *MockChatService) MarkDelivered(ctx context.Context, chatID, userID, messageID string) error {
+	args := m.Called(ctx, chatID, userID, messageID)
+	return args.Error(0)
+}
+
+func (m *MockChatService) GetUnreadCount(ctx context.Context, chatID, userID string) (int64, error) {
+	args := m.Called(ctx, chatID, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChatService) GetReadState(ctx context.Context, chatID, messageID, requesterID string) ([]domain.ReadState, []domain.ReadState, error) {
+	args := m.Called(ctx, chatID, messageID, requesterID)
+	return args.Get(0).([]domain.ReadState), args.Get(1).([]domain.ReadState), args.Error(2)
+}
+
+func (m *MockChatService) ListMentions(ctx context.Context, userID, chatID string, limit int, cursor string) ([]domain.Mention, string, int64, error) {
+	args := m.Called(ctx, userID, chatID, limit, cursor)
+	return args.Get(0).([]domain.Mention), args.String(1), args.Get(2).(int64), args.Error(3)
+}
+
 func (m *MockChatService) ToggleSaved(ctx context.Context, userID, messageID string, saved bool) error {
 	args := m.Called(ctx, userID, messageID, saved)
 	return args.Error(0)
@@ -65,11 +100,145 @@ func (m *MockChatService) ListSaved(ctx context.Context, userID string, limit in
 	return args.Get(0).([]domain.Message), args.String(1), args.Error(2)
 }
 
-func (m *MockChatService) ListChats(ctx context.Context, userID string, limit int, cursor string) ([]domain.Chat, string, error) {
-	args := m.Called(ctx, userID, limit, cursor)
+func (m *MockChatService) ListChats(ctx context.Context, userID string, limit int, cursor string, archived bool) ([]domain.Chat, string, error) {
+	args := m.Called(ctx, userID, limit, cursor, archived)
+	return args.Get(0).([]domain.Chat), args.String(1), args.Error(2)
+}
+
+func (m *MockChatService) ListFolderChats(ctx context.Context, userID, folderID string, limit int, cursor string) ([]domain.Chat, string, error) {
+	args := m.Called(ctx, userID, folderID, limit, cursor)
 	return args.Get(0).([]domain.Chat), args.String(1), args.Error(2)
 }
 
+func (m *MockChatService) PinChat(ctx context.Context, chatID, userID string, pinned bool) (domain.UserChatState, error) {
+	args := m.Called(ctx, chatID, userID, pinned)
+	return args.Get(0).(domain.UserChatState), args.Error(1)
+}
+
+func (m *MockChatService) ArchiveChat(ctx context.Context, chatID, userID string, archived bool) (domain.UserChatState, error) {
+	args := m.Called(ctx, chatID, userID, archived)
+	return args.Get(0).(domain.UserChatState), args.Error(1)
+}
+
+func (m *MockChatService) MuteChat(ctx context.Context, chatID, userID string, until int64) (domain.UserChatState, error) {
+	args := m.Called(ctx, chatID, userID, until)
+	return args.Get(0).(domain.UserChatState), args.Error(1)
+}
+
+func (m *MockChatService) MarkChatUnread(ctx context.Context, chatID, userID string, unread bool) (domain.UserChatState, error) {
+	args := m.Called(ctx, chatID, userID, unread)
+	return args.Get(0).(domain.UserChatState), args.Error(1)
+}
+
+func (m *MockChatService) ScheduleMessage(ctx context.Context, msg domain.ScheduledMessage) (domain.ScheduledMessage, error) {
+	args := m.Called(ctx, msg)
+	return args.Get(0).(domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockChatService) ListScheduledMessages(ctx context.Context, chatID, authorID string) ([]domain.ScheduledMessage, error) {
+	args := m.Called(ctx, chatID, authorID)
+	return args.Get(0).([]domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockChatService) UpdateScheduledMessage(ctx context.Context, id, authorID string, text *string, media *[]domain.Media, sendAt *int64) (domain.ScheduledMessage, error) {
+	args := m.Called(ctx, id, authorID, text, media, sendAt)
+	return args.Get(0).(domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockChatService) CancelScheduledMessage(ctx context.Context, id, authorID string) error {
+	args := m.Called(ctx, id, authorID)
+	return args.Error(0)
+}
+
+func (m *MockChatService) Vote(ctx context.Context, messageID, userID string, optionIDs []int) (domain.PollResults, error) {
+	args := m.Called(ctx, messageID, userID, optionIDs)
+	return args.Get(0).(domain.PollResults), args.Error(1)
+}
+
+func (m *MockChatService) RetractVote(ctx context.Context, messageID, userID string) (domain.PollResults, error) {
+	args := m.Called(ctx, messageID, userID)
+	return args.Get(0).(domain.PollResults), args.Error(1)
+}
+
+func (m *MockChatService) ClosePoll(ctx context.Context, messageID, requesterID string) (domain.PollResults, error) {
+	args := m.Called(ctx, messageID, requesterID)
+	return args.Get(0).(domain.PollResults), args.Error(1)
+}
+
+func (m *MockChatService) GetPollResults(ctx context.Context, messageID, requesterID string) (domain.PollResults, error) {
+	args := m.Called(ctx, messageID, requesterID)
+	return args.Get(0).(domain.PollResults), args.Error(1)
+}
+
+func (m *MockChatService) CreateInviteLink(ctx context.Context, chatID, requesterID string, settings domain.InviteSettings) (domain.InviteLink, error) {
+	args := m.Called(ctx, chatID, requesterID, settings)
+	return args.Get(0).(domain.InviteLink), args.Error(1)
+}
+
+func (m *MockChatService) RevokeInviteLink(ctx context.Context, code, requesterID string) error {
+	args := m.Called(ctx, code, requesterID)
+	return args.Error(0)
+}
+
+func (m *MockChatService) JoinByInvite(ctx context.Context, code, userID string) (domain.Chat, *domain.JoinRequest, error) {
+	args := m.Called(ctx, code, userID)
+	var req *domain.JoinRequest
+	if r := args.Get(1); r != nil {
+		req = r.(*domain.JoinRequest)
+	}
+	return args.Get(0).(domain.Chat), req, args.Error(2)
+}
+
+func (m *MockChatService) ListJoinRequests(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.JoinRequest, string, error) {
+	args := m.Called(ctx, chatID, requesterID, limit, cursor)
+	return args.Get(0).([]domain.JoinRequest), args.String(1), args.Error(2)
+}
+
+func (m *MockChatService) ApproveJoinRequest(ctx context.Context, requestID, requesterID string) (domain.Chat, error) {
+	args := m.Called(ctx, requestID, requesterID)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) RejectJoinRequest(ctx context.Context, requestID, requesterID string) error {
+	args := m.Called(ctx, requestID, requesterID)
+	return args.Error(0)
+}
+
+func (m *MockChatService) CreateChannel(ctx context.Context, creatorID, title, handle string) (domain.Chat, error) {
+	args := m.Called(ctx, creatorID, title, handle)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) GetChannelByHandle(ctx context.Context, handle string) (domain.Chat, error) {
+	args := m.Called(ctx, handle)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) SubscribeChannel(ctx context.Context, chatID, userID string) (domain.Chat, error) {
+	args := m.Called(ctx, chatID, userID)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) UnsubscribeChannel(ctx context.Context, chatID, userID string) error {
+	args := m.Called(ctx, chatID, userID)
+	return args.Error(0)
+}
+
+func (m *MockChatService) ListChannelSubscribers(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ChannelSubscription, string, error) {
+	args := m.Called(ctx, chatID, requesterID, limit, cursor)
+	return args.Get(0).([]domain.ChannelSubscription), args.String(1), args.Error(2)
+}
+
+func (m *MockChatService) SetChannelAdmin(ctx context.Context, chatID, requesterID, userID string, admin bool) (domain.Chat, error) {
+	args := m.Called(ctx, chatID, requesterID, userID, admin)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) ViewMessages(ctx context.Context, chatID, userID string, messageIDs []string) error {
+	args := m.Called(ctx, chatID, userID, messageIDs)
+	return args.Error(0)
+}
+
 func (m *MockChatService) GetChat(ctx context.Context, chatID string) (domain.Chat, error) {
 	args := m.Called(ctx, chatID)
 	return args.Get(0).(domain.Chat), args.Error(1)
@@ -80,11 +249,196 @@ func (m *MockChatService) UpdateGroupChat(ctx context.Context, req *chatpb.Updat
 	return args.Get(0).(domain.Chat), args.Error(1)
 }
 
+func (m *MockChatService) SetSlowMode(ctx context.Context, chatID, requesterID string, seconds int64) (domain.Chat, error) {
+	args := m.Called(ctx, chatID, requesterID, seconds)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) SetMessageTTL(ctx context.Context, chatID, requesterID string, ttl int64) (domain.Chat, error) {
+	args := m.Called(ctx, chatID, requesterID, ttl)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
 func (m *MockChatService) ListReadMessages(ctx context.Context, userID, chatID string, limit int) ([]domain.Message, error) {
 	args := m.Called(ctx, userID, chatID, limit)
 	return args.Get(0).([]domain.Message), args.Error(1)
 }
 
+func (m *MockChatService) PinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error) {
+	args := m.Called(ctx, chatID, messageID, requesterID)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) UnpinMessage(ctx context.Context, chatID, messageID, requesterID string) (domain.Chat, error) {
+	args := m.Called(ctx, chatID, messageID, requesterID)
+	return args.Get(0).(domain.Chat), args.Error(1)
+}
+
+func (m *MockChatService) ListPinned(ctx context.Context, chatID, requesterID string) ([]domain.PinnedMessage, error) {
+	args := m.Called(ctx, chatID, requesterID)
+	return args.Get(0).([]domain.PinnedMessage), args.Error(1)
+}
+
+func (m *MockChatService) SetTyping(ctx context.Context, chatID, userID string, action domain.TypingAction) (bool, error) {
+	args := m.Called(ctx, chatID, userID, action)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatService) ListTyping(ctx context.Context, chatID, requesterID string) ([]domain.TypingStatus, error) {
+	args := m.Called(ctx, chatID, requesterID)
+	return args.Get(0).([]domain.TypingStatus), args.Error(1)
+}
+
+func (m *MockChatService) SaveDraft(ctx context.Context, d domain.Draft, baseVersion int64) (domain.Draft, bool, error) {
+	args := m.Called(ctx, d, baseVersion)
+	return args.Get(0).(domain.Draft), args.Bool(1), args.Error(2)
+}
+
+func (m *MockChatService) GetDrafts(ctx context.Context, userID, chatID string) ([]domain.Draft, error) {
+	args := m.Called(ctx, userID, chatID)
+	return args.Get(0).([]domain.Draft), args.Error(1)
+}
+
+func (m *MockChatService) ClearDraft(ctx context.Context, chatID, userID string, baseVersion int64, deviceID string) (domain.Draft, bool, error) {
+	args := m.Called(ctx, chatID, userID, baseVersion, deviceID)
+	return args.Get(0).(domain.Draft), args.Bool(1), args.Error(2)
+}
+
+func (m *MockChatService) CreateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error) {
+	args := m.Called(ctx, f)
+	return args.Get(0).(domain.ChatFolder), args.Error(1)
+}
+
+func (m *MockChatService) UpdateFolder(ctx context.Context, f domain.ChatFolder) (domain.ChatFolder, error) {
+	args := m.Called(ctx, f)
+	return args.Get(0).(domain.ChatFolder), args.Error(1)
+}
+
+func (m *MockChatService) DeleteFolder(ctx context.Context, folderID, userID string) error {
+	args := m.Called(ctx, folderID, userID)
+	return args.Error(0)
+}
+
+func (m *MockChatService) ReorderFolders(ctx context.Context, userID string, folderIDs []string) ([]domain.ChatFolder, error) {
+	args := m.Called(ctx, userID, folderIDs)
+	return args.Get(0).([]domain.ChatFolder), args.Error(1)
+}
+
+func (m *MockChatService) ListFolders(ctx context.Context, userID string) ([]domain.ChatFolder, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.ChatFolder), args.Error(1)
+}
+
+func (m *MockChatService) GetUpdates(ctx context.Context, userID string, sinceSeq int64, limit int) (domain.UpdatesPage, error) {
+	args := m.Called(ctx, userID, sinceSeq, limit)
+	return args.Get(0).(domain.UpdatesPage), args.Error(1)
+}
+
+// SubscribeChats отдаёт в send события из первого аргумента Return и возвращает второй
+func (m *MockChatService) SubscribeChats(ctx context.Context, userID, resumeToken string, send func(domain.StreamEvent) error) error {
+	args := m.Called(ctx, userID, resumeToken)
+	if events, ok := args.Get(0).([]domain.StreamEvent); ok {
+		for _, e := range events {
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+// ExportChat пишет в w строку из первого значения Return
+func (m *MockChatService) ExportChat(ctx context.Context, chatID, requesterID, format string, w io.Writer) error {
+	args := m.Called(ctx, chatID, requesterID, format)
+	if data, ok := args.Get(0).(string); ok {
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// ImportChat передаёт в мок прочитанный архив строкой
+func (m *MockChatService) ImportChat(ctx context.Context, requesterID, title string, r io.Reader) (domain.ImportResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+	args := m.Called(ctx, requesterID, title, string(data))
+	return args.Get(0).(domain.ImportResult), args.Error(1)
+}
+
+func (m *MockChatService) ReportMessage(ctx context.Context, messageID, reporterID, reason string) error {
+	args := m.Called(ctx, messageID, reporterID, reason)
+	return args.Error(0)
+}
+
+func (m *MockChatService) ListModerationQueue(ctx context.Context, chatID, requesterID string, limit int, cursor string) ([]domain.ModerationItem, string, error) {
+	args := m.Called(ctx, chatID, requesterID, limit, cursor)
+	return args.Get(0).([]domain.ModerationItem), args.String(1), args.Error(2)
+}
+
+func (m *MockChatService) ResolveModeration(ctx context.Context, itemID, requesterID string, status domain.ModerationStatus) (domain.ModerationItem, error) {
+	args := m.Called(ctx, itemID, requesterID, status)
+	return args.Get(0).(domain.ModerationItem), args.Error(1)
+}
+
+// fakeExportStream — серверная сторона потока ExportChat
+type fakeExportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*chatpb.ExportChatChunk
+}
+
+func (f *fakeExportStream) Context() context.Context { return f.ctx }
+
+func (f *fakeExportStream) Send(c *chatpb.ExportChatChunk) error {
+	f.sent = append(f.sent, c)
+	return nil
+}
+
+// fakeImportStream — клиентский поток ImportChat: отдаёт reqs по очереди, затем io.EOF
+type fakeImportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*chatpb.ImportChatRequest
+	resp *chatpb.ImportChatResponse
+}
+
+func (f *fakeImportStream) Context() context.Context { return f.ctx }
+
+func (f *fakeImportStream) Recv() (*chatpb.ImportChatRequest, error) {
+	if len(f.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := f.reqs[0]
+	f.reqs = f.reqs[1:]
+	return req, nil
+}
+
+func (f *fakeImportStream) SendAndClose(resp *chatpb.ImportChatResponse) error {
+	f.resp = resp
+	return nil
+}
+
+// fakeChatStream — серверная сторона потока SubscribeChats
+type fakeChatStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    []*chatpb.ChatStreamEvent
+	sendErr error
+}
+
+func (f *fakeChatStream) Context() context.Context { return f.ctx }
+
+func (f *fakeChatStream) Send(e *chatpb.ChatStreamEvent) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = append(f.sent, e)
+	return nil
+}
+
 // ==================== ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ====================
 
 // createTestServer создает тестовый gRPC сервер с моком сервиса
@@ -253,9 +607,12 @@ func TestChatServer_ListMessages_Success(t *testing.T) {
 		createTestMessage("msg-1", "chat1", "user1", "Message 1"),
 		createTestMessage("msg-2", "chat1", "user2", "Message 2"),
 	}
+	expectedMessages[0].Seq = 1
+	expectedMessages[1].Seq = 2
 
 	// Настройка моков
-	mockService.On("ListMessages", ctx, "chat1", 10, "").Return(expectedMessages, "msg-2", nil)
+	mockService.On("ListMessages", ctx, domain.MessageQuery{ChatID: "chat1", Limit: 10}).
+		Return(domain.MessagePage{Messages: expectedMessages, HasBefore: true}, nil)
 
 	// Выполнение
 	resp, err := server.ListMessages(ctx, req)
@@ -264,7 +621,11 @@ func TestChatServer_ListMessages_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Len(t, resp.Messages, 2)
-	assert.Equal(t, "msg-2", resp.NextCursor)
+	// Последняя страница: листать можно только назад, к более старым
+	assert.Empty(t, resp.NextCursor)
+	assert.Equal(t, "1", resp.PrevCursor)
+	assert.True(t, resp.HasBefore)
+	assert.False(t, resp.HasAfter)
 	assert.Equal(t, "Message 1", resp.Messages[0].Text)
 
 	mockService.AssertExpectations(t)
@@ -438,6 +799,17 @@ func TestChatServer_CreateDirectChat_NotFound(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestChatServer_CreateDirectChat_Blocked(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("CreateDirect", ctx, "user1", "user2").Return(domain.Chat{}, domain.ErrPermissionDenied)
+
+	_, err := server.CreateDirectChat(ctx, &chatpb.CreateDirectChatRequest{UserId: "user1", PeerId: "user2"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
 func TestChatServer_CreateGroupChat_Success(t *testing.T) {
 	// Подготовка
 	server, mockService := createTestServer()
@@ -486,8 +858,11 @@ func TestChatServer_ListChats_Success(t *testing.T) {
 		createTestChat("chat2", domain.ChatKindGroup),
 	}
 
+	expectedChats[0].State = &domain.UserChatState{ChatID: "chat1", Pinned: true, UnreadCount: 4}
+	expectedChats[1].LastMessage = &domain.MessagePreview{ID: "msg-9", Seq: 9, Text: "Последнее"}
+
 	// Настройка моков
-	mockService.On("ListChats", ctx, "user1", 10, "").Return(expectedChats, "chat2", nil)
+	mockService.On("ListChats", ctx, "user1", 10, "", false).Return(expectedChats, "chat2", nil)
 
 	// Выполнение
 	resp, err := server.ListChats(ctx, req)
@@ -497,10 +872,68 @@ func TestChatServer_ListChats_Success(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Len(t, resp.Chats, 2)
 	assert.Equal(t, "chat2", resp.NextCursor)
+	assert.True(t, resp.Chats[0].State.Pinned)
+	assert.Equal(t, int64(4), resp.Chats[0].State.UnreadCount)
+	assert.Equal(t, "Последнее", resp.Chats[1].LastMessage.Text)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ListChats_Folder(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	// С folder_id список строится по правилам папки, archived не учитывается
+	mockService.On("ListFolderChats", ctx, "user1", "folder1", 20, "").
+		Return([]domain.Chat{createTestChat("chat2", domain.ChatKindGroup)}, "100:chat2", nil)
+
+	resp, err := server.ListChats(ctx, &chatpb.ListChatsRequest{UserId: "user1", Limit: 20, FolderId: "folder1", Archived: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Chats, 1)
+	assert.Equal(t, "100:chat2", resp.NextCursor)
+	mockService.AssertNotCalled(t, "ListChats", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockService.AssertExpectations(t)
+}
 
+func TestChatServer_CreateFolder(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	expected := domain.ChatFolder{
+		UserID: "user1",
+		Title:  "Работа",
+		Rules:  domain.FolderRules{Kinds: []domain.ChatKind{domain.ChatKindGroup}, Unread: domain.FolderFlagOnly},
+	}
+	created := expected
+	created.ID = "folder1"
+	mockService.On("CreateFolder", ctx, expected).Return(created, nil)
+
+	resp, err := server.CreateFolder(ctx, &chatpb.FolderRequest{
+		UserId: "user1",
+		Folder: &chatpb.ChatFolder{
+			Title: "Работа",
+			Rules: &chatpb.FolderRules{Kinds: []string{"group"}, Unread: "only"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "folder1", resp.Folder.Id)
+	assert.Equal(t, []string{"group"}, resp.Folder.Rules.Kinds)
 	mockService.AssertExpectations(t)
 }
 
+func TestChatServer_CreateFolder_TooMany(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("CreateFolder", ctx, mock.Anything).Return(domain.ChatFolder{}, domain.ErrTooManyFolders)
+
+	_, err := server.CreateFolder(ctx, &chatpb.FolderRequest{UserId: "user1", Folder: &chatpb.ChatFolder{Title: "21-я"}})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
 func TestChatServer_GetChat_Success(t *testing.T) {
 	// Подготовка
 	server, mockService := createTestServer()
@@ -719,7 +1152,8 @@ func TestChatServer_ListMessages_ServiceError(t *testing.T) {
 	}
 
 	// Настройка моков - сервис возвращает ошибку
-	mockService.On("ListMessages", ctx, "chat1", 10, "").Return([]domain.Message{}, "", assert.AnError)
+	mockService.On("ListMessages", ctx, domain.MessageQuery{ChatID: "chat1", Limit: 10}).
+		Return(domain.MessagePage{}, assert.AnError)
 
 	// Выполнение
 	resp, err := server.ListMessages(ctx, req)
@@ -844,31 +1278,891 @@ func TestToProtoChat(t *testing.T) {
 	assert.Contains(t, protoChat.MemberIds, "user2")
 }
 
-// ==================== ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ====================
+func TestChatServer_PinMessage_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
 
-// createTestChat создает тестовый чат
-func createTestChat(id string, kind domain.ChatKind) domain.Chat {
-	return domain.Chat{
-		ID:        id,
-		Kind:      kind,
-		MemberIDs: []string{"user1", "user2"},
-		Title:     "Test Chat",
-		CreatedBy: "user1",
-		CreatedAt: time.Now().Unix(),
-	}
+	chat := createTestChat("chat1", domain.ChatKindDirect)
+	chat.Pinned = []domain.PinnedMessage{{MessageID: "msg1", PinnedBy: "user1", PinnedAt: 1234567890}}
+
+	mockService.On("PinMessage", ctx, "chat1", "msg1", "user1").Return(chat, nil)
+
+	// Выполнение
+	resp, err := server.PinMessage(ctx, &chatpb.PinMessageRequest{ChatId: "chat1", MessageId: "msg1", RequesterId: "user1"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Chat.Pinned, 1)
+	assert.Equal(t, "msg1", resp.Chat.Pinned[0].MessageId)
+	assert.Equal(t, "1234567890", resp.Chat.Pinned[0].PinnedAt)
+
+	mockService.AssertExpectations(t)
 }
 
-// createTestMessage создает тестовое сообщение
-func createTestMessage(id, chatID, authorID, text string) domain.Message {
-	return domain.Message{
-		ID:        id,
-		ChatID:    chatID,
-		AuthorID:  authorID,
-		Text:      text,
-		CreatedAt: time.Now().Unix(),
-		UpdatedAt: time.Now().Unix(),
-		ReadBy:    []domain.ReadInfo{},
-		SavedBy:   []domain.SavedInfo{},
-		Deleted:   false,
+func TestChatServer_PinMessage_PermissionDenied(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("PinMessage", ctx, "chat1", "msg1", "user2").Return(domain.Chat{}, domain.ErrPermissionDenied)
+
+	// Выполнение
+	resp, err := server.PinMessage(ctx, &chatpb.PinMessageRequest{ChatId: "chat1", MessageId: "msg1", RequesterId: "user2"})
+
+	// Проверки
+	assert.Nil(t, resp)
+	grpcErr, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, grpcErr.Code())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ListPinned_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	msg := createTestMessage("msg1", "chat1", "user1", "Hello")
+	mockService.On("ListPinned", ctx, "chat1", "user2").Return([]domain.PinnedMessage{
+		{MessageID: "msg1", PinnedBy: "user1", Message: &msg},
+	}, nil)
+
+	// Выполнение
+	resp, err := server.ListPinned(ctx, &chatpb.ListPinnedRequest{ChatId: "chat1", RequesterId: "user2"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Pinned, 1)
+	assert.Equal(t, "Hello", resp.Pinned[0].Message.Text)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ListMessageRevisions_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("ListMessageRevisions", ctx, "msg1", "user1").Return([]domain.MessageRevision{
+		{MessageID: "msg1", Revision: 1, Text: "Original", CreatedAt: 100, ReplacedAt: 200},
+	}, nil)
+
+	// Выполнение
+	resp, err := server.ListMessageRevisions(ctx, &chatpb.ListMessageRevisionsRequest{MessageId: "msg1", RequesterId: "user1"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Revisions, 1)
+	assert.Equal(t, int32(1), resp.Revisions[0].Revision)
+	assert.Equal(t, "Original", resp.Revisions[0].Text)
+	assert.Equal(t, "200", resp.Revisions[0].ReplacedAt)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_UpdateMessage_EditWindowClosed(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	text := "New"
+	mockService.On("UpdateMessage", ctx, "msg1", "user1", &text, (*[]domain.Media)(nil)).
+		Return(domain.Message{}, domain.ErrEditWindowClosed)
+
+	// Выполнение
+	_, err := server.UpdateMessage(ctx, &chatpb.UpdateMessageRequest{MessageId: "msg1", AuthorId: "user1", Text: "New"})
+
+	// Проверки
+	grpcErr, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, grpcErr.Code())
+}
+
+func TestChatServer_ListMentions_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("ListMentions", ctx, "user2", "chat1", 1, "").Return([]domain.Mention{
+		{ChatID: "chat1", MessageID: "msg1", AuthorID: "user1", CreatedAt: 100},
+	}, "100:msg1", int64(3), nil)
+
+	// Выполнение
+	resp, err := server.ListMentions(ctx, &chatpb.ListMentionsRequest{UserId: "user2", ChatId: "chat1", Limit: 1})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Mentions, 1)
+	assert.Equal(t, "msg1", resp.Mentions[0].MessageId)
+	assert.Equal(t, "100:msg1", resp.NextCursor)
+	assert.Equal(t, int64(3), resp.UnreadCount)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_SetTyping_InvalidAction(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SetTyping", ctx, "chat1", "user1", domain.TypingAction("dancing")).
+		Return(false, domain.ErrInvalidArgument)
+
+	// Выполнение
+	_, err := server.SetTyping(ctx, &chatpb.SetTypingRequest{ChatId: "chat1", UserId: "user1", Action: "dancing"})
+
+	// Проверки
+	grpcErr, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, grpcErr.Code())
+}
+
+func TestChatServer_ListTyping_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("ListTyping", ctx, "chat1", "user2").Return([]domain.TypingStatus{
+		{ChatID: "chat1", UserID: "user1", Action: domain.TypingActionRecording, ExpiresAt: 1000},
+	}, nil)
+
+	// Выполнение
+	resp, err := server.ListTyping(ctx, &chatpb.ListTypingRequest{ChatId: "chat1", RequesterId: "user2"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Statuses, 1)
+	assert.Equal(t, "recording_voice", resp.Statuses[0].Action)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ListMessages_CursorIsAfterSeq(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	// next_cursor предыдущего ответа продолжает историю вперёд
+	mockService.On("ListMessages", ctx, domain.MessageQuery{ChatID: "chat1", Limit: 10, AfterSeq: 20}).
+		Return(domain.MessagePage{}, nil)
+
+	// Выполнение
+	resp, err := server.ListMessages(ctx, &chatpb.ListMessagesRequest{ChatId: "chat1", Limit: 10, Cursor: "20"})
+
+	// Проверки
+	assert.NoError(t, err)
+	// Новее ничего нет — курсор вперёд не выдаётся
+	assert.Empty(t, resp.NextCursor)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ListMessages_PrevCursorIsBeforeSeq(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	// prev_cursor предыдущего ответа продолжает историю назад
+	mockService.On("ListMessages", ctx, domain.MessageQuery{ChatID: "chat1", Limit: 2, BeforeSeq: 20}).
+		Return(domain.MessagePage{
+			Messages:  []domain.Message{{ID: "m18", Seq: 18}, {ID: "m19", Seq: 19}},
+			HasBefore: true,
+			HasAfter:  true,
+		}, nil)
+
+	// Выполнение
+	resp, err := server.ListMessages(ctx, &chatpb.ListMessagesRequest{ChatId: "chat1", Limit: 2, PrevCursor: "20"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "18", resp.PrevCursor)
+	assert.Equal(t, "19", resp.NextCursor)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ListMessages_InvalidCursor(t *testing.T) {
+	server, _ := createTestServer()
+
+	// Курсоры старого формата (id сообщения) больше не принимаются
+	_, err := server.ListMessages(context.Background(), &chatpb.ListMessagesRequest{ChatId: "chat1", Cursor: "msg-2"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestChatServer_PinChat_TooMany(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("PinChat", ctx, "chat1", "user1", true).Return(domain.UserChatState{}, domain.ErrTooManyPinnedChats)
+
+	// Выполнение
+	_, err := server.PinChat(ctx, &chatpb.PinChatRequest{ChatId: "chat1", UserId: "user1", Pinned: true})
+
+	// Проверки
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestChatServer_MuteChat_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("MuteChat", ctx, "chat1", "user1", int64(1700000000)).
+		Return(domain.UserChatState{ChatID: "chat1", MutedUntil: 1700000000}, nil)
+
+	// Выполнение
+	resp, err := server.MuteChat(ctx, &chatpb.MuteChatRequest{ChatId: "chat1", UserId: "user1", MutedUntil: 1700000000})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1700000000), resp.State.MutedUntil)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_UpdateScheduledMessage_OnlySendAt(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	sendAt := int64(1900000000)
+	mockService.On("UpdateScheduledMessage", ctx, "sch1", "user1", (*string)(nil), (*[]domain.Media)(nil), &sendAt).
+		Return(domain.ScheduledMessage{ID: "sch1", SendAt: sendAt, Status: domain.ScheduledPending}, nil)
+
+	// Выполнение
+	resp, err := server.UpdateScheduledMessage(ctx, &chatpb.UpdateScheduledMessageRequest{Id: "sch1", AuthorId: "user1", SendAt: sendAt})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, sendAt, resp.Scheduled.SendAt)
+	assert.Equal(t, "pending", resp.Scheduled.Status)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_CancelScheduledMessage_AlreadySent(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("CancelScheduledMessage", ctx, "sch1", "user1").Return(domain.ErrScheduledClosed)
+
+	// Выполнение
+	_, err := server.CancelScheduledMessage(ctx, &chatpb.CancelScheduledMessageRequest{Id: "sch1", AuthorId: "user1"})
+
+	// Проверки
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestChatServer_SetMessageTTL_Invalid(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SetMessageTTL", ctx, "chat1", "user1", int64(5)).Return(domain.Chat{}, domain.ErrInvalidArgument)
+
+	// Выполнение
+	_, err := server.SetMessageTTL(ctx, &chatpb.SetMessageTTLRequest{ChatId: "chat1", RequesterId: "user1", TtlSeconds: 5})
+
+	// Проверки
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestChatServer_SendMessage_Poll(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	poll := &domain.Poll{
+		Question: "Где?",
+		Options:  []domain.PollOption{{ID: 0, Text: "Тут", Votes: 2}, {ID: 1, Text: "Там"}},
+	}
+	mockService.On("SendMessage", ctx, mock.MatchedBy(func(m domain.Message) bool {
+		return m.Poll != nil && m.Poll.Question == "Где?" && len(m.Poll.Options) == 2 && m.Poll.MultipleChoice
+	})).Return(domain.Message{ID: "poll1", ChatID: "chat1", Type: domain.MessageTypePoll, Poll: poll}, nil)
+
+	// Выполнение
+	resp, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{
+		ChatId:   "chat1",
+		AuthorId: "user1",
+		Poll:     &chatpb.PollInput{Question: "Где?", Options: []string{"Тут", "Там"}, MultipleChoice: true},
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "poll1", resp.Message.Poll.MessageId)
+	assert.Equal(t, int64(2), resp.Message.Poll.Options[0].Votes)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_Vote_PollClosed(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("Vote", ctx, "poll1", "user2", []int{1}).Return(domain.PollResults{}, domain.ErrPollClosed)
+
+	// Выполнение
+	_, err := server.Vote(ctx, &chatpb.VoteRequest{MessageId: "poll1", UserId: "user2", OptionIds: []int32{1}})
+
+	// Проверки
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestChatServer_GetPollResults_Voters(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("GetPollResults", ctx, "poll1", "user1").Return(domain.PollResults{
+		MessageID:   "poll1",
+		Poll:        domain.Poll{Options: []domain.PollOption{{ID: 0, Text: "Да", Votes: 1}, {ID: 1, Text: "Нет"}}},
+		MyOptionIDs: []int{0},
+		Voters:      map[int][]string{0: {"user1"}},
+	}, nil)
+
+	// Выполнение
+	resp, err := server.GetPollResults(ctx, &chatpb.GetPollResultsRequest{MessageId: "poll1", RequesterId: "user1"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{0}, resp.MyOptionIds)
+	assert.Len(t, resp.Voters, 2)
+	assert.Equal(t, []string{"user1"}, resp.Voters[0].UserIds)
+	assert.Empty(t, resp.Voters[1].UserIds)
+}
+
+func TestChatServer_CreateChannel_HandleTaken(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("CreateChannel", ctx, "user1", "Новости", "news").Return(domain.Chat{}, domain.ErrHandleTaken)
+
+	// Выполнение
+	_, err := server.CreateChannel(ctx, &chatpb.CreateChannelRequest{UserId: "user1", Title: "Новости", Handle: "news"})
+
+	// Проверки
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestChatServer_SubscribeChannel_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	channel := domain.Chat{ID: "channel1", Kind: domain.ChatKindChannel, Handle: "news", SubscriberCount: 42}
+	mockService.On("SubscribeChannel", ctx, "channel1", "user2").Return(channel, nil)
+
+	// Выполнение
+	resp, err := server.SubscribeChannel(ctx, &chatpb.SubscribeChannelRequest{ChatId: "channel1", UserId: "user2"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Equal(t, "channel", resp.Chat.Kind)
+	assert.Equal(t, "news", resp.Chat.Handle)
+	assert.Equal(t, int64(42), resp.Chat.SubscriberCount)
+}
+
+func TestChatServer_JoinByInvite_Pending(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	req := &domain.JoinRequest{ID: "req1", ChatID: "group1", UserID: "user3", Status: domain.JoinRequestPending}
+	mockService.On("JoinByInvite", ctx, "code1", "user3").Return(domain.Chat{}, req, nil)
+
+	// Выполнение
+	resp, err := server.JoinByInvite(ctx, &chatpb.JoinByInviteRequest{Code: "code1", UserId: "user3"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Chat)
+	assert.Equal(t, "pending", resp.JoinRequest.Status)
+}
+
+func TestChatServer_JoinByInvite_UsedUp(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("JoinByInvite", ctx, "code1", "user3").Return(domain.Chat{}, nil, domain.ErrInviteUnusable)
+
+	_, err := server.JoinByInvite(ctx, &chatpb.JoinByInviteRequest{Code: "code1", UserId: "user3"})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestChatServer_MarkRead_NotMember(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("MarkRead", ctx, "chat1", "user9", "msg1").Return(domain.ErrPermissionDenied)
+
+	// Выполнение
+	_, err := server.MarkRead(ctx, &chatpb.MarkReadRequest{ChatId: "chat1", UserId: "user9", MessageId: "msg1"})
+
+	// Проверки
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestChatServer_GetReadState_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	read := []domain.ReadState{{UserID: "user2", LastReadSeq: 7, LastReadAt: 1640995200, LastDeliveredSeq: 7}}
+	delivered := []domain.ReadState{{UserID: "user3", LastReadSeq: 3, LastDeliveredSeq: 7}}
+	mockService.On("GetReadState", ctx, "chat1", "msg1", "user1").Return(read, delivered, nil)
+
+	// Выполнение
+	resp, err := server.GetReadState(ctx, &chatpb.GetReadStateRequest{ChatId: "chat1", MessageId: "msg1", RequesterId: "user1"})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Read, 1)
+	assert.Equal(t, "user2", resp.Read[0].UserId)
+	assert.Equal(t, "1640995200", resp.Read[0].LastReadAt)
+	assert.Len(t, resp.Delivered, 1)
+	assert.Equal(t, int64(7), resp.Delivered[0].LastDeliveredSeq)
+
+	mockService.AssertExpectations(t)
+}
+
+// ==================== ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ ====================
+
+// createTestChat создает тестовый чат
+func createTestChat(id string, kind domain.ChatKind) domain.Chat {
+	return domain.Chat{
+		ID:        id,
+		Kind:      kind,
+		MemberIDs: []string{"user1", "user2"},
+		Title:     "Test Chat",
+		CreatedBy: "user1",
+		CreatedAt: time.Now().Unix(),
+	}
+}
+
+// createTestMessage создает тестовое сообщение
+func createTestMessage(id, chatID, authorID, text string) domain.Message {
+	return domain.Message{
+		ID:        id,
+		ChatID:    chatID,
+		AuthorID:  authorID,
+		Text:      text,
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+		SavedBy:   []domain.SavedInfo{},
+		Deleted:   false,
+	}
+}
+
+func TestChatServer_SaveDraft_Stale(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	current := domain.Draft{ChatID: "chat1", UserID: "user1", Text: "новее", UpdatedAt: 2000, DeviceID: "phone", Version: 5}
+	mockService.On("SaveDraft", ctx, domain.Draft{
+		ChatID:    "chat1",
+		UserID:    "user1",
+		Text:      "старее",
+		ReplyToID: "msg1",
+		DeviceID:  "laptop",
+	}, int64(4)).Return(current, false, nil)
+
+	// Выполнение
+	resp, err := server.SaveDraft(ctx, &chatpb.SaveDraftRequest{
+		ChatId:           "chat1",
+		UserId:           "user1",
+		Text:             "старее",
+		ReplyToMessageId: "msg1",
+		BaseVersion:      4,
+		DeviceId:         "laptop",
+	})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.False(t, resp.Applied)
+	assert.Equal(t, "новее", resp.Draft.Text)
+	assert.Equal(t, "phone", resp.Draft.DeviceId)
+	assert.Equal(t, int64(5), resp.Draft.Version)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_SaveDraft_ReplyNotFound(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SaveDraft", ctx, mock.Anything, int64(0)).Return(domain.Draft{}, false, domain.ErrMessageNotFound)
+
+	_, err := server.SaveDraft(ctx, &chatpb.SaveDraftRequest{ChatId: "chat1", UserId: "user1", ReplyToMessageId: "gone"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestChatServer_GetUpdates_Success(t *testing.T) {
+	// Подготовка
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("GetUpdates", ctx, "user1", int64(10), 50).Return(domain.UpdatesPage{
+		Updates: []domain.UserUpdate{
+			{Seq: 11, Type: domain.UpdateMessageNew, ChatID: "chat1", MessageIDs: []string{"msg1"},
+				Messages: []domain.Message{{ID: "msg1", ChatID: "chat1", Text: "Привет"}}},
+			{Seq: 12, Type: domain.UpdateRead, ChatID: "chat1", ActorID: "user2", ReadSeq: 5},
+		},
+		Seq:     12,
+		HasMore: true,
+	}, nil)
+
+	// Выполнение
+	resp, err := server.GetUpdates(ctx, &chatpb.GetUpdatesRequest{UserId: "user1", SinceSeq: 10, Limit: 50})
+
+	// Проверки
+	assert.NoError(t, err)
+	assert.Len(t, resp.Updates, 2)
+	assert.Equal(t, "message.new", resp.Updates[0].Type)
+	assert.Equal(t, "Привет", resp.Updates[0].Messages[0].Text)
+	assert.Equal(t, int64(5), resp.Updates[1].ReadSeq)
+	assert.Equal(t, int64(12), resp.Seq)
+	assert.True(t, resp.HasMore)
+	assert.False(t, resp.Resync)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_GetUpdates_Resync(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("GetUpdates", ctx, "user1", int64(3), 0).Return(domain.UpdatesPage{Seq: 900, Resync: true}, nil)
+
+	resp, err := server.GetUpdates(ctx, &chatpb.GetUpdatesRequest{UserId: "user1", SinceSeq: 3})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Resync)
+	assert.Empty(t, resp.Updates)
+	assert.Equal(t, int64(900), resp.Seq)
+}
+
+func TestChatServer_SubscribeChats_StreamsEvents(t *testing.T) {
+	server, mockService := createTestServer()
+	stream := &fakeChatStream{ctx: context.Background()}
+
+	mockService.On("SubscribeChats", stream.ctx, "user1", "10").Return([]domain.StreamEvent{
+		{Update: &domain.UserUpdate{Seq: 11, Type: domain.UpdateMembership, ChatID: "chat1", Action: domain.MembershipAdded, UserIDs: []string{"user3"}}, ResumeToken: "11"},
+		{Resync: true, ResumeToken: "500"},
+		{Typing: &domain.TypingStatus{ChatID: "chat1", UserID: "user2", Action: domain.TypingActionRecording, ExpiresAt: 1700}},
+	}, nil)
+
+	err := server.SubscribeChats(&chatpb.SubscribeChatsRequest{UserId: "user1", ResumeToken: "10"}, stream)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 3)
+	assert.Equal(t, "membership", stream.sent[0].Update.Type)
+	assert.Equal(t, []string{"user3"}, stream.sent[0].Update.UserIds)
+	assert.Equal(t, "11", stream.sent[0].ResumeToken)
+	assert.True(t, stream.sent[1].Resync)
+	assert.Nil(t, stream.sent[1].Update)
+	assert.Equal(t, "500", stream.sent[1].ResumeToken)
+	assert.Equal(t, "recording_voice", stream.sent[2].Typing.Action)
+	assert.Equal(t, "user2", stream.sent[2].Typing.UserId)
+	assert.Empty(t, stream.sent[2].ResumeToken)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_SubscribeChats_Errors(t *testing.T) {
+	t.Run("bad resume token", func(t *testing.T) {
+		server, mockService := createTestServer()
+		stream := &fakeChatStream{ctx: context.Background()}
+		mockService.On("SubscribeChats", stream.ctx, "user1", "abc").Return(nil, domain.ErrInvalidArgument)
+
+		err := server.SubscribeChats(&chatpb.SubscribeChatsRequest{UserId: "user1", ResumeToken: "abc"}, stream)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("send error is passed through", func(t *testing.T) {
+		server, mockService := createTestServer()
+		stream := &fakeChatStream{ctx: context.Background(), sendErr: status.Error(codes.Unavailable, "transport is closing")}
+		mockService.On("SubscribeChats", stream.ctx, "user1", "").Return([]domain.StreamEvent{{Resync: true, ResumeToken: "1"}}, nil)
+
+		err := server.SubscribeChats(&chatpb.SubscribeChatsRequest{UserId: "user1"}, stream)
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestChatServer_SendMessage_PassesMedia(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SendMessage", ctx, mock.MatchedBy(func(m domain.Message) bool {
+		return len(m.Media) == 1 && m.Media[0].ID == "file1" && m.Media[0].AuthorID == "user1"
+	})).Return(domain.Message{ID: "msg1", ChatID: "chat1", Media: []domain.Media{{ID: "file1", Mime: "image/png", SizeBytes: 10}}}, nil)
+
+	resp, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{
+		ChatId: "chat1", AuthorId: "user1", Media: []*chatpb.Media{{Id: "file1", Url: "https://evil.example"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", resp.Message.Media[0].Mime)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_SendMessage_MediaNotFound(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SendMessage", ctx, mock.Anything).Return(domain.Message{}, domain.ErrMediaNotFound)
+
+	_, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{ChatId: "chat1", AuthorId: "user1", Media: []*chatpb.Media{{Id: "x"}}})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestChatServer_ExportChat_Chunks(t *testing.T) {
+	server, mockService := createTestServer()
+	stream := &fakeExportStream{ctx: context.Background()}
+	big := strings.Repeat("x", exportChunkSize+10)
+
+	mockService.On("ExportChat", stream.ctx, "chat1", "user1", "html").Return(big, nil)
+
+	err := server.ExportChat(&chatpb.ExportChatRequest{ChatId: "chat1", RequesterId: "user1", Format: "html"}, stream)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 2)
+	assert.Equal(t, "text/html; charset=utf-8", stream.sent[0].ContentType)
+	assert.Equal(t, "chat-chat1.html", stream.sent[0].Filename)
+	assert.Empty(t, stream.sent[1].ContentType)
+	assert.Equal(t, big, string(stream.sent[0].Data)+string(stream.sent[1].Data))
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ExportChat_DefaultsToJSON(t *testing.T) {
+	server, mockService := createTestServer()
+	stream := &fakeExportStream{ctx: context.Background()}
+
+	mockService.On("ExportChat", stream.ctx, "chat1", "user1", "json").Return(`{"version":1}`, nil)
+
+	err := server.ExportChat(&chatpb.ExportChatRequest{ChatId: "chat1", RequesterId: "user1"}, stream)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 1)
+	assert.Equal(t, "application/json", stream.sent[0].ContentType)
+	assert.Equal(t, "chat-chat1.json", stream.sent[0].Filename)
+}
+
+func TestChatServer_ExportChat_NotReader(t *testing.T) {
+	server, mockService := createTestServer()
+	stream := &fakeExportStream{ctx: context.Background()}
+
+	mockService.On("ExportChat", stream.ctx, "chat1", "stranger", "json").Return(nil, domain.ErrPermissionDenied)
+
+	err := server.ExportChat(&chatpb.ExportChatRequest{ChatId: "chat1", RequesterId: "stranger", Format: "json"}, stream)
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Empty(t, stream.sent)
+}
+
+func TestChatServer_ImportChat_JoinsChunks(t *testing.T) {
+	server, mockService := createTestServer()
+	stream := &fakeImportStream{ctx: context.Background(), reqs: []*chatpb.ImportChatRequest{
+		{RequesterId: "user1", Title: "Архив", Data: []byte(`{"version":`)},
+		{Data: []byte(`1}`)},
+	}}
+
+	mockService.On("ImportChat", stream.ctx, "user1", "Архив", `{"version":1}`).Return(domain.ImportResult{
+		Chat:               domain.Chat{ID: "new1", Kind: domain.ChatKindGroup, Title: "Архив"},
+		ImportedMessages:   3,
+		SkippedMembers:     1,
+		DroppedAttachments: 2,
+		ReassignedMessages: 1,
+	}, nil)
+
+	err := server.ImportChat(stream)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new1", stream.resp.Chat.Id)
+	assert.Equal(t, int32(3), stream.resp.ImportedMessages)
+	assert.Equal(t, int32(1), stream.resp.SkippedMembers)
+	assert.Equal(t, int32(2), stream.resp.DroppedAttachments)
+	assert.Equal(t, int32(1), stream.resp.ReassignedMessages)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_ImportChat_Errors(t *testing.T) {
+	t.Run("empty stream", func(t *testing.T) {
+		server, _ := createTestServer()
+
+		err := server.ImportChat(&fakeImportStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("bad archive", func(t *testing.T) {
+		server, mockService := createTestServer()
+		stream := &fakeImportStream{ctx: context.Background(), reqs: []*chatpb.ImportChatRequest{
+			{RequesterId: "user1", Data: []byte("nope")},
+		}}
+		mockService.On("ImportChat", stream.ctx, "user1", "", "nope").
+			Return(domain.ImportResult{}, domain.ErrInvalidArgument)
+
+		err := server.ImportChat(stream)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, stream.resp)
+	})
+}
+
+func TestChatServer_SendMessage_RateLimited(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SendMessage", ctx, mock.Anything).
+		Return(domain.Message{}, &domain.RateLimitError{RetryAfter: 12 * time.Second, SlowMode: true})
+
+	_, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{ChatId: "group1", AuthorId: "user2", Text: "ещё"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Contains(t, st.Message(), "через 12 с")
+	if assert.Len(t, st.Details(), 1) {
+		info, ok := st.Details()[0].(*errdetails.RetryInfo)
+		assert.True(t, ok)
+		assert.Equal(t, 12*time.Second, info.RetryDelay.AsDuration())
+	}
+}
+
+func TestChatServer_SetSlowMode(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SetSlowMode", ctx, "group1", "user1", int64(30)).
+		Return(domain.Chat{ID: "group1", Kind: domain.ChatKindGroup, SlowModeSeconds: 30}, nil)
+	mockService.On("SetSlowMode", ctx, "group1", "user2", int64(30)).Return(domain.Chat{}, domain.ErrPermissionDenied)
+
+	resp, err := server.SetSlowMode(ctx, &chatpb.SetSlowModeRequest{ChatId: "group1", RequesterId: "user1", Seconds: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), resp.Chat.SlowModeSeconds)
+
+	_, err = server.SetSlowMode(ctx, &chatpb.SetSlowModeRequest{ChatId: "group1", RequesterId: "user2", Seconds: 30})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestChatServer_SendMessage_Held(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SendMessage", ctx, mock.Anything).Return(domain.Message{ID: "msg1", ChatID: "group1", Held: true}, nil).Once()
+	mockService.On("SendMessage", ctx, mock.Anything).
+		Return(domain.Message{}, fmt.Errorf("%w: banned_words: запрещённое слово", domain.ErrMessageRejected)).Once()
+
+	resp, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{ChatId: "group1", AuthorId: "user2", Text: "см. spam.example"})
+	assert.NoError(t, err)
+	assert.True(t, resp.Message.Held)
+
+	_, err = server.SendMessage(ctx, &chatpb.SendMessageRequest{ChatId: "group1", AuthorId: "user2", Text: "дурак"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "запрещённое слово")
+}
+
+func TestChatServer_ReportMessage(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("ReportMessage", ctx, "msg1", "user2", "спам").Return(nil)
+	mockService.On("ReportMessage", ctx, "msg2", "user2", "").Return(domain.ErrMessageNotFound)
+
+	_, err := server.ReportMessage(ctx, &chatpb.ReportMessageRequest{MessageId: "msg1", ReporterId: "user2", Reason: "спам"})
+	assert.NoError(t, err)
+
+	_, err = server.ReportMessage(ctx, &chatpb.ReportMessageRequest{MessageId: "msg2", ReporterId: "user2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestChatServer_ModerationQueue(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	item := domain.ModerationItem{
+		ID: "mod1", ChatID: "group1", Kind: domain.ModerationKindReport, MessageID: "msg1", Status: domain.ModerationPending,
+		CreatedAt: 1000, Reports: []domain.ModerationReport{{UserID: "user2", Reason: "спам", CreatedAt: 1000}},
+	}
+	mockService.On("ListModerationQueue", ctx, "group1", "user1", 20, "").Return([]domain.ModerationItem{item}, "1000:mod1", nil)
+	decided := item
+	decided.Status, decided.DecidedBy, decided.DecidedAt = domain.ModerationRemoved, "user1", 2000
+	mockService.On("ResolveModeration", ctx, "mod1", "user1", domain.ModerationRemoved).Return(decided, nil)
+	mockService.On("ResolveModeration", ctx, "mod1", "user1", domain.ModerationAllowed).Return(domain.ModerationItem{}, domain.ErrModerationItemNotFound)
+
+	list, err := server.ListModerationQueue(ctx, &chatpb.ListModerationQueueRequest{ChatId: "group1", RequesterId: "user1", Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, "1000:mod1", list.NextCursor)
+	assert.Equal(t, "report", list.Items[0].Kind)
+	assert.Equal(t, "спам", list.Items[0].Reports[0].Reason)
+	assert.Empty(t, list.Items[0].DecidedAt)
+
+	resp, err := server.ResolveModeration(ctx, &chatpb.ResolveModerationRequest{ItemId: "mod1", RequesterId: "user1", Status: "removed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "removed", resp.Item.Status)
+	assert.Equal(t, "2000", resp.Item.DecidedAt)
+
+	_, err = server.ResolveModeration(ctx, &chatpb.ResolveModerationRequest{ItemId: "mod1", RequesterId: "user1", Status: "allowed"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestChatServer_CreateSecretChat(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("CreateSecretChat", ctx, "user1", "user2").
+		Return(domain.Chat{ID: "s1", Kind: domain.ChatKindDirect, MemberIDs: []string{"user1", "user2"}, Secret: true}, nil)
+
+	resp, err := server.CreateSecretChat(ctx, &chatpb.CreateSecretChatRequest{UserId: "user1", PeerId: "user2"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Chat.Secret)
+	mockService.AssertExpectations(t)
+}
+
+func TestChatServer_SendMessage_Envelopes(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	envelopes := []domain.Envelope{{UserID: "user2", DeviceID: "phone", Ciphertext: []byte{1, 2}}}
+	mockService.On("SendMessage", ctx, domain.Message{ChatID: "s1", AuthorID: "user1", SenderDeviceID: "laptop", Envelopes: envelopes}).
+		Return(domain.Message{ID: "m1", ChatID: "s1", Type: domain.MessageTypeEncrypted, SenderDeviceID: "laptop", Envelopes: envelopes}, nil)
+
+	resp, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{
+		ChatId:         "s1",
+		AuthorId:       "user1",
+		SenderDeviceId: "laptop",
+		Envelopes:      []*chatpb.Envelope{{UserId: "user2", DeviceId: "phone", Ciphertext: []byte{1, 2}}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "encrypted", resp.Message.Type)
+	assert.Equal(t, "laptop", resp.Message.SenderDeviceId)
+	assert.Equal(t, []byte{1, 2}, resp.Message.Envelopes[0].Ciphertext)
+}
+
+func TestChatServer_SendMessage_DeviceMismatch(t *testing.T) {
+	server, mockService := createTestServer()
+	ctx := context.Background()
+
+	mockService.On("SendMessage", ctx, mock.Anything).
+		Return(domain.Message{}, &domain.DeviceMismatchError{Missing: []string{"user2/tablet"}, Unknown: []string{"user2/old"}})
+
+	_, err := server.SendMessage(ctx, &chatpb.SendMessageRequest{ChatId: "s1", AuthorId: "user1", SenderDeviceId: "laptop"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+		if assert.True(t, ok) && assert.Len(t, failure.Violations, 2) {
+			assert.Equal(t, "DEVICE_MISSING", failure.Violations[0].Type)
+			assert.Equal(t, "user2/tablet", failure.Violations[0].Subject)
+			assert.Equal(t, "DEVICE_UNKNOWN", failure.Violations[1].Type)
+		}
 	}
 }
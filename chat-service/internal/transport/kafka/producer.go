@@ -2,8 +2,9 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"main/internal/domain"
+	"main/internal/events"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 )
@@ -18,22 +19,32 @@ func NewProducer(brokers []string, topic string) *Producer {
 			Addr:     kafka.TCP(brokers...),
 			Topic:    topic,
 			Balancer: &kafka.LeastBytes{},
+			// relay публикует события по одному: не ждём наполнения пачки (по умолчанию до 1s)
+			BatchTimeout: 10 * time.Millisecond,
 		},
 	}
 }
 
-func (p *Producer) PublishNewMessage(ctx context.Context, e domain.NewMessageEvent) error {
-	data, _ := json.Marshal(e)
+// Publish отправляет готовое сообщение — через него relay публикует строки outbox
+func (p *Producer) Publish(ctx context.Context, key string, value []byte) error {
 	return p.writer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(e.MessageID),
-		Value: data,
+		Key:   []byte(key),
+		Value: value,
 	})
 }
 
+func (p *Producer) PublishNewMessage(ctx context.Context, e domain.NewMessageEvent) error {
+	key, data, err := events.EncodeNewMessage(e)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, key, data)
+}
+
 func (p *Producer) PublishEvent(ctx context.Context, evt domain.SearchEvent) error {
-	data, _ := json.Marshal(evt)
-	return p.writer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(evt.Type),
-		Value: data,
-	})
+	key, data, err := events.Encode(evt)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, key, data)
 }
@@ -2,7 +2,10 @@ package userserviceclient
 
 import (
 	"context"
+	"fmt"
+	"main/internal/domain"
 	userpb "main/pkg/api_user_service"
+	"strings"
 
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -24,3 +27,59 @@ func GetUserInfo(client userpb.UserServiceClient, uuid string) (*userpb.UserResp
 	}
 	return resp, nil
 }
+
+// ResolveUserNames одним запросом ищет среди uuids владельцев имён userNames: имя в нижнем регистре → uuid
+func ResolveUserNames(client userpb.UserServiceClient, userNames, uuids []string) (map[string]string, error) {
+	byName := make(map[string]string, len(userNames))
+	if len(userNames) == 0 || len(uuids) == 0 {
+		return byName, nil
+	}
+	resp, err := client.ResolveUserNames(context.Background(), &userpb.ResolveUserNamesRequest{UserNames: userNames, Uuids: uuids})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range resp.Users {
+		byName[strings.ToLower(u.UserName)] = u.Uuid
+	}
+	return byName, nil
+}
+
+// BlockedBy возвращает тех из userIDs, кто заблокировал uuid
+func BlockedBy(client userpb.UserServiceClient, uuid string, userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	ctx := context.Background()
+	resp, err := client.CheckBlocked(ctx, &userpb.CheckBlockedRequest{Uuid: uuid, UserUuids: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	return resp.BlockedBy, nil
+}
+
+// CheckNotBlocked отклоняет действие uuid, если кто-то из userIDs его заблокировал.
+// Ошибка намеренно не говорит о блокировке
+func CheckNotBlocked(client userpb.UserServiceClient, uuid string, userIDs []string) error {
+	blockedBy, err := BlockedBy(client, uuid, userIDs)
+	if err != nil {
+		return fmt.Errorf("не удалось проверить блокировки: %w", err)
+	}
+	if len(blockedBy) > 0 {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// Devices возвращает устройства пользователей с ключами E2E-шифрования: uuid → device_id
+func Devices(client userpb.UserServiceClient, userIDs []string) (map[string][]string, error) {
+	ctx := context.Background()
+	resp, err := client.ListDevices(ctx, &userpb.ListDevicesRequest{Uuids: userIDs})
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить устройства: %w", err)
+	}
+	devices := make(map[string][]string, len(userIDs))
+	for _, d := range resp.Devices {
+		devices[d.Uuid] = append(devices[d.Uuid], d.DeviceId)
+	}
+	return devices, nil
+}
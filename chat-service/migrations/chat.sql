@@ -1,7 +1,153 @@
 // Индексы для коллекции messages
 db.messages.createIndex({ "id": 1 }, { unique: true });
 db.messages.createIndex({ "chat_id": 1, "created_at": -1 });
-db.messages.createIndex({ "chat_id": 1, "read_by.user_id": 1 });
+db.messages.createIndex({ "chat_id": 1, "seq": 1 }, { unique: true, partialFilterExpression: { "seq": { $gt: 0 } } });
+db.messages.createIndex({ "chat_id": 1, "deleted": 1, "seq": 1 });
 db.messages.createIndex({ "saved_by.user_id": 1, "created_at": -1 });
 db.messages.createIndex({ "author_id": 1, "created_at": -1 });
-db.messages.createIndex({ "deleted": 1 });
\ No newline at end of file
+db.messages.createIndex({ "deleted": 1 });
+db.messages.createIndex({ "expires_at": 1 }, { partialFilterExpression: { "expires_at": { $gt: 0 } } });
+db.messages.createIndex({ "deleted_at": 1 }, { partialFilterExpression: { "deleted_at": { $gt: 0 } } });
+// Проверка, держит ли ещё файл какое-нибудь сообщение чата, перед снятием отметки в media-service
+db.messages.createIndex({ "chat_id": 1, "media.id": 1 }, { partialFilterExpression: { "media.id": { $exists: true } } });
+
+// Индексы для коллекции chats
+db.chats.createIndex({ "id": 1 }, { unique: true });
+db.chats.createIndex({ "member_ids": 1, "last_message_at": -1, "id": -1 });
+db.chats.createIndex({ "handle": 1 }, { unique: true, partialFilterExpression: { "handle": { $type: "string" } } });
+
+// Индексы для коллекции chat_states
+db.chat_states.createIndex({ "chat_id": 1, "user_id": 1 }, { unique: true });
+db.chat_states.createIndex({ "user_id": 1, "pinned": 1 });
+
+// Индексы для коллекции channel_subscribers
+db.channel_subscribers.createIndex({ "chat_id": 1, "user_id": 1 }, { unique: true });
+db.channel_subscribers.createIndex({ "chat_id": 1, "subscribed_at": 1, "user_id": 1 });
+db.channel_subscribers.createIndex({ "user_id": 1 });
+
+// Индексы для коллекций invite_links и join_requests
+db.invite_links.createIndex({ "code": 1 }, { unique: true });
+db.invite_links.createIndex({ "chat_id": 1 });
+db.join_requests.createIndex({ "id": 1 }, { unique: true });
+db.join_requests.createIndex({ "chat_id": 1, "user_id": 1 }, { unique: true, partialFilterExpression: { "status": "pending" } });
+db.join_requests.createIndex({ "chat_id": 1, "status": 1, "created_at": 1, "id": 1 });
+
+// Индексы для коллекции message_revisions
+db.message_revisions.createIndex({ "message_id": 1, "revision": 1 }, { unique: true });
+
+// Индексы для коллекции poll_votes: один голос на пользователя, проголосовавшие — в порядке голосования
+db.poll_votes.createIndex({ "message_id": 1, "user_id": 1 }, { unique: true });
+db.poll_votes.createIndex({ "message_id": 1, "voted_at": 1, "user_id": 1 });
+
+// Индексы для коллекции mentions
+db.mentions.createIndex({ "user_id": 1, "chat_id": 1, "read": 1, "created_at": 1, "message_id": 1 });
+db.mentions.createIndex({ "user_id": 1, "read": 1, "created_at": 1, "message_id": 1 });
+db.mentions.createIndex({ "message_id": 1 });
+
+// Индексы для коллекции scheduled_messages
+db.scheduled_messages.createIndex({ "id": 1 }, { unique: true });
+db.scheduled_messages.createIndex({ "status": 1, "send_at": 1 });
+db.scheduled_messages.createIndex({ "status": 1, "locked_until": 1 });
+db.scheduled_messages.createIndex({ "chat_id": 1, "author_id": 1, "status": 1, "send_at": 1 });
+
+// Индексы для коллекции read_states
+db.read_states.createIndex({ "chat_id": 1, "user_id": 1 }, { unique: true });
+// Непрочитанные чаты пользователя для счётчиков папок (ListUnread); префикс user_id — для ListByUser
+db.read_states.createIndex({ "user_id": 1, "unread_count": 1 });
+
+// Индексы для коллекции drafts (уникальный индекс нужен для проверки версии в DraftRepo.Save)
+db.drafts.createIndex({ "chat_id": 1, "user_id": 1 }, { unique: true });
+db.drafts.createIndex({ "user_id": 1, "updated_at": -1 });
+
+// Индексы для коллекции chat_folders
+db.chat_folders.createIndex({ "id": 1 }, { unique: true });
+db.chat_folders.createIndex({ "user_id": 1, "position": 1 });
+
+// Индексы для журналов обновлений (user_update_seqs — счётчики, user_updates — записи)
+db.user_update_seqs.createIndex({ "user_id": 1 }, { unique: true });
+db.user_updates.createIndex({ "user_id": 1, "seq": 1 }, { unique: true });
+db.user_updates.createIndex({ "created_at": 1 });
+
+// Индексы для outbox: захват relay по статусу и сроку, удаление опубликованных
+db.outbox.createIndex({ "id": 1 }, { unique: true });
+db.outbox.createIndex({ "status": 1, "next_attempt_at": 1, "created_at": 1 });
+db.outbox.createIndex({ "status": 1, "sent_at": 1 });
+
+// Индекс для задачи очистки истории: уникальный id держит аренду в одном документе
+db.retention_jobs.createIndex({ "id": 1 }, { unique: true });
+
+// Индексы для очереди модерации (уникальный индекс держит жалобы на сообщение в одной ожидающей записи)
+db.moderation_queue.createIndex({ "id": 1 }, { unique: true });
+db.moderation_queue.createIndex({ "message_id": 1, "kind": 1 }, { unique: true, partialFilterExpression: { "status": "pending" } });
+db.moderation_queue.createIndex({ "chat_id": 1, "status": 1, "created_at": 1, "id": 1 });
+
+// Переход с read_by на read_states (однократно): нумеруем старые сообщения,
+// переносим последнее прочтение каждого участника и удаляем массивы read_by
+db.chats.find({}).forEach(function (chat) {
+  var seq = 0;
+  var lastRead = {};
+  db.messages.find({ chat_id: chat.id }).sort({ created_at: 1, id: 1 }).forEach(function (m) {
+    seq++;
+    db.messages.updateOne({ _id: m._id }, { $set: { seq: seq } });
+    (m.read_by || []).forEach(function (r) {
+      lastRead[r.user_id] = { seq: seq, at: r.read_at };
+    });
+  });
+  db.chats.updateOne({ _id: chat._id }, { $set: { last_seq: seq } });
+  Object.keys(lastRead).forEach(function (userId) {
+    var st = lastRead[userId];
+    db.read_states.updateOne(
+      { chat_id: chat.id, user_id: userId },
+      { $max: { last_read_seq: st.seq, last_read_at: st.at, last_delivered_seq: st.seq, last_delivered_at: st.at } },
+      { upsert: true }
+    );
+  });
+});
+db.messages.updateMany({}, { $unset: { read_by: "" } });
+db.messages.dropIndex({ "chat_id": 1, "read_by.user_id": 1 });
+
+// Заполнение last_message_at и превью для существующих чатов (однократно)
+db.chats.find({ last_message_at: { $exists: false } }).forEach(function (chat) {
+  var last = db.messages.find({ chat_id: chat.id, deleted: false }).sort({ seq: -1 }).limit(1).toArray()[0];
+  var set = { last_message_at: chat.created_at };
+  if (last) {
+    set.last_message_at = last.created_at;
+    set.last_message = {
+      id: last.id, seq: last.seq, author_id: last.author_id, type: last.type || "text",
+      text: (last.text || "").substring(0, 100), created_at: last.created_at, deleted: false
+    };
+  }
+  db.chats.updateOne({ _id: chat._id }, { $set: set });
+});
+
+// Перенос голосов из poll.voters в poll_votes (однократно); счётчики в сообщениях остаются
+db.messages.find({ "poll.voters.0": { $exists: true } }).forEach(function (m) {
+  m.poll.voters.forEach(function (v) {
+    db.poll_votes.updateOne(
+      { message_id: m.id, user_id: v.user_id },
+      { $setOnInsert: { option_ids: v.option_ids, voted_at: v.voted_at } },
+      { upsert: true }
+    );
+  });
+});
+db.messages.updateMany({ "poll.voters": { $exists: true } }, { $unset: { "poll.voters": "" } });
+
+// Версии черновиков (однократно): до них порядок правок задавали часы клиента
+db.drafts.updateMany({ version: { $exists: false } }, { $set: { version: 0 } });
+
+// Счётчики непрочитанного в read_states (однократно): указатели заводятся всем участникам и подписчикам,
+// которые ещё ничего не прочли, chat_last_seq и unread_count считаются по last_seq чата
+db.read_states.dropIndex({ "user_id": 1 });
+db.chats.find({}).forEach(function (chat) {
+  var users = (chat.member_ids || []).slice();
+  if (chat.kind === "channel") {
+    db.channel_subscribers.find({ chat_id: chat.id }).forEach(function (sub) { users.push(sub.user_id); });
+  }
+  var lastSeq = chat.last_seq || 0;
+  users.forEach(function (userId) {
+    db.read_states.updateOne({ chat_id: chat.id, user_id: userId }, [
+      { $set: { chat_last_seq: { $max: ["$chat_last_seq", lastSeq] } } },
+      { $set: { unread_count: { $max: [0, { $subtract: [{ $ifNull: ["$chat_last_seq", 0] }, { $ifNull: ["$last_read_seq", 0] }] }] } } }
+    ], { upsert: true });
+  });
+});
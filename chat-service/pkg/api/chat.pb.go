@@ -74,6 +74,60 @@ func (x *CreateDirectChatRequest) GetPeerId() string {
 	return ""
 }
 
+// Секретный личный чат: сообщения шифруются на устройствах, сервер хранит только шифротекст.
+// У собеседника должно быть хотя бы одно устройство с ключами в user-service.
+type CreateSecretChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PeerId        string                 `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSecretChatRequest) Reset() {
+	*x = CreateSecretChatRequest{}
+	mi := &file_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecretChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretChatRequest) ProtoMessage() {}
+
+func (x *CreateSecretChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretChatRequest.ProtoReflect.Descriptor instead.
+func (*CreateSecretChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateSecretChatRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSecretChatRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
 type CreateGroupChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -85,7 +139,7 @@ type CreateGroupChatRequest struct {
 
 func (x *CreateGroupChatRequest) Reset() {
 	*x = CreateGroupChatRequest{}
-	mi := &file_chat_proto_msgTypes[1]
+	mi := &file_chat_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -97,7 +151,7 @@ func (x *CreateGroupChatRequest) String() string {
 func (*CreateGroupChatRequest) ProtoMessage() {}
 
 func (x *CreateGroupChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[1]
+	mi := &file_chat_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -110,7 +164,7 @@ func (x *CreateGroupChatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupChatRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{1}
+	return file_chat_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateGroupChatRequest) GetUserId() string {
@@ -141,13 +195,14 @@ type UpdateGroupChatRequest struct {
 	AddMemberIds    []string               `protobuf:"bytes,3,rep,name=add_member_ids,json=addMemberIds,proto3" json:"add_member_ids,omitempty"`
 	RemoveMemberIds []string               `protobuf:"bytes,4,rep,name=remove_member_ids,json=removeMemberIds,proto3" json:"remove_member_ids,omitempty"`
 	RequesterId     string                 `protobuf:"bytes,5,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	MessageTtl      *int64                 `protobuf:"varint,6,opt,name=message_ttl,json=messageTtl,proto3,oneof" json:"message_ttl,omitempty"` // таймер автоудаления в секундах, 0 — выключить
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateGroupChatRequest) Reset() {
 	*x = UpdateGroupChatRequest{}
-	mi := &file_chat_proto_msgTypes[2]
+	mi := &file_chat_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -159,7 +214,7 @@ func (x *UpdateGroupChatRequest) String() string {
 func (*UpdateGroupChatRequest) ProtoMessage() {}
 
 func (x *UpdateGroupChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[2]
+	mi := &file_chat_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -172,7 +227,7 @@ func (x *UpdateGroupChatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateGroupChatRequest.ProtoReflect.Descriptor instead.
 func (*UpdateGroupChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{2}
+	return file_chat_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UpdateGroupChatRequest) GetChatId() string {
@@ -210,73 +265,38 @@ func (x *UpdateGroupChatRequest) GetRequesterId() string {
 	return ""
 }
 
-type GetChatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetChatRequest) Reset() {
-	*x = GetChatRequest{}
-	mi := &file_chat_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetChatRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetChatRequest) ProtoMessage() {}
-
-func (x *GetChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetChatRequest.ProtoReflect.Descriptor instead.
-func (*GetChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *GetChatRequest) GetChatId() string {
-	if x != nil {
-		return x.ChatId
+func (x *UpdateGroupChatRequest) GetMessageTtl() int64 {
+	if x != nil && x.MessageTtl != nil {
+		return *x.MessageTtl
 	}
-	return ""
+	return 0
 }
 
-type ListChatsRequest struct {
+// Таймер автоудаления для любого чата; в группе меняет только владелец.
+// ttl_seconds: 0 — выключить, иначе от 60 секунд до года.
+type SetMessageTTLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChatsRequest) Reset() {
-	*x = ListChatsRequest{}
+func (x *SetMessageTTLRequest) Reset() {
+	*x = SetMessageTTLRequest{}
 	mi := &file_chat_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChatsRequest) String() string {
+func (x *SetMessageTTLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChatsRequest) ProtoMessage() {}
+func (*SetMessageTTLRequest) ProtoMessage() {}
 
-func (x *ListChatsRequest) ProtoReflect() protoreflect.Message {
+func (x *SetMessageTTLRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -288,56 +308,57 @@ func (x *ListChatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChatsRequest.ProtoReflect.Descriptor instead.
-func (*ListChatsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetMessageTTLRequest.ProtoReflect.Descriptor instead.
+func (*SetMessageTTLRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListChatsRequest) GetUserId() string {
+func (x *SetMessageTTLRequest) GetChatId() string {
 	if x != nil {
-		return x.UserId
+		return x.ChatId
 	}
 	return ""
 }
 
-func (x *ListChatsRequest) GetLimit() int32 {
+func (x *SetMessageTTLRequest) GetRequesterId() string {
 	if x != nil {
-		return x.Limit
+		return x.RequesterId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListChatsRequest) GetCursor() string {
+func (x *SetMessageTTLRequest) GetTtlSeconds() int64 {
 	if x != nil {
-		return x.Cursor
+		return x.TtlSeconds
 	}
-	return ""
+	return 0
 }
 
-type SendMessageRequest struct {
+// Медленный режим группы; меняет только владелец. seconds: 0 — выключить, иначе до 3600.
+// Слишком частая отправка отклоняется с RESOURCE_EXHAUSTED и RetryInfo — через сколько можно писать снова
+type SetSlowModeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Seconds       int64                  `protobuf:"varint,3,opt,name=seconds,proto3" json:"seconds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendMessageRequest) Reset() {
-	*x = SendMessageRequest{}
+func (x *SetSlowModeRequest) Reset() {
+	*x = SetSlowModeRequest{}
 	mi := &file_chat_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageRequest) String() string {
+func (x *SetSlowModeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageRequest) ProtoMessage() {}
+func (*SetSlowModeRequest) ProtoMessage() {}
 
-func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+func (x *SetSlowModeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -349,63 +370,56 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetSlowModeRequest.ProtoReflect.Descriptor instead.
+func (*SetSlowModeRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SendMessageRequest) GetChatId() string {
+func (x *SetSlowModeRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetAuthorId() string {
-	if x != nil {
-		return x.AuthorId
-	}
-	return ""
-}
-
-func (x *SendMessageRequest) GetText() string {
+func (x *SetSlowModeRequest) GetRequesterId() string {
 	if x != nil {
-		return x.Text
+		return x.RequesterId
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetMedia() []*Media {
+func (x *SetSlowModeRequest) GetSeconds() int64 {
 	if x != nil {
-		return x.Media
+		return x.Seconds
 	}
-	return nil
+	return 0
 }
 
-type UpdateMessageRequest struct {
+// Жалоба на сообщение группы или канала; повторная жалоба того же пользователя не учитывается
+type ReportMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	ReporterId    string                 `protobuf:"bytes,2,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // до 500 символов
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMessageRequest) Reset() {
-	*x = UpdateMessageRequest{}
+func (x *ReportMessageRequest) Reset() {
+	*x = ReportMessageRequest{}
 	mi := &file_chat_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMessageRequest) String() string {
+func (x *ReportMessageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMessageRequest) ProtoMessage() {}
+func (*ReportMessageRequest) ProtoMessage() {}
 
-func (x *UpdateMessageRequest) ProtoReflect() protoreflect.Message {
+func (x *ReportMessageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -417,63 +431,57 @@ func (x *UpdateMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMessageRequest.ProtoReflect.Descriptor instead.
-func (*UpdateMessageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReportMessageRequest.ProtoReflect.Descriptor instead.
+func (*ReportMessageRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *UpdateMessageRequest) GetMessageId() string {
+func (x *ReportMessageRequest) GetMessageId() string {
 	if x != nil {
 		return x.MessageId
 	}
 	return ""
 }
 
-func (x *UpdateMessageRequest) GetAuthorId() string {
+func (x *ReportMessageRequest) GetReporterId() string {
 	if x != nil {
-		return x.AuthorId
+		return x.ReporterId
 	}
 	return ""
 }
 
-func (x *UpdateMessageRequest) GetText() string {
+func (x *ReportMessageRequest) GetReason() string {
 	if x != nil {
-		return x.Text
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *UpdateMessageRequest) GetMedia() []*Media {
-	if x != nil {
-		return x.Media
-	}
-	return nil
-}
-
-// изменено: теперь repeated string message_ids
-type DeleteMessageRequest struct {
+// Очередь модерации видят владелец группы и администраторы канала
+type ListModerationQueueRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageIds    []string               `protobuf:"bytes,1,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"` // список ID сообщений
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
 	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	HardDelete    bool                   `protobuf:"varint,3,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMessageRequest) Reset() {
-	*x = DeleteMessageRequest{}
+func (x *ListModerationQueueRequest) Reset() {
+	*x = ListModerationQueueRequest{}
 	mi := &file_chat_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMessageRequest) String() string {
+func (x *ListModerationQueueRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMessageRequest) ProtoMessage() {}
+func (*ListModerationQueueRequest) ProtoMessage() {}
 
-func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+func (x *ListModerationQueueRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -485,55 +493,64 @@ func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListModerationQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListModerationQueueRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeleteMessageRequest) GetMessageIds() []string {
+func (x *ListModerationQueueRequest) GetChatId() string {
 	if x != nil {
-		return x.MessageIds
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-func (x *DeleteMessageRequest) GetRequesterId() string {
+func (x *ListModerationQueueRequest) GetRequesterId() string {
 	if x != nil {
 		return x.RequesterId
 	}
 	return ""
 }
 
-func (x *DeleteMessageRequest) GetHardDelete() bool {
+func (x *ListModerationQueueRequest) GetLimit() int32 {
 	if x != nil {
-		return x.HardDelete
+		return x.Limit
 	}
-	return false
+	return 0
 }
 
-type ListMessagesRequest struct {
+func (x *ListModerationQueueRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// status: allowed — опубликовать задержанное или отклонить жалобы;
+// removed — отбросить задержанное или удалить обжалованное сообщение
+type ResolveModerationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMessagesRequest) Reset() {
-	*x = ListMessagesRequest{}
+func (x *ResolveModerationRequest) Reset() {
+	*x = ResolveModerationRequest{}
 	mi := &file_chat_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMessagesRequest) String() string {
+func (x *ResolveModerationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMessagesRequest) ProtoMessage() {}
+func (*ResolveModerationRequest) ProtoMessage() {}
 
-func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+func (x *ResolveModerationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -545,55 +562,53 @@ func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
-func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResolveModerationRequest.ProtoReflect.Descriptor instead.
+func (*ResolveModerationRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListMessagesRequest) GetChatId() string {
+func (x *ResolveModerationRequest) GetItemId() string {
 	if x != nil {
-		return x.ChatId
+		return x.ItemId
 	}
 	return ""
 }
 
-func (x *ListMessagesRequest) GetLimit() int32 {
+func (x *ResolveModerationRequest) GetRequesterId() string {
 	if x != nil {
-		return x.Limit
+		return x.RequesterId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListMessagesRequest) GetCursor() string {
+func (x *ResolveModerationRequest) GetStatus() string {
 	if x != nil {
-		return x.Cursor
+		return x.Status
 	}
 	return ""
 }
 
-type MarkReadRequest struct {
+type GetChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkReadRequest) Reset() {
-	*x = MarkReadRequest{}
+func (x *GetChatRequest) Reset() {
+	*x = GetChatRequest{}
 	mi := &file_chat_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkReadRequest) String() string {
+func (x *GetChatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkReadRequest) ProtoMessage() {}
+func (*GetChatRequest) ProtoMessage() {}
 
-func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+func (x *GetChatRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -605,55 +620,46 @@ func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
-func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetChatRequest.ProtoReflect.Descriptor instead.
+func (*GetChatRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *MarkReadRequest) GetChatId() string {
+func (x *GetChatRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-func (x *MarkReadRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
+// Чаты от недавно активных к давним; закреплённые — в начале первой страницы.
+// archived = true — только архив.
+// folder_id — только чаты папки (archived тогда не учитывается, закреплённые не поднимаются наверх)
+type ListChatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Archived      bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	FolderId      string                 `protobuf:"bytes,5,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkReadRequest) GetMessageId() string {
-	if x != nil {
-		return x.MessageId
-	}
-	return ""
-}
-
-type ToggleSavedRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	Saved         bool                   `protobuf:"varint,3,opt,name=saved,proto3" json:"saved,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ToggleSavedRequest) Reset() {
-	*x = ToggleSavedRequest{}
+func (x *ListChatsRequest) Reset() {
+	*x = ListChatsRequest{}
 	mi := &file_chat_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToggleSavedRequest) String() string {
+func (x *ListChatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleSavedRequest) ProtoMessage() {}
+func (*ListChatsRequest) ProtoMessage() {}
 
-func (x *ToggleSavedRequest) ProtoReflect() protoreflect.Message {
+func (x *ListChatsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -665,55 +671,69 @@ func (x *ToggleSavedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleSavedRequest.ProtoReflect.Descriptor instead.
-func (*ToggleSavedRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListChatsRequest.ProtoReflect.Descriptor instead.
+func (*ListChatsRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ToggleSavedRequest) GetUserId() string {
+func (x *ListChatsRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *ToggleSavedRequest) GetMessageId() string {
+func (x *ListChatsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.MessageId
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListChatsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
 	}
 	return ""
 }
 
-func (x *ToggleSavedRequest) GetSaved() bool {
+func (x *ListChatsRequest) GetArchived() bool {
 	if x != nil {
-		return x.Saved
+		return x.Archived
 	}
 	return false
 }
 
-type ListSavedRequest struct {
+func (x *ListChatsRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+type PinChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Pinned        bool                   `protobuf:"varint,3,opt,name=pinned,proto3" json:"pinned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSavedRequest) Reset() {
-	*x = ListSavedRequest{}
+func (x *PinChatRequest) Reset() {
+	*x = PinChatRequest{}
 	mi := &file_chat_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSavedRequest) String() string {
+func (x *PinChatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSavedRequest) ProtoMessage() {}
+func (*PinChatRequest) ProtoMessage() {}
 
-func (x *ListSavedRequest) ProtoReflect() protoreflect.Message {
+func (x *PinChatRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -725,55 +745,55 @@ func (x *ListSavedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSavedRequest.ProtoReflect.Descriptor instead.
-func (*ListSavedRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PinChatRequest.ProtoReflect.Descriptor instead.
+func (*PinChatRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ListSavedRequest) GetUserId() string {
+func (x *PinChatRequest) GetChatId() string {
 	if x != nil {
-		return x.UserId
+		return x.ChatId
 	}
 	return ""
 }
 
-func (x *ListSavedRequest) GetLimit() int32 {
+func (x *PinChatRequest) GetUserId() string {
 	if x != nil {
-		return x.Limit
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListSavedRequest) GetCursor() string {
+func (x *PinChatRequest) GetPinned() bool {
 	if x != nil {
-		return x.Cursor
+		return x.Pinned
 	}
-	return ""
+	return false
 }
 
-type ListReadMessagesRequest struct {
+type ArchiveChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Archived      bool                   `protobuf:"varint,3,opt,name=archived,proto3" json:"archived,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListReadMessagesRequest) Reset() {
-	*x = ListReadMessagesRequest{}
+func (x *ArchiveChatRequest) Reset() {
+	*x = ArchiveChatRequest{}
 	mi := &file_chat_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListReadMessagesRequest) String() string {
+func (x *ArchiveChatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListReadMessagesRequest) ProtoMessage() {}
+func (*ArchiveChatRequest) ProtoMessage() {}
 
-func (x *ListReadMessagesRequest) ProtoReflect() protoreflect.Message {
+func (x *ArchiveChatRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -785,54 +805,56 @@ func (x *ListReadMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListReadMessagesRequest.ProtoReflect.Descriptor instead.
-func (*ListReadMessagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArchiveChatRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveChatRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListReadMessagesRequest) GetUserId() string {
+func (x *ArchiveChatRequest) GetChatId() string {
 	if x != nil {
-		return x.UserId
+		return x.ChatId
 	}
 	return ""
 }
 
-func (x *ListReadMessagesRequest) GetChatId() string {
+func (x *ArchiveChatRequest) GetUserId() string {
 	if x != nil {
-		return x.ChatId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ListReadMessagesRequest) GetLimit() int32 {
+func (x *ArchiveChatRequest) GetArchived() bool {
 	if x != nil {
-		return x.Limit
+		return x.Archived
 	}
-	return 0
+	return false
 }
 
-// --- Ответы ---
-type ChatResponse struct {
+// muted_until — unix-время; 0 — включить звук
+type MuteChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MutedUntil    int64                  `protobuf:"varint,3,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatResponse) Reset() {
-	*x = ChatResponse{}
+func (x *MuteChatRequest) Reset() {
+	*x = MuteChatRequest{}
 	mi := &file_chat_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatResponse) String() string {
+func (x *MuteChatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatResponse) ProtoMessage() {}
+func (*MuteChatRequest) ProtoMessage() {}
 
-func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+func (x *MuteChatRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -844,40 +866,55 @@ func (x *ChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
-func (*ChatResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MuteChatRequest.ProtoReflect.Descriptor instead.
+func (*MuteChatRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ChatResponse) GetChat() *Chat {
+func (x *MuteChatRequest) GetChatId() string {
 	if x != nil {
-		return x.Chat
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-type ListChatsResponse struct {
+func (x *MuteChatRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MuteChatRequest) GetMutedUntil() int64 {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return 0
+}
+
+type MarkChatUnreadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
-	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Unread        bool                   `protobuf:"varint,3,opt,name=unread,proto3" json:"unread,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChatsResponse) Reset() {
-	*x = ListChatsResponse{}
+func (x *MarkChatUnreadRequest) Reset() {
+	*x = MarkChatUnreadRequest{}
 	mi := &file_chat_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChatsResponse) String() string {
+func (x *MarkChatUnreadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChatsResponse) ProtoMessage() {}
+func (*MarkChatUnreadRequest) ProtoMessage() {}
 
-func (x *ListChatsResponse) ProtoReflect() protoreflect.Message {
+func (x *MarkChatUnreadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -889,46 +926,59 @@ func (x *ListChatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChatsResponse.ProtoReflect.Descriptor instead.
-func (*ListChatsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MarkChatUnreadRequest.ProtoReflect.Descriptor instead.
+func (*MarkChatUnreadRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListChatsResponse) GetChats() []*Chat {
+func (x *MarkChatUnreadRequest) GetChatId() string {
 	if x != nil {
-		return x.Chats
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListChatsResponse) GetNextCursor() string {
+func (x *MarkChatUnreadRequest) GetUserId() string {
 	if x != nil {
-		return x.NextCursor
+		return x.UserId
 	}
 	return ""
 }
 
-type MessageResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MarkChatUnreadRequest) GetUnread() bool {
+	if x != nil {
+		return x.Unread
+	}
+	return false
 }
 
-func (x *MessageResponse) Reset() {
-	*x = MessageResponse{}
+// expires_at — unix-время окончания действия, usage_limit — число вступлений; 0 — без ограничений.
+// requires_approval — вступление через заявку, которую одобряет администратор (без usage_limit)
+type CreateInviteLinkRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChatId           string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId      string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	ExpiresAt        int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UsageLimit       int64                  `protobuf:"varint,4,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	RequiresApproval bool                   `protobuf:"varint,5,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateInviteLinkRequest) Reset() {
+	*x = CreateInviteLinkRequest{}
 	mi := &file_chat_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageResponse) String() string {
+func (x *CreateInviteLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageResponse) ProtoMessage() {}
+func (*CreateInviteLinkRequest) ProtoMessage() {}
 
-func (x *MessageResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateInviteLinkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -940,40 +990,68 @@ func (x *MessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageResponse.ProtoReflect.Descriptor instead.
-func (*MessageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateInviteLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteLinkRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *MessageResponse) GetMessage() *Message {
+func (x *CreateInviteLinkRequest) GetChatId() string {
 	if x != nil {
-		return x.Message
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-type DeleteMessageResponse struct {
+func (x *CreateInviteLinkRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *CreateInviteLinkRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CreateInviteLinkRequest) GetUsageLimit() int64 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *CreateInviteLinkRequest) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+type RevokeInviteLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMessageResponse) Reset() {
-	*x = DeleteMessageResponse{}
+func (x *RevokeInviteLinkRequest) Reset() {
+	*x = RevokeInviteLinkRequest{}
 	mi := &file_chat_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMessageResponse) String() string {
+func (x *RevokeInviteLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMessageResponse) ProtoMessage() {}
+func (*RevokeInviteLinkRequest) ProtoMessage() {}
 
-func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+func (x *RevokeInviteLinkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -985,47 +1063,47 @@ func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
-func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevokeInviteLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteLinkRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeleteMessageResponse) GetSuccess() bool {
+func (x *RevokeInviteLinkRequest) GetCode() string {
 	if x != nil {
-		return x.Success
+		return x.Code
 	}
-	return false
+	return ""
 }
 
-func (x *DeleteMessageResponse) GetMessage() string {
+func (x *RevokeInviteLinkRequest) GetRequesterId() string {
 	if x != nil {
-		return x.Message
+		return x.RequesterId
 	}
 	return ""
 }
 
-type ListMessagesResponse struct {
+type JoinByInviteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMessagesResponse) Reset() {
-	*x = ListMessagesResponse{}
+func (x *JoinByInviteRequest) Reset() {
+	*x = JoinByInviteRequest{}
 	mi := &file_chat_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMessagesResponse) String() string {
+func (x *JoinByInviteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMessagesResponse) ProtoMessage() {}
+func (*JoinByInviteRequest) ProtoMessage() {}
 
-func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+func (x *JoinByInviteRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1037,46 +1115,49 @@ func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
-func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinByInviteRequest.ProtoReflect.Descriptor instead.
+func (*JoinByInviteRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListMessagesResponse) GetMessages() []*Message {
+func (x *JoinByInviteRequest) GetCode() string {
 	if x != nil {
-		return x.Messages
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-func (x *ListMessagesResponse) GetNextCursor() string {
+func (x *JoinByInviteRequest) GetUserId() string {
 	if x != nil {
-		return x.NextCursor
+		return x.UserId
 	}
 	return ""
 }
 
-type MarkReadResponse struct {
+type ListJoinRequestsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkReadResponse) Reset() {
-	*x = MarkReadResponse{}
+func (x *ListJoinRequestsRequest) Reset() {
+	*x = ListJoinRequestsRequest{}
 	mi := &file_chat_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkReadResponse) String() string {
+func (x *ListJoinRequestsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkReadResponse) ProtoMessage() {}
+func (*ListJoinRequestsRequest) ProtoMessage() {}
 
-func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+func (x *ListJoinRequestsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1088,39 +1169,61 @@ func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
-func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListJoinRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *MarkReadResponse) GetSuccess() bool {
+func (x *ListJoinRequestsRequest) GetChatId() string {
 	if x != nil {
-		return x.Success
+		return x.ChatId
 	}
-	return false
-}
-
-type ToggleSavedResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *ToggleSavedResponse) Reset() {
-	*x = ToggleSavedResponse{}
-	mi := &file_chat_proto_msgTypes[19]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListJoinRequestsRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
 }
 
-func (x *ToggleSavedResponse) String() string {
+func (x *ListJoinRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListJoinRequestsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type DecideJoinRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideJoinRequestRequest) Reset() {
+	*x = DecideJoinRequestRequest{}
+	mi := &file_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideJoinRequestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleSavedResponse) ProtoMessage() {}
+func (*DecideJoinRequestRequest) ProtoMessage() {}
 
-func (x *ToggleSavedResponse) ProtoReflect() protoreflect.Message {
+func (x *DecideJoinRequestRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chat_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1132,41 +1235,6949 @@ func (x *ToggleSavedResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleSavedResponse.ProtoReflect.Descriptor instead.
-func (*ToggleSavedResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DecideJoinRequestRequest.ProtoReflect.Descriptor instead.
+func (*DecideJoinRequestRequest) Descriptor() ([]byte, []int) {
 	return file_chat_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ToggleSavedResponse) GetSuccess() bool {
+func (x *DecideJoinRequestRequest) GetRequestId() string {
 	if x != nil {
-		return x.Success
+		return x.RequestId
 	}
-	return false
+	return ""
 }
 
-type ListSavedResponse struct {
+func (x *DecideJoinRequestRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+// handle — публичное имя канала: 5–32 латинских букв, цифр или _, начиная с буквы
+type CreateChannelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Handle        string                 `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSavedResponse) Reset() {
-	*x = ListSavedResponse{}
+func (x *CreateChannelRequest) Reset() {
+	*x = CreateChannelRequest{}
 	mi := &file_chat_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSavedResponse) String() string {
+func (x *CreateChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChannelRequest) ProtoMessage() {}
+
+func (x *CreateChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChannelRequest.ProtoReflect.Descriptor instead.
+func (*CreateChannelRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateChannelRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateChannelRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateChannelRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type GetChannelByHandleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        string                 `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"` // можно с @
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChannelByHandleRequest) Reset() {
+	*x = GetChannelByHandleRequest{}
+	mi := &file_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChannelByHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChannelByHandleRequest) ProtoMessage() {}
+
+func (x *GetChannelByHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChannelByHandleRequest.ProtoReflect.Descriptor instead.
+func (*GetChannelByHandleRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetChannelByHandleRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type SubscribeChannelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeChannelRequest) Reset() {
+	*x = SubscribeChannelRequest{}
+	mi := &file_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeChannelRequest) ProtoMessage() {}
+
+func (x *SubscribeChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeChannelRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeChannelRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SubscribeChannelRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SubscribeChannelRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnsubscribeChannelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeChannelRequest) Reset() {
+	*x = UnsubscribeChannelRequest{}
+	mi := &file_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeChannelRequest) ProtoMessage() {}
+
+func (x *UnsubscribeChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeChannelRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeChannelRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UnsubscribeChannelRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *UnsubscribeChannelRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListChannelSubscribersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChannelSubscribersRequest) Reset() {
+	*x = ListChannelSubscribersRequest{}
+	mi := &file_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChannelSubscribersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChannelSubscribersRequest) ProtoMessage() {}
+
+func (x *ListChannelSubscribersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChannelSubscribersRequest.ProtoReflect.Descriptor instead.
+func (*ListChannelSubscribersRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListChannelSubscribersRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListChannelSubscribersRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *ListChannelSubscribersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListChannelSubscribersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Назначить (admin = true) или снять администратора — только владелец канала
+type SetChannelAdminRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Admin         bool                   `protobuf:"varint,4,opt,name=admin,proto3" json:"admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChannelAdminRequest) Reset() {
+	*x = SetChannelAdminRequest{}
+	mi := &file_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChannelAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChannelAdminRequest) ProtoMessage() {}
+
+func (x *SetChannelAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChannelAdminRequest.ProtoReflect.Descriptor instead.
+func (*SetChannelAdminRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetChannelAdminRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SetChannelAdminRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *SetChannelAdminRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetChannelAdminRequest) GetAdmin() bool {
+	if x != nil {
+		return x.Admin
+	}
+	return false
+}
+
+// Просмотренные посты канала; не больше 100 за раз
+type ViewMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageIds    []string               `protobuf:"bytes,3,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewMessagesRequest) Reset() {
+	*x = ViewMessagesRequest{}
+	mi := &file_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewMessagesRequest) ProtoMessage() {}
+
+func (x *ViewMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ViewMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ViewMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ViewMessagesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ViewMessagesRequest) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+type SendMessageRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ChatId   string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	AuthorId string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text     string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Media    []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"` // достаточно id файла из media-service: остальные поля берутся оттуда
+	Poll     *PollInput             `protobuf:"bytes,5,opt,name=poll,proto3" json:"poll,omitempty"`   // если задан, сообщение становится опросом, text игнорируется
+	// Только для секретного чата (text, media и poll пусты): устройство автора и по конверту
+	// на каждое устройство участников, кроме sender_device_id
+	SenderDeviceId string      `protobuf:"bytes,6,opt,name=sender_device_id,json=senderDeviceId,proto3" json:"sender_device_id,omitempty"`
+	Envelopes      []*Envelope `protobuf:"bytes,7,rep,name=envelopes,proto3" json:"envelopes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SendMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetPoll() *PollInput {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetSenderDeviceId() string {
+	if x != nil {
+		return x.SenderDeviceId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetEnvelopes() []*Envelope {
+	if x != nil {
+		return x.Envelopes
+	}
+	return nil
+}
+
+// Шифротекст сообщения для одного устройства; сервер его не читает
+type Envelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Ciphertext    []byte                 `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Envelope) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Envelope) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Envelope) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+// closes_at — unix-время автоматического закрытия, 0 — без ограничения
+type PollInput struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Question       string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []string               `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	MultipleChoice bool                   `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	Anonymous      bool                   `protobuf:"varint,4,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	ClosesAt       int64                  `protobuf:"varint,5,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PollInput) Reset() {
+	*x = PollInput{}
+	mi := &file_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollInput) ProtoMessage() {}
+
+func (x *PollInput) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollInput.ProtoReflect.Descriptor instead.
+func (*PollInput) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PollInput) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *PollInput) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PollInput) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *PollInput) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+func (x *PollInput) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+type UpdateMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMessageRequest) Reset() {
+	*x = UpdateMessageRequest{}
+	mi := &file_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMessageRequest) ProtoMessage() {}
+
+func (x *UpdateMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMessageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *UpdateMessageRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *UpdateMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UpdateMessageRequest) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type ListMessageRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessageRevisionsRequest) Reset() {
+	*x = ListMessageRevisionsRequest{}
+	mi := &file_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessageRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessageRevisionsRequest) ProtoMessage() {}
+
+func (x *ListMessageRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessageRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMessageRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListMessageRevisionsRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ListMessageRevisionsRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+// send_at — unix-время отправки, не раньше текущего момента
+type ScheduleMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	SendAt        int64                  `protobuf:"varint,5,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleMessageRequest) Reset() {
+	*x = ScheduleMessageRequest{}
+	mi := &file_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMessageRequest) ProtoMessage() {}
+
+func (x *ScheduleMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMessageRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ScheduleMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *ScheduleMessageRequest) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+type ListScheduledMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesRequest) Reset() {
+	*x = ListScheduledMessagesRequest{}
+	mi := &file_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesRequest) ProtoMessage() {}
+
+func (x *ListScheduledMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListScheduledMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListScheduledMessagesRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+// Пустые text, media и нулевой send_at оставляют поле без изменений
+type UpdateScheduledMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	SendAt        int64                  `protobuf:"varint,5,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduledMessageRequest) Reset() {
+	*x = UpdateScheduledMessageRequest{}
+	mi := &file_chat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduledMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduledMessageRequest) ProtoMessage() {}
+
+func (x *UpdateScheduledMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduledMessageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduledMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateScheduledMessageRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *UpdateScheduledMessageRequest) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+type CancelScheduledMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageRequest) Reset() {
+	*x = CancelScheduledMessageRequest{}
+	mi := &file_chat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageRequest) ProtoMessage() {}
+
+func (x *CancelScheduledMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CancelScheduledMessageRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelScheduledMessageRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+// option_ids — номера вариантов; в опросе с одним ответом ровно один
+type VoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteRequest) Reset() {
+	*x = VoteRequest{}
+	mi := &file_chat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteRequest) ProtoMessage() {}
+
+func (x *VoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteRequest.ProtoReflect.Descriptor instead.
+func (*VoteRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VoteRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *VoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VoteRequest) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+type RetractVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractVoteRequest) Reset() {
+	*x = RetractVoteRequest{}
+	mi := &file_chat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractVoteRequest) ProtoMessage() {}
+
+func (x *RetractVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractVoteRequest.ProtoReflect.Descriptor instead.
+func (*RetractVoteRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RetractVoteRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RetractVoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ClosePollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosePollRequest) Reset() {
+	*x = ClosePollRequest{}
+	mi := &file_chat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollRequest) ProtoMessage() {}
+
+func (x *ClosePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollRequest.ProtoReflect.Descriptor instead.
+func (*ClosePollRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ClosePollRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ClosePollRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+type GetPollResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPollResultsRequest) Reset() {
+	*x = GetPollResultsRequest{}
+	mi := &file_chat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollResultsRequest) ProtoMessage() {}
+
+func (x *GetPollResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetPollResultsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetPollResultsRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *GetPollResultsRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+// изменено: теперь repeated string message_ids
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageIds    []string               `protobuf:"bytes,1,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"` // список ID сообщений
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	HardDelete    bool                   `protobuf:"varint,3,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_chat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteMessageRequest) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *DeleteMessageRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.HardDelete
+	}
+	return false
+}
+
+// Якоря — номера seq, задаётся не более одного. Без якоря — последние сообщения.
+// around_seq возвращает сообщения вокруг якоря вместе с ним (переход к результату поиска).
+type ListMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor предыдущего ответа, равносильно after_seq
+	BeforeSeq     int64                  `protobuf:"varint,4,opt,name=before_seq,json=beforeSeq,proto3" json:"before_seq,omitempty"`
+	AfterSeq      int64                  `protobuf:"varint,5,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	AroundSeq     int64                  `protobuf:"varint,6,opt,name=around_seq,json=aroundSeq,proto3" json:"around_seq,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // prev_cursor предыдущего ответа, равносильно before_seq
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_chat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetBeforeSeq() int64 {
+	if x != nil {
+		return x.BeforeSeq
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetAroundSeq() int64 {
+	if x != nil {
+		return x.AroundSeq
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_chat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MarkReadRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type MarkDeliveredRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkDeliveredRequest) Reset() {
+	*x = MarkDeliveredRequest{}
+	mi := &file_chat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkDeliveredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkDeliveredRequest) ProtoMessage() {}
+
+func (x *MarkDeliveredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkDeliveredRequest.ProtoReflect.Descriptor instead.
+func (*MarkDeliveredRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MarkDeliveredRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MarkDeliveredRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkDeliveredRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type GetUnreadCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountRequest) Reset() {
+	*x = GetUnreadCountRequest{}
+	mi := &file_chat_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountRequest) ProtoMessage() {}
+
+func (x *GetUnreadCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetUnreadCountRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetUnreadCountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetReadStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadStateRequest) Reset() {
+	*x = GetReadStateRequest{}
+	mi := &file_chat_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadStateRequest) ProtoMessage() {}
+
+func (x *GetReadStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadStateRequest.ProtoReflect.Descriptor instead.
+func (*GetReadStateRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetReadStateRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetReadStateRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *GetReadStateRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+// Непрочитанные упоминания; пустой chat_id — во всех чатах.
+// Для перехода к следующему упоминанию: limit = 1 и next_cursor из предыдущего ответа.
+type ListMentionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMentionsRequest) Reset() {
+	*x = ListMentionsRequest{}
+	mi := &file_chat_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMentionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMentionsRequest) ProtoMessage() {}
+
+func (x *ListMentionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMentionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMentionsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListMentionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMentionsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListMentionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMentionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ToggleSavedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Saved         bool                   `protobuf:"varint,3,opt,name=saved,proto3" json:"saved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleSavedRequest) Reset() {
+	*x = ToggleSavedRequest{}
+	mi := &file_chat_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleSavedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleSavedRequest) ProtoMessage() {}
+
+func (x *ToggleSavedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleSavedRequest.ProtoReflect.Descriptor instead.
+func (*ToggleSavedRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ToggleSavedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ToggleSavedRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ToggleSavedRequest) GetSaved() bool {
+	if x != nil {
+		return x.Saved
+	}
+	return false
+}
+
+type ListSavedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedRequest) Reset() {
+	*x = ListSavedRequest{}
+	mi := &file_chat_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedRequest) ProtoMessage() {}
+
+func (x *ListSavedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListSavedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSavedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSavedRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListReadMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReadMessagesRequest) Reset() {
+	*x = ListReadMessagesRequest{}
+	mi := &file_chat_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReadMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReadMessagesRequest) ProtoMessage() {}
+
+func (x *ListReadMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReadMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListReadMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListReadMessagesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReadMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListReadMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_chat_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PinMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+type UnpinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageRequest) Reset() {
+	*x = UnpinMessageRequest{}
+	mi := &file_chat_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageRequest) ProtoMessage() {}
+
+func (x *UnpinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnpinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UnpinMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *UnpinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *UnpinMessageRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+type ListPinnedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedRequest) Reset() {
+	*x = ListPinnedRequest{}
+	mi := &file_chat_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedRequest) ProtoMessage() {}
+
+func (x *ListPinnedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListPinnedRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListPinnedRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+// action: typing | recording_voice | uploading | cancel
+type SetTypingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTypingRequest) Reset() {
+	*x = SetTypingRequest{}
+	mi := &file_chat_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTypingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTypingRequest) ProtoMessage() {}
+
+func (x *SetTypingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTypingRequest.ProtoReflect.Descriptor instead.
+func (*SetTypingRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SetTypingRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SetTypingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetTypingRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type ListTypingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypingRequest) Reset() {
+	*x = ListTypingRequest{}
+	mi := &file_chat_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypingRequest) ProtoMessage() {}
+
+func (x *ListTypingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypingRequest.ProtoReflect.Descriptor instead.
+func (*ListTypingRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListTypingRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ListTypingRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+// base_version — версия черновика, которую правил клиент (0 — черновика не было): правка принимается,
+// только если это последняя версия. device_id возвращается в обновлении draft.updated, чтобы устройство узнало свою правку.
+type SaveDraftRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChatId           string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId           string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text             string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	ReplyToMessageId string                 `protobuf:"bytes,4,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"`
+	Media            []*Media               `protobuf:"bytes,5,rep,name=media,proto3" json:"media,omitempty"`
+	DeviceId         string                 `protobuf:"bytes,7,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	BaseVersion      int64                  `protobuf:"varint,8,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SaveDraftRequest) Reset() {
+	*x = SaveDraftRequest{}
+	mi := &file_chat_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDraftRequest) ProtoMessage() {}
+
+func (x *SaveDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDraftRequest.ProtoReflect.Descriptor instead.
+func (*SaveDraftRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SaveDraftRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetReplyToMessageId() string {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *SaveDraftRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetBaseVersion() int64 {
+	if x != nil {
+		return x.BaseVersion
+	}
+	return 0
+}
+
+// chat_id пустой — черновики во всех чатах
+type GetDraftsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDraftsRequest) Reset() {
+	*x = GetDraftsRequest{}
+	mi := &file_chat_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDraftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDraftsRequest) ProtoMessage() {}
+
+func (x *GetDraftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDraftsRequest.ProtoReflect.Descriptor instead.
+func (*GetDraftsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetDraftsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetDraftsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type ClearDraftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	BaseVersion   int64                  `protobuf:"varint,5,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDraftRequest) Reset() {
+	*x = ClearDraftRequest{}
+	mi := &file_chat_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDraftRequest) ProtoMessage() {}
+
+func (x *ClearDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDraftRequest.ProtoReflect.Descriptor instead.
+func (*ClearDraftRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ClearDraftRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ClearDraftRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClearDraftRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ClearDraftRequest) GetBaseVersion() int64 {
+	if x != nil {
+		return x.BaseVersion
+	}
+	return 0
+}
+
+// Для UpdateFolder заполняется folder.id; position задаётся только через ReorderFolders
+type FolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Folder        *ChatFolder            `protobuf:"bytes,2,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FolderRequest) Reset() {
+	*x = FolderRequest{}
+	mi := &file_chat_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderRequest) ProtoMessage() {}
+
+func (x *FolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderRequest.ProtoReflect.Descriptor instead.
+func (*FolderRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *FolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FolderRequest) GetFolder() *ChatFolder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type DeleteFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId      string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderRequest) Reset() {
+	*x = DeleteFolderRequest{}
+	mi := &file_chat_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderRequest) ProtoMessage() {}
+
+func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFolderRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+// folder_ids — все папки пользователя в новом порядке
+type ReorderFoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderIds     []string               `protobuf:"bytes,2,rep,name=folder_ids,json=folderIds,proto3" json:"folder_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderFoldersRequest) Reset() {
+	*x = ReorderFoldersRequest{}
+	mi := &file_chat_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderFoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderFoldersRequest) ProtoMessage() {}
+
+func (x *ReorderFoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderFoldersRequest.ProtoReflect.Descriptor instead.
+func (*ReorderFoldersRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ReorderFoldersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReorderFoldersRequest) GetFolderIds() []string {
+	if x != nil {
+		return x.FolderIds
+	}
+	return nil
+}
+
+type ListFoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFoldersRequest) Reset() {
+	*x = ListFoldersRequest{}
+	mi := &file_chat_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFoldersRequest) ProtoMessage() {}
+
+func (x *ListFoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFoldersRequest.ProtoReflect.Descriptor instead.
+func (*ListFoldersRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListFoldersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// since_seq — последний обработанный seq журнала (0 — с начала); limit по умолчанию 100, не больше 1000
+type GetUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SinceSeq      int64                  `protobuf:"varint,2,opt,name=since_seq,json=sinceSeq,proto3" json:"since_seq,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUpdatesRequest) Reset() {
+	*x = GetUpdatesRequest{}
+	mi := &file_chat_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUpdatesRequest) ProtoMessage() {}
+
+func (x *GetUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*GetUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetUpdatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUpdatesRequest) GetSinceSeq() int64 {
+	if x != nil {
+		return x.SinceSeq
+	}
+	return 0
+}
+
+func (x *GetUpdatesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Поток обновлений всех чатов пользователя (те же записи, что в GetUpdates).
+// resume_token — из последнего полученного события; пустой — только новые обновления
+type SubscribeChatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ResumeToken   string                 `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeChatsRequest) Reset() {
+	*x = SubscribeChatsRequest{}
+	mi := &file_chat_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeChatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeChatsRequest) ProtoMessage() {}
+
+func (x *SubscribeChatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeChatsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeChatsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SubscribeChatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubscribeChatsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+// format — json (архив для ImportChat, по умолчанию) или html (страница для чтения)
+type ExportChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChatRequest) Reset() {
+	*x = ExportChatRequest{}
+	mi := &file_chat_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChatRequest) ProtoMessage() {}
+
+func (x *ExportChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChatRequest.ProtoReflect.Descriptor instead.
+func (*ExportChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ExportChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ExportChatRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *ExportChatRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// Архив передаётся частями: requester_id и title — в первом сообщении потока, data — во всех.
+// Пустой title — название из архива
+type ImportChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   string                 `protobuf:"bytes,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportChatRequest) Reset() {
+	*x = ImportChatRequest{}
+	mi := &file_chat_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportChatRequest) ProtoMessage() {}
+
+func (x *ImportChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportChatRequest.ProtoReflect.Descriptor instead.
+func (*ImportChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ImportChatRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *ImportChatRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ImportChatRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// --- Ответы ---
+type ChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_chat_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type ListChatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChatsResponse) Reset() {
+	*x = ListChatsResponse{}
+	mi := &file_chat_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChatsResponse) ProtoMessage() {}
+
+func (x *ListChatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChatsResponse.ProtoReflect.Descriptor instead.
+func (*ListChatsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListChatsResponse) GetChats() []*Chat {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+func (x *ListChatsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ChatStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *ChatState             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatStateResponse) Reset() {
+	*x = ChatStateResponse{}
+	mi := &file_chat_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatStateResponse) ProtoMessage() {}
+
+func (x *ChatStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatStateResponse.ProtoReflect.Descriptor instead.
+func (*ChatStateResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ChatStateResponse) GetState() *ChatState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type MessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageResponse) Reset() {
+	*x = MessageResponse{}
+	mi := &file_chat_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageResponse) ProtoMessage() {}
+
+func (x *MessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageResponse.ProtoReflect.Descriptor instead.
+func (*MessageResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *MessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type ListMessageRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*MessageRevision     `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessageRevisionsResponse) Reset() {
+	*x = ListMessageRevisionsResponse{}
+	mi := &file_chat_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessageRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessageRevisionsResponse) ProtoMessage() {}
+
+func (x *ListMessageRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessageRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMessageRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListMessageRevisionsResponse) GetRevisions() []*MessageRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type ScheduledMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scheduled     *ScheduledMessage      `protobuf:"bytes,1,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledMessageResponse) Reset() {
+	*x = ScheduledMessageResponse{}
+	mi := &file_chat_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessageResponse) ProtoMessage() {}
+
+func (x *ScheduledMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessageResponse.ProtoReflect.Descriptor instead.
+func (*ScheduledMessageResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ScheduledMessageResponse) GetScheduled() *ScheduledMessage {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type ListScheduledMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scheduled     []*ScheduledMessage    `protobuf:"bytes,1,rep,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesResponse) Reset() {
+	*x = ListScheduledMessagesResponse{}
+	mi := &file_chat_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesResponse) ProtoMessage() {}
+
+func (x *ListScheduledMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListScheduledMessagesResponse) GetScheduled() []*ScheduledMessage {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type PollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *Poll                  `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	MyOptionIds   []int32                `protobuf:"varint,2,rep,packed,name=my_option_ids,json=myOptionIds,proto3" json:"my_option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollResponse) Reset() {
+	*x = PollResponse{}
+	mi := &file_chat_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollResponse) ProtoMessage() {}
+
+func (x *PollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollResponse.ProtoReflect.Descriptor instead.
+func (*PollResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *PollResponse) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *PollResponse) GetMyOptionIds() []int32 {
+	if x != nil {
+		return x.MyOptionIds
+	}
+	return nil
+}
+
+// voters заполняется только для публичных опросов
+type GetPollResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *Poll                  `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	MyOptionIds   []int32                `protobuf:"varint,2,rep,packed,name=my_option_ids,json=myOptionIds,proto3" json:"my_option_ids,omitempty"`
+	Voters        []*PollOptionVoters    `protobuf:"bytes,3,rep,name=voters,proto3" json:"voters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPollResultsResponse) Reset() {
+	*x = GetPollResultsResponse{}
+	mi := &file_chat_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollResultsResponse) ProtoMessage() {}
+
+func (x *GetPollResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollResultsResponse.ProtoReflect.Descriptor instead.
+func (*GetPollResultsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetPollResultsResponse) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *GetPollResultsResponse) GetMyOptionIds() []int32 {
+	if x != nil {
+		return x.MyOptionIds
+	}
+	return nil
+}
+
+func (x *GetPollResultsResponse) GetVoters() []*PollOptionVoters {
+	if x != nil {
+		return x.Voters
+	}
+	return nil
+}
+
+type InviteLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InviteLink    *InviteLink            `protobuf:"bytes,1,opt,name=invite_link,json=inviteLink,proto3" json:"invite_link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteLinkResponse) Reset() {
+	*x = InviteLinkResponse{}
+	mi := &file_chat_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteLinkResponse) ProtoMessage() {}
+
+func (x *InviteLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteLinkResponse.ProtoReflect.Descriptor instead.
+func (*InviteLinkResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *InviteLinkResponse) GetInviteLink() *InviteLink {
+	if x != nil {
+		return x.InviteLink
+	}
+	return nil
+}
+
+type RevokeInviteLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteLinkResponse) Reset() {
+	*x = RevokeInviteLinkResponse{}
+	mi := &file_chat_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteLinkResponse) ProtoMessage() {}
+
+func (x *RevokeInviteLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInviteLinkResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RevokeInviteLinkResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Задано одно из полей: chat — пользователь вступил, join_request — создана заявка
+type JoinByInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	JoinRequest   *JoinRequest           `protobuf:"bytes,2,opt,name=join_request,json=joinRequest,proto3" json:"join_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinByInviteResponse) Reset() {
+	*x = JoinByInviteResponse{}
+	mi := &file_chat_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinByInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinByInviteResponse) ProtoMessage() {}
+
+func (x *JoinByInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinByInviteResponse.ProtoReflect.Descriptor instead.
+func (*JoinByInviteResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *JoinByInviteResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *JoinByInviteResponse) GetJoinRequest() *JoinRequest {
+	if x != nil {
+		return x.JoinRequest
+	}
+	return nil
+}
+
+type ReportMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportMessageResponse) Reset() {
+	*x = ReportMessageResponse{}
+	mi := &file_chat_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportMessageResponse) ProtoMessage() {}
+
+func (x *ReportMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportMessageResponse.ProtoReflect.Descriptor instead.
+func (*ReportMessageResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{78}
+}
+
+type ListModerationQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ModerationItem      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationQueueResponse) Reset() {
+	*x = ListModerationQueueResponse{}
+	mi := &file_chat_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationQueueResponse) ProtoMessage() {}
+
+func (x *ListModerationQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListModerationQueueResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListModerationQueueResponse) GetItems() []*ModerationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListModerationQueueResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ModerationItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *ModerationItem        `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationItemResponse) Reset() {
+	*x = ModerationItemResponse{}
+	mi := &file_chat_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationItemResponse) ProtoMessage() {}
+
+func (x *ModerationItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationItemResponse.ProtoReflect.Descriptor instead.
+func (*ModerationItemResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ModerationItemResponse) GetItem() *ModerationItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type ListJoinRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*JoinRequest         `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJoinRequestsResponse) Reset() {
+	*x = ListJoinRequestsResponse{}
+	mi := &file_chat_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinRequestsResponse) ProtoMessage() {}
+
+func (x *ListJoinRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ListJoinRequestsResponse) GetRequests() []*JoinRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *ListJoinRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type RejectJoinRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectJoinRequestResponse) Reset() {
+	*x = RejectJoinRequestResponse{}
+	mi := &file_chat_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectJoinRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectJoinRequestResponse) ProtoMessage() {}
+
+func (x *RejectJoinRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectJoinRequestResponse.ProtoReflect.Descriptor instead.
+func (*RejectJoinRequestResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *RejectJoinRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UnsubscribeChannelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeChannelResponse) Reset() {
+	*x = UnsubscribeChannelResponse{}
+	mi := &file_chat_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeChannelResponse) ProtoMessage() {}
+
+func (x *UnsubscribeChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeChannelResponse.ProtoReflect.Descriptor instead.
+func (*UnsubscribeChannelResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *UnsubscribeChannelResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListChannelSubscribersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscribers   []*ChannelSubscriber   `protobuf:"bytes,1,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChannelSubscribersResponse) Reset() {
+	*x = ListChannelSubscribersResponse{}
+	mi := &file_chat_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChannelSubscribersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChannelSubscribersResponse) ProtoMessage() {}
+
+func (x *ListChannelSubscribersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChannelSubscribersResponse.ProtoReflect.Descriptor instead.
+func (*ListChannelSubscribersResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ListChannelSubscribersResponse) GetSubscribers() []*ChannelSubscriber {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+func (x *ListChannelSubscribersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ViewMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewMessagesResponse) Reset() {
+	*x = ViewMessagesResponse{}
+	mi := &file_chat_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewMessagesResponse) ProtoMessage() {}
+
+func (x *ViewMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ViewMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ViewMessagesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CancelScheduledMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageResponse) Reset() {
+	*x = CancelScheduledMessageResponse{}
+	mi := &file_chat_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageResponse) ProtoMessage() {}
+
+func (x *CancelScheduledMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *CancelScheduledMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DeleteMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageResponse) Reset() {
+	*x = DeleteMessageResponse{}
+	mi := &file_chat_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageResponse) ProtoMessage() {}
+
+func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *DeleteMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteMessageResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// messages всегда по возрастанию seq
+// next_cursor — seq последнего сообщения страницы, пустой, если новее ничего нет;
+// prev_cursor — seq первого, пустой, если старее ничего нет
+type ListMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasBefore     bool                   `protobuf:"varint,3,opt,name=has_before,json=hasBefore,proto3" json:"has_before,omitempty"`
+	HasAfter      bool                   `protobuf:"varint,4,opt,name=has_after,json=hasAfter,proto3" json:"has_after,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,5,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
+	mi := &file_chat_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesResponse) ProtoMessage() {}
+
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListMessagesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListMessagesResponse) GetHasBefore() bool {
+	if x != nil {
+		return x.HasBefore
+	}
+	return false
+}
+
+func (x *ListMessagesResponse) GetHasAfter() bool {
+	if x != nil {
+		return x.HasAfter
+	}
+	return false
+}
+
+func (x *ListMessagesResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_chat_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *MarkReadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type MarkDeliveredResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkDeliveredResponse) Reset() {
+	*x = MarkDeliveredResponse{}
+	mi := &file_chat_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkDeliveredResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkDeliveredResponse) ProtoMessage() {}
+
+func (x *MarkDeliveredResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkDeliveredResponse.ProtoReflect.Descriptor instead.
+func (*MarkDeliveredResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *MarkDeliveredResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetUnreadCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UnreadCount   int64                  `protobuf:"varint,1,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountResponse) Reset() {
+	*x = GetUnreadCountResponse{}
+	mi := &file_chat_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountResponse) ProtoMessage() {}
+
+func (x *GetUnreadCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetUnreadCountResponse) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+// read — прочитали сообщение; delivered — получили, но ещё не прочитали
+type GetReadStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Read          []*ReadState           `protobuf:"bytes,1,rep,name=read,proto3" json:"read,omitempty"`
+	Delivered     []*ReadState           `protobuf:"bytes,2,rep,name=delivered,proto3" json:"delivered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadStateResponse) Reset() {
+	*x = GetReadStateResponse{}
+	mi := &file_chat_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadStateResponse) ProtoMessage() {}
+
+func (x *GetReadStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadStateResponse.ProtoReflect.Descriptor instead.
+func (*GetReadStateResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetReadStateResponse) GetRead() []*ReadState {
+	if x != nil {
+		return x.Read
+	}
+	return nil
+}
+
+func (x *GetReadStateResponse) GetDelivered() []*ReadState {
+	if x != nil {
+		return x.Delivered
+	}
+	return nil
+}
+
+type ListMentionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mentions      []*Mention             `protobuf:"bytes,1,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	UnreadCount   int64                  `protobuf:"varint,3,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMentionsResponse) Reset() {
+	*x = ListMentionsResponse{}
+	mi := &file_chat_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMentionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMentionsResponse) ProtoMessage() {}
+
+func (x *ListMentionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMentionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMentionsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ListMentionsResponse) GetMentions() []*Mention {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *ListMentionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListMentionsResponse) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type ToggleSavedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleSavedResponse) Reset() {
+	*x = ToggleSavedResponse{}
+	mi := &file_chat_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleSavedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleSavedResponse) ProtoMessage() {}
+
+func (x *ToggleSavedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleSavedResponse.ProtoReflect.Descriptor instead.
+func (*ToggleSavedResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ToggleSavedResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListSavedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedResponse) Reset() {
+	*x = ListSavedResponse{}
+	mi := &file_chat_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedResponse) ProtoMessage() {}
+
+func (x *ListSavedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ListSavedResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListSavedResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ListReadMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReadMessagesResponse) Reset() {
+	*x = ListReadMessagesResponse{}
+	mi := &file_chat_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReadMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReadMessagesResponse) ProtoMessage() {}
+
+func (x *ListReadMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReadMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListReadMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ListReadMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type ListPinnedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pinned        []*PinnedMessage       `protobuf:"bytes,1,rep,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedResponse) Reset() {
+	*x = ListPinnedResponse{}
+	mi := &file_chat_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedResponse) ProtoMessage() {}
+
+func (x *ListPinnedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *ListPinnedResponse) GetPinned() []*PinnedMessage {
+	if x != nil {
+		return x.Pinned
+	}
+	return nil
+}
+
+type SetTypingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Throttled     bool                   `protobuf:"varint,1,opt,name=throttled,proto3" json:"throttled,omitempty"` // обновление отброшено: предыдущее ещё актуально
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTypingResponse) Reset() {
+	*x = SetTypingResponse{}
+	mi := &file_chat_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTypingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTypingResponse) ProtoMessage() {}
+
+func (x *SetTypingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTypingResponse.ProtoReflect.Descriptor instead.
+func (*SetTypingResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *SetTypingResponse) GetThrottled() bool {
+	if x != nil {
+		return x.Throttled
+	}
+	return false
+}
+
+type ListTypingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []*TypingStatus        `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypingResponse) Reset() {
+	*x = ListTypingResponse{}
+	mi := &file_chat_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypingResponse) ProtoMessage() {}
+
+func (x *ListTypingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypingResponse.ProtoReflect.Descriptor instead.
+func (*ListTypingResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ListTypingResponse) GetStatuses() []*TypingStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+// applied = false — черновик уже правили с другого устройства, в draft — действующая версия
+type DraftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Draft         *Draft                 `protobuf:"bytes,1,opt,name=draft,proto3" json:"draft,omitempty"`
+	Applied       bool                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DraftResponse) Reset() {
+	*x = DraftResponse{}
+	mi := &file_chat_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DraftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DraftResponse) ProtoMessage() {}
+
+func (x *DraftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DraftResponse.ProtoReflect.Descriptor instead.
+func (*DraftResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *DraftResponse) GetDraft() *Draft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+func (x *DraftResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type GetDraftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drafts        []*Draft               `protobuf:"bytes,1,rep,name=drafts,proto3" json:"drafts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDraftsResponse) Reset() {
+	*x = GetDraftsResponse{}
+	mi := &file_chat_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDraftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDraftsResponse) ProtoMessage() {}
+
+func (x *GetDraftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDraftsResponse.ProtoReflect.Descriptor instead.
+func (*GetDraftsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *GetDraftsResponse) GetDrafts() []*Draft {
+	if x != nil {
+		return x.Drafts
+	}
+	return nil
+}
+
+type FolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *ChatFolder            `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FolderResponse) Reset() {
+	*x = FolderResponse{}
+	mi := &file_chat_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderResponse) ProtoMessage() {}
+
+func (x *FolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderResponse.ProtoReflect.Descriptor instead.
+func (*FolderResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *FolderResponse) GetFolder() *ChatFolder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type DeleteFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderResponse) Reset() {
+	*x = DeleteFolderResponse{}
+	mi := &file_chat_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderResponse) ProtoMessage() {}
+
+func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFolderResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *DeleteFolderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListFoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*ChatFolder          `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFoldersResponse) Reset() {
+	*x = ListFoldersResponse{}
+	mi := &file_chat_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFoldersResponse) ProtoMessage() {}
+
+func (x *ListFoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFoldersResponse.ProtoReflect.Descriptor instead.
+func (*ListFoldersResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ListFoldersResponse) GetFolders() []*ChatFolder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+// resync = true — журнал с since_seq уже очищен: перезагрузите чаты и историю и продолжайте с seq.
+// has_more = true — запросите следующую страницу с since_seq = seq.
+type GetUpdatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updates       []*Update              `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	Seq           int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	Resync        bool                   `protobuf:"varint,4,opt,name=resync,proto3" json:"resync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUpdatesResponse) Reset() {
+	*x = GetUpdatesResponse{}
+	mi := &file_chat_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUpdatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUpdatesResponse) ProtoMessage() {}
+
+func (x *GetUpdatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUpdatesResponse.ProtoReflect.Descriptor instead.
+func (*GetUpdatesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GetUpdatesResponse) GetUpdates() []*Update {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *GetUpdatesResponse) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetUpdatesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetUpdatesResponse) GetResync() bool {
+	if x != nil {
+		return x.Resync
+	}
+	return false
+}
+
+// Событие SubscribeChats: update, resync = true (журнал с resume_token уже очищен —
+// перезагрузите чаты и историю) или typing — статус набора в чате. resume_token сохраняйте для
+// переподключения; у typing его нет, такие события после переподключения не повторяются.
+type ChatStreamEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Update        *Update                `protobuf:"bytes,1,opt,name=update,proto3" json:"update,omitempty"`
+	Resync        bool                   `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	ResumeToken   string                 `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Typing        *TypingStatus          `protobuf:"bytes,4,opt,name=typing,proto3" json:"typing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatStreamEvent) Reset() {
+	*x = ChatStreamEvent{}
+	mi := &file_chat_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatStreamEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatStreamEvent) ProtoMessage() {}
+
+func (x *ChatStreamEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatStreamEvent.ProtoReflect.Descriptor instead.
+func (*ChatStreamEvent) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *ChatStreamEvent) GetUpdate() *Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *ChatStreamEvent) GetResync() bool {
+	if x != nil {
+		return x.Resync
+	}
+	return false
+}
+
+func (x *ChatStreamEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *ChatStreamEvent) GetTyping() *TypingStatus {
+	if x != nil {
+		return x.Typing
+	}
+	return nil
+}
+
+// Часть выгрузки; content_type и filename заполнены в первой части
+type ExportChatChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChatChunk) Reset() {
+	*x = ExportChatChunk{}
+	mi := &file_chat_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChatChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChatChunk) ProtoMessage() {}
+
+func (x *ExportChatChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChatChunk.ProtoReflect.Descriptor instead.
+func (*ExportChatChunk) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ExportChatChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportChatChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportChatChunk) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// Счётчики — что из архива не перенесено как есть: участники не найдены или заблокировали импортёра,
+// файлы вложений не найдены или чужие, авторы сообщений не найдены (сообщения записаны от импортёра)
+type ImportChatResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Chat               *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	ImportedMessages   int32                  `protobuf:"varint,2,opt,name=imported_messages,json=importedMessages,proto3" json:"imported_messages,omitempty"`
+	SkippedMembers     int32                  `protobuf:"varint,3,opt,name=skipped_members,json=skippedMembers,proto3" json:"skipped_members,omitempty"`
+	DroppedAttachments int32                  `protobuf:"varint,4,opt,name=dropped_attachments,json=droppedAttachments,proto3" json:"dropped_attachments,omitempty"`
+	ReassignedMessages int32                  `protobuf:"varint,5,opt,name=reassigned_messages,json=reassignedMessages,proto3" json:"reassigned_messages,omitempty"`
+	InvitedMembers     []string               `protobuf:"bytes,6,rep,name=invited_members,json=invitedMembers,proto3" json:"invited_members,omitempty"` // участники архива, которым предназначена ссылка
+	InviteLink         *InviteLink            `protobuf:"bytes,7,opt,name=invite_link,json=inviteLink,proto3" json:"invite_link,omitempty"`             // по ней участники архива вступают сами; пусто — приглашать некого
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImportChatResponse) Reset() {
+	*x = ImportChatResponse{}
+	mi := &file_chat_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportChatResponse) ProtoMessage() {}
+
+func (x *ImportChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportChatResponse.ProtoReflect.Descriptor instead.
+func (*ImportChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ImportChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *ImportChatResponse) GetImportedMessages() int32 {
+	if x != nil {
+		return x.ImportedMessages
+	}
+	return 0
+}
+
+func (x *ImportChatResponse) GetSkippedMembers() int32 {
+	if x != nil {
+		return x.SkippedMembers
+	}
+	return 0
+}
+
+func (x *ImportChatResponse) GetDroppedAttachments() int32 {
+	if x != nil {
+		return x.DroppedAttachments
+	}
+	return 0
+}
+
+func (x *ImportChatResponse) GetReassignedMessages() int32 {
+	if x != nil {
+		return x.ReassignedMessages
+	}
+	return 0
+}
+
+func (x *ImportChatResponse) GetInvitedMembers() []string {
+	if x != nil {
+		return x.InvitedMembers
+	}
+	return nil
+}
+
+func (x *ImportChatResponse) GetInviteLink() *InviteLink {
+	if x != nil {
+		return x.InviteLink
+	}
+	return nil
+}
+
+// --- Сущности ---
+type Chat struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind            string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	MemberIds       []string               `protobuf:"bytes,3,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedBy       string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Pinned          []*PinnedMessage       `protobuf:"bytes,7,rep,name=pinned,proto3" json:"pinned,omitempty"`
+	LastSeq         int64                  `protobuf:"varint,8,opt,name=last_seq,json=lastSeq,proto3" json:"last_seq,omitempty"`
+	LastMessageAt   string                 `protobuf:"bytes,9,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`
+	LastMessage     *MessagePreview        `protobuf:"bytes,10,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	State           *ChatState             `protobuf:"bytes,11,opt,name=state,proto3" json:"state,omitempty"`                                               // только в ListChats
+	MessageTtl      int64                  `protobuf:"varint,12,opt,name=message_ttl,json=messageTtl,proto3" json:"message_ttl,omitempty"`                  // таймер автоудаления в секундах, 0 — выключен
+	Handle          string                 `protobuf:"bytes,13,opt,name=handle,proto3" json:"handle,omitempty"`                                             // только для каналов
+	SubscriberCount int64                  `protobuf:"varint,14,opt,name=subscriber_count,json=subscriberCount,proto3" json:"subscriber_count,omitempty"`   // только для каналов
+	SlowModeSeconds int64                  `protobuf:"varint,15,opt,name=slow_mode_seconds,json=slowModeSeconds,proto3" json:"slow_mode_seconds,omitempty"` // медленный режим группы, 0 — выключен
+	Secret          bool                   `protobuf:"varint,16,opt,name=secret,proto3" json:"secret,omitempty"`                                            // секретный чат: сквозное шифрование, без поиска и предпросмотра ссылок
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	mi := &file_chat_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *Chat) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Chat) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Chat) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *Chat) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Chat) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Chat) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Chat) GetPinned() []*PinnedMessage {
+	if x != nil {
+		return x.Pinned
+	}
+	return nil
+}
+
+func (x *Chat) GetLastSeq() int64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+func (x *Chat) GetLastMessageAt() string {
+	if x != nil {
+		return x.LastMessageAt
+	}
+	return ""
+}
+
+func (x *Chat) GetLastMessage() *MessagePreview {
+	if x != nil {
+		return x.LastMessage
+	}
+	return nil
+}
+
+func (x *Chat) GetState() *ChatState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Chat) GetMessageTtl() int64 {
+	if x != nil {
+		return x.MessageTtl
+	}
+	return 0
+}
+
+func (x *Chat) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *Chat) GetSubscriberCount() int64 {
+	if x != nil {
+		return x.SubscriberCount
+	}
+	return 0
+}
+
+func (x *Chat) GetSlowModeSeconds() int64 {
+	if x != nil {
+		return x.SlowModeSeconds
+	}
+	return 0
+}
+
+func (x *Chat) GetSecret() bool {
+	if x != nil {
+		return x.Secret
+	}
+	return false
+}
+
+type InviteLink struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ChatId           string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	CreatedBy        string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt        int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UsageLimit       int64                  `protobuf:"varint,6,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	UsageCount       int64                  `protobuf:"varint,7,opt,name=usage_count,json=usageCount,proto3" json:"usage_count,omitempty"`
+	RequiresApproval bool                   `protobuf:"varint,8,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	Revoked          bool                   `protobuf:"varint,9,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *InviteLink) Reset() {
+	*x = InviteLink{}
+	mi := &file_chat_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteLink) ProtoMessage() {}
+
+func (x *InviteLink) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteLink.ProtoReflect.Descriptor instead.
+func (*InviteLink) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *InviteLink) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *InviteLink) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *InviteLink) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *InviteLink) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *InviteLink) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *InviteLink) GetUsageLimit() int64 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *InviteLink) GetUsageCount() int64 {
+	if x != nil {
+		return x.UsageCount
+	}
+	return 0
+}
+
+func (x *InviteLink) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+func (x *InviteLink) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+// status: pending | approved | rejected
+type JoinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,4,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	mi := &file_chat_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *JoinRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// kind: held — сообщение задержано фильтром, report — жалобы на опубликованное сообщение.
+// status: pending | allowed | removed
+type ModerationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	MessageId     string                 `protobuf:"bytes,4,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Reasons       []string               `protobuf:"bytes,7,rep,name=reasons,proto3" json:"reasons,omitempty"` // какие фильтры сработали
+	Reports       []*ModerationReport    `protobuf:"bytes,8,rep,name=reports,proto3" json:"reports,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DecidedBy     string                 `protobuf:"bytes,11,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	DecidedAt     string                 `protobuf:"bytes,12,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationItem) Reset() {
+	*x = ModerationItem{}
+	mi := &file_chat_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationItem) ProtoMessage() {}
+
+func (x *ModerationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationItem.ProtoReflect.Descriptor instead.
+func (*ModerationItem) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ModerationItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ModerationItem) GetReports() []*ModerationReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ModerationItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *ModerationItem) GetDecidedAt() string {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return ""
+}
+
+type ModerationReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationReport) Reset() {
+	*x = ModerationReport{}
+	mi := &file_chat_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationReport) ProtoMessage() {}
+
+func (x *ModerationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationReport.ProtoReflect.Descriptor instead.
+func (*ModerationReport) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ModerationReport) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ModerationReport) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ModerationReport) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ChannelSubscriber struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SubscribedAt  string                 `protobuf:"bytes,2,opt,name=subscribed_at,json=subscribedAt,proto3" json:"subscribed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelSubscriber) Reset() {
+	*x = ChannelSubscriber{}
+	mi := &file_chat_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelSubscriber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelSubscriber) ProtoMessage() {}
+
+func (x *ChannelSubscriber) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelSubscriber.ProtoReflect.Descriptor instead.
+func (*ChannelSubscriber) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *ChannelSubscriber) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChannelSubscriber) GetSubscribedAt() string {
+	if x != nil {
+		return x.SubscribedAt
+	}
+	return ""
+}
+
+type MessagePreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seq           int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"` // не длиннее 100 символов
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Deleted       bool                   `protobuf:"varint,7,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessagePreview) Reset() {
+	*x = MessagePreview{}
+	mi := &file_chat_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessagePreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessagePreview) ProtoMessage() {}
+
+func (x *MessagePreview) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessagePreview.ProtoReflect.Descriptor instead.
+func (*MessagePreview) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *MessagePreview) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MessagePreview) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Личные настройки чата у пользователя
+type ChatState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Pinned        bool                   `protobuf:"varint,2,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Archived      bool                   `protobuf:"varint,3,opt,name=archived,proto3" json:"archived,omitempty"`
+	MutedUntil    int64                  `protobuf:"varint,4,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`
+	MarkedUnread  bool                   `protobuf:"varint,5,opt,name=marked_unread,json=markedUnread,proto3" json:"marked_unread,omitempty"`
+	UnreadCount   int64                  `protobuf:"varint,6,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatState) Reset() {
+	*x = ChatState{}
+	mi := &file_chat_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatState) ProtoMessage() {}
+
+func (x *ChatState) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatState.ProtoReflect.Descriptor instead.
+func (*ChatState) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ChatState) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ChatState) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *ChatState) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *ChatState) GetMutedUntil() int64 {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return 0
+}
+
+func (x *ChatState) GetMarkedUnread() bool {
+	if x != nil {
+		return x.MarkedUnread
+	}
+	return false
+}
+
+func (x *ChatState) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+// Правила папки: kinds и contacts (личные чаты с этими пользователями) отбирают чаты,
+// если оба пусты — подходят все; muted и unread: "" — не важно, only — только такие, exclude — только без этого
+type FolderRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kinds         []string               `protobuf:"bytes,1,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	Contacts      []string               `protobuf:"bytes,2,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	Muted         string                 `protobuf:"bytes,3,opt,name=muted,proto3" json:"muted,omitempty"`
+	Unread        string                 `protobuf:"bytes,4,opt,name=unread,proto3" json:"unread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FolderRules) Reset() {
+	*x = FolderRules{}
+	mi := &file_chat_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FolderRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderRules) ProtoMessage() {}
+
+func (x *FolderRules) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderRules.ProtoReflect.Descriptor instead.
+func (*FolderRules) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *FolderRules) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *FolderRules) GetContacts() []string {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *FolderRules) GetMuted() string {
+	if x != nil {
+		return x.Muted
+	}
+	return ""
+}
+
+func (x *FolderRules) GetUnread() string {
+	if x != nil {
+		return x.Unread
+	}
+	return ""
+}
+
+// Папка чатов. include_chat_ids входят в обход правил (в том числе архивные),
+// exclude_chat_ids не входят никогда. unread_chats и unread_messages заполняются в ListFolders.
+type ChatFolder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Position       int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	IncludeChatIds []string               `protobuf:"bytes,4,rep,name=include_chat_ids,json=includeChatIds,proto3" json:"include_chat_ids,omitempty"`
+	ExcludeChatIds []string               `protobuf:"bytes,5,rep,name=exclude_chat_ids,json=excludeChatIds,proto3" json:"exclude_chat_ids,omitempty"`
+	Rules          *FolderRules           `protobuf:"bytes,6,opt,name=rules,proto3" json:"rules,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UnreadChats    int64                  `protobuf:"varint,8,opt,name=unread_chats,json=unreadChats,proto3" json:"unread_chats,omitempty"`
+	UnreadMessages int64                  `protobuf:"varint,9,opt,name=unread_messages,json=unreadMessages,proto3" json:"unread_messages,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChatFolder) Reset() {
+	*x = ChatFolder{}
+	mi := &file_chat_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatFolder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatFolder) ProtoMessage() {}
+
+func (x *ChatFolder) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatFolder.ProtoReflect.Descriptor instead.
+func (*ChatFolder) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ChatFolder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatFolder) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ChatFolder) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ChatFolder) GetIncludeChatIds() []string {
+	if x != nil {
+		return x.IncludeChatIds
+	}
+	return nil
+}
+
+func (x *ChatFolder) GetExcludeChatIds() []string {
+	if x != nil {
+		return x.ExcludeChatIds
+	}
+	return nil
+}
+
+func (x *ChatFolder) GetRules() *FolderRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ChatFolder) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ChatFolder) GetUnreadChats() int64 {
+	if x != nil {
+		return x.UnreadChats
+	}
+	return 0
+}
+
+func (x *ChatFolder) GetUnreadMessages() int64 {
+	if x != nil {
+		return x.UnreadMessages
+	}
+	return 0
+}
+
+// Закреплённое сообщение; message заполняется только в ListPinned
+type PinnedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	PinnedBy      string                 `protobuf:"bytes,2,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"`
+	PinnedAt      string                 `protobuf:"bytes,3,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	Message       *Message               `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedMessage) Reset() {
+	*x = PinnedMessage{}
+	mi := &file_chat_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedMessage) ProtoMessage() {}
+
+func (x *PinnedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedMessage.ProtoReflect.Descriptor instead.
+func (*PinnedMessage) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *PinnedMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *PinnedMessage) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *PinnedMessage) GetPinnedAt() string {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return ""
+}
+
+func (x *PinnedMessage) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type Message struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId         string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	AuthorId       string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text           string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Media          []*Media               `protobuf:"bytes,5,rep,name=media,proto3" json:"media,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Deleted        bool                   `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Type           string                 `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	System         *SystemEvent           `protobuf:"bytes,10,opt,name=system,proto3" json:"system,omitempty"`
+	Edited         bool                   `protobuf:"varint,11,opt,name=edited,proto3" json:"edited,omitempty"`
+	EditCount      int32                  `protobuf:"varint,12,opt,name=edit_count,json=editCount,proto3" json:"edit_count,omitempty"`
+	Entities       []*MessageEntity       `protobuf:"bytes,13,rep,name=entities,proto3" json:"entities,omitempty"`
+	Seq            int64                  `protobuf:"varint,14,opt,name=seq,proto3" json:"seq,omitempty"`                                              // порядковый номер внутри чата
+	ExpiresAt      string                 `protobuf:"bytes,15,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                  // когда сообщение удалится по таймеру чата; пусто — бессрочно
+	Poll           *Poll                  `protobuf:"bytes,16,opt,name=poll,proto3" json:"poll,omitempty"`                                             // только для type = poll
+	Views          int64                  `protobuf:"varint,17,opt,name=views,proto3" json:"views,omitempty"`                                          // просмотры поста в канале
+	ImportedFrom   string                 `protobuf:"bytes,18,opt,name=imported_from,json=importedFrom,proto3" json:"imported_from,omitempty"`         // только для импортированных: имя исходного автора, которого не нашлось
+	Held           bool                   `protobuf:"varint,19,opt,name=held,proto3" json:"held,omitempty"`                                            // только в ответе SendMessage: сообщение ждёт решения модератора и пока никому не видно
+	SenderDeviceId string                 `protobuf:"bytes,20,opt,name=sender_device_id,json=senderDeviceId,proto3" json:"sender_device_id,omitempty"` // только в секретном чате
+	Envelopes      []*Envelope            `protobuf:"bytes,21,rep,name=envelopes,proto3" json:"envelopes,omitempty"`                                   // только в секретном чате: клиент берёт конверт своего устройства
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_chat_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *Message) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Message) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *Message) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *Message) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Message) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Message) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Message) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Message) GetSystem() *SystemEvent {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
+
+func (x *Message) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *Message) GetEditCount() int32 {
+	if x != nil {
+		return x.EditCount
+	}
+	return 0
+}
+
+func (x *Message) GetEntities() []*MessageEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *Message) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Message) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Message) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *Message) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *Message) GetImportedFrom() string {
+	if x != nil {
+		return x.ImportedFrom
+	}
+	return ""
+}
+
+func (x *Message) GetHeld() bool {
+	if x != nil {
+		return x.Held
+	}
+	return false
+}
+
+func (x *Message) GetSenderDeviceId() string {
+	if x != nil {
+		return x.SenderDeviceId
+	}
+	return ""
+}
+
+func (x *Message) GetEnvelopes() []*Envelope {
+	if x != nil {
+		return x.Envelopes
+	}
+	return nil
+}
+
+// Опрос без списка проголосовавших; closed учитывает и истёкший closes_at
+type Poll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MessageId      string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Question       string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []*PollOption          `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	MultipleChoice bool                   `protobuf:"varint,4,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	Anonymous      bool                   `protobuf:"varint,5,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	ClosesAt       int64                  `protobuf:"varint,6,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	Closed         bool                   `protobuf:"varint,7,opt,name=closed,proto3" json:"closed,omitempty"`
+	TotalVoters    int64                  `protobuf:"varint,8,opt,name=total_voters,json=totalVoters,proto3" json:"total_voters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	mi := &file_chat_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *Poll) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *Poll) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Poll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *Poll) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+func (x *Poll) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+func (x *Poll) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Poll) GetTotalVoters() int64 {
+	if x != nil {
+		return x.TotalVoters
+	}
+	return 0
+}
+
+type PollOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Votes         int64                  `protobuf:"varint,3,opt,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOption) Reset() {
+	*x = PollOption{}
+	mi := &file_chat_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOption) ProtoMessage() {}
+
+func (x *PollOption) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *PollOption) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PollOption) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PollOption) GetVotes() int64 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type PollOptionVoters struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptionId      int32                  `protobuf:"varint,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOptionVoters) Reset() {
+	*x = PollOptionVoters{}
+	mi := &file_chat_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOptionVoters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOptionVoters) ProtoMessage() {}
+
+func (x *PollOptionVoters) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOptionVoters.ProtoReflect.Descriptor instead.
+func (*PollOptionVoters) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *PollOptionVoters) GetOptionId() int32 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *PollOptionVoters) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// Отложенное сообщение; status: pending | sending | sent | canceled | failed
+type ScheduledMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Media         []*Media               `protobuf:"bytes,5,rep,name=media,proto3" json:"media,omitempty"`
+	SendAt        int64                  `protobuf:"varint,6,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	MessageId     string                 `protobuf:"bytes,8,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // id сообщения в чате после отправки
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledMessage) Reset() {
+	*x = ScheduledMessage{}
+	mi := &file_chat_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessage) ProtoMessage() {}
+
+func (x *ScheduledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledMessage) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *ScheduledMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *ScheduledMessage) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Указатели участника: прочитано и доставлено всё до seq включительно
+type ReadState struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LastReadSeq      int64                  `protobuf:"varint,2,opt,name=last_read_seq,json=lastReadSeq,proto3" json:"last_read_seq,omitempty"`
+	LastReadAt       string                 `protobuf:"bytes,3,opt,name=last_read_at,json=lastReadAt,proto3" json:"last_read_at,omitempty"`
+	LastDeliveredSeq int64                  `protobuf:"varint,4,opt,name=last_delivered_seq,json=lastDeliveredSeq,proto3" json:"last_delivered_seq,omitempty"`
+	LastDeliveredAt  string                 `protobuf:"bytes,5,opt,name=last_delivered_at,json=lastDeliveredAt,proto3" json:"last_delivered_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReadState) Reset() {
+	*x = ReadState{}
+	mi := &file_chat_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadState) ProtoMessage() {}
+
+func (x *ReadState) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadState.ProtoReflect.Descriptor instead.
+func (*ReadState) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *ReadState) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReadState) GetLastReadSeq() int64 {
+	if x != nil {
+		return x.LastReadSeq
+	}
+	return 0
+}
+
+func (x *ReadState) GetLastReadAt() string {
+	if x != nil {
+		return x.LastReadAt
+	}
+	return ""
+}
+
+func (x *ReadState) GetLastDeliveredSeq() int64 {
+	if x != nil {
+		return x.LastDeliveredSeq
+	}
+	return 0
+}
+
+func (x *ReadState) GetLastDeliveredAt() string {
+	if x != nil {
+		return x.LastDeliveredAt
+	}
+	return ""
+}
+
+// Размеченный фрагмент текста; offset и length в символах
+type MessageEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // mention | mention_all
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int32                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageEntity) Reset() {
+	*x = MessageEntity{}
+	mi := &file_chat_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEntity) ProtoMessage() {}
+
+func (x *MessageEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEntity.ProtoReflect.Descriptor instead.
+func (*MessageEntity) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *MessageEntity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MessageEntity) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *MessageEntity) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *MessageEntity) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Черновик сообщения; пустые text, reply_to_message_id и media — черновик очищен
+type Draft struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChatId           string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Text             string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	ReplyToMessageId string                 `protobuf:"bytes,3,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"`
+	Media            []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	UpdatedAt        int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unix ms, время сервера
+	DeviceId         string                 `protobuf:"bytes,6,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Version          int64                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"` // назначает сервер; передаётся как base_version следующей правки
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Draft) Reset() {
+	*x = Draft{}
+	mi := &file_chat_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Draft) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Draft) ProtoMessage() {}
+
+func (x *Draft) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Draft.ProtoReflect.Descriptor instead.
+func (*Draft) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *Draft) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *Draft) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Draft) GetReplyToMessageId() string {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return ""
+}
+
+func (x *Draft) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *Draft) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Draft) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Draft) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Запись журнала обновлений пользователя.
+// type: message.new | message.edited (messages) | message.deleted (message_ids) |
+// read (actor_id прочитал до read_seq) | membership (action над user_ids) |
+// folders (action над folder_id; reordered — без folder_id) | poll.updated (messages) |
+// draft.updated (draft — черновик после правки, только в журнале владельца)
+type Update struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ChatId        string                 `protobuf:"bytes,3,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Messages      []*Message             `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	MessageIds    []string               `protobuf:"bytes,5,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	ActorId       string                 `protobuf:"bytes,6,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ReadSeq       int64                  `protobuf:"varint,7,opt,name=read_seq,json=readSeq,proto3" json:"read_seq,omitempty"`
+	Action        string                 `protobuf:"bytes,8,opt,name=action,proto3" json:"action,omitempty"` // membership: created | joined | added | removed | left; folders: created | updated | deleted | reordered
+	UserIds       []string               `protobuf:"bytes,9,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FolderId      string                 `protobuf:"bytes,11,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Draft         *Draft                 `protobuf:"bytes,12,opt,name=draft,proto3" json:"draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Update) Reset() {
+	*x = Update{}
+	mi := &file_chat_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Update) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Update) ProtoMessage() {}
+
+func (x *Update) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Update.ProtoReflect.Descriptor instead.
+func (*Update) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *Update) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Update) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Update) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *Update) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *Update) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *Update) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *Update) GetReadSeq() int64 {
+	if x != nil {
+		return x.ReadSeq
+	}
+	return 0
+}
+
+func (x *Update) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Update) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *Update) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Update) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *Update) GetDraft() *Draft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+type TypingStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix ms
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypingStatus) Reset() {
+	*x = TypingStatus{}
+	mi := &file_chat_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypingStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSavedResponse) ProtoMessage() {}
+func (*TypingStatus) ProtoMessage() {}
 
-func (x *ListSavedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[20]
+func (x *TypingStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1177,47 +8188,64 @@ func (x *ListSavedResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSavedResponse.ProtoReflect.Descriptor instead.
-func (*ListSavedResponse) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use TypingStatus.ProtoReflect.Descriptor instead.
+func (*TypingStatus) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{129}
 }
 
-func (x *ListSavedResponse) GetMessages() []*Message {
+func (x *TypingStatus) GetChatId() string {
 	if x != nil {
-		return x.Messages
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListSavedResponse) GetNextCursor() string {
+func (x *TypingStatus) GetUserId() string {
 	if x != nil {
-		return x.NextCursor
+		return x.UserId
 	}
 	return ""
 }
 
-type ListReadMessagesResponse struct {
+func (x *TypingStatus) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TypingStatus) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type Mention struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListReadMessagesResponse) Reset() {
-	*x = ListReadMessagesResponse{}
-	mi := &file_chat_proto_msgTypes[21]
+func (x *Mention) Reset() {
+	*x = Mention{}
+	mi := &file_chat_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListReadMessagesResponse) String() string {
+func (x *Mention) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListReadMessagesResponse) ProtoMessage() {}
+func (*Mention) ProtoMessage() {}
 
-func (x *ListReadMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[21]
+func (x *Mention) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1228,46 +8256,67 @@ func (x *ListReadMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListReadMessagesResponse.ProtoReflect.Descriptor instead.
-func (*ListReadMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{130}
 }
 
-func (x *ListReadMessagesResponse) GetMessages() []*Message {
+func (x *Mention) GetChatId() string {
 	if x != nil {
-		return x.Messages
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-// --- Сущности ---
-type Chat struct {
+func (x *Mention) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *Mention) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Mention) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Предыдущая версия сообщения; revision = 1 — исходный текст
+type MessageRevision struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	MemberIds     []string               `protobuf:"bytes,3,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
-	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Revision      int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReplacedAt    string                 `protobuf:"bytes,6,opt,name=replaced_at,json=replacedAt,proto3" json:"replaced_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Chat) Reset() {
-	*x = Chat{}
-	mi := &file_chat_proto_msgTypes[22]
+func (x *MessageRevision) Reset() {
+	*x = MessageRevision{}
+	mi := &file_chat_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Chat) String() string {
+func (x *MessageRevision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Chat) ProtoMessage() {}
+func (*MessageRevision) ProtoMessage() {}
 
-func (x *Chat) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[22]
+func (x *MessageRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1278,82 +8327,79 @@ func (x *Chat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
-func (*Chat) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use MessageRevision.ProtoReflect.Descriptor instead.
+func (*MessageRevision) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{131}
 }
 
-func (x *Chat) GetId() string {
+func (x *MessageRevision) GetMessageId() string {
 	if x != nil {
-		return x.Id
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *Chat) GetKind() string {
+func (x *MessageRevision) GetRevision() int32 {
 	if x != nil {
-		return x.Kind
+		return x.Revision
 	}
-	return ""
+	return 0
 }
 
-func (x *Chat) GetMemberIds() []string {
+func (x *MessageRevision) GetText() string {
 	if x != nil {
-		return x.MemberIds
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *Chat) GetTitle() string {
+func (x *MessageRevision) GetMedia() []*Media {
 	if x != nil {
-		return x.Title
+		return x.Media
 	}
-	return ""
+	return nil
 }
 
-func (x *Chat) GetCreatedBy() string {
+func (x *MessageRevision) GetCreatedAt() string {
 	if x != nil {
-		return x.CreatedBy
+		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *Chat) GetCreatedAt() string {
+func (x *MessageRevision) GetReplacedAt() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.ReplacedAt
 	}
 	return ""
 }
 
-type Message struct {
+// Служебное событие системного сообщения (закрепление и т.п.)
+type SystemEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
-	Media         []*Media               `protobuf:"bytes,5,rep,name=media,proto3" json:"media,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Deleted       bool                   `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	ActorId       string                 `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // над кем совершено действие (например, кто вступил)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Message) Reset() {
-	*x = Message{}
-	mi := &file_chat_proto_msgTypes[23]
+func (x *SystemEvent) Reset() {
+	*x = SystemEvent{}
+	mi := &file_chat_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Message) String() string {
+func (x *SystemEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Message) ProtoMessage() {}
+func (*SystemEvent) ProtoMessage() {}
 
-func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[23]
+func (x *SystemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,67 +8410,39 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Message.ProtoReflect.Descriptor instead.
-func (*Message) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *Message) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Message) GetChatId() string {
-	if x != nil {
-		return x.ChatId
-	}
-	return ""
+// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
+func (*SystemEvent) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{132}
 }
 
-func (x *Message) GetAuthorId() string {
+func (x *SystemEvent) GetAction() string {
 	if x != nil {
-		return x.AuthorId
+		return x.Action
 	}
 	return ""
 }
 
-func (x *Message) GetText() string {
+func (x *SystemEvent) GetActorId() string {
 	if x != nil {
-		return x.Text
+		return x.ActorId
 	}
 	return ""
 }
 
-func (x *Message) GetMedia() []*Media {
-	if x != nil {
-		return x.Media
-	}
-	return nil
-}
-
-func (x *Message) GetCreatedAt() string {
+func (x *SystemEvent) GetMessageId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *Message) GetUpdatedAt() string {
+func (x *SystemEvent) GetUserId() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Message) GetDeleted() bool {
-	if x != nil {
-		return x.Deleted
-	}
-	return false
-}
-
 type Media struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1438,7 +8456,7 @@ type Media struct {
 
 func (x *Media) Reset() {
 	*x = Media{}
-	mi := &file_chat_proto_msgTypes[24]
+	mi := &file_chat_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1450,7 +8468,7 @@ func (x *Media) String() string {
 func (*Media) ProtoMessage() {}
 
 func (x *Media) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[24]
+	mi := &file_chat_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1463,7 +8481,7 @@ func (x *Media) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Media.ProtoReflect.Descriptor instead.
 func (*Media) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{24}
+	return file_chat_proto_rawDescGZIP(), []int{133}
 }
 
 func (x *Media) GetId() string {
@@ -1509,50 +8527,229 @@ const file_chat_proto_rawDesc = "" +
 	"chat.proto\x12\x04chat\"K\n" +
 	"\x17CreateDirectChatRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apeer_id\x18\x02 \x01(\tR\x06peerId\"K\n" +
+	"\x17CreateSecretChatRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
 	"\apeer_id\x18\x02 \x01(\tR\x06peerId\"f\n" +
 	"\x16CreateGroupChatRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
 	"member_ids\x18\x02 \x03(\tR\tmemberIds\x12\x14\n" +
-	"\x05title\x18\x03 \x01(\tR\x05title\"\xbc\x01\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"\xf2\x01\n" +
 	"\x16UpdateGroupChatRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12$\n" +
 	"\x0eadd_member_ids\x18\x03 \x03(\tR\faddMemberIds\x12*\n" +
 	"\x11remove_member_ids\x18\x04 \x03(\tR\x0fremoveMemberIds\x12!\n" +
-	"\frequester_id\x18\x05 \x01(\tR\vrequesterId\")\n" +
+	"\frequester_id\x18\x05 \x01(\tR\vrequesterId\x12$\n" +
+	"\vmessage_ttl\x18\x06 \x01(\x03H\x00R\n" +
+	"messageTtl\x88\x01\x01B\x0e\n" +
+	"\f_message_ttl\"s\n" +
+	"\x14SetMessageTTLRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\"j\n" +
+	"\x12SetSlowModeRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x18\n" +
+	"\aseconds\x18\x03 \x01(\x03R\aseconds\"n\n" +
+	"\x14ReportMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1f\n" +
+	"\vreporter_id\x18\x02 \x01(\tR\n" +
+	"reporterId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x86\x01\n" +
+	"\x1aListModerationQueueRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"n\n" +
+	"\x18ResolveModerationRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\")\n" +
 	"\x0eGetChatRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\tR\x06chatId\"Y\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"\x92\x01\n" +
 	"\x10ListChatsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\x81\x01\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x1b\n" +
+	"\tfolder_id\x18\x05 \x01(\tR\bfolderId\"Z\n" +
+	"\x0ePinChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06pinned\x18\x03 \x01(\bR\x06pinned\"b\n" +
+	"\x12ArchiveChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\barchived\x18\x03 \x01(\bR\barchived\"d\n" +
+	"\x0fMuteChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vmuted_until\x18\x03 \x01(\x03R\n" +
+	"mutedUntil\"a\n" +
+	"\x15MarkChatUnreadRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06unread\x18\x03 \x01(\bR\x06unread\"\xc2\x01\n" +
+	"\x17CreateInviteLinkRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x1f\n" +
+	"\vusage_limit\x18\x04 \x01(\x03R\n" +
+	"usageLimit\x12+\n" +
+	"\x11requires_approval\x18\x05 \x01(\bR\x10requiresApproval\"P\n" +
+	"\x17RevokeInviteLinkRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"B\n" +
+	"\x13JoinByInviteRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x83\x01\n" +
+	"\x17ListJoinRequestsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\\\n" +
+	"\x18DecideJoinRequestRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"]\n" +
+	"\x14CreateChannelRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06handle\x18\x03 \x01(\tR\x06handle\"3\n" +
+	"\x19GetChannelByHandleRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\tR\x06handle\"K\n" +
+	"\x17SubscribeChannelRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"M\n" +
+	"\x19UnsubscribeChannelRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x89\x01\n" +
+	"\x1dListChannelSubscribersRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\x83\x01\n" +
+	"\x16SetChannelAdminRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05admin\x18\x04 \x01(\bR\x05admin\"h\n" +
+	"\x13ViewMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vmessage_ids\x18\x03 \x03(\tR\n" +
+	"messageIds\"\xfe\x01\n" +
 	"\x12SendMessageRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1b\n" +
 	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
 	"\x04text\x18\x03 \x01(\tR\x04text\x12!\n" +
-	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\"\x89\x01\n" +
+	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\x12#\n" +
+	"\x04poll\x18\x05 \x01(\v2\x0f.chat.PollInputR\x04poll\x12(\n" +
+	"\x10sender_device_id\x18\x06 \x01(\tR\x0esenderDeviceId\x12,\n" +
+	"\tenvelopes\x18\a \x03(\v2\x0e.chat.EnvelopeR\tenvelopes\"`\n" +
+	"\bEnvelope\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x03 \x01(\fR\n" +
+	"ciphertext\"\xa5\x01\n" +
+	"\tPollInput\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x12\x18\n" +
+	"\aoptions\x18\x02 \x03(\tR\aoptions\x12'\n" +
+	"\x0fmultiple_choice\x18\x03 \x01(\bR\x0emultipleChoice\x12\x1c\n" +
+	"\tanonymous\x18\x04 \x01(\bR\tanonymous\x12\x1b\n" +
+	"\tcloses_at\x18\x05 \x01(\x03R\bclosesAt\"\x89\x01\n" +
 	"\x14UpdateMessageRequest\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1b\n" +
 	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
 	"\x04text\x18\x03 \x01(\tR\x04text\x12!\n" +
-	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\"{\n" +
+	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\"_\n" +
+	"\x1bListMessageRevisionsRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"\x9e\x01\n" +
+	"\x16ScheduleMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12!\n" +
+	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\x12\x17\n" +
+	"\asend_at\x18\x05 \x01(\x03R\x06sendAt\"T\n" +
+	"\x1cListScheduledMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\"\x9c\x01\n" +
+	"\x1dUpdateScheduledMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12!\n" +
+	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\x12\x17\n" +
+	"\asend_at\x18\x05 \x01(\x03R\x06sendAt\"L\n" +
+	"\x1dCancelScheduledMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\"d\n" +
+	"\vVoteRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\x05R\toptionIds\"L\n" +
+	"\x12RetractVoteRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"T\n" +
+	"\x10ClosePollRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"Y\n" +
+	"\x15GetPollResultsRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"{\n" +
 	"\x14DeleteMessageRequest\x12\x1f\n" +
 	"\vmessage_ids\x18\x01 \x03(\tR\n" +
 	"messageIds\x12!\n" +
 	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x1f\n" +
 	"\vhard_delete\x18\x03 \x01(\bR\n" +
-	"hardDelete\"\\\n" +
+	"hardDelete\"\xd8\x01\n" +
 	"\x13ListMessagesRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"b\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1d\n" +
+	"\n" +
+	"before_seq\x18\x04 \x01(\x03R\tbeforeSeq\x12\x1b\n" +
+	"\tafter_seq\x18\x05 \x01(\x03R\bafterSeq\x12\x1d\n" +
+	"\n" +
+	"around_seq\x18\x06 \x01(\x03R\taroundSeq\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"b\n" +
 	"\x0fMarkReadRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x03 \x01(\tR\tmessageId\"b\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\"g\n" +
+	"\x14MarkDeliveredRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\"I\n" +
+	"\x15GetUnreadCountRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"p\n" +
+	"\x13GetReadStateRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\tR\vrequesterId\"u\n" +
+	"\x13ListMentionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"b\n" +
 	"\x12ToggleSavedRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
@@ -1565,7 +8762,70 @@ const file_chat_proto_rawDesc = "" +
 	"\x17ListReadMessagesRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
 	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\".\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"n\n" +
+	"\x11PinMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\tR\vrequesterId\"p\n" +
+	"\x13UnpinMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\tR\vrequesterId\"O\n" +
+	"\x11ListPinnedRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"\\\n" +
+	"\x10SetTypingRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\"O\n" +
+	"\x11ListTypingRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"\xf0\x01\n" +
+	"\x10SaveDraftRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12-\n" +
+	"\x13reply_to_message_id\x18\x04 \x01(\tR\x10replyToMessageId\x12!\n" +
+	"\x05media\x18\x05 \x03(\v2\v.chat.MediaR\x05media\x12\x1b\n" +
+	"\tdevice_id\x18\a \x01(\tR\bdeviceId\x12!\n" +
+	"\fbase_version\x18\b \x01(\x03R\vbaseVersionJ\x04\b\x06\x10\a\"D\n" +
+	"\x10GetDraftsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\"\x8b\x01\n" +
+	"\x11ClearDraftRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12!\n" +
+	"\fbase_version\x18\x05 \x01(\x03R\vbaseVersionJ\x04\b\x03\x10\x04\"R\n" +
+	"\rFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
+	"\x06folder\x18\x02 \x01(\v2\x10.chat.ChatFolderR\x06folder\"K\n" +
+	"\x13DeleteFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\"O\n" +
+	"\x15ReorderFoldersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"folder_ids\x18\x02 \x03(\tR\tfolderIds\"-\n" +
+	"\x12ListFoldersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"_\n" +
+	"\x11GetUpdatesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tsince_seq\x18\x02 \x01(\x03R\bsinceSeq\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"S\n" +
+	"\x15SubscribeChatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"g\n" +
+	"\x11ExportChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12!\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\"`\n" +
+	"\x11ImportChatRequest\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\tR\vrequesterId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\".\n" +
 	"\fChatResponse\x12\x1e\n" +
 	"\x04chat\x18\x01 \x01(\v2\n" +
 	".chat.ChatR\x04chat\"V\n" +
@@ -1574,17 +8834,83 @@ const file_chat_proto_rawDesc = "" +
 	".chat.ChatR\x05chats\x12\x1f\n" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
 	"nextCursor\":\n" +
+	"\x11ChatStateResponse\x12%\n" +
+	"\x05state\x18\x01 \x01(\v2\x0f.chat.ChatStateR\x05state\":\n" +
 	"\x0fMessageResponse\x12'\n" +
-	"\amessage\x18\x01 \x01(\v2\r.chat.MessageR\amessage\"K\n" +
+	"\amessage\x18\x01 \x01(\v2\r.chat.MessageR\amessage\"S\n" +
+	"\x1cListMessageRevisionsResponse\x123\n" +
+	"\trevisions\x18\x01 \x03(\v2\x15.chat.MessageRevisionR\trevisions\"P\n" +
+	"\x18ScheduledMessageResponse\x124\n" +
+	"\tscheduled\x18\x01 \x01(\v2\x16.chat.ScheduledMessageR\tscheduled\"U\n" +
+	"\x1dListScheduledMessagesResponse\x124\n" +
+	"\tscheduled\x18\x01 \x03(\v2\x16.chat.ScheduledMessageR\tscheduled\"R\n" +
+	"\fPollResponse\x12\x1e\n" +
+	"\x04poll\x18\x01 \x01(\v2\n" +
+	".chat.PollR\x04poll\x12\"\n" +
+	"\rmy_option_ids\x18\x02 \x03(\x05R\vmyOptionIds\"\x8c\x01\n" +
+	"\x16GetPollResultsResponse\x12\x1e\n" +
+	"\x04poll\x18\x01 \x01(\v2\n" +
+	".chat.PollR\x04poll\x12\"\n" +
+	"\rmy_option_ids\x18\x02 \x03(\x05R\vmyOptionIds\x12.\n" +
+	"\x06voters\x18\x03 \x03(\v2\x16.chat.PollOptionVotersR\x06voters\"G\n" +
+	"\x12InviteLinkResponse\x121\n" +
+	"\vinvite_link\x18\x01 \x01(\v2\x10.chat.InviteLinkR\n" +
+	"inviteLink\"4\n" +
+	"\x18RevokeInviteLinkResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"l\n" +
+	"\x14JoinByInviteResponse\x12\x1e\n" +
+	"\x04chat\x18\x01 \x01(\v2\n" +
+	".chat.ChatR\x04chat\x124\n" +
+	"\fjoin_request\x18\x02 \x01(\v2\x11.chat.JoinRequestR\vjoinRequest\"\x17\n" +
+	"\x15ReportMessageResponse\"j\n" +
+	"\x1bListModerationQueueResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.chat.ModerationItemR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"B\n" +
+	"\x16ModerationItemResponse\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.chat.ModerationItemR\x04item\"j\n" +
+	"\x18ListJoinRequestsResponse\x12-\n" +
+	"\brequests\x18\x01 \x03(\v2\x11.chat.JoinRequestR\brequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"5\n" +
+	"\x19RejectJoinRequestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\x1aUnsubscribeChannelResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"|\n" +
+	"\x1eListChannelSubscribersResponse\x129\n" +
+	"\vsubscribers\x18\x01 \x03(\v2\x17.chat.ChannelSubscriberR\vsubscribers\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"0\n" +
+	"\x14ViewMessagesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\":\n" +
+	"\x1eCancelScheduledMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"K\n" +
 	"\x15DeleteMessageResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"b\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xbf\x01\n" +
 	"\x14ListMessagesResponse\x12)\n" +
 	"\bmessages\x18\x01 \x03(\v2\r.chat.MessageR\bmessages\x12\x1f\n" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
-	"nextCursor\",\n" +
+	"nextCursor\x12\x1d\n" +
+	"\n" +
+	"has_before\x18\x03 \x01(\bR\thasBefore\x12\x1b\n" +
+	"\thas_after\x18\x04 \x01(\bR\bhasAfter\x12\x1f\n" +
+	"\vprev_cursor\x18\x05 \x01(\tR\n" +
+	"prevCursor\",\n" +
 	"\x10MarkReadResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"/\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"1\n" +
+	"\x15MarkDeliveredResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\";\n" +
+	"\x16GetUnreadCountResponse\x12!\n" +
+	"\funread_count\x18\x01 \x01(\x03R\vunreadCount\"j\n" +
+	"\x14GetReadStateResponse\x12#\n" +
+	"\x04read\x18\x01 \x03(\v2\x0f.chat.ReadStateR\x04read\x12-\n" +
+	"\tdelivered\x18\x02 \x03(\v2\x0f.chat.ReadStateR\tdelivered\"\x85\x01\n" +
+	"\x14ListMentionsResponse\x12)\n" +
+	"\bmentions\x18\x01 \x03(\v2\r.chat.MentionR\bmentions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12!\n" +
+	"\funread_count\x18\x03 \x01(\x03R\vunreadCount\"/\n" +
 	"\x13ToggleSavedResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"_\n" +
 	"\x11ListSavedResponse\x12)\n" +
@@ -1592,7 +8918,48 @@ const file_chat_proto_rawDesc = "" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
 	"nextCursor\"E\n" +
 	"\x18ListReadMessagesResponse\x12)\n" +
-	"\bmessages\x18\x01 \x03(\v2\r.chat.MessageR\bmessages\"\x9d\x01\n" +
+	"\bmessages\x18\x01 \x03(\v2\r.chat.MessageR\bmessages\"A\n" +
+	"\x12ListPinnedResponse\x12+\n" +
+	"\x06pinned\x18\x01 \x03(\v2\x13.chat.PinnedMessageR\x06pinned\"1\n" +
+	"\x11SetTypingResponse\x12\x1c\n" +
+	"\tthrottled\x18\x01 \x01(\bR\tthrottled\"D\n" +
+	"\x12ListTypingResponse\x12.\n" +
+	"\bstatuses\x18\x01 \x03(\v2\x12.chat.TypingStatusR\bstatuses\"L\n" +
+	"\rDraftResponse\x12!\n" +
+	"\x05draft\x18\x01 \x01(\v2\v.chat.DraftR\x05draft\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\"8\n" +
+	"\x11GetDraftsResponse\x12#\n" +
+	"\x06drafts\x18\x01 \x03(\v2\v.chat.DraftR\x06drafts\":\n" +
+	"\x0eFolderResponse\x12(\n" +
+	"\x06folder\x18\x01 \x01(\v2\x10.chat.ChatFolderR\x06folder\"0\n" +
+	"\x14DeleteFolderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"A\n" +
+	"\x13ListFoldersResponse\x12*\n" +
+	"\afolders\x18\x01 \x03(\v2\x10.chat.ChatFolderR\afolders\"\x81\x01\n" +
+	"\x12GetUpdatesResponse\x12&\n" +
+	"\aupdates\x18\x01 \x03(\v2\f.chat.UpdateR\aupdates\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x16\n" +
+	"\x06resync\x18\x04 \x01(\bR\x06resync\"\x9e\x01\n" +
+	"\x0fChatStreamEvent\x12$\n" +
+	"\x06update\x18\x01 \x01(\v2\f.chat.UpdateR\x06update\x12\x16\n" +
+	"\x06resync\x18\x02 \x01(\bR\x06resync\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\x12*\n" +
+	"\x06typing\x18\x04 \x01(\v2\x12.chat.TypingStatusR\x06typing\"d\n" +
+	"\x0fExportChatChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\"\xc8\x02\n" +
+	"\x12ImportChatResponse\x12\x1e\n" +
+	"\x04chat\x18\x01 \x01(\v2\n" +
+	".chat.ChatR\x04chat\x12+\n" +
+	"\x11imported_messages\x18\x02 \x01(\x05R\x10importedMessages\x12'\n" +
+	"\x0fskipped_members\x18\x03 \x01(\x05R\x0eskippedMembers\x12/\n" +
+	"\x13dropped_attachments\x18\x04 \x01(\x05R\x12droppedAttachments\x12/\n" +
+	"\x13reassigned_messages\x18\x05 \x01(\x05R\x12reassignedMessages\x12'\n" +
+	"\x0finvited_members\x18\x06 \x03(\tR\x0einvitedMembers\x121\n" +
+	"\vinvite_link\x18\a \x01(\v2\x10.chat.InviteLinkR\n" +
+	"inviteLink\"\x95\x04\n" +
 	"\x04Chat\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1d\n" +
@@ -1602,7 +8969,110 @@ const file_chat_proto_rawDesc = "" +
 	"\n" +
 	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\xde\x01\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12+\n" +
+	"\x06pinned\x18\a \x03(\v2\x13.chat.PinnedMessageR\x06pinned\x12\x19\n" +
+	"\blast_seq\x18\b \x01(\x03R\alastSeq\x12&\n" +
+	"\x0flast_message_at\x18\t \x01(\tR\rlastMessageAt\x127\n" +
+	"\flast_message\x18\n" +
+	" \x01(\v2\x14.chat.MessagePreviewR\vlastMessage\x12%\n" +
+	"\x05state\x18\v \x01(\v2\x0f.chat.ChatStateR\x05state\x12\x1f\n" +
+	"\vmessage_ttl\x18\f \x01(\x03R\n" +
+	"messageTtl\x12\x16\n" +
+	"\x06handle\x18\r \x01(\tR\x06handle\x12)\n" +
+	"\x10subscriber_count\x18\x0e \x01(\x03R\x0fsubscriberCount\x12*\n" +
+	"\x11slow_mode_seconds\x18\x0f \x01(\x03R\x0fslowModeSeconds\x12\x16\n" +
+	"\x06secret\x18\x10 \x01(\bR\x06secret\"\x9f\x02\n" +
+	"\n" +
+	"InviteLink\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x1f\n" +
+	"\vusage_limit\x18\x06 \x01(\x03R\n" +
+	"usageLimit\x12\x1f\n" +
+	"\vusage_count\x18\a \x01(\x03R\n" +
+	"usageCount\x12+\n" +
+	"\x11requires_approval\x18\b \x01(\bR\x10requiresApproval\x12\x18\n" +
+	"\arevoked\x18\t \x01(\bR\arevoked\"\xa7\x01\n" +
+	"\vJoinRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vinvite_code\x18\x04 \x01(\tR\n" +
+	"inviteCode\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\xde\x02\n" +
+	"\x0eModerationItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x04 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tauthor_id\x18\x05 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12\x18\n" +
+	"\areasons\x18\a \x03(\tR\areasons\x120\n" +
+	"\areports\x18\b \x03(\v2\x16.chat.ModerationReportR\areports\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\v \x01(\tR\tdecidedBy\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\f \x01(\tR\tdecidedAt\"b\n" +
+	"\x10ModerationReport\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"Q\n" +
+	"\x11ChannelSubscriber\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rsubscribed_at\x18\x02 \x01(\tR\fsubscribedAt\"\xb0\x01\n" +
+	"\x0eMessagePreview\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\adeleted\x18\a \x01(\bR\adeleted\"\xc1\x01\n" +
+	"\tChatState\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x16\n" +
+	"\x06pinned\x18\x02 \x01(\bR\x06pinned\x12\x1a\n" +
+	"\barchived\x18\x03 \x01(\bR\barchived\x12\x1f\n" +
+	"\vmuted_until\x18\x04 \x01(\x03R\n" +
+	"mutedUntil\x12#\n" +
+	"\rmarked_unread\x18\x05 \x01(\bR\fmarkedUnread\x12!\n" +
+	"\funread_count\x18\x06 \x01(\x03R\vunreadCount\"m\n" +
+	"\vFolderRules\x12\x14\n" +
+	"\x05kinds\x18\x01 \x03(\tR\x05kinds\x12\x1a\n" +
+	"\bcontacts\x18\x02 \x03(\tR\bcontacts\x12\x14\n" +
+	"\x05muted\x18\x03 \x01(\tR\x05muted\x12\x16\n" +
+	"\x06unread\x18\x04 \x01(\tR\x06unread\"\xb6\x02\n" +
+	"\n" +
+	"ChatFolder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x12(\n" +
+	"\x10include_chat_ids\x18\x04 \x03(\tR\x0eincludeChatIds\x12(\n" +
+	"\x10exclude_chat_ids\x18\x05 \x03(\tR\x0eexcludeChatIds\x12'\n" +
+	"\x05rules\x18\x06 \x01(\v2\x11.chat.FolderRulesR\x05rules\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12!\n" +
+	"\funread_chats\x18\b \x01(\x03R\vunreadChats\x12'\n" +
+	"\x0funread_messages\x18\t \x01(\x03R\x0eunreadMessages\"\x91\x01\n" +
+	"\rPinnedMessage\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tpinned_by\x18\x02 \x01(\tR\bpinnedBy\x12\x1b\n" +
+	"\tpinned_at\x18\x03 \x01(\tR\bpinnedAt\x12'\n" +
+	"\amessage\x18\x04 \x01(\v2\r.chat.MessageR\amessage\"\xfd\x04\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1b\n" +
@@ -1613,28 +9083,201 @@ const file_chat_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x18\n" +
-	"\adeleted\x18\b \x01(\bR\adeleted\"p\n" +
+	"\adeleted\x18\b \x01(\bR\adeleted\x12\x12\n" +
+	"\x04type\x18\t \x01(\tR\x04type\x12)\n" +
+	"\x06system\x18\n" +
+	" \x01(\v2\x11.chat.SystemEventR\x06system\x12\x16\n" +
+	"\x06edited\x18\v \x01(\bR\x06edited\x12\x1d\n" +
+	"\n" +
+	"edit_count\x18\f \x01(\x05R\teditCount\x12/\n" +
+	"\bentities\x18\r \x03(\v2\x13.chat.MessageEntityR\bentities\x12\x10\n" +
+	"\x03seq\x18\x0e \x01(\x03R\x03seq\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x0f \x01(\tR\texpiresAt\x12\x1e\n" +
+	"\x04poll\x18\x10 \x01(\v2\n" +
+	".chat.PollR\x04poll\x12\x14\n" +
+	"\x05views\x18\x11 \x01(\x03R\x05views\x12#\n" +
+	"\rimported_from\x18\x12 \x01(\tR\fimportedFrom\x12\x12\n" +
+	"\x04held\x18\x13 \x01(\bR\x04held\x12(\n" +
+	"\x10sender_device_id\x18\x14 \x01(\tR\x0esenderDeviceId\x12,\n" +
+	"\tenvelopes\x18\x15 \x03(\v2\x0e.chat.EnvelopeR\tenvelopes\"\x8c\x02\n" +
+	"\x04Poll\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1a\n" +
+	"\bquestion\x18\x02 \x01(\tR\bquestion\x12*\n" +
+	"\aoptions\x18\x03 \x03(\v2\x10.chat.PollOptionR\aoptions\x12'\n" +
+	"\x0fmultiple_choice\x18\x04 \x01(\bR\x0emultipleChoice\x12\x1c\n" +
+	"\tanonymous\x18\x05 \x01(\bR\tanonymous\x12\x1b\n" +
+	"\tcloses_at\x18\x06 \x01(\x03R\bclosesAt\x12\x16\n" +
+	"\x06closed\x18\a \x01(\bR\x06closed\x12!\n" +
+	"\ftotal_voters\x18\b \x01(\x03R\vtotalVoters\"F\n" +
+	"\n" +
+	"PollOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05votes\x18\x03 \x01(\x03R\x05votes\"J\n" +
+	"\x10PollOptionVoters\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\x05R\boptionId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"\xfe\x01\n" +
+	"\x10ScheduledMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12!\n" +
+	"\x05media\x18\x05 \x03(\v2\v.chat.MediaR\x05media\x12\x17\n" +
+	"\asend_at\x18\x06 \x01(\x03R\x06sendAt\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\b \x01(\tR\tmessageId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\xc4\x01\n" +
+	"\tReadState\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\rlast_read_seq\x18\x02 \x01(\x03R\vlastReadSeq\x12 \n" +
+	"\flast_read_at\x18\x03 \x01(\tR\n" +
+	"lastReadAt\x12,\n" +
+	"\x12last_delivered_seq\x18\x04 \x01(\x03R\x10lastDeliveredSeq\x12*\n" +
+	"\x11last_delivered_at\x18\x05 \x01(\tR\x0flastDeliveredAt\"l\n" +
+	"\rMessageEntity\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x05R\x06length\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"\xdc\x01\n" +
+	"\x05Draft\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12-\n" +
+	"\x13reply_to_message_id\x18\x03 \x01(\tR\x10replyToMessageId\x12!\n" +
+	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12\x1b\n" +
+	"\tdevice_id\x18\x06 \x01(\tR\bdeviceId\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\"\xdb\x02\n" +
+	"\x06Update\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
+	"\achat_id\x18\x03 \x01(\tR\x06chatId\x12)\n" +
+	"\bmessages\x18\x04 \x03(\v2\r.chat.MessageR\bmessages\x12\x1f\n" +
+	"\vmessage_ids\x18\x05 \x03(\tR\n" +
+	"messageIds\x12\x19\n" +
+	"\bactor_id\x18\x06 \x01(\tR\aactorId\x12\x19\n" +
+	"\bread_seq\x18\a \x01(\x03R\areadSeq\x12\x16\n" +
+	"\x06action\x18\b \x01(\tR\x06action\x12\x19\n" +
+	"\buser_ids\x18\t \x03(\tR\auserIds\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\tfolder_id\x18\v \x01(\tR\bfolderId\x12!\n" +
+	"\x05draft\x18\f \x01(\v2\v.chat.DraftR\x05draft\"w\n" +
+	"\fTypingStatus\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"}\n" +
+	"\aMention\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\xc3\x01\n" +
+	"\x0fMessageRevision\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12!\n" +
+	"\x05media\x18\x04 \x03(\v2\v.chat.MediaR\x05media\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1f\n" +
+	"\vreplaced_at\x18\x06 \x01(\tR\n" +
+	"replacedAt\"x\n" +
+	"\vSystemEvent\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"p\n" +
 	"\x05Media\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x10\n" +
 	"\x03url\x18\x03 \x01(\tR\x03url\x12\x12\n" +
 	"\x04mime\x18\x04 \x01(\tR\x04mime\x12\x1d\n" +
 	"\n" +
-	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes2\xf6\x06\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes2\xdd$\n" +
 	"\vChatService\x12E\n" +
-	"\x10CreateDirectChat\x12\x1d.chat.CreateDirectChatRequest\x1a\x12.chat.ChatResponse\x12C\n" +
+	"\x10CreateDirectChat\x12\x1d.chat.CreateDirectChatRequest\x1a\x12.chat.ChatResponse\x12E\n" +
+	"\x10CreateSecretChat\x12\x1d.chat.CreateSecretChatRequest\x1a\x12.chat.ChatResponse\x12C\n" +
 	"\x0fCreateGroupChat\x12\x1c.chat.CreateGroupChatRequest\x1a\x12.chat.ChatResponse\x12C\n" +
-	"\x0fUpdateGroupChat\x12\x1c.chat.UpdateGroupChatRequest\x1a\x12.chat.ChatResponse\x123\n" +
+	"\x0fUpdateGroupChat\x12\x1c.chat.UpdateGroupChatRequest\x1a\x12.chat.ChatResponse\x12?\n" +
+	"\rSetMessageTTL\x12\x1a.chat.SetMessageTTLRequest\x1a\x12.chat.ChatResponse\x12;\n" +
+	"\vSetSlowMode\x12\x18.chat.SetSlowModeRequest\x1a\x12.chat.ChatResponse\x123\n" +
 	"\aGetChat\x12\x14.chat.GetChatRequest\x1a\x12.chat.ChatResponse\x12<\n" +
-	"\tListChats\x12\x16.chat.ListChatsRequest\x1a\x17.chat.ListChatsResponse\x12>\n" +
+	"\tListChats\x12\x16.chat.ListChatsRequest\x1a\x17.chat.ListChatsResponse\x128\n" +
+	"\aPinChat\x12\x14.chat.PinChatRequest\x1a\x17.chat.ChatStateResponse\x12@\n" +
+	"\vArchiveChat\x12\x18.chat.ArchiveChatRequest\x1a\x17.chat.ChatStateResponse\x12:\n" +
+	"\bMuteChat\x12\x15.chat.MuteChatRequest\x1a\x17.chat.ChatStateResponse\x12F\n" +
+	"\x0eMarkChatUnread\x12\x1b.chat.MarkChatUnreadRequest\x1a\x17.chat.ChatStateResponse\x129\n" +
+	"\fCreateFolder\x12\x13.chat.FolderRequest\x1a\x14.chat.FolderResponse\x129\n" +
+	"\fUpdateFolder\x12\x13.chat.FolderRequest\x1a\x14.chat.FolderResponse\x12E\n" +
+	"\fDeleteFolder\x12\x19.chat.DeleteFolderRequest\x1a\x1a.chat.DeleteFolderResponse\x12H\n" +
+	"\x0eReorderFolders\x12\x1b.chat.ReorderFoldersRequest\x1a\x19.chat.ListFoldersResponse\x12B\n" +
+	"\vListFolders\x12\x18.chat.ListFoldersRequest\x1a\x19.chat.ListFoldersResponse\x12K\n" +
+	"\x10CreateInviteLink\x12\x1d.chat.CreateInviteLinkRequest\x1a\x18.chat.InviteLinkResponse\x12Q\n" +
+	"\x10RevokeInviteLink\x12\x1d.chat.RevokeInviteLinkRequest\x1a\x1e.chat.RevokeInviteLinkResponse\x12E\n" +
+	"\fJoinByInvite\x12\x19.chat.JoinByInviteRequest\x1a\x1a.chat.JoinByInviteResponse\x12Q\n" +
+	"\x10ListJoinRequests\x12\x1d.chat.ListJoinRequestsRequest\x1a\x1e.chat.ListJoinRequestsResponse\x12H\n" +
+	"\x12ApproveJoinRequest\x12\x1e.chat.DecideJoinRequestRequest\x1a\x12.chat.ChatResponse\x12T\n" +
+	"\x11RejectJoinRequest\x12\x1e.chat.DecideJoinRequestRequest\x1a\x1f.chat.RejectJoinRequestResponse\x12?\n" +
+	"\rCreateChannel\x12\x1a.chat.CreateChannelRequest\x1a\x12.chat.ChatResponse\x12I\n" +
+	"\x12GetChannelByHandle\x12\x1f.chat.GetChannelByHandleRequest\x1a\x12.chat.ChatResponse\x12E\n" +
+	"\x10SubscribeChannel\x12\x1d.chat.SubscribeChannelRequest\x1a\x12.chat.ChatResponse\x12W\n" +
+	"\x12UnsubscribeChannel\x12\x1f.chat.UnsubscribeChannelRequest\x1a .chat.UnsubscribeChannelResponse\x12c\n" +
+	"\x16ListChannelSubscribers\x12#.chat.ListChannelSubscribersRequest\x1a$.chat.ListChannelSubscribersResponse\x12C\n" +
+	"\x0fSetChannelAdmin\x12\x1c.chat.SetChannelAdminRequest\x1a\x12.chat.ChatResponse\x12E\n" +
+	"\fViewMessages\x12\x19.chat.ViewMessagesRequest\x1a\x1a.chat.ViewMessagesResponse\x12>\n" +
 	"\vSendMessage\x12\x18.chat.SendMessageRequest\x1a\x15.chat.MessageResponse\x12B\n" +
-	"\rUpdateMessage\x12\x1a.chat.UpdateMessageRequest\x1a\x15.chat.MessageResponse\x12H\n" +
+	"\rUpdateMessage\x12\x1a.chat.UpdateMessageRequest\x1a\x15.chat.MessageResponse\x12]\n" +
+	"\x14ListMessageRevisions\x12!.chat.ListMessageRevisionsRequest\x1a\".chat.ListMessageRevisionsResponse\x12O\n" +
+	"\x0fScheduleMessage\x12\x1c.chat.ScheduleMessageRequest\x1a\x1e.chat.ScheduledMessageResponse\x12`\n" +
+	"\x15ListScheduledMessages\x12\".chat.ListScheduledMessagesRequest\x1a#.chat.ListScheduledMessagesResponse\x12]\n" +
+	"\x16UpdateScheduledMessage\x12#.chat.UpdateScheduledMessageRequest\x1a\x1e.chat.ScheduledMessageResponse\x12c\n" +
+	"\x16CancelScheduledMessage\x12#.chat.CancelScheduledMessageRequest\x1a$.chat.CancelScheduledMessageResponse\x12-\n" +
+	"\x04Vote\x12\x11.chat.VoteRequest\x1a\x12.chat.PollResponse\x12;\n" +
+	"\vRetractVote\x12\x18.chat.RetractVoteRequest\x1a\x12.chat.PollResponse\x127\n" +
+	"\tClosePoll\x12\x16.chat.ClosePollRequest\x1a\x12.chat.PollResponse\x12K\n" +
+	"\x0eGetPollResults\x12\x1b.chat.GetPollResultsRequest\x1a\x1c.chat.GetPollResultsResponse\x12H\n" +
 	"\rDeleteMessage\x12\x1a.chat.DeleteMessageRequest\x1a\x1b.chat.DeleteMessageResponse\x12E\n" +
 	"\fListMessages\x12\x19.chat.ListMessagesRequest\x1a\x1a.chat.ListMessagesResponse\x129\n" +
-	"\bMarkRead\x12\x15.chat.MarkReadRequest\x1a\x16.chat.MarkReadResponse\x12B\n" +
+	"\bMarkRead\x12\x15.chat.MarkReadRequest\x1a\x16.chat.MarkReadResponse\x12H\n" +
+	"\rMarkDelivered\x12\x1a.chat.MarkDeliveredRequest\x1a\x1b.chat.MarkDeliveredResponse\x12K\n" +
+	"\x0eGetUnreadCount\x12\x1b.chat.GetUnreadCountRequest\x1a\x1c.chat.GetUnreadCountResponse\x12E\n" +
+	"\fGetReadState\x12\x19.chat.GetReadStateRequest\x1a\x1a.chat.GetReadStateResponse\x12E\n" +
+	"\fListMentions\x12\x19.chat.ListMentionsRequest\x1a\x1a.chat.ListMentionsResponse\x12B\n" +
 	"\vToggleSaved\x12\x18.chat.ToggleSavedRequest\x1a\x19.chat.ToggleSavedResponse\x12<\n" +
 	"\tListSaved\x12\x16.chat.ListSavedRequest\x1a\x17.chat.ListSavedResponse\x12Q\n" +
-	"\x10ListReadMessages\x12\x1d.chat.ListReadMessagesRequest\x1a\x1e.chat.ListReadMessagesResponseB\bZ\x06./chatb\x06proto3"
+	"\x10ListReadMessages\x12\x1d.chat.ListReadMessagesRequest\x1a\x1e.chat.ListReadMessagesResponse\x129\n" +
+	"\n" +
+	"PinMessage\x12\x17.chat.PinMessageRequest\x1a\x12.chat.ChatResponse\x12=\n" +
+	"\fUnpinMessage\x12\x19.chat.UnpinMessageRequest\x1a\x12.chat.ChatResponse\x12?\n" +
+	"\n" +
+	"ListPinned\x12\x17.chat.ListPinnedRequest\x1a\x18.chat.ListPinnedResponse\x12<\n" +
+	"\tSetTyping\x12\x16.chat.SetTypingRequest\x1a\x17.chat.SetTypingResponse\x12?\n" +
+	"\n" +
+	"ListTyping\x12\x17.chat.ListTypingRequest\x1a\x18.chat.ListTypingResponse\x128\n" +
+	"\tSaveDraft\x12\x16.chat.SaveDraftRequest\x1a\x13.chat.DraftResponse\x12<\n" +
+	"\tGetDrafts\x12\x16.chat.GetDraftsRequest\x1a\x17.chat.GetDraftsResponse\x12:\n" +
+	"\n" +
+	"ClearDraft\x12\x17.chat.ClearDraftRequest\x1a\x13.chat.DraftResponse\x12?\n" +
+	"\n" +
+	"GetUpdates\x12\x17.chat.GetUpdatesRequest\x1a\x18.chat.GetUpdatesResponse\x12F\n" +
+	"\x0eSubscribeChats\x12\x1b.chat.SubscribeChatsRequest\x1a\x15.chat.ChatStreamEvent0\x01\x12>\n" +
+	"\n" +
+	"ExportChat\x12\x17.chat.ExportChatRequest\x1a\x15.chat.ExportChatChunk0\x01\x12A\n" +
+	"\n" +
+	"ImportChat\x12\x17.chat.ImportChatRequest\x1a\x18.chat.ImportChatResponse(\x01\x12H\n" +
+	"\rReportMessage\x12\x1a.chat.ReportMessageRequest\x1a\x1b.chat.ReportMessageResponse\x12Z\n" +
+	"\x13ListModerationQueue\x12 .chat.ListModerationQueueRequest\x1a!.chat.ListModerationQueueResponse\x12Q\n" +
+	"\x11ResolveModeration\x12\x1e.chat.ResolveModerationRequest\x1a\x1c.chat.ModerationItemResponseB\bZ\x06./chatb\x06proto3"
 
 var (
 	file_chat_proto_rawDescOnce sync.Once
@@ -1648,75 +9291,340 @@ func file_chat_proto_rawDescGZIP() []byte {
 	return file_chat_proto_rawDescData
 }
 
-var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 134)
 var file_chat_proto_goTypes = []any{
-	(*CreateDirectChatRequest)(nil),  // 0: chat.CreateDirectChatRequest
-	(*CreateGroupChatRequest)(nil),   // 1: chat.CreateGroupChatRequest
-	(*UpdateGroupChatRequest)(nil),   // 2: chat.UpdateGroupChatRequest
-	(*GetChatRequest)(nil),           // 3: chat.GetChatRequest
-	(*ListChatsRequest)(nil),         // 4: chat.ListChatsRequest
-	(*SendMessageRequest)(nil),       // 5: chat.SendMessageRequest
-	(*UpdateMessageRequest)(nil),     // 6: chat.UpdateMessageRequest
-	(*DeleteMessageRequest)(nil),     // 7: chat.DeleteMessageRequest
-	(*ListMessagesRequest)(nil),      // 8: chat.ListMessagesRequest
-	(*MarkReadRequest)(nil),          // 9: chat.MarkReadRequest
-	(*ToggleSavedRequest)(nil),       // 10: chat.ToggleSavedRequest
-	(*ListSavedRequest)(nil),         // 11: chat.ListSavedRequest
-	(*ListReadMessagesRequest)(nil),  // 12: chat.ListReadMessagesRequest
-	(*ChatResponse)(nil),             // 13: chat.ChatResponse
-	(*ListChatsResponse)(nil),        // 14: chat.ListChatsResponse
-	(*MessageResponse)(nil),          // 15: chat.MessageResponse
-	(*DeleteMessageResponse)(nil),    // 16: chat.DeleteMessageResponse
-	(*ListMessagesResponse)(nil),     // 17: chat.ListMessagesResponse
-	(*MarkReadResponse)(nil),         // 18: chat.MarkReadResponse
-	(*ToggleSavedResponse)(nil),      // 19: chat.ToggleSavedResponse
-	(*ListSavedResponse)(nil),        // 20: chat.ListSavedResponse
-	(*ListReadMessagesResponse)(nil), // 21: chat.ListReadMessagesResponse
-	(*Chat)(nil),                     // 22: chat.Chat
-	(*Message)(nil),                  // 23: chat.Message
-	(*Media)(nil),                    // 24: chat.Media
+	(*CreateDirectChatRequest)(nil),        // 0: chat.CreateDirectChatRequest
+	(*CreateSecretChatRequest)(nil),        // 1: chat.CreateSecretChatRequest
+	(*CreateGroupChatRequest)(nil),         // 2: chat.CreateGroupChatRequest
+	(*UpdateGroupChatRequest)(nil),         // 3: chat.UpdateGroupChatRequest
+	(*SetMessageTTLRequest)(nil),           // 4: chat.SetMessageTTLRequest
+	(*SetSlowModeRequest)(nil),             // 5: chat.SetSlowModeRequest
+	(*ReportMessageRequest)(nil),           // 6: chat.ReportMessageRequest
+	(*ListModerationQueueRequest)(nil),     // 7: chat.ListModerationQueueRequest
+	(*ResolveModerationRequest)(nil),       // 8: chat.ResolveModerationRequest
+	(*GetChatRequest)(nil),                 // 9: chat.GetChatRequest
+	(*ListChatsRequest)(nil),               // 10: chat.ListChatsRequest
+	(*PinChatRequest)(nil),                 // 11: chat.PinChatRequest
+	(*ArchiveChatRequest)(nil),             // 12: chat.ArchiveChatRequest
+	(*MuteChatRequest)(nil),                // 13: chat.MuteChatRequest
+	(*MarkChatUnreadRequest)(nil),          // 14: chat.MarkChatUnreadRequest
+	(*CreateInviteLinkRequest)(nil),        // 15: chat.CreateInviteLinkRequest
+	(*RevokeInviteLinkRequest)(nil),        // 16: chat.RevokeInviteLinkRequest
+	(*JoinByInviteRequest)(nil),            // 17: chat.JoinByInviteRequest
+	(*ListJoinRequestsRequest)(nil),        // 18: chat.ListJoinRequestsRequest
+	(*DecideJoinRequestRequest)(nil),       // 19: chat.DecideJoinRequestRequest
+	(*CreateChannelRequest)(nil),           // 20: chat.CreateChannelRequest
+	(*GetChannelByHandleRequest)(nil),      // 21: chat.GetChannelByHandleRequest
+	(*SubscribeChannelRequest)(nil),        // 22: chat.SubscribeChannelRequest
+	(*UnsubscribeChannelRequest)(nil),      // 23: chat.UnsubscribeChannelRequest
+	(*ListChannelSubscribersRequest)(nil),  // 24: chat.ListChannelSubscribersRequest
+	(*SetChannelAdminRequest)(nil),         // 25: chat.SetChannelAdminRequest
+	(*ViewMessagesRequest)(nil),            // 26: chat.ViewMessagesRequest
+	(*SendMessageRequest)(nil),             // 27: chat.SendMessageRequest
+	(*Envelope)(nil),                       // 28: chat.Envelope
+	(*PollInput)(nil),                      // 29: chat.PollInput
+	(*UpdateMessageRequest)(nil),           // 30: chat.UpdateMessageRequest
+	(*ListMessageRevisionsRequest)(nil),    // 31: chat.ListMessageRevisionsRequest
+	(*ScheduleMessageRequest)(nil),         // 32: chat.ScheduleMessageRequest
+	(*ListScheduledMessagesRequest)(nil),   // 33: chat.ListScheduledMessagesRequest
+	(*UpdateScheduledMessageRequest)(nil),  // 34: chat.UpdateScheduledMessageRequest
+	(*CancelScheduledMessageRequest)(nil),  // 35: chat.CancelScheduledMessageRequest
+	(*VoteRequest)(nil),                    // 36: chat.VoteRequest
+	(*RetractVoteRequest)(nil),             // 37: chat.RetractVoteRequest
+	(*ClosePollRequest)(nil),               // 38: chat.ClosePollRequest
+	(*GetPollResultsRequest)(nil),          // 39: chat.GetPollResultsRequest
+	(*DeleteMessageRequest)(nil),           // 40: chat.DeleteMessageRequest
+	(*ListMessagesRequest)(nil),            // 41: chat.ListMessagesRequest
+	(*MarkReadRequest)(nil),                // 42: chat.MarkReadRequest
+	(*MarkDeliveredRequest)(nil),           // 43: chat.MarkDeliveredRequest
+	(*GetUnreadCountRequest)(nil),          // 44: chat.GetUnreadCountRequest
+	(*GetReadStateRequest)(nil),            // 45: chat.GetReadStateRequest
+	(*ListMentionsRequest)(nil),            // 46: chat.ListMentionsRequest
+	(*ToggleSavedRequest)(nil),             // 47: chat.ToggleSavedRequest
+	(*ListSavedRequest)(nil),               // 48: chat.ListSavedRequest
+	(*ListReadMessagesRequest)(nil),        // 49: chat.ListReadMessagesRequest
+	(*PinMessageRequest)(nil),              // 50: chat.PinMessageRequest
+	(*UnpinMessageRequest)(nil),            // 51: chat.UnpinMessageRequest
+	(*ListPinnedRequest)(nil),              // 52: chat.ListPinnedRequest
+	(*SetTypingRequest)(nil),               // 53: chat.SetTypingRequest
+	(*ListTypingRequest)(nil),              // 54: chat.ListTypingRequest
+	(*SaveDraftRequest)(nil),               // 55: chat.SaveDraftRequest
+	(*GetDraftsRequest)(nil),               // 56: chat.GetDraftsRequest
+	(*ClearDraftRequest)(nil),              // 57: chat.ClearDraftRequest
+	(*FolderRequest)(nil),                  // 58: chat.FolderRequest
+	(*DeleteFolderRequest)(nil),            // 59: chat.DeleteFolderRequest
+	(*ReorderFoldersRequest)(nil),          // 60: chat.ReorderFoldersRequest
+	(*ListFoldersRequest)(nil),             // 61: chat.ListFoldersRequest
+	(*GetUpdatesRequest)(nil),              // 62: chat.GetUpdatesRequest
+	(*SubscribeChatsRequest)(nil),          // 63: chat.SubscribeChatsRequest
+	(*ExportChatRequest)(nil),              // 64: chat.ExportChatRequest
+	(*ImportChatRequest)(nil),              // 65: chat.ImportChatRequest
+	(*ChatResponse)(nil),                   // 66: chat.ChatResponse
+	(*ListChatsResponse)(nil),              // 67: chat.ListChatsResponse
+	(*ChatStateResponse)(nil),              // 68: chat.ChatStateResponse
+	(*MessageResponse)(nil),                // 69: chat.MessageResponse
+	(*ListMessageRevisionsResponse)(nil),   // 70: chat.ListMessageRevisionsResponse
+	(*ScheduledMessageResponse)(nil),       // 71: chat.ScheduledMessageResponse
+	(*ListScheduledMessagesResponse)(nil),  // 72: chat.ListScheduledMessagesResponse
+	(*PollResponse)(nil),                   // 73: chat.PollResponse
+	(*GetPollResultsResponse)(nil),         // 74: chat.GetPollResultsResponse
+	(*InviteLinkResponse)(nil),             // 75: chat.InviteLinkResponse
+	(*RevokeInviteLinkResponse)(nil),       // 76: chat.RevokeInviteLinkResponse
+	(*JoinByInviteResponse)(nil),           // 77: chat.JoinByInviteResponse
+	(*ReportMessageResponse)(nil),          // 78: chat.ReportMessageResponse
+	(*ListModerationQueueResponse)(nil),    // 79: chat.ListModerationQueueResponse
+	(*ModerationItemResponse)(nil),         // 80: chat.ModerationItemResponse
+	(*ListJoinRequestsResponse)(nil),       // 81: chat.ListJoinRequestsResponse
+	(*RejectJoinRequestResponse)(nil),      // 82: chat.RejectJoinRequestResponse
+	(*UnsubscribeChannelResponse)(nil),     // 83: chat.UnsubscribeChannelResponse
+	(*ListChannelSubscribersResponse)(nil), // 84: chat.ListChannelSubscribersResponse
+	(*ViewMessagesResponse)(nil),           // 85: chat.ViewMessagesResponse
+	(*CancelScheduledMessageResponse)(nil), // 86: chat.CancelScheduledMessageResponse
+	(*DeleteMessageResponse)(nil),          // 87: chat.DeleteMessageResponse
+	(*ListMessagesResponse)(nil),           // 88: chat.ListMessagesResponse
+	(*MarkReadResponse)(nil),               // 89: chat.MarkReadResponse
+	(*MarkDeliveredResponse)(nil),          // 90: chat.MarkDeliveredResponse
+	(*GetUnreadCountResponse)(nil),         // 91: chat.GetUnreadCountResponse
+	(*GetReadStateResponse)(nil),           // 92: chat.GetReadStateResponse
+	(*ListMentionsResponse)(nil),           // 93: chat.ListMentionsResponse
+	(*ToggleSavedResponse)(nil),            // 94: chat.ToggleSavedResponse
+	(*ListSavedResponse)(nil),              // 95: chat.ListSavedResponse
+	(*ListReadMessagesResponse)(nil),       // 96: chat.ListReadMessagesResponse
+	(*ListPinnedResponse)(nil),             // 97: chat.ListPinnedResponse
+	(*SetTypingResponse)(nil),              // 98: chat.SetTypingResponse
+	(*ListTypingResponse)(nil),             // 99: chat.ListTypingResponse
+	(*DraftResponse)(nil),                  // 100: chat.DraftResponse
+	(*GetDraftsResponse)(nil),              // 101: chat.GetDraftsResponse
+	(*FolderResponse)(nil),                 // 102: chat.FolderResponse
+	(*DeleteFolderResponse)(nil),           // 103: chat.DeleteFolderResponse
+	(*ListFoldersResponse)(nil),            // 104: chat.ListFoldersResponse
+	(*GetUpdatesResponse)(nil),             // 105: chat.GetUpdatesResponse
+	(*ChatStreamEvent)(nil),                // 106: chat.ChatStreamEvent
+	(*ExportChatChunk)(nil),                // 107: chat.ExportChatChunk
+	(*ImportChatResponse)(nil),             // 108: chat.ImportChatResponse
+	(*Chat)(nil),                           // 109: chat.Chat
+	(*InviteLink)(nil),                     // 110: chat.InviteLink
+	(*JoinRequest)(nil),                    // 111: chat.JoinRequest
+	(*ModerationItem)(nil),                 // 112: chat.ModerationItem
+	(*ModerationReport)(nil),               // 113: chat.ModerationReport
+	(*ChannelSubscriber)(nil),              // 114: chat.ChannelSubscriber
+	(*MessagePreview)(nil),                 // 115: chat.MessagePreview
+	(*ChatState)(nil),                      // 116: chat.ChatState
+	(*FolderRules)(nil),                    // 117: chat.FolderRules
+	(*ChatFolder)(nil),                     // 118: chat.ChatFolder
+	(*PinnedMessage)(nil),                  // 119: chat.PinnedMessage
+	(*Message)(nil),                        // 120: chat.Message
+	(*Poll)(nil),                           // 121: chat.Poll
+	(*PollOption)(nil),                     // 122: chat.PollOption
+	(*PollOptionVoters)(nil),               // 123: chat.PollOptionVoters
+	(*ScheduledMessage)(nil),               // 124: chat.ScheduledMessage
+	(*ReadState)(nil),                      // 125: chat.ReadState
+	(*MessageEntity)(nil),                  // 126: chat.MessageEntity
+	(*Draft)(nil),                          // 127: chat.Draft
+	(*Update)(nil),                         // 128: chat.Update
+	(*TypingStatus)(nil),                   // 129: chat.TypingStatus
+	(*Mention)(nil),                        // 130: chat.Mention
+	(*MessageRevision)(nil),                // 131: chat.MessageRevision
+	(*SystemEvent)(nil),                    // 132: chat.SystemEvent
+	(*Media)(nil),                          // 133: chat.Media
 }
 var file_chat_proto_depIdxs = []int32{
-	24, // 0: chat.SendMessageRequest.media:type_name -> chat.Media
-	24, // 1: chat.UpdateMessageRequest.media:type_name -> chat.Media
-	22, // 2: chat.ChatResponse.chat:type_name -> chat.Chat
-	22, // 3: chat.ListChatsResponse.chats:type_name -> chat.Chat
-	23, // 4: chat.MessageResponse.message:type_name -> chat.Message
-	23, // 5: chat.ListMessagesResponse.messages:type_name -> chat.Message
-	23, // 6: chat.ListSavedResponse.messages:type_name -> chat.Message
-	23, // 7: chat.ListReadMessagesResponse.messages:type_name -> chat.Message
-	24, // 8: chat.Message.media:type_name -> chat.Media
-	0,  // 9: chat.ChatService.CreateDirectChat:input_type -> chat.CreateDirectChatRequest
-	1,  // 10: chat.ChatService.CreateGroupChat:input_type -> chat.CreateGroupChatRequest
-	2,  // 11: chat.ChatService.UpdateGroupChat:input_type -> chat.UpdateGroupChatRequest
-	3,  // 12: chat.ChatService.GetChat:input_type -> chat.GetChatRequest
-	4,  // 13: chat.ChatService.ListChats:input_type -> chat.ListChatsRequest
-	5,  // 14: chat.ChatService.SendMessage:input_type -> chat.SendMessageRequest
-	6,  // 15: chat.ChatService.UpdateMessage:input_type -> chat.UpdateMessageRequest
-	7,  // 16: chat.ChatService.DeleteMessage:input_type -> chat.DeleteMessageRequest
-	8,  // 17: chat.ChatService.ListMessages:input_type -> chat.ListMessagesRequest
-	9,  // 18: chat.ChatService.MarkRead:input_type -> chat.MarkReadRequest
-	10, // 19: chat.ChatService.ToggleSaved:input_type -> chat.ToggleSavedRequest
-	11, // 20: chat.ChatService.ListSaved:input_type -> chat.ListSavedRequest
-	12, // 21: chat.ChatService.ListReadMessages:input_type -> chat.ListReadMessagesRequest
-	13, // 22: chat.ChatService.CreateDirectChat:output_type -> chat.ChatResponse
-	13, // 23: chat.ChatService.CreateGroupChat:output_type -> chat.ChatResponse
-	13, // 24: chat.ChatService.UpdateGroupChat:output_type -> chat.ChatResponse
-	13, // 25: chat.ChatService.GetChat:output_type -> chat.ChatResponse
-	14, // 26: chat.ChatService.ListChats:output_type -> chat.ListChatsResponse
-	15, // 27: chat.ChatService.SendMessage:output_type -> chat.MessageResponse
-	15, // 28: chat.ChatService.UpdateMessage:output_type -> chat.MessageResponse
-	16, // 29: chat.ChatService.DeleteMessage:output_type -> chat.DeleteMessageResponse
-	17, // 30: chat.ChatService.ListMessages:output_type -> chat.ListMessagesResponse
-	18, // 31: chat.ChatService.MarkRead:output_type -> chat.MarkReadResponse
-	19, // 32: chat.ChatService.ToggleSaved:output_type -> chat.ToggleSavedResponse
-	20, // 33: chat.ChatService.ListSaved:output_type -> chat.ListSavedResponse
-	21, // 34: chat.ChatService.ListReadMessages:output_type -> chat.ListReadMessagesResponse
-	22, // [22:35] is the sub-list for method output_type
-	9,  // [9:22] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	133, // 0: chat.SendMessageRequest.media:type_name -> chat.Media
+	29,  // 1: chat.SendMessageRequest.poll:type_name -> chat.PollInput
+	28,  // 2: chat.SendMessageRequest.envelopes:type_name -> chat.Envelope
+	133, // 3: chat.UpdateMessageRequest.media:type_name -> chat.Media
+	133, // 4: chat.ScheduleMessageRequest.media:type_name -> chat.Media
+	133, // 5: chat.UpdateScheduledMessageRequest.media:type_name -> chat.Media
+	133, // 6: chat.SaveDraftRequest.media:type_name -> chat.Media
+	118, // 7: chat.FolderRequest.folder:type_name -> chat.ChatFolder
+	109, // 8: chat.ChatResponse.chat:type_name -> chat.Chat
+	109, // 9: chat.ListChatsResponse.chats:type_name -> chat.Chat
+	116, // 10: chat.ChatStateResponse.state:type_name -> chat.ChatState
+	120, // 11: chat.MessageResponse.message:type_name -> chat.Message
+	131, // 12: chat.ListMessageRevisionsResponse.revisions:type_name -> chat.MessageRevision
+	124, // 13: chat.ScheduledMessageResponse.scheduled:type_name -> chat.ScheduledMessage
+	124, // 14: chat.ListScheduledMessagesResponse.scheduled:type_name -> chat.ScheduledMessage
+	121, // 15: chat.PollResponse.poll:type_name -> chat.Poll
+	121, // 16: chat.GetPollResultsResponse.poll:type_name -> chat.Poll
+	123, // 17: chat.GetPollResultsResponse.voters:type_name -> chat.PollOptionVoters
+	110, // 18: chat.InviteLinkResponse.invite_link:type_name -> chat.InviteLink
+	109, // 19: chat.JoinByInviteResponse.chat:type_name -> chat.Chat
+	111, // 20: chat.JoinByInviteResponse.join_request:type_name -> chat.JoinRequest
+	112, // 21: chat.ListModerationQueueResponse.items:type_name -> chat.ModerationItem
+	112, // 22: chat.ModerationItemResponse.item:type_name -> chat.ModerationItem
+	111, // 23: chat.ListJoinRequestsResponse.requests:type_name -> chat.JoinRequest
+	114, // 24: chat.ListChannelSubscribersResponse.subscribers:type_name -> chat.ChannelSubscriber
+	120, // 25: chat.ListMessagesResponse.messages:type_name -> chat.Message
+	125, // 26: chat.GetReadStateResponse.read:type_name -> chat.ReadState
+	125, // 27: chat.GetReadStateResponse.delivered:type_name -> chat.ReadState
+	130, // 28: chat.ListMentionsResponse.mentions:type_name -> chat.Mention
+	120, // 29: chat.ListSavedResponse.messages:type_name -> chat.Message
+	120, // 30: chat.ListReadMessagesResponse.messages:type_name -> chat.Message
+	119, // 31: chat.ListPinnedResponse.pinned:type_name -> chat.PinnedMessage
+	129, // 32: chat.ListTypingResponse.statuses:type_name -> chat.TypingStatus
+	127, // 33: chat.DraftResponse.draft:type_name -> chat.Draft
+	127, // 34: chat.GetDraftsResponse.drafts:type_name -> chat.Draft
+	118, // 35: chat.FolderResponse.folder:type_name -> chat.ChatFolder
+	118, // 36: chat.ListFoldersResponse.folders:type_name -> chat.ChatFolder
+	128, // 37: chat.GetUpdatesResponse.updates:type_name -> chat.Update
+	128, // 38: chat.ChatStreamEvent.update:type_name -> chat.Update
+	129, // 39: chat.ChatStreamEvent.typing:type_name -> chat.TypingStatus
+	109, // 40: chat.ImportChatResponse.chat:type_name -> chat.Chat
+	110, // 41: chat.ImportChatResponse.invite_link:type_name -> chat.InviteLink
+	119, // 42: chat.Chat.pinned:type_name -> chat.PinnedMessage
+	115, // 43: chat.Chat.last_message:type_name -> chat.MessagePreview
+	116, // 44: chat.Chat.state:type_name -> chat.ChatState
+	113, // 45: chat.ModerationItem.reports:type_name -> chat.ModerationReport
+	117, // 46: chat.ChatFolder.rules:type_name -> chat.FolderRules
+	120, // 47: chat.PinnedMessage.message:type_name -> chat.Message
+	133, // 48: chat.Message.media:type_name -> chat.Media
+	132, // 49: chat.Message.system:type_name -> chat.SystemEvent
+	126, // 50: chat.Message.entities:type_name -> chat.MessageEntity
+	121, // 51: chat.Message.poll:type_name -> chat.Poll
+	28,  // 52: chat.Message.envelopes:type_name -> chat.Envelope
+	122, // 53: chat.Poll.options:type_name -> chat.PollOption
+	133, // 54: chat.ScheduledMessage.media:type_name -> chat.Media
+	133, // 55: chat.Draft.media:type_name -> chat.Media
+	120, // 56: chat.Update.messages:type_name -> chat.Message
+	127, // 57: chat.Update.draft:type_name -> chat.Draft
+	133, // 58: chat.MessageRevision.media:type_name -> chat.Media
+	0,   // 59: chat.ChatService.CreateDirectChat:input_type -> chat.CreateDirectChatRequest
+	1,   // 60: chat.ChatService.CreateSecretChat:input_type -> chat.CreateSecretChatRequest
+	2,   // 61: chat.ChatService.CreateGroupChat:input_type -> chat.CreateGroupChatRequest
+	3,   // 62: chat.ChatService.UpdateGroupChat:input_type -> chat.UpdateGroupChatRequest
+	4,   // 63: chat.ChatService.SetMessageTTL:input_type -> chat.SetMessageTTLRequest
+	5,   // 64: chat.ChatService.SetSlowMode:input_type -> chat.SetSlowModeRequest
+	9,   // 65: chat.ChatService.GetChat:input_type -> chat.GetChatRequest
+	10,  // 66: chat.ChatService.ListChats:input_type -> chat.ListChatsRequest
+	11,  // 67: chat.ChatService.PinChat:input_type -> chat.PinChatRequest
+	12,  // 68: chat.ChatService.ArchiveChat:input_type -> chat.ArchiveChatRequest
+	13,  // 69: chat.ChatService.MuteChat:input_type -> chat.MuteChatRequest
+	14,  // 70: chat.ChatService.MarkChatUnread:input_type -> chat.MarkChatUnreadRequest
+	58,  // 71: chat.ChatService.CreateFolder:input_type -> chat.FolderRequest
+	58,  // 72: chat.ChatService.UpdateFolder:input_type -> chat.FolderRequest
+	59,  // 73: chat.ChatService.DeleteFolder:input_type -> chat.DeleteFolderRequest
+	60,  // 74: chat.ChatService.ReorderFolders:input_type -> chat.ReorderFoldersRequest
+	61,  // 75: chat.ChatService.ListFolders:input_type -> chat.ListFoldersRequest
+	15,  // 76: chat.ChatService.CreateInviteLink:input_type -> chat.CreateInviteLinkRequest
+	16,  // 77: chat.ChatService.RevokeInviteLink:input_type -> chat.RevokeInviteLinkRequest
+	17,  // 78: chat.ChatService.JoinByInvite:input_type -> chat.JoinByInviteRequest
+	18,  // 79: chat.ChatService.ListJoinRequests:input_type -> chat.ListJoinRequestsRequest
+	19,  // 80: chat.ChatService.ApproveJoinRequest:input_type -> chat.DecideJoinRequestRequest
+	19,  // 81: chat.ChatService.RejectJoinRequest:input_type -> chat.DecideJoinRequestRequest
+	20,  // 82: chat.ChatService.CreateChannel:input_type -> chat.CreateChannelRequest
+	21,  // 83: chat.ChatService.GetChannelByHandle:input_type -> chat.GetChannelByHandleRequest
+	22,  // 84: chat.ChatService.SubscribeChannel:input_type -> chat.SubscribeChannelRequest
+	23,  // 85: chat.ChatService.UnsubscribeChannel:input_type -> chat.UnsubscribeChannelRequest
+	24,  // 86: chat.ChatService.ListChannelSubscribers:input_type -> chat.ListChannelSubscribersRequest
+	25,  // 87: chat.ChatService.SetChannelAdmin:input_type -> chat.SetChannelAdminRequest
+	26,  // 88: chat.ChatService.ViewMessages:input_type -> chat.ViewMessagesRequest
+	27,  // 89: chat.ChatService.SendMessage:input_type -> chat.SendMessageRequest
+	30,  // 90: chat.ChatService.UpdateMessage:input_type -> chat.UpdateMessageRequest
+	31,  // 91: chat.ChatService.ListMessageRevisions:input_type -> chat.ListMessageRevisionsRequest
+	32,  // 92: chat.ChatService.ScheduleMessage:input_type -> chat.ScheduleMessageRequest
+	33,  // 93: chat.ChatService.ListScheduledMessages:input_type -> chat.ListScheduledMessagesRequest
+	34,  // 94: chat.ChatService.UpdateScheduledMessage:input_type -> chat.UpdateScheduledMessageRequest
+	35,  // 95: chat.ChatService.CancelScheduledMessage:input_type -> chat.CancelScheduledMessageRequest
+	36,  // 96: chat.ChatService.Vote:input_type -> chat.VoteRequest
+	37,  // 97: chat.ChatService.RetractVote:input_type -> chat.RetractVoteRequest
+	38,  // 98: chat.ChatService.ClosePoll:input_type -> chat.ClosePollRequest
+	39,  // 99: chat.ChatService.GetPollResults:input_type -> chat.GetPollResultsRequest
+	40,  // 100: chat.ChatService.DeleteMessage:input_type -> chat.DeleteMessageRequest
+	41,  // 101: chat.ChatService.ListMessages:input_type -> chat.ListMessagesRequest
+	42,  // 102: chat.ChatService.MarkRead:input_type -> chat.MarkReadRequest
+	43,  // 103: chat.ChatService.MarkDelivered:input_type -> chat.MarkDeliveredRequest
+	44,  // 104: chat.ChatService.GetUnreadCount:input_type -> chat.GetUnreadCountRequest
+	45,  // 105: chat.ChatService.GetReadState:input_type -> chat.GetReadStateRequest
+	46,  // 106: chat.ChatService.ListMentions:input_type -> chat.ListMentionsRequest
+	47,  // 107: chat.ChatService.ToggleSaved:input_type -> chat.ToggleSavedRequest
+	48,  // 108: chat.ChatService.ListSaved:input_type -> chat.ListSavedRequest
+	49,  // 109: chat.ChatService.ListReadMessages:input_type -> chat.ListReadMessagesRequest
+	50,  // 110: chat.ChatService.PinMessage:input_type -> chat.PinMessageRequest
+	51,  // 111: chat.ChatService.UnpinMessage:input_type -> chat.UnpinMessageRequest
+	52,  // 112: chat.ChatService.ListPinned:input_type -> chat.ListPinnedRequest
+	53,  // 113: chat.ChatService.SetTyping:input_type -> chat.SetTypingRequest
+	54,  // 114: chat.ChatService.ListTyping:input_type -> chat.ListTypingRequest
+	55,  // 115: chat.ChatService.SaveDraft:input_type -> chat.SaveDraftRequest
+	56,  // 116: chat.ChatService.GetDrafts:input_type -> chat.GetDraftsRequest
+	57,  // 117: chat.ChatService.ClearDraft:input_type -> chat.ClearDraftRequest
+	62,  // 118: chat.ChatService.GetUpdates:input_type -> chat.GetUpdatesRequest
+	63,  // 119: chat.ChatService.SubscribeChats:input_type -> chat.SubscribeChatsRequest
+	64,  // 120: chat.ChatService.ExportChat:input_type -> chat.ExportChatRequest
+	65,  // 121: chat.ChatService.ImportChat:input_type -> chat.ImportChatRequest
+	6,   // 122: chat.ChatService.ReportMessage:input_type -> chat.ReportMessageRequest
+	7,   // 123: chat.ChatService.ListModerationQueue:input_type -> chat.ListModerationQueueRequest
+	8,   // 124: chat.ChatService.ResolveModeration:input_type -> chat.ResolveModerationRequest
+	66,  // 125: chat.ChatService.CreateDirectChat:output_type -> chat.ChatResponse
+	66,  // 126: chat.ChatService.CreateSecretChat:output_type -> chat.ChatResponse
+	66,  // 127: chat.ChatService.CreateGroupChat:output_type -> chat.ChatResponse
+	66,  // 128: chat.ChatService.UpdateGroupChat:output_type -> chat.ChatResponse
+	66,  // 129: chat.ChatService.SetMessageTTL:output_type -> chat.ChatResponse
+	66,  // 130: chat.ChatService.SetSlowMode:output_type -> chat.ChatResponse
+	66,  // 131: chat.ChatService.GetChat:output_type -> chat.ChatResponse
+	67,  // 132: chat.ChatService.ListChats:output_type -> chat.ListChatsResponse
+	68,  // 133: chat.ChatService.PinChat:output_type -> chat.ChatStateResponse
+	68,  // 134: chat.ChatService.ArchiveChat:output_type -> chat.ChatStateResponse
+	68,  // 135: chat.ChatService.MuteChat:output_type -> chat.ChatStateResponse
+	68,  // 136: chat.ChatService.MarkChatUnread:output_type -> chat.ChatStateResponse
+	102, // 137: chat.ChatService.CreateFolder:output_type -> chat.FolderResponse
+	102, // 138: chat.ChatService.UpdateFolder:output_type -> chat.FolderResponse
+	103, // 139: chat.ChatService.DeleteFolder:output_type -> chat.DeleteFolderResponse
+	104, // 140: chat.ChatService.ReorderFolders:output_type -> chat.ListFoldersResponse
+	104, // 141: chat.ChatService.ListFolders:output_type -> chat.ListFoldersResponse
+	75,  // 142: chat.ChatService.CreateInviteLink:output_type -> chat.InviteLinkResponse
+	76,  // 143: chat.ChatService.RevokeInviteLink:output_type -> chat.RevokeInviteLinkResponse
+	77,  // 144: chat.ChatService.JoinByInvite:output_type -> chat.JoinByInviteResponse
+	81,  // 145: chat.ChatService.ListJoinRequests:output_type -> chat.ListJoinRequestsResponse
+	66,  // 146: chat.ChatService.ApproveJoinRequest:output_type -> chat.ChatResponse
+	82,  // 147: chat.ChatService.RejectJoinRequest:output_type -> chat.RejectJoinRequestResponse
+	66,  // 148: chat.ChatService.CreateChannel:output_type -> chat.ChatResponse
+	66,  // 149: chat.ChatService.GetChannelByHandle:output_type -> chat.ChatResponse
+	66,  // 150: chat.ChatService.SubscribeChannel:output_type -> chat.ChatResponse
+	83,  // 151: chat.ChatService.UnsubscribeChannel:output_type -> chat.UnsubscribeChannelResponse
+	84,  // 152: chat.ChatService.ListChannelSubscribers:output_type -> chat.ListChannelSubscribersResponse
+	66,  // 153: chat.ChatService.SetChannelAdmin:output_type -> chat.ChatResponse
+	85,  // 154: chat.ChatService.ViewMessages:output_type -> chat.ViewMessagesResponse
+	69,  // 155: chat.ChatService.SendMessage:output_type -> chat.MessageResponse
+	69,  // 156: chat.ChatService.UpdateMessage:output_type -> chat.MessageResponse
+	70,  // 157: chat.ChatService.ListMessageRevisions:output_type -> chat.ListMessageRevisionsResponse
+	71,  // 158: chat.ChatService.ScheduleMessage:output_type -> chat.ScheduledMessageResponse
+	72,  // 159: chat.ChatService.ListScheduledMessages:output_type -> chat.ListScheduledMessagesResponse
+	71,  // 160: chat.ChatService.UpdateScheduledMessage:output_type -> chat.ScheduledMessageResponse
+	86,  // 161: chat.ChatService.CancelScheduledMessage:output_type -> chat.CancelScheduledMessageResponse
+	73,  // 162: chat.ChatService.Vote:output_type -> chat.PollResponse
+	73,  // 163: chat.ChatService.RetractVote:output_type -> chat.PollResponse
+	73,  // 164: chat.ChatService.ClosePoll:output_type -> chat.PollResponse
+	74,  // 165: chat.ChatService.GetPollResults:output_type -> chat.GetPollResultsResponse
+	87,  // 166: chat.ChatService.DeleteMessage:output_type -> chat.DeleteMessageResponse
+	88,  // 167: chat.ChatService.ListMessages:output_type -> chat.ListMessagesResponse
+	89,  // 168: chat.ChatService.MarkRead:output_type -> chat.MarkReadResponse
+	90,  // 169: chat.ChatService.MarkDelivered:output_type -> chat.MarkDeliveredResponse
+	91,  // 170: chat.ChatService.GetUnreadCount:output_type -> chat.GetUnreadCountResponse
+	92,  // 171: chat.ChatService.GetReadState:output_type -> chat.GetReadStateResponse
+	93,  // 172: chat.ChatService.ListMentions:output_type -> chat.ListMentionsResponse
+	94,  // 173: chat.ChatService.ToggleSaved:output_type -> chat.ToggleSavedResponse
+	95,  // 174: chat.ChatService.ListSaved:output_type -> chat.ListSavedResponse
+	96,  // 175: chat.ChatService.ListReadMessages:output_type -> chat.ListReadMessagesResponse
+	66,  // 176: chat.ChatService.PinMessage:output_type -> chat.ChatResponse
+	66,  // 177: chat.ChatService.UnpinMessage:output_type -> chat.ChatResponse
+	97,  // 178: chat.ChatService.ListPinned:output_type -> chat.ListPinnedResponse
+	98,  // 179: chat.ChatService.SetTyping:output_type -> chat.SetTypingResponse
+	99,  // 180: chat.ChatService.ListTyping:output_type -> chat.ListTypingResponse
+	100, // 181: chat.ChatService.SaveDraft:output_type -> chat.DraftResponse
+	101, // 182: chat.ChatService.GetDrafts:output_type -> chat.GetDraftsResponse
+	100, // 183: chat.ChatService.ClearDraft:output_type -> chat.DraftResponse
+	105, // 184: chat.ChatService.GetUpdates:output_type -> chat.GetUpdatesResponse
+	106, // 185: chat.ChatService.SubscribeChats:output_type -> chat.ChatStreamEvent
+	107, // 186: chat.ChatService.ExportChat:output_type -> chat.ExportChatChunk
+	108, // 187: chat.ChatService.ImportChat:output_type -> chat.ImportChatResponse
+	78,  // 188: chat.ChatService.ReportMessage:output_type -> chat.ReportMessageResponse
+	79,  // 189: chat.ChatService.ListModerationQueue:output_type -> chat.ListModerationQueueResponse
+	80,  // 190: chat.ChatService.ResolveModeration:output_type -> chat.ModerationItemResponse
+	125, // [125:191] is the sub-list for method output_type
+	59,  // [59:125] is the sub-list for method input_type
+	59,  // [59:59] is the sub-list for extension type_name
+	59,  // [59:59] is the sub-list for extension extendee
+	0,   // [0:59] is the sub-list for field type_name
 }
 
 func init() { file_chat_proto_init() }
@@ -1724,13 +9632,14 @@ func file_chat_proto_init() {
 	if File_chat_proto != nil {
 		return
 	}
+	file_chat_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_proto_rawDesc), len(file_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   134,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,19 +19,72 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChatService_CreateDirectChat_FullMethodName = "/chat.ChatService/CreateDirectChat"
-	ChatService_CreateGroupChat_FullMethodName  = "/chat.ChatService/CreateGroupChat"
-	ChatService_UpdateGroupChat_FullMethodName  = "/chat.ChatService/UpdateGroupChat"
-	ChatService_GetChat_FullMethodName          = "/chat.ChatService/GetChat"
-	ChatService_ListChats_FullMethodName        = "/chat.ChatService/ListChats"
-	ChatService_SendMessage_FullMethodName      = "/chat.ChatService/SendMessage"
-	ChatService_UpdateMessage_FullMethodName    = "/chat.ChatService/UpdateMessage"
-	ChatService_DeleteMessage_FullMethodName    = "/chat.ChatService/DeleteMessage"
-	ChatService_ListMessages_FullMethodName     = "/chat.ChatService/ListMessages"
-	ChatService_MarkRead_FullMethodName         = "/chat.ChatService/MarkRead"
-	ChatService_ToggleSaved_FullMethodName      = "/chat.ChatService/ToggleSaved"
-	ChatService_ListSaved_FullMethodName        = "/chat.ChatService/ListSaved"
-	ChatService_ListReadMessages_FullMethodName = "/chat.ChatService/ListReadMessages"
+	ChatService_CreateDirectChat_FullMethodName       = "/chat.ChatService/CreateDirectChat"
+	ChatService_CreateSecretChat_FullMethodName       = "/chat.ChatService/CreateSecretChat"
+	ChatService_CreateGroupChat_FullMethodName        = "/chat.ChatService/CreateGroupChat"
+	ChatService_UpdateGroupChat_FullMethodName        = "/chat.ChatService/UpdateGroupChat"
+	ChatService_SetMessageTTL_FullMethodName          = "/chat.ChatService/SetMessageTTL"
+	ChatService_SetSlowMode_FullMethodName            = "/chat.ChatService/SetSlowMode"
+	ChatService_GetChat_FullMethodName                = "/chat.ChatService/GetChat"
+	ChatService_ListChats_FullMethodName              = "/chat.ChatService/ListChats"
+	ChatService_PinChat_FullMethodName                = "/chat.ChatService/PinChat"
+	ChatService_ArchiveChat_FullMethodName            = "/chat.ChatService/ArchiveChat"
+	ChatService_MuteChat_FullMethodName               = "/chat.ChatService/MuteChat"
+	ChatService_MarkChatUnread_FullMethodName         = "/chat.ChatService/MarkChatUnread"
+	ChatService_CreateFolder_FullMethodName           = "/chat.ChatService/CreateFolder"
+	ChatService_UpdateFolder_FullMethodName           = "/chat.ChatService/UpdateFolder"
+	ChatService_DeleteFolder_FullMethodName           = "/chat.ChatService/DeleteFolder"
+	ChatService_ReorderFolders_FullMethodName         = "/chat.ChatService/ReorderFolders"
+	ChatService_ListFolders_FullMethodName            = "/chat.ChatService/ListFolders"
+	ChatService_CreateInviteLink_FullMethodName       = "/chat.ChatService/CreateInviteLink"
+	ChatService_RevokeInviteLink_FullMethodName       = "/chat.ChatService/RevokeInviteLink"
+	ChatService_JoinByInvite_FullMethodName           = "/chat.ChatService/JoinByInvite"
+	ChatService_ListJoinRequests_FullMethodName       = "/chat.ChatService/ListJoinRequests"
+	ChatService_ApproveJoinRequest_FullMethodName     = "/chat.ChatService/ApproveJoinRequest"
+	ChatService_RejectJoinRequest_FullMethodName      = "/chat.ChatService/RejectJoinRequest"
+	ChatService_CreateChannel_FullMethodName          = "/chat.ChatService/CreateChannel"
+	ChatService_GetChannelByHandle_FullMethodName     = "/chat.ChatService/GetChannelByHandle"
+	ChatService_SubscribeChannel_FullMethodName       = "/chat.ChatService/SubscribeChannel"
+	ChatService_UnsubscribeChannel_FullMethodName     = "/chat.ChatService/UnsubscribeChannel"
+	ChatService_ListChannelSubscribers_FullMethodName = "/chat.ChatService/ListChannelSubscribers"
+	ChatService_SetChannelAdmin_FullMethodName        = "/chat.ChatService/SetChannelAdmin"
+	ChatService_ViewMessages_FullMethodName           = "/chat.ChatService/ViewMessages"
+	ChatService_SendMessage_FullMethodName            = "/chat.ChatService/SendMessage"
+	ChatService_UpdateMessage_FullMethodName          = "/chat.ChatService/UpdateMessage"
+	ChatService_ListMessageRevisions_FullMethodName   = "/chat.ChatService/ListMessageRevisions"
+	ChatService_ScheduleMessage_FullMethodName        = "/chat.ChatService/ScheduleMessage"
+	ChatService_ListScheduledMessages_FullMethodName  = "/chat.ChatService/ListScheduledMessages"
+	ChatService_UpdateScheduledMessage_FullMethodName = "/chat.ChatService/UpdateScheduledMessage"
+	ChatService_CancelScheduledMessage_FullMethodName = "/chat.ChatService/CancelScheduledMessage"
+	ChatService_Vote_FullMethodName                   = "/chat.ChatService/Vote"
+	ChatService_RetractVote_FullMethodName            = "/chat.ChatService/RetractVote"
+	ChatService_ClosePoll_FullMethodName              = "/chat.ChatService/ClosePoll"
+	ChatService_GetPollResults_FullMethodName         = "/chat.ChatService/GetPollResults"
+	ChatService_DeleteMessage_FullMethodName          = "/chat.ChatService/DeleteMessage"
+	ChatService_ListMessages_FullMethodName           = "/chat.ChatService/ListMessages"
+	ChatService_MarkRead_FullMethodName               = "/chat.ChatService/MarkRead"
+	ChatService_MarkDelivered_FullMethodName          = "/chat.ChatService/MarkDelivered"
+	ChatService_GetUnreadCount_FullMethodName         = "/chat.ChatService/GetUnreadCount"
+	ChatService_GetReadState_FullMethodName           = "/chat.ChatService/GetReadState"
+	ChatService_ListMentions_FullMethodName           = "/chat.ChatService/ListMentions"
+	ChatService_ToggleSaved_FullMethodName            = "/chat.ChatService/ToggleSaved"
+	ChatService_ListSaved_FullMethodName              = "/chat.ChatService/ListSaved"
+	ChatService_ListReadMessages_FullMethodName       = "/chat.ChatService/ListReadMessages"
+	ChatService_PinMessage_FullMethodName             = "/chat.ChatService/PinMessage"
+	ChatService_UnpinMessage_FullMethodName           = "/chat.ChatService/UnpinMessage"
+	ChatService_ListPinned_FullMethodName             = "/chat.ChatService/ListPinned"
+	ChatService_SetTyping_FullMethodName              = "/chat.ChatService/SetTyping"
+	ChatService_ListTyping_FullMethodName             = "/chat.ChatService/ListTyping"
+	ChatService_SaveDraft_FullMethodName              = "/chat.ChatService/SaveDraft"
+	ChatService_GetDrafts_FullMethodName              = "/chat.ChatService/GetDrafts"
+	ChatService_ClearDraft_FullMethodName             = "/chat.ChatService/ClearDraft"
+	ChatService_GetUpdates_FullMethodName             = "/chat.ChatService/GetUpdates"
+	ChatService_SubscribeChats_FullMethodName         = "/chat.ChatService/SubscribeChats"
+	ChatService_ExportChat_FullMethodName             = "/chat.ChatService/ExportChat"
+	ChatService_ImportChat_FullMethodName             = "/chat.ChatService/ImportChat"
+	ChatService_ReportMessage_FullMethodName          = "/chat.ChatService/ReportMessage"
+	ChatService_ListModerationQueue_FullMethodName    = "/chat.ChatService/ListModerationQueue"
+	ChatService_ResolveModeration_FullMethodName      = "/chat.ChatService/ResolveModeration"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -41,18 +94,71 @@ const (
 // --- Сервис управления чатами и сообщениями ---
 type ChatServiceClient interface {
 	CreateDirectChat(ctx context.Context, in *CreateDirectChatRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	CreateSecretChat(ctx context.Context, in *CreateSecretChatRequest, opts ...grpc.CallOption) (*ChatResponse, error)
 	CreateGroupChat(ctx context.Context, in *CreateGroupChatRequest, opts ...grpc.CallOption) (*ChatResponse, error)
 	UpdateGroupChat(ctx context.Context, in *UpdateGroupChatRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	SetSlowMode(ctx context.Context, in *SetSlowModeRequest, opts ...grpc.CallOption) (*ChatResponse, error)
 	GetChat(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*ChatResponse, error)
 	ListChats(ctx context.Context, in *ListChatsRequest, opts ...grpc.CallOption) (*ListChatsResponse, error)
+	PinChat(ctx context.Context, in *PinChatRequest, opts ...grpc.CallOption) (*ChatStateResponse, error)
+	ArchiveChat(ctx context.Context, in *ArchiveChatRequest, opts ...grpc.CallOption) (*ChatStateResponse, error)
+	MuteChat(ctx context.Context, in *MuteChatRequest, opts ...grpc.CallOption) (*ChatStateResponse, error)
+	MarkChatUnread(ctx context.Context, in *MarkChatUnreadRequest, opts ...grpc.CallOption) (*ChatStateResponse, error)
+	CreateFolder(ctx context.Context, in *FolderRequest, opts ...grpc.CallOption) (*FolderResponse, error)
+	UpdateFolder(ctx context.Context, in *FolderRequest, opts ...grpc.CallOption) (*FolderResponse, error)
+	DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error)
+	ReorderFolders(ctx context.Context, in *ReorderFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	CreateInviteLink(ctx context.Context, in *CreateInviteLinkRequest, opts ...grpc.CallOption) (*InviteLinkResponse, error)
+	RevokeInviteLink(ctx context.Context, in *RevokeInviteLinkRequest, opts ...grpc.CallOption) (*RevokeInviteLinkResponse, error)
+	JoinByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*JoinByInviteResponse, error)
+	ListJoinRequests(ctx context.Context, in *ListJoinRequestsRequest, opts ...grpc.CallOption) (*ListJoinRequestsResponse, error)
+	ApproveJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	RejectJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*RejectJoinRequestResponse, error)
+	CreateChannel(ctx context.Context, in *CreateChannelRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	GetChannelByHandle(ctx context.Context, in *GetChannelByHandleRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	SubscribeChannel(ctx context.Context, in *SubscribeChannelRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	UnsubscribeChannel(ctx context.Context, in *UnsubscribeChannelRequest, opts ...grpc.CallOption) (*UnsubscribeChannelResponse, error)
+	ListChannelSubscribers(ctx context.Context, in *ListChannelSubscribersRequest, opts ...grpc.CallOption) (*ListChannelSubscribersResponse, error)
+	SetChannelAdmin(ctx context.Context, in *SetChannelAdminRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	ViewMessages(ctx context.Context, in *ViewMessagesRequest, opts ...grpc.CallOption) (*ViewMessagesResponse, error)
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error)
 	UpdateMessage(ctx context.Context, in *UpdateMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	ListMessageRevisions(ctx context.Context, in *ListMessageRevisionsRequest, opts ...grpc.CallOption) (*ListMessageRevisionsResponse, error)
+	ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ScheduledMessageResponse, error)
+	ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...grpc.CallOption) (*ListScheduledMessagesResponse, error)
+	UpdateScheduledMessage(ctx context.Context, in *UpdateScheduledMessageRequest, opts ...grpc.CallOption) (*ScheduledMessageResponse, error)
+	CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...grpc.CallOption) (*CancelScheduledMessageResponse, error)
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*PollResponse, error)
+	RetractVote(ctx context.Context, in *RetractVoteRequest, opts ...grpc.CallOption) (*PollResponse, error)
+	ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*PollResponse, error)
+	GetPollResults(ctx context.Context, in *GetPollResultsRequest, opts ...grpc.CallOption) (*GetPollResultsResponse, error)
 	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error)
 	ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
 	MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error)
+	MarkDelivered(ctx context.Context, in *MarkDeliveredRequest, opts ...grpc.CallOption) (*MarkDeliveredResponse, error)
+	GetUnreadCount(ctx context.Context, in *GetUnreadCountRequest, opts ...grpc.CallOption) (*GetUnreadCountResponse, error)
+	GetReadState(ctx context.Context, in *GetReadStateRequest, opts ...grpc.CallOption) (*GetReadStateResponse, error)
+	ListMentions(ctx context.Context, in *ListMentionsRequest, opts ...grpc.CallOption) (*ListMentionsResponse, error)
 	ToggleSaved(ctx context.Context, in *ToggleSavedRequest, opts ...grpc.CallOption) (*ToggleSavedResponse, error)
 	ListSaved(ctx context.Context, in *ListSavedRequest, opts ...grpc.CallOption) (*ListSavedResponse, error)
 	ListReadMessages(ctx context.Context, in *ListReadMessagesRequest, opts ...grpc.CallOption) (*ListReadMessagesResponse, error)
+	PinMessage(ctx context.Context, in *PinMessageRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...grpc.CallOption) (*ChatResponse, error)
+	ListPinned(ctx context.Context, in *ListPinnedRequest, opts ...grpc.CallOption) (*ListPinnedResponse, error)
+	SetTyping(ctx context.Context, in *SetTypingRequest, opts ...grpc.CallOption) (*SetTypingResponse, error)
+	ListTyping(ctx context.Context, in *ListTypingRequest, opts ...grpc.CallOption) (*ListTypingResponse, error)
+	SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*DraftResponse, error)
+	GetDrafts(ctx context.Context, in *GetDraftsRequest, opts ...grpc.CallOption) (*GetDraftsResponse, error)
+	ClearDraft(ctx context.Context, in *ClearDraftRequest, opts ...grpc.CallOption) (*DraftResponse, error)
+	GetUpdates(ctx context.Context, in *GetUpdatesRequest, opts ...grpc.CallOption) (*GetUpdatesResponse, error)
+	SubscribeChats(ctx context.Context, in *SubscribeChatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatStreamEvent], error)
+	ExportChat(ctx context.Context, in *ExportChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChatChunk], error)
+	ImportChat(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportChatRequest, ImportChatResponse], error)
+	ReportMessage(ctx context.Context, in *ReportMessageRequest, opts ...grpc.CallOption) (*ReportMessageResponse, error)
+	ListModerationQueue(ctx context.Context, in *ListModerationQueueRequest, opts ...grpc.CallOption) (*ListModerationQueueResponse, error)
+	ResolveModeration(ctx context.Context, in *ResolveModerationRequest, opts ...grpc.CallOption) (*ModerationItemResponse, error)
 }
 
 type chatServiceClient struct {
@@ -73,6 +179,16 @@ func (c *chatServiceClient) CreateDirectChat(ctx context.Context, in *CreateDire
 	return out, nil
 }
 
+func (c *chatServiceClient) CreateSecretChat(ctx context.Context, in *CreateSecretChatRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_CreateSecretChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) CreateGroupChat(ctx context.Context, in *CreateGroupChatRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChatResponse)
@@ -93,6 +209,26 @@ func (c *chatServiceClient) UpdateGroupChat(ctx context.Context, in *UpdateGroup
 	return out, nil
 }
 
+func (c *chatServiceClient) SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_SetMessageTTL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SetSlowMode(ctx context.Context, in *SetSlowModeRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_SetSlowMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) GetChat(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChatResponse)
@@ -113,6 +249,226 @@ func (c *chatServiceClient) ListChats(ctx context.Context, in *ListChatsRequest,
 	return out, nil
 }
 
+func (c *chatServiceClient) PinChat(ctx context.Context, in *PinChatRequest, opts ...grpc.CallOption) (*ChatStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatStateResponse)
+	err := c.cc.Invoke(ctx, ChatService_PinChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ArchiveChat(ctx context.Context, in *ArchiveChatRequest, opts ...grpc.CallOption) (*ChatStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatStateResponse)
+	err := c.cc.Invoke(ctx, ChatService_ArchiveChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) MuteChat(ctx context.Context, in *MuteChatRequest, opts ...grpc.CallOption) (*ChatStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatStateResponse)
+	err := c.cc.Invoke(ctx, ChatService_MuteChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) MarkChatUnread(ctx context.Context, in *MarkChatUnreadRequest, opts ...grpc.CallOption) (*ChatStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatStateResponse)
+	err := c.cc.Invoke(ctx, ChatService_MarkChatUnread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreateFolder(ctx context.Context, in *FolderRequest, opts ...grpc.CallOption) (*FolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FolderResponse)
+	err := c.cc.Invoke(ctx, ChatService_CreateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateFolder(ctx context.Context, in *FolderRequest, opts ...grpc.CallOption) (*FolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FolderResponse)
+	err := c.cc.Invoke(ctx, ChatService_UpdateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFolderResponse)
+	err := c.cc.Invoke(ctx, ChatService_DeleteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ReorderFolders(ctx context.Context, in *ReorderFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, ChatService_ReorderFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreateInviteLink(ctx context.Context, in *CreateInviteLinkRequest, opts ...grpc.CallOption) (*InviteLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteLinkResponse)
+	err := c.cc.Invoke(ctx, ChatService_CreateInviteLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RevokeInviteLink(ctx context.Context, in *RevokeInviteLinkRequest, opts ...grpc.CallOption) (*RevokeInviteLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeInviteLinkResponse)
+	err := c.cc.Invoke(ctx, ChatService_RevokeInviteLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) JoinByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*JoinByInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinByInviteResponse)
+	err := c.cc.Invoke(ctx, ChatService_JoinByInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListJoinRequests(ctx context.Context, in *ListJoinRequestsRequest, opts ...grpc.CallOption) (*ListJoinRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJoinRequestsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListJoinRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ApproveJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_ApproveJoinRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RejectJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*RejectJoinRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectJoinRequestResponse)
+	err := c.cc.Invoke(ctx, ChatService_RejectJoinRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CreateChannel(ctx context.Context, in *CreateChannelRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_CreateChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetChannelByHandle(ctx context.Context, in *GetChannelByHandleRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetChannelByHandle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SubscribeChannel(ctx context.Context, in *SubscribeChannelRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_SubscribeChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UnsubscribeChannel(ctx context.Context, in *UnsubscribeChannelRequest, opts ...grpc.CallOption) (*UnsubscribeChannelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnsubscribeChannelResponse)
+	err := c.cc.Invoke(ctx, ChatService_UnsubscribeChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListChannelSubscribers(ctx context.Context, in *ListChannelSubscribersRequest, opts ...grpc.CallOption) (*ListChannelSubscribersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChannelSubscribersResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListChannelSubscribers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SetChannelAdmin(ctx context.Context, in *SetChannelAdminRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_SetChannelAdmin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ViewMessages(ctx context.Context, in *ViewMessagesRequest, opts ...grpc.CallOption) (*ViewMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ViewMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ViewMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MessageResponse)
@@ -133,6 +489,96 @@ func (c *chatServiceClient) UpdateMessage(ctx context.Context, in *UpdateMessage
 	return out, nil
 }
 
+func (c *chatServiceClient) ListMessageRevisions(ctx context.Context, in *ListMessageRevisionsRequest, opts ...grpc.CallOption) (*ListMessageRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMessageRevisionsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListMessageRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ScheduledMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_ScheduleMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...grpc.CallOption) (*ListScheduledMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListScheduledMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateScheduledMessage(ctx context.Context, in *UpdateScheduledMessageRequest, opts ...grpc.CallOption) (*ScheduledMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_UpdateScheduledMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...grpc.CallOption) (*CancelScheduledMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_CancelScheduledMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*PollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollResponse)
+	err := c.cc.Invoke(ctx, ChatService_Vote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RetractVote(ctx context.Context, in *RetractVoteRequest, opts ...grpc.CallOption) (*PollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollResponse)
+	err := c.cc.Invoke(ctx, ChatService_RetractVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*PollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollResponse)
+	err := c.cc.Invoke(ctx, ChatService_ClosePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetPollResults(ctx context.Context, in *GetPollResultsRequest, opts ...grpc.CallOption) (*GetPollResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPollResultsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetPollResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteMessageResponse)
@@ -163,55 +609,319 @@ func (c *chatServiceClient) MarkRead(ctx context.Context, in *MarkReadRequest, o
 	return out, nil
 }
 
-func (c *chatServiceClient) ToggleSaved(ctx context.Context, in *ToggleSavedRequest, opts ...grpc.CallOption) (*ToggleSavedResponse, error) {
+func (c *chatServiceClient) MarkDelivered(ctx context.Context, in *MarkDeliveredRequest, opts ...grpc.CallOption) (*MarkDeliveredResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ToggleSavedResponse)
-	err := c.cc.Invoke(ctx, ChatService_ToggleSaved_FullMethodName, in, out, cOpts...)
+	out := new(MarkDeliveredResponse)
+	err := c.cc.Invoke(ctx, ChatService_MarkDelivered_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *chatServiceClient) ListSaved(ctx context.Context, in *ListSavedRequest, opts ...grpc.CallOption) (*ListSavedResponse, error) {
+func (c *chatServiceClient) GetUnreadCount(ctx context.Context, in *GetUnreadCountRequest, opts ...grpc.CallOption) (*GetUnreadCountResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListSavedResponse)
-	err := c.cc.Invoke(ctx, ChatService_ListSaved_FullMethodName, in, out, cOpts...)
+	out := new(GetUnreadCountResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetUnreadCount_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *chatServiceClient) ListReadMessages(ctx context.Context, in *ListReadMessagesRequest, opts ...grpc.CallOption) (*ListReadMessagesResponse, error) {
+func (c *chatServiceClient) GetReadState(ctx context.Context, in *GetReadStateRequest, opts ...grpc.CallOption) (*GetReadStateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListReadMessagesResponse)
-	err := c.cc.Invoke(ctx, ChatService_ListReadMessages_FullMethodName, in, out, cOpts...)
+	out := new(GetReadStateResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetReadState_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ChatServiceServer is the server API for ChatService service.
+func (c *chatServiceClient) ListMentions(ctx context.Context, in *ListMentionsRequest, opts ...grpc.CallOption) (*ListMentionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMentionsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListMentions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ToggleSaved(ctx context.Context, in *ToggleSavedRequest, opts ...grpc.CallOption) (*ToggleSavedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ToggleSavedResponse)
+	err := c.cc.Invoke(ctx, ChatService_ToggleSaved_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListSaved(ctx context.Context, in *ListSavedRequest, opts ...grpc.CallOption) (*ListSavedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListSaved_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListReadMessages(ctx context.Context, in *ListReadMessagesRequest, opts ...grpc.CallOption) (*ListReadMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReadMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListReadMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) PinMessage(ctx context.Context, in *PinMessageRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_PinMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...grpc.CallOption) (*ChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_UnpinMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListPinned(ctx context.Context, in *ListPinnedRequest, opts ...grpc.CallOption) (*ListPinnedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPinnedResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListPinned_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SetTyping(ctx context.Context, in *SetTypingRequest, opts ...grpc.CallOption) (*SetTypingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTypingResponse)
+	err := c.cc.Invoke(ctx, ChatService_SetTyping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListTyping(ctx context.Context, in *ListTypingRequest, opts ...grpc.CallOption) (*ListTypingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTypingResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListTyping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*DraftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DraftResponse)
+	err := c.cc.Invoke(ctx, ChatService_SaveDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetDrafts(ctx context.Context, in *GetDraftsRequest, opts ...grpc.CallOption) (*GetDraftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDraftsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ClearDraft(ctx context.Context, in *ClearDraftRequest, opts ...grpc.CallOption) (*DraftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DraftResponse)
+	err := c.cc.Invoke(ctx, ChatService_ClearDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetUpdates(ctx context.Context, in *GetUpdatesRequest, opts ...grpc.CallOption) (*GetUpdatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUpdatesResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetUpdates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SubscribeChats(ctx context.Context, in *SubscribeChatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatStreamEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[0], ChatService_SubscribeChats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeChatsRequest, ChatStreamEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_SubscribeChatsClient = grpc.ServerStreamingClient[ChatStreamEvent]
+
+func (c *chatServiceClient) ExportChat(ctx context.Context, in *ExportChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChatChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[1], ChatService_ExportChat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportChatRequest, ExportChatChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ExportChatClient = grpc.ServerStreamingClient[ExportChatChunk]
+
+func (c *chatServiceClient) ImportChat(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportChatRequest, ImportChatResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[2], ChatService_ImportChat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportChatRequest, ImportChatResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ImportChatClient = grpc.ClientStreamingClient[ImportChatRequest, ImportChatResponse]
+
+func (c *chatServiceClient) ReportMessage(ctx context.Context, in *ReportMessageRequest, opts ...grpc.CallOption) (*ReportMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_ReportMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListModerationQueue(ctx context.Context, in *ListModerationQueueRequest, opts ...grpc.CallOption) (*ListModerationQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModerationQueueResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListModerationQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ResolveModeration(ctx context.Context, in *ResolveModerationRequest, opts ...grpc.CallOption) (*ModerationItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModerationItemResponse)
+	err := c.cc.Invoke(ctx, ChatService_ResolveModeration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
 //
 // --- Сервис управления чатами и сообщениями ---
 type ChatServiceServer interface {
 	CreateDirectChat(context.Context, *CreateDirectChatRequest) (*ChatResponse, error)
+	CreateSecretChat(context.Context, *CreateSecretChatRequest) (*ChatResponse, error)
 	CreateGroupChat(context.Context, *CreateGroupChatRequest) (*ChatResponse, error)
 	UpdateGroupChat(context.Context, *UpdateGroupChatRequest) (*ChatResponse, error)
+	SetMessageTTL(context.Context, *SetMessageTTLRequest) (*ChatResponse, error)
+	SetSlowMode(context.Context, *SetSlowModeRequest) (*ChatResponse, error)
 	GetChat(context.Context, *GetChatRequest) (*ChatResponse, error)
 	ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error)
+	PinChat(context.Context, *PinChatRequest) (*ChatStateResponse, error)
+	ArchiveChat(context.Context, *ArchiveChatRequest) (*ChatStateResponse, error)
+	MuteChat(context.Context, *MuteChatRequest) (*ChatStateResponse, error)
+	MarkChatUnread(context.Context, *MarkChatUnreadRequest) (*ChatStateResponse, error)
+	CreateFolder(context.Context, *FolderRequest) (*FolderResponse, error)
+	UpdateFolder(context.Context, *FolderRequest) (*FolderResponse, error)
+	DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error)
+	ReorderFolders(context.Context, *ReorderFoldersRequest) (*ListFoldersResponse, error)
+	ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error)
+	CreateInviteLink(context.Context, *CreateInviteLinkRequest) (*InviteLinkResponse, error)
+	RevokeInviteLink(context.Context, *RevokeInviteLinkRequest) (*RevokeInviteLinkResponse, error)
+	JoinByInvite(context.Context, *JoinByInviteRequest) (*JoinByInviteResponse, error)
+	ListJoinRequests(context.Context, *ListJoinRequestsRequest) (*ListJoinRequestsResponse, error)
+	ApproveJoinRequest(context.Context, *DecideJoinRequestRequest) (*ChatResponse, error)
+	RejectJoinRequest(context.Context, *DecideJoinRequestRequest) (*RejectJoinRequestResponse, error)
+	CreateChannel(context.Context, *CreateChannelRequest) (*ChatResponse, error)
+	GetChannelByHandle(context.Context, *GetChannelByHandleRequest) (*ChatResponse, error)
+	SubscribeChannel(context.Context, *SubscribeChannelRequest) (*ChatResponse, error)
+	UnsubscribeChannel(context.Context, *UnsubscribeChannelRequest) (*UnsubscribeChannelResponse, error)
+	ListChannelSubscribers(context.Context, *ListChannelSubscribersRequest) (*ListChannelSubscribersResponse, error)
+	SetChannelAdmin(context.Context, *SetChannelAdminRequest) (*ChatResponse, error)
+	ViewMessages(context.Context, *ViewMessagesRequest) (*ViewMessagesResponse, error)
 	SendMessage(context.Context, *SendMessageRequest) (*MessageResponse, error)
 	UpdateMessage(context.Context, *UpdateMessageRequest) (*MessageResponse, error)
+	ListMessageRevisions(context.Context, *ListMessageRevisionsRequest) (*ListMessageRevisionsResponse, error)
+	ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduledMessageResponse, error)
+	ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error)
+	UpdateScheduledMessage(context.Context, *UpdateScheduledMessageRequest) (*ScheduledMessageResponse, error)
+	CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error)
+	Vote(context.Context, *VoteRequest) (*PollResponse, error)
+	RetractVote(context.Context, *RetractVoteRequest) (*PollResponse, error)
+	ClosePoll(context.Context, *ClosePollRequest) (*PollResponse, error)
+	GetPollResults(context.Context, *GetPollResultsRequest) (*GetPollResultsResponse, error)
 	DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error)
 	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
 	MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error)
+	MarkDelivered(context.Context, *MarkDeliveredRequest) (*MarkDeliveredResponse, error)
+	GetUnreadCount(context.Context, *GetUnreadCountRequest) (*GetUnreadCountResponse, error)
+	GetReadState(context.Context, *GetReadStateRequest) (*GetReadStateResponse, error)
+	ListMentions(context.Context, *ListMentionsRequest) (*ListMentionsResponse, error)
 	ToggleSaved(context.Context, *ToggleSavedRequest) (*ToggleSavedResponse, error)
 	ListSaved(context.Context, *ListSavedRequest) (*ListSavedResponse, error)
 	ListReadMessages(context.Context, *ListReadMessagesRequest) (*ListReadMessagesResponse, error)
+	PinMessage(context.Context, *PinMessageRequest) (*ChatResponse, error)
+	UnpinMessage(context.Context, *UnpinMessageRequest) (*ChatResponse, error)
+	ListPinned(context.Context, *ListPinnedRequest) (*ListPinnedResponse, error)
+	SetTyping(context.Context, *SetTypingRequest) (*SetTypingResponse, error)
+	ListTyping(context.Context, *ListTypingRequest) (*ListTypingResponse, error)
+	SaveDraft(context.Context, *SaveDraftRequest) (*DraftResponse, error)
+	GetDrafts(context.Context, *GetDraftsRequest) (*GetDraftsResponse, error)
+	ClearDraft(context.Context, *ClearDraftRequest) (*DraftResponse, error)
+	GetUpdates(context.Context, *GetUpdatesRequest) (*GetUpdatesResponse, error)
+	SubscribeChats(*SubscribeChatsRequest, grpc.ServerStreamingServer[ChatStreamEvent]) error
+	ExportChat(*ExportChatRequest, grpc.ServerStreamingServer[ExportChatChunk]) error
+	ImportChat(grpc.ClientStreamingServer[ImportChatRequest, ImportChatResponse]) error
+	ReportMessage(context.Context, *ReportMessageRequest) (*ReportMessageResponse, error)
+	ListModerationQueue(context.Context, *ListModerationQueueRequest) (*ListModerationQueueResponse, error)
+	ResolveModeration(context.Context, *ResolveModerationRequest) (*ModerationItemResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -225,24 +935,126 @@ type UnimplementedChatServiceServer struct{}
 func (UnimplementedChatServiceServer) CreateDirectChat(context.Context, *CreateDirectChatRequest) (*ChatResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateDirectChat not implemented")
 }
+func (UnimplementedChatServiceServer) CreateSecretChat(context.Context, *CreateSecretChatRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSecretChat not implemented")
+}
 func (UnimplementedChatServiceServer) CreateGroupChat(context.Context, *CreateGroupChatRequest) (*ChatResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateGroupChat not implemented")
 }
 func (UnimplementedChatServiceServer) UpdateGroupChat(context.Context, *UpdateGroupChatRequest) (*ChatResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateGroupChat not implemented")
 }
+func (UnimplementedChatServiceServer) SetMessageTTL(context.Context, *SetMessageTTLRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMessageTTL not implemented")
+}
+func (UnimplementedChatServiceServer) SetSlowMode(context.Context, *SetSlowModeRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetSlowMode not implemented")
+}
 func (UnimplementedChatServiceServer) GetChat(context.Context, *GetChatRequest) (*ChatResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetChat not implemented")
 }
 func (UnimplementedChatServiceServer) ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListChats not implemented")
 }
+func (UnimplementedChatServiceServer) PinChat(context.Context, *PinChatRequest) (*ChatStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinChat not implemented")
+}
+func (UnimplementedChatServiceServer) ArchiveChat(context.Context, *ArchiveChatRequest) (*ChatStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveChat not implemented")
+}
+func (UnimplementedChatServiceServer) MuteChat(context.Context, *MuteChatRequest) (*ChatStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MuteChat not implemented")
+}
+func (UnimplementedChatServiceServer) MarkChatUnread(context.Context, *MarkChatUnreadRequest) (*ChatStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkChatUnread not implemented")
+}
+func (UnimplementedChatServiceServer) CreateFolder(context.Context, *FolderRequest) (*FolderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateFolder(context.Context, *FolderRequest) (*FolderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateFolder not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedChatServiceServer) ReorderFolders(context.Context, *ReorderFoldersRequest) (*ListFoldersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderFolders not implemented")
+}
+func (UnimplementedChatServiceServer) ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedChatServiceServer) CreateInviteLink(context.Context, *CreateInviteLinkRequest) (*InviteLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateInviteLink not implemented")
+}
+func (UnimplementedChatServiceServer) RevokeInviteLink(context.Context, *RevokeInviteLinkRequest) (*RevokeInviteLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeInviteLink not implemented")
+}
+func (UnimplementedChatServiceServer) JoinByInvite(context.Context, *JoinByInviteRequest) (*JoinByInviteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinByInvite not implemented")
+}
+func (UnimplementedChatServiceServer) ListJoinRequests(context.Context, *ListJoinRequestsRequest) (*ListJoinRequestsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListJoinRequests not implemented")
+}
+func (UnimplementedChatServiceServer) ApproveJoinRequest(context.Context, *DecideJoinRequestRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveJoinRequest not implemented")
+}
+func (UnimplementedChatServiceServer) RejectJoinRequest(context.Context, *DecideJoinRequestRequest) (*RejectJoinRequestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectJoinRequest not implemented")
+}
+func (UnimplementedChatServiceServer) CreateChannel(context.Context, *CreateChannelRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateChannel not implemented")
+}
+func (UnimplementedChatServiceServer) GetChannelByHandle(context.Context, *GetChannelByHandleRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChannelByHandle not implemented")
+}
+func (UnimplementedChatServiceServer) SubscribeChannel(context.Context, *SubscribeChannelRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubscribeChannel not implemented")
+}
+func (UnimplementedChatServiceServer) UnsubscribeChannel(context.Context, *UnsubscribeChannelRequest) (*UnsubscribeChannelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnsubscribeChannel not implemented")
+}
+func (UnimplementedChatServiceServer) ListChannelSubscribers(context.Context, *ListChannelSubscribersRequest) (*ListChannelSubscribersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListChannelSubscribers not implemented")
+}
+func (UnimplementedChatServiceServer) SetChannelAdmin(context.Context, *SetChannelAdminRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetChannelAdmin not implemented")
+}
+func (UnimplementedChatServiceServer) ViewMessages(context.Context, *ViewMessagesRequest) (*ViewMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ViewMessages not implemented")
+}
 func (UnimplementedChatServiceServer) SendMessage(context.Context, *SendMessageRequest) (*MessageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SendMessage not implemented")
 }
 func (UnimplementedChatServiceServer) UpdateMessage(context.Context, *UpdateMessageRequest) (*MessageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateMessage not implemented")
 }
+func (UnimplementedChatServiceServer) ListMessageRevisions(context.Context, *ListMessageRevisionsRequest) (*ListMessageRevisionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMessageRevisions not implemented")
+}
+func (UnimplementedChatServiceServer) ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ScheduledMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScheduleMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScheduledMessages not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateScheduledMessage(context.Context, *UpdateScheduledMessageRequest) (*ScheduledMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateScheduledMessage not implemented")
+}
+func (UnimplementedChatServiceServer) CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelScheduledMessage not implemented")
+}
+func (UnimplementedChatServiceServer) Vote(context.Context, *VoteRequest) (*PollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Vote not implemented")
+}
+func (UnimplementedChatServiceServer) RetractVote(context.Context, *RetractVoteRequest) (*PollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetractVote not implemented")
+}
+func (UnimplementedChatServiceServer) ClosePoll(context.Context, *ClosePollRequest) (*PollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClosePoll not implemented")
+}
+func (UnimplementedChatServiceServer) GetPollResults(context.Context, *GetPollResultsRequest) (*GetPollResultsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPollResults not implemented")
+}
 func (UnimplementedChatServiceServer) DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteMessage not implemented")
 }
@@ -252,266 +1064,1252 @@ func (UnimplementedChatServiceServer) ListMessages(context.Context, *ListMessage
 func (UnimplementedChatServiceServer) MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method MarkRead not implemented")
 }
+func (UnimplementedChatServiceServer) MarkDelivered(context.Context, *MarkDeliveredRequest) (*MarkDeliveredResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkDelivered not implemented")
+}
+func (UnimplementedChatServiceServer) GetUnreadCount(context.Context, *GetUnreadCountRequest) (*GetUnreadCountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUnreadCount not implemented")
+}
+func (UnimplementedChatServiceServer) GetReadState(context.Context, *GetReadStateRequest) (*GetReadStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReadState not implemented")
+}
+func (UnimplementedChatServiceServer) ListMentions(context.Context, *ListMentionsRequest) (*ListMentionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMentions not implemented")
+}
 func (UnimplementedChatServiceServer) ToggleSaved(context.Context, *ToggleSavedRequest) (*ToggleSavedResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ToggleSaved not implemented")
 }
 func (UnimplementedChatServiceServer) ListSaved(context.Context, *ListSavedRequest) (*ListSavedResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListSaved not implemented")
 }
-func (UnimplementedChatServiceServer) ListReadMessages(context.Context, *ListReadMessagesRequest) (*ListReadMessagesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListReadMessages not implemented")
+func (UnimplementedChatServiceServer) ListReadMessages(context.Context, *ListReadMessagesRequest) (*ListReadMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReadMessages not implemented")
+}
+func (UnimplementedChatServiceServer) PinMessage(context.Context, *PinMessageRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinMessage not implemented")
+}
+func (UnimplementedChatServiceServer) UnpinMessage(context.Context, *UnpinMessageRequest) (*ChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnpinMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListPinned(context.Context, *ListPinnedRequest) (*ListPinnedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPinned not implemented")
+}
+func (UnimplementedChatServiceServer) SetTyping(context.Context, *SetTypingRequest) (*SetTypingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTyping not implemented")
+}
+func (UnimplementedChatServiceServer) ListTyping(context.Context, *ListTypingRequest) (*ListTypingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTyping not implemented")
+}
+func (UnimplementedChatServiceServer) SaveDraft(context.Context, *SaveDraftRequest) (*DraftResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedChatServiceServer) GetDrafts(context.Context, *GetDraftsRequest) (*GetDraftsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDrafts not implemented")
+}
+func (UnimplementedChatServiceServer) ClearDraft(context.Context, *ClearDraftRequest) (*DraftResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearDraft not implemented")
+}
+func (UnimplementedChatServiceServer) GetUpdates(context.Context, *GetUpdatesRequest) (*GetUpdatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUpdates not implemented")
+}
+func (UnimplementedChatServiceServer) SubscribeChats(*SubscribeChatsRequest, grpc.ServerStreamingServer[ChatStreamEvent]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeChats not implemented")
+}
+func (UnimplementedChatServiceServer) ExportChat(*ExportChatRequest, grpc.ServerStreamingServer[ExportChatChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportChat not implemented")
+}
+func (UnimplementedChatServiceServer) ImportChat(grpc.ClientStreamingServer[ImportChatRequest, ImportChatResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportChat not implemented")
+}
+func (UnimplementedChatServiceServer) ReportMessage(context.Context, *ReportMessageRequest) (*ReportMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListModerationQueue(context.Context, *ListModerationQueueRequest) (*ListModerationQueueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListModerationQueue not implemented")
+}
+func (UnimplementedChatServiceServer) ResolveModeration(context.Context, *ResolveModerationRequest) (*ModerationItemResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveModeration not implemented")
+}
+func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
+func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChatServiceServer will
+// result in compilation errors.
+type UnsafeChatServiceServer interface {
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
+	// If the following call panics, it indicates UnimplementedChatServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChatService_ServiceDesc, srv)
+}
+
+func _ChatService_CreateDirectChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDirectChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateDirectChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateDirectChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateDirectChat(ctx, req.(*CreateDirectChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateSecretChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateSecretChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateSecretChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateSecretChat(ctx, req.(*CreateSecretChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateGroupChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateGroupChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateGroupChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateGroupChat(ctx, req.(*CreateGroupChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateGroupChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateGroupChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateGroupChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateGroupChat(ctx, req.(*UpdateGroupChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SetMessageTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMessageTTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SetMessageTTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SetMessageTTL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SetMessageTTL(ctx, req.(*SetMessageTTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SetSlowMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSlowModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SetSlowMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SetSlowMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SetSlowMode(ctx, req.(*SetSlowModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChat(ctx, req.(*GetChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListChats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListChats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListChats(ctx, req.(*ListChatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_PinChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PinChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PinChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PinChat(ctx, req.(*PinChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ArchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ArchiveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ArchiveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ArchiveChat(ctx, req.(*ArchiveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MuteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MuteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MuteChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MuteChat(ctx, req.(*MuteChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MarkChatUnread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkChatUnreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MarkChatUnread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MarkChatUnread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MarkChatUnread(ctx, req.(*MarkChatUnreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateFolder(ctx, req.(*FolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateFolder(ctx, req.(*FolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ReorderFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ReorderFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ReorderFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ReorderFolders(ctx, req.(*ReorderFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListFolders(ctx, req.(*ListFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateInviteLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateInviteLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateInviteLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateInviteLink(ctx, req.(*CreateInviteLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RevokeInviteLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RevokeInviteLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RevokeInviteLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RevokeInviteLink(ctx, req.(*RevokeInviteLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_JoinByInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinByInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).JoinByInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_JoinByInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).JoinByInvite(ctx, req.(*JoinByInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListJoinRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListJoinRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListJoinRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListJoinRequests(ctx, req.(*ListJoinRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ApproveJoinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideJoinRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ApproveJoinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ApproveJoinRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ApproveJoinRequest(ctx, req.(*DecideJoinRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RejectJoinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideJoinRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RejectJoinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RejectJoinRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RejectJoinRequest(ctx, req.(*DecideJoinRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreateChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateChannel(ctx, req.(*CreateChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetChannelByHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelByHandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChannelByHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChannelByHandle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChannelByHandle(ctx, req.(*GetChannelByHandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SubscribeChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SubscribeChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SubscribeChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SubscribeChannel(ctx, req.(*SubscribeChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UnsubscribeChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UnsubscribeChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UnsubscribeChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UnsubscribeChannel(ctx, req.(*UnsubscribeChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListChannelSubscribers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelSubscribersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListChannelSubscribers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListChannelSubscribers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListChannelSubscribers(ctx, req.(*ListChannelSubscribersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SetChannelAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetChannelAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SetChannelAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SetChannelAdmin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SetChannelAdmin(ctx, req.(*SetChannelAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ViewMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ViewMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ViewMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ViewMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ViewMessages(ctx, req.(*ViewMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateMessage(ctx, req.(*UpdateMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMessageRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMessageRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMessageRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListMessageRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMessageRevisions(ctx, req.(*ListMessageRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ScheduleMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ScheduleMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ScheduleMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ScheduleMessage(ctx, req.(*ScheduleMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListScheduledMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListScheduledMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListScheduledMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListScheduledMessages(ctx, req.(*ListScheduledMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateScheduledMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduledMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateScheduledMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateScheduledMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateScheduledMessage(ctx, req.(*UpdateScheduledMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CancelScheduledMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CancelScheduledMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CancelScheduledMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CancelScheduledMessage(ctx, req.(*CancelScheduledMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_Vote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).Vote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RetractVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RetractVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RetractVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RetractVote(ctx, req.(*RetractVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ClosePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ClosePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ClosePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ClosePoll(ctx, req.(*ClosePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetPollResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetPollResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetPollResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetPollResults(ctx, req.(*GetPollResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMessages(ctx, req.(*ListMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MarkRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MarkRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MarkRead(ctx, req.(*MarkReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MarkDelivered_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkDeliveredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MarkDelivered(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MarkDelivered_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MarkDelivered(ctx, req.(*MarkDeliveredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetUnreadCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetUnreadCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetUnreadCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetUnreadCount(ctx, req.(*GetUnreadCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetReadState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetReadState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetReadState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetReadState(ctx, req.(*GetReadStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMentions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMentionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMentions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListMentions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMentions(ctx, req.(*ListMentionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
-func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ChatServiceServer will
-// result in compilation errors.
-type UnsafeChatServiceServer interface {
-	mustEmbedUnimplementedChatServiceServer()
+func _ChatService_ToggleSaved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleSavedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ToggleSaved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ToggleSaved_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ToggleSaved(ctx, req.(*ToggleSavedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
-	// If the following call panics, it indicates UnimplementedChatServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ChatService_ListSaved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ChatService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListSaved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListSaved_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListSaved(ctx, req.(*ListSavedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreateDirectChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDirectChatRequest)
+func _ChatService_ListReadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReadMessagesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).CreateDirectChat(ctx, in)
+		return srv.(ChatServiceServer).ListReadMessages(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_CreateDirectChat_FullMethodName,
+		FullMethod: ChatService_ListReadMessages_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).CreateDirectChat(ctx, req.(*CreateDirectChatRequest))
+		return srv.(ChatServiceServer).ListReadMessages(ctx, req.(*ListReadMessagesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreateGroupChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateGroupChatRequest)
+func _ChatService_PinMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).CreateGroupChat(ctx, in)
+		return srv.(ChatServiceServer).PinMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_CreateGroupChat_FullMethodName,
+		FullMethod: ChatService_PinMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).CreateGroupChat(ctx, req.(*CreateGroupChatRequest))
+		return srv.(ChatServiceServer).PinMessage(ctx, req.(*PinMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_UpdateGroupChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateGroupChatRequest)
+func _ChatService_UnpinMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).UpdateGroupChat(ctx, in)
+		return srv.(ChatServiceServer).UnpinMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_UpdateGroupChat_FullMethodName,
+		FullMethod: ChatService_UnpinMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).UpdateGroupChat(ctx, req.(*UpdateGroupChatRequest))
+		return srv.(ChatServiceServer).UnpinMessage(ctx, req.(*UnpinMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChatRequest)
+func _ChatService_ListPinned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPinnedRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetChat(ctx, in)
+		return srv.(ChatServiceServer).ListPinned(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetChat_FullMethodName,
+		FullMethod: ChatService_ListPinned_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetChat(ctx, req.(*GetChatRequest))
+		return srv.(ChatServiceServer).ListPinned(ctx, req.(*ListPinnedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChatsRequest)
+func _ChatService_SetTyping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTypingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListChats(ctx, in)
+		return srv.(ChatServiceServer).SetTyping(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListChats_FullMethodName,
+		FullMethod: ChatService_SetTyping_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListChats(ctx, req.(*ListChatsRequest))
+		return srv.(ChatServiceServer).SetTyping(ctx, req.(*SetTypingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendMessageRequest)
+func _ChatService_ListTyping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTypingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).SendMessage(ctx, in)
+		return srv.(ChatServiceServer).ListTyping(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_SendMessage_FullMethodName,
+		FullMethod: ChatService_ListTyping_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+		return srv.(ChatServiceServer).ListTyping(ctx, req.(*ListTypingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_UpdateMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateMessageRequest)
+func _ChatService_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).UpdateMessage(ctx, in)
+		return srv.(ChatServiceServer).SaveDraft(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_UpdateMessage_FullMethodName,
+		FullMethod: ChatService_SaveDraft_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).UpdateMessage(ctx, req.(*UpdateMessageRequest))
+		return srv.(ChatServiceServer).SaveDraft(ctx, req.(*SaveDraftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteMessageRequest)
+func _ChatService_GetDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDraftsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).DeleteMessage(ctx, in)
+		return srv.(ChatServiceServer).GetDrafts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_DeleteMessage_FullMethodName,
+		FullMethod: ChatService_GetDrafts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
+		return srv.(ChatServiceServer).GetDrafts(ctx, req.(*GetDraftsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListMessagesRequest)
+func _ChatService_ClearDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearDraftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListMessages(ctx, in)
+		return srv.(ChatServiceServer).ClearDraft(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListMessages_FullMethodName,
+		FullMethod: ChatService_ClearDraft_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListMessages(ctx, req.(*ListMessagesRequest))
+		return srv.(ChatServiceServer).ClearDraft(ctx, req.(*ClearDraftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MarkReadRequest)
+func _ChatService_GetUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUpdatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).MarkRead(ctx, in)
+		return srv.(ChatServiceServer).GetUpdates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_MarkRead_FullMethodName,
+		FullMethod: ChatService_GetUpdates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).MarkRead(ctx, req.(*MarkReadRequest))
+		return srv.(ChatServiceServer).GetUpdates(ctx, req.(*GetUpdatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ToggleSaved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ToggleSavedRequest)
+func _ChatService_SubscribeChats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeChatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChatServiceServer).SubscribeChats(m, &grpc.GenericServerStream[SubscribeChatsRequest, ChatStreamEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_SubscribeChatsServer = grpc.ServerStreamingServer[ChatStreamEvent]
+
+func _ChatService_ExportChat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportChatRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChatServiceServer).ExportChat(m, &grpc.GenericServerStream[ExportChatRequest, ExportChatChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ExportChatServer = grpc.ServerStreamingServer[ExportChatChunk]
+
+func _ChatService_ImportChat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChatServiceServer).ImportChat(&grpc.GenericServerStream[ImportChatRequest, ImportChatResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ImportChatServer = grpc.ClientStreamingServer[ImportChatRequest, ImportChatResponse]
+
+func _ChatService_ReportMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ToggleSaved(ctx, in)
+		return srv.(ChatServiceServer).ReportMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ToggleSaved_FullMethodName,
+		FullMethod: ChatService_ReportMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ToggleSaved(ctx, req.(*ToggleSavedRequest))
+		return srv.(ChatServiceServer).ReportMessage(ctx, req.(*ReportMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListSaved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSavedRequest)
+func _ChatService_ListModerationQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModerationQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListSaved(ctx, in)
+		return srv.(ChatServiceServer).ListModerationQueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListSaved_FullMethodName,
+		FullMethod: ChatService_ListModerationQueue_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListSaved(ctx, req.(*ListSavedRequest))
+		return srv.(ChatServiceServer).ListModerationQueue(ctx, req.(*ListModerationQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListReadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListReadMessagesRequest)
+func _ChatService_ResolveModeration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveModerationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListReadMessages(ctx, in)
+		return srv.(ChatServiceServer).ResolveModeration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListReadMessages_FullMethodName,
+		FullMethod: ChatService_ResolveModeration_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListReadMessages(ctx, req.(*ListReadMessagesRequest))
+		return srv.(ChatServiceServer).ResolveModeration(ctx, req.(*ResolveModerationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -527,6 +2325,10 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateDirectChat",
 			Handler:    _ChatService_CreateDirectChat_Handler,
 		},
+		{
+			MethodName: "CreateSecretChat",
+			Handler:    _ChatService_CreateSecretChat_Handler,
+		},
 		{
 			MethodName: "CreateGroupChat",
 			Handler:    _ChatService_CreateGroupChat_Handler,
@@ -535,6 +2337,14 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateGroupChat",
 			Handler:    _ChatService_UpdateGroupChat_Handler,
 		},
+		{
+			MethodName: "SetMessageTTL",
+			Handler:    _ChatService_SetMessageTTL_Handler,
+		},
+		{
+			MethodName: "SetSlowMode",
+			Handler:    _ChatService_SetSlowMode_Handler,
+		},
 		{
 			MethodName: "GetChat",
 			Handler:    _ChatService_GetChat_Handler,
@@ -543,6 +2353,94 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListChats",
 			Handler:    _ChatService_ListChats_Handler,
 		},
+		{
+			MethodName: "PinChat",
+			Handler:    _ChatService_PinChat_Handler,
+		},
+		{
+			MethodName: "ArchiveChat",
+			Handler:    _ChatService_ArchiveChat_Handler,
+		},
+		{
+			MethodName: "MuteChat",
+			Handler:    _ChatService_MuteChat_Handler,
+		},
+		{
+			MethodName: "MarkChatUnread",
+			Handler:    _ChatService_MarkChatUnread_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _ChatService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "UpdateFolder",
+			Handler:    _ChatService_UpdateFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _ChatService_DeleteFolder_Handler,
+		},
+		{
+			MethodName: "ReorderFolders",
+			Handler:    _ChatService_ReorderFolders_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _ChatService_ListFolders_Handler,
+		},
+		{
+			MethodName: "CreateInviteLink",
+			Handler:    _ChatService_CreateInviteLink_Handler,
+		},
+		{
+			MethodName: "RevokeInviteLink",
+			Handler:    _ChatService_RevokeInviteLink_Handler,
+		},
+		{
+			MethodName: "JoinByInvite",
+			Handler:    _ChatService_JoinByInvite_Handler,
+		},
+		{
+			MethodName: "ListJoinRequests",
+			Handler:    _ChatService_ListJoinRequests_Handler,
+		},
+		{
+			MethodName: "ApproveJoinRequest",
+			Handler:    _ChatService_ApproveJoinRequest_Handler,
+		},
+		{
+			MethodName: "RejectJoinRequest",
+			Handler:    _ChatService_RejectJoinRequest_Handler,
+		},
+		{
+			MethodName: "CreateChannel",
+			Handler:    _ChatService_CreateChannel_Handler,
+		},
+		{
+			MethodName: "GetChannelByHandle",
+			Handler:    _ChatService_GetChannelByHandle_Handler,
+		},
+		{
+			MethodName: "SubscribeChannel",
+			Handler:    _ChatService_SubscribeChannel_Handler,
+		},
+		{
+			MethodName: "UnsubscribeChannel",
+			Handler:    _ChatService_UnsubscribeChannel_Handler,
+		},
+		{
+			MethodName: "ListChannelSubscribers",
+			Handler:    _ChatService_ListChannelSubscribers_Handler,
+		},
+		{
+			MethodName: "SetChannelAdmin",
+			Handler:    _ChatService_SetChannelAdmin_Handler,
+		},
+		{
+			MethodName: "ViewMessages",
+			Handler:    _ChatService_ViewMessages_Handler,
+		},
 		{
 			MethodName: "SendMessage",
 			Handler:    _ChatService_SendMessage_Handler,
@@ -551,6 +2449,42 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateMessage",
 			Handler:    _ChatService_UpdateMessage_Handler,
 		},
+		{
+			MethodName: "ListMessageRevisions",
+			Handler:    _ChatService_ListMessageRevisions_Handler,
+		},
+		{
+			MethodName: "ScheduleMessage",
+			Handler:    _ChatService_ScheduleMessage_Handler,
+		},
+		{
+			MethodName: "ListScheduledMessages",
+			Handler:    _ChatService_ListScheduledMessages_Handler,
+		},
+		{
+			MethodName: "UpdateScheduledMessage",
+			Handler:    _ChatService_UpdateScheduledMessage_Handler,
+		},
+		{
+			MethodName: "CancelScheduledMessage",
+			Handler:    _ChatService_CancelScheduledMessage_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _ChatService_Vote_Handler,
+		},
+		{
+			MethodName: "RetractVote",
+			Handler:    _ChatService_RetractVote_Handler,
+		},
+		{
+			MethodName: "ClosePoll",
+			Handler:    _ChatService_ClosePoll_Handler,
+		},
+		{
+			MethodName: "GetPollResults",
+			Handler:    _ChatService_GetPollResults_Handler,
+		},
 		{
 			MethodName: "DeleteMessage",
 			Handler:    _ChatService_DeleteMessage_Handler,
@@ -563,6 +2497,22 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MarkRead",
 			Handler:    _ChatService_MarkRead_Handler,
 		},
+		{
+			MethodName: "MarkDelivered",
+			Handler:    _ChatService_MarkDelivered_Handler,
+		},
+		{
+			MethodName: "GetUnreadCount",
+			Handler:    _ChatService_GetUnreadCount_Handler,
+		},
+		{
+			MethodName: "GetReadState",
+			Handler:    _ChatService_GetReadState_Handler,
+		},
+		{
+			MethodName: "ListMentions",
+			Handler:    _ChatService_ListMentions_Handler,
+		},
 		{
 			MethodName: "ToggleSaved",
 			Handler:    _ChatService_ToggleSaved_Handler,
@@ -575,7 +2525,71 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListReadMessages",
 			Handler:    _ChatService_ListReadMessages_Handler,
 		},
+		{
+			MethodName: "PinMessage",
+			Handler:    _ChatService_PinMessage_Handler,
+		},
+		{
+			MethodName: "UnpinMessage",
+			Handler:    _ChatService_UnpinMessage_Handler,
+		},
+		{
+			MethodName: "ListPinned",
+			Handler:    _ChatService_ListPinned_Handler,
+		},
+		{
+			MethodName: "SetTyping",
+			Handler:    _ChatService_SetTyping_Handler,
+		},
+		{
+			MethodName: "ListTyping",
+			Handler:    _ChatService_ListTyping_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _ChatService_SaveDraft_Handler,
+		},
+		{
+			MethodName: "GetDrafts",
+			Handler:    _ChatService_GetDrafts_Handler,
+		},
+		{
+			MethodName: "ClearDraft",
+			Handler:    _ChatService_ClearDraft_Handler,
+		},
+		{
+			MethodName: "GetUpdates",
+			Handler:    _ChatService_GetUpdates_Handler,
+		},
+		{
+			MethodName: "ReportMessage",
+			Handler:    _ChatService_ReportMessage_Handler,
+		},
+		{
+			MethodName: "ListModerationQueue",
+			Handler:    _ChatService_ListModerationQueue_Handler,
+		},
+		{
+			MethodName: "ResolveModeration",
+			Handler:    _ChatService_ResolveModeration_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeChats",
+			Handler:       _ChatService_SubscribeChats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportChat",
+			Handler:       _ChatService_ExportChat_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportChat",
+			Handler:       _ChatService_ImportChat_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "chat.proto",
 }
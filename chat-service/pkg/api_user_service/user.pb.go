@@ -7,12 +7,11 @@
 package user
 
 import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
-
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 )
 
 const (
@@ -274,6 +273,157 @@ func (x *AboutMeRequest) GetUuid() string {
 	return ""
 }
 
+// Запрос на проверку блокировок
+type CheckBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                            // Кого могли заблокировать
+	UserUuids     []string               `protobuf:"bytes,2,rep,name=user_uuids,json=userUuids,proto3" json:"user_uuids,omitempty"` // Кого проверяем
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckBlockedRequest) Reset() {
+	*x = CheckBlockedRequest{}
+	mi := &file_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckBlockedRequest) ProtoMessage() {}
+
+func (x *CheckBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckBlockedRequest.ProtoReflect.Descriptor instead.
+func (*CheckBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckBlockedRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CheckBlockedRequest) GetUserUuids() []string {
+	if x != nil {
+		return x.UserUuids
+	}
+	return nil
+}
+
+// Запрос на поиск по именам
+type ResolveUserNamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserNames     []string               `protobuf:"bytes,1,rep,name=user_names,json=userNames,proto3" json:"user_names,omitempty"` // Имена из текста, без @
+	Uuids         []string               `protobuf:"bytes,2,rep,name=uuids,proto3" json:"uuids,omitempty"`                          // Среди кого искать (участники чата)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUserNamesRequest) Reset() {
+	*x = ResolveUserNamesRequest{}
+	mi := &file_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUserNamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUserNamesRequest) ProtoMessage() {}
+
+func (x *ResolveUserNamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUserNamesRequest.ProtoReflect.Descriptor instead.
+func (*ResolveUserNamesRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveUserNamesRequest) GetUserNames() []string {
+	if x != nil {
+		return x.UserNames
+	}
+	return nil
+}
+
+func (x *ResolveUserNamesRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+// Запрос устройств пользователей
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListDevicesRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
 // Ответ при удалении
 type DeleteUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -285,7 +435,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_user_proto_msgTypes[4]
+	mi := &file_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +447,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[4]
+	mi := &file_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,7 +460,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{4}
+	return file_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteUserResponse) GetSuccess() bool {
@@ -344,7 +494,7 @@ type UserResponse struct {
 
 func (x *UserResponse) Reset() {
 	*x = UserResponse{}
-	mi := &file_user_proto_msgTypes[5]
+	mi := &file_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +506,7 @@ func (x *UserResponse) String() string {
 func (*UserResponse) ProtoMessage() {}
 
 func (x *UserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[5]
+	mi := &file_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +519,7 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
 func (*UserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{5}
+	return file_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UserResponse) GetUuid() string {
@@ -428,6 +578,263 @@ func (x *UserResponse) GetUpdatedAt() string {
 	return ""
 }
 
+// Ответ на проверку блокировок
+type CheckBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedBy     []string               `protobuf:"bytes,1,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"` // Те из user_uuids, кто заблокировал uuid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckBlockedResponse) Reset() {
+	*x = CheckBlockedResponse{}
+	mi := &file_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckBlockedResponse) ProtoMessage() {}
+
+func (x *CheckBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckBlockedResponse.ProtoReflect.Descriptor instead.
+func (*CheckBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CheckBlockedResponse) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+// Имя пользователя
+type UserName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserName) Reset() {
+	*x = UserName{}
+	mi := &file_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserName) ProtoMessage() {}
+
+func (x *UserName) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserName.ProtoReflect.Descriptor instead.
+func (*UserName) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UserName) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UserName) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+// Ответ на поиск по именам
+type ResolveUserNamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserName            `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUserNamesResponse) Reset() {
+	*x = ResolveUserNamesResponse{}
+	mi := &file_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUserNamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUserNamesResponse) ProtoMessage() {}
+
+func (x *ResolveUserNamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUserNamesResponse.ProtoReflect.Descriptor instead.
+func (*ResolveUserNamesResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResolveUserNamesResponse) GetUsers() []*UserName {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// Устройство пользователя
+type Device struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                                              // Владелец
+	DeviceId       string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                      // Идентификатор устройства
+	OneTimePrekeys int32                  `protobuf:"varint,3,opt,name=one_time_prekeys,json=oneTimePrekeys,proto3" json:"one_time_prekeys,omitempty"` // Остаток одноразовых ключей
+	UpdatedAt      string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                   // Когда ключи обновлялись
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Device) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Device) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Device) GetOneTimePrekeys() int32 {
+	if x != nil {
+		return x.OneTimePrekeys
+	}
+	return 0
+}
+
+func (x *Device) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// Ответ со списком устройств
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 const file_user_proto_rawDesc = "" +
@@ -451,6 +858,16 @@ const file_user_proto_rawDesc = "" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"$\n" +
 	"\x0eAboutMeRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"H\n" +
+	"\x13CheckBlockedRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1d\n" +
+	"\n" +
+	"user_uuids\x18\x02 \x03(\tR\tuserUuids\"N\n" +
+	"\x17ResolveUserNamesRequest\x12\x1d\n" +
+	"\n" +
+	"user_names\x18\x01 \x03(\tR\tuserNames\x12\x14\n" +
+	"\x05uuids\x18\x02 \x03(\tR\x05uuids\"*\n" +
+	"\x12ListDevicesRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"H\n" +
 	"\x12DeleteUserResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"\xe0\x01\n" +
@@ -464,7 +881,23 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt2\xfd\x01\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\"5\n" +
+	"\x14CheckBlockedResponse\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x01 \x03(\tR\tblockedBy\";\n" +
+	"\bUserName\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\"@\n" +
+	"\x18ResolveUserNamesResponse\x12$\n" +
+	"\x05users\x18\x01 \x03(\v2\x0e.user.UserNameR\x05users\"\x82\x01\n" +
+	"\x06Device\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12(\n" +
+	"\x10one_time_prekeys\x18\x03 \x01(\x05R\x0eoneTimePrekeys\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"=\n" +
+	"\x13ListDevicesResponse\x12&\n" +
+	"\adevices\x18\x01 \x03(\v2\f.user.DeviceR\adevices2\xdb\x03\n" +
 	"\vUserService\x129\n" +
 	"\n" +
 	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x12.user.UserResponse\x129\n" +
@@ -472,7 +905,10 @@ const file_user_proto_rawDesc = "" +
 	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x12.user.UserResponse\x12?\n" +
 	"\n" +
 	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x18.user.DeleteUserResponse\x127\n" +
-	"\vAboutMeUser\x12\x14.user.AboutMeRequest\x1a\x12.user.UserResponseB\bZ\x06./userb\x06proto3"
+	"\vAboutMeUser\x12\x14.user.AboutMeRequest\x1a\x12.user.UserResponse\x12E\n" +
+	"\fCheckBlocked\x12\x19.user.CheckBlockedRequest\x1a\x1a.user.CheckBlockedResponse\x12Q\n" +
+	"\x10ResolveUserNames\x12\x1d.user.ResolveUserNamesRequest\x1a\x1e.user.ResolveUserNamesResponse\x12B\n" +
+	"\vListDevices\x12\x18.user.ListDevicesRequest\x1a\x19.user.ListDevicesResponseB\bZ\x06./userb\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
@@ -486,29 +922,45 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_user_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),  // 0: user.CreateUserRequest
-	(*UpdateUserRequest)(nil),  // 1: user.UpdateUserRequest
-	(*DeleteUserRequest)(nil),  // 2: user.DeleteUserRequest
-	(*AboutMeRequest)(nil),     // 3: user.AboutMeRequest
-	(*DeleteUserResponse)(nil), // 4: user.DeleteUserResponse
-	(*UserResponse)(nil),       // 5: user.UserResponse
+	(*CreateUserRequest)(nil),        // 0: user.CreateUserRequest
+	(*UpdateUserRequest)(nil),        // 1: user.UpdateUserRequest
+	(*DeleteUserRequest)(nil),        // 2: user.DeleteUserRequest
+	(*AboutMeRequest)(nil),           // 3: user.AboutMeRequest
+	(*CheckBlockedRequest)(nil),      // 4: user.CheckBlockedRequest
+	(*ResolveUserNamesRequest)(nil),  // 5: user.ResolveUserNamesRequest
+	(*ListDevicesRequest)(nil),       // 6: user.ListDevicesRequest
+	(*DeleteUserResponse)(nil),       // 7: user.DeleteUserResponse
+	(*UserResponse)(nil),             // 8: user.UserResponse
+	(*CheckBlockedResponse)(nil),     // 9: user.CheckBlockedResponse
+	(*UserName)(nil),                 // 10: user.UserName
+	(*ResolveUserNamesResponse)(nil), // 11: user.ResolveUserNamesResponse
+	(*Device)(nil),                   // 12: user.Device
+	(*ListDevicesResponse)(nil),      // 13: user.ListDevicesResponse
 }
 var file_user_proto_depIdxs = []int32{
-	0, // 0: user.UserService.CreateUser:input_type -> user.CreateUserRequest
-	1, // 1: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	2, // 2: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
-	3, // 3: user.UserService.AboutMeUser:input_type -> user.AboutMeRequest
-	5, // 4: user.UserService.CreateUser:output_type -> user.UserResponse
-	5, // 5: user.UserService.UpdateUser:output_type -> user.UserResponse
-	4, // 6: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
-	5, // 7: user.UserService.AboutMeUser:output_type -> user.UserResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: user.ResolveUserNamesResponse.users:type_name -> user.UserName
+	12, // 1: user.ListDevicesResponse.devices:type_name -> user.Device
+	0,  // 2: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	1,  // 3: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	2,  // 4: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	3,  // 5: user.UserService.AboutMeUser:input_type -> user.AboutMeRequest
+	4,  // 6: user.UserService.CheckBlocked:input_type -> user.CheckBlockedRequest
+	5,  // 7: user.UserService.ResolveUserNames:input_type -> user.ResolveUserNamesRequest
+	6,  // 8: user.UserService.ListDevices:input_type -> user.ListDevicesRequest
+	8,  // 9: user.UserService.CreateUser:output_type -> user.UserResponse
+	8,  // 10: user.UserService.UpdateUser:output_type -> user.UserResponse
+	7,  // 11: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
+	8,  // 12: user.UserService.AboutMeUser:output_type -> user.UserResponse
+	9,  // 13: user.UserService.CheckBlocked:output_type -> user.CheckBlockedResponse
+	11, // 14: user.UserService.ResolveUserNames:output_type -> user.ResolveUserNamesResponse
+	13, // 15: user.UserService.ListDevices:output_type -> user.ListDevicesResponse
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -522,7 +974,7 @@ func file_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
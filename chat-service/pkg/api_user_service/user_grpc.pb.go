@@ -19,10 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_CreateUser_FullMethodName  = "/user.UserService/CreateUser"
-	UserService_UpdateUser_FullMethodName  = "/user.UserService/UpdateUser"
-	UserService_DeleteUser_FullMethodName  = "/user.UserService/DeleteUser"
-	UserService_AboutMeUser_FullMethodName = "/user.UserService/AboutMeUser"
+	UserService_CreateUser_FullMethodName       = "/user.UserService/CreateUser"
+	UserService_UpdateUser_FullMethodName       = "/user.UserService/UpdateUser"
+	UserService_DeleteUser_FullMethodName       = "/user.UserService/DeleteUser"
+	UserService_AboutMeUser_FullMethodName      = "/user.UserService/AboutMeUser"
+	UserService_CheckBlocked_FullMethodName     = "/user.UserService/CheckBlocked"
+	UserService_ResolveUserNames_FullMethodName = "/user.UserService/ResolveUserNames"
+	UserService_ListDevices_FullMethodName      = "/user.UserService/ListDevices"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -39,6 +42,12 @@ type UserServiceClient interface {
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	// Получение информациии о пользователе через uuid
 	AboutMeUser(ctx context.Context, in *AboutMeRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	// Кто из user_uuids заблокировал uuid
+	CheckBlocked(ctx context.Context, in *CheckBlockedRequest, opts ...grpc.CallOption) (*CheckBlockedResponse, error)
+	// Кто из uuids носит одно из user_names (без учёта регистра) — разбор @упоминаний
+	ResolveUserNames(ctx context.Context, in *ResolveUserNamesRequest, opts ...grpc.CallOption) (*ResolveUserNamesResponse, error)
+	// Устройства с ключами E2E-шифрования — адресаты сообщений секретного чата
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
 }
 
 type userServiceClient struct {
@@ -89,6 +98,36 @@ func (c *userServiceClient) AboutMeUser(ctx context.Context, in *AboutMeRequest,
 	return out, nil
 }
 
+func (c *userServiceClient) CheckBlocked(ctx context.Context, in *CheckBlockedRequest, opts ...grpc.CallOption) (*CheckBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckBlockedResponse)
+	err := c.cc.Invoke(ctx, UserService_CheckBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ResolveUserNames(ctx context.Context, in *ResolveUserNamesRequest, opts ...grpc.CallOption) (*ResolveUserNamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveUserNamesResponse)
+	err := c.cc.Invoke(ctx, UserService_ResolveUserNames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -103,6 +142,12 @@ type UserServiceServer interface {
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	// Получение информациии о пользователе через uuid
 	AboutMeUser(context.Context, *AboutMeRequest) (*UserResponse, error)
+	// Кто из user_uuids заблокировал uuid
+	CheckBlocked(context.Context, *CheckBlockedRequest) (*CheckBlockedResponse, error)
+	// Кто из uuids носит одно из user_names (без учёта регистра) — разбор @упоминаний
+	ResolveUserNames(context.Context, *ResolveUserNamesRequest) (*ResolveUserNamesResponse, error)
+	// Устройства с ключами E2E-шифрования — адресаты сообщений секретного чата
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -125,6 +170,15 @@ func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserReq
 func (UnimplementedUserServiceServer) AboutMeUser(context.Context, *AboutMeRequest) (*UserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AboutMeUser not implemented")
 }
+func (UnimplementedUserServiceServer) CheckBlocked(context.Context, *CheckBlockedRequest) (*CheckBlockedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckBlocked not implemented")
+}
+func (UnimplementedUserServiceServer) ResolveUserNames(context.Context, *ResolveUserNamesRequest) (*ResolveUserNamesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveUserNames not implemented")
+}
+func (UnimplementedUserServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -218,6 +272,60 @@ func _UserService_AboutMeUser_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_CheckBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckBlocked(ctx, req.(*CheckBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResolveUserNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveUserNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResolveUserNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ResolveUserNames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResolveUserNames(ctx, req.(*ResolveUserNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +349,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AboutMeUser",
 			Handler:    _UserService_AboutMeUser_Handler,
 		},
+		{
+			MethodName: "CheckBlocked",
+			Handler:    _UserService_CheckBlocked_Handler,
+		},
+		{
+			MethodName: "ResolveUserNames",
+			Handler:    _UserService_ResolveUserNames_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _UserService_ListDevices_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
@@ -0,0 +1,150 @@
+package events
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var update = flag.Bool("update", false, "записать текущие схемы в testdata/schemas.json (только без ломающих изменений)")
+
+const schemasFile = "testdata/schemas.json"
+
+// snapshot — схемы всех версий событий и конверта: "type@version" → путь номеров полей → "имя сигнатура".
+// Сигнатура — кардинальность, тип и для вложенных сообщений их полное имя.
+func snapshot() map[string]map[string]string {
+	out := map[string]map[string]string{
+		"envelope": fields((&Envelope{}).ProtoReflect().Descriptor()),
+	}
+	for _, s := range registry {
+		out[fmt.Sprintf("%s@%d", s.Type, s.Version)] = fields(s.New().ProtoReflect().Descriptor())
+	}
+	return out
+}
+
+func fields(md protoreflect.MessageDescriptor) map[string]string {
+	out := map[string]string{"": string(md.FullName())}
+	walk(md, "", map[protoreflect.FullName]bool{}, out)
+	return out
+}
+
+func walk(md protoreflect.MessageDescriptor, prefix string, seen map[protoreflect.FullName]bool, out map[string]string) {
+	seen[md.FullName()] = true
+	defer delete(seen, md.FullName())
+
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		path := prefix + strconv.Itoa(int(fd.Number()))
+		sig := fd.Kind().String()
+		if fd.IsList() {
+			sig = "repeated " + sig
+		}
+		if fd.Message() != nil {
+			sig += " " + string(fd.Message().FullName())
+		}
+		out[path] = string(fd.Name()) + " " + sig
+		if fd.Message() != nil && !seen[fd.Message().FullName()] {
+			walk(fd.Message(), path+".", seen, out)
+		}
+	}
+}
+
+// breaking перечисляет несовместимые отличия новой схемы от записанной:
+// пропавшие версии и поля, смену типа, кардинальности или сообщения поля.
+// Новые поля, версии и переименования совместимы.
+func breaking(golden, cur map[string]map[string]string) []string {
+	var problems []string
+	for key, oldFields := range golden {
+		newFields, ok := cur[key]
+		if !ok {
+			problems = append(problems, key+": версия удалена из реестра")
+			continue
+		}
+		if oldFields[""] != newFields[""] {
+			problems = append(problems, fmt.Sprintf("%s: payload %s заменён на %s", key, oldFields[""], newFields[""]))
+		}
+		for path, old := range oldFields {
+			if path == "" {
+				continue
+			}
+			now, ok := newFields[path]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: поле %s (%s) удалено", key, path, old))
+				continue
+			}
+			if signature(old) != signature(now) {
+				problems = append(problems, fmt.Sprintf("%s: поле %s изменено: %s → %s", key, path, old, now))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func signature(field string) string {
+	_, sig, _ := strings.Cut(field, " ")
+	return sig
+}
+
+// TestSchemaCompatibility сравнивает схемы с testdata/schemas.json.
+// Ломающее изменение делается новой версией типа; совместимое записывается через
+// go test ./events -run TestSchemaCompatibility -update
+func TestSchemaCompatibility(t *testing.T) {
+	cur := snapshot()
+
+	data, err := os.ReadFile(schemasFile)
+	if err != nil && !(*update && os.IsNotExist(err)) {
+		t.Fatalf("read %s: %v", schemasFile, err)
+	}
+	golden := map[string]map[string]string{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &golden); err != nil {
+			t.Fatalf("parse %s: %v", schemasFile, err)
+		}
+	}
+
+	if problems := breaking(golden, cur); len(problems) > 0 {
+		t.Fatalf("ломающие изменения контрактов — добавьте новую версию типа вместо правки существующей:\n%s",
+			strings.Join(problems, "\n"))
+	}
+
+	want, _ := json.MarshalIndent(cur, "", "  ")
+	want = append(want, '\n')
+	if string(want) == string(data) {
+		return
+	}
+	if *update {
+		if err := os.WriteFile(schemasFile, want, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	t.Fatalf("%s устарел: изменения совместимы, обновите его: go test ./events -run TestSchemaCompatibility -update", schemasFile)
+}
+
+func TestBreaking_DetectsIncompatibleChanges(t *testing.T) {
+	golden := map[string]map[string]string{
+		"chat.message.sent@1": {"": "gax.events.v1.MessageSent", "1": "message_id string", "5": "timestamp int64"},
+		"user.deleted@1":      {"": "gax.events.v1.UserDeleted", "1": "id string"},
+	}
+	cur := map[string]map[string]string{
+		"chat.message.sent@1": {"": "gax.events.v1.MessageSent", "1": "id string", "5": "timestamp string", "6": "seq int64"},
+	}
+
+	problems := breaking(golden, cur)
+
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %v", problems)
+	}
+	if !strings.Contains(problems[0], "поле 5 изменено") || !strings.Contains(problems[1], "user.deleted@1") {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+}
@@ -0,0 +1,151 @@
+// Package events — общие контракты событий Kafka.
+// Каждое событие — Envelope с payload одного из сообщений events/v1; пара (type, version)
+// однозначно задаёт схему payload. Ломающее изменение схемы — новая версия типа, старая остаётся в реестре.
+package events
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Типы событий
+const (
+	TypeMessageSent       = "chat.message.sent"
+	TypeMessageUpserted   = "chat.message.upserted"
+	TypeMessagesDeleted   = "chat.messages.deleted"
+	TypeMentionCreated    = "chat.mention.created"
+	TypeChatUpserted      = "chat.upserted"
+	TypeMembershipChanged = "chat.membership.changed"
+	TypeFileUploaded      = "media.file.uploaded"
+	TypeFileDeleted       = "media.file.deleted"
+	TypeUserUpserted      = "user.upserted"
+	TypeUserDeleted       = "user.deleted"
+)
+
+// Продюсеры
+const (
+	ProducerChat  = "chat-service"
+	ProducerMedia = "media-service"
+	ProducerUser  = "user-service"
+)
+
+var (
+	ErrUnregistered       = errors.New("events: payload type is not registered")
+	ErrUnknownType        = errors.New("events: unknown event type")
+	ErrUnsupportedVersion = errors.New("events: unsupported event version")
+)
+
+// schema — версия типа события и сообщение её payload
+type schema struct {
+	Type    string
+	Version uint32
+	New     func() proto.Message
+}
+
+// registry — все когда-либо опубликованные версии. Удалять записи нельзя:
+// в топиках могут лежать события старых версий (это проверяет TestSchemaCompatibility).
+var registry = []schema{
+	{TypeMessageSent, 1, func() proto.Message { return &MessageSent{} }},
+	{TypeMessageUpserted, 1, func() proto.Message { return &MessageUpserted{} }},
+	{TypeMessagesDeleted, 1, func() proto.Message { return &MessagesDeleted{} }},
+	{TypeMentionCreated, 1, func() proto.Message { return &MentionCreated{} }},
+	{TypeChatUpserted, 1, func() proto.Message { return &ChatUpserted{} }},
+	{TypeMembershipChanged, 1, func() proto.Message { return &MembershipChanged{} }},
+	{TypeFileUploaded, 1, func() proto.Message { return &FileUploaded{} }},
+	{TypeFileDeleted, 1, func() proto.Message { return &FileDeleted{} }},
+	{TypeUserUpserted, 1, func() proto.Message { return &UserUpserted{} }},
+	{TypeUserDeleted, 1, func() proto.Message { return &UserDeleted{} }},
+}
+
+func lookup(typ string, version uint32) (schema, error) {
+	known := false
+	for _, s := range registry {
+		if s.Type != typ {
+			continue
+		}
+		known = true
+		if s.Version == version {
+			return s, nil
+		}
+	}
+	if known {
+		return schema{}, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, typ, version)
+	}
+	return schema{}, fmt.Errorf("%w: %s", ErrUnknownType, typ)
+}
+
+// current возвращает тип и последнюю версию, в которой payload — сообщение name
+func current(name protoreflect.FullName) (schema, error) {
+	var found schema
+	ok := false
+	for _, s := range registry {
+		if s.New().ProtoReflect().Descriptor().FullName() == name && (!ok || s.Version > found.Version) {
+			found, ok = s, true
+		}
+	}
+	if !ok {
+		return schema{}, fmt.Errorf("%w: %s", ErrUnregistered, name)
+	}
+	return found, nil
+}
+
+// New оборачивает payload в конверт: тип и версия берутся из реестра по типу payload
+func New(producer string, payload proto.Message) (*Envelope, error) {
+	s, err := current(payload.ProtoReflect().Descriptor().FullName())
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Id:         newID(),
+		Type:       s.Type,
+		Version:    s.Version,
+		OccurredAt: time.Now().UnixMilli(),
+		Producer:   producer,
+		Payload:    data,
+	}, nil
+}
+
+// Marshal — New и сериализация конверта: готовое значение сообщения Kafka
+func Marshal(producer string, payload proto.Message) ([]byte, error) {
+	env, err := New(producer, payload)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(env)
+}
+
+// Unmarshal разбирает конверт и его payload. Для неизвестного типа или версии возвращает
+// конверт и ErrUnknownType / ErrUnsupportedVersion — потребитель решает, пропустить ли событие.
+func Unmarshal(data []byte) (*Envelope, proto.Message, error) {
+	env := &Envelope{}
+	if err := proto.Unmarshal(data, env); err != nil {
+		return nil, nil, fmt.Errorf("events: decode envelope: %w", err)
+	}
+	s, err := lookup(env.Type, env.Version)
+	if err != nil {
+		return env, nil, err
+	}
+	payload := s.New()
+	if err := proto.Unmarshal(env.Payload, payload); err != nil {
+		return env, nil, fmt.Errorf("events: decode %s v%d: %w", env.Type, env.Version, err)
+	}
+	return env, payload, nil
+}
+
+// newID — случайный UUID v4
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarshalUnmarshal_RoundTrip(t *testing.T) {
+	sent := &MessageSent{MessageId: "msg1", ChatId: "chat1", AuthorId: "user1", Text: "Привет", Timestamp: 1700000000}
+
+	data, err := Marshal(ProducerChat, sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, payload, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if env.Type != TypeMessageSent || env.Version != 1 || env.Producer != ProducerChat {
+		t.Fatalf("unexpected envelope: %v", env)
+	}
+	if env.Id == "" || env.OccurredAt == 0 {
+		t.Fatalf("envelope id and occurred_at must be set: %v", env)
+	}
+	if !proto.Equal(payload, sent) {
+		t.Fatalf("payload mismatch: %v", payload)
+	}
+}
+
+func TestNew_UnregisteredPayload(t *testing.T) {
+	_, err := New(ProducerChat, &Message{Id: "msg1"})
+
+	if !errors.Is(err, ErrUnregistered) {
+		t.Fatalf("expected ErrUnregistered, got %v", err)
+	}
+}
+
+func TestUnmarshal_UnknownTypeAndVersion(t *testing.T) {
+	for _, tc := range []struct {
+		env  *Envelope
+		want error
+	}{
+		{&Envelope{Type: "chat.reaction.added", Version: 1}, ErrUnknownType},
+		{&Envelope{Type: TypeMessageSent, Version: 2}, ErrUnsupportedVersion},
+	} {
+		data, _ := proto.Marshal(tc.env)
+
+		env, payload, err := Unmarshal(data)
+
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%s v%d: expected %v, got %v", tc.env.Type, tc.env.Version, tc.want, err)
+		}
+		if env == nil || payload != nil {
+			t.Fatalf("%s v%d: envelope must be returned without payload", tc.env.Type, tc.env.Version)
+		}
+	}
+}
+
+func TestRegistry_UniqueVersions(t *testing.T) {
+	seen := map[string]bool{}
+	for _, s := range registry {
+		key := fmt.Sprintf("%s@%d", s.Type, s.Version)
+		if seen[key] {
+			t.Fatalf("duplicate registry entry %s v%d", s.Type, s.Version)
+		}
+		seen[key] = true
+	}
+}
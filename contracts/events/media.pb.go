@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.6.1
+// source: events/v1/media.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// media.file.uploaded v1 — файл загружен в хранилище
+type FileUploaded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,6,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Bucket        string                 `protobuf:"bytes,8,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	ObjectName    string                 `protobuf:"bytes,9,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileUploaded) Reset() {
+	*x = FileUploaded{}
+	mi := &file_events_v1_media_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileUploaded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileUploaded) ProtoMessage() {}
+
+func (x *FileUploaded) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_media_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileUploaded.ProtoReflect.Descriptor instead.
+func (*FileUploaded) Descriptor() ([]byte, []int) {
+	return file_events_v1_media_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FileUploaded) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileUploaded) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetObjectName() string {
+	if x != nil {
+		return x.ObjectName
+	}
+	return ""
+}
+
+func (x *FileUploaded) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// media.file.deleted v1 — файл удалён владельцем из хранилища
+type FileDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeletedAt     int64                  `protobuf:"varint,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDeleted) Reset() {
+	*x = FileDeleted{}
+	mi := &file_events_v1_media_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDeleted) ProtoMessage() {}
+
+func (x *FileDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_media_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDeleted.ProtoReflect.Descriptor instead.
+func (*FileDeleted) Descriptor() ([]byte, []int) {
+	return file_events_v1_media_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FileDeleted) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileDeleted) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FileDeleted) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+var File_events_v1_media_proto protoreflect.FileDescriptor
+
+const file_events_v1_media_proto_rawDesc = "" +
+	"\n" +
+	"\x15events/v1/media.proto\x12\rgax.events.v1\"\x9d\x02\n" +
+	"\fFileUploaded\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x17\n" +
+	"\achat_id\x18\x06 \x01(\tR\x06chatId\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x16\n" +
+	"\x06bucket\x18\b \x01(\tR\x06bucket\x12\x1f\n" +
+	"\vobject_name\x18\t \x01(\tR\n" +
+	"objectName\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\"U\n" +
+	"\vFileDeleted\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\x03R\tdeletedAtB\x19Z\x17contracts/events;eventsb\x06proto3"
+
+var (
+	file_events_v1_media_proto_rawDescOnce sync.Once
+	file_events_v1_media_proto_rawDescData []byte
+)
+
+func file_events_v1_media_proto_rawDescGZIP() []byte {
+	file_events_v1_media_proto_rawDescOnce.Do(func() {
+		file_events_v1_media_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_v1_media_proto_rawDesc), len(file_events_v1_media_proto_rawDesc)))
+	})
+	return file_events_v1_media_proto_rawDescData
+}
+
+var file_events_v1_media_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_events_v1_media_proto_goTypes = []any{
+	(*FileUploaded)(nil), // 0: gax.events.v1.FileUploaded
+	(*FileDeleted)(nil),  // 1: gax.events.v1.FileDeleted
+}
+var file_events_v1_media_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_events_v1_media_proto_init() }
+func file_events_v1_media_proto_init() {
+	if File_events_v1_media_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_v1_media_proto_rawDesc), len(file_events_v1_media_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_v1_media_proto_goTypes,
+		DependencyIndexes: file_events_v1_media_proto_depIdxs,
+		MessageInfos:      file_events_v1_media_proto_msgTypes,
+	}.Build()
+	File_events_v1_media_proto = out.File
+	file_events_v1_media_proto_goTypes = nil
+	file_events_v1_media_proto_depIdxs = nil
+}
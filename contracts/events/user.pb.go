@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.6.1
+// source: events/v1/user.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Avatar        string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	AboutMe       string                 `protobuf:"bytes,5,opt,name=about_me,json=aboutMe,proto3" json:"about_me,omitempty"`
+	Friends       []string               `protobuf:"bytes,6,rep,name=friends,proto3" json:"friends,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_events_v1_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_events_v1_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *User) GetAboutMe() string {
+	if x != nil {
+		return x.AboutMe
+	}
+	return ""
+}
+
+func (x *User) GetFriends() []string {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// user.upserted v1 — пользователь создан или изменён
+type UserUpserted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserUpserted) Reset() {
+	*x = UserUpserted{}
+	mi := &file_events_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUpserted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUpserted) ProtoMessage() {}
+
+func (x *UserUpserted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUpserted.ProtoReflect.Descriptor instead.
+func (*UserUpserted) Descriptor() ([]byte, []int) {
+	return file_events_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserUpserted) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// user.deleted v1 — пользователь удалён
+type UserDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDeleted) Reset() {
+	*x = UserDeleted{}
+	mi := &file_events_v1_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeleted) ProtoMessage() {}
+
+func (x *UserDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeleted.ProtoReflect.Descriptor instead.
+func (*UserDeleted) Descriptor() ([]byte, []int) {
+	return file_events_v1_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserDeleted) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_events_v1_user_proto protoreflect.FileDescriptor
+
+const file_events_v1_user_proto_rawDesc = "" +
+	"\n" +
+	"\x14events/v1/user.proto\x12\rgax.events.v1\"\xb5\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\x12\x19\n" +
+	"\babout_me\x18\x05 \x01(\tR\aaboutMe\x12\x18\n" +
+	"\afriends\x18\x06 \x03(\tR\afriends\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"7\n" +
+	"\fUserUpserted\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.gax.events.v1.UserR\x04user\"\x1d\n" +
+	"\vUserDeleted\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02idB\x19Z\x17contracts/events;eventsb\x06proto3"
+
+var (
+	file_events_v1_user_proto_rawDescOnce sync.Once
+	file_events_v1_user_proto_rawDescData []byte
+)
+
+func file_events_v1_user_proto_rawDescGZIP() []byte {
+	file_events_v1_user_proto_rawDescOnce.Do(func() {
+		file_events_v1_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_v1_user_proto_rawDesc), len(file_events_v1_user_proto_rawDesc)))
+	})
+	return file_events_v1_user_proto_rawDescData
+}
+
+var file_events_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_events_v1_user_proto_goTypes = []any{
+	(*User)(nil),         // 0: gax.events.v1.User
+	(*UserUpserted)(nil), // 1: gax.events.v1.UserUpserted
+	(*UserDeleted)(nil),  // 2: gax.events.v1.UserDeleted
+}
+var file_events_v1_user_proto_depIdxs = []int32{
+	0, // 0: gax.events.v1.UserUpserted.user:type_name -> gax.events.v1.User
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_events_v1_user_proto_init() }
+func file_events_v1_user_proto_init() {
+	if File_events_v1_user_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_v1_user_proto_rawDesc), len(file_events_v1_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_v1_user_proto_goTypes,
+		DependencyIndexes: file_events_v1_user_proto_depIdxs,
+		MessageInfos:      file_events_v1_user_proto_msgTypes,
+	}.Build()
+	File_events_v1_user_proto = out.File
+	file_events_v1_user_proto_goTypes = nil
+	file_events_v1_user_proto_depIdxs = nil
+}
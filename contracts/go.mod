@@ -0,0 +1,5 @@
+module contracts
+
+go 1.25.4
+
+require google.golang.org/protobuf v1.36.10
@@ -65,6 +65,8 @@ func main() {
 	r.HandleFunc("/download/{id}", h.Download).Methods("GET")
 	r.HandleFunc("/media/delete/{id}", h.DeleteFile).Methods("DELETE")
 	r.HandleFunc("/media/list", h.ListFiles).Methods("GET")
+	r.HandleFunc("/media/{id}/attach", h.AttachToChat).Methods("POST")
+	r.HandleFunc("/media/{id}/detach", h.DetachFromChat).Methods("POST")
 	r.HandleFunc("/media/{id}", h.GetFileMeta).Methods("GET")
 
 	// Корневой endpoint
@@ -80,6 +82,8 @@ func main() {
 				"GET /media/{id}":           "Get file metadata",
 				"DELETE /media/delete/{id}": "Delete a file",
 				"GET /media/list":           "List user files",
+				"POST /media/{id}/attach":   "Attach own file to a chat",
+				"POST /media/{id}/detach":   "Detach a file from a chat",
 				"GET /health":               "Health check",
 			},
 		})
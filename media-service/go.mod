@@ -1,8 +1,9 @@
 module media-service
 
-go 1.25.1
+go 1.25.4
 
 require (
+	contracts v0.0.0
 	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/mux v1.8.1
@@ -31,5 +32,9 @@ require (
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/text v0.26.0 // indirect
 	golang.org/x/tools v0.33.0 // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// Общие контракты событий Kafka (в Docker собираются из корня репозитория)
+replace contracts => ../contracts
@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrNotFileOwner = errors.New("file belongs to another user")
+)
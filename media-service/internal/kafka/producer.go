@@ -2,11 +2,12 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"media-service/internal/domain"
 	"time"
 
+	"contracts/events"
+
 	"github.com/segmentio/kafka-go"
 )
 
@@ -28,11 +29,18 @@ func NewProducer(brokers []string, topic string) *Producer {
 
 // SendFileUploaded — это тот метод, который требует интерфейс
 func (p *Producer) SendFileUploaded(meta *domain.FileMeta) error {
-	// 1. Формируем JSON
-	payload, err := json.Marshal(map[string]interface{}{
-		"event_type": "FileUploaded",
-		"payload":    meta,
-		"timestamp":  time.Now().Unix(),
+	// 1. Формируем конверт общего контракта media.file.uploaded
+	payload, err := events.Marshal(events.ProducerMedia, &events.FileUploaded{
+		Id:          meta.ID,
+		Filename:    meta.Filename,
+		ContentType: meta.ContentType,
+		Size:        meta.Size,
+		UserId:      meta.UserID,
+		ChatId:      meta.ChatID,
+		Description: meta.Description,
+		Bucket:      meta.Bucket,
+		ObjectName:  meta.ObjectName,
+		CreatedAt:   meta.CreatedAt.Unix(),
 	})
 	if err != nil {
 		return err
@@ -54,6 +62,30 @@ func (p *Producer) SendFileUploaded(meta *domain.FileMeta) error {
 	return err
 }
 
+// SendFileDeleted публикует media.file.deleted: файл удалён из хранилища
+func (p *Producer) SendFileDeleted(meta *domain.FileMeta, at time.Time) error {
+	payload, err := events.Marshal(events.ProducerMedia, &events.FileDeleted{
+		Id:        meta.ID,
+		UserId:    meta.UserID,
+		DeletedAt: at.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(meta.ID),
+		Value: payload,
+	})
+	if err != nil {
+		log.Printf("Failed to send kafka event: %v", err)
+	}
+	return err
+}
+
 // Close закрывает соединение (нужно для graceful shutdown)
 func (p *Producer) Close() error {
 	return p.writer.Close()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"media-service/internal/domain"
+	"time"
 )
 
 type PgRepo struct {
@@ -76,6 +77,22 @@ func (r *PgRepo) GetTotalFilesByUser(ctx context.Context, userID string) (int, e
 	return total, err
 }
 
+// AttachToChat отмечает, что файл использован в чате; повторная отметка ничего не меняет
+func (r *PgRepo) AttachToChat(ctx context.Context, fileID, chatID, userID string, at time.Time) error {
+	query := `INSERT INTO file_chats (file_id, chat_id, attached_by, attached_at)
+	          VALUES ($1, $2, $3, $4)
+	          ON CONFLICT (file_id, chat_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, fileID, chatID, userID, at)
+	return err
+}
+
+// DetachFromChat забывает, что файл использован в чате; если отметки не было — ничего не меняет
+func (r *PgRepo) DetachFromChat(ctx context.Context, fileID, chatID string) error {
+	query := `DELETE FROM file_chats WHERE file_id = $1 AND chat_id = $2`
+	_, err := r.db.ExecContext(ctx, query, fileID, chatID)
+	return err
+}
+
 // Delete удаляет запись о файле по id
 func (r *PgRepo) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM files WHERE id = $1`
@@ -123,3 +123,50 @@ func TestPgRepo_Delete(t *testing.T) {
 		t.Errorf("unfulfilled expectations: %s", err)
 	}
 }
+
+func TestPgRepo_AttachToChat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := NewPgRepo(db)
+	at := time.Now()
+
+	// Повторная отметка того же чата не должна падать на первичном ключе
+	mock.ExpectExec("INSERT INTO file_chats .* ON CONFLICT \\(file_id, chat_id\\) DO NOTHING").
+		WithArgs("file-1", "chat-1", "user-1", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.AttachToChat(context.Background(), "file-1", "chat-1", "user-1", at); err != nil {
+		t.Errorf("error not expected: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPgRepo_DetachFromChat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := NewPgRepo(db)
+
+	// Повторное открепление не ошибка: отметки уже нет
+	mock.ExpectExec("DELETE FROM file_chats WHERE file_id = \\$1 AND chat_id = \\$2").
+		WithArgs("file-1", "chat-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DetachFromChat(context.Background(), "file-1", "chat-1"); err != nil {
+		t.Errorf("error not expected: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"media-service/internal/domain"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 )
@@ -15,6 +16,8 @@ type FileMetadataRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetFilesByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.FileMeta, error)
 	GetTotalFilesByUser(ctx context.Context, userID string) (int, error)
+	AttachToChat(ctx context.Context, fileID, chatID, userID string, at time.Time) error
+	DetachFromChat(ctx context.Context, fileID, chatID string) error
 }
 
 // Интерфейс для работы с Байтами (S3)
@@ -27,4 +30,5 @@ type BlobStorage interface {
 // Интерфейс для Kafka
 type EventProducer interface {
 	SendFileUploaded(meta *domain.FileMeta) error
+	SendFileDeleted(meta *domain.FileMeta, at time.Time) error
 }
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"io"
 	"log"
 	"media-service/internal/domain"
@@ -92,6 +94,38 @@ func (s *MediaService) GetMeta(ctx context.Context, id string) (*domain.FileMeta
 	return s.pg.GetByID(ctx, id)
 }
 
+// AttachToChat разрешает использовать файл во вложении сообщения только его владельцу
+// и запоминает чат, в который файл отправлен. Возвращает настоящие метаданные файла.
+func (s *MediaService) AttachToChat(ctx context.Context, fileID, userID, chatID string) (*domain.FileMeta, error) {
+	meta, err := s.pg.GetByID(ctx, fileID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && meta == nil) {
+		return nil, domain.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if meta.UserID == "" || meta.UserID != userID {
+		return nil, domain.ErrNotFileOwner
+	}
+	if err := s.pg.AttachToChat(ctx, fileID, chatID, userID, time.Now()); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// DetachFromChat вызывается, когда сообщения с файлом стёрты из чата безвозвратно.
+// Сам файл остаётся у владельца: его могут использовать другие чаты
+func (s *MediaService) DetachFromChat(ctx context.Context, fileID, chatID string) error {
+	meta, err := s.pg.GetByID(ctx, fileID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && meta == nil) {
+		return domain.ErrFileNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.pg.DetachFromChat(ctx, fileID, chatID)
+}
+
 func (s *MediaService) DeleteFile(ctx context.Context, id string) error {
 	meta, err := s.pg.GetByID(ctx, id)
 	if err != nil {
@@ -103,7 +137,17 @@ func (s *MediaService) DeleteFile(ctx context.Context, id string) error {
 		return err
 	}
 
-	return s.pg.Delete(ctx, id)
+	if err := s.pg.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// Kafka Event: по нему search-service убирает файл из поиска. Файл уже удалён, поэтому ошибка только логируется
+	if s.kafka != nil {
+		if err := s.kafka.SendFileDeleted(meta, time.Now()); err != nil {
+			log.Printf("Kafka send error: %v", err)
+		}
+	}
+	return nil
 }
 func (s *MediaService) ListUserFiles(ctx context.Context, userID string, limit, offset int) (*domain.FileList, error) {
 	if limit <= 0 {
@@ -3,10 +3,13 @@ package service
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
 	"media-service/internal/domain"
 	"media-service/tests/mocks"
 	"mime/multipart"
 	"testing"
+	"time"
 )
 
 func TestUploadFlow(t *testing.T) {
@@ -94,7 +97,13 @@ func TestDeleteFlow(t *testing.T) {
 		},
 	}
 
-	mockKafka := &mocks.Kafka{}
+	var deleted []string
+	mockKafka := &mocks.Kafka{
+		SendFileDeletedFunc: func(meta *domain.FileMeta, at time.Time) error {
+			deleted = append(deleted, meta.ID)
+			return nil
+		},
+	}
 
 	svc := NewMediaService(mockPg, mockMinio, mockKafka)
 
@@ -105,4 +114,59 @@ func TestDeleteFlow(t *testing.T) {
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
+	if len(deleted) != 1 || deleted[0] != targetID {
+		t.Errorf("Expected file.deleted event for %s, got %v", targetID, deleted)
+	}
+}
+
+func TestAttachToChat(t *testing.T) {
+	owned := &domain.FileMeta{ID: "file-1", UserID: "owner", ContentType: "image/png", Size: 42}
+	newSvc := func(attached *[]string) *MediaService {
+		mockPg := &mocks.PgRepo{
+			GetByIDFunc: func(ctx context.Context, id string) (*domain.FileMeta, error) {
+				if id != owned.ID {
+					return &domain.FileMeta{}, sql.ErrNoRows
+				}
+				return owned, nil
+			},
+			AttachToChatFunc: func(ctx context.Context, fileID, chatID, userID string, at time.Time) error {
+				*attached = append(*attached, fileID+"@"+chatID)
+				return nil
+			},
+		}
+		return NewMediaService(mockPg, &mocks.MinioRepo{}, &mocks.Kafka{})
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		var attached []string
+		meta, err := newSvc(&attached).AttachToChat(context.Background(), "file-1", "owner", "chat-1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if meta.Size != 42 || meta.ContentType != "image/png" {
+			t.Errorf("Expected real metadata, got %+v", meta)
+		}
+		if len(attached) != 1 || attached[0] != "file-1@chat-1" {
+			t.Errorf("Expected chat association to be recorded, got %v", attached)
+		}
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		var attached []string
+		_, err := newSvc(&attached).AttachToChat(context.Background(), "file-1", "intruder", "chat-1")
+		if !errors.Is(err, domain.ErrNotFileOwner) {
+			t.Errorf("Expected ErrNotFileOwner, got %v", err)
+		}
+		if len(attached) != 0 {
+			t.Error("Foreign file must not be attached")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		var attached []string
+		_, err := newSvc(&attached).AttachToChat(context.Background(), "nope", "owner", "chat-1")
+		if !errors.Is(err, domain.ErrFileNotFound) {
+			t.Errorf("Expected ErrFileNotFound, got %v", err)
+		}
+	})
 }
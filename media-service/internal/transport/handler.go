@@ -2,9 +2,11 @@ package transport
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"media-service/internal/domain"
 	"media-service/internal/service"
 	"net/http"
 	"strconv"
@@ -111,6 +113,66 @@ func (h *Handler) GetFileMeta(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(meta)
 }
 
+// POST /media/{id}/attach — вызывается chat-service при отправке вложения.
+// Body: {"user_id": "...", "chat_id": "..."}; ответ — метаданные файла (JSON)
+func (h *Handler) AttachToChat(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req struct {
+		UserID string `json:"user_id"`
+		ChatID string `json:"chat_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.ChatID == "" {
+		http.Error(w, "user_id and chat_id are required", http.StatusBadRequest)
+		return
+	}
+
+	meta, err := h.svc.AttachToChat(r.Context(), id, req.UserID, req.ChatID)
+	switch {
+	case errors.Is(err, domain.ErrFileNotFound):
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	case errors.Is(err, domain.ErrNotFileOwner):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case err != nil:
+		log.Printf("ATTACH ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meta)
+}
+
+// POST /media/{id}/detach — вызывается chat-service, когда сообщения с файлом стёрты.
+// Body: {"chat_id": "..."}
+func (h *Handler) DetachFromChat(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req struct {
+		ChatID string `json:"chat_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChatID == "" {
+		http.Error(w, "chat_id is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.svc.DetachFromChat(r.Context(), id, req.ChatID)
+	switch {
+	case errors.Is(err, domain.ErrFileNotFound):
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	case err != nil:
+		log.Printf("DETACH ERROR: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"status":"detached"}`))
+}
+
 // DELETE /media/delete/{id}
 func (h *Handler) DeleteFile(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
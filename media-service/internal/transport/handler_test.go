@@ -3,10 +3,12 @@ package transport
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"media-service/internal/domain"
@@ -94,3 +96,88 @@ func TestDeleteHandler(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
 	}
 }
+
+func TestAttachHandler(t *testing.T) {
+	mockPg := &mocks.PgRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.FileMeta, error) {
+			return &domain.FileMeta{ID: id, UserID: "owner", ContentType: "image/jpeg", Size: 10}, nil
+		},
+	}
+	handler := NewHandler(service.NewMediaService(mockPg, &mocks.MinioRepo{}, &mocks.Kafka{}))
+	router := mux.NewRouter()
+	router.HandleFunc("/media/{id}/attach", handler.AttachToChat).Methods("POST")
+
+	cases := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"owner", `{"user_id":"owner","chat_id":"chat-1"}`, http.StatusOK},
+		{"other user", `{"user_id":"intruder","chat_id":"chat-1"}`, http.StatusForbidden},
+		{"no chat", `{"user_id":"owner"}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/media/file-1/attach", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tc.code)
+			}
+			if tc.code == http.StatusOK {
+				var meta domain.FileMeta
+				json.NewDecoder(rr.Body).Decode(&meta)
+				if meta.ID != "file-1" || meta.ContentType != "image/jpeg" {
+					t.Errorf("Unexpected metadata: %+v", meta)
+				}
+			}
+		})
+	}
+}
+
+func TestDetachHandler(t *testing.T) {
+	var detached []string
+	mockPg := &mocks.PgRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.FileMeta, error) {
+			if id != "file-1" {
+				return nil, sql.ErrNoRows
+			}
+			return &domain.FileMeta{ID: id, UserID: "owner"}, nil
+		},
+		DetachFromChatFunc: func(ctx context.Context, fileID, chatID string) error {
+			detached = append(detached, fileID+"@"+chatID)
+			return nil
+		},
+	}
+	handler := NewHandler(service.NewMediaService(mockPg, &mocks.MinioRepo{}, &mocks.Kafka{}))
+	router := mux.NewRouter()
+	router.HandleFunc("/media/{id}/detach", handler.DetachFromChat).Methods("POST")
+
+	cases := []struct {
+		name string
+		path string
+		body string
+		code int
+	}{
+		{"detached", "/media/file-1/detach", `{"chat_id":"chat-1"}`, http.StatusOK},
+		{"missing file", "/media/nope/detach", `{"chat_id":"chat-1"}`, http.StatusNotFound},
+		{"no chat", "/media/file-1/detach", `{}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tc.path, strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tc.code)
+			}
+		})
+	}
+	if len(detached) != 1 || detached[0] != "file-1@chat-1" {
+		t.Errorf("Expected one detached file, got %v", detached)
+	}
+}
@@ -0,0 +1,12 @@
+-- Чаты, в сообщениях которых использован файл (заполняет chat-service при отправке вложения).
+-- Без внешнего ключа на files: docker-entrypoint применяет миграции по алфавиту, раньше init.sql
+CREATE TABLE IF NOT EXISTS file_chats (
+    file_id VARCHAR(255) NOT NULL,
+    chat_id VARCHAR(255) NOT NULL,
+    attached_by VARCHAR(255) NOT NULL,
+    attached_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (file_id, chat_id)
+);
+
+-- Создание индекса для поиска файлов чата
+CREATE INDEX IF NOT EXISTS idx_file_chats_chat_id ON file_chats(chat_id);
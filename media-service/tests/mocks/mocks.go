@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"media-service/internal/domain"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 )
@@ -17,6 +18,8 @@ type PgRepo struct {
 	// НОВЫЕ ПОЛЯ для реализации полного интерфейса:
 	GetFilesByUserFunc      func(ctx context.Context, userID string, limit, offset int) ([]*domain.FileMeta, error)
 	GetTotalFilesByUserFunc func(ctx context.Context, userID string) (int, error)
+	AttachToChatFunc        func(ctx context.Context, fileID, chatID, userID string, at time.Time) error
+	DetachFromChatFunc      func(ctx context.Context, fileID, chatID string) error
 }
 
 func (m *PgRepo) Save(ctx context.Context, f *domain.FileMeta) error {
@@ -56,6 +59,20 @@ func (m *PgRepo) GetTotalFilesByUser(ctx context.Context, userID string) (int, e
 	return 0, nil
 }
 
+func (m *PgRepo) AttachToChat(ctx context.Context, fileID, chatID, userID string, at time.Time) error {
+	if m.AttachToChatFunc != nil {
+		return m.AttachToChatFunc(ctx, fileID, chatID, userID, at)
+	}
+	return nil
+}
+
+func (m *PgRepo) DetachFromChat(ctx context.Context, fileID, chatID string) error {
+	if m.DetachFromChatFunc != nil {
+		return m.DetachFromChatFunc(ctx, fileID, chatID)
+	}
+	return nil
+}
+
 // --- Mock MinIO ---
 type MinioRepo struct {
 	UploadFunc func() error
@@ -80,6 +97,15 @@ func (m *MinioRepo) Delete(ctx context.Context, bucket, name string) error {
 }
 
 // --- Mock Kafka ---
-type Kafka struct{}
+type Kafka struct {
+	SendFileDeletedFunc func(meta *domain.FileMeta, at time.Time) error
+}
 
 func (m *Kafka) SendFileUploaded(meta *domain.FileMeta) error { return nil }
+
+func (m *Kafka) SendFileDeleted(meta *domain.FileMeta, at time.Time) error {
+	if m.SendFileDeletedFunc != nil {
+		return m.SendFileDeletedFunc(meta, at)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"media-service/internal/domain"
 	"testing"
+	"time"
 )
 
 func TestPgRepo_Coverage(t *testing.T) {
@@ -87,6 +88,19 @@ func TestPgRepo_Coverage(t *testing.T) {
 			t.Error("Expected 5 count")
 		}
 	})
+
+	t.Run("AttachToChat", func(t *testing.T) {
+		m := &PgRepo{}
+		// 1. Default
+		if err := m.AttachToChat(ctx, "f", "c", "u", time.Now()); err != nil {
+			t.Error("Expected nil error when func is nil")
+		}
+		// 2. Custom
+		m.AttachToChatFunc = func(_ context.Context, _, _, _ string, _ time.Time) error { return testErr }
+		if err := m.AttachToChat(ctx, "f", "c", "u", time.Now()); err != testErr {
+			t.Error("Expected custom error")
+		}
+	})
 }
 
 func TestMinioRepo_Coverage(t *testing.T) {
@@ -11,6 +11,7 @@ import (
 	"main/internal/service"
 	trgrpc "main/internal/transport/grpc"
 	"main/internal/transport/websocket"
+	userserviceclient "main/internal/user-service-client"
 	api "main/pkg/api"
 
 	"github.com/gorilla/mux"
@@ -29,8 +30,20 @@ func main() {
 	}
 	redisRepo := repository.NewRoomRedisRepo(redisAddr)
 
+	// user-service нужен для проверки блокировок при входе в комнату
+	var blocks service.BlockChecker
+	if userAddr := os.Getenv("USER_SERVICE_ADDR"); userAddr != "" {
+		userClient, err := userserviceclient.New(userAddr)
+		if err != nil {
+			log.Fatalf("failed to connect to user-service: %v", err)
+		}
+		blocks = userClient
+	} else {
+		log.Println("USER_SERVICE_ADDR is not set, blocks are not checked")
+	}
+
 	// Сервис
-	svc := service.NewRoomService(redisRepo)
+	svc := service.NewRoomService(redisRepo, blocks)
 
 	// WebSocket сервер
 	wsServer := websocket.NewWebSocketServer()
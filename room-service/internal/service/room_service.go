@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
 	"time"
 
@@ -16,12 +18,21 @@ type RoomService interface {
 	GetState(ctx context.Context, req *api.GetStateRequest) (*api.RoomResponse, error)
 }
 
+// ErrJoinNotAllowed — вход в комнату запрещён. Причину (блокировку) не раскрываем
+var ErrJoinNotAllowed = errors.New("cannot join room")
+
+// BlockChecker сообщает, кто из users заблокировал userID
+type BlockChecker interface {
+	BlockedBy(ctx context.Context, userID string, users []string) ([]string, error)
+}
+
 type roomService struct {
-	repo repository.RoomRepository
+	repo   repository.RoomRepository
+	blocks BlockChecker // nil — блокировки не проверяются
 }
 
-func NewRoomService(repo repository.RoomRepository) RoomService {
-	return &roomService{repo: repo}
+func NewRoomService(repo repository.RoomRepository, blocks BlockChecker) RoomService {
+	return &roomService{repo: repo, blocks: blocks}
 }
 
 func (s *roomService) CreateRoom(ctx context.Context, req *api.CreateRoomRequest) (*api.RoomResponse, error) {
@@ -65,6 +76,17 @@ func (s *roomService) JoinRoom(ctx context.Context, req *api.JoinRoomRequest) (*
 
 	// Добавляем пользователя, если его нет
 	if !userExists {
+		// Нельзя войти в комнату, где есть заблокировавший тебя
+		if s.blocks != nil {
+			blockedBy, err := s.blocks.BlockedBy(ctx, req.UserId, room.Users)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check blocks: %w", err)
+			}
+			if len(blockedBy) > 0 {
+				return nil, ErrJoinNotAllowed
+			}
+		}
+
 		room.Users = append(room.Users, req.UserId)
 		room.Timestamp = time.Now().Unix()
 
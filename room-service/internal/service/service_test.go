@@ -38,7 +38,7 @@ var ErrNotFound = errors.New("not found")
 func TestCreateRoom(t *testing.T) {
 	// Создаем тестовый репозиторий
 	repo := newTestRepo()
-	service := NewRoomService(repo)
+	service := NewRoomService(repo, nil)
 
 	// Тест 1: Создание комнаты
 	req := &api.CreateRoomRequest{
@@ -80,7 +80,7 @@ func TestCreateRoom(t *testing.T) {
 
 func TestGetState(t *testing.T) {
 	repo := newTestRepo()
-	service := NewRoomService(repo)
+	service := NewRoomService(repo, nil)
 
 	// Сначала создаем комнату
 	createReq := &api.CreateRoomRequest{
@@ -116,7 +116,7 @@ func TestGetState(t *testing.T) {
 
 func TestJoinRoom(t *testing.T) {
 	repo := newTestRepo()
-	service := NewRoomService(repo)
+	service := NewRoomService(repo, nil)
 
 	// Создаем комнату
 	createReq := &api.CreateRoomRequest{
@@ -174,9 +174,52 @@ func TestJoinRoom(t *testing.T) {
 	}
 }
 
+// Тестовая проверка блокировок: blockers[userID] — кто заблокировал userID
+type testBlocks struct {
+	blockers map[string][]string
+}
+
+func (b *testBlocks) BlockedBy(ctx context.Context, userID string, users []string) ([]string, error) {
+	var result []string
+	for _, blocker := range b.blockers[userID] {
+		for _, user := range users {
+			if user == blocker {
+				result = append(result, blocker)
+			}
+		}
+	}
+	return result, nil
+}
+
+func TestJoinRoom_Blocked(t *testing.T) {
+	repo := newTestRepo()
+	blocks := &testBlocks{blockers: map[string][]string{"user1": {"host"}}}
+	service := NewRoomService(repo, blocks)
+
+	createdRoom, _ := service.CreateRoom(context.Background(), &api.CreateRoomRequest{HostId: "host", TrackUrl: "song.mp3"})
+
+	// Тест 1: Хост заблокировал пользователя — вход запрещён
+	_, err := service.JoinRoom(context.Background(), &api.JoinRoomRequest{RoomId: createdRoom.RoomId, UserId: "user1"})
+	if !errors.Is(err, ErrJoinNotAllowed) {
+		t.Fatalf("Ожидалась ErrJoinNotAllowed, получил %v", err)
+	}
+	if len(repo.savedRooms[createdRoom.RoomId].Users) != 1 {
+		t.Error("Заблокированный пользователь не должен попасть в комнату")
+	}
+
+	// Тест 2: Остальные входят как обычно
+	room, err := service.JoinRoom(context.Background(), &api.JoinRoomRequest{RoomId: createdRoom.RoomId, UserId: "user2"})
+	if err != nil {
+		t.Fatalf("Ошибка при присоединении: %v", err)
+	}
+	if len(room.Users) != 2 {
+		t.Errorf("Ожидалось 2 пользователя, получил %d", len(room.Users))
+	}
+}
+
 func TestSetPlayback(t *testing.T) {
 	repo := newTestRepo()
-	service := NewRoomService(repo)
+	service := NewRoomService(repo, nil)
 
 	// Создаем комнату
 	createReq := &api.CreateRoomRequest{
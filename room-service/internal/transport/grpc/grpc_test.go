@@ -6,7 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"main/internal/service"
 	api "main/pkg/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Простой мок сервиса
@@ -255,6 +259,23 @@ func TestHandlerErrorPropagation(t *testing.T) {
 	}
 }
 
+func TestJoinRoomHandler_NotAllowed(t *testing.T) {
+	mockSvc := &mockService{
+		joinRoomFunc: func(ctx context.Context, req *api.JoinRoomRequest) (*api.RoomResponse, error) {
+			return nil, service.ErrJoinNotAllowed
+		},
+	}
+
+	handler := NewRoomHandler(mockSvc, nil)
+
+	_, err := handler.JoinRoom(context.Background(), &api.JoinRoomRequest{RoomId: "ABC123", UserId: "user2"})
+
+	// Причина отказа клиенту не раскрывается
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Ожидался PermissionDenied, получил %v", err)
+	}
+}
+
 // func TestNilRequestHandling(t *testing.T) {
 // 	mockSvc := &mockService{}
 // 	handler := NewRoomHandler(mockSvc, nil)
@@ -2,9 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"main/internal/service"
 	"main/internal/transport/websocket"
 	api "main/pkg/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type RoomHandler struct {
@@ -21,7 +25,11 @@ func (h *RoomHandler) CreateRoom(ctx context.Context, req *api.CreateRoomRequest
 }
 
 func (h *RoomHandler) JoinRoom(ctx context.Context, req *api.JoinRoomRequest) (*api.RoomResponse, error) {
-	return h.svc.JoinRoom(ctx, req)
+	room, err := h.svc.JoinRoom(ctx, req)
+	if errors.Is(err, service.ErrJoinNotAllowed) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return room, err
 }
 
 func (h *RoomHandler) SetPlayback(ctx context.Context, req *api.SetPlaybackRequest) (*api.RoomResponse, error) {
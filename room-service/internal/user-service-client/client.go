@@ -0,0 +1,35 @@
+package userserviceclient
+
+import (
+	"context"
+
+	userpb "main/pkg/api_user_service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client — клиент user-service для проверки блокировок
+type Client struct {
+	api userpb.UserServiceClient
+}
+
+func New(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{api: userpb.NewUserServiceClient(conn)}, nil
+}
+
+// BlockedBy возвращает тех из users, кто заблокировал userID
+func (c *Client) BlockedBy(ctx context.Context, userID string, users []string) ([]string, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+	resp, err := c.api.CheckBlocked(ctx, &userpb.CheckBlockedRequest{Uuid: userID, UserUuids: users})
+	if err != nil {
+		return nil, err
+	}
+	return resp.BlockedBy, nil
+}
@@ -3,15 +3,16 @@ package main
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	"time"
 
+	"contracts/events"
+
 	_ "github.com/lib/pq"
 	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
 
 	"main/internal/config"
-	"main/internal/domain"
 	"main/internal/logger"
 	"main/internal/repository/es"
 	"main/internal/repository/postgres"
@@ -20,11 +21,6 @@ import (
 	kafkaconsumer "main/internal/transport/kafka"
 )
 
-type SearchEvent struct {
-	Type string      `json:"type"`
-	Data interface{} `json:"data"`
-}
-
 // Функция для создания всех необходимых топиков
 func ensureTopics(broker string) error {
 	conn, err := kafka.Dial("tcp", broker)
@@ -78,109 +74,65 @@ func ensureTopics(broker string) error {
 func sendTestMessages(w *kafka.Writer) error {
 	ctx := context.Background()
 	log := logger.GetLogger()
-	// 1. Тестовое сообщение типа "message"
-	msgEvent := SearchEvent{
-		Type: "message",
-		Data: domain.Message{
-			ID:        "msg-" + time.Now().Format("20060102150405"),
-			ChatID:    "chat-001",
-			AuthorID:  "user-001",
+	now := time.Now()
+
+	// Тестовые события в формате общих контрактов: сообщение, пользователь, чат и два файла
+	testEvents := []struct {
+		key      string
+		producer string
+		payload  proto.Message
+	}{
+		{"chat-001", events.ProducerChat, &events.MessageUpserted{Message: &events.Message{
+			Id:        "msg-" + now.Format("20060102150405"),
+			ChatId:    "chat-001",
+			AuthorId:  "user-001",
 			Text:      "Привет, как дела?",
-			CreatedAt: time.Now().Unix(),
-		},
-	}
-	b1, _ := json.Marshal(msgEvent)
-	if err := w.WriteMessages(ctx, kafka.Message{
-		Key:   []byte("message"),
-		Value: b1,
-	}); err != nil {
-		return fmt.Errorf("failed to write message: %w", err)
-	}
-	log.Info().Msg(" Test message sent to Kafka")
-
-	// 2. Тестовое сообщение типа "user"
-	userEvent := SearchEvent{
-		Type: "user",
-		Data: domain.UserIndex{
-			ID:        "user-001",
+			CreatedAt: now.Unix(),
+		}}},
+		{"user-001", events.ProducerUser, &events.UserUpserted{User: &events.User{
+			Id:        "user-001",
 			UserName:  "Алексей Петров",
 			Email:     "alexey@example.com",
 			AboutMe:   "Разработчик ПО, увлекаюсь фотографией",
-			UpdatedAt: time.Now().Unix(),
-		},
-	}
-	b2, _ := json.Marshal(userEvent)
-	if err := w.WriteMessages(ctx, kafka.Message{
-		Key:   []byte("user"),
-		Value: b2,
-	}); err != nil {
-		return fmt.Errorf("failed to write user: %w", err)
-	}
-	log.Info().Msg(" Test user sent to Kafka")
-
-	// 3. Тестовое сообщение типа "chat"
-	chatEvent := SearchEvent{
-		Type: "chat",
-		Data: domain.ChatIndex{
-			ID:        "chat-001",
+			UpdatedAt: now.Unix(),
+		}}},
+		{"chat-001", events.ProducerChat, &events.ChatUpserted{Chat: &events.Chat{
+			Id:        "chat-001",
 			Title:     "Общий чат проекта",
-			MemberIDs: []string{"user-001", "user-002", "user-003"},
+			MemberIds: []string{"user-001", "user-002", "user-003"},
 			Kind:      "group",
-			UpdatedAt: time.Now().Unix(),
-		},
-	}
-	b3, _ := json.Marshal(chatEvent)
-	if err := w.WriteMessages(ctx, kafka.Message{
-		Key:   []byte("chat"),
-		Value: b3,
-	}); err != nil {
-		return fmt.Errorf("failed to write chat: %w", err)
-	}
-	log.Info().Msg(" Test chat sent to Kafka")
-
-	// 4. Тестовое сообщение типа "file" (медиа)
-	fileEvent := SearchEvent{
-		Type: "file.created",
-		Data: domain.FileIndex{
-			ID:        "file-" + time.Now().Format("20060102150405"),
-			URL:       "http://localhost:9000/media-bucket/presentations/project.pdf",
-			Type:      "document",
-			SizeBytes: 5242880, // 5MB
-			AuthorID:  "user-001",
-			ChatID:    "chat-001",
-			UpdatedAt: time.Now().Unix(),
-		},
-	}
-	b4, _ := json.Marshal(fileEvent)
-	if err := w.WriteMessages(ctx, kafka.Message{
-		Key:   []byte("file"),
-		Value: b4,
-	}); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+			CreatedAt: now.Unix(),
+		}}},
+		{"file-" + now.Format("20060102150405"), events.ProducerMedia, &events.FileUploaded{
+			Id:          "file-" + now.Format("20060102150405"),
+			Filename:    "project.pdf",
+			ContentType: "application/pdf",
+			Size:        5242880, // 5MB
+			UserId:      "user-001",
+			ChatId:      "chat-001",
+			CreatedAt:   now.Unix(),
+		}},
+		{"img-" + now.Format("20060102150405"), events.ProducerMedia, &events.FileUploaded{
+			Id:          "img-" + now.Format("20060102150405"),
+			Filename:    "architecture.png",
+			ContentType: "image/png",
+			Size:        2097152, // 2MB
+			UserId:      "user-002",
+			ChatId:      "chat-001",
+			CreatedAt:   now.Unix(),
+		}},
 	}
-	log.Info().Msg(" Test file sent to Kafka")
-
-	// 5. Еще один файл (изображение)
-	imageEvent := SearchEvent{
-		Type: "file",
-		Data: domain.FileIndex{
-			ID:        "img-" + time.Now().Format("20060102150405"),
-			URL:       "http://localhost:9000/media-bucket/images/architecture.png",
-			Type:      "image",
-			SizeBytes: 2097152, // 2MB
-			AuthorID:  "user-002",
-			ChatID:    "chat-001",
-			UpdatedAt: time.Now().Unix(),
-		},
-	}
-	b5, _ := json.Marshal(imageEvent)
-	if err := w.WriteMessages(ctx, kafka.Message{
-		Key:   []byte("image"),
-		Value: b5,
-	}); err != nil {
-		return fmt.Errorf("failed to write image: %w", err)
+
+	for _, e := range testEvents {
+		value, err := events.Marshal(e.producer, e.payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode %T: %w", e.payload, err)
+		}
+		if err := w.WriteMessages(ctx, kafka.Message{Key: []byte(e.key), Value: value}); err != nil {
+			return fmt.Errorf("failed to write %T: %w", e.payload, err)
+		}
+		log.Info().Msgf(" Test %T sent to Kafka", e.payload)
 	}
-	log.Info().Msg(" Test image sent to Kafka")
 
 	return nil
 }
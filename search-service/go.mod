@@ -3,12 +3,14 @@ module main
 go 1.25.4
 
 require (
+	contracts v0.0.0
 	github.com/elastic/go-elasticsearch/v8 v8.19.0
 	github.com/google/uuid v1.6.0
 	github.com/lib/pq v1.10.9
 	github.com/rs/zerolog v1.34.0
 	github.com/segmentio/kafka-go v0.4.49
 	github.com/stretchr/testify v1.9.0
+	google.golang.org/protobuf v1.36.10
 )
 
 require (
@@ -27,3 +29,6 @@ require (
 	golang.org/x/sys v0.19.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// Общие контракты событий Kafka (в Docker собираются из корня репозитория)
+replace contracts => ../contracts
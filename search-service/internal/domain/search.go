@@ -76,6 +76,15 @@ type Message struct {
 	UpdatedAt int64  `json:"updated_at"`
 	Deleted   bool   `json:"deleted"`
 }
+
+// MessagesDeleted — событие "message.deleted" из chat-service
+type MessagesDeleted struct {
+	ChatID     string   `json:"chat_id"`
+	MessageIDs []string `json:"message_ids"`
+	MediaIDs   []string `json:"media_ids"`
+	Reason     string   `json:"reason"`
+}
+
 type Media struct {
 	ID          string    `json:"id"`
 	Filename    string    `json:"filename"`
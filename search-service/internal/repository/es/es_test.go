@@ -125,6 +125,18 @@ func TestRepo_DeleteFile(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRepo_DeleteMessage_AlreadyGone(t *testing.T) {
+	repo := newTestRepo(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "DELETE", req.Method)
+		assert.Contains(t, req.URL.Path, "/messages/_doc/msg-1")
+		return mockResponse(404, `{"result": "not_found"}`)
+	})
+
+	// Повторное событие об удалении не должно считаться ошибкой
+	err := repo.DeleteMessage(context.Background(), "msg-1")
+	assert.NoError(t, err)
+}
+
 func TestRepo_Search(t *testing.T) {
 	// Подготавливаем JSON ответ от ElasticSearch
 	esResponse := `{
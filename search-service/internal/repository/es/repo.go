@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"main/internal/domain"
+	"net/http"
 	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
@@ -333,8 +334,21 @@ func (r *Repo) Search(ctx context.Context, q domain.SearchQuery) ([]domain.Searc
 	return out, nil
 }
 func (r *Repo) DeleteFile(ctx context.Context, id string) error {
+	return r.delete(ctx, r.fileIndex, id)
+}
+
+func (r *Repo) DeleteMessage(ctx context.Context, id string) error {
+	return r.delete(ctx, r.msgIndex, id)
+}
+
+func (r *Repo) DeleteUser(ctx context.Context, id string) error {
+	return r.delete(ctx, r.userIndex, id)
+}
+
+// delete удаляет документ; отсутствующий документ не считается ошибкой (событие могло прийти повторно)
+func (r *Repo) delete(ctx context.Context, index, id string) error {
 	req := esapi.DeleteRequest{
-		Index:      r.fileIndex,
+		Index:      index,
 		DocumentID: id,
 	}
 
@@ -344,7 +358,7 @@ func (r *Repo) DeleteFile(ctx context.Context, id string) error {
 	}
 	defer res.Body.Close()
 
-	if res.IsError() {
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
 		return fmt.Errorf("es delete error: %s", res.String())
 	}
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
 )
 
@@ -29,6 +30,15 @@ func (r *Repo) SaveMessage(ctx context.Context, m domain.Message, log zerolog.Lo
 	return err
 }
 
+// DeleteMessages удаляет сообщения, удалённые в chat-service безвозвратно
+func (r *Repo) DeleteMessages(ctx context.Context, ids []string, log zerolog.Logger) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM messages WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		log.Err(err).Msg("error DeleteMessages postgres")
+	}
+	return err
+}
+
 func (r *Repo) SaveChat(ctx context.Context, c domain.Chat, log zerolog.Logger) error {
 	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO chats (id, kind, title, created_by, created_at)
@@ -2,21 +2,19 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"errors"
 	"main/internal/domain"
 	"main/internal/repository/es"
 	"main/internal/repository/postgres"
 	"time"
 
+	"contracts/events"
+
 	"github.com/rs/zerolog"
 	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
 )
 
-type SearchEvent struct {
-	Type string      `json:"type"` // "user", "chat", "message"
-	Data interface{} `json:"data"` // сам объект
-}
 type Consumer struct {
 	reader *kafka.Reader
 	esRepo *es.Repo
@@ -32,176 +30,150 @@ func NewConsumer(brokers []string, topic, groupID string, esRepo *es.Repo, pgRep
 		Topic:   topic,
 		Logger:  &log,
 	})
-	return &Consumer{reader: r, esRepo: esRepo, pgRepo: pgRepo, topic: topic}
+	return &Consumer{reader: r, esRepo: esRepo, pgRepo: pgRepo, topic: topic, log: log}
 }
 
+// Run читает конверты общих контрактов (contracts/events) до отмены ctx.
+// События чужих типов и неизвестных версий пропускаются: поиску нужны не все события топика.
 func (c *Consumer) Run(ctx context.Context) {
 	for {
 		m, err := c.reader.ReadMessage(ctx)
 		if err != nil {
-			log.Printf("Kafka read error: %v", err)
+			if ctx.Err() != nil {
+				return
+			}
+			c.log.Err(err).Str("topic", c.topic).Msg("Kafka read error")
 			continue
 		}
 
-		var evt SearchEvent
-		if err := json.Unmarshal(m.Value, &evt); err != nil {
-			c.log.Err(err).Msg(" Unmarshal error")
+		env, payload, err := events.Unmarshal(m.Value)
+		if errors.Is(err, events.ErrUnknownType) || errors.Is(err, events.ErrUnsupportedVersion) {
+			c.log.Debug().Str("type", env.Type).Uint32("version", env.Version).Msg("event skipped")
 			continue
 		}
-		c.log.Info().Msg("")
-
-		switch evt.Type {
-		case "user":
-			var u domain.UserIndex
-			b, _ := json.Marshal(evt.Data)
-			if err := json.Unmarshal(b, &u); err != nil {
-				c.log.Err(err).Msg(" User decode error")
-				continue
-			}
-			if err := c.pgRepo.SaveUser(ctx, u, c.log); err != nil {
-				c.log.Err(err).Msg(" PG save user error")
-
-			}
-			if err := c.esRepo.IndexUser(ctx, u); err != nil {
-				c.log.Err(err).Msg(" ES index user error")
-
-			}
-			c.log.Info().Msg("sent new user")
-
-		case "chat":
-			var chat domain.Chat
-			b, _ := json.Marshal(evt.Data)
-			if err := json.Unmarshal(b, &chat); err != nil {
-				c.log.Err(err).Msg(" Chat decode error")
-
-				continue
-			}
-			if err := c.pgRepo.SaveChat(ctx, chat, c.log); err != nil {
-				c.log.Err(err).Msg(" PG save chat error")
-
-				log.Printf("PG save chat error: %v", err)
-			}
-			if err := c.esRepo.IndexChat(ctx, chat); err != nil {
-				c.log.Err(err).Msg(" ES index chat error")
-
-			}
-			c.log.Info().Msg("sent new chat")
-
-		case "message":
-			var msg domain.Message
-			b, _ := json.Marshal(evt.Data)
-			if err := json.Unmarshal(b, &msg); err != nil {
-				c.log.Err(err).Msg(" Message decode error")
-				continue
-			}
-			if err := c.pgRepo.SaveMessage(ctx, msg, c.log); err != nil {
-				c.log.Err(err).Msg(" PG save message error")
-
-			}
-			if err := c.esRepo.IndexMessage(ctx, msg); err != nil {
-				c.log.Err(err).Msg(" ES index message error")
-
-			}
-			c.log.Info().Msg("sent new message")
-
-		case "file.events":
-			c.handleFileEvent(ctx, evt.Data)
-
-		case "file.created", "file.uploaded":
-			c.handleFileCreated(ctx, evt.Data)
-
-		case "file.deleted":
-			c.handleFileDeleted(ctx, evt.Data)
-
-		case "file.updated":
-			c.handleFileUpdated(ctx, evt.Data)
+		if err != nil {
+			c.log.Err(err).Str("topic", c.topic).Msg(" Event decode error")
+			continue
 		}
-
+		c.handle(ctx, payload)
 	}
 }
 
-// handleFileEvent обрабатывает события связанные с файлами
-func (c *Consumer) handleFileEvent(ctx context.Context, data interface{}) {
-	var file domain.FileIndex
-	b, _ := json.Marshal(data)
-	if err := json.Unmarshal(b, &file); err != nil {
-		c.log.Err(err).Msg(" File decode error")
-		return
+func (c *Consumer) handle(ctx context.Context, payload proto.Message) {
+	switch p := payload.(type) {
+	case *events.UserUpserted:
+		c.handleUserUpserted(ctx, p.GetUser())
+	case *events.UserDeleted:
+		if err := c.esRepo.DeleteUser(ctx, p.GetId()); err != nil {
+			c.log.Err(err).Str("user_id", p.GetId()).Msg(" ES delete user error")
+		}
+	case *events.ChatUpserted:
+		c.handleChatUpserted(ctx, p.GetChat())
+	case *events.MessageUpserted:
+		c.handleMessageUpserted(ctx, p.GetMessage())
+	case *events.MessagesDeleted:
+		c.handleMessagesDeleted(ctx, p)
+	case *events.FileUploaded:
+		c.handleFileUploaded(ctx, p)
+	case *events.FileDeleted:
+		if err := c.esRepo.DeleteFile(ctx, p.GetId()); err != nil {
+			c.log.Err(err).Str("file_id", p.GetId()).Msg(" ES delete file error")
+		}
 	}
+}
 
-	// Индексируем файл в Elasticsearch
-	if err := c.esRepo.IndexFile(ctx, file); err != nil {
-		c.log.Err(err).Msg(" ES index file error")
-		return
+func (c *Consumer) handleUserUpserted(ctx context.Context, user *events.User) {
+	u := domain.UserIndex{
+		ID:        user.GetId(),
+		UUID:      user.GetId(),
+		UserName:  user.GetUserName(),
+		Email:     user.GetEmail(),
+		AboutMe:   user.GetAboutMe(),
+		Friends:   user.GetFriends(),
+		UpdatedAt: user.GetUpdatedAt(),
 	}
-
-	c.log.Info().Str("file_id", file.ID).Msg("File indexed in Elasticsearch")
+	if err := c.pgRepo.SaveUser(ctx, u, c.log); err != nil {
+		c.log.Err(err).Msg(" PG save user error")
+	}
+	if err := c.esRepo.IndexUser(ctx, u); err != nil {
+		c.log.Err(err).Msg(" ES index user error")
+	}
+	c.log.Info().Str("user_id", u.UUID).Msg("user indexed")
 }
 
-// handleFileCreated обрабатывает создание файла
-func (c *Consumer) handleFileCreated(ctx context.Context, data interface{}) {
-	var file domain.FileIndex
-	b, _ := json.Marshal(data)
-	if err := json.Unmarshal(b, &file); err != nil {
-		c.log.Err(err).Msg(" File created decode error")
-		return
+func (c *Consumer) handleChatUpserted(ctx context.Context, chat *events.Chat) {
+	ch := domain.Chat{
+		ID:        chat.GetId(),
+		Kind:      chat.GetKind(),
+		MemberIDs: chat.GetMemberIds(),
+		Title:     chat.GetTitle(),
+		CreatedBy: chat.GetCreatedBy(),
+		CreatedAt: chat.GetCreatedAt(),
 	}
-
-	// Устанавливаем timestamp если не установлен
-	if file.UpdatedAt == 0 {
-		file.UpdatedAt = time.Now().Unix()
+	if err := c.pgRepo.SaveChat(ctx, ch, c.log); err != nil {
+		c.log.Err(err).Msg(" PG save chat error")
 	}
-
-	// Индексируем в Elasticsearch
-	if err := c.esRepo.IndexFile(ctx, file); err != nil {
-		c.log.Err(err).Msg(" ES index file (created) error")
-		return
+	if err := c.esRepo.IndexChat(ctx, ch); err != nil {
+		c.log.Err(err).Msg(" ES index chat error")
 	}
-
-	c.log.Info().Str("file_id", file.ID).Str("filename", file.ID).Msg("File created event processed")
+	c.log.Info().Str("chat_id", ch.ID).Msg("chat indexed")
 }
 
-// handleFileDeleted обрабатывает удаление файла
-func (c *Consumer) handleFileDeleted(ctx context.Context, data interface{}) {
-	var payload map[string]interface{}
-	b, _ := json.Marshal(data)
-	if err := json.Unmarshal(b, &payload); err != nil {
-		c.log.Err(err).Msg(" File deleted decode error")
-		return
+func (c *Consumer) handleMessageUpserted(ctx context.Context, msg *events.Message) {
+	m := domain.Message{
+		ID:        msg.GetId(),
+		ChatID:    msg.GetChatId(),
+		AuthorID:  msg.GetAuthorId(),
+		Text:      msg.GetText(),
+		CreatedAt: msg.GetCreatedAt(),
+		UpdatedAt: msg.GetUpdatedAt(),
+		Deleted:   msg.GetDeleted(),
 	}
-
-	fileID, ok := payload["id"].(string)
-	if !ok {
-		c.log.Error().Msg("File deleted event missing ID")
-		return
+	if err := c.pgRepo.SaveMessage(ctx, m, c.log); err != nil {
+		c.log.Err(err).Msg(" PG save message error")
+	}
+	if err := c.esRepo.IndexMessage(ctx, m); err != nil {
+		c.log.Err(err).Msg(" ES index message error")
 	}
+	c.log.Info().Str("message_id", m.ID).Msg("message indexed")
+}
 
-	// TODO: Удалить файл из Elasticsearch
-	// Нужно добавить метод DeleteFile в es.Repo
-	// if err := c.esRepo.DeleteFile(ctx, fileID); err != nil {
-	//     c.log.Err(err).Msg(" ES delete file error")
-	// }
+// handleMessagesDeleted убирает из индексов безвозвратно удалённые сообщения. Вложения (media_ids) не трогаются:
+// файл остаётся в media-service и может быть прикреплён к другим сообщениям, из поиска его убирает media.file.deleted
+func (c *Consumer) handleMessagesDeleted(ctx context.Context, evt *events.MessagesDeleted) {
+	for _, id := range evt.GetMessageIds() {
+		if err := c.esRepo.DeleteMessage(ctx, id); err != nil {
+			c.log.Err(err).Str("message_id", id).Msg(" ES delete message error")
+		}
+	}
+	if len(evt.GetMessageIds()) > 0 {
+		if err := c.pgRepo.DeleteMessages(ctx, evt.GetMessageIds(), c.log); err != nil {
+			c.log.Err(err).Msg(" PG delete messages error")
+		}
+	}
 
-	c.log.Info().Str("file_id", fileID).Msg("File deleted event processed")
+	c.log.Info().Str("chat_id", evt.GetChatId()).Int("count", len(evt.GetMessageIds())).Str("reason", evt.GetReason()).
+		Msg("messages deleted event processed")
 }
 
-// handleFileUpdated обрабатывает обновление файла
-func (c *Consumer) handleFileUpdated(ctx context.Context, data interface{}) {
-	var file domain.FileIndex
-	b, _ := json.Marshal(data)
-	if err := json.Unmarshal(b, &file); err != nil {
-		c.log.Err(err).Msg(" File updated decode error")
-		return
+// handleFileUploaded индексирует загруженный файл
+func (c *Consumer) handleFileUploaded(ctx context.Context, evt *events.FileUploaded) {
+	file := domain.FileIndex{
+		ID:        evt.GetId(),
+		Mime:      evt.GetContentType(),
+		SizeBytes: evt.GetSize(),
+		AuthorID:  evt.GetUserId(),
+		ChatID:    evt.GetChatId(),
+		UpdatedAt: evt.GetCreatedAt(),
+	}
+	if file.UpdatedAt == 0 {
+		file.UpdatedAt = time.Now().Unix()
 	}
 
-	// Обновляем timestamp
-	file.UpdatedAt = time.Now().Unix()
-
-	// Обновляем индекс в Elasticsearch
 	if err := c.esRepo.IndexFile(ctx, file); err != nil {
-		c.log.Err(err).Msg(" ES index file (updated) error")
+		c.log.Err(err).Msg(" ES index file error")
 		return
 	}
 
-	c.log.Info().Str("file_id", file.ID).Msg("File updated event processed")
+	c.log.Info().Str("file_id", file.ID).Str("filename", evt.GetFilename()).Msg("File uploaded event processed")
 }
@@ -11,6 +11,8 @@ import (
 	"os"
 	"testing"
 
+	"contracts/events"
+
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,10 +34,13 @@ func TestConsumer_HandleFileEvents(t *testing.T) {
 	logger := zerolog.New(os.Stdout)
 	pgRepo := &postgres.Repo{}
 
-	t.Run("handleFileCreated", func(t *testing.T) {
+	t.Run("handleFileUploaded", func(t *testing.T) {
 		// Подготовка данных
-		fileData := domain.FileIndex{
-			ID: "file-1",
+		evt := &events.FileUploaded{
+			Id:          "file-1",
+			ContentType: "image/png",
+			Size:        2048,
+			UserId:      "user-1",
 		}
 
 		// Поднимаем мок-сервер ES
@@ -43,9 +48,12 @@ func TestConsumer_HandleFileEvents(t *testing.T) {
 			assert.Equal(t, "PUT", r.Method)
 			assert.Contains(t, r.URL.Path, "/files/_doc/file-1")
 
-			// Проверяем, что дата обновления проставилась
+			// Проверяем, что поля контракта перенесены и дата обновления проставилась
 			var body domain.FileIndex
 			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "image/png", body.Mime)
+			assert.Equal(t, int64(2048), body.SizeBytes)
+			assert.Equal(t, "user-1", body.AuthorID)
 			assert.NotZero(t, body.UpdatedAt)
 
 			w.WriteHeader(http.StatusCreated)
@@ -64,52 +72,19 @@ func TestConsumer_HandleFileEvents(t *testing.T) {
 			log:    logger,
 		}
 
-		// Вызываем приватный метод напрямую (мы в пакете kafka, так что имеем доступ к c.handleFileCreated)
-		c.handleFileCreated(context.Background(), fileData)
-	})
-
-	t.Run("handleFileUpdated", func(t *testing.T) {
-		fileData := domain.FileIndex{
-			ID:        "file-2",
-			UpdatedAt: 100, // Старое время
-		}
-
-		ts := mockESServer(t, func(w http.ResponseWriter, r *http.Request) {
-			assert.Equal(t, "PUT", r.Method)
-			var body domain.FileIndex
-			_ = json.NewDecoder(r.Body).Decode(&body)
-
-			// Проверяем, что метод обновил время
-			assert.Greater(t, body.UpdatedAt, int64(100))
-
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(`{"result": "updated"}`))
-		})
-		defer ts.Close()
-
-		esRepo, err := es.NewRepo(ts.URL, logger)
+		// Событие проходит тот же путь, что и в Run: конверт → типизированный payload → обработчик
+		data, err := events.Marshal(events.ProducerMedia, evt)
 		require.NoError(t, err)
-
-		c := &Consumer{esRepo: esRepo, pgRepo: pgRepo, log: logger}
-
-		c.handleFileUpdated(context.Background(), fileData)
+		_, payload, err := events.Unmarshal(data)
+		require.NoError(t, err)
+		c.handle(context.Background(), payload)
 	})
 
-	t.Run("handleFileDeleted", func(t *testing.T) {
-		// Тут ожидается payload map[string]interface{} с полем id
-		payload := map[string]interface{}{
-			"id": "file-3",
-		}
-
-		// В коде consumer.go логика удаления пока закомментирована (TODO),
-		// но тест подготовим. Если ты раскомментируешь вызов c.esRepo.DeleteFile,
-		// то нужно будет проверить DELETE запрос.
-
-		// Сейчас метод просто логирует. Проверим, что не падает.
+	t.Run("handleMessagesDeleted keeps attachments", func(t *testing.T) {
+		var deleted []string
 		ts := mockESServer(t, func(w http.ResponseWriter, r *http.Request) {
-			// Если логика будет включена:
-			// assert.Equal(t, "DELETE", r.Method)
-			// assert.Contains(t, r.URL.Path, "file-3")
+			assert.Equal(t, "DELETE", r.Method)
+			deleted = append(deleted, r.URL.Path)
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte(`{"result": "deleted"}`))
 		})
@@ -120,6 +95,12 @@ func TestConsumer_HandleFileEvents(t *testing.T) {
 
 		c := &Consumer{esRepo: esRepo, pgRepo: pgRepo, log: logger}
 
-		c.handleFileDeleted(context.Background(), payload)
+		// Без message_ids Postgres не трогается; файл мог остаться в других сообщениях — его документ не удаляется
+		c.handle(context.Background(), &events.MessagesDeleted{ChatId: "chat-1", MediaIds: []string{"file-3"}, Reason: "expired"})
+		assert.Empty(t, deleted)
+
+		// Документ файла удаляет только событие media-service
+		c.handle(context.Background(), &events.FileDeleted{Id: "file-3", UserId: "user-1"})
+		assert.Equal(t, []string{"/files/_doc/file-3"}, deleted)
 	})
 }
@@ -81,6 +81,8 @@ func main() {
 
 	// Инициализация репозитория
 	repo := repository.NewUserRepository(db)
+	blockRepo := repository.NewBlockRepository(db)
+	keyRepo := repository.NewKeyRepository(db)
 
 	// Инициализация Kafka writer
 	kafkaWriter := &kafka.Writer{
@@ -91,7 +93,7 @@ func main() {
 	defer kafkaWriter.Close()
 
 	// Создание сервиса
-	userService := service.NewUserService(repo, kafkaWriter, redisClient)
+	userService := service.NewUserService(repo, blockRepo, keyRepo, kafkaWriter, redisClient)
 
 	// Создание Kafka topic
 	if err := ensureTopic(cfg.KafkaBroker, cfg.KafkaTopicSearch); err != nil {
@@ -3,6 +3,7 @@ module main
 go 1.25.4
 
 require (
+	contracts v0.0.0
 	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/alicebob/miniredis/v2 v2.35.0
 	github.com/google/uuid v1.6.0
@@ -32,3 +33,6 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// Общие контракты событий Kafka (в Docker собираются из корня репозитория)
+replace contracts => ../contracts
@@ -6,4 +6,9 @@ var (
 	ErrUserNotFound     = errors.New("user not found")
 	ErrInvalidEmail     = errors.New("invalid email")
 	ErrUserNameRequired = errors.New("user name is required")
+	ErrCannotBlockSelf  = errors.New("cannot block yourself")
+	ErrBlockedByUser    = errors.New("user has blocked you")
+	ErrInvalidKeys      = errors.New("invalid device keys")
+	ErrNoDevices        = errors.New("user has no registered devices")
+	ErrDeviceNotFound   = errors.New("device not found")
 )
\ No newline at end of file
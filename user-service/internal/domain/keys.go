@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// SignedPreKey — среднесрочный ключ устройства, подписанный его identity-ключом
+type SignedPreKey struct {
+	KeyID     uint32
+	PublicKey []byte
+	Signature []byte
+}
+
+// OneTimePreKey — одноразовый ключ: выдаётся одному собеседнику и удаляется
+type OneTimePreKey struct {
+	KeyID     uint32
+	PublicKey []byte
+}
+
+// DeviceKeys — публичные ключи устройства пользователя. Приватные ключи сервер не видит
+type DeviceKeys struct {
+	UserUUID     string
+	DeviceID     string
+	IdentityKey  []byte
+	SignedPreKey SignedPreKey
+	UpdatedAt    time.Time
+}
+
+// KeyBundle — набор для установки сессии с одним устройством.
+// OneTimePreKey пуст, если запас одноразовых ключей у устройства кончился
+type KeyBundle struct {
+	DeviceKeys
+	OneTimePreKey *OneTimePreKey
+}
+
+// Device — зарегистрированное устройство и остаток его одноразовых ключей
+type Device struct {
+	UserUUID       string
+	DeviceID       string
+	OneTimePreKeys int
+	UpdatedAt      time.Time
+}
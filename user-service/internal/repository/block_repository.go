@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// BlockRepository хранит блокировки: blocker_uuid заблокировал blocked_uuid
+type BlockRepository interface {
+	Block(ctx context.Context, blockerUUID, blockedUUID string) error
+	Unblock(ctx context.Context, blockerUUID, blockedUUID string) error
+	ListBlocked(ctx context.Context, blockerUUID string, limit, offset int) ([]string, int, error)
+	BlockedBy(ctx context.Context, uuid string, candidates []string) ([]string, error)
+}
+
+type pgBlockRepository struct {
+	db *sql.DB
+}
+
+func NewBlockRepository(db *sql.DB) BlockRepository {
+	return &pgBlockRepository{db: db}
+}
+
+// Block идемпотентен: повторная блокировка ничего не меняет
+func (r *pgBlockRepository) Block(ctx context.Context, blockerUUID, blockedUUID string) error {
+	query := `
+		INSERT INTO user_blocks (blocker_uuid, blocked_uuid)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_uuid, blocked_uuid) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, blockerUUID, blockedUUID); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+	return nil
+}
+
+func (r *pgBlockRepository) Unblock(ctx context.Context, blockerUUID, blockedUUID string) error {
+	query := `DELETE FROM user_blocks WHERE blocker_uuid = $1 AND blocked_uuid = $2`
+	if _, err := r.db.ExecContext(ctx, query, blockerUUID, blockedUUID); err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// ListBlocked возвращает заблокированных, новые первыми, и их общее число
+func (r *pgBlockRepository) ListBlocked(ctx context.Context, blockerUUID string, limit, offset int) ([]string, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM user_blocks WHERE blocker_uuid = $1`, blockerUUID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count blocked users: %w", err)
+	}
+
+	query := `
+		SELECT blocked_uuid
+		FROM user_blocks
+		WHERE blocker_uuid = $1
+		ORDER BY created_at DESC, blocked_uuid
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, blockerUUID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	uuids := []string{}
+	for rows.Next() {
+		var uuid string
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan blocked user: %w", err)
+		}
+		uuids = append(uuids, uuid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list blocked users: %w", err)
+	}
+	return uuids, total, nil
+}
+
+// BlockedBy возвращает тех из candidates, кто заблокировал uuid
+func (r *pgBlockRepository) BlockedBy(ctx context.Context, uuid string, candidates []string) ([]string, error) {
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+	query := `
+		SELECT blocker_uuid
+		FROM user_blocks
+		WHERE blocked_uuid = $1 AND blocker_uuid = ANY($2)
+	`
+	rows, err := r.db.QueryContext(ctx, query, uuid, pq.Array(candidates))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blockers := []string{}
+	for rows.Next() {
+		var blocker string
+		if err := rows.Scan(&blocker); err != nil {
+			return nil, fmt.Errorf("failed to scan blocker: %w", err)
+		}
+		blockers = append(blockers, blocker)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to check blocks: %w", err)
+	}
+	return blockers, nil
+}
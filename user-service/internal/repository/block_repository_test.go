@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockBlockRepo(t *testing.T) (sqlmock.Sqlmock, BlockRepository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return mock, NewBlockRepository(db)
+}
+
+func TestPgBlockRepository_Block(t *testing.T) {
+	mock, repo := newMockBlockRepo(t)
+
+	// Повторная блокировка не должна падать на первичном ключе
+	mock.ExpectExec("INSERT INTO user_blocks .* ON CONFLICT \\(blocker_uuid, blocked_uuid\\) DO NOTHING").
+		WithArgs("u1", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Block(context.Background(), "u1", "u2")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgBlockRepository_Unblock_Error(t *testing.T) {
+	mock, repo := newMockBlockRepo(t)
+
+	mock.ExpectExec("DELETE FROM user_blocks").
+		WithArgs("u1", "u2").
+		WillReturnError(errors.New("db down"))
+
+	err := repo.Unblock(context.Background(), "u1", "u2")
+	assert.Error(t, err)
+}
+
+func TestPgBlockRepository_ListBlocked(t *testing.T) {
+	mock, repo := newMockBlockRepo(t)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM user_blocks").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT blocked_uuid FROM user_blocks").
+		WithArgs("u1", 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"blocked_uuid"}).AddRow("u3").AddRow("u2"))
+
+	uuids, total, err := repo.ListBlocked(context.Background(), "u1", 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"u3", "u2"}, uuids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgBlockRepository_BlockedBy(t *testing.T) {
+	mock, repo := newMockBlockRepo(t)
+
+	mock.ExpectQuery("SELECT blocker_uuid FROM user_blocks").
+		WithArgs("u1", pq.Array([]string{"u2", "u3"})).
+		WillReturnRows(sqlmock.NewRows([]string{"blocker_uuid"}).AddRow("u3"))
+
+	blockers, err := repo.BlockedBy(context.Background(), "u1", []string{"u2", "u3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, blockers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgBlockRepository_BlockedBy_NoCandidates(t *testing.T) {
+	mock, repo := newMockBlockRepo(t)
+
+	blockers, err := repo.BlockedBy(context.Background(), "u1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, blockers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
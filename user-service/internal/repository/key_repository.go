@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"main/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// KeyRepository — каталог публичных ключей устройств для E2E-чатов
+type KeyRepository interface {
+	Upload(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error)
+	Claim(ctx context.Context, userUUID string) ([]domain.KeyBundle, error)
+	ListDevices(ctx context.Context, userUUIDs []string) ([]domain.Device, error)
+	RemoveDevice(ctx context.Context, userUUID, deviceID string) error
+}
+
+type pgKeyRepository struct {
+	db *sql.DB
+}
+
+func NewKeyRepository(db *sql.DB) KeyRepository {
+	return &pgKeyRepository{db: db}
+}
+
+// Upload сохраняет ключи устройства и добавляет одноразовые prekey, возвращая их остаток.
+// Смена identity-ключа означает переустановку: старые одноразовые ключи удаляются
+func (r *pgKeyRepository) Upload(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var identity []byte
+	err = tx.QueryRowContext(ctx,
+		`SELECT identity_key FROM device_keys WHERE user_uuid = $1 AND device_id = $2 FOR UPDATE`,
+		keys.UserUUID, keys.DeviceID,
+	).Scan(&identity)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get device keys: %w", err)
+	}
+	if identity != nil && !bytes.Equal(identity, keys.IdentityKey) {
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM one_time_prekeys WHERE user_uuid = $1 AND device_id = $2`,
+			keys.UserUUID, keys.DeviceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to drop one-time prekeys: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO device_keys (user_uuid, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_uuid, device_id) DO UPDATE SET
+			identity_key = EXCLUDED.identity_key,
+			signed_prekey_id = EXCLUDED.signed_prekey_id,
+			signed_prekey = EXCLUDED.signed_prekey,
+			signed_prekey_signature = EXCLUDED.signed_prekey_signature,
+			updated_at = NOW()
+	`
+	_, err = tx.ExecContext(ctx, query, keys.UserUUID, keys.DeviceID, keys.IdentityKey,
+		int64(keys.SignedPreKey.KeyID), keys.SignedPreKey.PublicKey, keys.SignedPreKey.Signature)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save device keys: %w", err)
+	}
+
+	// Повторно загруженный key_id не перезаписывает ключ, который мог уже уйти собеседнику
+	for _, pk := range prekeys {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO one_time_prekeys (user_uuid, device_id, key_id, public_key)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_uuid, device_id, key_id) DO NOTHING
+		`, keys.UserUUID, keys.DeviceID, int64(pk.KeyID), pk.PublicKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save one-time prekey: %w", err)
+		}
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM one_time_prekeys WHERE user_uuid = $1 AND device_id = $2`,
+		keys.UserUUID, keys.DeviceID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count one-time prekeys: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit device keys: %w", err)
+	}
+	return count, nil
+}
+
+// Claim возвращает по набору ключей на каждое устройство пользователя, забирая у каждого
+// один одноразовый prekey. SKIP LOCKED не даёт двум параллельным запросам получить один ключ
+func (r *pgKeyRepository) Claim(ctx context.Context, userUUID string) ([]domain.KeyBundle, error) {
+	query := `
+		SELECT device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, updated_at
+		FROM device_keys
+		WHERE user_uuid = $1
+		ORDER BY device_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device keys: %w", err)
+	}
+	defer rows.Close()
+
+	bundles := []domain.KeyBundle{}
+	for rows.Next() {
+		b := domain.KeyBundle{DeviceKeys: domain.DeviceKeys{UserUUID: userUUID}}
+		var keyID int64
+		if err := rows.Scan(&b.DeviceID, &b.IdentityKey, &keyID,
+			&b.SignedPreKey.PublicKey, &b.SignedPreKey.Signature, &b.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan device keys: %w", err)
+		}
+		b.SignedPreKey.KeyID = uint32(keyID)
+		bundles = append(bundles, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get device keys: %w", err)
+	}
+	rows.Close()
+
+	claim := `
+		DELETE FROM one_time_prekeys
+		WHERE (user_uuid, device_id, key_id) = (
+			SELECT user_uuid, device_id, key_id
+			FROM one_time_prekeys
+			WHERE user_uuid = $1 AND device_id = $2
+			ORDER BY key_id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING key_id, public_key
+	`
+	for i := range bundles {
+		var pk domain.OneTimePreKey
+		var keyID int64
+		err := r.db.QueryRowContext(ctx, claim, userUUID, bundles[i].DeviceID).Scan(&keyID, &pk.PublicKey)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim one-time prekey: %w", err)
+		}
+		pk.KeyID = uint32(keyID)
+		bundles[i].OneTimePreKey = &pk
+	}
+	return bundles, nil
+}
+
+// ListDevices возвращает устройства пользователей с остатком одноразовых ключей
+func (r *pgKeyRepository) ListDevices(ctx context.Context, userUUIDs []string) ([]domain.Device, error) {
+	if len(userUUIDs) == 0 {
+		return []domain.Device{}, nil
+	}
+	query := `
+		SELECT d.user_uuid, d.device_id, d.updated_at, COUNT(p.key_id)
+		FROM device_keys d
+		LEFT JOIN one_time_prekeys p ON p.user_uuid = d.user_uuid AND p.device_id = d.device_id
+		WHERE d.user_uuid = ANY($1)
+		GROUP BY d.user_uuid, d.device_id, d.updated_at
+		ORDER BY d.user_uuid, d.device_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userUUIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := []domain.Device{}
+	for rows.Next() {
+		var d domain.Device
+		if err := rows.Scan(&d.UserUUID, &d.DeviceID, &d.UpdatedAt, &d.OneTimePreKeys); err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	return devices, nil
+}
+
+// RemoveDevice удаляет устройство вместе с его одноразовыми ключами
+func (r *pgKeyRepository) RemoveDevice(ctx context.Context, userUUID, deviceID string) error {
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM device_keys WHERE user_uuid = $1 AND device_id = $2`, userUUID, deviceID)
+	if err != nil {
+		return fmt.Errorf("failed to remove device: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrDeviceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"main/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockKeyRepo(t *testing.T) (sqlmock.Sqlmock, KeyRepository) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return mock, NewKeyRepository(db)
+}
+
+func testDeviceKeys() domain.DeviceKeys {
+	return domain.DeviceKeys{
+		UserUUID:     "u1",
+		DeviceID:     "phone",
+		IdentityKey:  []byte("identity-new"),
+		SignedPreKey: domain.SignedPreKey{KeyID: 7, PublicKey: []byte("spk"), Signature: []byte("sig")},
+	}
+}
+
+func TestPgKeyRepository_Upload_NewDevice(t *testing.T) {
+	mock, repo := newMockKeyRepo(t)
+	keys := testDeviceKeys()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT identity_key FROM device_keys .* FOR UPDATE").
+		WithArgs("u1", "phone").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT INTO device_keys .* ON CONFLICT \\(user_uuid, device_id\\) DO UPDATE").
+		WithArgs("u1", "phone", keys.IdentityKey, int64(7), keys.SignedPreKey.PublicKey, keys.SignedPreKey.Signature).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO one_time_prekeys .* DO NOTHING").
+		WithArgs("u1", "phone", int64(1), []byte("otk1")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM one_time_prekeys").
+		WithArgs("u1", "phone").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectCommit()
+
+	count, err := repo.Upload(context.Background(), keys, []domain.OneTimePreKey{{KeyID: 1, PublicKey: []byte("otk1")}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgKeyRepository_Upload_IdentityChanged(t *testing.T) {
+	mock, repo := newMockKeyRepo(t)
+
+	// Переустановка приложения: старые одноразовые ключи подписаны не тем identity и удаляются
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT identity_key FROM device_keys").
+		WithArgs("u1", "phone").
+		WillReturnRows(sqlmock.NewRows([]string{"identity_key"}).AddRow([]byte("identity-old")))
+	mock.ExpectExec("DELETE FROM one_time_prekeys").
+		WithArgs("u1", "phone").
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec("INSERT INTO device_keys").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM one_time_prekeys").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectCommit()
+
+	count, err := repo.Upload(context.Background(), testDeviceKeys(), nil)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgKeyRepository_Upload_Error(t *testing.T) {
+	mock, repo := newMockKeyRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT identity_key FROM device_keys").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT INTO device_keys").
+		WillReturnError(errors.New("db down"))
+	mock.ExpectRollback()
+
+	_, err := repo.Upload(context.Background(), testDeviceKeys(), nil)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgKeyRepository_Claim(t *testing.T) {
+	mock, repo := newMockKeyRepo(t)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT device_id, identity_key, .* FROM device_keys").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id", "identity_key", "signed_prekey_id", "signed_prekey", "signed_prekey_signature", "updated_at"}).
+			AddRow("laptop", []byte("ik1"), 3, []byte("spk1"), []byte("sig1"), now).
+			AddRow("phone", []byte("ik2"), 4, []byte("spk2"), []byte("sig2"), now))
+	mock.ExpectQuery("DELETE FROM one_time_prekeys .* FOR UPDATE SKIP LOCKED .* RETURNING key_id, public_key").
+		WithArgs("u1", "laptop").
+		WillReturnRows(sqlmock.NewRows([]string{"key_id", "public_key"}).AddRow(11, []byte("otk11")))
+	mock.ExpectQuery("DELETE FROM one_time_prekeys").
+		WithArgs("u1", "phone").
+		WillReturnError(sql.ErrNoRows)
+
+	bundles, err := repo.Claim(context.Background(), "u1")
+	assert.NoError(t, err)
+	assert.Len(t, bundles, 2)
+	assert.Equal(t, uint32(3), bundles[0].SignedPreKey.KeyID)
+	assert.Equal(t, &domain.OneTimePreKey{KeyID: 11, PublicKey: []byte("otk11")}, bundles[0].OneTimePreKey)
+	assert.Nil(t, bundles[1].OneTimePreKey, "запас ключей кончился — набор без одноразового ключа")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgKeyRepository_ListDevices(t *testing.T) {
+	mock, repo := newMockKeyRepo(t)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT d.user_uuid, d.device_id, d.updated_at, COUNT\\(p.key_id\\) FROM device_keys d").
+		WithArgs(pq.Array([]string{"u1", "u2"})).
+		WillReturnRows(sqlmock.NewRows([]string{"user_uuid", "device_id", "updated_at", "count"}).
+			AddRow("u1", "phone", now, 42))
+
+	devices, err := repo.ListDevices(context.Background(), []string{"u1", "u2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Device{{UserUUID: "u1", DeviceID: "phone", OneTimePreKeys: 42, UpdatedAt: now}}, devices)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgKeyRepository_RemoveDevice_NotFound(t *testing.T) {
+	mock, repo := newMockKeyRepo(t)
+
+	mock.ExpectExec("DELETE FROM device_keys").
+		WithArgs("u1", "tablet").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.RemoveDevice(context.Background(), "u1", "tablet")
+	assert.ErrorIs(t, err, domain.ErrDeviceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"main/internal/domain"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +20,7 @@ type UserRepository interface {
 	Delete(ctx context.Context, uuid string) error
 	List(ctx context.Context, limit, offset int) ([]domain.User, int, error)
 	AboutMe(ctx context.Context, uuid string) (domain.User, error)
+	FindByUserNames(ctx context.Context, userNames, among []string) ([]domain.User, error)
 }
 
 type pgUserRepository struct {
@@ -205,3 +207,38 @@ func (r *pgUserRepository) List(ctx context.Context, limit, offset int) ([]domai
 	// TODO: реализовать если нужно
 	return nil, 0, nil
 }
+
+// FindByUserNames ищет среди among пользователей с именами из userNames (без учёта регистра).
+// Заполняются только uuid и user_name
+func (r *pgUserRepository) FindByUserNames(ctx context.Context, userNames, among []string) ([]domain.User, error) {
+	if len(userNames) == 0 || len(among) == 0 {
+		return []domain.User{}, nil
+	}
+	lowered := make([]string, len(userNames))
+	for i, name := range userNames {
+		lowered[i] = strings.ToLower(name)
+	}
+	query := `
+		SELECT uuid, user_name
+		FROM users
+		WHERE uuid = ANY($1) AND lower(user_name) = ANY($2)
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(among), pq.Array(lowered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user names: %w", err)
+	}
+	defer rows.Close()
+
+	users := []domain.User{}
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.UUID, &u.UserName); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to resolve user names: %w", err)
+	}
+	return users, nil
+}
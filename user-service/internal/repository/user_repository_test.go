@@ -11,6 +11,7 @@ import (
 	"main/internal/domain"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -258,3 +259,18 @@ func TestPgUserRepository_List(t *testing.T) {
 	assert.Equal(t, 0, count)
 	assert.Nil(t, err)
 }
+
+func TestPgUserRepository_FindByUserNames(t *testing.T) {
+	db, mock, repo := newMockRepo(t)
+	defer db.Close()
+
+	// Имена сравниваются в нижнем регистре, поиск только среди переданных uuid
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE uuid = ANY($1) AND lower(user_name) = ANY($2)")).
+		WithArgs(pq.Array([]string{"u1", "u2"}), pq.Array([]string{"bob"})).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "user_name"}).AddRow("u2", "Bob"))
+
+	users, err := repo.FindByUserNames(context.Background(), []string{"Bob"}, []string{"u1", "u2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.User{{UUID: "u2", UserName: "Bob"}}, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
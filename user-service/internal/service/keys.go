@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"main/internal/domain"
+	"unicode/utf8"
+)
+
+const (
+	maxDeviceIDLength   = 64
+	maxPreKeysPerUpload = 100
+	curve25519KeySize   = 32
+)
+
+// UploadKeys регистрирует устройство или обновляет его ключи и возвращает остаток одноразовых ключей.
+// Подпись signed prekey проверяется identity-ключом: подменить его мимо владельца сервер не сможет
+func (s *userService) UploadKeys(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error) {
+	if err := validateDeviceKeys(keys, prekeys); err != nil {
+		return 0, err
+	}
+	if _, err := s.repo.GetByUUID(ctx, keys.UserUUID); err != nil {
+		return 0, err
+	}
+	return s.keys.Upload(ctx, keys, prekeys)
+}
+
+// GetKeyBundles выдаёт наборы ключей всех устройств uuid для установки сессии.
+// Тот, кого uuid заблокировал, ключей не получает
+func (s *userService) GetKeyBundles(ctx context.Context, uuid, requesterUUID string) ([]domain.KeyBundle, error) {
+	if requesterUUID != uuid {
+		blockedBy, err := s.blocks.BlockedBy(ctx, requesterUUID, []string{uuid})
+		if err != nil {
+			return nil, err
+		}
+		if len(blockedBy) > 0 {
+			return nil, domain.ErrBlockedByUser
+		}
+	}
+	bundles, err := s.keys.Claim(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, domain.ErrNoDevices
+	}
+	return bundles, nil
+}
+
+// ListDevices — устройства пользователей; chat-service сверяет по ним адресатов зашифрованных сообщений
+func (s *userService) ListDevices(ctx context.Context, uuids []string) ([]domain.Device, error) {
+	return s.keys.ListDevices(ctx, uuids)
+}
+
+func (s *userService) RemoveDevice(ctx context.Context, uuid, deviceID string) error {
+	return s.keys.RemoveDevice(ctx, uuid, deviceID)
+}
+
+func validateDeviceKeys(keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) error {
+	if keys.UserUUID == "" {
+		return fmt.Errorf("%w: uuid is required", domain.ErrInvalidKeys)
+	}
+	if keys.DeviceID == "" || utf8.RuneCountInString(keys.DeviceID) > maxDeviceIDLength {
+		return fmt.Errorf("%w: device_id must be 1-%d characters", domain.ErrInvalidKeys, maxDeviceIDLength)
+	}
+	if len(keys.IdentityKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: identity key must be %d bytes", domain.ErrInvalidKeys, ed25519.PublicKeySize)
+	}
+	spk := keys.SignedPreKey
+	if len(spk.PublicKey) != curve25519KeySize || len(spk.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed signed prekey", domain.ErrInvalidKeys)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(keys.IdentityKey), spk.PublicKey, spk.Signature) {
+		return fmt.Errorf("%w: signed prekey signature mismatch", domain.ErrInvalidKeys)
+	}
+	if len(prekeys) > maxPreKeysPerUpload {
+		return fmt.Errorf("%w: at most %d one-time prekeys per upload", domain.ErrInvalidKeys, maxPreKeysPerUpload)
+	}
+	seen := make(map[uint32]bool, len(prekeys))
+	for _, pk := range prekeys {
+		if len(pk.PublicKey) != curve25519KeySize {
+			return fmt.Errorf("%w: one-time prekey %d must be %d bytes", domain.ErrInvalidKeys, pk.KeyID, curve25519KeySize)
+		}
+		if seen[pk.KeyID] {
+			return fmt.Errorf("%w: duplicate one-time prekey id %d", domain.ErrInvalidKeys, pk.KeyID)
+		}
+		seen[pk.KeyID] = true
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"main/internal/domain"
+	"testing"
+)
+
+// MockKeyRepository имитирует каталог ключей
+type MockKeyRepository struct {
+	UploadFn      func(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error)
+	ClaimFn       func(ctx context.Context, userUUID string) ([]domain.KeyBundle, error)
+	ListDevicesFn func(ctx context.Context, userUUIDs []string) ([]domain.Device, error)
+}
+
+func (m *MockKeyRepository) Upload(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error) {
+	if m.UploadFn != nil {
+		return m.UploadFn(ctx, keys, prekeys)
+	}
+	return len(prekeys), nil
+}
+
+func (m *MockKeyRepository) Claim(ctx context.Context, userUUID string) ([]domain.KeyBundle, error) {
+	if m.ClaimFn != nil {
+		return m.ClaimFn(ctx, userUUID)
+	}
+	return []domain.KeyBundle{}, nil
+}
+
+func (m *MockKeyRepository) ListDevices(ctx context.Context, userUUIDs []string) ([]domain.Device, error) {
+	if m.ListDevicesFn != nil {
+		return m.ListDevicesFn(ctx, userUUIDs)
+	}
+	return []domain.Device{}, nil
+}
+
+func (m *MockKeyRepository) RemoveDevice(ctx context.Context, userUUID, deviceID string) error {
+	return nil
+}
+
+// signedDeviceKeys собирает корректный набор ключей устройства, как это делает клиент
+func signedDeviceKeys(t *testing.T) domain.DeviceKeys {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spk := make([]byte, 32)
+	_, _ = rand.Read(spk)
+	return domain.DeviceKeys{
+		UserUUID:     "u1",
+		DeviceID:     "phone",
+		IdentityKey:  pub,
+		SignedPreKey: domain.SignedPreKey{KeyID: 1, PublicKey: spk, Signature: ed25519.Sign(priv, spk)},
+	}
+}
+
+func TestUserService_UploadKeys(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		GetByUUIDFn: func(ctx context.Context, uuid string) (domain.User, error) {
+			return domain.User{UUID: uuid}, nil
+		},
+	}
+	mockKeys := &MockKeyRepository{}
+	svc := NewUserService(mockRepo, nil, mockKeys, nil, nil)
+	prekeys := []domain.OneTimePreKey{{KeyID: 1, PublicKey: make([]byte, 32)}, {KeyID: 2, PublicKey: make([]byte, 32)}}
+
+	t.Run("Success", func(t *testing.T) {
+		count, err := svc.UploadKeys(context.Background(), signedDeviceKeys(t), prekeys)
+		if err != nil || count != 2 {
+			t.Errorf("Unexpected result: %d, %v", count, err)
+		}
+	})
+
+	t.Run("Forged Signature", func(t *testing.T) {
+		keys := signedDeviceKeys(t)
+		keys.SignedPreKey.PublicKey[0] ^= 0xff
+		_, err := svc.UploadKeys(context.Background(), keys, nil)
+		if !errors.Is(err, domain.ErrInvalidKeys) {
+			t.Errorf("Expected ErrInvalidKeys, got %v", err)
+		}
+	})
+
+	t.Run("Duplicate Prekey", func(t *testing.T) {
+		_, err := svc.UploadKeys(context.Background(), signedDeviceKeys(t), append(prekeys, prekeys[0]))
+		if !errors.Is(err, domain.ErrInvalidKeys) {
+			t.Errorf("Expected ErrInvalidKeys, got %v", err)
+		}
+	})
+
+	t.Run("Too Many Prekeys", func(t *testing.T) {
+		many := make([]domain.OneTimePreKey, maxPreKeysPerUpload+1)
+		_, err := svc.UploadKeys(context.Background(), signedDeviceKeys(t), many)
+		if !errors.Is(err, domain.ErrInvalidKeys) {
+			t.Errorf("Expected ErrInvalidKeys, got %v", err)
+		}
+	})
+}
+
+func TestUserService_GetKeyBundles(t *testing.T) {
+	mockBlocks := &MockBlockRepository{}
+	mockKeys := &MockKeyRepository{}
+	svc := NewUserService(&MockUserRepository{}, mockBlocks, mockKeys, nil, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		mockKeys.ClaimFn = func(ctx context.Context, userUUID string) ([]domain.KeyBundle, error) {
+			return []domain.KeyBundle{{DeviceKeys: domain.DeviceKeys{UserUUID: userUUID, DeviceID: "phone"}}}, nil
+		}
+		bundles, err := svc.GetKeyBundles(context.Background(), "u2", "u1")
+		if err != nil || len(bundles) != 1 {
+			t.Errorf("Unexpected result: %v, %v", bundles, err)
+		}
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		mockBlocks.BlockedByFn = func(ctx context.Context, uuid string, candidates []string) ([]string, error) {
+			return candidates, nil
+		}
+		mockKeys.ClaimFn = func(ctx context.Context, userUUID string) ([]domain.KeyBundle, error) {
+			t.Error("Claim should not be called")
+			return nil, nil
+		}
+		_, err := svc.GetKeyBundles(context.Background(), "u2", "u1")
+		if !errors.Is(err, domain.ErrBlockedByUser) {
+			t.Errorf("Expected ErrBlockedByUser, got %v", err)
+		}
+	})
+
+	t.Run("No Devices", func(t *testing.T) {
+		mockBlocks.BlockedByFn = nil
+		mockKeys.ClaimFn = nil
+		_, err := svc.GetKeyBundles(context.Background(), "u2", "u1")
+		if !errors.Is(err, domain.ErrNoDevices) {
+			t.Errorf("Expected ErrNoDevices, got %v", err)
+		}
+	})
+}
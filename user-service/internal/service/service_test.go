@@ -20,6 +20,7 @@ type MockUserRepository struct {
 	DeleteFn     func(ctx context.Context, uuid string) error
 	ListFn       func(ctx context.Context, limit, offset int) ([]domain.User, int, error)
 	AboutMeFn    func(ctx context.Context, uuid string) (domain.User, error)
+	FindByNames  func(ctx context.Context, userNames, among []string) ([]domain.User, error)
 }
 
 func (m *MockUserRepository) Create(ctx context.Context, req *domain.CreateUserRequest) (domain.User, error) {
@@ -72,6 +73,49 @@ func (m *MockUserRepository) AboutMe(ctx context.Context, uuid string) (domain.U
 	return domain.User{}, nil
 }
 
+func (m *MockUserRepository) FindByUserNames(ctx context.Context, userNames, among []string) ([]domain.User, error) {
+	if m.FindByNames != nil {
+		return m.FindByNames(ctx, userNames, among)
+	}
+	return []domain.User{}, nil
+}
+
+// MockBlockRepository имитирует хранилище блокировок
+type MockBlockRepository struct {
+	BlockFn       func(ctx context.Context, blockerUUID, blockedUUID string) error
+	UnblockFn     func(ctx context.Context, blockerUUID, blockedUUID string) error
+	ListBlockedFn func(ctx context.Context, blockerUUID string, limit, offset int) ([]string, int, error)
+	BlockedByFn   func(ctx context.Context, uuid string, candidates []string) ([]string, error)
+}
+
+func (m *MockBlockRepository) Block(ctx context.Context, blockerUUID, blockedUUID string) error {
+	if m.BlockFn != nil {
+		return m.BlockFn(ctx, blockerUUID, blockedUUID)
+	}
+	return nil
+}
+
+func (m *MockBlockRepository) Unblock(ctx context.Context, blockerUUID, blockedUUID string) error {
+	if m.UnblockFn != nil {
+		return m.UnblockFn(ctx, blockerUUID, blockedUUID)
+	}
+	return nil
+}
+
+func (m *MockBlockRepository) ListBlocked(ctx context.Context, blockerUUID string, limit, offset int) ([]string, int, error) {
+	if m.ListBlockedFn != nil {
+		return m.ListBlockedFn(ctx, blockerUUID, limit, offset)
+	}
+	return []string{}, 0, nil
+}
+
+func (m *MockBlockRepository) BlockedBy(ctx context.Context, uuid string, candidates []string) ([]string, error) {
+	if m.BlockedByFn != nil {
+		return m.BlockedByFn(ctx, uuid, candidates)
+	}
+	return []string{}, nil
+}
+
 // Helpers
 func strPtr(s string) *string { return &s }
 
@@ -83,7 +127,7 @@ func TestUserService_CreateUser(t *testing.T) {
 	// Ошибка записи в Kafka логируется в сервисе, но не возвращается, что нам подходит.
 	mockKafka := &kafka.Writer{Addr: kafka.TCP("localhost:0")}
 
-	svc := NewUserService(mockRepo, mockKafka, nil)
+	svc := NewUserService(mockRepo, nil, nil, mockKafka, nil)
 
 	tests := []struct {
 		name    string
@@ -160,7 +204,7 @@ func TestUserService_CreateUser(t *testing.T) {
 
 func TestUserService_GetUser(t *testing.T) {
 	mockRepo := &MockUserRepository{}
-	svc := NewUserService(mockRepo, nil, nil)
+	svc := NewUserService(mockRepo, nil, nil, nil, nil)
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.GetByUUIDFn = func(ctx context.Context, uuid string) (domain.User, error) {
@@ -190,7 +234,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 	mockRepo := &MockUserRepository{}
 	// Важно: передаем kafka writer, чтобы избежать паники при вызове publishToKafka
 	mockKafka := &kafka.Writer{Addr: kafka.TCP("localhost:0")}
-	svc := NewUserService(mockRepo, mockKafka, nil)
+	svc := NewUserService(mockRepo, nil, nil, mockKafka, nil)
 
 	t.Run("User Not Found", func(t *testing.T) {
 		mockRepo.GetByUUIDFn = func(ctx context.Context, uuid string) (domain.User, error) {
@@ -241,7 +285,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 	mockRepo := &MockUserRepository{}
 	// Kafka writer нужен, чтобы избежать паники
 	mockKafka := &kafka.Writer{Addr: kafka.TCP("localhost:0")}
-	svc := NewUserService(mockRepo, mockKafka, nil)
+	svc := NewUserService(mockRepo, nil, nil, mockKafka, nil)
 
 	t.Run("Repo Error", func(t *testing.T) {
 		mockRepo.DeleteFn = func(ctx context.Context, uuid string) error {
@@ -271,7 +315,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 
 func TestUserService_ListUsers(t *testing.T) {
 	mockRepo := &MockUserRepository{}
-	svc := NewUserService(mockRepo, nil, nil)
+	svc := NewUserService(mockRepo, nil, nil, nil, nil)
 
 	tests := []struct {
 		name       string
@@ -301,7 +345,7 @@ func TestUserService_ListUsers(t *testing.T) {
 
 func TestUserService_AboutMe(t *testing.T) {
 	mockRepo := &MockUserRepository{}
-	NewUserService(mockRepo, nil, nil)
+	NewUserService(mockRepo, nil, nil, nil, nil)
 
 	mockRepo.AboutMeFn = func(ctx context.Context, uuid string) (domain.User, error) {
 		return domain.User{UUID: uuid, UserName: "Me"}, nil
@@ -314,6 +358,84 @@ func TestUserService_AboutMe(t *testing.T) {
 	})
 }
 
+func TestUserService_BlockUser(t *testing.T) {
+	mockRepo := &MockUserRepository{}
+	mockBlocks := &MockBlockRepository{}
+	svc := NewUserService(mockRepo, mockBlocks, nil, nil, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.GetByUUIDFn = func(ctx context.Context, uuid string) (domain.User, error) {
+			return domain.User{UUID: uuid}, nil
+		}
+		var blocker, blocked string
+		mockBlocks.BlockFn = func(ctx context.Context, blockerUUID, blockedUUID string) error {
+			blocker, blocked = blockerUUID, blockedUUID
+			return nil
+		}
+		if err := svc.BlockUser(context.Background(), "u1", "u2"); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if blocker != "u1" || blocked != "u2" {
+			t.Errorf("Block args: got (%s, %s), want (u1, u2)", blocker, blocked)
+		}
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		mockBlocks.BlockFn = func(ctx context.Context, blockerUUID, blockedUUID string) error {
+			t.Error("Block should not be called")
+			return nil
+		}
+		err := svc.BlockUser(context.Background(), "u1", "u1")
+		if !errors.Is(err, domain.ErrCannotBlockSelf) {
+			t.Errorf("Expected ErrCannotBlockSelf, got %v", err)
+		}
+	})
+
+	t.Run("Unknown Target", func(t *testing.T) {
+		mockRepo.GetByUUIDFn = func(ctx context.Context, uuid string) (domain.User, error) {
+			return domain.User{}, errors.New("user not found")
+		}
+		if err := svc.BlockUser(context.Background(), "u1", "404"); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
+func TestUserService_ListBlockedUsers_Limits(t *testing.T) {
+	mockBlocks := &MockBlockRepository{}
+	svc := NewUserService(&MockUserRepository{}, mockBlocks, nil, nil, nil)
+
+	mockBlocks.ListBlockedFn = func(ctx context.Context, blockerUUID string, limit, offset int) ([]string, int, error) {
+		if limit != 100 || offset != 0 {
+			t.Errorf("ListBlocked args: got (%d, %d), want (100, 0)", limit, offset)
+		}
+		return []string{"u2"}, 1, nil
+	}
+	uuids, total, err := svc.ListBlockedUsers(context.Background(), "u1", 500, -1)
+	if err != nil || total != 1 || len(uuids) != 1 {
+		t.Errorf("Unexpected result: %v, %d, %v", uuids, total, err)
+	}
+}
+
+func TestUserService_CheckBlocked(t *testing.T) {
+	mockBlocks := &MockBlockRepository{}
+	svc := NewUserService(&MockUserRepository{}, mockBlocks, nil, nil, nil)
+
+	mockBlocks.BlockedByFn = func(ctx context.Context, uuid string, candidates []string) ([]string, error) {
+		if uuid != "u1" || len(candidates) != 2 {
+			t.Errorf("BlockedBy args: got (%s, %v)", uuid, candidates)
+		}
+		return []string{"u3"}, nil
+	}
+	blockedBy, err := svc.CheckBlocked(context.Background(), "u1", []string{"u2", "u3"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(blockedBy) != 1 || blockedBy[0] != "u3" {
+		t.Errorf("Expected [u3], got %v", blockedBy)
+	}
+}
+
 // Helpers for test
 func TestHelpers(t *testing.T) {
 	s := "test"
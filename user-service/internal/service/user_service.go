@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"main/internal/domain"
 	"main/internal/logger"
@@ -11,6 +10,8 @@ import (
 	user "main/pkg/api"
 	"time"
 
+	"contracts/events"
+
 	"github.com/segmentio/kafka-go"
 )
 
@@ -26,17 +27,30 @@ type UserService interface {
 	SetOffline(ctx context.Context, uuid string) error
 	IsOnline(ctx context.Context, uuid string) (bool, error)
 	GetOnlineUsers(ctx context.Context) ([]string, error)
+	BlockUser(ctx context.Context, uuid, targetUUID string) error
+	UnblockUser(ctx context.Context, uuid, targetUUID string) error
+	ListBlockedUsers(ctx context.Context, uuid string, limit, offset int) ([]string, int, error)
+	CheckBlocked(ctx context.Context, uuid string, userUUIDs []string) ([]string, error)
+	ResolveUserNames(ctx context.Context, userNames, among []string) ([]domain.User, error)
+	UploadKeys(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error)
+	GetKeyBundles(ctx context.Context, uuid, requesterUUID string) ([]domain.KeyBundle, error)
+	ListDevices(ctx context.Context, uuids []string) ([]domain.Device, error)
+	RemoveDevice(ctx context.Context, uuid, deviceID string) error
 }
 
 type userService struct {
 	repo      repository.UserRepository
+	blocks    repository.BlockRepository
+	keys      repository.KeyRepository
 	kafkaProd *kafka.Writer
 	redis     *redis.Client // Если используете redis
 }
 
-func NewUserService(repo repository.UserRepository, kafkaProd *kafka.Writer, redisClient *redis.Client) UserService {
+func NewUserService(repo repository.UserRepository, blocks repository.BlockRepository, keys repository.KeyRepository, kafkaProd *kafka.Writer, redisClient *redis.Client) UserService {
 	return &userService{
 		repo:      repo,
+		blocks:    blocks,
+		keys:      keys,
 		kafkaProd: kafkaProd,
 		redis:     redisClient,
 	}
@@ -132,11 +146,13 @@ func (s *userService) DeleteUser(ctx context.Context, uuid string) error {
 		return err
 	}
 	// Отправляем событие о том, что пользователь удалён
-	evt := SearchEvent{Type: "user", Data: map[string]string{"uuid": uuid, "deleted": "true"}}
-	payload, _ := json.Marshal(evt)
+	payload, err := events.Marshal(events.ProducerUser, &events.UserDeleted{Id: uuid})
+	if err != nil {
+		return err
+	}
 	log.Debug().Msg("service delete user")
 
-	return s.kafkaProd.WriteMessages(ctx, kafka.Message{Topic: "search-events", Value: payload})
+	return s.kafkaProd.WriteMessages(ctx, kafka.Message{Topic: "search-events", Key: []byte(uuid), Value: payload})
 }
 
 func (s *userService) AboutMeUser(ctx context.Context, req *user.AboutMeRequest) (*user.UserResponse, error) {
@@ -188,6 +204,46 @@ func (s *userService) GetOnlineUsers(ctx context.Context) ([]string, error) {
 	return s.redis.GetOnlineUsers(ctx)
 }
 
+func (s *userService) BlockUser(ctx context.Context, uuid, targetUUID string) error {
+	if uuid == targetUUID {
+		return domain.ErrCannotBlockSelf
+	}
+	// Блокировать можно только существующего пользователя
+	if _, err := s.repo.GetByUUID(ctx, targetUUID); err != nil {
+		return err
+	}
+	return s.blocks.Block(ctx, uuid, targetUUID)
+}
+
+func (s *userService) UnblockUser(ctx context.Context, uuid, targetUUID string) error {
+	return s.blocks.Unblock(ctx, uuid, targetUUID)
+}
+
+func (s *userService) ListBlockedUsers(ctx context.Context, uuid string, limit, offset int) ([]string, int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.blocks.ListBlocked(ctx, uuid, limit, offset)
+}
+
+// CheckBlocked возвращает тех из userUUIDs, кто заблокировал uuid
+func (s *userService) CheckBlocked(ctx context.Context, uuid string, userUUIDs []string) ([]string, error) {
+	return s.blocks.BlockedBy(ctx, uuid, userUUIDs)
+}
+
+// ResolveUserNames находит среди among пользователей с именами из userNames — одним запросом
+// на все @упоминания сообщения
+func (s *userService) ResolveUserNames(ctx context.Context, userNames, among []string) ([]domain.User, error) {
+	return s.repo.FindByUserNames(ctx, userNames, among)
+}
+
 // Вспомогательные структуры для сервиса
 type CreateUserRequest struct {
 	UUID     string   `json:"uuid"`
@@ -199,16 +255,31 @@ type CreateUserRequest struct {
 }
 
 func (s *userService) publishToKafka(u *domain.User) error {
-	evt := SearchEvent{Type: "user", Data: u}
-	payload, _ := json.Marshal(evt)
+	payload, err := events.Marshal(events.ProducerUser, &events.UserUpserted{User: UserEvent(u)})
+	if err != nil {
+		return err
+	}
 
 	return s.kafkaProd.WriteMessages(context.Background(),
-		kafka.Message{Value: payload})
+		kafka.Message{Key: []byte(u.UUID), Value: payload})
 }
 
-type SearchEvent struct {
-	Type string      `json:"type"` // "user", "chat", "message"
-	Data interface{} `json:"data"` // сам объект
+// UserEvent переводит пользователя в контракт user.upserted
+func UserEvent(u *domain.User) *events.User {
+	evt := &events.User{
+		Id:        u.UUID,
+		UserName:  u.UserName,
+		Email:     u.Email,
+		Friends:   u.Friends,
+		UpdatedAt: u.UpdatedAt.Unix(),
+	}
+	if u.Avatar != nil {
+		evt.Avatar = *u.Avatar
+	}
+	if u.AboutMe != nil {
+		evt.AboutMe = *u.AboutMe
+	}
+	return evt
 }
 
 type UpdateUserRequest struct {
@@ -82,6 +82,54 @@ func (m *mockUserService) GetOnlineUsers(ctx context.Context) ([]string, error)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockUserService) BlockUser(ctx context.Context, uuid, targetUUID string) error {
+	args := m.Called(ctx, uuid, targetUUID)
+	return args.Error(0)
+}
+
+func (m *mockUserService) UnblockUser(ctx context.Context, uuid, targetUUID string) error {
+	args := m.Called(ctx, uuid, targetUUID)
+	return args.Error(0)
+}
+
+func (m *mockUserService) ListBlockedUsers(ctx context.Context, uuid string, limit, offset int) ([]string, int, error) {
+	args := m.Called(ctx, uuid, limit, offset)
+	return args.Get(0).([]string), args.Int(1), args.Error(2)
+}
+
+func (m *mockUserService) CheckBlocked(ctx context.Context, uuid string, userUUIDs []string) ([]string, error) {
+	args := m.Called(ctx, uuid, userUUIDs)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockUserService) ResolveUserNames(ctx context.Context, userNames, among []string) ([]domain.User, error) {
+	args := m.Called(ctx, userNames, among)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *mockUserService) UploadKeys(ctx context.Context, keys domain.DeviceKeys, prekeys []domain.OneTimePreKey) (int, error) {
+	args := m.Called(ctx, keys, prekeys)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockUserService) GetKeyBundles(ctx context.Context, uuid, requesterUUID string) ([]domain.KeyBundle, error) {
+	args := m.Called(ctx, uuid, requesterUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.KeyBundle), args.Error(1)
+}
+
+func (m *mockUserService) ListDevices(ctx context.Context, uuids []string) ([]domain.Device, error) {
+	args := m.Called(ctx, uuids)
+	return args.Get(0).([]domain.Device), args.Error(1)
+}
+
+func (m *mockUserService) RemoveDevice(ctx context.Context, uuid, deviceID string) error {
+	args := m.Called(ctx, uuid, deviceID)
+	return args.Error(0)
+}
+
 func TestCreateUser_Success(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
@@ -653,6 +701,171 @@ func TestGetOnlineUsers_Empty(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestBlockUser_Self(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("BlockUser", ctx, "u1", "u1").Return(domain.ErrCannotBlockSelf)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	resp, err := handler.BlockUser(ctx, &user.BlockUserRequest{Uuid: "u1", TargetUuid: "u1"})
+
+	// Assert
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestBlockUser_NotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("BlockUser", ctx, "u1", "missing").Return(errors.New("user not found"))
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	_, err := handler.BlockUser(ctx, &user.BlockUserRequest{Uuid: "u1", TargetUuid: "missing"})
+
+	// Assert
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
+func TestListBlockedUsers_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("ListBlockedUsers", ctx, "u1", 20, 0).Return([]string{"u2"}, 1, nil)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	resp, err := handler.ListBlockedUsers(ctx, &user.ListBlockedUsersRequest{Uuid: "u1", Limit: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, resp.Uuids)
+	assert.Equal(t, int32(1), resp.Total)
+	mockService.AssertExpectations(t)
+}
+
+func TestCheckBlocked_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("CheckBlocked", ctx, "u1", []string{"u2", "u3"}).Return([]string{"u3"}, nil)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	resp, err := handler.CheckBlocked(ctx, &user.CheckBlockedRequest{Uuid: "u1", UserUuids: []string{"u2", "u3"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, resp.BlockedBy)
+	mockService.AssertExpectations(t)
+}
+
+func TestResolveUserNames(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("ResolveUserNames", ctx, []string{"Bob"}, []string{"u1", "u2"}).
+		Return([]domain.User{{UUID: "u2", UserName: "bob"}}, nil)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	resp, err := handler.ResolveUserNames(ctx, &user.ResolveUserNamesRequest{UserNames: []string{"Bob"}, Uuids: []string{"u1", "u2"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 1)
+	assert.Equal(t, "u2", resp.Users[0].Uuid)
+	assert.Equal(t, "bob", resp.Users[0].UserName)
+	mockService.AssertExpectations(t)
+}
+
+func TestUploadKeys_Invalid(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("UploadKeys", ctx, mock.Anything, mock.Anything).Return(0, domain.ErrInvalidKeys)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	_, err := handler.UploadKeys(ctx, &user.UploadKeysRequest{Uuid: "u1", DeviceId: "phone"})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
+func TestGetKeyBundles_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("GetKeyBundles", ctx, "u2", "u1").Return([]domain.KeyBundle{
+		{
+			DeviceKeys:    domain.DeviceKeys{DeviceID: "phone", IdentityKey: []byte("ik"), SignedPreKey: domain.SignedPreKey{KeyID: 3}},
+			OneTimePreKey: &domain.OneTimePreKey{KeyID: 9, PublicKey: []byte("otk")},
+		},
+		{DeviceKeys: domain.DeviceKeys{DeviceID: "laptop"}},
+	}, nil)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	resp, err := handler.GetKeyBundles(ctx, &user.GetKeyBundlesRequest{Uuid: "u2", RequesterUuid: "u1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Bundles, 2)
+	assert.Equal(t, uint32(3), resp.Bundles[0].SignedPrekey.KeyId)
+	assert.Equal(t, uint32(9), resp.Bundles[0].OneTimePrekey.KeyId)
+	assert.Nil(t, resp.Bundles[1].OneTimePrekey)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetKeyBundles_Blocked(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockService := new(mockUserService)
+	mockService.On("GetKeyBundles", ctx, "u2", "u1").Return(nil, domain.ErrBlockedByUser)
+
+	handler := &userHandler{
+		userService: mockService,
+	}
+
+	// Act
+	_, err := handler.GetKeyBundles(ctx, &user.GetKeyBundlesRequest{Uuid: "u2", RequesterUuid: "u1"})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
 func TestNewUserHandler(t *testing.T) {
 	// Arrange
 	mockService := new(mockUserService)
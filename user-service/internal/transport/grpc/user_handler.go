@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"main/internal/domain"
 	"main/internal/service"
 	user "main/pkg/api"
 
@@ -134,3 +136,142 @@ func (h *userHandler) GetOnlineUsers(ctx context.Context, req *user.GetOnlineUse
 	}
 	return &user.GetOnlineUsersResponse{Uuids: users}, nil
 }
+
+func (h *userHandler) BlockUser(ctx context.Context, req *user.BlockUserRequest) (*user.StatusResponse, error) {
+	err := h.userService.BlockUser(ctx, req.GetUuid(), req.GetTargetUuid())
+	if err != nil {
+		if errors.Is(err, domain.ErrCannotBlockSelf) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err.Error() == "user not found" {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &user.StatusResponse{Success: true, Message: "user blocked"}, nil
+}
+
+func (h *userHandler) UnblockUser(ctx context.Context, req *user.UnblockUserRequest) (*user.StatusResponse, error) {
+	err := h.userService.UnblockUser(ctx, req.GetUuid(), req.GetTargetUuid())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &user.StatusResponse{Success: true, Message: "user unblocked"}, nil
+}
+
+func (h *userHandler) ListBlockedUsers(ctx context.Context, req *user.ListBlockedUsersRequest) (*user.ListBlockedUsersResponse, error) {
+	uuids, total, err := h.userService.ListBlockedUsers(ctx, req.GetUuid(), int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &user.ListBlockedUsersResponse{Uuids: uuids, Total: int32(total)}, nil
+}
+
+func (h *userHandler) CheckBlocked(ctx context.Context, req *user.CheckBlockedRequest) (*user.CheckBlockedResponse, error) {
+	blockedBy, err := h.userService.CheckBlocked(ctx, req.GetUuid(), req.GetUserUuids())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &user.CheckBlockedResponse{BlockedBy: blockedBy}, nil
+}
+
+func (h *userHandler) ResolveUserNames(ctx context.Context, req *user.ResolveUserNamesRequest) (*user.ResolveUserNamesResponse, error) {
+	users, err := h.userService.ResolveUserNames(ctx, req.GetUserNames(), req.GetUuids())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &user.ResolveUserNamesResponse{Users: make([]*user.UserName, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, &user.UserName{Uuid: u.UUID, UserName: u.UserName})
+	}
+	return resp, nil
+}
+
+func (h *userHandler) UploadKeys(ctx context.Context, req *user.UploadKeysRequest) (*user.UploadKeysResponse, error) {
+	keys := domain.DeviceKeys{
+		UserUUID:    req.GetUuid(),
+		DeviceID:    req.GetDeviceId(),
+		IdentityKey: req.GetIdentityKey(),
+		SignedPreKey: domain.SignedPreKey{
+			KeyID:     req.GetSignedPrekey().GetKeyId(),
+			PublicKey: req.GetSignedPrekey().GetPublicKey(),
+			Signature: req.GetSignedPrekey().GetSignature(),
+		},
+	}
+	prekeys := make([]domain.OneTimePreKey, 0, len(req.GetOneTimePrekeys()))
+	for _, pk := range req.GetOneTimePrekeys() {
+		prekeys = append(prekeys, domain.OneTimePreKey{KeyID: pk.GetKeyId(), PublicKey: pk.GetPublicKey()})
+	}
+
+	count, err := h.userService.UploadKeys(ctx, keys, prekeys)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidKeys) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err.Error() == "user not found" {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &user.UploadKeysResponse{OneTimePrekeys: int32(count)}, nil
+}
+
+func (h *userHandler) GetKeyBundles(ctx context.Context, req *user.GetKeyBundlesRequest) (*user.GetKeyBundlesResponse, error) {
+	bundles, err := h.userService.GetKeyBundles(ctx, req.GetUuid(), req.GetRequesterUuid())
+	if err != nil {
+		if errors.Is(err, domain.ErrBlockedByUser) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrNoDevices) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &user.GetKeyBundlesResponse{Uuid: req.GetUuid()}
+	for _, b := range bundles {
+		pb := &user.KeyBundle{
+			DeviceId:    b.DeviceID,
+			IdentityKey: b.IdentityKey,
+			SignedPrekey: &user.SignedPreKey{
+				KeyId:     b.SignedPreKey.KeyID,
+				PublicKey: b.SignedPreKey.PublicKey,
+				Signature: b.SignedPreKey.Signature,
+			},
+		}
+		if b.OneTimePreKey != nil {
+			pb.OneTimePrekey = &user.OneTimePreKey{KeyId: b.OneTimePreKey.KeyID, PublicKey: b.OneTimePreKey.PublicKey}
+		}
+		resp.Bundles = append(resp.Bundles, pb)
+	}
+	return resp, nil
+}
+
+func (h *userHandler) ListDevices(ctx context.Context, req *user.ListDevicesRequest) (*user.ListDevicesResponse, error) {
+	devices, err := h.userService.ListDevices(ctx, req.GetUuids())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &user.ListDevicesResponse{}
+	for _, d := range devices {
+		resp.Devices = append(resp.Devices, &user.Device{
+			Uuid:           d.UserUUID,
+			DeviceId:       d.DeviceID,
+			OneTimePrekeys: int32(d.OneTimePreKeys),
+			UpdatedAt:      d.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func (h *userHandler) RemoveDevice(ctx context.Context, req *user.RemoveDeviceRequest) (*user.StatusResponse, error) {
+	err := h.userService.RemoveDevice(ctx, req.GetUuid(), req.GetDeviceId())
+	if err != nil {
+		if errors.Is(err, domain.ErrDeviceNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &user.StatusResponse{Success: true, Message: "device removed"}, nil
+}
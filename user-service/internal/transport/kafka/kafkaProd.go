@@ -2,24 +2,29 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"main/internal/config"
 	"main/internal/domain"
+	"main/internal/service"
 	"main/internal/transport/kafka/models"
 
+	"contracts/events"
+
 	"github.com/segmentio/kafka-go"
 )
 
 func KafkaProd(user *domain.User) error {
+	if user == nil {
+		return fmt.Errorf("пользователь не создан")
+	}
 	writer := kafka.NewWriter(kafka.WriterConfig{
 		Brokers: []string{config.Load().KafkaBroker},
 		Topic:   models.WriteTopikName,
 	})
 	defer writer.Close()
 
-	// сериализация структуры в JSON
-	data, err := json.Marshal(user)
+	// сериализация в конверт общего контракта user.upserted
+	data, err := events.Marshal(events.ProducerUser, &events.UserUpserted{User: service.UserEvent(user)})
 	if err != nil {
 		return fmt.Errorf("ошибка сериализации: %w", err)
 	}
@@ -27,6 +32,7 @@ func KafkaProd(user *domain.User) error {
 	// отправка сообщения
 	err = writer.WriteMessages(context.Background(),
 		kafka.Message{
+			Key:   []byte(user.UUID),
 			Value: data,
 		},
 	)
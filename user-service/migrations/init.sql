@@ -14,3 +14,34 @@ CREATE TABLE IF NOT EXISTS users (
 );
 ALTER TABLE users
 ADD COLUMN status VARCHAR(20) DEFAULT 'offline';
+
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_uuid UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    blocked_uuid UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (blocker_uuid, blocked_uuid)
+);
+-- Проверки других сервисов идут от заблокированного: кто заблокировал uuid
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks (blocked_uuid);
+
+-- Каталог ключей E2E-шифрования: по одной записи на устройство пользователя
+CREATE TABLE IF NOT EXISTS device_keys (
+    user_uuid UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    device_id VARCHAR(64) NOT NULL,
+    identity_key BYTEA NOT NULL,
+    signed_prekey_id BIGINT NOT NULL,
+    signed_prekey BYTEA NOT NULL,
+    signed_prekey_signature BYTEA NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (user_uuid, device_id)
+);
+
+-- Одноразовые prekey выдаются по одному и сразу удаляются
+CREATE TABLE IF NOT EXISTS one_time_prekeys (
+    user_uuid UUID NOT NULL,
+    device_id VARCHAR(64) NOT NULL,
+    key_id BIGINT NOT NULL,
+    public_key BYTEA NOT NULL,
+    PRIMARY KEY (user_uuid, device_id, key_id),
+    FOREIGN KEY (user_uuid, device_id) REFERENCES device_keys(user_uuid, device_id) ON DELETE CASCADE
+);
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.10
-// 	protoc        v3.21.12
+// 	protoc        v3.6.1
 // source: user.proto
 
 package user
@@ -565,37 +565,998 @@ func (*GetOnlineUsersRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{9}
 }
 
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                               // Кто блокирует
+	TargetUuid    string                 `protobuf:"bytes,2,opt,name=target_uuid,json=targetUuid,proto3" json:"target_uuid,omitempty"` // Кого блокируют
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BlockUserRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetTargetUuid() string {
+	if x != nil {
+		return x.TargetUuid
+	}
+	return ""
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	TargetUuid    string                 `protobuf:"bytes,2,opt,name=target_uuid,json=targetUuid,proto3" json:"target_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnblockUserRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetTargetUuid() string {
+	if x != nil {
+		return x.TargetUuid
+	}
+	return ""
+}
+
+type ListBlockedUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedUsersRequest) Reset() {
+	*x = ListBlockedUsersRequest{}
+	mi := &file_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedUsersRequest) ProtoMessage() {}
+
+func (x *ListBlockedUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListBlockedUsersRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ListBlockedUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBlockedUsersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Кто из user_uuids заблокировал uuid — для проверок в других сервисах
+type CheckBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	UserUuids     []string               `protobuf:"bytes,2,rep,name=user_uuids,json=userUuids,proto3" json:"user_uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckBlockedRequest) Reset() {
+	*x = CheckBlockedRequest{}
+	mi := &file_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckBlockedRequest) ProtoMessage() {}
+
+func (x *CheckBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckBlockedRequest.ProtoReflect.Descriptor instead.
+func (*CheckBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CheckBlockedRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CheckBlockedRequest) GetUserUuids() []string {
+	if x != nil {
+		return x.UserUuids
+	}
+	return nil
+}
+
+// Кто из uuids носит одно из user_names (без учёта регистра) — разбор @упоминаний в чатах
+type ResolveUserNamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserNames     []string               `protobuf:"bytes,1,rep,name=user_names,json=userNames,proto3" json:"user_names,omitempty"`
+	Uuids         []string               `protobuf:"bytes,2,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUserNamesRequest) Reset() {
+	*x = ResolveUserNamesRequest{}
+	mi := &file_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUserNamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUserNamesRequest) ProtoMessage() {}
+
+func (x *ResolveUserNamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUserNamesRequest.ProtoReflect.Descriptor instead.
+func (*ResolveUserNamesRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResolveUserNamesRequest) GetUserNames() []string {
+	if x != nil {
+		return x.UserNames
+	}
+	return nil
+}
+
+func (x *ResolveUserNamesRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+// Ключи E2E-шифрования: identity_key — публичный Ed25519, остальные — X25519
+type SignedPreKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"` // Подпись public_key identity-ключом
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedPreKey) Reset() {
+	*x = SignedPreKey{}
+	mi := &file_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedPreKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedPreKey) ProtoMessage() {}
+
+func (x *SignedPreKey) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedPreKey.ProtoReflect.Descriptor instead.
+func (*SignedPreKey) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SignedPreKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *SignedPreKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SignedPreKey) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type OneTimePreKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OneTimePreKey) Reset() {
+	*x = OneTimePreKey{}
+	mi := &file_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OneTimePreKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneTimePreKey) ProtoMessage() {}
+
+func (x *OneTimePreKey) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneTimePreKey.ProtoReflect.Descriptor instead.
+func (*OneTimePreKey) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OneTimePreKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *OneTimePreKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type UploadKeysRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IdentityKey    []byte                 `protobuf:"bytes,3,opt,name=identity_key,json=identityKey,proto3" json:"identity_key,omitempty"`
+	SignedPrekey   *SignedPreKey          `protobuf:"bytes,4,opt,name=signed_prekey,json=signedPrekey,proto3" json:"signed_prekey,omitempty"`
+	OneTimePrekeys []*OneTimePreKey       `protobuf:"bytes,5,rep,name=one_time_prekeys,json=oneTimePrekeys,proto3" json:"one_time_prekeys,omitempty"` // Пополнение запаса, до 100 за раз
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UploadKeysRequest) Reset() {
+	*x = UploadKeysRequest{}
+	mi := &file_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadKeysRequest) ProtoMessage() {}
+
+func (x *UploadKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadKeysRequest.ProtoReflect.Descriptor instead.
+func (*UploadKeysRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UploadKeysRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UploadKeysRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UploadKeysRequest) GetIdentityKey() []byte {
+	if x != nil {
+		return x.IdentityKey
+	}
+	return nil
+}
+
+func (x *UploadKeysRequest) GetSignedPrekey() *SignedPreKey {
+	if x != nil {
+		return x.SignedPrekey
+	}
+	return nil
+}
+
+func (x *UploadKeysRequest) GetOneTimePrekeys() []*OneTimePreKey {
+	if x != nil {
+		return x.OneTimePrekeys
+	}
+	return nil
+}
+
+// Наборы ключей всех устройств uuid; каждый вызов расходует по одноразовому ключу
+type GetKeyBundlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	RequesterUuid string                 `protobuf:"bytes,2,opt,name=requester_uuid,json=requesterUuid,proto3" json:"requester_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyBundlesRequest) Reset() {
+	*x = GetKeyBundlesRequest{}
+	mi := &file_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyBundlesRequest) ProtoMessage() {}
+
+func (x *GetKeyBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyBundlesRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetKeyBundlesRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GetKeyBundlesRequest) GetRequesterUuid() string {
+	if x != nil {
+		return x.RequesterUuid
+	}
+	return ""
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDevicesRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+type RemoveDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceRequest) Reset() {
+	*x = RemoveDeviceRequest{}
+	mi := &file_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceRequest) ProtoMessage() {}
+
+func (x *RemoveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoveDeviceRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RemoveDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
 // Ответы
 type UserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	UserName      string                 `protobuf:"bytes,3,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
-	Avatar        string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
-	AboutMe       string                 `protobuf:"bytes,5,opt,name=about_me,json=aboutMe,proto3" json:"about_me,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Friends       []string               `protobuf:"bytes,9,rep,name=friends,proto3" json:"friends,omitempty"` // Добавлено поле friends
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	UserName      string                 `protobuf:"bytes,3,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Avatar        string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	AboutMe       string                 `protobuf:"bytes,5,opt,name=about_me,json=aboutMe,proto3" json:"about_me,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Friends       []string               `protobuf:"bytes,9,rep,name=friends,proto3" json:"friends,omitempty"` // Добавлено поле friends
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserResponse) Reset() {
+	*x = UserResponse{}
+	mi := &file_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserResponse) ProtoMessage() {}
+
+func (x *UserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
+func (*UserResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UserResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UserResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserResponse) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *UserResponse) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *UserResponse) GetAboutMe() string {
+	if x != nil {
+		return x.AboutMe
+	}
+	return ""
+}
+
+func (x *UserResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *UserResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *UserResponse) GetFriends() []string {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserResponse        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserResponse {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type IsOnlineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Online        bool                   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsOnlineResponse) Reset() {
+	*x = IsOnlineResponse{}
+	mi := &file_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsOnlineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsOnlineResponse) ProtoMessage() {}
+
+func (x *IsOnlineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsOnlineResponse.ProtoReflect.Descriptor instead.
+func (*IsOnlineResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *IsOnlineResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *IsOnlineResponse) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+type GetOnlineUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOnlineUsersResponse) Reset() {
+	*x = GetOnlineUsersResponse{}
+	mi := &file_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOnlineUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOnlineUsersResponse) ProtoMessage() {}
+
+func (x *GetOnlineUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOnlineUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetOnlineUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOnlineUsersResponse) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+type ListBlockedUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserResponse) Reset() {
-	*x = UserResponse{}
-	mi := &file_user_proto_msgTypes[10]
+func (x *ListBlockedUsersResponse) Reset() {
+	*x = ListBlockedUsersResponse{}
+	mi := &file_user_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserResponse) String() string {
+func (x *ListBlockedUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserResponse) ProtoMessage() {}
+func (*ListBlockedUsersResponse) ProtoMessage() {}
 
-func (x *UserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[10]
+func (x *ListBlockedUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -606,97 +1567,92 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
-func (*UserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListBlockedUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *UserResponse) GetUuid() string {
+func (x *ListBlockedUsersResponse) GetUuids() []string {
 	if x != nil {
-		return x.Uuid
+		return x.Uuids
 	}
-	return ""
+	return nil
 }
 
-func (x *UserResponse) GetEmail() string {
+func (x *ListBlockedUsersResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Email
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-func (x *UserResponse) GetUserName() string {
-	if x != nil {
-		return x.UserName
-	}
-	return ""
+type CheckBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedBy     []string               `protobuf:"bytes,1,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserResponse) GetAvatar() string {
-	if x != nil {
-		return x.Avatar
-	}
-	return ""
+func (x *CheckBlockedResponse) Reset() {
+	*x = CheckBlockedResponse{}
+	mi := &file_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserResponse) GetAboutMe() string {
-	if x != nil {
-		return x.AboutMe
-	}
-	return ""
+func (x *CheckBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *UserResponse) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
+func (*CheckBlockedResponse) ProtoMessage() {}
 
-func (x *UserResponse) GetCreatedAt() string {
+func (x *CheckBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[28]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UserResponse) GetUpdatedAt() string {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return ""
+// Deprecated: Use CheckBlockedResponse.ProtoReflect.Descriptor instead.
+func (*CheckBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *UserResponse) GetFriends() []string {
+func (x *CheckBlockedResponse) GetBlockedBy() []string {
 	if x != nil {
-		return x.Friends
+		return x.BlockedBy
 	}
 	return nil
 }
 
-type DeleteUserResponse struct {
+type UserName struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserResponse) Reset() {
-	*x = DeleteUserResponse{}
-	mi := &file_user_proto_msgTypes[11]
+func (x *UserName) Reset() {
+	*x = UserName{}
+	mi := &file_user_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserResponse) String() string {
+func (x *UserName) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserResponse) ProtoMessage() {}
+func (*UserName) ProtoMessage() {}
 
-func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[11]
+func (x *UserName) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,48 +1663,47 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
-func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use UserName.ProtoReflect.Descriptor instead.
+func (*UserName) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *DeleteUserResponse) GetSuccess() bool {
+func (x *UserName) GetUuid() string {
 	if x != nil {
-		return x.Success
+		return x.Uuid
 	}
-	return false
+	return ""
 }
 
-func (x *DeleteUserResponse) GetMessage() string {
+func (x *UserName) GetUserName() string {
 	if x != nil {
-		return x.Message
+		return x.UserName
 	}
 	return ""
 }
 
-type ListUsersResponse struct {
+type ResolveUserNamesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*UserResponse        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Users         []*UserName            `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersResponse) Reset() {
-	*x = ListUsersResponse{}
-	mi := &file_user_proto_msgTypes[12]
+func (x *ResolveUserNamesResponse) Reset() {
+	*x = ResolveUserNamesResponse{}
+	mi := &file_user_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersResponse) String() string {
+func (x *ResolveUserNamesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersResponse) ProtoMessage() {}
+func (*ResolveUserNamesResponse) ProtoMessage() {}
 
-func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[12]
+func (x *ResolveUserNamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,48 +1714,87 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
-func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ResolveUserNamesResponse.ProtoReflect.Descriptor instead.
+func (*ResolveUserNamesResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ListUsersResponse) GetUsers() []*UserResponse {
+func (x *ResolveUserNamesResponse) GetUsers() []*UserName {
 	if x != nil {
 		return x.Users
 	}
 	return nil
 }
 
-func (x *ListUsersResponse) GetTotal() int32 {
+type UploadKeysResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OneTimePrekeys int32                  `protobuf:"varint,1,opt,name=one_time_prekeys,json=oneTimePrekeys,proto3" json:"one_time_prekeys,omitempty"` // Сколько одноразовых ключей осталось у устройства
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UploadKeysResponse) Reset() {
+	*x = UploadKeysResponse{}
+	mi := &file_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadKeysResponse) ProtoMessage() {}
+
+func (x *UploadKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[31]
 	if x != nil {
-		return x.Total
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadKeysResponse.ProtoReflect.Descriptor instead.
+func (*UploadKeysResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UploadKeysResponse) GetOneTimePrekeys() int32 {
+	if x != nil {
+		return x.OneTimePrekeys
 	}
 	return 0
 }
 
-type StatusResponse struct {
+type KeyBundle struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IdentityKey   []byte                 `protobuf:"bytes,2,opt,name=identity_key,json=identityKey,proto3" json:"identity_key,omitempty"`
+	SignedPrekey  *SignedPreKey          `protobuf:"bytes,3,opt,name=signed_prekey,json=signedPrekey,proto3" json:"signed_prekey,omitempty"`
+	OneTimePrekey *OneTimePreKey         `protobuf:"bytes,4,opt,name=one_time_prekey,json=oneTimePrekey,proto3" json:"one_time_prekey,omitempty"` // Пусто, если запас кончился
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
-	mi := &file_user_proto_msgTypes[13]
+func (x *KeyBundle) Reset() {
+	*x = KeyBundle{}
+	mi := &file_user_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusResponse) String() string {
+func (x *KeyBundle) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse) ProtoMessage() {}
+func (*KeyBundle) ProtoMessage() {}
 
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[13]
+func (x *KeyBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,48 +1805,62 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use KeyBundle.ProtoReflect.Descriptor instead.
+func (*KeyBundle) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *StatusResponse) GetSuccess() bool {
+func (x *KeyBundle) GetDeviceId() string {
 	if x != nil {
-		return x.Success
+		return x.DeviceId
 	}
-	return false
+	return ""
 }
 
-func (x *StatusResponse) GetMessage() string {
+func (x *KeyBundle) GetIdentityKey() []byte {
 	if x != nil {
-		return x.Message
+		return x.IdentityKey
 	}
-	return ""
+	return nil
 }
 
-type IsOnlineResponse struct {
+func (x *KeyBundle) GetSignedPrekey() *SignedPreKey {
+	if x != nil {
+		return x.SignedPrekey
+	}
+	return nil
+}
+
+func (x *KeyBundle) GetOneTimePrekey() *OneTimePreKey {
+	if x != nil {
+		return x.OneTimePrekey
+	}
+	return nil
+}
+
+type GetKeyBundlesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Online        bool                   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	Bundles       []*KeyBundle           `protobuf:"bytes,2,rep,name=bundles,proto3" json:"bundles,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IsOnlineResponse) Reset() {
-	*x = IsOnlineResponse{}
-	mi := &file_user_proto_msgTypes[14]
+func (x *GetKeyBundlesResponse) Reset() {
+	*x = GetKeyBundlesResponse{}
+	mi := &file_user_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsOnlineResponse) String() string {
+func (x *GetKeyBundlesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsOnlineResponse) ProtoMessage() {}
+func (*GetKeyBundlesResponse) ProtoMessage() {}
 
-func (x *IsOnlineResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[14]
+func (x *GetKeyBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,47 +1871,115 @@ func (x *IsOnlineResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsOnlineResponse.ProtoReflect.Descriptor instead.
-func (*IsOnlineResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetKeyBundlesResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *IsOnlineResponse) GetUuid() string {
+func (x *GetKeyBundlesResponse) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *IsOnlineResponse) GetOnline() bool {
+func (x *GetKeyBundlesResponse) GetBundles() []*KeyBundle {
 	if x != nil {
-		return x.Online
+		return x.Bundles
 	}
-	return false
+	return nil
 }
 
-type GetOnlineUsersResponse struct {
+type Device struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	OneTimePrekeys int32                  `protobuf:"varint,3,opt,name=one_time_prekeys,json=oneTimePrekeys,proto3" json:"one_time_prekeys,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Device) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Device) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Device) GetOneTimePrekeys() int32 {
+	if x != nil {
+		return x.OneTimePrekeys
+	}
+	return 0
+}
+
+func (x *Device) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListDevicesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOnlineUsersResponse) Reset() {
-	*x = GetOnlineUsersResponse{}
-	mi := &file_user_proto_msgTypes[15]
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_user_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOnlineUsersResponse) String() string {
+func (x *ListDevicesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOnlineUsersResponse) ProtoMessage() {}
+func (*ListDevicesResponse) ProtoMessage() {}
 
-func (x *GetOnlineUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[15]
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,14 +1990,14 @@ func (x *GetOnlineUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOnlineUsersResponse.ProtoReflect.Descriptor instead.
-func (*GetOnlineUsersResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetOnlineUsersResponse) GetUuids() []string {
+func (x *ListDevicesResponse) GetDevices() []*Device {
 	if x != nil {
-		return x.Uuids
+		return x.Devices
 	}
 	return nil
 }
@@ -965,7 +2041,50 @@ const file_user_proto_rawDesc = "" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"%\n" +
 	"\x0fIsOnlineRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x17\n" +
-	"\x15GetOnlineUsersRequest\"\xf8\x01\n" +
+	"\x15GetOnlineUsersRequest\"G\n" +
+	"\x10BlockUserRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1f\n" +
+	"\vtarget_uuid\x18\x02 \x01(\tR\n" +
+	"targetUuid\"I\n" +
+	"\x12UnblockUserRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1f\n" +
+	"\vtarget_uuid\x18\x02 \x01(\tR\n" +
+	"targetUuid\"[\n" +
+	"\x17ListBlockedUsersRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"H\n" +
+	"\x13CheckBlockedRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1d\n" +
+	"\n" +
+	"user_uuids\x18\x02 \x03(\tR\tuserUuids\"N\n" +
+	"\x17ResolveUserNamesRequest\x12\x1d\n" +
+	"\n" +
+	"user_names\x18\x01 \x03(\tR\tuserNames\x12\x14\n" +
+	"\x05uuids\x18\x02 \x03(\tR\x05uuids\"b\n" +
+	"\fSignedPreKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\"E\n" +
+	"\rOneTimePreKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\"\xdf\x01\n" +
+	"\x11UploadKeysRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12!\n" +
+	"\fidentity_key\x18\x03 \x01(\fR\videntityKey\x127\n" +
+	"\rsigned_prekey\x18\x04 \x01(\v2\x12.user.SignedPreKeyR\fsignedPrekey\x12=\n" +
+	"\x10one_time_prekeys\x18\x05 \x03(\v2\x13.user.OneTimePreKeyR\x0eoneTimePrekeys\"Q\n" +
+	"\x14GetKeyBundlesRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12%\n" +
+	"\x0erequester_uuid\x18\x02 \x01(\tR\rrequesterUuid\"*\n" +
+	"\x12ListDevicesRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"F\n" +
+	"\x13RemoveDeviceRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\"\xf8\x01\n" +
 	"\fUserResponse\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1b\n" +
@@ -991,7 +2110,36 @@ const file_user_proto_rawDesc = "" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x16\n" +
 	"\x06online\x18\x02 \x01(\bR\x06online\".\n" +
 	"\x16GetOnlineUsersResponse\x12\x14\n" +
-	"\x05uuids\x18\x01 \x03(\tR\x05uuids2\xf0\x04\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"F\n" +
+	"\x18ListBlockedUsersResponse\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"5\n" +
+	"\x14CheckBlockedResponse\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x01 \x03(\tR\tblockedBy\";\n" +
+	"\bUserName\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\"@\n" +
+	"\x18ResolveUserNamesResponse\x12$\n" +
+	"\x05users\x18\x01 \x03(\v2\x0e.user.UserNameR\x05users\">\n" +
+	"\x12UploadKeysResponse\x12(\n" +
+	"\x10one_time_prekeys\x18\x01 \x01(\x05R\x0eoneTimePrekeys\"\xc1\x01\n" +
+	"\tKeyBundle\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12!\n" +
+	"\fidentity_key\x18\x02 \x01(\fR\videntityKey\x127\n" +
+	"\rsigned_prekey\x18\x03 \x01(\v2\x12.user.SignedPreKeyR\fsignedPrekey\x12;\n" +
+	"\x0fone_time_prekey\x18\x04 \x01(\v2\x13.user.OneTimePreKeyR\roneTimePrekey\"V\n" +
+	"\x15GetKeyBundlesResponse\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12)\n" +
+	"\abundles\x18\x02 \x03(\v2\x0f.user.KeyBundleR\abundles\"\x82\x01\n" +
+	"\x06Device\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12(\n" +
+	"\x10one_time_prekeys\x18\x03 \x01(\x05R\x0eoneTimePrekeys\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"=\n" +
+	"\x13ListDevicesResponse\x12&\n" +
+	"\adevices\x18\x01 \x03(\v2\f.user.DeviceR\adevices2\xe7\t\n" +
 	"\vUserService\x129\n" +
 	"\n" +
 	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x12.user.UserResponse\x123\n" +
@@ -1006,7 +2154,17 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"SetOffline\x12\x17.user.SetOfflineRequest\x1a\x14.user.StatusResponse\x129\n" +
 	"\bIsOnline\x12\x15.user.IsOnlineRequest\x1a\x16.user.IsOnlineResponse\x12K\n" +
-	"\x0eGetOnlineUsers\x12\x1b.user.GetOnlineUsersRequest\x1a\x1c.user.GetOnlineUsersResponseB\bZ\x06./userb\x06proto3"
+	"\x0eGetOnlineUsers\x12\x1b.user.GetOnlineUsersRequest\x1a\x1c.user.GetOnlineUsersResponse\x129\n" +
+	"\tBlockUser\x12\x16.user.BlockUserRequest\x1a\x14.user.StatusResponse\x12=\n" +
+	"\vUnblockUser\x12\x18.user.UnblockUserRequest\x1a\x14.user.StatusResponse\x12Q\n" +
+	"\x10ListBlockedUsers\x12\x1d.user.ListBlockedUsersRequest\x1a\x1e.user.ListBlockedUsersResponse\x12E\n" +
+	"\fCheckBlocked\x12\x19.user.CheckBlockedRequest\x1a\x1a.user.CheckBlockedResponse\x12Q\n" +
+	"\x10ResolveUserNames\x12\x1d.user.ResolveUserNamesRequest\x1a\x1e.user.ResolveUserNamesResponse\x12?\n" +
+	"\n" +
+	"UploadKeys\x12\x17.user.UploadKeysRequest\x1a\x18.user.UploadKeysResponse\x12H\n" +
+	"\rGetKeyBundles\x12\x1a.user.GetKeyBundlesRequest\x1a\x1b.user.GetKeyBundlesResponse\x12B\n" +
+	"\vListDevices\x12\x18.user.ListDevicesRequest\x1a\x19.user.ListDevicesResponse\x12?\n" +
+	"\fRemoveDevice\x12\x19.user.RemoveDeviceRequest\x1a\x14.user.StatusResponseB\bZ\x06./userb\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
@@ -1020,52 +2178,97 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_user_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),      // 0: user.CreateUserRequest
-	(*GetUserRequest)(nil),         // 1: user.GetUserRequest
-	(*UpdateUserRequest)(nil),      // 2: user.UpdateUserRequest
-	(*DeleteUserRequest)(nil),      // 3: user.DeleteUserRequest
-	(*ListUsersRequest)(nil),       // 4: user.ListUsersRequest
-	(*AboutMeRequest)(nil),         // 5: user.AboutMeRequest
-	(*SetOnlineRequest)(nil),       // 6: user.SetOnlineRequest
-	(*SetOfflineRequest)(nil),      // 7: user.SetOfflineRequest
-	(*IsOnlineRequest)(nil),        // 8: user.IsOnlineRequest
-	(*GetOnlineUsersRequest)(nil),  // 9: user.GetOnlineUsersRequest
-	(*UserResponse)(nil),           // 10: user.UserResponse
-	(*DeleteUserResponse)(nil),     // 11: user.DeleteUserResponse
-	(*ListUsersResponse)(nil),      // 12: user.ListUsersResponse
-	(*StatusResponse)(nil),         // 13: user.StatusResponse
-	(*IsOnlineResponse)(nil),       // 14: user.IsOnlineResponse
-	(*GetOnlineUsersResponse)(nil), // 15: user.GetOnlineUsersResponse
+	(*CreateUserRequest)(nil),        // 0: user.CreateUserRequest
+	(*GetUserRequest)(nil),           // 1: user.GetUserRequest
+	(*UpdateUserRequest)(nil),        // 2: user.UpdateUserRequest
+	(*DeleteUserRequest)(nil),        // 3: user.DeleteUserRequest
+	(*ListUsersRequest)(nil),         // 4: user.ListUsersRequest
+	(*AboutMeRequest)(nil),           // 5: user.AboutMeRequest
+	(*SetOnlineRequest)(nil),         // 6: user.SetOnlineRequest
+	(*SetOfflineRequest)(nil),        // 7: user.SetOfflineRequest
+	(*IsOnlineRequest)(nil),          // 8: user.IsOnlineRequest
+	(*GetOnlineUsersRequest)(nil),    // 9: user.GetOnlineUsersRequest
+	(*BlockUserRequest)(nil),         // 10: user.BlockUserRequest
+	(*UnblockUserRequest)(nil),       // 11: user.UnblockUserRequest
+	(*ListBlockedUsersRequest)(nil),  // 12: user.ListBlockedUsersRequest
+	(*CheckBlockedRequest)(nil),      // 13: user.CheckBlockedRequest
+	(*ResolveUserNamesRequest)(nil),  // 14: user.ResolveUserNamesRequest
+	(*SignedPreKey)(nil),             // 15: user.SignedPreKey
+	(*OneTimePreKey)(nil),            // 16: user.OneTimePreKey
+	(*UploadKeysRequest)(nil),        // 17: user.UploadKeysRequest
+	(*GetKeyBundlesRequest)(nil),     // 18: user.GetKeyBundlesRequest
+	(*ListDevicesRequest)(nil),       // 19: user.ListDevicesRequest
+	(*RemoveDeviceRequest)(nil),      // 20: user.RemoveDeviceRequest
+	(*UserResponse)(nil),             // 21: user.UserResponse
+	(*DeleteUserResponse)(nil),       // 22: user.DeleteUserResponse
+	(*ListUsersResponse)(nil),        // 23: user.ListUsersResponse
+	(*StatusResponse)(nil),           // 24: user.StatusResponse
+	(*IsOnlineResponse)(nil),         // 25: user.IsOnlineResponse
+	(*GetOnlineUsersResponse)(nil),   // 26: user.GetOnlineUsersResponse
+	(*ListBlockedUsersResponse)(nil), // 27: user.ListBlockedUsersResponse
+	(*CheckBlockedResponse)(nil),     // 28: user.CheckBlockedResponse
+	(*UserName)(nil),                 // 29: user.UserName
+	(*ResolveUserNamesResponse)(nil), // 30: user.ResolveUserNamesResponse
+	(*UploadKeysResponse)(nil),       // 31: user.UploadKeysResponse
+	(*KeyBundle)(nil),                // 32: user.KeyBundle
+	(*GetKeyBundlesResponse)(nil),    // 33: user.GetKeyBundlesResponse
+	(*Device)(nil),                   // 34: user.Device
+	(*ListDevicesResponse)(nil),      // 35: user.ListDevicesResponse
 }
 var file_user_proto_depIdxs = []int32{
-	10, // 0: user.ListUsersResponse.users:type_name -> user.UserResponse
-	0,  // 1: user.UserService.CreateUser:input_type -> user.CreateUserRequest
-	1,  // 2: user.UserService.GetUser:input_type -> user.GetUserRequest
-	2,  // 3: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	3,  // 4: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
-	4,  // 5: user.UserService.ListUsers:input_type -> user.ListUsersRequest
-	5,  // 6: user.UserService.AboutMeUser:input_type -> user.AboutMeRequest
-	6,  // 7: user.UserService.SetOnline:input_type -> user.SetOnlineRequest
-	7,  // 8: user.UserService.SetOffline:input_type -> user.SetOfflineRequest
-	8,  // 9: user.UserService.IsOnline:input_type -> user.IsOnlineRequest
-	9,  // 10: user.UserService.GetOnlineUsers:input_type -> user.GetOnlineUsersRequest
-	10, // 11: user.UserService.CreateUser:output_type -> user.UserResponse
-	10, // 12: user.UserService.GetUser:output_type -> user.UserResponse
-	10, // 13: user.UserService.UpdateUser:output_type -> user.UserResponse
-	11, // 14: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
-	12, // 15: user.UserService.ListUsers:output_type -> user.ListUsersResponse
-	10, // 16: user.UserService.AboutMeUser:output_type -> user.UserResponse
-	13, // 17: user.UserService.SetOnline:output_type -> user.StatusResponse
-	13, // 18: user.UserService.SetOffline:output_type -> user.StatusResponse
-	14, // 19: user.UserService.IsOnline:output_type -> user.IsOnlineResponse
-	15, // 20: user.UserService.GetOnlineUsers:output_type -> user.GetOnlineUsersResponse
-	11, // [11:21] is the sub-list for method output_type
-	1,  // [1:11] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	15, // 0: user.UploadKeysRequest.signed_prekey:type_name -> user.SignedPreKey
+	16, // 1: user.UploadKeysRequest.one_time_prekeys:type_name -> user.OneTimePreKey
+	21, // 2: user.ListUsersResponse.users:type_name -> user.UserResponse
+	29, // 3: user.ResolveUserNamesResponse.users:type_name -> user.UserName
+	15, // 4: user.KeyBundle.signed_prekey:type_name -> user.SignedPreKey
+	16, // 5: user.KeyBundle.one_time_prekey:type_name -> user.OneTimePreKey
+	32, // 6: user.GetKeyBundlesResponse.bundles:type_name -> user.KeyBundle
+	34, // 7: user.ListDevicesResponse.devices:type_name -> user.Device
+	0,  // 8: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	1,  // 9: user.UserService.GetUser:input_type -> user.GetUserRequest
+	2,  // 10: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	3,  // 11: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	4,  // 12: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	5,  // 13: user.UserService.AboutMeUser:input_type -> user.AboutMeRequest
+	6,  // 14: user.UserService.SetOnline:input_type -> user.SetOnlineRequest
+	7,  // 15: user.UserService.SetOffline:input_type -> user.SetOfflineRequest
+	8,  // 16: user.UserService.IsOnline:input_type -> user.IsOnlineRequest
+	9,  // 17: user.UserService.GetOnlineUsers:input_type -> user.GetOnlineUsersRequest
+	10, // 18: user.UserService.BlockUser:input_type -> user.BlockUserRequest
+	11, // 19: user.UserService.UnblockUser:input_type -> user.UnblockUserRequest
+	12, // 20: user.UserService.ListBlockedUsers:input_type -> user.ListBlockedUsersRequest
+	13, // 21: user.UserService.CheckBlocked:input_type -> user.CheckBlockedRequest
+	14, // 22: user.UserService.ResolveUserNames:input_type -> user.ResolveUserNamesRequest
+	17, // 23: user.UserService.UploadKeys:input_type -> user.UploadKeysRequest
+	18, // 24: user.UserService.GetKeyBundles:input_type -> user.GetKeyBundlesRequest
+	19, // 25: user.UserService.ListDevices:input_type -> user.ListDevicesRequest
+	20, // 26: user.UserService.RemoveDevice:input_type -> user.RemoveDeviceRequest
+	21, // 27: user.UserService.CreateUser:output_type -> user.UserResponse
+	21, // 28: user.UserService.GetUser:output_type -> user.UserResponse
+	21, // 29: user.UserService.UpdateUser:output_type -> user.UserResponse
+	22, // 30: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
+	23, // 31: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	21, // 32: user.UserService.AboutMeUser:output_type -> user.UserResponse
+	24, // 33: user.UserService.SetOnline:output_type -> user.StatusResponse
+	24, // 34: user.UserService.SetOffline:output_type -> user.StatusResponse
+	25, // 35: user.UserService.IsOnline:output_type -> user.IsOnlineResponse
+	26, // 36: user.UserService.GetOnlineUsers:output_type -> user.GetOnlineUsersResponse
+	24, // 37: user.UserService.BlockUser:output_type -> user.StatusResponse
+	24, // 38: user.UserService.UnblockUser:output_type -> user.StatusResponse
+	27, // 39: user.UserService.ListBlockedUsers:output_type -> user.ListBlockedUsersResponse
+	28, // 40: user.UserService.CheckBlocked:output_type -> user.CheckBlockedResponse
+	30, // 41: user.UserService.ResolveUserNames:output_type -> user.ResolveUserNamesResponse
+	31, // 42: user.UserService.UploadKeys:output_type -> user.UploadKeysResponse
+	33, // 43: user.UserService.GetKeyBundles:output_type -> user.GetKeyBundlesResponse
+	35, // 44: user.UserService.ListDevices:output_type -> user.ListDevicesResponse
+	24, // 45: user.UserService.RemoveDevice:output_type -> user.StatusResponse
+	27, // [27:46] is the sub-list for method output_type
+	8,  // [8:27] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -1079,7 +2282,7 @@ func file_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},